@@ -33,6 +33,7 @@ type Config struct {
 	Emails   Emails   `json:"emails" yaml:"emails"`
 	Worker   Worker   `json:"worker" yaml:"worker"`
 	Logs     Logs     `json:"logs" yaml:"logs"`
+	Storage  Storage  `json:"storage" yaml:"storage"`
 	S3       S3       `json:"s3" yaml:"s3"`
 	Jwt      Jwt      `json:"jwt" yaml:"jwt"`
 	Kms      Kms      `json:"kms" yaml:"kms"`
@@ -88,6 +89,26 @@ type Emails struct {
 	NotifyAddress     mail.Address   `json:"-"`
 	ContactAddressStr string         `json:"contact_address" yaml:"contact_address"`
 	ContactAddress    mail.Address   `json:"-"`
+	// SES configuration set and SNS topic used to receive bounces and complaints
+	SesConfigurationSet      string `json:"ses_configuration_set" yaml:"ses_configuration_set"`
+	SesNotificationsTopicArn string `json:"ses_notifications_topic_arn" yaml:"ses_notifications_topic_arn"`
+	// Required when provider == "smtp"
+	Smtp *Smtp `json:"smtp" yaml:"smtp"`
+}
+
+type Smtp struct {
+	Host     string `json:"host" yaml:"host"`
+	Port     uint16 `json:"port" yaml:"port"`
+	Username string `json:"username" yaml:"username"`
+	Password string `json:"password" yaml:"password"`
+	// "starttls" (default), "tls" or "none"
+	Tls string `json:"tls" yaml:"tls"`
+	// Maximum number of concurrent connections to the SMTP server
+	PoolSize int `json:"pool_size" yaml:"pool_size"`
+	// Maximum number of newsletter emails sent per second. Defaults to emails.NewsletterRateLimit
+	RateLimit int `json:"rate_limit" yaml:"rate_limit"`
+	// The DKIM keys of the domains of the websites are stored encrypted in the database
+	DkimSelector string `json:"dkim_selector" yaml:"dkim_selector"`
 }
 
 type Kms struct {
@@ -95,6 +116,13 @@ type Kms struct {
 	Provider    kms.Provider `json:"provider" yaml:"provider"`
 }
 
+type Storage struct {
+	// Defaults to "s3" for backward compatibility
+	Provider StorageProvider `json:"provider" yaml:"provider"`
+	// The root directory where objects are stored when provider == "filesystem"
+	Directory string `json:"directory" yaml:"directory"`
+}
+
 type S3 struct {
 	Provider S3Provider `json:"provider" yaml:"provider"`
 	Bucket   string     `json:"bucket" yaml:"bucket"`
@@ -223,6 +251,17 @@ func Load(ctx context.Context, configPath string) (config Config, err error) {
 }
 
 // TODO
+// IsLocalDevelopment returns true if the webapp is served on localhost, which means that the server is
+// running in a development or test environment.
+func (config *Config) IsLocalDevelopment() bool {
+	domain := config.HTTP.WebappDomain
+	if domain == "localhost" || strings.HasSuffix(domain, ".localhost") {
+		return true
+	}
+	ip := net.ParseIP(strings.Trim(domain, "[]"))
+	return ip != nil && ip.IsLoopback()
+}
+
 func (config *Config) validateAndDefaultValues() (err error) {
 	// Geoip Database
 
@@ -271,40 +310,23 @@ func (config *Config) validateAndDefaultValues() (err error) {
 	// }
 
 	// Storage
-	// if config.Storage.Driver != StorageDriverFilesystem && config.Storage.Driver != StorageDriverS3 {
-	// 	err = errs.InvalidArgument(fmt.Sprintf("config: storage.driver can't be empty. Valid values are [%s, %s]", StorageDriverFilesystem, StorageDriverS3))
-	// 	return err
-	// }
-	// if config.Storage.Driver == StorageDriverS3 {
-
-	// if config.S3 == nil {
-	// 	err = errs.InvalidArgument("config: s3 can't be null")
-	// 	return err
-	// }
-
-	// S3
-	if config.S3.Bucket == "" {
-		err = errs.InvalidArgument("config: s3.bucket is missing")
-		return err
+	if config.Storage.Provider == "" {
+		config.Storage.Provider = StorageProviderS3
 	}
 
-	if config.S3.Provider != S3ProviderAws && config.S3.Provider != S3ProviderScaleway {
-		return errs.InvalidArgument("config: s3.provider is not valid")
-	}
-
-	if config.S3.Provider == S3ProviderScaleway {
-		if config.Scaleway == nil {
-			return errs.InvalidArgument("config: scaleway is null while s3.provider == \"scaleway\"")
-		}
-		if config.S3.Endpoint == "" {
-			return errs.InvalidArgument("config: s3.endpoint is empty while s3.provider == \"scaleway\"")
+	switch config.Storage.Provider {
+	case StorageProviderFilesystem:
+		config.Storage.Directory = strings.TrimSpace(config.Storage.Directory)
+		if config.Storage.Directory == "" {
+			return errs.InvalidArgument("config: storage.directory is empty while storage.provider == \"filesystem\"")
 		}
-	}
-
-	if config.S3.Provider == S3ProviderAws {
-		if config.Aws == nil {
-			return errs.InvalidArgument("config: aws is null while s3.provider == \"aws\"")
+	case StorageProviderS3:
+		err = validateS3Config(config)
+		if err != nil {
+			return err
 		}
+	default:
+		return errs.InvalidArgument(fmt.Sprintf("config: storage.provider is not valid. Valid values are [%s, %s]", StorageProviderS3, StorageProviderFilesystem))
 	}
 
 	// KMS
@@ -356,12 +378,20 @@ func (config *Config) validateAndDefaultValues() (err error) {
 	// }
 
 	// Emails
-	if config.Emails.Provider != EmailsProviderConsole && config.Emails.Provider != EmailsProviderSes {
-		return errs.InvalidArgument(fmt.Sprintf("config: emails.provider is not valid. Valid values are [%s, %s]", EmailsProviderConsole, EmailsProviderSes))
+	if config.Emails.Provider != EmailsProviderConsole && config.Emails.Provider != EmailsProviderSes &&
+		config.Emails.Provider != EmailsProviderSmtp {
+		return errs.InvalidArgument(fmt.Sprintf("config: emails.provider is not valid. Valid values are [%s, %s, %s]",
+			EmailsProviderConsole, EmailsProviderSes, EmailsProviderSmtp))
 	}
 	if config.Emails.Provider == EmailsProviderSes && config.Aws == nil {
 		return errs.InvalidArgument("config: aws is null but emails.provider is \"ses\"")
 	}
+	if config.Emails.Provider == EmailsProviderSmtp {
+		err = validateSmtpConfig(config.Emails.Smtp)
+		if err != nil {
+			return err
+		}
+	}
 
 	if config.Emails.NotifyAddressStr == "" {
 		err = errs.InvalidArgument("config: emails.notify_address is empty")
@@ -428,6 +458,62 @@ func (config *Config) validateAndDefaultValues() (err error) {
 	return
 }
 
+func validateS3Config(config *Config) (err error) {
+	if config.S3.Bucket == "" {
+		return errs.InvalidArgument("config: s3.bucket is missing")
+	}
+
+	if config.S3.Provider != S3ProviderAws && config.S3.Provider != S3ProviderScaleway {
+		return errs.InvalidArgument("config: s3.provider is not valid")
+	}
+
+	if config.S3.Provider == S3ProviderScaleway {
+		if config.Scaleway == nil {
+			return errs.InvalidArgument("config: scaleway is null while s3.provider == \"scaleway\"")
+		}
+		if config.S3.Endpoint == "" {
+			return errs.InvalidArgument("config: s3.endpoint is empty while s3.provider == \"scaleway\"")
+		}
+	}
+
+	if config.S3.Provider == S3ProviderAws {
+		if config.Aws == nil {
+			return errs.InvalidArgument("config: aws is null while s3.provider == \"aws\"")
+		}
+	}
+
+	return nil
+}
+
+func validateSmtpConfig(smtpConfig *Smtp) (err error) {
+	if smtpConfig == nil {
+		return errs.InvalidArgument("config: emails.smtp is null while emails.provider == \"smtp\"")
+	}
+
+	smtpConfig.Host = strings.TrimSpace(smtpConfig.Host)
+	if smtpConfig.Host == "" {
+		return errs.InvalidArgument("config: emails.smtp.host is missing")
+	}
+
+	if smtpConfig.Port == 0 {
+		return errs.InvalidArgument("config: emails.smtp.port is missing")
+	}
+
+	if smtpConfig.Tls != "" && smtpConfig.Tls != "starttls" && smtpConfig.Tls != "tls" && smtpConfig.Tls != "none" {
+		return errs.InvalidArgument("config: emails.smtp.tls is not valid. Valid values are [starttls, tls, none]")
+	}
+
+	if smtpConfig.PoolSize < 0 {
+		return errs.InvalidArgument("config: emails.smtp.pool_size is not valid")
+	}
+
+	if smtpConfig.RateLimit < 0 {
+		return errs.InvalidArgument("config: emails.smtp.rate_limit is not valid")
+	}
+
+	return nil
+}
+
 func cleanAndValidateHttpConfig(config *Config) (err error) {
 	// webapp_base_url
 	config.HTTP.WebappBaseUrlStr = strings.ToLower(strings.TrimSpace(config.HTTP.WebappBaseUrlStr))
@@ -5,6 +5,14 @@ type EmailsProvider string
 const (
 	EmailsProviderConsole EmailsProvider = "console"
 	EmailsProviderSes     EmailsProvider = "ses"
+	EmailsProviderSmtp    EmailsProvider = "smtp"
+)
+
+type StorageProvider string
+
+const (
+	StorageProviderS3         StorageProvider = "s3"
+	StorageProviderFilesystem StorageProvider = "filesystem"
 )
 
 type S3Provider string
@@ -36,6 +36,7 @@ import (
 	organizations "markdown.ninja/pkg/services/organizations/service"
 	site "markdown.ninja/pkg/services/site/service"
 	store "markdown.ninja/pkg/services/store/service"
+	webhooks "markdown.ninja/pkg/services/webhooks/service"
 	websites "markdown.ninja/pkg/services/websites/service"
 	"markdown.ninja/pkg/worker"
 )
@@ -103,18 +104,29 @@ var rootCmd = &cobra.Command{
 
 		queue := postgres.NewPostgreSQLQueue(ctx, dbPool, logger)
 
+		dnsResolver := &net.Resolver{
+			PreferGo: true,
+			Dial: func(ctx context.Context, network, address string) (net.Conn, error) {
+				dialer := net.Dialer{
+					Timeout: 3 * time.Second,
+				}
+				dnsServer := "1.1.1.1:53"
+				return dialer.DialContext(ctx, network, dnsServer)
+			},
+		}
+
 		// initialize drivers
-		mailer, err := loadMailer(conf)
+		kms, err := loadKms(conf)
 		if err != nil {
 			return err
 		}
 
-		s3Client, err := loadS3(conf)
+		mailer, err := loadMailer(conf, dbPool, kms, dnsResolver)
 		if err != nil {
 			return err
 		}
 
-		kms, err := loadKms(conf)
+		storage, err := loadStorage(conf)
 		if err != nil {
 			return err
 		}
@@ -142,68 +154,60 @@ var rootCmd = &cobra.Command{
 		stripe.Key = conf.Stripe.SecretKey
 		stripe.EnableTelemetry = false
 
-		dnsResolver := &net.Resolver{
-			PreferGo: true,
-			Dial: func(ctx context.Context, network, address string) (net.Conn, error) {
-				dialer := net.Dialer{
-					Timeout: 3 * time.Second,
-				}
-				dnsServer := "1.1.1.1"
-				return dialer.DialContext(ctx, network, dnsServer)
-			},
-		}
-
 		// init services
 		kernelService := kernel.NewKernelService(conf, dbPool, queue, mailer, pingooClient, geoip)
 
 		organizationsService := organizations.NewOrganizationsService(conf, dbPool, mailer, queue, kernelService, pingooClient)
 
-		contentService, err := content.NewContentService(conf, dbPool, queue, s3Client, kernelService, organizationsService)
+		contentService, err := content.NewContentService(conf, dbPool, queue, storage, jwtProvider, kernelService, organizationsService)
 		if err != nil {
 			return err
 		}
 
-		eventsService, err := events.NewService(ctx, dbPool, dbPool /*eventsDb*/, queue, kernelService)
+		eventsService, err := events.NewService(ctx, conf, dbPool, dbPool /*eventsDb*/, queue, storage, kernelService)
 		if err != nil {
 			return err
 		}
 
-		emailsService := emails.NewEmailsService(conf, dbPool, queue, mailer, dnsResolver, kernelService,
+		emailsService := emails.NewEmailsService(conf, dbPool, queue, mailer, jwtProvider, dnsResolver, kernelService,
 			eventsService, contentService, organizationsService,
 		)
 
-		websitesService, err := websites.NewWebsitesService(conf, dbPool, queue, mailer, s3Client,
+		websitesService, err := websites.NewWebsitesService(conf, dbPool, queue, mailer, storage,
 			kernelService, emailsService, contentService, eventsService, organizationsService,
 		)
 		if err != nil {
 			return err
 		}
 
+		webhooksService := webhooks.NewWebhooksService(dbPool, queue, kernelService, websitesService, organizationsService)
+
 		contactsService, err := contacts.NewContactsService(conf, dbPool, mailer, queue, jwtProvider,
-			kernelService, websitesService, eventsService, emailsService)
+			kernelService, websitesService, eventsService, emailsService, organizationsService, webhooksService)
 		if err != nil {
 			return err
 		}
 
 		storeService, err := store.NewStoreService(dbPool, queue, conf, mailer,
 			kernelService, websitesService, contentService, contactsService, eventsService, emailsService,
-			organizationsService,
+			organizationsService, webhooksService,
 		)
 		if err != nil {
 			return err
 		}
 
 		siteService, err := site.NewSiteService(conf, dbPool, queue, mailer, logger, kernelService, websitesService,
-			contentService, eventsService, contactsService, emailsService, storeService,
+			contentService, eventsService, contactsService, emailsService, storeService, webhooksService,
+			organizationsService,
 		)
 		if err != nil {
 			return err
 		}
 
-		contentService.InjectServices(websitesService, storeService, emailsService)
+		contentService.InjectServices(websitesService, storeService, emailsService, webhooksService)
 		websitesService.InjectServices(storeService, contactsService)
-		emailsService.InjectServices(websitesService, contactsService)
-		eventsService.InjectServices(websitesService)
+		emailsService.InjectServices(websitesService, contactsService, storeService)
+		eventsService.InjectServices(websitesService, organizationsService)
 		contactsService.InjectServices(storeService)
 		organizationsService.InjectServices(websitesService, eventsService, contentService, storeService)
 
@@ -214,7 +218,7 @@ var rootCmd = &cobra.Command{
 			go func() {
 				errWorker := worker.Start(ctx, logger, conf.Worker.Concurrency, queue,
 					kernelService, websitesService, emailsService, storeService, contentService,
-					siteService, contactsService, eventsService, organizationsService,
+					siteService, contactsService, eventsService, organizationsService, webhooksService,
 				)
 				gracefulShutdownWaitGroup.Done()
 				if errWorker != nil {
@@ -235,7 +239,7 @@ var rootCmd = &cobra.Command{
 		}()
 
 		err = server.Start(ctx, conf, dbPool, geoip, pingooClient, kernelService, websitesService, contactsService, emailsService,
-			storeService, eventsService, siteService, contentService, organizationsService, logger, kms)
+			storeService, eventsService, siteService, contentService, organizationsService, webhooksService, logger, kms)
 		if err != nil {
 			logger.Error("cli.server: error running server", slogx.Err(err))
 			cancelCtx()
@@ -283,6 +287,9 @@ func logConfig(logger *slog.Logger, conf config.Config) {
 				slog.String("notify_address", conf.Emails.NotifyAddressStr),
 				slog.String("contact_address", conf.Emails.ContactAddressStr),
 			),
+			slog.Group("storage",
+				slog.String("provider", string(conf.Storage.Provider)),
+			),
 			slog.Group("s3",
 				slog.String("provider", string(conf.S3.Provider)),
 			),
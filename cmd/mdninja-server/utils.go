@@ -5,7 +5,9 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"net"
 
+	"github.com/bloom42/stdx-go/db"
 	"github.com/bloom42/stdx-go/log/loki"
 	"markdown.ninja/cmd/mdninja-server/config"
 	"markdown.ninja/pkg/buildinfo"
@@ -13,6 +15,10 @@ import (
 	"markdown.ninja/pkg/mailer"
 	"markdown.ninja/pkg/mailer/console"
 	"markdown.ninja/pkg/mailer/ses"
+	"markdown.ninja/pkg/mailer/smtp"
+	"markdown.ninja/pkg/services/emails"
+	"markdown.ninja/pkg/storage"
+	"markdown.ninja/pkg/storage/filesystem"
 	"markdown.ninja/pkg/storage/s3"
 )
 
@@ -31,10 +37,10 @@ func newLogger(ctx context.Context) (*slog.Logger, *slog.LevelVar, *loki.Writer)
 	return logger, logLevel, lokiWriter
 }
 
-func loadMailer(conf config.Config) (mailer mailer.Mailer, err error) {
+func loadMailer(conf config.Config, db db.DB, kms *kms.Kms, dnsResolver *net.Resolver) (mailer mailer.Mailer, err error) {
 	switch conf.Emails.Provider {
 	case config.EmailsProviderConsole:
-		mailer = console.NewConsoleMailer()
+		mailer = console.NewConsoleMailer(console.Config{AcceptUnsignedWebhooks: conf.IsLocalDevelopment()})
 	case config.EmailsProviderSes:
 		if conf.Aws == nil {
 			return nil, errors.New("mailer: config.aws is null")
@@ -43,11 +49,50 @@ func loadMailer(conf config.Config) (mailer mailer.Mailer, err error) {
 			AccessKeyID:     conf.Aws.AccessKeyID,
 			SecretAccessKey: conf.Aws.SecretAccessKey,
 			Region:          conf.Aws.Region,
+
+			ConfigurationSetName:  conf.Emails.SesConfigurationSet,
+			NotificationsTopicArn: conf.Emails.SesNotificationsTopicArn,
 		}
 		mailer, err = ses.NewSesMailer(sesConf)
+	case config.EmailsProviderSmtp:
+		if conf.Emails.Smtp == nil {
+			return nil, errors.New("mailer: config.emails.smtp is null")
+		}
+		rateLimit := conf.Emails.Smtp.RateLimit
+		if rateLimit == 0 {
+			rateLimit = emails.NewsletterRateLimit
+		}
+		mailer, err = smtp.NewSmtpMailer(smtp.Config{
+			Host:          conf.Emails.Smtp.Host,
+			Port:          conf.Emails.Smtp.Port,
+			Username:      conf.Emails.Smtp.Username,
+			Password:      conf.Emails.Smtp.Password,
+			TlsMode:       smtp.TlsMode(conf.Emails.Smtp.Tls),
+			PoolSize:      conf.Emails.Smtp.PoolSize,
+			RateLimit:     rateLimit,
+			DkimKeysStore: smtp.NewDbDkimKeysStore(db, kms),
+			DkimSelector:  conf.Emails.Smtp.DkimSelector,
+			DnsResolver:   dnsResolver,
+		})
+	default:
+		err = fmt.Errorf("mailer: %s is not a valid email provider. Valid values are: [%s, %s, %s]",
+			conf.Emails.Provider, config.EmailsProviderConsole, config.EmailsProviderSes, config.EmailsProviderSmtp)
+	}
+
+	return
+}
+
+func loadStorage(conf config.Config) (storage storage.Storage, err error) {
+	switch conf.Storage.Provider {
+	case config.StorageProviderS3:
+		storage, err = loadS3(conf)
+	case config.StorageProviderFilesystem:
+		storage, err = filesystem.NewStorage(filesystem.Config{
+			Directory: conf.Storage.Directory,
+		})
 	default:
-		err = fmt.Errorf("mailer: %s is not a valid email provider. Valid values are: [%s, %s]",
-			conf.Emails.Provider, config.EmailsProviderConsole, config.EmailsProviderSes)
+		err = fmt.Errorf("storage: %s is not a valid provider. Valid values are: [%s, %s]",
+			conf.Storage.Provider, config.StorageProviderS3, config.StorageProviderFilesystem)
 	}
 
 	return
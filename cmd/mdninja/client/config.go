@@ -2,6 +2,7 @@ package client
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
@@ -24,6 +25,10 @@ type config struct {
 	Navigation   *websites.WebsiteNavigation     `yaml:"navigation"`
 	RedirectsMap *orderedmap.Map[string, string] `yaml:"redirects"`
 	Redirects    []websites.RedirectInput        `yaml:"-"`
+	// RedirectsFile is a redirects file exported from another platform (e.g. _redirects or redirects.csv)
+	RedirectsFile *string `yaml:"redirects_file"`
+	// RedirectsFormat defaults to csv for .csv files and to netlify otherwise
+	RedirectsFormat *websites.RedirectsFormat `yaml:"redirects_format"`
 
 	Ad           *string `yaml:"ad"`
 	Announcement *string `yaml:"announcement"`
@@ -117,6 +122,21 @@ func (client *Client) loadConfig(_ context.Context, configPath string) (conf con
 	// 	}
 	// }
 
+	if conf.RedirectsMap != nil && conf.RedirectsFile != nil {
+		err = errors.New("config: redirects and redirects_file can't be used together")
+		return
+	}
+
+	if conf.RedirectsFile != nil {
+		if conf.RedirectsFormat == nil {
+			conf.RedirectsFormat = opt.Ptr(websites.RedirectsFormatFromFilename(*conf.RedirectsFile))
+		}
+		if !conf.RedirectsFormat.IsValid() {
+			err = fmt.Errorf("config: redirects_format is not valid: %s", *conf.RedirectsFormat)
+			return
+		}
+	}
+
 	if conf.RedirectsMap != nil {
 		conf.Redirects = make([]websites.RedirectInput, len(conf.RedirectsMap.Items()))
 		for i, elem := range conf.RedirectsMap.Items() {
@@ -186,6 +186,27 @@ func (client *Client) readAndParseMarkdownFile(ctx context.Context, filesystem f
 		localPage.Tags = make([]string, 0)
 	}
 
+	// authors are resolved to their slugs once all the pages are loaded (see resolveAuthors)
+	localPage.Authors = make([]string, 0)
+	authorsInterface := frontmatter.Data["authors"]
+	if authorsInterface != nil {
+		authors, authorsInterfaceIsSlice := authorsInterface.([]any)
+		if !authorsInterfaceIsSlice {
+			err = fmt.Errorf("publish: parsing frontmatter: authors is not a []string (%s)", realPath)
+			return
+		}
+
+		for _, author := range authors {
+			authorStr, authorInterfaceIsString := author.(string)
+			if !authorInterfaceIsString {
+				err = fmt.Errorf("publish: parsing frontmatter: authors is not a []string (%s)", realPath)
+				return
+			}
+
+			localPage.Authors = append(localPage.Authors, strings.TrimSpace(authorStr))
+		}
+	}
+
 	langInterface := frontmatter.Data["lang"]
 	if langInterface != nil {
 		pageLangStr, pageLangInterfaceIsString := langInterface.(string)
@@ -241,7 +262,31 @@ func (client *Client) readAndParseMarkdownFile(ctx context.Context, filesystem f
 		localPage.SendAsNewsletter = false
 	}
 
-	localPage.MetadataHash = content.HashPageMetadata(localPage.Type, localPage.Url, localPage.Date, localPage.SendAsNewsletter, localPage.Language, localPage.Title, localPage.Description, localPage.Tags)
+	paidOnlyInterface := frontmatter.Data["paid_only"]
+	if paidOnlyInterface != nil {
+		paidOnly, paidOnlyInterfaceIsBool := paidOnlyInterface.(bool)
+		if !paidOnlyInterfaceIsBool {
+			err = fmt.Errorf("publish: parsing frontmatter: paid_only is not a bool (%s)", realPath)
+			return
+		}
+		localPage.PaidOnly = paidOnly
+	}
+
+	translationKeyInterface := frontmatter.Data["translation_key"]
+	if translationKeyInterface != nil {
+		translationKey, translationKeyInterfaceIsString := translationKeyInterface.(string)
+		if !translationKeyInterfaceIsString {
+			err = fmt.Errorf("publish: parsing frontmatter: translation_key is not a string (%s)", realPath)
+			return
+		}
+
+		translationKey = strings.ToLower(strings.TrimSpace(translationKey))
+		if translationKey != "" {
+			localPage.TranslationKey = &translationKey
+		}
+	}
+
+	localPage.MetadataHash = localPage.hashMetadata()
 
 	return
 }
@@ -8,6 +8,7 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
@@ -25,6 +26,7 @@ type localPage struct {
 	Date              time.Time
 	UpdatedAt         *time.Time
 	Tags              []string
+	Authors           []string
 	FrontMatterSource string
 	Language          string
 	Description       string
@@ -32,6 +34,8 @@ type localPage struct {
 	BodyHash          []byte
 	MetadataHash      [32]byte
 	SendAsNewsletter  bool
+	PaidOnly          bool
+	TranslationKey    *string
 }
 
 func (client *Client) uploadPages(ctx context.Context, websiteID guid.GUID, pageDirs []string) (err error) {
@@ -52,6 +56,20 @@ func (client *Client) uploadPages(ctx context.Context, websiteID guid.GUID, page
 		pagesFromApiByUrl[page.Path] = page
 	}
 
+	authorsFromApi, err := client.apiClient.ListAuthors(ctx, content.ListAuthorsInput{WebsiteID: websiteID})
+	if err != nil {
+		err = fmt.Errorf("pages: error fetching authors: %w", err)
+		return
+	}
+	// authors can be referenced by their slug or by their name in the frontmatter
+	authorsSlugs := make(map[string]string, len(authorsFromApi.Data)*2)
+	for _, author := range authorsFromApi.Data {
+		authorsSlugs[strings.ToLower(author.Name)] = author.Slug
+	}
+	for _, author := range authorsFromApi.Data {
+		authorsSlugs[author.Slug] = author.Slug
+	}
+
 	localPages := make([]localPage, 0, len(pagesFromApi.Data))
 	for _, folder := range pageDirs {
 		var pages []localPage
@@ -62,6 +80,10 @@ func (client *Client) uploadPages(ctx context.Context, websiteID guid.GUID, page
 		localPages = append(localPages, pages...)
 	}
 
+	for i := range localPages {
+		client.resolveAuthors(&localPages[i], authorsSlugs)
+	}
+
 	// check for local pages with same URL
 	localPagesUniqueByUrl := make(map[string]localPage, len(pagesFromApi.Data))
 	for _, page := range localPages {
@@ -86,7 +108,10 @@ func (client *Client) uploadPages(ctx context.Context, websiteID guid.GUID, page
 					Description:      &localPage.Description,
 					Language:         localPage.Language,
 					Tags:             localPage.Tags,
+					Authors:          localPage.Authors,
 					SendAsNewsletter: localPage.SendAsNewsletter,
+					PaidOnly:         localPage.PaidOnly,
+					TranslationKey:   localPage.TranslationKey,
 				}
 				_, err = client.apiClient.UpdatePage(ctx, updatePageInput)
 				if err != nil {
@@ -106,8 +131,11 @@ func (client *Client) uploadPages(ctx context.Context, websiteID guid.GUID, page
 				Description:      localPage.Description,
 				Language:         localPage.Language,
 				Tags:             localPage.Tags,
+				Authors:          localPage.Authors,
 				Draft:            localPage.Draft,
 				SendAsNewsletter: localPage.SendAsNewsletter,
+				PaidOnly:         localPage.PaidOnly,
+				TranslationKey:   localPage.TranslationKey,
 			}
 			_, err = client.apiClient.CreatePage(ctx, createPageInput)
 			if err != nil {
@@ -184,3 +212,28 @@ func (client *Client) loadLocalPages(ctx context.Context, folder string, pagesFr
 
 	return localPages, nil
 }
+
+func (page *localPage) hashMetadata() [32]byte {
+	return content.HashPageMetadata(page.Type, page.Url, page.Date, page.SendAsNewsletter, page.PaidOnly, page.Language,
+		page.Title, page.Description, page.Tags, page.Authors, page.TranslationKey)
+}
+
+// resolveAuthors replaces the authors of the page by their slugs and updates the metadata hash.
+// Unknown authors are ignored with a warning as they need to be created in the dashboard first.
+func (client *Client) resolveAuthors(page *localPage, authorsSlugs map[string]string) {
+	resolvedAuthors := make([]string, 0, len(page.Authors))
+
+	for _, author := range page.Authors {
+		slug, exists := authorsSlugs[strings.ToLower(author)]
+		if !exists {
+			client.logger.Warn(fmt.Sprintf("Author \"%s\" not found, ignoring it (%s)", author, page.LocalPath))
+			continue
+		}
+		if !slices.Contains(resolvedAuthors, slug) {
+			resolvedAuthors = append(resolvedAuthors, slug)
+		}
+	}
+
+	page.Authors = resolvedAuthors
+	page.MetadataHash = page.hashMetadata()
+}
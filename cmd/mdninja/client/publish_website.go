@@ -67,6 +67,13 @@ func (client *Client) publishWebsite(ctx context.Context, websiteDomain string,
 		client.logger.Info("Redirects successfully updated")
 	}
 
+	if config.RedirectsFile != nil {
+		err = client.importRedirects(ctx, website.ID, *config.RedirectsFile, *config.RedirectsFormat)
+		if err != nil {
+			return err
+		}
+	}
+
 	// assets and snippets need to be updated before pages to avoid rendering a page with missing assets
 	errAssets := client.uploadWebsiteAssets(ctx, website.ID, false)
 	if errAssets != nil {
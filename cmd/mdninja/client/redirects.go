@@ -0,0 +1,43 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/bloom42/stdx-go/guid"
+	"markdown.ninja/pkg/services/websites"
+)
+
+// importRedirects replaces the redirects of the website by the redirects of the file. The redirects are
+// validated by the API which returns a report with the errors of each line.
+func (client *Client) importRedirects(ctx context.Context, websiteID guid.GUID, file string, format websites.RedirectsFormat) error {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("publish: reading redirects file (%s): %w", file, err)
+	}
+
+	res, err := client.apiClient.ImportRedirects(ctx, websites.ImportRedirectsInput{
+		WebsiteID: websiteID,
+		Format:    format,
+		Data:      string(data),
+		Replace:   true,
+	})
+	if err != nil {
+		return fmt.Errorf("publish: Importing redirects: %w", err)
+	}
+
+	for _, warning := range res.Report.Warnings {
+		client.logger.Warn(fmt.Sprintf("%s:%d: %s: %s", file, warning.Line, warning.Pattern, warning.Message))
+	}
+	for _, reportErr := range res.Report.Errors {
+		client.logger.Error(fmt.Sprintf("%s:%d: %s: %s", file, reportErr.Line, reportErr.Pattern, reportErr.Message))
+	}
+
+	if !res.Saved {
+		return fmt.Errorf("publish: %d redirects are not valid in %s", len(res.Report.Errors), file)
+	}
+
+	client.logger.Info(fmt.Sprintf("%d redirects successfully imported", len(res.Redirects)))
+	return nil
+}
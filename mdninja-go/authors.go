@@ -0,0 +1,22 @@
+package mdninja
+
+import (
+	"context"
+	"net/http"
+
+	"markdown.ninja/pkg/server/api"
+	"markdown.ninja/pkg/services/content"
+	"markdown.ninja/pkg/services/kernel"
+)
+
+func (client *Client) ListAuthors(ctx context.Context, apiInput content.ListAuthorsInput) (ret kernel.PaginatedResult[content.Author], err error) {
+	req := requestParams{
+		Method:  http.MethodPost,
+		Route:   api.RouteAuthors,
+		Payload: apiInput,
+	}
+
+	err = client.request(ctx, req, &ret)
+
+	return
+}
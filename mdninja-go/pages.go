@@ -63,3 +63,25 @@ func (client *Client) ListPosts(ctx context.Context, input content.ListPagesInpu
 	err = client.request(ctx, req, &res)
 	return
 }
+
+func (client *Client) RestorePageRevision(ctx context.Context, input content.RestorePageRevisionInput) (page content.Page, err error) {
+	req := requestParams{
+		Method:  http.MethodPost,
+		Route:   api.RouteRestorePageRevision,
+		Payload: input,
+	}
+
+	err = client.request(ctx, req, &page)
+	return
+}
+
+func (client *Client) CreatePreviewToken(ctx context.Context, input content.CreatePreviewTokenInput) (token content.PreviewToken, err error) {
+	req := requestParams{
+		Method:  http.MethodPost,
+		Route:   api.RouteCreatePreviewToken,
+		Payload: input,
+	}
+
+	err = client.request(ctx, req, &token)
+	return
+}
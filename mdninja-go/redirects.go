@@ -20,3 +20,15 @@ func (client *Client) SaveRedirects(ctx context.Context, apiInput websites.SaveR
 
 	return
 }
+
+func (client *Client) ImportRedirects(ctx context.Context, apiInput websites.ImportRedirectsInput) (output websites.ImportRedirectsOutput, err error) {
+	req := requestParams{
+		Method:  http.MethodPost,
+		Route:   api.RouteImportRedirects,
+		Payload: apiInput,
+	}
+
+	err = client.request(ctx, req, &output)
+
+	return
+}
@@ -0,0 +1,54 @@
+CREATE TABLE pages_search (
+  page_id UUID PRIMARY KEY REFERENCES pages(id) ON DELETE CASCADE,
+  updated_at TIMESTAMP WITH TIME ZONE NOT NULL,
+
+  language_config REGCONFIG NOT NULL,
+  body_text TEXT NOT NULL,
+  vector TSVECTOR NOT NULL,
+
+  website_id UUID NOT NULL REFERENCES websites(id) ON DELETE CASCADE
+);
+CREATE INDEX index_pages_search_on_website_id ON pages_search (website_id);
+CREATE INDEX index_pages_search_on_vector ON pages_search USING GIN (vector);
+
+-- existing pages are indexed from their markdown body. They will be re-indexed from their rendered
+-- text the next time they are updated.
+INSERT INTO pages_search (page_id, updated_at, language_config, body_text, vector, website_id)
+  SELECT pages.id, NOW(), search.language_config, pages.body_markdown,
+    setweight(to_tsvector(search.language_config, pages.title), 'A')
+      || setweight(to_tsvector(search.language_config, COALESCE(
+        (SELECT string_agg(tags.name, ' ') FROM pages_tags INNER JOIN tags ON tags.id = pages_tags.tag_id WHERE pages_tags.page_id = pages.id),
+        '')), 'B')
+      || setweight(to_tsvector(search.language_config, pages.description), 'B')
+      || setweight(to_tsvector(search.language_config, pages.body_markdown), 'D'),
+    pages.website_id
+  FROM pages
+  CROSS JOIN LATERAL (
+    SELECT (CASE pages.language
+      WHEN 'ar' THEN 'arabic'
+      WHEN 'da' THEN 'danish'
+      WHEN 'de' THEN 'german'
+      WHEN 'el' THEN 'greek'
+      WHEN 'en' THEN 'english'
+      WHEN 'es' THEN 'spanish'
+      WHEN 'fi' THEN 'finnish'
+      WHEN 'fr' THEN 'french'
+      WHEN 'ga' THEN 'irish'
+      WHEN 'hu' THEN 'hungarian'
+      WHEN 'id' THEN 'indonesian'
+      WHEN 'it' THEN 'italian'
+      WHEN 'lt' THEN 'lithuanian'
+      WHEN 'ne' THEN 'nepali'
+      WHEN 'nl' THEN 'dutch'
+      WHEN 'no' THEN 'norwegian'
+      WHEN 'nb' THEN 'norwegian'
+      WHEN 'nn' THEN 'norwegian'
+      WHEN 'pt' THEN 'portuguese'
+      WHEN 'ro' THEN 'romanian'
+      WHEN 'ru' THEN 'russian'
+      WHEN 'sv' THEN 'swedish'
+      WHEN 'ta' THEN 'tamil'
+      WHEN 'tr' THEN 'turkish'
+      ELSE 'simple'
+    END)::regconfig AS language_config
+  ) AS search;
@@ -0,0 +1,35 @@
+CREATE TABLE page_revisions (
+  id UUID PRIMARY KEY,
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+
+  date TIMESTAMP WITH TIME ZONE NOT NULL,
+  title TEXT NOT NULL,
+  path TEXT NOT NULL,
+  description TEXT NOT NULL,
+  language TEXT NOT NULL,
+  tags JSONB NOT NULL,
+  body_markdown TEXT NOT NULL,
+  size BIGINT NOT NULL,
+  body_hash BYTEA NOT NULL,
+  metadata_hash BYTEA NOT NULL,
+
+  user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+  page_id UUID NOT NULL REFERENCES pages(id) ON DELETE CASCADE
+);
+CREATE INDEX index_page_revisions_on_page_id_and_created_at ON page_revisions (page_id, created_at);
+CREATE INDEX index_page_revisions_on_user_id ON page_revisions (user_id);
+
+-- the current version of existing pages is their first revision
+INSERT INTO page_revisions (id, created_at, date, title, path, description, language, tags, body_markdown,
+    size, body_hash, metadata_hash, user_id, page_id)
+  SELECT uuid_generate_v7(), pages.updated_at, pages.date, pages.title, pages.path, pages.description,
+    pages.language,
+    COALESCE(
+      (SELECT jsonb_agg(tags.name ORDER BY tags.name) FROM pages_tags
+        INNER JOIN tags ON tags.id = pages_tags.tag_id
+        WHERE pages_tags.page_id = pages.id),
+      '[]'::JSONB
+    ),
+    pages.body_markdown, pages.size, pages.body_hash, pages.metadata_hash, NULL, pages.id
+  FROM pages
+  WHERE pages.type IN ('page', 'post');
@@ -0,0 +1,3 @@
+ALTER TABLE page_revisions DROP COLUMN IF EXISTS authors;
+DROP TABLE IF EXISTS pages_authors;
+DROP TABLE IF EXISTS authors;
@@ -0,0 +1,33 @@
+CREATE TABLE authors (
+  id UUID PRIMARY KEY,
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+  updated_at TIMESTAMP WITH TIME ZONE NOT NULL,
+
+  slug TEXT NOT NULL,
+  name TEXT NOT NULL,
+  bio TEXT NOT NULL,
+  social_links JSONB NOT NULL,
+
+  avatar_id UUID REFERENCES assets(id) ON DELETE SET NULL,
+  website_id UUID NOT NULL REFERENCES websites(id) ON DELETE CASCADE,
+
+  UNIQUE (website_id, slug)
+);
+CREATE INDEX index_authors_on_website_id ON authors (website_id);
+CREATE INDEX index_authors_on_avatar_id ON authors (avatar_id);
+
+
+CREATE TABLE pages_authors (
+  -- position is the order in which the authors are listed for the page
+  position BIGINT NOT NULL,
+
+  page_id UUID NOT NULL REFERENCES pages(id) ON DELETE CASCADE,
+  author_id UUID NOT NULL REFERENCES authors(id) ON DELETE CASCADE,
+
+  PRIMARY KEY (page_id, author_id)
+);
+CREATE INDEX index_pages_authors_on_author_id ON pages_authors (author_id);
+
+
+ALTER TABLE page_revisions ADD COLUMN authors JSONB NOT NULL DEFAULT '[]'::JSONB;
+ALTER TABLE page_revisions ALTER COLUMN authors DROP DEFAULT;
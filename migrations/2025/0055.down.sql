@@ -0,0 +1,4 @@
+ALTER TABLE newsletters DROP COLUMN IF EXISTS excluded_labels;
+ALTER TABLE newsletters DROP COLUMN IF EXISTS included_labels;
+DROP TABLE IF EXISTS contacts_labels;
+DROP TABLE IF EXISTS labels;
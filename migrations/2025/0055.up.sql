@@ -0,0 +1,28 @@
+CREATE TABLE labels (
+  id UUID PRIMARY KEY,
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+  updated_at TIMESTAMP WITH TIME ZONE NOT NULL,
+
+  name TEXT NOT NULL,
+  description TEXT NOT NULL,
+
+  website_id UUID NOT NULL REFERENCES websites(id) ON DELETE CASCADE,
+
+  UNIQUE (website_id, name)
+);
+CREATE INDEX index_labels_on_website_id ON labels (website_id);
+
+
+CREATE TABLE contacts_labels (
+  contact_id UUID NOT NULL REFERENCES contacts(id) ON DELETE CASCADE,
+  label_id UUID NOT NULL REFERENCES labels(id) ON DELETE CASCADE,
+
+  PRIMARY KEY (contact_id, label_id)
+);
+CREATE INDEX index_contacts_labels_on_label_id ON contacts_labels (label_id);
+
+
+ALTER TABLE newsletters ADD COLUMN included_labels JSONB NOT NULL DEFAULT '[]'::jsonb;
+ALTER TABLE newsletters ALTER COLUMN included_labels DROP DEFAULT;
+ALTER TABLE newsletters ADD COLUMN excluded_labels JSONB NOT NULL DEFAULT '[]'::jsonb;
+ALTER TABLE newsletters ALTER COLUMN excluded_labels DROP DEFAULT;
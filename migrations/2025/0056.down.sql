@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS index_events_on_newsletter_id;
+
+ALTER TABLE emails_website_configuration DROP COLUMN IF EXISTS track_clicks;
+ALTER TABLE emails_website_configuration DROP COLUMN IF EXISTS track_opens;
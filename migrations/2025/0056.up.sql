@@ -0,0 +1,6 @@
+ALTER TABLE emails_website_configuration ADD COLUMN track_opens BOOLEAN NOT NULL DEFAULT true;
+ALTER TABLE emails_website_configuration ALTER COLUMN track_opens DROP DEFAULT;
+ALTER TABLE emails_website_configuration ADD COLUMN track_clicks BOOLEAN NOT NULL DEFAULT true;
+ALTER TABLE emails_website_configuration ALTER COLUMN track_clicks DROP DEFAULT;
+
+CREATE INDEX index_events_on_newsletter_id ON events (newsletter_id) WHERE newsletter_id IS NOT NULL;
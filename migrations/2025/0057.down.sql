@@ -0,0 +1,2 @@
+ALTER TABLE contacts DROP COLUMN IF EXISTS complained_at;
+ALTER TABLE contacts DROP COLUMN IF EXISTS bounced_at;
@@ -0,0 +1,2 @@
+ALTER TABLE contacts ADD COLUMN bounced_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE contacts ADD COLUMN complained_at TIMESTAMP WITH TIME ZONE;
@@ -0,0 +1,5 @@
+ALTER TABLE pages DROP COLUMN IF EXISTS paid_only;
+
+DROP TABLE IF EXISTS subscriptions;
+
+ALTER TABLE products DROP COLUMN IF EXISTS yearly_price;
@@ -0,0 +1,29 @@
+ALTER TABLE products ADD COLUMN yearly_price BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE products ALTER COLUMN yearly_price DROP DEFAULT;
+
+
+CREATE TABLE subscriptions (
+  id UUID PRIMARY KEY,
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+  updated_at TIMESTAMP WITH TIME ZONE NOT NULL,
+
+  status TEXT NOT NULL,
+  billing_interval TEXT NOT NULL,
+  current_period_end TIMESTAMP WITH TIME ZONE NOT NULL,
+  cancel_at_period_end BOOLEAN NOT NULL,
+  canceled_at TIMESTAMP WITH TIME ZONE,
+
+  stripe_subscription_id TEXT NOT NULL,
+
+  website_id UUID NOT NULL REFERENCES websites(id) ON DELETE CASCADE,
+  contact_id UUID NOT NULL REFERENCES contacts(id) ON DELETE CASCADE,
+  product_id UUID NOT NULL REFERENCES products(id)
+);
+CREATE UNIQUE INDEX index_subscriptions_on_stripe_subscription_id ON subscriptions (stripe_subscription_id);
+CREATE INDEX index_subscriptions_on_website_id ON subscriptions (website_id);
+CREATE INDEX index_subscriptions_on_contact_id ON subscriptions (contact_id);
+CREATE INDEX index_subscriptions_on_product_id ON subscriptions (product_id);
+
+
+ALTER TABLE pages ADD COLUMN paid_only BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE pages ALTER COLUMN paid_only DROP DEFAULT;
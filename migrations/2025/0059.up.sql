@@ -0,0 +1,8 @@
+CREATE TABLE bundles_products (
+  bundle_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+  -- products can't be deleted while they are part of a bundle
+  product_id UUID NOT NULL REFERENCES products(id),
+
+  PRIMARY KEY (bundle_id, product_id)
+);
+CREATE INDEX index_bundles_products_on_product_id ON bundles_products (product_id);
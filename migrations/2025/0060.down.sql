@@ -0,0 +1,8 @@
+DELETE FROM staffs WHERE role NOT IN (1, 2) OR website_id IS NOT NULL;
+DELETE FROM staff_invitations WHERE role NOT IN (1, 2) OR website_id IS NOT NULL;
+
+ALTER TABLE staffs DROP COLUMN IF EXISTS website_id;
+ALTER TABLE staff_invitations DROP COLUMN IF EXISTS website_id;
+
+UPDATE staffs SET role = 1 WHERE role = 2;
+UPDATE staff_invitations SET role = 1 WHERE role = 2;
@@ -0,0 +1,9 @@
+-- existing administrators become owners so they keep access to billing
+UPDATE staffs SET role = 2 WHERE role = 1;
+UPDATE staff_invitations SET role = 2 WHERE role = 1;
+
+ALTER TABLE staffs ADD COLUMN website_id UUID REFERENCES websites(id) ON DELETE CASCADE;
+CREATE INDEX index_staffs_on_website_id ON staffs (website_id);
+
+ALTER TABLE staff_invitations ADD COLUMN website_id UUID REFERENCES websites(id) ON DELETE CASCADE;
+CREATE INDEX index_staff_invitations_on_website_id ON staff_invitations (website_id);
@@ -0,0 +1,4 @@
+ALTER TABLE api_keys DROP COLUMN IF EXISTS last_used_ip;
+ALTER TABLE api_keys DROP COLUMN IF EXISTS last_used_at;
+ALTER TABLE api_keys DROP COLUMN IF EXISTS websites;
+ALTER TABLE api_keys DROP COLUMN IF EXISTS scopes;
@@ -0,0 +1,9 @@
+-- existing API keys keep full access to the organization
+ALTER TABLE api_keys ADD COLUMN scopes JSONB NOT NULL
+  DEFAULT '["websites:read", "websites:write", "content:read", "content:write", "assets:read", "assets:write", "store:read", "store:write"]'::jsonb;
+ALTER TABLE api_keys ALTER COLUMN scopes DROP DEFAULT;
+ALTER TABLE api_keys ADD COLUMN websites JSONB NOT NULL DEFAULT '[]'::jsonb;
+ALTER TABLE api_keys ALTER COLUMN websites DROP DEFAULT;
+
+ALTER TABLE api_keys ADD COLUMN last_used_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE api_keys ADD COLUMN last_used_ip TEXT;
@@ -0,0 +1,30 @@
+CREATE TABLE audit_logs (
+  id UUID PRIMARY KEY,
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+
+  action TEXT NOT NULL,
+  entity_type TEXT NOT NULL,
+  entity_id TEXT NOT NULL,
+  changes JSONB NOT NULL,
+
+  -- actors, websites and API keys are not foreign keys so that the entries are kept when they are deleted
+  actor_user_id UUID,
+  actor_api_key_id UUID,
+  actor_ip TEXT NOT NULL,
+
+  website_id UUID,
+  organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE
+);
+CREATE INDEX index_audit_logs_on_organization_id_and_id ON audit_logs (organization_id, id);
+CREATE INDEX index_audit_logs_on_website_id ON audit_logs (website_id);
+
+
+-- audit logs are append-only. Entries can only be deleted when their organization is deleted.
+CREATE FUNCTION audit_logs_prevent_update() RETURNS TRIGGER AS $$
+BEGIN
+  RAISE EXCEPTION 'audit_logs are append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_logs_prevent_update BEFORE UPDATE ON audit_logs
+  FOR EACH ROW EXECUTE FUNCTION audit_logs_prevent_update();
@@ -0,0 +1,37 @@
+CREATE TABLE webhook_endpoints (
+  id UUID PRIMARY KEY,
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+  updated_at TIMESTAMP WITH TIME ZONE NOT NULL,
+
+  url TEXT NOT NULL,
+  description TEXT NOT NULL,
+  event_types JSONB NOT NULL,
+  active BOOLEAN NOT NULL,
+  secret TEXT NOT NULL,
+
+  website_id UUID NOT NULL REFERENCES websites(id) ON DELETE CASCADE
+);
+CREATE INDEX index_webhook_endpoints_on_website_id ON webhook_endpoints (website_id);
+
+
+CREATE TABLE webhook_deliveries (
+  id UUID PRIMARY KEY,
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+  updated_at TIMESTAMP WITH TIME ZONE NOT NULL,
+
+  event_type TEXT NOT NULL,
+  payload JSONB NOT NULL,
+  status TEXT NOT NULL,
+  attempts BIGINT NOT NULL,
+  last_attempt_at TIMESTAMP WITH TIME ZONE,
+  next_attempt_at TIMESTAMP WITH TIME ZONE,
+  response_status BIGINT,
+  response_body TEXT NOT NULL,
+  error TEXT NOT NULL,
+  duration BIGINT NOT NULL,
+
+  endpoint_id UUID NOT NULL REFERENCES webhook_endpoints(id) ON DELETE CASCADE,
+  website_id UUID NOT NULL REFERENCES websites(id) ON DELETE CASCADE
+);
+CREATE INDEX index_webhook_deliveries_on_website_id_and_id ON webhook_deliveries (website_id, id);
+CREATE INDEX index_webhook_deliveries_on_endpoint_id ON webhook_deliveries (endpoint_id);
@@ -0,0 +1,3 @@
+ALTER TABLE redirects DROP COLUMN IF EXISTS position;
+ALTER TABLE redirects DROP COLUMN IF EXISTS preserve_query;
+ALTER TABLE redirects DROP COLUMN IF EXISTS query_pattern;
@@ -0,0 +1,6 @@
+ALTER TABLE redirects ADD COLUMN query_pattern TEXT NOT NULL DEFAULT '';
+ALTER TABLE redirects ALTER COLUMN query_pattern DROP DEFAULT;
+ALTER TABLE redirects ADD COLUMN preserve_query BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE redirects ALTER COLUMN preserve_query DROP DEFAULT;
+ALTER TABLE redirects ADD COLUMN position BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE redirects ALTER COLUMN position DROP DEFAULT;
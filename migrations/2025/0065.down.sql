@@ -0,0 +1 @@
+DROP INDEX IF EXISTS index_pages_on_website_id_and_date_and_id;
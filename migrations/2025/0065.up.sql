@@ -0,0 +1 @@
+CREATE INDEX index_pages_on_website_id_and_date_and_id ON pages (website_id, date DESC, id DESC);
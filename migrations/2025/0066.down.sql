@@ -0,0 +1,4 @@
+ALTER TABLE websites DROP COLUMN IF EXISTS languages;
+
+DROP INDEX IF EXISTS index_pages_on_website_id_and_translation_key_and_language;
+ALTER TABLE pages DROP COLUMN IF EXISTS translation_key;
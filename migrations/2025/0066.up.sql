@@ -0,0 +1,5 @@
+ALTER TABLE pages ADD COLUMN translation_key TEXT;
+CREATE UNIQUE INDEX index_pages_on_website_id_and_translation_key_and_language ON pages (website_id, translation_key, language) WHERE translation_key IS NOT NULL;
+
+ALTER TABLE websites ADD COLUMN languages JSONB NOT NULL DEFAULT '[]';
+ALTER TABLE websites ALTER COLUMN languages DROP DEFAULT;
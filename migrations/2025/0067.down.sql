@@ -0,0 +1,2 @@
+ALTER TABLE assets DROP COLUMN IF EXISTS height;
+ALTER TABLE assets DROP COLUMN IF EXISTS width;
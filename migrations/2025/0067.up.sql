@@ -0,0 +1,2 @@
+ALTER TABLE assets ADD COLUMN width BIGINT;
+ALTER TABLE assets ADD COLUMN height BIGINT;
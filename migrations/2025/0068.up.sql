@@ -0,0 +1,15 @@
+-- page views aggregated by day and visitor, used to compute the analytics of long time ranges without
+-- scanning all the raw events.
+CREATE TABLE events_daily_rollups (
+  day TIMESTAMP WITH TIME ZONE NOT NULL,
+  website_id UUID NOT NULL,
+  anonymous_id UUID,
+  path TEXT,
+  referrer TEXT,
+  country TEXT,
+  browser INT,
+  operating_system INT,
+  page_views BIGINT NOT NULL
+);
+CREATE INDEX index_events_daily_rollups_on_website_id_and_day ON events_daily_rollups (website_id, day);
+CREATE INDEX index_events_daily_rollups_on_day ON events_daily_rollups (day);
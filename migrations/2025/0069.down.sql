@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS index_events_on_website_id_and_custom_event_name;
+DROP TABLE IF EXISTS goals;
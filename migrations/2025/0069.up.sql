@@ -0,0 +1,16 @@
+CREATE TABLE goals (
+  id UUID PRIMARY KEY,
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+  updated_at TIMESTAMP WITH TIME ZONE NOT NULL,
+
+  name TEXT NOT NULL,
+  type TEXT NOT NULL,
+  event_name TEXT NOT NULL,
+  path TEXT NOT NULL,
+
+  website_id UUID NOT NULL REFERENCES websites(id) ON DELETE CASCADE
+);
+CREATE INDEX index_goals_on_website_id ON goals (website_id);
+
+CREATE INDEX index_events_on_website_id_and_custom_event_name ON events (website_id, (data->>'event_name'))
+  WHERE type = 1;
@@ -0,0 +1,9 @@
+ALTER TABLE events_daily_rollups DROP COLUMN IF EXISTS utm_campaign;
+ALTER TABLE events_daily_rollups DROP COLUMN IF EXISTS utm_medium;
+ALTER TABLE events_daily_rollups DROP COLUMN IF EXISTS utm_source;
+
+ALTER TABLE events DROP COLUMN IF EXISTS utm_content;
+ALTER TABLE events DROP COLUMN IF EXISTS utm_term;
+ALTER TABLE events DROP COLUMN IF EXISTS utm_campaign;
+ALTER TABLE events DROP COLUMN IF EXISTS utm_medium;
+ALTER TABLE events DROP COLUMN IF EXISTS utm_source;
@@ -0,0 +1,9 @@
+ALTER TABLE events ADD COLUMN utm_source TEXT;
+ALTER TABLE events ADD COLUMN utm_medium TEXT;
+ALTER TABLE events ADD COLUMN utm_campaign TEXT;
+ALTER TABLE events ADD COLUMN utm_term TEXT;
+ALTER TABLE events ADD COLUMN utm_content TEXT;
+
+ALTER TABLE events_daily_rollups ADD COLUMN utm_source TEXT;
+ALTER TABLE events_daily_rollups ADD COLUMN utm_medium TEXT;
+ALTER TABLE events_daily_rollups ADD COLUMN utm_campaign TEXT;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS analytics_report_settings;
+DROP TABLE IF EXISTS analytics_exports;
@@ -0,0 +1,26 @@
+CREATE TABLE analytics_exports (
+  id UUID PRIMARY KEY,
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+  updated_at TIMESTAMP WITH TIME ZONE NOT NULL,
+
+  type TEXT NOT NULL,
+  format TEXT NOT NULL,
+  from_time TIMESTAMP WITH TIME ZONE NOT NULL,
+  to_time TIMESTAMP WITH TIME ZONE NOT NULL,
+  completed_at TIMESTAMP WITH TIME ZONE,
+  size BIGINT NOT NULL,
+
+  website_id UUID NOT NULL REFERENCES websites(id) ON DELETE CASCADE
+);
+CREATE INDEX index_analytics_exports_on_website_id ON analytics_exports (website_id);
+CREATE INDEX index_analytics_exports_on_created_at ON analytics_exports (created_at);
+
+-- websites without a row don't receive analytics reports
+CREATE TABLE analytics_report_settings (
+  website_id UUID PRIMARY KEY REFERENCES websites(id) ON DELETE CASCADE,
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+  updated_at TIMESTAMP WITH TIME ZONE NOT NULL,
+
+  frequency TEXT NOT NULL
+);
+CREATE INDEX index_analytics_report_settings_on_frequency ON analytics_report_settings (frequency);
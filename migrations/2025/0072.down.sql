@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS index_orders_on_coupon_id;
+ALTER TABLE orders DROP COLUMN IF EXISTS coupon_id;
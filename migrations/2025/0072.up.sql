@@ -0,0 +1,3 @@
+-- the coupon used for the order, if any
+ALTER TABLE orders ADD COLUMN coupon_id UUID REFERENCES coupons(id) ON DELETE SET NULL;
+CREATE INDEX index_orders_on_coupon_id ON orders (coupon_id);
@@ -0,0 +1,20 @@
+-- the anonymous IDs of visitors can't be restored: the rollups are emptied and will be recomputed from
+-- the raw events that still exist.
+DROP TABLE IF EXISTS events_daily_rollups;
+CREATE TABLE events_daily_rollups (
+  day TIMESTAMP WITH TIME ZONE NOT NULL,
+  website_id UUID NOT NULL,
+  anonymous_id UUID,
+  path TEXT,
+  referrer TEXT,
+  country TEXT,
+  browser INT,
+  operating_system INT,
+  page_views BIGINT NOT NULL,
+  utm_source TEXT,
+  utm_medium TEXT,
+  utm_campaign TEXT
+);
+CREATE INDEX index_events_daily_rollups_on_website_id_and_day ON events_daily_rollups (website_id, day);
+CREATE INDEX index_events_daily_rollups_on_day ON events_daily_rollups (day);
+DELETE FROM settings WHERE key = 'events.daily_rollups';
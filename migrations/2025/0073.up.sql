@@ -0,0 +1,57 @@
+-- Rollups no longer store the anonymous IDs of visitors. Instead, they store the page views and the number
+-- of unique visitors per day for each dimension (grouping set):
+--   'total': all the page views of the website
+--   'path', 'referrer', 'country', 'browser', 'operating_system', 'utm_source', 'utm_medium', 'utm_campaign':
+--     the page views for each value of a single column, the other columns are NULL
+--   'campaign': the page views for each combination of utm_source, utm_medium and utm_campaign
+--   'all': the page views for each combination of all the columns
+-- Anonymous IDs are rotated every day, so the daily numbers of visitors can be summed over several days.
+CREATE TABLE events_daily_rollups_v2 (
+  day TIMESTAMP WITH TIME ZONE NOT NULL,
+  website_id UUID NOT NULL,
+  dimension TEXT NOT NULL,
+  path TEXT,
+  referrer TEXT,
+  country TEXT,
+  browser INT,
+  operating_system INT,
+  utm_source TEXT,
+  utm_medium TEXT,
+  utm_campaign TEXT,
+  page_views BIGINT NOT NULL,
+  visitors BIGINT NOT NULL
+);
+
+-- the existing rollups are converted as the raw events of old days may have been deleted.
+-- GROUPING() returns a bitmask where the bit of a column (path is the most significant) is 1 if the
+-- column is not part of the grouping set.
+INSERT INTO events_daily_rollups_v2
+  (day, website_id, dimension, path, referrer, country, browser, operating_system,
+    utm_source, utm_medium, utm_campaign, page_views, visitors)
+SELECT day, website_id,
+  CASE GROUPING(path, referrer, country, browser, operating_system, utm_source, utm_medium, utm_campaign)
+    WHEN 255 THEN 'total'
+    WHEN 127 THEN 'path'
+    WHEN 191 THEN 'referrer'
+    WHEN 223 THEN 'country'
+    WHEN 239 THEN 'browser'
+    WHEN 247 THEN 'operating_system'
+    WHEN 251 THEN 'utm_source'
+    WHEN 253 THEN 'utm_medium'
+    WHEN 254 THEN 'utm_campaign'
+    WHEN 248 THEN 'campaign'
+    ELSE 'all'
+  END,
+  path, referrer, country, browser, operating_system, utm_source, utm_medium, utm_campaign,
+  SUM(page_views), COUNT(DISTINCT anonymous_id)
+FROM events_daily_rollups
+GROUP BY day, website_id, GROUPING SETS (
+  (), (path), (referrer), (country), (browser), (operating_system), (utm_source), (utm_medium), (utm_campaign),
+  (utm_source, utm_medium, utm_campaign),
+  (path, referrer, country, browser, operating_system, utm_source, utm_medium, utm_campaign)
+);
+
+DROP TABLE events_daily_rollups;
+ALTER TABLE events_daily_rollups_v2 RENAME TO events_daily_rollups;
+CREATE INDEX index_events_daily_rollups_on_website_id_and_dimension_and_day ON events_daily_rollups (website_id, dimension, day);
+CREATE INDEX index_events_daily_rollups_on_day ON events_daily_rollups (day);
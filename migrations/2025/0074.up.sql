@@ -0,0 +1,8 @@
+-- the DKIM private keys of the domains used to send emails with the SMTP mailer, encrypted with the KMS
+CREATE TABLE dkim_keys (
+  domain TEXT PRIMARY KEY,
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+  updated_at TIMESTAMP WITH TIME ZONE NOT NULL,
+
+  encrypted_private_key BYTEA NOT NULL
+);
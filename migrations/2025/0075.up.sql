@@ -0,0 +1,11 @@
+-- the full body of paid-only pages must not be searchable. Only their metadata is indexed until they are
+-- re-indexed with their teaser the next time they are updated.
+UPDATE pages_search
+  SET updated_at = NOW(), body_text = '',
+    vector = setweight(to_tsvector(pages_search.language_config, pages.title), 'A')
+      || setweight(to_tsvector(pages_search.language_config, COALESCE(
+        (SELECT string_agg(tags.name, ' ') FROM pages_tags INNER JOIN tags ON tags.id = pages_tags.tag_id WHERE pages_tags.page_id = pages.id),
+        '')), 'B')
+      || setweight(to_tsvector(pages_search.language_config, pages.description), 'B')
+  FROM pages
+  WHERE pages.id = pages_search.page_id AND pages.paid_only = true;
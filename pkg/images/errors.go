@@ -0,0 +1,25 @@
+package images
+
+import (
+	"fmt"
+	"strings"
+
+	"markdown.ninja/pkg/errs"
+)
+
+var (
+	ErrImageIsTooLarge = errs.InvalidArgument(fmt.Sprintf("Image is too large to be transformed. Max: %d pixels.", MaxSourcePixels))
+	ErrImageIsNotValid = func(err error) error {
+		return errs.InvalidArgument("Image is not valid: " + err.Error())
+	}
+	ErrFormatIsNotSupported = func(format Format) error {
+		return errs.InvalidArgument(fmt.Sprintf("Format \"%s\" is not supported.", format))
+	}
+	ErrTransformParameterIsNotValid = func(parameter string) error {
+		return errs.InvalidArgument(fmt.Sprintf("Parameter \"%s\" is not valid.", parameter))
+	}
+	ErrTransformParameterIsNotAllowed = func(parameter string, allowedValues []int64) error {
+		return errs.InvalidArgument(fmt.Sprintf("Parameter \"%s\" is not valid. Allowed values: %s.", parameter,
+			strings.Trim(fmt.Sprint(allowedValues), "[]")))
+	}
+)
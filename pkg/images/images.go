@@ -0,0 +1,302 @@
+package images
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"math"
+	"strings"
+
+	_ "image/gif"
+
+	"github.com/gen2brain/avif"
+	"github.com/gen2brain/webp"
+	"golang.org/x/image/draw"
+)
+
+const (
+	// MaxDimension is the maximum width or height of a transformed image
+	MaxDimension   = 4096
+	DefaultQuality = 80
+	// MaxSourceSize is the maximum size in bytes of the images that can be transformed
+	MaxSourceSize int64 = 30_000_000
+	// MaxSourcePixels protects against decompression bombs: small files that decode to huge images
+	MaxSourcePixels = 50_000_000
+)
+
+// ResponsiveWidths are the widths of the variants used in the srcset attribute of images
+var ResponsiveWidths = []int64{320, 640, 960, 1280, 1920}
+
+// Variants are generated on demand and stored, so anyone could fill the storage by requesting all the
+// possible combinations of parameters. Only a few dimensions and qualities are thus allowed, which bounds
+// the number of variants of an image.
+var (
+	// VariantDimensions are the allowed widths and heights of variants. They include ResponsiveWidths.
+	VariantDimensions = []int64{80, 160, 320, 480, 640, 960, 1280, 1920, 2560}
+	// VariantQualities are the allowed qualities of variants
+	VariantQualities = []int64{50, 65, DefaultQuality, 95}
+)
+
+type Format string
+
+const (
+	FormatJpeg Format = "jpeg"
+	FormatPng  Format = "png"
+	FormatWebp Format = "webp"
+	FormatAvif Format = "avif"
+)
+
+func (format Format) MediaType() string {
+	return "image/" + string(format)
+}
+
+// Extension returns the file extension of images with this format, including the leading dot
+func (format Format) Extension() string {
+	if format == FormatJpeg {
+		return ".jpg"
+	}
+	return "." + string(format)
+}
+
+// IsLossy returns true if the quality is used when encoding images to this format
+func (format Format) IsLossy() bool {
+	return format != FormatPng
+}
+
+type Fit string
+
+const (
+	// FitContain scales the image to fit in the box while preserving its aspect ratio
+	FitContain Fit = "contain"
+	// FitCover scales and crops the image so it covers the whole box
+	FitCover Fit = "cover"
+	// FitFill stretches the image to the dimensions of the box
+	FitFill Fit = "fill"
+)
+
+type TransformOptions struct {
+	// Width and Height are the dimensions of the box the image is resized to. 0 means auto
+	Width  int64
+	Height int64
+	Fit    Fit
+	// Quality is only used by lossy formats
+	Quality int64
+	// Format is the output format. If empty, the format of the source image is kept when possible
+	Format Format
+}
+
+// String returns a canonical representation of the options, used to identify variants
+func (options TransformOptions) String() string {
+	return fmt.Sprintf("w=%d&h=%d&fit=%s&q=%d&format=%s", options.Width, options.Height, options.Fit,
+		options.Quality, options.Format)
+}
+
+// An Encoder encodes an image. quality is between 1 and 100.
+type Encoder func(output io.Writer, img image.Image, quality int64) error
+
+var encoders = map[Format]Encoder{
+	FormatJpeg: encodeJpeg,
+	FormatPng:  encodePng,
+	FormatWebp: encodeWebp,
+	FormatAvif: encodeAvif,
+}
+
+func getEncoder(format Format) (encoder Encoder, exists bool) {
+	encoder, exists = encoders[format]
+	return
+}
+
+// CanEncode returns true if images can be converted to the given format
+func CanEncode(format Format) bool {
+	_, exists := getEncoder(format)
+	return exists
+}
+
+// SourceFormat returns the format of images with the given media type, and false if images
+// with this media type can't be transformed.
+// GIFs are not transformed as we would lose the animations.
+func SourceFormat(mediaType string) (Format, bool) {
+	switch strings.ToLower(mediaType) {
+	case "image/jpeg", "image/jpg":
+		return FormatJpeg, true
+	case "image/png":
+		return FormatPng, true
+	case "image/webp":
+		return FormatWebp, true
+	case "image/avif":
+		return FormatAvif, true
+	default:
+		return "", false
+	}
+}
+
+// ResolveFormat returns the format of the transformed image: the requested format if any, otherwise
+// the format of the source if it can be encoded, and PNG as a lossless fallback.
+func ResolveFormat(source Format, requested Format) (Format, error) {
+	if requested != "" {
+		if !CanEncode(requested) {
+			return "", ErrFormatIsNotSupported(requested)
+		}
+		return requested, nil
+	}
+
+	if CanEncode(source) {
+		return source, nil
+	}
+
+	return FormatPng, nil
+}
+
+// PreferredFormat returns the most efficient format accepted by the client (according to its Accept header),
+// or an empty format to keep the format of the source.
+func PreferredFormat(acceptHeader string) Format {
+	if strings.Contains(acceptHeader, FormatAvif.MediaType()) {
+		return FormatAvif
+	}
+	if strings.Contains(acceptHeader, FormatWebp.MediaType()) {
+		return FormatWebp
+	}
+	return ""
+}
+
+// DecodeConfig returns the dimensions of an image without decoding it entirely
+func DecodeConfig(input io.Reader) (width, height int64, err error) {
+	config, _, err := image.DecodeConfig(input)
+	if err != nil {
+		return 0, 0, fmt.Errorf("images: decoding image config: %w", err)
+	}
+
+	return int64(config.Width), int64(config.Height), nil
+}
+
+// Transform resizes and converts the input image according to options.
+// options.Format must have been resolved with ResolveFormat.
+func Transform(input []byte, options TransformOptions) (output []byte, err error) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(input))
+	if err != nil {
+		return nil, ErrImageIsNotValid(err)
+	}
+	if int64(config.Width)*int64(config.Height) > MaxSourcePixels {
+		return nil, ErrImageIsTooLarge
+	}
+
+	encoder, exists := getEncoder(options.Format)
+	if !exists {
+		return nil, ErrFormatIsNotSupported(options.Format)
+	}
+
+	sourceImage, _, err := image.Decode(bytes.NewReader(input))
+	if err != nil {
+		return nil, ErrImageIsNotValid(err)
+	}
+
+	outputImage := sourceImage
+	width, height, crop := computeTargetSize(int64(config.Width), int64(config.Height), options)
+	if width != int64(config.Width) || height != int64(config.Height) {
+		resizedImage := image.NewRGBA(image.Rect(0, 0, int(width), int(height)))
+		crop = crop.Add(sourceImage.Bounds().Min)
+		draw.CatmullRom.Scale(resizedImage, resizedImage.Bounds(), sourceImage, crop, draw.Src, nil)
+		outputImage = resizedImage
+	}
+
+	outputBuffer := bytes.NewBuffer(make([]byte, 0, len(input)/4))
+	err = encoder(outputBuffer, outputImage, options.Quality)
+	if err != nil {
+		return nil, fmt.Errorf("images: encoding image to %s: %w", options.Format, err)
+	}
+
+	return outputBuffer.Bytes(), nil
+}
+
+// computeTargetSize returns the dimensions of the transformed image, and the part of the source image
+// that is used. Images are never upscaled, and are downscaled to fit within MaxDimension.
+func computeTargetSize(sourceWidth, sourceHeight int64, options TransformOptions) (width, height int64, crop image.Rectangle) {
+	width, height, crop = computeBoxSize(sourceWidth, sourceHeight, options)
+	if width > MaxDimension || height > MaxDimension {
+		scale := min(float64(MaxDimension)/float64(width), float64(MaxDimension)/float64(height))
+		width = max(1, min(MaxDimension, int64(math.Round(float64(width)*scale))))
+		height = max(1, min(MaxDimension, int64(math.Round(float64(height)*scale))))
+	}
+	return width, height, crop
+}
+
+// computeBoxSize returns the dimensions of the image resized to the box defined by options, and the part
+// of the source image that is used.
+func computeBoxSize(sourceWidth, sourceHeight int64, options TransformOptions) (width, height int64, crop image.Rectangle) {
+	crop = image.Rect(0, 0, int(sourceWidth), int(sourceHeight))
+	width = options.Width
+	height = options.Height
+
+	switch {
+	case width == 0 && height == 0:
+		return sourceWidth, sourceHeight, crop
+
+	case height == 0:
+		width = min(width, sourceWidth)
+		height = scaleDimension(sourceHeight, width, sourceWidth)
+		return width, height, crop
+
+	case width == 0:
+		height = min(height, sourceHeight)
+		width = scaleDimension(sourceWidth, height, sourceHeight)
+		return width, height, crop
+	}
+
+	switch options.Fit {
+	case FitFill:
+		return min(width, sourceWidth), min(height, sourceHeight), crop
+
+	case FitCover:
+		// crop the source to the aspect ratio of the box
+		if sourceWidth*height > width*sourceHeight {
+			cropWidth := scaleDimension(sourceHeight, width, height)
+			offset := (sourceWidth - cropWidth) / 2
+			crop = image.Rect(int(offset), 0, int(offset+cropWidth), int(sourceHeight))
+		} else {
+			cropHeight := scaleDimension(sourceWidth, height, width)
+			offset := (sourceHeight - cropHeight) / 2
+			crop = image.Rect(0, int(offset), int(sourceWidth), int(offset+cropHeight))
+		}
+		if width > int64(crop.Dx()) {
+			return int64(crop.Dx()), int64(crop.Dy()), crop
+		}
+		return width, height, crop
+
+	default:
+		scale := min(float64(width)/float64(sourceWidth), float64(height)/float64(sourceHeight), 1)
+		width = max(1, int64(math.Round(float64(sourceWidth)*scale)))
+		height = max(1, int64(math.Round(float64(sourceHeight)*scale)))
+		return width, height, crop
+	}
+}
+
+// scaleDimension returns dimension * numerator / denominator, rounded and at least 1
+func scaleDimension(dimension, numerator, denominator int64) int64 {
+	return max(1, int64(math.Round(float64(dimension)*float64(numerator)/float64(denominator))))
+}
+
+func encodeJpeg(output io.Writer, img image.Image, quality int64) error {
+	return jpeg.Encode(output, img, &jpeg.Options{Quality: int(quality)})
+}
+
+func encodePng(output io.Writer, img image.Image, _ int64) error {
+	encoder := png.Encoder{CompressionLevel: png.BestSpeed}
+	return encoder.Encode(output, img)
+}
+
+func encodeWebp(output io.Writer, img image.Image, quality int64) error {
+	return webp.Encode(output, img, webp.Options{Quality: int(quality), Method: webp.DefaultMethod})
+}
+
+// AVIF images are encoded with the fastest speed as variants are generated on demand
+func encodeAvif(output io.Writer, img image.Image, quality int64) error {
+	return avif.Encode(output, img, avif.Options{
+		Quality:           int(quality),
+		QualityAlpha:      int(quality),
+		Speed:             avif.DefaultSpeed,
+		ChromaSubsampling: image.YCbCrSubsampleRatio420,
+	})
+}
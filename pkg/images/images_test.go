@@ -0,0 +1,182 @@
+package images
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"math/rand/v2"
+	"net/url"
+	"slices"
+	"testing"
+)
+
+func TestComputeTargetSize(t *testing.T) {
+	tests := []struct {
+		sourceWidth    int64
+		sourceHeight   int64
+		options        TransformOptions
+		expectedWidth  int64
+		expectedHeight int64
+		expectedCrop   image.Rectangle
+	}{
+		{1000, 500, TransformOptions{}, 1000, 500, image.Rect(0, 0, 1000, 500)},
+		{1000, 500, TransformOptions{Width: 400}, 400, 200, image.Rect(0, 0, 1000, 500)},
+		{1000, 500, TransformOptions{Height: 100}, 200, 100, image.Rect(0, 0, 1000, 500)},
+		// images are never upscaled
+		{1000, 500, TransformOptions{Width: 2000}, 1000, 500, image.Rect(0, 0, 1000, 500)},
+		{1000, 500, TransformOptions{Width: 400, Height: 400, Fit: FitContain}, 400, 200, image.Rect(0, 0, 1000, 500)},
+		{1000, 500, TransformOptions{Width: 400, Height: 400, Fit: FitFill}, 400, 400, image.Rect(0, 0, 1000, 500)},
+		{1000, 500, TransformOptions{Width: 400, Height: 400, Fit: FitCover}, 400, 400, image.Rect(250, 0, 750, 500)},
+		{500, 1000, TransformOptions{Width: 400, Height: 200, Fit: FitCover}, 400, 200, image.Rect(0, 375, 500, 625)},
+		{1000, 500, TransformOptions{Width: 2000, Height: 2000, Fit: FitCover}, 500, 500, image.Rect(250, 0, 750, 500)},
+		// images are downscaled to MaxDimension, even when no dimension is requested
+		{8192, 6000, TransformOptions{Quality: 50}, MaxDimension, 3000, image.Rect(0, 0, 8192, 6000)},
+		{1000, 20000, TransformOptions{Width: 640}, 205, MaxDimension, image.Rect(0, 0, 1000, 20000)},
+		{10000, 9000, TransformOptions{Width: 2560, Height: 2560, Fit: FitFill}, 2560, 2560, image.Rect(0, 0, 10000, 9000)},
+	}
+
+	for _, test := range tests {
+		width, height, crop := computeTargetSize(test.sourceWidth, test.sourceHeight, test.options)
+		if width != test.expectedWidth || height != test.expectedHeight || !crop.Eq(test.expectedCrop) {
+			t.Errorf("computeTargetSize(%d, %d, %s) = (%d, %d, %s), expected (%d, %d, %s)",
+				test.sourceWidth, test.sourceHeight, test.options, width, height, crop,
+				test.expectedWidth, test.expectedHeight, test.expectedCrop)
+		}
+	}
+}
+
+func TestTransform(t *testing.T) {
+	sourceImage := image.NewRGBA(image.Rect(0, 0, 300, 200))
+	for x := range 300 {
+		for y := range 200 {
+			sourceImage.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+	var sourceBuffer bytes.Buffer
+	err := png.Encode(&sourceBuffer, sourceImage)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, format := range []Format{FormatPng, FormatJpeg, FormatWebp, FormatAvif} {
+		output, err := Transform(sourceBuffer.Bytes(), TransformOptions{Width: 150, Fit: FitContain, Quality: DefaultQuality, Format: format})
+		if err != nil {
+			t.Fatalf("transforming image to %s: %v", format, err)
+		}
+
+		config, outputFormat, err := image.DecodeConfig(bytes.NewReader(output))
+		if err != nil {
+			t.Fatalf("decoding %s output: %v", format, err)
+		}
+		if outputFormat != string(format) {
+			t.Errorf("output format (%s) != expected format (%s)", outputFormat, format)
+		}
+		if config.Width != 150 || config.Height != 100 {
+			t.Errorf("output dimensions (%dx%d) != expected dimensions (150x100)", config.Width, config.Height)
+		}
+	}
+
+	_, err = Transform([]byte("not an image"), TransformOptions{Width: 150, Format: FormatPng})
+	if err == nil {
+		t.Error("transforming invalid data should fail")
+	}
+}
+
+func TestEncodeLossyFormats(t *testing.T) {
+	random := rand.New(rand.NewPCG(1, 2))
+	sourceImage := image.NewNRGBA(image.Rect(0, 0, 160, 120))
+	for x := range 160 {
+		for y := range 120 {
+			sourceImage.SetNRGBA(x, y, color.NRGBA{R: uint8(x + random.IntN(32)), G: uint8(y + random.IntN(32)),
+				B: uint8(random.IntN(256)), A: 255})
+		}
+	}
+
+	for _, format := range []Format{FormatJpeg, FormatWebp, FormatAvif} {
+		encoder, _ := getEncoder(format)
+
+		var lowQuality, highQuality bytes.Buffer
+		err := encoder(&lowQuality, sourceImage, VariantQualities[0])
+		if err != nil {
+			t.Fatalf("%s: encoding image: %v", format, err)
+		}
+		err = encoder(&highQuality, sourceImage, VariantQualities[len(VariantQualities)-1])
+		if err != nil {
+			t.Fatalf("%s: encoding image: %v", format, err)
+		}
+
+		if lowQuality.Len() >= highQuality.Len() {
+			t.Errorf("%s: the quality is ignored: %d bytes with a low quality, %d bytes with a high quality",
+				format, lowQuality.Len(), highQuality.Len())
+		}
+
+		decodedImage, decodedFormat, err := image.Decode(&lowQuality)
+		if err != nil {
+			t.Fatalf("%s: decoding image: %v", format, err)
+		}
+		if decodedFormat != string(format) {
+			t.Errorf("%s: decoded format is %s", format, decodedFormat)
+		}
+		if !decodedImage.Bounds().Eq(sourceImage.Bounds()) {
+			t.Errorf("%s: dimensions (%v) != expected dimensions (%v)", format, decodedImage.Bounds(), sourceImage.Bounds())
+		}
+	}
+}
+
+func TestPreferredFormat(t *testing.T) {
+	tests := []struct {
+		acceptHeader string
+		expected     Format
+	}{
+		{"image/avif,image/webp,image/apng,image/svg+xml,image/*,*/*;q=0.8", FormatAvif},
+		{"image/webp,*/*", FormatWebp},
+		{"image/png,image/*;q=0.8,*/*;q=0.5", ""},
+		{"", ""},
+	}
+
+	for _, test := range tests {
+		format := PreferredFormat(test.acceptHeader)
+		if format != test.expected {
+			t.Errorf("PreferredFormat(%q) = %q, expected %q", test.acceptHeader, format, test.expected)
+		}
+	}
+}
+
+func TestVariantDimensions(t *testing.T) {
+	for _, width := range ResponsiveWidths {
+		if !slices.Contains(VariantDimensions, width) {
+			t.Errorf("responsive width %d is not an allowed variant dimension", width)
+		}
+	}
+	for _, dimension := range VariantDimensions {
+		if dimension > MaxDimension {
+			t.Errorf("variant dimension %d is larger than the max dimension (%d)", dimension, MaxDimension)
+		}
+	}
+}
+
+func TestParseTransformOptions(t *testing.T) {
+	options, err := ParseTransformOptions(url.Values{})
+	if err != nil || options != nil {
+		t.Errorf("options should be nil when there is no transform parameter. Got: %v, %v", options, err)
+	}
+
+	query, _ := url.ParseQuery("w=640&h=480&fit=COVER&q=65&format=jpg")
+	options, err = ParseTransformOptions(query)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := TransformOptions{Width: 640, Height: 480, Fit: FitCover, Quality: 65, Format: FormatJpeg}
+	if *options != expected {
+		t.Errorf("options (%s) != expected (%s)", options, expected)
+	}
+
+	for _, invalidQuery := range []string{"w=0", "w=-1", "w=abc", "w=641", "h=5000", "q=101", "q=79", "fit=stretch", "format=bmp"} {
+		query, _ = url.ParseQuery(invalidQuery)
+		_, err = ParseTransformOptions(query)
+		if err == nil {
+			t.Errorf("query %q should not be valid", invalidQuery)
+		}
+	}
+}
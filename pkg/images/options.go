@@ -0,0 +1,78 @@
+package images
+
+import (
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ParseTransformOptions parses the transform options from the query parameters of a request:
+// w (width) and h (height) from VariantDimensions, fit (contain, cover or fill), q (quality) from
+// VariantQualities and format (jpeg, png, webp or avif).
+// It returns nil if the query doesn't contain any transform parameter.
+func ParseTransformOptions(query url.Values) (options *TransformOptions, err error) {
+	if !query.Has("w") && !query.Has("h") && !query.Has("fit") && !query.Has("q") && !query.Has("format") {
+		return nil, nil
+	}
+
+	options = &TransformOptions{
+		Fit:     FitContain,
+		Quality: DefaultQuality,
+	}
+
+	options.Width, err = parseTransformParameter(query, "w", VariantDimensions)
+	if err != nil {
+		return nil, err
+	}
+
+	options.Height, err = parseTransformParameter(query, "h", VariantDimensions)
+	if err != nil {
+		return nil, err
+	}
+
+	if query.Has("q") {
+		options.Quality, err = parseTransformParameter(query, "q", VariantQualities)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if query.Has("fit") {
+		options.Fit = Fit(strings.ToLower(strings.TrimSpace(query.Get("fit"))))
+		switch options.Fit {
+		case FitContain, FitCover, FitFill:
+		default:
+			return nil, ErrTransformParameterIsNotValid("fit")
+		}
+	}
+
+	if query.Has("format") {
+		options.Format = Format(strings.ToLower(strings.TrimSpace(query.Get("format"))))
+		if options.Format == "jpg" {
+			options.Format = FormatJpeg
+		}
+		switch options.Format {
+		case FormatJpeg, FormatPng, FormatWebp, FormatAvif:
+		default:
+			return nil, ErrTransformParameterIsNotValid("format")
+		}
+	}
+
+	return options, nil
+}
+
+// parseTransformParameter parses an integer parameter that must be one of allowedValues.
+// Missing parameters are parsed as 0.
+func parseTransformParameter(query url.Values, name string, allowedValues []int64) (value int64, err error) {
+	if !query.Has(name) {
+		return 0, nil
+	}
+
+	value, err = strconv.ParseInt(strings.TrimSpace(query.Get(name)), 10, 64)
+	if err != nil || !slices.Contains(allowedValues, value) {
+		return 0, ErrTransformParameterIsNotAllowed(name, allowedValues)
+	}
+
+	return value, nil
+}
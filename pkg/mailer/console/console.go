@@ -2,7 +2,10 @@ package console
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
 
 	"github.com/bloom42/stdx-go/email"
 	"markdown.ninja/pkg/mailer"
@@ -10,18 +13,30 @@ import (
 
 // consoleMailer implements the `Mailer` interface to print emails to console
 type ConsoleMailer struct {
+	acceptUnsignedWebhooks bool
+}
+
+type Config struct {
+	// AcceptUnsignedWebhooks enables the webhooks, which are not signed. It must only be enabled in
+	// development and test environments, otherwise anyone could mark contacts as bounced.
+	AcceptUnsignedWebhooks bool
 }
 
 // ensure that consoleMailer satisfies the Storage interface
 var _ mailer.Mailer = (*ConsoleMailer)(nil)
 
+var ErrUnsignedWebhooksAreDisabled = errors.New("mailer: webhooks are not signed with the console mailer and are only accepted in development")
+
 // NewMailer returns a new console Mailer
-func NewConsoleMailer() *ConsoleMailer {
-	return &ConsoleMailer{}
+func NewConsoleMailer(config Config) *ConsoleMailer {
+	return &ConsoleMailer{
+		acceptUnsignedWebhooks: config.AcceptUnsignedWebhooks,
+	}
 }
 
 // Send an email using the console mailer
 func (consoleMailer *ConsoleMailer) SendTransactionnal(ctx context.Context, email email.Email) error {
+	// the tags header is kept so the tags are printed with the other headers of the email
 	data, err := email.Bytes()
 	if err != nil {
 		return err
@@ -61,3 +76,19 @@ func (consoleMailer *ConsoleMailer) GetSuppressions(ctx context.Context) (suppre
 func (consoleMailer *ConsoleMailer) DeleteSuppression(ctx context.Context, email string) (err error) {
 	return nil
 }
+
+// ParseAndVerifyWebhook is a local stand-in for the webhooks of real email providers: payload is a JSON array
+// of mailer.WebhookEvent and is not signed. It returns an error unless unsigned webhooks are accepted.
+func (consoleMailer *ConsoleMailer) ParseAndVerifyWebhook(ctx context.Context, headers http.Header, payload []byte) (events []mailer.WebhookEvent, err error) {
+	if !consoleMailer.acceptUnsignedWebhooks {
+		return nil, ErrUnsignedWebhooksAreDisabled
+	}
+
+	events = make([]mailer.WebhookEvent, 0, 1)
+	err = json.Unmarshal(payload, &events)
+	if err != nil {
+		return nil, fmt.Errorf("mailer: error parsing webhook events: %w", err)
+	}
+
+	return events, nil
+}
@@ -5,6 +5,9 @@ import (
 	"database/sql/driver"
 	"encoding/json"
 	"fmt"
+	"net/http"
+	"strings"
+	"time"
 
 	"github.com/bloom42/stdx-go/email"
 )
@@ -17,6 +20,55 @@ type Mailer interface {
 	VerifyDomain(ctx context.Context, domain string) (verified bool, err error)
 	GetSuppressions(ctx context.Context) (suppressions []Suppression, err error)
 	DeleteSuppression(ctx context.Context, email string) (err error)
+	// ParseAndVerifyWebhook verifies the signature of a webhook sent by the email provider and returns the
+	// bounces and complaints that it contains. events may be empty for webhooks that don't carry
+	// bounces or complaints (e.g. subscription confirmations).
+	ParseAndVerifyWebhook(ctx context.Context, headers http.Header, payload []byte) (events []WebhookEvent, err error)
+}
+
+// HeaderTags is used to attach tags to an email (e.g. the ID of the newsletter) so they can be found in the
+// webhook events sent by the email provider. Its value is formatted as "key1=value1, key2=value2".
+// Mailers remove this header before sending the email.
+const HeaderTags = "X-Mdninja-Tags"
+
+const (
+	TagWebsiteID    = "website_id"
+	TagNewsletterID = "newsletter_id"
+)
+
+type WebhookEventType string
+
+const (
+	WebhookEventTypeBounce    WebhookEventType = "bounce"
+	WebhookEventTypeComplaint WebhookEventType = "complaint"
+)
+
+type WebhookEvent struct {
+	Type WebhookEventType `json:"type"`
+	// Permanent is true for hard bounces and always false for complaints
+	Permanent  bool              `json:"permanent"`
+	Recipients []string          `json:"recipients"`
+	Tags       map[string]string `json:"tags"`
+	Timestamp  time.Time         `json:"timestamp"`
+}
+
+func EncodeTags(tags map[string]string) string {
+	encodedTags := make([]string, 0, len(tags))
+	for key, value := range tags {
+		encodedTags = append(encodedTags, key+"="+value)
+	}
+	return strings.Join(encodedTags, ", ")
+}
+
+func DecodeTags(encodedTags string) map[string]string {
+	tags := make(map[string]string)
+	for _, tag := range strings.Split(encodedTags, ",") {
+		key, value, found := strings.Cut(strings.TrimSpace(tag), "=")
+		if found && key != "" {
+			tags[key] = value
+		}
+	}
+	return tags
 }
 
 type Domain struct {
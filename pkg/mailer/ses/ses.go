@@ -3,6 +3,7 @@ package ses
 import (
 	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"net"
 	"net/http"
@@ -14,13 +15,20 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/sesv2"
 	"github.com/aws/aws-sdk-go-v2/service/sesv2/types"
 	"github.com/bloom42/stdx-go/email"
+	"github.com/bloom42/stdx-go/memorycache"
 	"markdown.ninja/pkg/mailer"
 )
 
 // SesMailer implements the `Mailer` interface to send emails using AWS' SES
 // https://pkg.go.dev/github.com/aws/aws-sdk-go-v2/service/sesv2
 type SesMailer struct {
-	sesClient *sesv2.Client
+	sesClient             *sesv2.Client
+	configurationSetName  *string
+	notificationsTopicArn string
+	httpClient            *http.Client
+	snsCertificatesCache  *memorycache.Cache[string, *x509.Certificate]
+	// fetchCertificate can be replaced in tests
+	fetchCertificate func(ctx context.Context, url string) (*x509.Certificate, error)
 }
 
 type Config struct {
@@ -28,6 +36,11 @@ type Config struct {
 	SecretAccessKey string
 	Region          string
 	HttpClient      *http.Client
+	// ConfigurationSetName is the configuration set used to publish bounces and complaints (with the tags
+	// of the emails) to the SNS topic NotificationsTopicArn. Webhooks are rejected if NotificationsTopicArn
+	// is empty.
+	ConfigurationSetName  string
+	NotificationsTopicArn string
 }
 
 // ensure that sesMailer satisfies the Storage interface
@@ -78,12 +91,47 @@ func NewSesMailer(config Config) (*SesMailer, error) {
 
 	sesClient := sesv2.NewFromConfig(sesConfig)
 
-	return &SesMailer{
-		sesClient,
-	}, nil
+	var configurationSetName *string
+	if config.ConfigurationSetName != "" {
+		configurationSetName = aws.String(config.ConfigurationSetName)
+	}
+
+	sesMailer := &SesMailer{
+		sesClient:             sesClient,
+		configurationSetName:  configurationSetName,
+		notificationsTopicArn: config.NotificationsTopicArn,
+		httpClient: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+		snsCertificatesCache: memorycache.New(
+			memorycache.WithTTL[string, *x509.Certificate](24*time.Hour),
+			memorycache.WithCapacity[string, *x509.Certificate](100),
+		),
+	}
+	sesMailer.fetchCertificate = sesMailer.fetchSnsCertificate
+
+	return sesMailer, nil
 }
 
 func (sesMailer *SesMailer) SendTransactionnal(ctx context.Context, email email.Email) error {
+	var emailTags []types.MessageTag
+	if tags := email.Headers[mailer.HeaderTags]; len(tags) != 0 {
+		for key, value := range mailer.DecodeTags(tags[0]) {
+			emailTags = append(emailTags, types.MessageTag{
+				Name:  aws.String(key),
+				Value: aws.String(value),
+			})
+		}
+
+		headers := make(map[string][]string, len(email.Headers))
+		for key, value := range email.Headers {
+			if key != mailer.HeaderTags {
+				headers[key] = value
+			}
+		}
+		email.Headers = headers
+	}
+
 	rawEmail, err := email.Bytes()
 	if err != nil {
 		return fmt.Errorf("ses: error getting raw email: %w", err)
@@ -94,6 +142,8 @@ func (sesMailer *SesMailer) SendTransactionnal(ctx context.Context, email email.
 				Data: rawEmail,
 			},
 		},
+		ConfigurationSetName: sesMailer.configurationSetName,
+		EmailTags:            emailTags,
 	}
 
 	_, err = sesMailer.sesClient.SendEmail(ctx, &request)
@@ -0,0 +1,308 @@
+package ses
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"markdown.ninja/pkg/mailer"
+)
+
+// SES sends bounce and complaint notifications through Amazon SNS.
+// See https://docs.aws.amazon.com/sns/latest/dg/sns-verify-signature-of-message.html
+// and https://docs.aws.amazon.com/ses/latest/dg/event-publishing-retrieving-sns-contents.html
+
+const (
+	snsMessageTypeNotification             = "Notification"
+	snsMessageTypeSubscriptionConfirmation = "SubscriptionConfirmation"
+	snsMessageTypeUnsubscribeConfirmation  = "UnsubscribeConfirmation"
+)
+
+var snsHostRegexp = regexp.MustCompile(`^sns\.[a-z0-9-]+\.amazonaws\.com(\.cn)?$`)
+
+type snsMessage struct {
+	Type             string `json:"Type"`
+	MessageId        string `json:"MessageId"`
+	Token            string `json:"Token"`
+	TopicArn         string `json:"TopicArn"`
+	Subject          string `json:"Subject"`
+	Message          string `json:"Message"`
+	Timestamp        string `json:"Timestamp"`
+	SignatureVersion string `json:"SignatureVersion"`
+	Signature        string `json:"Signature"`
+	SigningCertURL   string `json:"SigningCertURL"`
+	SubscribeURL     string `json:"SubscribeURL"`
+}
+
+// sesNotification supports both notifications (notificationType) and event publishing (eventType)
+type sesNotification struct {
+	NotificationType string `json:"notificationType"`
+	EventType        string `json:"eventType"`
+	Bounce           *struct {
+		BounceType        string         `json:"bounceType"`
+		BouncedRecipients []sesRecipient `json:"bouncedRecipients"`
+		Timestamp         time.Time      `json:"timestamp"`
+	} `json:"bounce"`
+	Complaint *struct {
+		ComplainedRecipients []sesRecipient `json:"complainedRecipients"`
+		Timestamp            time.Time      `json:"timestamp"`
+	} `json:"complaint"`
+	Mail struct {
+		Tags map[string][]string `json:"tags"`
+	} `json:"mail"`
+}
+
+type sesRecipient struct {
+	EmailAddress string `json:"emailAddress"`
+}
+
+func (sesMailer *SesMailer) ParseAndVerifyWebhook(ctx context.Context, headers http.Header, payload []byte) (events []mailer.WebhookEvent, err error) {
+	events = []mailer.WebhookEvent{}
+
+	if sesMailer.notificationsTopicArn == "" {
+		err = errors.New("ses: notifications topic ARN is not configured")
+		return
+	}
+
+	var message snsMessage
+	err = json.Unmarshal(payload, &message)
+	if err != nil {
+		err = fmt.Errorf("ses: error parsing SNS message: %w", err)
+		return
+	}
+
+	// anyone can send correctly signed messages from their own SNS topic so we need to check the topic
+	if message.TopicArn != sesMailer.notificationsTopicArn {
+		err = fmt.Errorf("ses: SNS topic is not valid: %s", message.TopicArn)
+		return
+	}
+
+	err = sesMailer.verifySnsMessageSignature(ctx, message)
+	if err != nil {
+		return
+	}
+
+	switch message.Type {
+	case snsMessageTypeSubscriptionConfirmation:
+		err = sesMailer.confirmSnsSubscription(ctx, message.SubscribeURL)
+		return
+	case snsMessageTypeUnsubscribeConfirmation:
+		return
+	case snsMessageTypeNotification:
+	default:
+		err = fmt.Errorf("ses: unknown SNS message type: %s", message.Type)
+		return
+	}
+
+	var notification sesNotification
+	err = json.Unmarshal([]byte(message.Message), &notification)
+	if err != nil {
+		err = fmt.Errorf("ses: error parsing SES notification: %w", err)
+		return
+	}
+
+	tags := make(map[string]string, len(notification.Mail.Tags))
+	for key, values := range notification.Mail.Tags {
+		if len(values) != 0 {
+			tags[key] = values[0]
+		}
+	}
+
+	notificationType := notification.NotificationType
+	if notificationType == "" {
+		notificationType = notification.EventType
+	}
+
+	switch {
+	case notificationType == "Bounce" && notification.Bounce != nil:
+		event := mailer.WebhookEvent{
+			Type:       mailer.WebhookEventTypeBounce,
+			Permanent:  notification.Bounce.BounceType == "Permanent",
+			Recipients: make([]string, 0, len(notification.Bounce.BouncedRecipients)),
+			Tags:       tags,
+			Timestamp:  notification.Bounce.Timestamp,
+		}
+		for _, recipient := range notification.Bounce.BouncedRecipients {
+			event.Recipients = append(event.Recipients, recipient.EmailAddress)
+		}
+		events = append(events, event)
+	case notificationType == "Complaint" && notification.Complaint != nil:
+		event := mailer.WebhookEvent{
+			Type:       mailer.WebhookEventTypeComplaint,
+			Permanent:  false,
+			Recipients: make([]string, 0, len(notification.Complaint.ComplainedRecipients)),
+			Tags:       tags,
+			Timestamp:  notification.Complaint.Timestamp,
+		}
+		for _, recipient := range notification.Complaint.ComplainedRecipients {
+			event.Recipients = append(event.Recipients, recipient.EmailAddress)
+		}
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+func (sesMailer *SesMailer) verifySnsMessageSignature(ctx context.Context, message snsMessage) (err error) {
+	var hashFunction crypto.Hash
+	switch message.SignatureVersion {
+	case "1":
+		hashFunction = crypto.SHA1
+	case "2":
+		hashFunction = crypto.SHA256
+	default:
+		return fmt.Errorf("ses: SNS signature version is not valid: %s", message.SignatureVersion)
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(message.Signature)
+	if err != nil {
+		return errors.New("ses: SNS signature is not valid base64")
+	}
+
+	err = validateSnsUrl(message.SigningCertURL)
+	if err != nil {
+		return err
+	}
+
+	certificate, err := sesMailer.fetchCertificate(ctx, message.SigningCertURL)
+	if err != nil {
+		return err
+	}
+
+	publicKey, ok := certificate.PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return errors.New("ses: SNS certificate public key is not a RSA key")
+	}
+
+	stringToSign := snsStringToSign(message)
+	var digest []byte
+	if hashFunction == crypto.SHA1 {
+		hash := sha1.Sum([]byte(stringToSign))
+		digest = hash[:]
+	} else {
+		hash := sha256.Sum256([]byte(stringToSign))
+		digest = hash[:]
+	}
+
+	err = rsa.VerifyPKCS1v15(publicKey, hashFunction, digest, signature)
+	if err != nil {
+		return fmt.Errorf("ses: SNS signature is not valid: %w", err)
+	}
+
+	return nil
+}
+
+func snsStringToSign(message snsMessage) string {
+	var builder strings.Builder
+	add := func(key, value string) {
+		builder.WriteString(key)
+		builder.WriteString("\n")
+		builder.WriteString(value)
+		builder.WriteString("\n")
+	}
+
+	add("Message", message.Message)
+	add("MessageId", message.MessageId)
+	if message.Type == snsMessageTypeNotification {
+		if message.Subject != "" {
+			add("Subject", message.Subject)
+		}
+		add("Timestamp", message.Timestamp)
+		add("TopicArn", message.TopicArn)
+		add("Type", message.Type)
+	} else {
+		add("SubscribeURL", message.SubscribeURL)
+		add("Timestamp", message.Timestamp)
+		add("Token", message.Token)
+		add("TopicArn", message.TopicArn)
+		add("Type", message.Type)
+	}
+
+	return builder.String()
+}
+
+func validateSnsUrl(rawUrl string) error {
+	parsedUrl, err := url.Parse(rawUrl)
+	if err != nil || parsedUrl.Scheme != "https" || !snsHostRegexp.MatchString(parsedUrl.Host) {
+		return fmt.Errorf("ses: SNS URL is not valid: %s", rawUrl)
+	}
+	return nil
+}
+
+func (sesMailer *SesMailer) fetchSnsCertificate(ctx context.Context, certificateUrl string) (certificate *x509.Certificate, err error) {
+	if cached := sesMailer.snsCertificatesCache.Get(certificateUrl); cached != nil {
+		return cached.Value(), nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, certificateUrl, nil)
+	if err != nil {
+		return nil, fmt.Errorf("ses: error creating SNS certificate request: %w", err)
+	}
+
+	res, err := sesMailer.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("ses: error fetching SNS certificate: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("ses: error fetching SNS certificate: status %d", res.StatusCode)
+	}
+
+	certificatePem, err := io.ReadAll(io.LimitReader(res.Body, 100_000))
+	if err != nil {
+		return nil, fmt.Errorf("ses: error reading SNS certificate: %w", err)
+	}
+
+	block, _ := pem.Decode(certificatePem)
+	if block == nil {
+		return nil, errors.New("ses: SNS certificate is not valid PEM")
+	}
+
+	certificate, err = x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("ses: error parsing SNS certificate: %w", err)
+	}
+
+	sesMailer.snsCertificatesCache.Set(certificateUrl, certificate, 24*time.Hour)
+
+	return certificate, nil
+}
+
+func (sesMailer *SesMailer) confirmSnsSubscription(ctx context.Context, subscribeUrl string) (err error) {
+	err = validateSnsUrl(subscribeUrl)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, subscribeUrl, nil)
+	if err != nil {
+		return fmt.Errorf("ses: error creating SNS subscription confirmation request: %w", err)
+	}
+
+	res, err := sesMailer.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("ses: error confirming SNS subscription: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("ses: error confirming SNS subscription: status %d", res.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,148 @@
+package ses
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"testing"
+	"time"
+
+	"markdown.ninja/pkg/mailer"
+)
+
+const testTopicArn = "arn:aws:sns:eu-central-1:123456789012:ses-notifications"
+
+func newTestMailer(t *testing.T) (*SesMailer, *rsa.PrivateKey) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generating RSA key: %s", err)
+	}
+
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "sns.amazonaws.com"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	certificateDer, err := x509.CreateCertificate(rand.Reader, &template, &template, &privateKey.PublicKey, privateKey)
+	if err != nil {
+		t.Fatalf("creating certificate: %s", err)
+	}
+	certificate, err := x509.ParseCertificate(certificateDer)
+	if err != nil {
+		t.Fatalf("parsing certificate: %s", err)
+	}
+
+	sesMailer := &SesMailer{
+		notificationsTopicArn: testTopicArn,
+		fetchCertificate: func(ctx context.Context, url string) (*x509.Certificate, error) {
+			return certificate, nil
+		},
+	}
+	return sesMailer, privateKey
+}
+
+func signTestMessage(t *testing.T, privateKey *rsa.PrivateKey, message snsMessage) []byte {
+	message.SignatureVersion = "2"
+	message.SigningCertURL = "https://sns.eu-central-1.amazonaws.com/SimpleNotificationService-0000.pem"
+	digest := sha256.Sum256([]byte(snsStringToSign(message)))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, privateKey, crypto.SHA256, digest[:])
+	if err != nil {
+		t.Fatalf("signing message: %s", err)
+	}
+	message.Signature = base64.StdEncoding.EncodeToString(signature)
+
+	payload, err := json.Marshal(message)
+	if err != nil {
+		t.Fatalf("encoding message: %s", err)
+	}
+	return payload
+}
+
+func TestParseAndVerifyWebhookBounce(t *testing.T) {
+	sesMailer, privateKey := newTestMailer(t)
+
+	notification := `{"eventType":"Bounce","bounce":{"bounceType":"Permanent","bouncedRecipients":[{"emailAddress":"bounce@example.com"}],"timestamp":"2025-01-01T00:00:00Z"},"mail":{"tags":{"newsletter_id":["0193f6d4-0000-7000-8000-000000000000"]}}}`
+	payload := signTestMessage(t, privateKey, snsMessage{
+		Type:      snsMessageTypeNotification,
+		MessageId: "1",
+		TopicArn:  testTopicArn,
+		Message:   notification,
+		Timestamp: "2025-01-01T00:00:00.000Z",
+	})
+
+	events, err := sesMailer.ParseAndVerifyWebhook(context.Background(), nil, payload)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(events) != 1 {
+		t.Fatalf("expected 1 event, got: %d", len(events))
+	}
+	event := events[0]
+	if event.Type != mailer.WebhookEventTypeBounce || !event.Permanent {
+		t.Errorf("unexpected event: %+v", event)
+	}
+	if len(event.Recipients) != 1 || event.Recipients[0] != "bounce@example.com" {
+		t.Errorf("unexpected recipients: %v", event.Recipients)
+	}
+	if event.Tags[mailer.TagNewsletterID] != "0193f6d4-0000-7000-8000-000000000000" {
+		t.Errorf("unexpected tags: %v", event.Tags)
+	}
+}
+
+func TestParseAndVerifyWebhookRejectsForgedMessages(t *testing.T) {
+	sesMailer, privateKey := newTestMailer(t)
+
+	message := snsMessage{
+		Type:      snsMessageTypeNotification,
+		MessageId: "1",
+		TopicArn:  testTopicArn,
+		Message:   `{"notificationType":"Complaint","complaint":{"complainedRecipients":[{"emailAddress":"a@example.com"}]}}`,
+		Timestamp: "2025-01-01T00:00:00.000Z",
+	}
+
+	var tampered snsMessage
+	json.Unmarshal(signTestMessage(t, privateKey, message), &tampered)
+	tampered.Message = `{"notificationType":"Complaint","complaint":{"complainedRecipients":[{"emailAddress":"b@example.com"}]}}`
+	tamperedPayload, _ := json.Marshal(tampered)
+	_, err := sesMailer.ParseAndVerifyWebhook(context.Background(), nil, tamperedPayload)
+	if err == nil {
+		t.Error("tampered message should be rejected")
+	}
+
+	message.TopicArn = "arn:aws:sns:eu-central-1:999999999999:attacker"
+	_, err = sesMailer.ParseAndVerifyWebhook(context.Background(), nil, signTestMessage(t, privateKey, message))
+	if err == nil {
+		t.Error("message from another topic should be rejected")
+	}
+}
+
+func TestValidateSnsUrl(t *testing.T) {
+	valid := []string{
+		"https://sns.us-east-1.amazonaws.com/SimpleNotificationService-1234.pem",
+		"https://sns.cn-north-1.amazonaws.com.cn/SimpleNotificationService-1234.pem",
+	}
+	invalid := []string{
+		"http://sns.us-east-1.amazonaws.com/SimpleNotificationService-1234.pem",
+		"https://sns.us-east-1.amazonaws.com.attacker.com/cert.pem",
+		"https://attacker.com/sns.us-east-1.amazonaws.com/cert.pem",
+	}
+
+	for _, rawUrl := range valid {
+		if err := validateSnsUrl(rawUrl); err != nil {
+			t.Errorf("%s should be valid", rawUrl)
+		}
+	}
+	for _, rawUrl := range invalid {
+		if err := validateSnsUrl(rawUrl); err == nil {
+			t.Errorf("%s should not be valid", rawUrl)
+		}
+	}
+}
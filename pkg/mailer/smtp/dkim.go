@@ -0,0 +1,323 @@
+package smtp
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bloom42/stdx-go/memorycache"
+	"markdown.ninja/pkg/mailer"
+)
+
+const dkimKeySize = 2048
+
+// dkimSignedHeaders are the headers that are signed when they are present in the email
+var dkimSignedHeaders = []string{
+	"From",
+	"Reply-To",
+	"Subject",
+	"Date",
+	"To",
+	"Cc",
+	"Message-Id",
+	"Mime-Version",
+	"Content-Type",
+	"List-Unsubscribe",
+	"List-Unsubscribe-Post",
+}
+
+// AddDomain generates a DKIM key for the domain (or reuses the existing one) and returns the DNS record
+// that needs to be published for emails to be signed and verified.
+// Domains are case-insensitive and stored in lowercase.
+func (smtpMailer *SmtpMailer) AddDomain(ctx context.Context, domain string) (ret mailer.Domain, err error) {
+	domain = strings.ToLower(domain)
+	privateKey, err := smtpMailer.loadDkimKey(ctx, domain)
+	if err != nil {
+		return ret, err
+	}
+
+	if privateKey == nil {
+		privateKey, err = rsa.GenerateKey(rand.Reader, dkimKeySize)
+		if err != nil {
+			return ret, fmt.Errorf("smtp: error generating DKIM key: %w", err)
+		}
+
+		privateKeyDer, err := x509.MarshalPKCS8PrivateKey(privateKey)
+		if err != nil {
+			return ret, fmt.Errorf("smtp: error encoding DKIM key: %w", err)
+		}
+		privateKeyPem := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateKeyDer})
+
+		err = smtpMailer.dkimKeysStore.Put(ctx, domain, privateKeyPem)
+		if err != nil {
+			return ret, err
+		}
+
+		smtpMailer.dkimKeysCache.Set(domain, privateKey, memorycache.DefaultTTL)
+	}
+
+	dkimRecordValue, err := dkimDnsRecordValue(&privateKey.PublicKey)
+	if err != nil {
+		return ret, err
+	}
+
+	ret = mailer.Domain{
+		Domain: domain,
+		DnsRecords: []mailer.DnsRecord{
+			{
+				Host: smtpMailer.dkimDnsRecordHost(domain),
+				Type: "TXT",
+				Val:  dkimRecordValue,
+			},
+		},
+	}
+
+	return ret, nil
+}
+
+func (smtpMailer *SmtpMailer) RemoveDomain(ctx context.Context, domain string) error {
+	domain = strings.ToLower(domain)
+	if !isValidDomain(domain) {
+		return fmt.Errorf("smtp: domain is not valid: %s", domain)
+	}
+
+	smtpMailer.dkimKeysCache.Delete(domain)
+
+	return smtpMailer.dkimKeysStore.Delete(ctx, domain)
+}
+
+// VerifyDomain checks that the DKIM DNS record of the domain contains the public key of the domain
+func (smtpMailer *SmtpMailer) VerifyDomain(ctx context.Context, domain string) (verified bool, err error) {
+	domain = strings.ToLower(domain)
+	privateKey, err := smtpMailer.loadDkimKey(ctx, domain)
+	if err != nil {
+		return false, err
+	}
+	if privateKey == nil {
+		return false, nil
+	}
+
+	expectedPublicKey, err := dkimEncodePublicKey(&privateKey.PublicKey)
+	if err != nil {
+		return false, err
+	}
+
+	records, err := smtpMailer.dnsResolver.LookupTXT(ctx, smtpMailer.dkimDnsRecordHost(domain))
+	if err != nil {
+		// the record doesn't exist (yet)
+		return false, nil
+	}
+
+	for _, record := range records {
+		tags := parseDkimTags(record)
+		publicKey := strings.Join(strings.Fields(tags["p"]), "")
+		if (tags["k"] == "" || tags["k"] == "rsa") && publicKey == expectedPublicKey {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (smtpMailer *SmtpMailer) dkimDnsRecordHost(domain string) string {
+	return smtpMailer.dkimSelector + "._domainkey." + domain
+}
+
+// loadDkimKey returns the DKIM private key of the domain, or nil if the domain has no key.
+// domain must be lowercase.
+func (smtpMailer *SmtpMailer) loadDkimKey(ctx context.Context, domain string) (*rsa.PrivateKey, error) {
+	if !isValidDomain(domain) {
+		return nil, fmt.Errorf("smtp: domain is not valid: %s", domain)
+	}
+
+	if cachedKey := smtpMailer.dkimKeysCache.Get(domain); cachedKey != nil {
+		return cachedKey.Value(), nil
+	}
+
+	privateKeyPem, err := smtpMailer.dkimKeysStore.Get(ctx, domain)
+	if err != nil {
+		if errors.Is(err, ErrDkimKeyNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	pemBlock, _ := pem.Decode(privateKeyPem)
+	if pemBlock == nil {
+		return nil, fmt.Errorf("smtp: DKIM key for %s is not valid PEM", domain)
+	}
+	key, err := x509.ParsePKCS8PrivateKey(pemBlock.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("smtp: error parsing DKIM key for %s: %w", domain, err)
+	}
+	privateKey, isRsa := key.(*rsa.PrivateKey)
+	if !isRsa {
+		return nil, fmt.Errorf("smtp: DKIM key for %s is not a RSA key", domain)
+	}
+
+	smtpMailer.dkimKeysCache.Set(domain, privateKey, memorycache.DefaultTTL)
+
+	return privateKey, nil
+}
+
+// isValidDomain performs basic sanity checks on the domain before it is used as a key in the DKIM keys store
+func isValidDomain(domain string) bool {
+	return domain != "" && len(domain) <= 253 && !strings.ContainsAny(domain, "/\\") &&
+		!strings.HasPrefix(domain, ".")
+}
+
+func dkimEncodePublicKey(publicKey *rsa.PublicKey) (string, error) {
+	publicKeyDer, err := x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		return "", fmt.Errorf("smtp: error encoding DKIM public key: %w", err)
+	}
+	return base64.StdEncoding.EncodeToString(publicKeyDer), nil
+}
+
+func dkimDnsRecordValue(publicKey *rsa.PublicKey) (string, error) {
+	encodedPublicKey, err := dkimEncodePublicKey(publicKey)
+	if err != nil {
+		return "", err
+	}
+	return "v=DKIM1; k=rsa; p=" + encodedPublicKey, nil
+}
+
+// parseDkimTags parses a tag list (RFC 6376 section 3.2) such as "v=DKIM1; k=rsa; p=..."
+func parseDkimTags(value string) map[string]string {
+	tags := make(map[string]string)
+	for _, tag := range strings.Split(value, ";") {
+		key, value, found := strings.Cut(tag, "=")
+		if found {
+			tags[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+	}
+	return tags
+}
+
+// dkimSign signs the message with rsa-sha256 and the relaxed/relaxed canonicalization and returns
+// the message with the DKIM-Signature header prepended.
+// See RFC 6376 https://www.rfc-editor.org/rfc/rfc6376
+func dkimSign(message []byte, domain, selector string, privateKey *rsa.PrivateKey, now time.Time) ([]byte, error) {
+	headerSection, body, found := bytes.Cut(message, []byte("\r\n\r\n"))
+	if !found {
+		return nil, errors.New("message has no body")
+	}
+	headers := parseHeaders(headerSection)
+
+	bodyHash := sha256.Sum256(dkimCanonicalizeBodyRelaxed(body))
+
+	signedHeaderNames := make([]string, 0, len(dkimSignedHeaders))
+	signedHeaders := make([]string, 0, len(dkimSignedHeaders))
+	for _, name := range dkimSignedHeaders {
+		// when a header is present multiple times, the last instance is signed
+		for i := len(headers) - 1; i >= 0; i -= 1 {
+			if strings.EqualFold(headers[i].name, name) {
+				signedHeaderNames = append(signedHeaderNames, strings.ToLower(name))
+				signedHeaders = append(signedHeaders, dkimCanonicalizeHeaderRelaxed(headers[i].name, headers[i].value))
+				break
+			}
+		}
+	}
+
+	signatureValue := "v=1; a=rsa-sha256; c=relaxed/relaxed; d=" + domain + "; s=" + selector +
+		"; t=" + strconv.FormatInt(now.Unix(), 10) + "; h=" + strings.Join(signedHeaderNames, ":") +
+		"; bh=" + base64.StdEncoding.EncodeToString(bodyHash[:]) + "; b="
+
+	hash := sha256.New()
+	for _, header := range signedHeaders {
+		hash.Write([]byte(header))
+		hash.Write([]byte("\r\n"))
+	}
+	// the DKIM-Signature header itself is signed with an empty b= tag and without the trailing CRLF
+	hash.Write([]byte(dkimCanonicalizeHeaderRelaxed("DKIM-Signature", signatureValue)))
+
+	signature, err := rsa.SignPKCS1v15(rand.Reader, privateKey, crypto.SHA256, hash.Sum(nil))
+	if err != nil {
+		return nil, err
+	}
+
+	signedMessage := make([]byte, 0, len(message)+len(signatureValue)+512)
+	signedMessage = append(signedMessage, "DKIM-Signature: "...)
+	signedMessage = append(signedMessage, signatureValue...)
+	signedMessage = append(signedMessage, base64.StdEncoding.EncodeToString(signature)...)
+	signedMessage = append(signedMessage, "\r\n"...)
+	signedMessage = append(signedMessage, message...)
+
+	return signedMessage, nil
+}
+
+type header struct {
+	name string
+	// the raw value, which may contain folding whitespace
+	value string
+}
+
+func parseHeaders(headerSection []byte) []header {
+	headers := []header{}
+	for _, line := range strings.Split(string(headerSection), "\r\n") {
+		if len(line) != 0 && (line[0] == ' ' || line[0] == '\t') && len(headers) != 0 {
+			// continuation of a folded header
+			headers[len(headers)-1].value += "\r\n" + line
+			continue
+		}
+		name, value, found := strings.Cut(line, ":")
+		if found {
+			headers = append(headers, header{name: name, value: value})
+		}
+	}
+	return headers
+}
+
+// dkimCanonicalizeHeaderRelaxed implements the "relaxed" header canonicalization (RFC 6376 section 3.4.2)
+func dkimCanonicalizeHeaderRelaxed(name, value string) string {
+	value = strings.ReplaceAll(value, "\r\n", "")
+	return strings.ToLower(strings.TrimRight(name, " \t")) + ":" + strings.Trim(reduceWhitespace(value), " ")
+}
+
+// dkimCanonicalizeBodyRelaxed implements the "relaxed" body canonicalization (RFC 6376 section 3.4.4)
+func dkimCanonicalizeBodyRelaxed(body []byte) []byte {
+	lines := strings.Split(string(body), "\r\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(reduceWhitespace(line), " ")
+	}
+
+	// remove the empty lines at the end of the body
+	for len(lines) != 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	if len(lines) == 0 {
+		return []byte{}
+	}
+
+	return []byte(strings.Join(lines, "\r\n") + "\r\n")
+}
+
+// reduceWhitespace replaces all the sequences of whitespace (WSP: space and tab) by a single space
+func reduceWhitespace(input string) string {
+	var ret strings.Builder
+	ret.Grow(len(input))
+	previousIsWhitespace := false
+	for i := 0; i < len(input); i += 1 {
+		if input[i] == ' ' || input[i] == '\t' {
+			if !previousIsWhitespace {
+				ret.WriteByte(' ')
+			}
+			previousIsWhitespace = true
+			continue
+		}
+		ret.WriteByte(input[i])
+		previousIsWhitespace = false
+	}
+	return ret.String()
+}
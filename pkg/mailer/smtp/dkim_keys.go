@@ -0,0 +1,92 @@
+package smtp
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/bloom42/stdx-go/db"
+	"markdown.ninja/pkg/kms"
+)
+
+var ErrDkimKeyNotFound = errors.New("smtp: DKIM key not found")
+
+// DkimKeysStore stores the PEM-encoded DKIM private keys of the domains so that they are shared by all
+// the instances of the server.
+type DkimKeysStore interface {
+	// Get returns ErrDkimKeyNotFound if the domain has no DKIM key
+	Get(ctx context.Context, domain string) (privateKeyPem []byte, err error)
+	Put(ctx context.Context, domain string, privateKeyPem []byte) error
+	Delete(ctx context.Context, domain string) error
+}
+
+// DbDkimKeysStore stores the DKIM keys in the database, encrypted with the KMS.
+type DbDkimKeysStore struct {
+	db  db.DB
+	kms *kms.Kms
+}
+
+type dkimKey struct {
+	Domain              string    `db:"domain"`
+	CreatedAt           time.Time `db:"created_at"`
+	UpdatedAt           time.Time `db:"updated_at"`
+	EncryptedPrivateKey []byte    `db:"encrypted_private_key"`
+}
+
+// ensure that DbDkimKeysStore satisfies the DkimKeysStore interface
+var _ DkimKeysStore = (*DbDkimKeysStore)(nil)
+
+func NewDbDkimKeysStore(db db.DB, kms *kms.Kms) *DbDkimKeysStore {
+	return &DbDkimKeysStore{
+		db:  db,
+		kms: kms,
+	}
+}
+
+func (store *DbDkimKeysStore) Get(ctx context.Context, domain string) (privateKeyPem []byte, err error) {
+	var key dkimKey
+
+	err = store.db.Get(ctx, &key, "SELECT * FROM dkim_keys WHERE domain = $1", domain)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrDkimKeyNotFound
+		}
+		return nil, fmt.Errorf("smtp: error getting DKIM key from db [%s]: %w", domain, err)
+	}
+
+	privateKeyPem, err = store.kms.Decrypt(ctx, key.EncryptedPrivateKey, []byte(key.Domain))
+	if err != nil {
+		return nil, fmt.Errorf("smtp: error decrypting DKIM key [%s]: %w", domain, err)
+	}
+
+	return privateKeyPem, nil
+}
+
+func (store *DbDkimKeysStore) Put(ctx context.Context, domain string, privateKeyPem []byte) error {
+	const query = `INSERT INTO dkim_keys (domain, created_at, updated_at, encrypted_private_key)
+		VALUES ($1, $2, $2, $3)
+		ON CONFLICT (domain) DO UPDATE SET updated_at = $2, encrypted_private_key = $3`
+
+	encryptedPrivateKey, err := store.kms.Encrypt(ctx, privateKeyPem, []byte(domain))
+	if err != nil {
+		return fmt.Errorf("smtp: error encrypting DKIM key [%s]: %w", domain, err)
+	}
+
+	_, err = store.db.Exec(ctx, query, domain, time.Now().UTC(), encryptedPrivateKey)
+	if err != nil {
+		return fmt.Errorf("smtp: error inserting DKIM key in db [%s]: %w", domain, err)
+	}
+
+	return nil
+}
+
+func (store *DbDkimKeysStore) Delete(ctx context.Context, domain string) error {
+	_, err := store.db.Exec(ctx, "DELETE FROM dkim_keys WHERE domain = $1", domain)
+	if err != nil {
+		return fmt.Errorf("smtp: error deleting DKIM key [%s]: %w", domain, err)
+	}
+
+	return nil
+}
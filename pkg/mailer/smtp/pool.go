@@ -0,0 +1,209 @@
+package smtp
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+	"time"
+)
+
+const (
+	dialTimeout = 10 * time.Second
+	// sendTimeout is used when the context of a send has no deadline
+	sendTimeout = 60 * time.Second
+	// most SMTP servers close idle connections after a few minutes, so we don't try to reuse
+	// connections that have been idle for longer
+	maxIdleDuration = 30 * time.Second
+)
+
+type connection struct {
+	client   *smtp.Client
+	conn     net.Conn
+	lastUsed time.Time
+}
+
+// connectionPool limits the number of concurrent connections to the SMTP server and keeps idle
+// connections around to avoid the cost of the TCP + TLS + AUTH handshakes for each email.
+type connectionPool struct {
+	address   string
+	host      string
+	username  string
+	password  string
+	tlsMode   TlsMode
+	tlsConfig *tls.Config
+
+	// semaphore is used to limit the number of connections that are in use
+	semaphore       chan struct{}
+	idleConnections chan *connection
+}
+
+func newConnectionPool(config Config) *connectionPool {
+	return &connectionPool{
+		address:   net.JoinHostPort(config.Host, strconv.FormatUint(uint64(config.Port), 10)),
+		host:      config.Host,
+		username:  config.Username,
+		password:  config.Password,
+		tlsMode:   config.TlsMode,
+		tlsConfig: config.TlsConfig,
+
+		semaphore:       make(chan struct{}, config.PoolSize),
+		idleConnections: make(chan *connection, config.PoolSize),
+	}
+}
+
+// get returns an idle connection if a healthy one is available, or opens a new connection.
+// The connection MUST be returned to the pool with put or discard.
+func (pool *connectionPool) get(ctx context.Context) (*connection, error) {
+	select {
+	case pool.semaphore <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	for {
+		select {
+		case conn := <-pool.idleConnections:
+			if time.Since(conn.lastUsed) > maxIdleDuration {
+				conn.close()
+				continue
+			}
+			conn.setDeadline(ctx)
+			// the server may have closed the connection in the meantime
+			if err := conn.client.Reset(); err != nil {
+				conn.close()
+				continue
+			}
+			return conn, nil
+		default:
+			conn, err := pool.dial(ctx)
+			if err != nil {
+				<-pool.semaphore
+				return nil, err
+			}
+			return conn, nil
+		}
+	}
+}
+
+func (pool *connectionPool) put(conn *connection) {
+	conn.lastUsed = time.Now()
+	select {
+	case pool.idleConnections <- conn:
+	default:
+		conn.close()
+	}
+	<-pool.semaphore
+}
+
+func (pool *connectionPool) discard(conn *connection) {
+	conn.conn.Close()
+	<-pool.semaphore
+}
+
+func (pool *connectionPool) close() {
+	for {
+		select {
+		case conn := <-pool.idleConnections:
+			conn.close()
+		default:
+			return
+		}
+	}
+}
+
+func (pool *connectionPool) dial(ctx context.Context) (*connection, error) {
+	var conn net.Conn
+	var err error
+
+	dialer := &net.Dialer{Timeout: dialTimeout}
+	if pool.tlsMode == TlsModeImplicit {
+		tlsDialer := &tls.Dialer{NetDialer: dialer, Config: pool.tlsConfig}
+		conn, err = tlsDialer.DialContext(ctx, "tcp", pool.address)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", pool.address)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("smtp: error connecting to server: %w", err)
+	}
+
+	ret := &connection{conn: conn}
+	ret.setDeadline(ctx)
+
+	ret.client, err = smtp.NewClient(conn, pool.host)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("smtp: error creating client: %w", err)
+	}
+
+	if pool.tlsMode == TlsModeStartTls {
+		if ok, _ := ret.client.Extension("STARTTLS"); !ok {
+			ret.close()
+			return nil, errors.New("smtp: server doesn't support STARTTLS")
+		}
+		err = ret.client.StartTLS(pool.tlsConfig)
+		if err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("smtp: STARTTLS failed: %w", err)
+		}
+	}
+
+	if pool.username != "" {
+		if ok, _ := ret.client.Extension("AUTH"); !ok {
+			ret.close()
+			return nil, errors.New("smtp: server doesn't support AUTH")
+		}
+		// smtp.PlainAuth refuses to send credentials over unencrypted connections (except to localhost)
+		err = ret.client.Auth(smtp.PlainAuth("", pool.username, pool.password, pool.host))
+		if err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("smtp: authentication failed: %w", err)
+		}
+	}
+
+	return ret, nil
+}
+
+func (conn *connection) setDeadline(ctx context.Context) {
+	deadline, hasDeadline := ctx.Deadline()
+	if !hasDeadline {
+		deadline = time.Now().Add(sendTimeout)
+	}
+	conn.conn.SetDeadline(deadline)
+}
+
+func (conn *connection) send(ctx context.Context, from string, recipients []string, message []byte) (err error) {
+	conn.setDeadline(ctx)
+
+	err = conn.client.Mail(from)
+	if err != nil {
+		return err
+	}
+
+	for _, recipient := range recipients {
+		err = conn.client.Rcpt(recipient)
+		if err != nil {
+			return err
+		}
+	}
+
+	dataWriter, err := conn.client.Data()
+	if err != nil {
+		return err
+	}
+	_, err = dataWriter.Write(message)
+	if err != nil {
+		return err
+	}
+	return dataWriter.Close()
+}
+
+// close gracefully closes the connection with the QUIT command
+func (conn *connection) close() {
+	conn.conn.SetDeadline(time.Now().Add(5 * time.Second))
+	conn.client.Quit()
+	conn.conn.Close()
+}
@@ -0,0 +1,55 @@
+package smtp
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// rateLimiter is a token bucket that allows up to `rate` events per second, with bursts of up to
+// `rate` events.
+type rateLimiter struct {
+	mutex  sync.Mutex
+	rate   float64
+	tokens float64
+	last   time.Time
+}
+
+func newRateLimiter(ratePerSecond int) *rateLimiter {
+	return &rateLimiter{
+		rate:   float64(ratePerSecond),
+		tokens: float64(ratePerSecond),
+		last:   time.Now(),
+	}
+}
+
+// wait blocks until an event is allowed or ctx is canceled
+func (limiter *rateLimiter) wait(ctx context.Context) error {
+	limiter.mutex.Lock()
+	now := time.Now()
+	limiter.tokens = min(limiter.rate, limiter.tokens+now.Sub(limiter.last).Seconds()*limiter.rate)
+	limiter.last = now
+	// the token is reserved now, even if we have to wait for it to become available
+	limiter.tokens -= 1
+	delay := time.Duration(0)
+	if limiter.tokens < 0 {
+		delay = time.Duration(-limiter.tokens / limiter.rate * float64(time.Second))
+	}
+	limiter.mutex.Unlock()
+
+	if delay == 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		limiter.mutex.Lock()
+		limiter.tokens += 1
+		limiter.mutex.Unlock()
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,223 @@
+package smtp
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/bloom42/stdx-go/email"
+	"github.com/bloom42/stdx-go/memorycache"
+	"markdown.ninja/pkg/mailer"
+)
+
+type TlsMode string
+
+const (
+	// TlsModeStartTls connects in plaintext and then upgrades the connection with the STARTTLS command.
+	// Usually on port 587.
+	TlsModeStartTls TlsMode = "starttls"
+	// TlsModeImplicit connects directly with TLS. Usually on port 465.
+	TlsModeImplicit TlsMode = "tls"
+	// TlsModeNone should only be used with a local relay or in tests.
+	TlsModeNone TlsMode = "none"
+)
+
+const (
+	DefaultPoolSize     = 4
+	DefaultDkimSelector = "mdninja"
+)
+
+// SmtpMailer implements the `Mailer` interface to send emails with any SMTP server.
+// Emails are signed with DKIM when a key has been generated for the domain of the From address
+// with AddDomain.
+type SmtpMailer struct {
+	pool             *connectionPool
+	broadcastLimiter *rateLimiter
+	dkimKeysStore    DkimKeysStore
+	dkimSelector     string
+	dnsResolver      *net.Resolver
+
+	// in-memory cache used to avoid loading and decrypting the DKIM key of a domain for each email
+	dkimKeysCache *memorycache.Cache[string, *rsa.PrivateKey]
+}
+
+type Config struct {
+	Host     string
+	Port     uint16
+	Username string
+	Password string
+	// Defaults to TlsModeStartTls
+	TlsMode TlsMode
+	// TlsConfig is optional and can be used to customize the TLS configuration (e.g. in tests)
+	TlsConfig *tls.Config
+	// PoolSize is the maximum number of concurrent connections to the SMTP server.
+	// Defaults to DefaultPoolSize
+	PoolSize int
+	// RateLimit is the maximum number of broadcast emails sent per second. 0 disables rate limiting.
+	RateLimit int
+	// DkimKeysStore is where the DKIM private keys of the domains are stored
+	DkimKeysStore DkimKeysStore
+	// Defaults to DefaultDkimSelector
+	DkimSelector string
+	// DnsResolver is used to verify domains. Defaults to net.DefaultResolver
+	DnsResolver *net.Resolver
+}
+
+// ensure that SmtpMailer satisfies the Mailer interface
+var _ mailer.Mailer = (*SmtpMailer)(nil)
+
+func NewSmtpMailer(config Config) (*SmtpMailer, error) {
+	if config.Host == "" {
+		return nil, errors.New("smtp: host is empty")
+	}
+	if config.Port == 0 {
+		return nil, errors.New("smtp: port is empty")
+	}
+
+	if config.TlsMode == "" {
+		config.TlsMode = TlsModeStartTls
+	}
+	if config.TlsMode != TlsModeStartTls && config.TlsMode != TlsModeImplicit && config.TlsMode != TlsModeNone {
+		return nil, fmt.Errorf("smtp: %s is not a valid TLS mode. Valid values are: [%s, %s, %s]",
+			config.TlsMode, TlsModeStartTls, TlsModeImplicit, TlsModeNone)
+	}
+
+	if config.TlsConfig == nil {
+		config.TlsConfig = &tls.Config{
+			ServerName: config.Host,
+			MinVersion: tls.VersionTLS12,
+		}
+	}
+
+	if config.PoolSize <= 0 {
+		config.PoolSize = DefaultPoolSize
+	}
+
+	if config.DkimKeysStore == nil {
+		return nil, errors.New("smtp: DKIM keys store is nil")
+	}
+	if config.DkimSelector == "" {
+		config.DkimSelector = DefaultDkimSelector
+	}
+
+	if config.DnsResolver == nil {
+		config.DnsResolver = net.DefaultResolver
+	}
+
+	smtpMailer := &SmtpMailer{
+		pool:          newConnectionPool(config),
+		dkimKeysStore: config.DkimKeysStore,
+		dkimSelector:  config.DkimSelector,
+		dnsResolver:   config.DnsResolver,
+
+		dkimKeysCache: memorycache.New(
+			memorycache.WithCapacity[string, *rsa.PrivateKey](1_000),
+			memorycache.WithTTL[string, *rsa.PrivateKey](1*time.Hour),
+		),
+	}
+	if config.RateLimit > 0 {
+		smtpMailer.broadcastLimiter = newRateLimiter(config.RateLimit)
+	}
+
+	return smtpMailer, nil
+}
+
+// Close closes all the idle connections to the SMTP server
+func (smtpMailer *SmtpMailer) Close() {
+	smtpMailer.pool.close()
+}
+
+func (smtpMailer *SmtpMailer) SendTransactionnal(ctx context.Context, email email.Email) error {
+	if len(email.HTML) == 0 && len(email.Text) == 0 {
+		return errors.New("smtp: either HTML or Text must be provided")
+	}
+
+	recipients := make([]string, 0, len(email.To)+len(email.Cc)+len(email.Bcc))
+	for _, addresses := range [][]mail.Address{email.To, email.Cc, email.Bcc} {
+		for _, address := range addresses {
+			recipients = append(recipients, address.Address)
+		}
+	}
+	if len(recipients) == 0 {
+		return errors.New("smtp: at least one recipient is required")
+	}
+
+	// tags are only used by providers that send webhooks
+	if _, hasTags := email.Headers[mailer.HeaderTags]; hasTags {
+		headers := make(map[string][]string, len(email.Headers))
+		for key, value := range email.Headers {
+			if key != mailer.HeaderTags {
+				headers[key] = value
+			}
+		}
+		email.Headers = headers
+	}
+
+	message, err := email.Bytes()
+	if err != nil {
+		return fmt.Errorf("smtp: error getting raw email: %w", err)
+	}
+
+	_, fromDomain, _ := strings.Cut(email.From.Address, "@")
+	fromDomain = strings.ToLower(fromDomain)
+	dkimKey, err := smtpMailer.loadDkimKey(ctx, fromDomain)
+	if err != nil {
+		return err
+	}
+	if dkimKey != nil {
+		message, err = dkimSign(message, fromDomain, smtpMailer.dkimSelector, dkimKey, time.Now())
+		if err != nil {
+			return fmt.Errorf("smtp: error signing email: %w", err)
+		}
+	}
+
+	conn, err := smtpMailer.pool.get(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = conn.send(ctx, email.From.Address, recipients, message)
+	if err != nil {
+		// the state of the connection is unknown after an error, so we don't reuse it
+		smtpMailer.pool.discard(conn)
+		return fmt.Errorf("smtp: error sending email: %w", err)
+	}
+	smtpMailer.pool.put(conn)
+
+	return nil
+}
+
+// SendBroadcast is rate limited to not get flagged as spam by the SMTP server or the recipients' providers
+func (smtpMailer *SmtpMailer) SendBroadcast(ctx context.Context, email email.Email) error {
+	if smtpMailer.broadcastLimiter != nil {
+		err := smtpMailer.broadcastLimiter.wait(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	return smtpMailer.SendTransactionnal(ctx, email)
+}
+
+// GetSuppressions always returns an empty list: SMTP servers don't provide a standard way to list
+// suppressed recipients.
+func (smtpMailer *SmtpMailer) GetSuppressions(ctx context.Context) (suppressions []mailer.Suppression, err error) {
+	return []mailer.Suppression{}, nil
+}
+
+func (smtpMailer *SmtpMailer) DeleteSuppression(ctx context.Context, email string) (err error) {
+	return nil
+}
+
+// ParseAndVerifyWebhook always returns an error: SMTP servers don't send webhooks for bounces and
+// complaints.
+func (smtpMailer *SmtpMailer) ParseAndVerifyWebhook(ctx context.Context, headers http.Header, payload []byte) (events []mailer.WebhookEvent, err error) {
+	return nil, errors.New("smtp: webhooks are not supported")
+}
@@ -0,0 +1,378 @@
+package smtp
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bloom42/stdx-go/email"
+	"markdown.ninja/pkg/mailer"
+)
+
+type sinkMessage struct {
+	from       string
+	recipients []string
+	data       []byte
+}
+
+// smtpSink is a minimal local SMTP server that accepts all the emails
+type smtpSink struct {
+	listener net.Listener
+
+	mutex       sync.Mutex
+	messages    []sinkMessage
+	connections int
+}
+
+func newSmtpSink(t *testing.T) *smtpSink {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("starting SMTP sink: %s", err)
+	}
+	sink := &smtpSink{listener: listener}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			sink.mutex.Lock()
+			sink.connections += 1
+			sink.mutex.Unlock()
+			go sink.handle(conn)
+		}
+	}()
+
+	return sink
+}
+
+func (sink *smtpSink) port() uint16 {
+	return uint16(sink.listener.Addr().(*net.TCPAddr).Port)
+}
+
+func (sink *smtpSink) handle(conn net.Conn) {
+	defer conn.Close()
+	text := textproto.NewConn(conn)
+	var message sinkMessage
+
+	text.PrintfLine("220 sink ESMTP")
+	for {
+		line, err := text.ReadLine()
+		if err != nil {
+			return
+		}
+		command, argument, _ := strings.Cut(line, " ")
+		switch strings.ToUpper(command) {
+		case "EHLO", "HELO":
+			text.PrintfLine("250-sink\r\n250-AUTH PLAIN\r\n250 8BITMIME")
+		case "AUTH":
+			text.PrintfLine("235 authenticated")
+		case "MAIL":
+			// MAIL FROM:<address> [parameters]
+			from, _, _ := strings.Cut(strings.TrimPrefix(argument, "FROM:<"), ">")
+			message = sinkMessage{from: from}
+			text.PrintfLine("250 ok")
+		case "RCPT":
+			message.recipients = append(message.recipients, strings.Trim(strings.TrimPrefix(argument, "TO:"), "<>"))
+			text.PrintfLine("250 ok")
+		case "DATA":
+			text.PrintfLine("354 go ahead")
+			data, err := text.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			// the dot reader converts line endings to "\n"
+			message.data = bytes.ReplaceAll(data, []byte("\n"), []byte("\r\n"))
+			sink.mutex.Lock()
+			sink.messages = append(sink.messages, message)
+			sink.mutex.Unlock()
+			text.PrintfLine("250 queued")
+		case "RSET", "NOOP":
+			text.PrintfLine("250 ok")
+		case "QUIT":
+			text.PrintfLine("221 bye")
+			return
+		default:
+			text.PrintfLine("502 unknown command")
+		}
+	}
+}
+
+// memoryDkimKeysStore is an in-memory DkimKeysStore
+type memoryDkimKeysStore struct {
+	mutex sync.Mutex
+	keys  map[string][]byte
+}
+
+func newMemoryDkimKeysStore() *memoryDkimKeysStore {
+	return &memoryDkimKeysStore{keys: make(map[string][]byte)}
+}
+
+func (store *memoryDkimKeysStore) Get(ctx context.Context, domain string) ([]byte, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	privateKeyPem, exists := store.keys[domain]
+	if !exists {
+		return nil, ErrDkimKeyNotFound
+	}
+	return privateKeyPem, nil
+}
+
+func (store *memoryDkimKeysStore) Put(ctx context.Context, domain string, privateKeyPem []byte) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	store.keys[domain] = privateKeyPem
+	return nil
+}
+
+func (store *memoryDkimKeysStore) Delete(ctx context.Context, domain string) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	delete(store.keys, domain)
+	return nil
+}
+
+func newTestMailer(t *testing.T, sink *smtpSink) *SmtpMailer {
+	smtpMailer, err := NewSmtpMailer(Config{
+		Host:          "127.0.0.1",
+		Port:          sink.port(),
+		Username:      "user",
+		Password:      "password",
+		TlsMode:       TlsModeNone,
+		PoolSize:      2,
+		DkimKeysStore: newMemoryDkimKeysStore(),
+	})
+	if err != nil {
+		t.Fatalf("creating mailer: %s", err)
+	}
+	t.Cleanup(smtpMailer.Close)
+	return smtpMailer
+}
+
+func newTestEmail() email.Email {
+	return email.Email{
+		From:    mail.Address{Name: "Markdown Ninja", Address: "hello@example.com"},
+		To:      []mail.Address{{Address: "to@example.org"}},
+		Bcc:     []mail.Address{{Address: "bcc@example.org"}},
+		Subject: "Hello   World",
+		HTML:    []byte("<p>Hello World</p>  \n\n"),
+		Text:    []byte("Hello World"),
+		Headers: textproto.MIMEHeader{
+			mailer.HeaderTags: []string{mailer.EncodeTags(map[string]string{mailer.TagWebsiteID: "123"})},
+		},
+	}
+}
+
+func TestSendTransactionnalDkim(t *testing.T) {
+	sink := newSmtpSink(t)
+	smtpMailer := newTestMailer(t, sink)
+	ctx := context.Background()
+
+	domain, err := smtpMailer.AddDomain(ctx, "example.com")
+	if err != nil {
+		t.Fatalf("adding domain: %s", err)
+	}
+	if len(domain.DnsRecords) != 1 || domain.DnsRecords[0].Host != "mdninja._domainkey.example.com" ||
+		domain.DnsRecords[0].Type != "TXT" {
+		t.Fatalf("unexpected DNS records: %#v", domain.DnsRecords)
+	}
+
+	err = smtpMailer.SendTransactionnal(ctx, newTestEmail())
+	if err != nil {
+		t.Fatalf("sending email: %s", err)
+	}
+
+	if len(sink.messages) != 1 {
+		t.Fatalf("expected 1 message, got %d", len(sink.messages))
+	}
+	message := sink.messages[0]
+	if message.from != "hello@example.com" {
+		t.Errorf("unexpected sender: %s", message.from)
+	}
+	if strings.Join(message.recipients, ",") != "to@example.org,bcc@example.org" {
+		t.Errorf("unexpected recipients: %v", message.recipients)
+	}
+	if bytes.Contains(message.data, []byte(mailer.HeaderTags)) {
+		t.Errorf("the tags header has not been removed")
+	}
+
+	tags := parseDkimTags(strings.TrimPrefix(domain.DnsRecords[0].Val, "v=DKIM1;"))
+	publicKeyDer, _ := base64.StdEncoding.DecodeString(tags["p"])
+	publicKey, err := x509.ParsePKIXPublicKey(publicKeyDer)
+	if err != nil {
+		t.Fatalf("parsing public key: %s", err)
+	}
+	err = verifyTestDkimSignature(message.data, publicKey.(*rsa.PublicKey))
+	if err != nil {
+		t.Errorf("verifying DKIM signature: %s", err)
+	}
+
+	// tampering with the body must invalidate the signature
+	tampered := bytes.Replace(message.data, []byte("Hello World"), []byte("Hello Mallory"), 1)
+	if verifyTestDkimSignature(tampered, publicKey.(*rsa.PublicKey)) == nil {
+		t.Errorf("DKIM signature of tampered message is valid")
+	}
+}
+
+func TestDkimDomainsAreCaseInsensitive(t *testing.T) {
+	sink := newSmtpSink(t)
+	smtpMailer := newTestMailer(t, sink)
+	ctx := context.Background()
+
+	domain, err := smtpMailer.AddDomain(ctx, "Example.COM")
+	if err != nil {
+		t.Fatalf("adding domain: %s", err)
+	}
+	if domain.Domain != "example.com" || domain.DnsRecords[0].Host != "mdninja._domainkey.example.com" {
+		t.Errorf("domain is not lowercase: %#v", domain)
+	}
+
+	// the key is loaded from the store and not from the cache
+	smtpMailer.dkimKeysCache.DeleteAll()
+
+	testEmail := newTestEmail()
+	testEmail.From.Address = "hello@EXAMPLE.com"
+	err = smtpMailer.SendTransactionnal(ctx, testEmail)
+	if err != nil {
+		t.Fatalf("sending email: %s", err)
+	}
+	if !bytes.Contains(sink.messages[0].data, []byte("d=example.com;")) {
+		t.Errorf("email should be signed for example.com")
+	}
+
+	err = smtpMailer.RemoveDomain(ctx, "EXAMPLE.com")
+	if err != nil {
+		t.Fatalf("removing domain: %s", err)
+	}
+	err = smtpMailer.SendTransactionnal(ctx, testEmail)
+	if err != nil {
+		t.Fatalf("sending email: %s", err)
+	}
+	if bytes.Contains(sink.messages[1].data, []byte("DKIM-Signature")) {
+		t.Errorf("email should not be signed after the domain has been removed")
+	}
+}
+
+func TestSendWithoutDkimKey(t *testing.T) {
+	sink := newSmtpSink(t)
+	smtpMailer := newTestMailer(t, sink)
+
+	err := smtpMailer.SendTransactionnal(context.Background(), newTestEmail())
+	if err != nil {
+		t.Fatalf("sending email: %s", err)
+	}
+	if bytes.Contains(sink.messages[0].data, []byte("DKIM-Signature")) {
+		t.Errorf("email should not be signed")
+	}
+}
+
+func TestConnectionsAreReused(t *testing.T) {
+	sink := newSmtpSink(t)
+	smtpMailer := newTestMailer(t, sink)
+
+	for range 5 {
+		err := smtpMailer.SendBroadcast(context.Background(), newTestEmail())
+		if err != nil {
+			t.Fatalf("sending email: %s", err)
+		}
+	}
+
+	if len(sink.messages) != 5 {
+		t.Errorf("expected 5 messages, got %d", len(sink.messages))
+	}
+	if sink.connections != 1 {
+		t.Errorf("expected 1 connection, got %d", sink.connections)
+	}
+}
+
+func TestRateLimiter(t *testing.T) {
+	limiter := newRateLimiter(20)
+	start := time.Now()
+	// 20 events are allowed immediately, then 1 every 50ms
+	for range 30 {
+		err := limiter.wait(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	elapsed := time.Since(start)
+	if elapsed < 450*time.Millisecond || elapsed > 2*time.Second {
+		t.Errorf("unexpected duration for 30 events at 20/s: %s", elapsed)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for range 5 {
+		limiter.wait(context.Background())
+	}
+	if limiter.wait(ctx) == nil {
+		t.Errorf("wait should fail when the context is canceled")
+	}
+}
+
+func TestDkimCanonicalization(t *testing.T) {
+	// example from RFC 6376 section 3.4.5
+	header := dkimCanonicalizeHeaderRelaxed("A", " X\r\n")
+	if header != "a:X" {
+		t.Errorf("unexpected canonicalized header: %q", header)
+	}
+	header = dkimCanonicalizeHeaderRelaxed("B ", " Y\t\r\n\tZ  ")
+	if header != "b:Y Z" {
+		t.Errorf("unexpected canonicalized header: %q", header)
+	}
+
+	body := dkimCanonicalizeBodyRelaxed([]byte(" C \r\nD \t E\r\n\r\n\r\n"))
+	if string(body) != " C\r\nD E\r\n" {
+		t.Errorf("unexpected canonicalized body: %q", body)
+	}
+}
+
+// verifyTestDkimSignature verifies a DKIM-Signature with the rsa-sha256 and relaxed/relaxed algorithms
+func verifyTestDkimSignature(message []byte, publicKey *rsa.PublicKey) error {
+	headerSection, body, _ := bytes.Cut(message, []byte("\r\n\r\n"))
+	headers := parseHeaders(headerSection)
+	if !strings.EqualFold(headers[0].name, "DKIM-Signature") {
+		return errors.New("the first header is not DKIM-Signature")
+	}
+	tags := parseDkimTags(headers[0].value)
+	if tags["a"] != "rsa-sha256" || tags["c"] != "relaxed/relaxed" || tags["d"] != "example.com" {
+		return errors.New("unexpected DKIM-Signature tags")
+	}
+
+	bodyHash := sha256.Sum256(dkimCanonicalizeBodyRelaxed(body))
+	if base64.StdEncoding.EncodeToString(bodyHash[:]) != tags["bh"] {
+		return errors.New("body hash doesn't match")
+	}
+
+	hash := sha256.New()
+	for _, name := range strings.Split(tags["h"], ":") {
+		for i := len(headers) - 1; i > 0; i -= 1 {
+			if strings.EqualFold(headers[i].name, name) {
+				hash.Write([]byte(dkimCanonicalizeHeaderRelaxed(headers[i].name, headers[i].value) + "\r\n"))
+				break
+			}
+		}
+	}
+	signatureValue := headers[0].value[:strings.LastIndex(headers[0].value, "b=")+2]
+	hash.Write([]byte(dkimCanonicalizeHeaderRelaxed(headers[0].name, signatureValue)))
+
+	signature, err := base64.StdEncoding.DecodeString(tags["b"])
+	if err != nil {
+		return err
+	}
+	return rsa.VerifyPKCS1v15(publicKey, crypto.SHA256, hash.Sum(nil), signature)
+}
@@ -35,9 +35,14 @@ func newMarkdownRenderer(extenders ...goldmark.Extender) goldmark.Markdown {
 	)
 }
 
-func ToHtmlPage(contentMarkdown, websiteBaseUrl string) (string, error) {
+// ToHtmlPage renders markdown to HTML. If imageResolver is not nil, local images are rendered responsively.
+func ToHtmlPage(contentMarkdown, websiteBaseUrl string, imageResolver ImageResolver) (string, error) {
 	htmlBuffer := bytes.NewBuffer(make([]byte, 0, len(contentMarkdown)))
-	markdownRenderer := newMarkdownRenderer(NewAbsoluteUrlsExtension(websiteBaseUrl, true, false))
+	extensions := []goldmark.Extender{NewAbsoluteUrlsExtension(websiteBaseUrl, true, false)}
+	if imageResolver != nil {
+		extensions = append(extensions, NewResponsiveImagesExtension(websiteBaseUrl, imageResolver))
+	}
+	markdownRenderer := newMarkdownRenderer(extensions...)
 
 	err := markdownRenderer.Convert([]byte(contentMarkdown), htmlBuffer)
 	if err != nil {
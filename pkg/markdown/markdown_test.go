@@ -52,7 +52,7 @@ Some text
 <p><a href="https://markdown.ninja/some-absolute-link">some absolute link</a></p>
 `
 
-	output, err := ToHtmlPage(input, "https://markdown.ninja")
+	output, err := ToHtmlPage(input, "https://markdown.ninja", nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -0,0 +1,112 @@
+package markdown
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+	"markdown.ninja/pkg/images"
+)
+
+// Image contains the information needed to render a local image responsively
+type Image struct {
+	Width  int64
+	Height int64
+	// Resizable is true if smaller variants of the image can be requested with the w query parameter
+	Resizable bool
+}
+
+// ImageResolver returns the information about the local image at path (e.g. /assets/2025/01/photo.jpg)
+type ImageResolver func(path string) (image Image, found bool)
+
+type responsiveImagesExtension struct {
+	websiteBaseUrl string
+	resolver       ImageResolver
+}
+
+// NewResponsiveImagesExtension adds the width and height attributes to the local images, and the srcset
+// and sizes attributes to the images that can be resized.
+func NewResponsiveImagesExtension(websiteBaseUrl string, resolver ImageResolver) *responsiveImagesExtension {
+	return &responsiveImagesExtension{
+		websiteBaseUrl,
+		resolver,
+	}
+}
+
+func (extension *responsiveImagesExtension) Extend(m goldmark.Markdown) {
+	m.Parser().AddOptions(
+		parser.WithASTTransformers(
+			// the transformer needs to run before the absolute URLs transformer (500) so images still have
+			// their local path
+			util.Prioritized(responsiveImagesAstTransformer{extension.websiteBaseUrl, extension.resolver}, 400),
+		),
+	)
+}
+
+type responsiveImagesAstTransformer struct {
+	websiteBaseUrl string
+	resolver       ImageResolver
+}
+
+func (transformer responsiveImagesAstTransformer) Transform(node *ast.Document, reader text.Reader, pc parser.Context) {
+	ast.Walk(node, func(node ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering || node.Kind() != ast.KindImage {
+			return ast.WalkContinue, nil
+		}
+
+		img := node.(*ast.Image)
+		path := strings.TrimSpace(string(img.Destination))
+		// images with query parameters may already be transformed
+		if !strings.HasPrefix(path, "/assets/") || strings.ContainsAny(path, "?#") {
+			return ast.WalkContinue, nil
+		}
+
+		image, found := transformer.resolver(path)
+		if !found || image.Width <= 0 || image.Height <= 0 {
+			return ast.WalkContinue, nil
+		}
+
+		img.SetAttributeString("width", strconv.FormatInt(image.Width, 10))
+		img.SetAttributeString("height", strconv.FormatInt(image.Height, 10))
+		img.SetAttributeString("loading", "lazy")
+		img.SetAttributeString("decoding", "async")
+
+		if image.Resizable {
+			srcset := responsiveImageSrcset(transformer.websiteBaseUrl+path, image.Width)
+			if srcset != "" {
+				img.SetAttributeString("srcset", srcset)
+				img.SetAttributeString("sizes", fmt.Sprintf("(max-width: %dpx) 100vw, %dpx", image.Width, image.Width))
+			}
+		}
+
+		return ast.WalkContinue, nil
+	})
+}
+
+// responsiveImageSrcset returns the srcset of an image of the given width, or an empty string if the image is
+// smaller than the smallest variant. The original image is only a candidate if it is not larger than the
+// largest variant.
+func responsiveImageSrcset(url string, width int64) string {
+	candidates := make([]string, 0, len(images.ResponsiveWidths)+1)
+	for _, candidateWidth := range images.ResponsiveWidths {
+		if candidateWidth >= width {
+			break
+		}
+		candidates = append(candidates, fmt.Sprintf("%s?w=%d %dw", url, candidateWidth, candidateWidth))
+	}
+
+	if len(candidates) == 0 {
+		return ""
+	}
+
+	if width <= images.ResponsiveWidths[len(images.ResponsiveWidths)-1] {
+		candidates = append(candidates, fmt.Sprintf("%s %dw", url, width))
+	}
+
+	return strings.Join(candidates, ", ")
+}
@@ -0,0 +1,63 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestResponsiveImages(t *testing.T) {
+	input := `![Large](/assets/large.jpg)
+
+![Small](/assets/small.png)
+
+![Animated](/assets/animated.gif)
+
+![Unknown](/assets/unknown.jpg)
+
+![Resized](/assets/large.jpg?w=320)
+
+![External](https://example.com/photo.jpg)
+`
+	resolver := func(path string) (Image, bool) {
+		switch path {
+		case "/assets/large.jpg":
+			return Image{Width: 1000, Height: 500, Resizable: true}, true
+		case "/assets/small.png":
+			return Image{Width: 200, Height: 100, Resizable: true}, true
+		case "/assets/animated.gif":
+			return Image{Width: 800, Height: 600, Resizable: false}, true
+		default:
+			return Image{}, false
+		}
+	}
+
+	output, err := ToHtmlPage(input, "https://markdown.ninja", resolver)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		`<img src="https://markdown.ninja/assets/large.jpg" alt="Large" width="1000" height="500" loading="lazy" decoding="async" srcset="https://markdown.ninja/assets/large.jpg?w=320 320w, https://markdown.ninja/assets/large.jpg?w=640 640w, https://markdown.ninja/assets/large.jpg?w=960 960w, https://markdown.ninja/assets/large.jpg 1000w" sizes="(max-width: 1000px) 100vw, 1000px" />`,
+		`<img src="https://markdown.ninja/assets/small.png" alt="Small" width="200" height="100" loading="lazy" decoding="async" />`,
+		`<img src="https://markdown.ninja/assets/animated.gif" alt="Animated" width="800" height="600" loading="lazy" decoding="async" />`,
+		`<img src="https://markdown.ninja/assets/unknown.jpg" alt="Unknown" />`,
+		`<img src="https://markdown.ninja/assets/large.jpg?w=320" alt="Resized" />`,
+		`<img src="https://example.com/photo.jpg" alt="External" />`,
+	}
+	for _, expectedImage := range expected {
+		if !strings.Contains(output, expectedImage) {
+			t.Errorf("output doesn't contain %s. Got: %s", expectedImage, output)
+		}
+	}
+}
+
+func TestResponsiveImageSrcset(t *testing.T) {
+	if srcset := responsiveImageSrcset("/assets/a.jpg", 300); srcset != "" {
+		t.Errorf("srcset should be empty for images smaller than the smallest variant. Got: %s", srcset)
+	}
+
+	expected := "/assets/a.jpg?w=320 320w, /assets/a.jpg?w=640 640w, /assets/a.jpg?w=960 960w, /assets/a.jpg?w=1280 1280w, /assets/a.jpg?w=1920 1920w"
+	if srcset := responsiveImageSrcset("/assets/a.jpg", 4000); srcset != expected {
+		t.Errorf("srcset (%s) != expected (%s)", srcset, expected)
+	}
+}
@@ -91,6 +91,30 @@ func Start(ctx context.Context, db db.DB, queue queue.Queue, jwtProvider *jwt.Pr
 		return err
 	}
 
+	// every hour at XX:20
+	err = cronScheduler.Schedule("events.DispatchRollupPageViews", "0 20 * * * *", scheduler.eventsDispatchRollupPageViews)
+	if err != nil {
+		return err
+	}
+
+	// every day at 03:00
+	err = cronScheduler.Schedule("events.DispatchDeleteExpiredAnalyticsExports", "0 0 3 * * *", scheduler.eventsDispatchDeleteExpiredAnalyticsExports)
+	if err != nil {
+		return err
+	}
+
+	// every monday at 08:00, after the page views of the previous day have been rolled up
+	err = cronScheduler.Schedule("events.DispatchSendWeeklyAnalyticsReports", "0 0 8 * * 1", scheduler.eventsDispatchSendWeeklyAnalyticsReports)
+	if err != nil {
+		return err
+	}
+
+	// every first day of the month at 08:00
+	err = cronScheduler.Schedule("events.DispatchSendMonthlyAnalyticsReports", "0 0 8 1 * *", scheduler.eventsDispatchSendMonthlyAnalyticsReports)
+	if err != nil {
+		return err
+	}
+
 	// every day at 01:30
 	err = cronScheduler.Schedule("kernel.TaskRefreshGeoipDatabase", "0 30 1 * * *", kernelService.TaskRefreshGeoipDatabase)
 	if err != nil {
@@ -19,3 +19,51 @@ func (scheduler *Scheduler) eventsDispatchRotateAnonymousIDSalt(ctx context.Cont
 		return
 	}
 }
+
+func (scheduler *Scheduler) eventsDispatchRollupPageViews(ctx context.Context) {
+	job := queue.NewJobInput{
+		Data: events.JobRollupPageViews{},
+	}
+	err := scheduler.queue.Push(ctx, nil, job)
+	if err != nil {
+		logger := slogx.FromCtx(ctx)
+		logger.Error("scheduler.DispatchRollupPageViews: Pushing job to queue", slogx.Err(err))
+		return
+	}
+}
+
+func (scheduler *Scheduler) eventsDispatchDeleteExpiredAnalyticsExports(ctx context.Context) {
+	job := queue.NewJobInput{
+		Data: events.JobDeleteExpiredAnalyticsExports{},
+	}
+	err := scheduler.queue.Push(ctx, nil, job)
+	if err != nil {
+		logger := slogx.FromCtx(ctx)
+		logger.Error("scheduler.DispatchDeleteExpiredAnalyticsExports: Pushing job to queue", slogx.Err(err))
+		return
+	}
+}
+
+func (scheduler *Scheduler) eventsDispatchSendWeeklyAnalyticsReports(ctx context.Context) {
+	job := queue.NewJobInput{
+		Data: events.JobDispatchSendAnalyticsReports{Frequency: events.AnalyticsReportFrequencyWeekly},
+	}
+	err := scheduler.queue.Push(ctx, nil, job)
+	if err != nil {
+		logger := slogx.FromCtx(ctx)
+		logger.Error("scheduler.DispatchSendWeeklyAnalyticsReports: Pushing job to queue", slogx.Err(err))
+		return
+	}
+}
+
+func (scheduler *Scheduler) eventsDispatchSendMonthlyAnalyticsReports(ctx context.Context) {
+	job := queue.NewJobInput{
+		Data: events.JobDispatchSendAnalyticsReports{Frequency: events.AnalyticsReportFrequencyMonthly},
+	}
+	err := scheduler.queue.Push(ctx, nil, job)
+	if err != nil {
+		logger := slogx.FromCtx(ctx)
+		logger.Error("scheduler.DispatchSendMonthlyAnalyticsReports: Pushing job to queue", slogx.Err(err))
+		return
+	}
+}
@@ -70,6 +70,7 @@ func (server *server) Api(apiRouter chi.Router) {
 	////////////////////////////////////////////////////////////////////////////////////////////////
 	apiRouter.Post(api.RouteWebhooksStripe, server.stripeWebhook)
 	apiRouter.Post(api.RouteWebhooksPingoo, server.pingooWebhookHandler)
+	apiRouter.Post(api.RouteWebhooksEmails, server.emailsWebhookHandler)
 
 	////////////////////////////////////////////////////////////////////////////////////////////////
 	// Kernel
@@ -93,6 +94,8 @@ func (server *server) Api(apiRouter chi.Router) {
 	apiRouter.Post(api.RouteOrganizationStripeCustomerPortal, apiutil.JsonEndpoint(server.organizationsService.GetStripeCustomerPortalUrl))
 	apiRouter.Post(api.RouteOrganizationSyncStripe, apiutil.JsonEndpointOk(server.organizationsService.SyncStripe))
 	apiRouter.Post(api.RouteOrganizationBillingUsage, apiutil.JsonEndpoint(server.organizationsService.GetBillingUsage))
+	apiRouter.Post(api.RouteOrganizationAuditLogs, apiutil.JsonEndpoint(server.organizationsService.ListAuditLogs))
+	apiRouter.Post(api.RouteOrganizationExportAuditLogs, apiutil.JsonEndpoint(server.organizationsService.ExportAuditLogs))
 
 	// staffs
 	apiRouter.Post(api.RouteInviteStaffs, apiutil.JsonEndpoint(server.organizationsService.InviteStaffs))
@@ -119,9 +122,18 @@ func (server *server) Api(apiRouter chi.Router) {
 	apiRouter.Post(api.RouteWebsite, apiutil.JsonEndpoint(server.websitesService.GetWebsite))
 	apiRouter.Post(api.RouteUpdateWebsite, apiutil.JsonEndpoint(server.websitesService.UpdateWebsite))
 	apiRouter.Post(api.RouteSaveRedirect, apiutil.JsonEndpoint(server.websitesService.SaveRedirects))
+	apiRouter.Post(api.RouteImportRedirects, apiutil.JsonEndpoint(server.websitesService.ImportRedirects))
 	apiRouter.Post(api.RouteAllWebsites, apiutil.JsonEndpoint(server.websitesService.ListWebsites))
 	apiRouter.Post(api.RouteWebsiteUpdateIcon, server.websiteUpdateIcon)
 
+	// webhooks
+	apiRouter.Post(api.RouteCreateWebhookEndpoint, apiutil.JsonEndpoint(server.webhooksService.CreateWebhookEndpoint))
+	apiRouter.Post(api.RouteUpdateWebhookEndpoint, apiutil.JsonEndpoint(server.webhooksService.UpdateWebhookEndpoint))
+	apiRouter.Post(api.RouteDeleteWebhookEndpoint, apiutil.JsonEndpointOk(server.webhooksService.DeleteWebhookEndpoint))
+	apiRouter.Post(api.RouteWebhookEndpoints, apiutil.JsonEndpoint(server.webhooksService.ListWebhookEndpoints))
+	apiRouter.Post(api.RouteWebhookDeliveries, apiutil.JsonEndpoint(server.webhooksService.ListWebhookDeliveries))
+	apiRouter.Post(api.RouteRedeliverWebhook, apiutil.JsonEndpoint(server.webhooksService.RedeliverWebhook))
+
 	// snippets
 	apiRouter.Post(api.RouteCreateSnippet, apiutil.JsonEndpoint(server.contentService.CreateSnippet))
 	apiRouter.Post(api.RouteDeleteSnippet, apiutil.JsonEndpointOk(server.contentService.DeleteSnippet))
@@ -134,6 +146,13 @@ func (server *server) Api(apiRouter chi.Router) {
 	apiRouter.Post(api.RouteDeleteTag, apiutil.JsonEndpointOk(server.contentService.DeleteTag))
 	apiRouter.Post(api.RouteTags, apiutil.JsonEndpoint(server.contentService.GetTags))
 
+	// authors
+	apiRouter.Post(api.RouteCreateAuthor, apiutil.JsonEndpoint(server.contentService.CreateAuthor))
+	apiRouter.Post(api.RouteUpdateAuthor, apiutil.JsonEndpoint(server.contentService.UpdateAuthor))
+	apiRouter.Post(api.RouteDeleteAuthor, apiutil.JsonEndpointOk(server.contentService.DeleteAuthor))
+	apiRouter.Post(api.RouteAuthor, apiutil.JsonEndpoint(server.contentService.GetAuthor))
+	apiRouter.Post(api.RouteAuthors, apiutil.JsonEndpoint(server.contentService.ListAuthors))
+
 	// pages
 	apiRouter.Post(api.RouteCreatePage, apiutil.JsonEndpoint(server.contentService.CreatePage))
 	apiRouter.Post(api.RouteUpdatePage, apiutil.JsonEndpoint(server.contentService.UpdatePage))
@@ -142,6 +161,15 @@ func (server *server) Api(apiRouter chi.Router) {
 	apiRouter.Post(api.RoutePages, apiutil.JsonEndpoint(server.contentService.ListPages))
 	apiRouter.Post(api.RoutePosts, apiutil.JsonEndpoint(server.contentService.ListPosts))
 
+	// page revisions
+	apiRouter.Post(api.RoutePageRevisions, apiutil.JsonEndpoint(server.contentService.ListPageRevisions))
+	apiRouter.Post(api.RoutePageRevision, apiutil.JsonEndpoint(server.contentService.GetPageRevision))
+	apiRouter.Post(api.RouteDiffPageRevisions, apiutil.JsonEndpoint(server.contentService.DiffPageRevisions))
+	apiRouter.Post(api.RouteRestorePageRevision, apiutil.JsonEndpoint(server.contentService.RestorePageRevision))
+
+	// preview tokens
+	apiRouter.Post(api.RouteCreatePreviewToken, apiutil.JsonEndpoint(server.contentService.CreatePreviewToken))
+
 	// assets
 	apiRouter.Post(api.RouteUploadAsset, server.uploadAsset)
 	apiRouter.Post(api.RouteDeleteAsset, apiutil.JsonEndpointOk(server.contentService.DeleteAsset))
@@ -170,6 +198,12 @@ func (server *server) Api(apiRouter chi.Router) {
 	apiRouter.Post(api.RouteBlockContact, apiutil.JsonEndpoint(server.contactsService.BlockContact))
 	apiRouter.Post(api.RouteUnblockContact, apiutil.JsonEndpoint(server.contactsService.UnblockContact))
 
+	// labels
+	apiRouter.Post(api.RouteCreateLabel, apiutil.JsonEndpoint(server.contactsService.CreateLabel))
+	apiRouter.Post(api.RouteUpdateLabel, apiutil.JsonEndpoint(server.contactsService.UpdateLabel))
+	apiRouter.Post(api.RouteDeleteLabel, apiutil.JsonEndpointOk(server.contactsService.DeleteLabel))
+	apiRouter.Post(api.RouteLabels, apiutil.JsonEndpoint(server.contactsService.ListLabels))
+
 	////////////////////////////////////////////////////////////////////////////////////////////////
 	// Emails
 	////////////////////////////////////////////////////////////////////////////////////////////////
@@ -224,4 +258,14 @@ func (server *server) Api(apiRouter chi.Router) {
 	// Analytics
 	////////////////////////////////////////////////////////////////////////////////////////////////
 	apiRouter.Post(api.RouteAnalyticsData, apiutil.JsonEndpoint(server.eventsService.GetAnalyticsData))
+	apiRouter.Post(api.RouteCreateGoal, apiutil.JsonEndpoint(server.eventsService.CreateGoal))
+	apiRouter.Post(api.RouteUpdateGoal, apiutil.JsonEndpoint(server.eventsService.UpdateGoal))
+	apiRouter.Post(api.RouteDeleteGoal, apiutil.JsonEndpointOk(server.eventsService.DeleteGoal))
+	apiRouter.Post(api.RouteGoals, apiutil.JsonEndpoint(server.eventsService.ListGoals))
+	apiRouter.Post(api.RouteCreateAnalyticsExport, apiutil.JsonEndpoint(server.eventsService.CreateAnalyticsExport))
+	apiRouter.Post(api.RouteAnalyticsExports, apiutil.JsonEndpoint(server.eventsService.ListAnalyticsExports))
+	apiRouter.Post(api.RouteDownloadAnalyticsExport, server.downloadAnalyticsExport)
+	apiRouter.Post(api.RouteAnalyticsReportSettings, apiutil.JsonEndpoint(server.eventsService.GetAnalyticsReportSettings))
+	apiRouter.Post(api.RouteUpdateAnalyticsReportSettings, apiutil.JsonEndpoint(server.eventsService.UpdateAnalyticsReportSettings))
+	apiRouter.Post(api.RouteLiveAnalytics, server.streamLiveAnalytics)
 }
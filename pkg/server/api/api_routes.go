@@ -9,6 +9,7 @@ const (
 	// webhooks
 	RouteWebhooksStripe = "/webhooks/stripe"
 	RouteWebhooksPingoo = "/webhooks/pingoo/{secret}"
+	RouteWebhooksEmails = "/webhooks/emails"
 
 	// background jobs
 	RouteFailedBackgroundJobs = "/queue/failed_background_jobs"
@@ -28,6 +29,8 @@ const (
 	RouteOrganizationStripeCustomerPortal = "/organizations/stripe_customer_portal"
 	RouteOrganizationSyncStripe           = "/organizations/sync_stripe"
 	RouteOrganizationBillingUsage         = "/organizations/billing_usage"
+	RouteOrganizationAuditLogs            = "/organizations/audit_logs"
+	RouteOrganizationExportAuditLogs      = "/organizations/export_audit_logs"
 
 	// staffs
 	RouteStaffs                = "/staffs"
@@ -67,8 +70,26 @@ const (
 	RoutePages      = "/pages"
 	RoutePosts      = "/posts"
 
+	// page revisions
+	RoutePageRevisions       = "/page_revisions"
+	RoutePageRevision        = "/page_revision"
+	RouteDiffPageRevisions   = "/diff_page_revisions"
+	RouteRestorePageRevision = "/restore_page_revision"
+
+	// preview tokens
+	RouteCreatePreviewToken = "/create_preview_token"
+
 	// redirects
-	RouteSaveRedirect = "/save_redirects"
+	RouteSaveRedirect    = "/save_redirects"
+	RouteImportRedirects = "/import_redirects"
+
+	// webhooks
+	RouteCreateWebhookEndpoint = "/create_webhook_endpoint"
+	RouteUpdateWebhookEndpoint = "/update_webhook_endpoint"
+	RouteDeleteWebhookEndpoint = "/delete_webhook_endpoint"
+	RouteWebhookEndpoints      = "/webhook_endpoints"
+	RouteWebhookDeliveries     = "/webhook_deliveries"
+	RouteRedeliverWebhook      = "/redeliver_webhook"
 
 	// assets
 	RouteUploadAsset       = "/upload_asset"
@@ -88,6 +109,13 @@ const (
 	RouteDeleteTag = "/delete_tag"
 	RouteTags      = "/tags"
 
+	// authors
+	RouteCreateAuthor = "/create_author"
+	RouteUpdateAuthor = "/update_author"
+	RouteDeleteAuthor = "/delete_author"
+	RouteAuthor       = "/author"
+	RouteAuthors      = "/authors"
+
 	// contacts
 	RouteCreateContact            = "/create_contact"
 	RouteContacts                 = "/contacts"
@@ -100,6 +128,12 @@ const (
 	RouteBlockContact             = "/block_contact"
 	RouteUnblockContact           = "/unblock_contact"
 
+	// labels
+	RouteCreateLabel = "/create_label"
+	RouteUpdateLabel = "/update_label"
+	RouteDeleteLabel = "/delete_label"
+	RouteLabels      = "/labels"
+
 	// emails configuration
 	RouteEmailsConfiguration          = "/emails_configuration"
 	RouteUpdateEmailsConfiguration    = "/update_emails_configuration"
@@ -143,5 +177,15 @@ const (
 	RouteCoupons      = "/coupons"
 
 	// analytics
-	RouteAnalyticsData = "/analytics_data"
+	RouteAnalyticsData                 = "/analytics_data"
+	RouteCreateGoal                    = "/create_goal"
+	RouteUpdateGoal                    = "/update_goal"
+	RouteDeleteGoal                    = "/delete_goal"
+	RouteGoals                         = "/goals"
+	RouteCreateAnalyticsExport         = "/create_analytics_export"
+	RouteAnalyticsExports              = "/analytics_exports"
+	RouteDownloadAnalyticsExport       = "/download_analytics_export"
+	RouteAnalyticsReportSettings       = "/analytics_report_settings"
+	RouteUpdateAnalyticsReportSettings = "/update_analytics_report_settings"
+	RouteLiveAnalytics                 = "/live_analytics"
 )
@@ -16,6 +16,11 @@ const (
 	WebsiteSitemap   = "public, max-age=300, stale-while-revalidate=3600"
 	WebsiteFavicon   = "public, max-age=30, stale-while-revalidate=2592000" // 30 days
 
-	HeadlessApiPages = "public, max-age=0, stale-while-revalidate=3600"
-	HeadlessApiTags  = "public, max-age=10, stale-while-revalidate=3600"
+	HeadlessApiPages   = "public, max-age=0, stale-while-revalidate=3600"
+	HeadlessApiTags    = "public, max-age=10, stale-while-revalidate=3600"
+	HeadlessApiSearch  = "public, max-age=0, stale-while-revalidate=600"
+	HeadlessApiAuthors = "public, max-age=10, stale-while-revalidate=3600"
+	// HeadlessApiContent is used by the headless content API, which is authenticated with API keys,
+	// so responses must not be stored by shared caches.
+	HeadlessApiContent = "private, no-cache"
 )
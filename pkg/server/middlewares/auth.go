@@ -14,6 +14,7 @@ import (
 	"markdown.ninja/pkg/services/contacts"
 	"markdown.ninja/pkg/services/kernel"
 	"markdown.ninja/pkg/services/organizations"
+	"markdown.ninja/pkg/services/websites"
 )
 
 type authMiddleware struct {
@@ -35,7 +36,8 @@ var allowPathsWithoutAuth = set.NewFromSlice([]string{
 // Auth is an HTTP middleware that checks authentication and return an error code if
 // some credentials are present but not valid.
 // for the webapp domain it checks the users auth cookie and the `Authorization` header (for API keys)
-// for the websites domaians it checks the contacts auth cookie
+// for the websites domaians it checks the contacts auth cookie, and the `Authorization` header
+// for API keys on the headless API
 // if the authentication is successful then the autenticated entity is injected into the request's context
 // no rate-limiting is performed by the Auth middleware. Rate-limiting should be performed by dowstream
 // services.
@@ -134,6 +136,22 @@ func (middleware *authMiddleware) handleWebsiteAuth(ctx context.Context, w http.
 		return nil
 	}
 
+	// API keys are accepted by the headless API so websites can be used as a headless CMS.
+	// Other types of Authorization headers are ignored as the public API doesn't use them.
+	if strings.HasPrefix(req.URL.Path, websites.MarkdownNinjaPathPrefix+"/api/") {
+		tokenType, token, err := decodeAuthorizationHeader(req.Header.Get(kernel.AuthHttpHeader))
+		if err == nil && tokenType == "apikey" {
+			apiKey, err := middleware.organizationsService.VerifyApiKey(ctx, token)
+			if err != nil {
+				apiutil.SendError(ctx, w, err)
+				return err
+			}
+
+			httpctx.FromCtx(ctx).ApiKey = &apiKey
+			return nil
+		}
+	}
+
 	// we can ignore error as req.Cookie will returns an error only if the cookie is missing
 	authCookie, _ := req.Cookie(contacts.AuthCookie)
 	if authCookie != nil {
@@ -0,0 +1,103 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/bloom42/stdx-go/httpx"
+	"github.com/bloom42/stdx-go/log/slogx"
+	"markdown.ninja/pkg/server/apiutil"
+	"markdown.ninja/pkg/services/events"
+)
+
+// downloadAnalyticsExport streams the file of the export instead of wrapping it in a JSON response
+func (server *server) downloadAnalyticsExport(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+
+	var input events.DownloadAnalyticsExportInput
+	err := apiutil.DecodeRequest(w, req, &input)
+	if err != nil {
+		apiutil.SendError(ctx, w, err)
+		return
+	}
+
+	export, data, err := server.eventsService.DownloadAnalyticsExport(ctx, input)
+	if err != nil {
+		apiutil.SendError(ctx, w, err)
+		return
+	}
+	defer data.Close()
+
+	w.Header().Set(httpx.HeaderContentType, export.Format.MediaType())
+	w.Header().Set(httpx.HeaderContentLength, strconv.FormatInt(export.Size, 10))
+	w.Header().Set(httpx.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s"`, export.Filename()))
+	w.WriteHeader(http.StatusOK)
+
+	_, err = io.Copy(w, data)
+	if err != nil {
+		// headers have already been sent so we can only log the error
+		logger := slogx.FromCtx(ctx)
+		logger.Warn("downloadAnalyticsExport: error sending export", slogx.Err(err),
+			slog.String("analytics_export.id", export.ID.String()))
+		return
+	}
+}
+
+// streamLiveAnalytics streams the live analytics of a website as server-sent events. Requests are
+// canceled by the timeout middleware so clients are expected to reconnect when the stream ends.
+func (server *server) streamLiveAnalytics(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	responseController := http.NewResponseController(w)
+	headersSent := false
+
+	var input events.StreamLiveAnalyticsInput
+	err := apiutil.DecodeRequest(w, req, &input)
+	if err != nil {
+		apiutil.SendError(ctx, w, err)
+		return
+	}
+
+	err = server.eventsService.StreamLiveAnalytics(ctx, input, func(data events.LiveAnalytics) error {
+		// headers are only sent with the first event so errors happening before can be sent as usual
+		if !headersSent {
+			w.Header().Set(httpx.HeaderContentType, "text/event-stream")
+			w.Header().Set(httpx.HeaderCacheControl, httpx.CacheControlNoCache)
+			// disable buffering by reverse proxies
+			w.Header().Set("X-Accel-Buffering", "no")
+			w.WriteHeader(http.StatusOK)
+			headersSent = true
+			// the stream is bounded by the timeout middleware, not by the WriteTimeout of the server
+			_ = responseController.SetWriteDeadline(time.Time{})
+		}
+
+		dataJson, err := json.Marshal(data)
+		if err != nil {
+			return fmt.Errorf("encoding live analytics to JSON: %w", err)
+		}
+
+		_, err = fmt.Fprintf(w, "data: %s\n\n", dataJson)
+		if err != nil {
+			return err
+		}
+
+		return responseController.Flush()
+	})
+	if err != nil {
+		if !headersSent {
+			apiutil.SendError(ctx, w, err)
+			return
+		}
+		// headers have already been sent so we can only log the error. The client may simply have disconnected
+		if ctx.Err() == nil {
+			logger := slogx.FromCtx(ctx)
+			logger.Warn("streamLiveAnalytics: error streaming live analytics", slogx.Err(err),
+				slog.String("website.id", input.WebsiteID.String()))
+		}
+		return
+	}
+}
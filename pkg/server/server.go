@@ -9,14 +9,13 @@ import (
 	"io"
 	"io/fs"
 	"log"
+	"log/slog"
 	"net"
 	"net/http"
 	"net/url"
 	"strconv"
 	"time"
 
-	"log/slog"
-
 	"github.com/bloom42/stdx-go/crypto/blake3"
 	"github.com/bloom42/stdx-go/db"
 	"github.com/bloom42/stdx-go/httpx"
@@ -39,6 +38,7 @@ import (
 	"markdown.ninja/pkg/services/organizations"
 	"markdown.ninja/pkg/services/site"
 	"markdown.ninja/pkg/services/store"
+	"markdown.ninja/pkg/services/webhooks"
 	"markdown.ninja/pkg/services/websites"
 	"markdown.ninja/webapp"
 )
@@ -55,6 +55,7 @@ type server struct {
 	siteService          site.Service
 	contentService       content.Service
 	organizationsService organizations.Service
+	webhooksService      webhooks.Service
 	kms                  *kms.Kms
 
 	// env                config.Env
@@ -80,7 +81,7 @@ type server struct {
 func Start(ctx context.Context, conf config.Config, db db.DB, geoipDb *geoip.Resolver, pingooClient *pingoo.Client, kernelService kernel.Service,
 	websitesService websites.Service, contactsService contacts.Service, emailsService emails.Service,
 	storeService store.Service, eventsService events.Service, siteService site.Service, contentService content.Service,
-	organizationsService organizations.Service, logger *slog.Logger, kms *kms.Kms,
+	organizationsService organizations.Service, webhooksService webhooks.Service, logger *slog.Logger, kms *kms.Kms,
 ) (err error) {
 	blockedCountries := set.NewFromSlice(conf.BlockedCountries)
 
@@ -113,6 +114,7 @@ func Start(ctx context.Context, conf config.Config, db db.DB, geoipDb *geoip.Res
 		siteService:          siteService,
 		contentService:       contentService,
 		organizationsService: organizationsService,
+		webhooksService:      webhooksService,
 		kms:                  kms,
 
 		webappDomain:            conf.HTTP.WebappDomain,
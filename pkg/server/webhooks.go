@@ -88,3 +88,25 @@ func (server *server) pingooWebhookHandler(res http.ResponseWriter, req *http.Re
 
 	res.WriteHeader(http.StatusOK)
 }
+
+// emailsWebhookHandler receives the bounces and complaints sent by the email provider
+func (server *server) emailsWebhookHandler(res http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+
+	const MaxBodyBytes = int64(256_000)
+	req.Body = http.MaxBytesReader(res, req.Body, MaxBodyBytes)
+	payload, err := io.ReadAll(req.Body)
+	if err != nil {
+		err = fmt.Errorf("server.emailsWebhookHandler: error reading body: %w", err)
+		apiutil.SendError(ctx, res, err)
+		return
+	}
+
+	err = server.contactsService.HandleMailerWebhook(ctx, req.Header, payload)
+	if err != nil {
+		apiutil.SendError(ctx, res, err)
+		return
+	}
+
+	res.WriteHeader(http.StatusOK)
+}
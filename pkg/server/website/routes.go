@@ -41,6 +41,8 @@ func Routes(ctx context.Context, siteService site.Service, contactsService conta
 	router.Route(websites.MarkdownNinjaPathPrefix, func(mdninjaRouter chi.Router) {
 		// mdninjaRouter.Get("/videos/{asset_id}/iframe", siteService.ServeVideoIframe)
 		mdninjaRouter.Get("/preview/{page_id}", siteService.ServePreview)
+		mdninjaRouter.Get("/emails/open", siteService.ServeEmailOpen)
+		mdninjaRouter.Get("/emails/click", siteService.ServeEmailClick)
 
 		mdninjaRouter.Route("/api", func(apiRouter chi.Router) {
 			apiRouter.Use(middleware.NoCache)
@@ -50,7 +52,15 @@ func Routes(ctx context.Context, siteService site.Service, contactsService conta
 			apiRouter.Get("/website", apiutil.GetEndpoint(siteService.GetWebsite))
 			apiRouter.Get("/page", apiutil.GetEndpoint(siteService.GetPage))
 			apiRouter.Get("/tags", apiutil.GetEndpoint(siteService.ListTags))
+			apiRouter.Get("/author", apiutil.GetEndpoint(siteService.GetAuthor))
 			apiRouter.Get("/pages", apiutil.GetEndpoint(siteService.ListPages))
+			apiRouter.Get("/search", apiutil.GetEndpoint(siteService.Search))
+
+			// Headless CMS, authenticated with API keys
+			apiRouter.Get("/headless/pages", apiutil.GetEndpoint(siteService.HeadlessListPages))
+			apiRouter.Get("/headless/page", apiutil.GetEndpoint(siteService.HeadlessGetPage))
+			apiRouter.Get("/graphql", apiutil.GetEndpoint(siteService.Graphql))
+			apiRouter.Post("/graphql", apiutil.JsonEndpoint(siteService.Graphql))
 
 			// Contacts
 			apiRouter.Get("/me", apiutil.GetEndpoint(siteService.GetMe))
@@ -75,6 +85,7 @@ func Routes(ctx context.Context, siteService site.Service, contactsService conta
 
 			// events
 			apiRouter.Post("/events/page_view", apiutil.JsonEndpointOk(siteService.TrackEventPageView))
+			apiRouter.Post("/events/custom", apiutil.JsonEndpointOk(siteService.TrackEventCustom))
 		})
 
 		mdninjaRouter.NotFound(apiutil.NotFoundHandler)
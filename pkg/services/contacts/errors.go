@@ -25,17 +25,22 @@ var (
 	// Sessions
 	ErrSessionNotFound = errs.NotFound("Session not found.")
 
+	// Mailer
+	ErrMailerWebhookIsNotValid = errs.InvalidArgument("Webhook is not valid.")
+
 	// Billing
 	ErrBillingInformationNotFound = errs.NotFound("Billing information not found.")
 
 	// Labels
-	// ErrLabelNotFound      = errs.NotFound("Label not found.")
-	// ErrLabelAlreadyExists = func(name string) error {
-	// 	return errs.InvalidArgument(fmt.Sprintf("Label \"%s\" already exists.", name))
-	// }
-	// ErrLabelDescriptionIsTooLong = errs.InvalidArgument(fmt.Sprintf("Description is too long (max: %d characters)", LabelDescriptionMaxSize))
-	// ErrLabelNameIsTooShort       = errs.InvalidArgument(fmt.Sprintf("Name is too short (min: %d characters)", LabelNameMinSize))
-	// ErrLabelNameIsTooLong        = errs.InvalidArgument(fmt.Sprintf("Name is too long (max: %d characters)", LabelNameMaxSize))
-	// ErrLabelNameMustBeLower      = errs.InvalidArgument("Name must be lowercase")
-	// ErrLabelNameIsNotValid       = errs.InvalidArgument("Name is not valid.")
+	ErrLabelNotFound      = errs.NotFound("Label not found.")
+	ErrLabelAlreadyExists = func(name string) error {
+		return errs.InvalidArgument(fmt.Sprintf("Label \"%s\" already exists.", name))
+	}
+	ErrLabelDescriptionIsTooLong  = errs.InvalidArgument(fmt.Sprintf("Description is too long (max: %d characters)", LabelDescriptionMaxSize))
+	ErrLabelNameIsTooShort        = errs.InvalidArgument(fmt.Sprintf("Name is too short (min: %d characters)", LabelNameMinSize))
+	ErrLabelNameIsTooLong         = errs.InvalidArgument(fmt.Sprintf("Name is too long (max: %d characters)", LabelNameMaxSize))
+	ErrLabelNameMustBeLower       = errs.InvalidArgument("Name must be lowercase")
+	ErrLabelNameIsNotValid        = errs.InvalidArgument("Name is not valid.")
+	ErrLabelDescriptionIsNotValid = errs.InvalidArgument("Description is not valid.")
+	ErrTooManyLabels              = errs.InvalidArgument(fmt.Sprintf("Too many labels (max: %d)", LabelsMaxPerWebsite))
 )
@@ -8,12 +8,13 @@ import (
 	"markdown.ninja/pkg/services/store"
 )
 
-// const (
-// 	LabelNameMinSize        = 1
-// 	LabelNameMaxSize        = 42
-// 	LabelDescriptionMaxSize = 420
-// 	LabelNameAlphabet       = "abcdefghijklmnopqrstuvwxyz0123456789-"
-// )
+const (
+	LabelNameMinSize        = 1
+	LabelNameMaxSize        = 42
+	LabelDescriptionMaxSize = 420
+	LabelNameAlphabet       = "abcdefghijklmnopqrstuvwxyz0123456789-"
+	LabelsMaxPerWebsite     = 200
+)
 
 const (
 	KeyInfoUnsubscribe = "unsubscribe"
@@ -46,6 +47,10 @@ type Contact struct {
 	FailedSignupAttempts         int64      `db:"failed_signup_attempts" json:"-"`
 	SignupCodeHash               string     `db:"signup_code_hash" json:"-"`
 	BlockedAt                    *time.Time `db:"blocked_at" json:"blocked_at"`
+	// BouncedAt and ComplainedAt are set when the email provider reports a hard bounce or a spam complaint
+	// for the email address of the contact. These contacts no longer receive newsletters.
+	BouncedAt    *time.Time `db:"bounced_at" json:"bounced_at"`
+	ComplainedAt *time.Time `db:"complained_at" json:"complained_at"`
 
 	BillingAddress   kernel.Address `db:"billing_address" json:"billing_address"`
 	StripeCustomerID *string        `db:"stripe_customer_id" json:"stripe_customer_id"`
@@ -54,6 +59,7 @@ type Contact struct {
 
 	Products []store.Product `db:"-" json:"products"`
 	Orders   []store.Order   `db:"-" json:"orders"`
+	Labels   []Label         `db:"-" json:"labels"`
 }
 
 // UpdatedAt is the last time a session has been refreshed
@@ -73,21 +79,26 @@ type Session struct {
 	WebsiteID guid.GUID `db:"website_id"`
 }
 
-// type Label struct {
-// 	ID        guid.GUID `db:"id" json:"id"`
-// 	CreatedAt time.Time `db:"created_at" json:"created_at"`
-// 	UpdatedAt time.Time `db:"updated_at" json:"updated_at"`
+type Label struct {
+	ID        guid.GUID `db:"id" json:"id"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+	UpdatedAt time.Time `db:"updated_at" json:"updated_at"`
 
-// 	Name        string `db:"name" json:"name"`
-// 	Description string `db:"description" json:"description"`
+	Name        string `db:"name" json:"name"`
+	Description string `db:"description" json:"description"`
 
-// 	WebsiteID guid.GUID `db:"website_id" json:"-"`
-// }
+	WebsiteID guid.GUID `db:"website_id" json:"-"`
+}
 
-// type ContactLabelRelation struct {
-// 	ContactID guid.GUID `db:"contact_id"`
-// 	LabelID   guid.GUID `db:"label_id"`
-// }
+type ContactLabelRelation struct {
+	ContactID guid.GUID `db:"contact_id"`
+	LabelID   guid.GUID `db:"label_id"`
+}
+
+type ContactLabel struct {
+	Label
+	ContactID guid.GUID `db:"contact_id"`
+}
 
 type PaymentMethod struct {
 	Brand    string `db:"brand"`
@@ -160,6 +171,9 @@ type UpdateContactInput struct {
 	SignupCodeHash       *string `json:"-"`
 	FailedSignupAttempts *int64  `json:"-"`
 	StripeCustomerID     *string `json:"-"`
+
+	// Labels, if not nil, replaces all the labels of the contact
+	Labels *[]guid.GUID `json:"labels"`
 }
 
 type DeleteContactInput struct {
@@ -169,6 +183,8 @@ type DeleteContactInput struct {
 type ImportContactsInput struct {
 	WebsiteID   guid.GUID `json:"website_id"`
 	ContactsCsv string    `json:"contacts"`
+	// Labels are added to all the imported contacts
+	Labels []guid.GUID `json:"labels"`
 }
 
 type GetContactInput struct {
@@ -178,13 +194,15 @@ type GetContactInput struct {
 type ListContactsInput struct {
 	WebsiteID guid.GUID `json:"website_id"`
 	Query     string    `json:"query"`
+	// Labels, if not empty, returns only the contacts with at least one of the labels
+	Labels []guid.GUID `json:"labels"`
 }
 
 type DeleteLabelInput struct {
 	ID guid.GUID `json:"id"`
 }
 
-type GetLabelsInput struct {
+type ListLabelsInput struct {
 	WebsiteID guid.GUID `json:"website_id"`
 }
 
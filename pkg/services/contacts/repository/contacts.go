@@ -31,15 +31,15 @@ func (repo *ContactsRepository) CreateContact(ctx context.Context, db db.Queryer
 	const query = `INSERT INTO contacts
 				(id, created_at, updated_at, email, subscribed_to_newsletter_at, subscribed_to_product_updates_at,
 					verified, name, country_code, failed_signup_attempts, signup_code_hash,
-					billing_address, stripe_customer_id, blocked_at,
+					billing_address, stripe_customer_id, blocked_at, bounced_at, complained_at,
 					website_id)
-			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)`
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)`
 
 	_, err = db.Exec(ctx, query, contact.ID, contact.CreatedAt, contact.UpdatedAt, contact.Email,
 		contact.SubscribedToNewsletterAt, contact.SubscribedToProductUpdatesAt, contact.Verified,
 		contact.Name, contact.CountryCode, contact.FailedSignupAttempts, contact.SignupCodeHash,
 		contact.BillingAddress, contact.StripeCustomerID,
-		contact.BlockedAt,
+		contact.BlockedAt, contact.BouncedAt, contact.ComplainedAt,
 		contact.WebsiteID)
 	if err != nil {
 		err = fmt.Errorf("contacts.CreateContact: %w", err)
@@ -69,13 +69,13 @@ func (repo *ContactsRepository) UpdateContact(ctx context.Context, db db.Queryer
 	const query = `UPDATE contacts
 		SET updated_at = $1, email = $2, subscribed_to_newsletter_at = $3, subscribed_to_product_updates_at = $4,
 			verified = $5, name = $6, country_code = $7, failed_signup_attempts = $8, signup_code_hash = $9,
-			billing_address = $10, stripe_customer_id = $11, blocked_at = $12
-		WHERE id = $13`
+			billing_address = $10, stripe_customer_id = $11, blocked_at = $12, bounced_at = $13, complained_at = $14
+		WHERE id = $15`
 
 	_, err = db.Exec(ctx, query, contact.UpdatedAt, contact.Email, contact.SubscribedToNewsletterAt,
 		contact.SubscribedToProductUpdatesAt, contact.Verified, contact.Name, contact.CountryCode,
 		contact.FailedSignupAttempts, contact.SignupCodeHash, contact.BillingAddress,
-		contact.StripeCustomerID, contact.BlockedAt,
+		contact.StripeCustomerID, contact.BlockedAt, contact.BouncedAt, contact.ComplainedAt,
 		contact.ID)
 	if err != nil {
 		err = fmt.Errorf("contacts.UpdateContact: %w", err)
@@ -115,15 +115,21 @@ func (repo *ContactsRepository) FindContactsByEmail(ctx context.Context, db db.Q
 	return ret, nil
 }
 
-func (repo *ContactsRepository) FindVerifiedContactsForWebsite(ctx context.Context, db db.Queryer, websiteID guid.GUID, searchQuery string, limit int64) (ret []contacts.Contact, err error) {
+func (repo *ContactsRepository) FindVerifiedContactsForWebsite(ctx context.Context, db db.Queryer, websiteID guid.GUID, searchQuery string, labels []guid.GUID, limit int64) (ret []contacts.Contact, err error) {
 	ret = make([]contacts.Contact, 0)
+	if labels == nil {
+		labels = []guid.GUID{}
+	}
 	const query = `SELECT * FROM contacts
 		WHERE website_id = $1 AND verified = $2 AND email LIKE $3 || '%'
+			AND (cardinality($4::UUID[]) = 0 OR id IN (
+				SELECT contact_id FROM contacts_labels WHERE label_id = ANY($4)
+			))
 		ORDER BY id DESC
-		LIMIT $4
+		LIMIT $5
 	`
 
-	err = db.Select(ctx, &ret, query, websiteID, true, searchQuery, limit)
+	err = db.Select(ctx, &ret, query, websiteID, true, searchQuery, labels, limit)
 	if err != nil {
 		err = fmt.Errorf("contacts.FindVerifiedContactsForWebsite: %w", err)
 		return
@@ -132,15 +138,29 @@ func (repo *ContactsRepository) FindVerifiedContactsForWebsite(ctx context.Conte
 	return
 }
 
-func (repo *ContactsRepository) FindVerifiedAndSubscribedToNewsletterContacts(ctx context.Context, db db.Queryer, websiteID guid.GUID) (ret []contacts.Contact, err error) {
+func (repo *ContactsRepository) FindVerifiedAndSubscribedToNewsletterContacts(ctx context.Context, db db.Queryer, websiteID guid.GUID, includedLabels, excludedLabels []guid.GUID) (ret []contacts.Contact, err error) {
 	ret = make([]contacts.Contact, 0)
+	if includedLabels == nil {
+		includedLabels = []guid.GUID{}
+	}
+	if excludedLabels == nil {
+		excludedLabels = []guid.GUID{}
+	}
 	const query = `SELECT * FROM contacts
 		WHERE website_id = $1
 			AND verified = $2
 			AND subscribed_to_newsletter_at IS NOT NULL
+			AND bounced_at IS NULL
+			AND complained_at IS NULL
+			AND (cardinality($3::UUID[]) = 0 OR id IN (
+				SELECT contact_id FROM contacts_labels WHERE label_id = ANY($3)
+			))
+			AND id NOT IN (
+				SELECT contact_id FROM contacts_labels WHERE label_id = ANY($4)
+			)
 `
 
-	err = db.Select(ctx, &ret, query, websiteID, true)
+	err = db.Select(ctx, &ret, query, websiteID, true, includedLabels, excludedLabels)
 	if err != nil {
 		err = fmt.Errorf("contacts.FindVerifiedAndSubscribedToNewsletterContacts: %w", err)
 		return
@@ -0,0 +1,235 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/bloom42/stdx-go/db"
+	"github.com/bloom42/stdx-go/guid"
+	"github.com/bloom42/stdx-go/migrate"
+	"markdown.ninja/migrations"
+	"markdown.ninja/pkg/services/contacts"
+	"markdown.ninja/pkg/services/kernel"
+	"markdown.ninja/pkg/services/organizations"
+	organizationsrepository "markdown.ninja/pkg/services/organizations/repository"
+	"markdown.ninja/pkg/services/websites"
+	websitesrepository "markdown.ninja/pkg/services/websites/repository"
+)
+
+// errRollbackTestTransaction is returned by the test transactions so nothing is saved in the database
+var errRollbackTestTransaction = errors.New("rollback test transaction")
+
+// withTestTransaction runs fn within a transaction of the database at DATABASE_URL that is always
+// rolled back. The test is skipped if DATABASE_URL is not set.
+func withTestTransaction(t *testing.T, fn func(ctx context.Context, tx db.Tx)) {
+	databaseUrl := os.Getenv("DATABASE_URL")
+	if databaseUrl == "" {
+		t.Skip("DATABASE_URL is not set")
+	}
+	ctx := context.Background()
+
+	database, err := db.Connect(databaseUrl, 2)
+	if err != nil {
+		t.Fatalf("connecting to database: %s", err)
+	}
+	defer database.Close()
+
+	dbMigrations, err := migrate.Load(migrations.MigrationsFs)
+	if err != nil {
+		t.Fatalf("loading migrations: %s", err)
+	}
+	err = db.Migrate(ctx, slog.New(slog.DiscardHandler), database, dbMigrations)
+	if err != nil {
+		t.Fatalf("applying migrations: %s", err)
+	}
+
+	err = database.Transaction(ctx, func(tx db.Tx) error {
+		fn(ctx, tx)
+		return errRollbackTestTransaction
+	})
+	if !errors.Is(err, errRollbackTestTransaction) {
+		t.Fatalf("running test transaction: %s", err)
+	}
+}
+
+func createTestWebsite(t *testing.T, ctx context.Context, tx db.Tx) websites.Website {
+	now := time.Now().UTC()
+	organization := organizations.Organization{
+		ID:        guid.NewTimeBased(),
+		CreatedAt: now,
+		UpdatedAt: now,
+		Name:      "Labels",
+		Plan:      kernel.PlanFree.ID,
+	}
+	organizationsRepo := organizationsrepository.NewOrganizationsRepository()
+	err := organizationsRepo.CreateOrganization(ctx, tx, organization)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	website := websites.Website{
+		ID:             guid.NewTimeBased(),
+		CreatedAt:      now,
+		UpdatedAt:      now,
+		ModifiedAt:     now,
+		Name:           "Labels",
+		Slug:           "labels-" + guid.NewTimeBased().String(),
+		Language:       "en",
+		PrimaryDomain:  guid.NewTimeBased().String() + ".example.com",
+		Currency:       websites.CurrencyUSD,
+		Theme:          "blog",
+		OrganizationID: organization.ID,
+	}
+	websitesRepo := websitesrepository.NewWebsitesRepository()
+	err = websitesRepo.CreateWebsite(ctx, tx, website)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return website
+}
+
+// createTestContact creates a verified contact subscribed to the newsletter with the given labels.
+// The fields of contact override the defaults.
+func createTestContact(t *testing.T, ctx context.Context, tx db.Tx, websiteID guid.GUID, contact contacts.Contact, labels ...contacts.Label) contacts.Contact {
+	repo := NewContactsRepository()
+	now := time.Now().UTC()
+
+	contact.ID = guid.NewTimeBased()
+	contact.CreatedAt = now
+	contact.UpdatedAt = now
+	contact.Email = contact.ID.String() + "@example.com"
+	contact.Verified = true
+	if contact.SubscribedToNewsletterAt == nil {
+		contact.SubscribedToNewsletterAt = &now
+	}
+	contact.WebsiteID = websiteID
+
+	err := repo.CreateContact(ctx, tx, contact)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	labelIDs := make([]guid.GUID, len(labels))
+	for i, label := range labels {
+		labelIDs[i] = label.ID
+	}
+	err = repo.AddLabelsToContacts(ctx, tx, []guid.GUID{contact.ID}, labelIDs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return contact
+}
+
+func createTestLabel(t *testing.T, ctx context.Context, tx db.Tx, websiteID guid.GUID, name string) contacts.Label {
+	repo := NewContactsRepository()
+	now := time.Now().UTC()
+
+	label := contacts.Label{
+		ID:        guid.NewTimeBased(),
+		CreatedAt: now,
+		UpdatedAt: now,
+		Name:      name,
+		WebsiteID: websiteID,
+	}
+	err := repo.CreateLabel(ctx, tx, label)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return label
+}
+
+func checkTestContacts(t *testing.T, name string, contactsList []contacts.Contact, expected ...contacts.Contact) {
+	t.Helper()
+
+	if len(contactsList) != len(expected) {
+		t.Errorf("%s: expected %d contacts, got %d", name, len(expected), len(contactsList))
+		return
+	}
+	for _, expectedContact := range expected {
+		if !slices.ContainsFunc(contactsList, func(contact contacts.Contact) bool { return contact.ID.Equal(expectedContact.ID) }) {
+			t.Errorf("%s: contact %s is missing", name, expectedContact.ID)
+		}
+	}
+}
+
+func TestFindVerifiedContactsForWebsiteWithLabels(t *testing.T) {
+	withTestTransaction(t, func(ctx context.Context, tx db.Tx) {
+		repo := NewContactsRepository()
+		website := createTestWebsite(t, ctx, tx)
+		customers := createTestLabel(t, ctx, tx, website.ID, "customers")
+		beta := createTestLabel(t, ctx, tx, website.ID, "beta")
+		unusedLabel := createTestLabel(t, ctx, tx, website.ID, "unused")
+
+		customer := createTestContact(t, ctx, tx, website.ID, contacts.Contact{}, customers)
+		betaCustomer := createTestContact(t, ctx, tx, website.ID, contacts.Contact{}, customers, beta)
+		betaTester := createTestContact(t, ctx, tx, website.ID, contacts.Contact{}, beta)
+		withoutLabels := createTestContact(t, ctx, tx, website.ID, contacts.Contact{})
+
+		tests := []struct {
+			name     string
+			labels   []guid.GUID
+			expected []contacts.Contact
+		}{
+			{"no labels", nil, []contacts.Contact{customer, betaCustomer, betaTester, withoutLabels}},
+			{"one label", []guid.GUID{customers.ID}, []contacts.Contact{customer, betaCustomer}},
+			{"any of the labels", []guid.GUID{customers.ID, beta.ID}, []contacts.Contact{customer, betaCustomer, betaTester}},
+			{"unused label", []guid.GUID{unusedLabel.ID}, []contacts.Contact{}},
+		}
+
+		for _, test := range tests {
+			contactsList, err := repo.FindVerifiedContactsForWebsite(ctx, tx, website.ID, "", test.labels, 100)
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkTestContacts(t, test.name, contactsList, test.expected...)
+		}
+	})
+}
+
+func TestFindVerifiedAndSubscribedToNewsletterContactsSegments(t *testing.T) {
+	withTestTransaction(t, func(ctx context.Context, tx db.Tx) {
+		repo := NewContactsRepository()
+		website := createTestWebsite(t, ctx, tx)
+		customers := createTestLabel(t, ctx, tx, website.ID, "customers")
+		beta := createTestLabel(t, ctx, tx, website.ID, "beta")
+		churned := createTestLabel(t, ctx, tx, website.ID, "churned")
+		now := time.Now().UTC()
+
+		customer := createTestContact(t, ctx, tx, website.ID, contacts.Contact{}, customers)
+		betaCustomer := createTestContact(t, ctx, tx, website.ID, contacts.Contact{}, customers, beta)
+		churnedCustomer := createTestContact(t, ctx, tx, website.ID, contacts.Contact{}, customers, churned)
+		withoutLabels := createTestContact(t, ctx, tx, website.ID, contacts.Contact{})
+		// contacts that never receive newsletters, whatever their labels
+		createTestContact(t, ctx, tx, website.ID, contacts.Contact{BouncedAt: &now}, customers)
+		createTestContact(t, ctx, tx, website.ID, contacts.Contact{ComplainedAt: &now}, customers)
+
+		tests := []struct {
+			name     string
+			included []guid.GUID
+			excluded []guid.GUID
+			expected []contacts.Contact
+		}{
+			{"all the subscribers", nil, nil, []contacts.Contact{customer, betaCustomer, churnedCustomer, withoutLabels}},
+			{"included label", []guid.GUID{customers.ID}, nil, []contacts.Contact{customer, betaCustomer, churnedCustomer}},
+			{"any of the included labels", []guid.GUID{beta.ID, churned.ID}, nil, []contacts.Contact{betaCustomer, churnedCustomer}},
+			{"excluded label", nil, []guid.GUID{churned.ID}, []contacts.Contact{customer, betaCustomer, withoutLabels}},
+			{"included and excluded labels", []guid.GUID{customers.ID}, []guid.GUID{beta.ID, churned.ID}, []contacts.Contact{customer}},
+		}
+
+		for _, test := range tests {
+			contactsList, err := repo.FindVerifiedAndSubscribedToNewsletterContacts(ctx, tx, website.ID, test.included, test.excluded)
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkTestContacts(t, test.name, contactsList, test.expected...)
+		}
+	})
+}
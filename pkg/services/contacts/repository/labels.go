@@ -0,0 +1,184 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/bloom42/stdx-go/db"
+	"github.com/bloom42/stdx-go/guid"
+	"markdown.ninja/pkg/services/contacts"
+)
+
+func (repo *ContactsRepository) CreateLabel(ctx context.Context, db db.Queryer, label contacts.Label) (err error) {
+	const query = `INSERT INTO labels
+				(id, created_at, updated_at, name, description, website_id)
+			VALUES ($1, $2, $3, $4, $5, $6)`
+
+	_, err = db.Exec(ctx, query, label.ID, label.CreatedAt, label.UpdatedAt, label.Name, label.Description,
+		label.WebsiteID)
+	if err != nil {
+		return fmt.Errorf("contacts.CreateLabel: %w", err)
+	}
+
+	return nil
+}
+
+func (repo *ContactsRepository) UpdateLabel(ctx context.Context, db db.Queryer, label contacts.Label) (err error) {
+	const query = `UPDATE labels
+		SET updated_at = $1, name = $2, description = $3
+		WHERE id = $4`
+
+	_, err = db.Exec(ctx, query, label.UpdatedAt, label.Name, label.Description,
+		label.ID)
+	if err != nil {
+		return fmt.Errorf("contacts.UpdateLabel: %w", err)
+	}
+
+	return nil
+}
+
+func (repo *ContactsRepository) DeleteLabel(ctx context.Context, db db.Queryer, labelID guid.GUID) (err error) {
+	const query = `DELETE FROM labels WHERE id = $1`
+
+	_, err = db.Exec(ctx, query, labelID)
+	if err != nil {
+		return fmt.Errorf("contacts.DeleteLabel: %w", err)
+	}
+
+	return nil
+}
+
+func (repo *ContactsRepository) FindLabelByID(ctx context.Context, db db.Queryer, labelID guid.GUID) (label contacts.Label, err error) {
+	const query = "SELECT * FROM labels WHERE id = $1"
+
+	err = db.Get(ctx, &label, query, labelID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return label, contacts.ErrLabelNotFound
+		} else {
+			return label, fmt.Errorf("contacts.FindLabelByID: %w", err)
+		}
+	}
+
+	return label, nil
+}
+
+func (repo *ContactsRepository) FindLabelByName(ctx context.Context, db db.Queryer, websiteID guid.GUID, name string) (label contacts.Label, err error) {
+	const query = "SELECT * FROM labels WHERE website_id = $1 AND name = $2"
+
+	err = db.Get(ctx, &label, query, websiteID, name)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return label, contacts.ErrLabelNotFound
+		} else {
+			return label, fmt.Errorf("contacts.FindLabelByName: %w", err)
+		}
+	}
+
+	return label, nil
+}
+
+func (repo *ContactsRepository) FindLabelsForWebsite(ctx context.Context, db db.Queryer, websiteID guid.GUID) (labels []contacts.Label, err error) {
+	labels = make([]contacts.Label, 0)
+	const query = "SELECT * FROM labels WHERE website_id = $1 ORDER BY name"
+
+	err = db.Select(ctx, &labels, query, websiteID)
+	if err != nil {
+		return labels, fmt.Errorf("contacts.FindLabelsForWebsite: %w", err)
+	}
+
+	return labels, nil
+}
+
+func (repo *ContactsRepository) FindLabelsByIDs(ctx context.Context, db db.Queryer, websiteID guid.GUID, labelIDs []guid.GUID) (labels []contacts.Label, err error) {
+	labels = make([]contacts.Label, 0, len(labelIDs))
+	if len(labelIDs) == 0 {
+		return
+	}
+
+	const query = "SELECT * FROM labels WHERE website_id = $1 AND id = ANY($2) ORDER BY name"
+
+	err = db.Select(ctx, &labels, query, websiteID, labelIDs)
+	if err != nil {
+		return labels, fmt.Errorf("contacts.FindLabelsByIDs: %w", err)
+	}
+
+	return labels, nil
+}
+
+func (repo *ContactsRepository) GetLabelsCountForWebsite(ctx context.Context, db db.Queryer, websiteID guid.GUID) (count int64, err error) {
+	const query = "SELECT COUNT(*) FROM labels WHERE website_id = $1"
+
+	err = db.Get(ctx, &count, query, websiteID)
+	if err != nil {
+		return count, fmt.Errorf("contacts.GetLabelsCountForWebsite: %w", err)
+	}
+
+	return count, nil
+}
+
+func (repo *ContactsRepository) FindLabelsForContact(ctx context.Context, db db.Queryer, contactID guid.GUID) (labels []contacts.Label, err error) {
+	labels = make([]contacts.Label, 0)
+	const query = `SELECT labels.* FROM labels
+			INNER JOIN contacts_labels ON contacts_labels.label_id = labels.id
+		WHERE contacts_labels.contact_id = $1
+		ORDER BY labels.name
+	`
+
+	err = db.Select(ctx, &labels, query, contactID)
+	if err != nil {
+		return labels, fmt.Errorf("contacts.FindLabelsForContact: %w", err)
+	}
+
+	return labels, nil
+}
+
+func (repo *ContactsRepository) FindLabelsForContacts(ctx context.Context, db db.Queryer, contactIDs []guid.GUID) (labels []contacts.ContactLabel, err error) {
+	labels = make([]contacts.ContactLabel, 0)
+	if len(contactIDs) == 0 {
+		return
+	}
+
+	const query = `SELECT labels.*, contacts_labels.contact_id FROM labels
+			INNER JOIN contacts_labels ON contacts_labels.label_id = labels.id
+		WHERE contacts_labels.contact_id = ANY($1)
+		ORDER BY labels.name
+	`
+
+	err = db.Select(ctx, &labels, query, contactIDs)
+	if err != nil {
+		return labels, fmt.Errorf("contacts.FindLabelsForContacts: %w", err)
+	}
+
+	return labels, nil
+}
+
+// AddLabelsToContacts adds all the given labels to all the given contacts. Existing relations are ignored.
+func (repo *ContactsRepository) AddLabelsToContacts(ctx context.Context, db db.Queryer, contactIDs, labelIDs []guid.GUID) (err error) {
+	if len(contactIDs) == 0 || len(labelIDs) == 0 {
+		return nil
+	}
+
+	const query = `INSERT INTO contacts_labels (contact_id, label_id)
+		SELECT contact_id, label_id FROM UNNEST($1::UUID[]) AS contact_id CROSS JOIN UNNEST($2::UUID[]) AS label_id
+		ON CONFLICT DO NOTHING`
+
+	_, err = db.Exec(ctx, query, contactIDs, labelIDs)
+	if err != nil {
+		return fmt.Errorf("contacts.AddLabelsToContacts: %w", err)
+	}
+
+	return nil
+}
+
+func (repo *ContactsRepository) DeleteLabelsForContact(ctx context.Context, db db.Queryer, contactID guid.GUID) (err error) {
+	const query = `DELETE FROM contacts_labels WHERE contact_id = $1`
+
+	_, err = db.Exec(ctx, query, contactID)
+	if err != nil {
+		return fmt.Errorf("contacts.DeleteLabelsForContact: %w", err)
+	}
+
+	return nil
+}
@@ -20,7 +20,9 @@ type Service interface {
 	ListContacts(ctx context.Context, input ListContactsInput) (contacts kernel.PaginatedResult[Contact], err error)
 	GetContact(ctx context.Context, input GetContactInput) (contact Contact, err error)
 	ImportContacts(ctx context.Context, input ImportContactsInput) (contacts []Contact, err error)
-	FindVerifiedAndSubscribedToNewsletterContacts(ctx context.Context, db db.Queryer, websiteID guid.GUID) (contacts []Contact, err error)
+	// FindVerifiedAndSubscribedToNewsletterContacts returns the contacts subscribed to the newsletter that
+	// have at least one of includedLabels (if any) and none of excludedLabels
+	FindVerifiedAndSubscribedToNewsletterContacts(ctx context.Context, db db.Queryer, websiteID guid.GUID, includedLabels, excludedLabels []guid.GUID) (contacts []Contact, err error)
 	GetVerifiedAndSubscribedToNewsletterContactsCount(ctx context.Context, db db.Queryer, websiteID guid.GUID) (count int64, err error)
 	FindContactByEmail(ctx context.Context, db db.Queryer, websiteID guid.GUID, email string) (contact Contact, err error)
 	FindContact(ctx context.Context, db db.Queryer, contactID guid.GUID) (contact Contact, err error)
@@ -39,6 +41,16 @@ type Service interface {
 	UnblockContact(ctx context.Context, input UnblockContactInput) (contact Contact, err error)
 	ParseAndVerifyUnsubscribeToken(token string) (contactID guid.GUID, err error)
 	DeleteContactInternal(ctx context.Context, db db.Queryer, contactID, websiteID guid.GUID) (err error)
+	// HandleMailerWebhook processes the bounces and complaints reported by the email provider
+	HandleMailerWebhook(ctx context.Context, headers http.Header, payload []byte) (err error)
+
+	// Labels
+	CreateLabel(ctx context.Context, input CreateLabelInput) (label Label, err error)
+	UpdateLabel(ctx context.Context, input UpdateLabelInput) (label Label, err error)
+	DeleteLabel(ctx context.Context, input DeleteLabelInput) (err error)
+	ListLabels(ctx context.Context, input ListLabelsInput) (labels kernel.PaginatedResult[Label], err error)
+	// FindLabelsByIDs returns an error if any of the labels doesn't exist for the given website
+	FindLabelsByIDs(ctx context.Context, db db.Queryer, websiteID guid.GUID, labelIDs []guid.GUID) (labels []Label, err error)
 
 	// Sessions
 	VerifySessionToken(ctx context.Context, token string) (contactAndSession ContactAndSession, err error)
@@ -6,20 +6,16 @@ import (
 
 	"github.com/bloom42/stdx-go/db"
 	"markdown.ninja/pkg/services/contacts"
+	"markdown.ninja/pkg/services/organizations"
 )
 
 func (service *ContactsService) BlockContact(ctx context.Context, input contacts.BlockContactInput) (contact contacts.Contact, err error) {
-	actorID, err := service.kernel.CurrentUserID(ctx)
-	if err != nil {
-		return
-	}
-
 	contact, err = service.repo.FindContactByID(ctx, service.db, input.ID)
 	if err != nil {
 		return
 	}
 
-	err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, contact.WebsiteID)
+	err = service.checkCanManageContacts(ctx, contact.WebsiteID, organizations.ApiKeyScopeContactsWrite)
 	if err != nil {
 		return
 	}
@@ -43,7 +39,14 @@ func (service *ContactsService) BlockContact(ctx context.Context, input contacts
 			return
 		}
 
-		return nil
+		txErr = service.organizationsService.RecordAuditLog(ctx, tx, organizations.RecordAuditLogInput{
+			WebsiteID: &contact.WebsiteID,
+			Action:    organizations.AuditLogActionContactBlock,
+			EntityID:  contact.ID.String(),
+			Before:    map[string]any{"blocked_at": nil},
+			After:     map[string]any{"blocked_at": contact.BlockedAt},
+		})
+		return txErr
 	})
 	if err != nil {
 		return
@@ -1,14 +1,20 @@
 package service
 
 import (
+	"context"
 	"fmt"
 	"net/url"
 	"strings"
 	"time"
 
+	"github.com/bloom42/stdx-go/db"
 	"github.com/bloom42/stdx-go/guid"
 	"markdown.ninja/pkg/jwt"
+	"markdown.ninja/pkg/server/httpctx"
 	"markdown.ninja/pkg/services/contacts"
+	"markdown.ninja/pkg/services/kernel"
+	"markdown.ninja/pkg/services/organizations"
+	"markdown.ninja/pkg/services/webhooks"
 )
 
 const (
@@ -150,3 +156,39 @@ func (service *ContactsService) extractNameFromEmail(email string) string {
 	emailParts := strings.Split(email, "@")
 	return emailParts[0]
 }
+
+// dispatchSubscriberEvent sends a subscriber.added or subscriber.removed event to the webhook endpoints
+// of the website of the contact
+func (service *ContactsService) dispatchSubscriberEvent(ctx context.Context, db db.Queryer, eventType webhooks.EventType, contact contacts.Contact) error {
+	return service.webhooksService.DispatchEvent(ctx, db, webhooks.DispatchEventInput{
+		WebsiteID: contact.WebsiteID,
+		Type:      eventType,
+		Data: webhooks.EventDataSubscriber{
+			ContactID: contact.ID,
+			Email:     contact.Email,
+			Name:      contact.Name,
+		},
+	})
+}
+
+// checkCanManageContacts verifies that the current user can manage the contacts of the website or, when
+// authenticated with an API key, that the key has the given scope for the website.
+func (service *ContactsService) checkCanManageContacts(ctx context.Context, websiteID guid.GUID, scope organizations.ApiKeyScope) (err error) {
+	actorID, err := service.kernel.CurrentUserID(ctx)
+	if err == nil {
+		return service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, websiteID, kernel.PermissionManageContacts)
+	}
+
+	httpCtx := httpctx.FromCtx(ctx)
+	if httpCtx.ApiKey == nil {
+		return kernel.ErrPermissionDenied
+	}
+
+	website, err := service.websitesService.FindWebsiteByID(ctx, service.db, websiteID)
+	if err != nil {
+		return err
+	}
+
+	_, err = service.organizationsService.CheckCurrentApiKey(ctx, website.OrganizationID, website.ID, scope)
+	return err
+}
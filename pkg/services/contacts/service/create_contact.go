@@ -12,15 +12,11 @@ import (
 	"markdown.ninja/pkg/server/httpctx"
 	"markdown.ninja/pkg/services/contacts"
 	"markdown.ninja/pkg/services/kernel"
+	"markdown.ninja/pkg/services/organizations"
 )
 
 func (service *ContactsService) CreateContact(ctx context.Context, input contacts.CreateContactInput) (contact contacts.Contact, err error) {
-	actorID, err := service.kernel.CurrentUserID(ctx)
-	if err != nil {
-		return
-	}
-
-	err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, input.WebsiteID)
+	err = service.checkCanManageContacts(ctx, input.WebsiteID, organizations.ApiKeyScopeContactsWrite)
 	if err != nil {
 		return
 	}
@@ -57,7 +53,21 @@ func (service *ContactsService) CreateContact(ctx context.Context, input contact
 		WebsiteID:   input.WebsiteID,
 		CountryCode: countries.CodeUnknown,
 	}
-	contact, err = service.CreateContactInternal(ctx, service.db, createContactInput)
+	err = service.db.Transaction(ctx, func(tx db.Tx) (txErr error) {
+		contact, txErr = service.CreateContactInternal(ctx, tx, createContactInput)
+		if txErr != nil {
+			return txErr
+		}
+
+		txErr = service.organizationsService.RecordAuditLog(ctx, tx, organizations.RecordAuditLogInput{
+			WebsiteID: &contact.WebsiteID,
+			Action:    organizations.AuditLogActionContactCreate,
+			EntityID:  contact.ID.String(),
+			Before:    nil,
+			After:     contact,
+		})
+		return txErr
+	})
 	if err != nil {
 		return
 	}
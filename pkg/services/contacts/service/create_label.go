@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/bloom42/stdx-go/guid"
+	"markdown.ninja/pkg/errs"
+	"markdown.ninja/pkg/services/contacts"
+	"markdown.ninja/pkg/services/organizations"
+)
+
+func (service *ContactsService) CreateLabel(ctx context.Context, input contacts.CreateLabelInput) (label contacts.Label, err error) {
+	err = service.checkCanManageContacts(ctx, input.WebsiteID, organizations.ApiKeyScopeContactsWrite)
+	if err != nil {
+		return
+	}
+
+	name := strings.TrimSpace(input.Name)
+	description := strings.TrimSpace(input.Description)
+	now := time.Now().UTC()
+
+	err = service.validateLabelName(name)
+	if err != nil {
+		return
+	}
+
+	err = service.validateLabelDescription(description)
+	if err != nil {
+		return
+	}
+
+	labelsCount, err := service.repo.GetLabelsCountForWebsite(ctx, service.db, input.WebsiteID)
+	if err != nil {
+		return
+	}
+
+	if labelsCount >= contacts.LabelsMaxPerWebsite {
+		err = contacts.ErrTooManyLabels
+		return
+	}
+
+	// check that label with same name doesn't already exists
+	_, err = service.repo.FindLabelByName(ctx, service.db, input.WebsiteID, name)
+	if err == nil {
+		err = contacts.ErrLabelAlreadyExists(name)
+	} else {
+		if errs.IsNotFound(err) {
+			err = nil
+		}
+	}
+	if err != nil {
+		return
+	}
+
+	label = contacts.Label{
+		ID:          guid.NewTimeBased(),
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		Name:        name,
+		Description: description,
+		WebsiteID:   input.WebsiteID,
+	}
+	err = service.repo.CreateLabel(ctx, service.db, label)
+	if err != nil {
+		return
+	}
+
+	return
+}
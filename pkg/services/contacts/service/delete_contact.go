@@ -5,34 +5,47 @@ import (
 
 	"github.com/bloom42/stdx-go/db"
 	"github.com/bloom42/stdx-go/guid"
+	"github.com/bloom42/stdx-go/log/slogx"
 	"markdown.ninja/pkg/errs"
 	"markdown.ninja/pkg/services/contacts"
 	"markdown.ninja/pkg/services/events"
+	"markdown.ninja/pkg/services/organizations"
+	"markdown.ninja/pkg/services/webhooks"
 )
 
 func (service *ContactsService) DeleteContact(ctx context.Context, input contacts.DeleteContactInput) (err error) {
-	actorID, err := service.kernel.CurrentUserID(ctx)
+	contact, err := service.repo.FindContactByID(ctx, service.db, input.ID)
 	if err != nil {
 		return
 	}
 
-	contact, err := service.repo.FindContactByID(ctx, service.db, input.ID)
+	err = service.checkCanManageContacts(ctx, contact.WebsiteID, organizations.ApiKeyScopeContactsWrite)
 	if err != nil {
 		return
 	}
 
-	err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, contact.WebsiteID)
+	err = service.DeleteContactInternal(ctx, service.db, contact.ID, contact.WebsiteID)
 	if err != nil {
 		return
 	}
 
-	err = service.DeleteContactInternal(ctx, service.db, contact.ID, contact.WebsiteID)
+	err = service.organizationsService.RecordAuditLog(ctx, service.db, organizations.RecordAuditLogInput{
+		WebsiteID: &contact.WebsiteID,
+		Action:    organizations.AuditLogActionContactDelete,
+		EntityID:  contact.ID.String(),
+		Before:    contact,
+		After:     nil,
+	})
 	return err
 }
 
 // TODO: track account deleted event instead of unsubscribed event
 // TODO: delete all events for contact?
 func (service *ContactsService) DeleteContactInternal(ctx context.Context, db db.Queryer, contactID, websiteID guid.GUID) (err error) {
+	contact, err := service.repo.FindContactByID(ctx, service.db, contactID)
+	if err != nil {
+		return
+	}
 
 	orders, err := service.storeService.FindOrdersForContact(ctx, service.db, contactID)
 	if err != nil {
@@ -54,5 +67,13 @@ func (service *ContactsService) DeleteContactInternal(ctx context.Context, db db
 	}
 	service.eventsService.TrackUnsubscribedFromNewsletter(ctx, eventInput)
 
+	if contact.SubscribedToNewsletterAt != nil {
+		dispatchErr := service.dispatchSubscriberEvent(ctx, service.db, webhooks.EventTypeSubscriberRemoved, contact)
+		if dispatchErr != nil {
+			logger := slogx.FromCtx(ctx)
+			logger.Error("contacts.DeleteContactInternal: error dispatching webhook event", slogx.Err(dispatchErr))
+		}
+	}
+
 	return
 }
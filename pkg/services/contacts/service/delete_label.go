@@ -0,0 +1,27 @@
+package service
+
+import (
+	"context"
+
+	"markdown.ninja/pkg/services/contacts"
+	"markdown.ninja/pkg/services/organizations"
+)
+
+func (service *ContactsService) DeleteLabel(ctx context.Context, input contacts.DeleteLabelInput) (err error) {
+	label, err := service.repo.FindLabelByID(ctx, service.db, input.ID)
+	if err != nil {
+		return
+	}
+
+	err = service.checkCanManageContacts(ctx, label.WebsiteID, organizations.ApiKeyScopeContactsWrite)
+	if err != nil {
+		return
+	}
+
+	err = service.repo.DeleteLabel(ctx, service.db, label.ID)
+	if err != nil {
+		return
+	}
+
+	return
+}
@@ -10,21 +10,18 @@ import (
 	"github.com/bloom42/stdx-go/log/slogx"
 	"markdown.ninja/pkg/errs"
 	"markdown.ninja/pkg/services/contacts"
+	"markdown.ninja/pkg/services/organizations"
 )
 
 func (service *ContactsService) ExportContacts(ctx context.Context, input contacts.ExportContactsInput) (ret contacts.ExportContactsOutput, err error) {
-	actorID, err := service.kernel.CurrentUserID(ctx)
-	if err != nil {
-		return
-	}
 	logger := slogx.FromCtx(ctx)
 
-	err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, input.WebsiteID)
+	err = service.checkCanManageContacts(ctx, input.WebsiteID, organizations.ApiKeyScopeContactsRead)
 	if err != nil {
 		return
 	}
 
-	contacts, err := service.repo.FindVerifiedContactsForWebsite(ctx, service.db, input.WebsiteID, "", math.MaxInt64)
+	contacts, err := service.repo.FindVerifiedContactsForWebsite(ctx, service.db, input.WebsiteID, "", nil, math.MaxInt64)
 	if err != nil {
 		return
 	}
@@ -63,6 +60,17 @@ func (service *ContactsService) ExportContacts(ctx context.Context, input contac
 		return
 	}
 
+	err = service.organizationsService.RecordAuditLog(ctx, service.db, organizations.RecordAuditLogInput{
+		WebsiteID: &input.WebsiteID,
+		Action:    organizations.AuditLogActionContactsExport,
+		EntityID:  "",
+		Before:    nil,
+		After:     map[string]any{"contacts": len(contacts)},
+	})
+	if err != nil {
+		return
+	}
+
 	ret.Contacts = csvBuffer.String()
 
 	return
@@ -7,13 +7,10 @@ import (
 	"github.com/bloom42/stdx-go/log/slogx"
 	"markdown.ninja/pkg/errs"
 	"markdown.ninja/pkg/services/contacts"
+	"markdown.ninja/pkg/services/organizations"
 )
 
 func (service *ContactsService) ExportContactsForProduct(ctx context.Context, input contacts.ExportContactsForProductInput) (res contacts.ExportContactsForProductOutput, err error) {
-	actorID, err := service.kernel.CurrentUserID(ctx)
-	if err != nil {
-		return
-	}
 	logger := slogx.FromCtx(ctx)
 
 	product, err := service.storeService.FindProduct(ctx, service.db, input.ProductID)
@@ -21,7 +18,7 @@ func (service *ContactsService) ExportContactsForProduct(ctx context.Context, in
 		return
 	}
 
-	err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, product.WebsiteID)
+	err = service.checkCanManageContacts(ctx, product.WebsiteID, organizations.ApiKeyScopeContactsRead)
 	if err != nil {
 		return
 	}
@@ -52,6 +49,17 @@ func (service *ContactsService) ExportContactsForProduct(ctx context.Context, in
 		}
 	}
 
+	err = service.organizationsService.RecordAuditLog(ctx, service.db, organizations.RecordAuditLogInput{
+		WebsiteID: &product.WebsiteID,
+		Action:    organizations.AuditLogActionContactsExport,
+		EntityID:  "",
+		Before:    nil,
+		After:     map[string]any{"contacts": len(contacts), "product_id": product.ID},
+	})
+	if err != nil {
+		return
+	}
+
 	res.Contacts = output.String()
 
 	return
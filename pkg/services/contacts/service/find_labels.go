@@ -0,0 +1,25 @@
+package service
+
+import (
+	"context"
+
+	"github.com/bloom42/stdx-go/db"
+	"github.com/bloom42/stdx-go/guid"
+	"markdown.ninja/pkg/services/contacts"
+)
+
+func (service *ContactsService) FindLabelsByIDs(ctx context.Context, db db.Queryer, websiteID guid.GUID, labelIDs []guid.GUID) (labels []contacts.Label, err error) {
+	labelIDs = uniqueLabelIDs(labelIDs)
+
+	labels, err = service.repo.FindLabelsByIDs(ctx, db, websiteID, labelIDs)
+	if err != nil {
+		return
+	}
+
+	if len(labels) != len(labelIDs) {
+		err = contacts.ErrLabelNotFound
+		return
+	}
+
+	return
+}
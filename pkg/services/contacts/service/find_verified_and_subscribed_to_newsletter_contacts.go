@@ -8,7 +8,7 @@ import (
 	"markdown.ninja/pkg/services/contacts"
 )
 
-func (service *ContactsService) FindVerifiedAndSubscribedToNewsletterContacts(ctx context.Context, db db.Queryer, websiteID guid.GUID) (contacts []contacts.Contact, err error) {
-	contacts, err = service.repo.FindVerifiedAndSubscribedToNewsletterContacts(ctx, db, websiteID)
+func (service *ContactsService) FindVerifiedAndSubscribedToNewsletterContacts(ctx context.Context, db db.Queryer, websiteID guid.GUID, includedLabels, excludedLabels []guid.GUID) (contacts []contacts.Contact, err error) {
+	contacts, err = service.repo.FindVerifiedAndSubscribedToNewsletterContacts(ctx, db, websiteID, includedLabels, excludedLabels)
 	return
 }
@@ -4,30 +4,31 @@ import (
 	"context"
 
 	"markdown.ninja/pkg/services/contacts"
+	"markdown.ninja/pkg/services/organizations"
 )
 
 func (service *ContactsService) GetContact(ctx context.Context, input contacts.GetContactInput) (contact contacts.Contact, err error) {
-	actorID, err := service.kernel.CurrentUserID(ctx)
+	contact, err = service.repo.FindContactByID(ctx, service.db, input.ID)
 	if err != nil {
 		return
 	}
 
-	contact, err = service.repo.FindContactByID(ctx, service.db, input.ID)
+	err = service.checkCanManageContacts(ctx, contact.WebsiteID, organizations.ApiKeyScopeContactsRead)
 	if err != nil {
 		return
 	}
 
-	err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, contact.WebsiteID)
+	contact.Products, err = service.storeService.FindProductsForContact(ctx, service.db, contact.ID)
 	if err != nil {
 		return
 	}
 
-	contact.Products, err = service.storeService.FindProductsForContact(ctx, service.db, contact.ID)
+	contact.Orders, err = service.storeService.FindOrdersForContact(ctx, service.db, contact.ID)
 	if err != nil {
 		return
 	}
 
-	contact.Orders, err = service.storeService.FindOrdersForContact(ctx, service.db, contact.ID)
+	contact.Labels, err = service.repo.FindLabelsForContact(ctx, service.db, contact.ID)
 	if err != nil {
 		return
 	}
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/bloom42/stdx-go/db"
+	"github.com/bloom42/stdx-go/guid"
+	"github.com/bloom42/stdx-go/log/slogx"
+	"markdown.ninja/pkg/mailer"
+	"markdown.ninja/pkg/services/contacts"
+	"markdown.ninja/pkg/services/events"
+)
+
+// HandleMailerWebhook marks the contacts as bounced (hard bounces only) or complained so they no longer
+// receive newsletters, and records the bounces and complaints for analytics.
+// Like suppressions, bounces and complaints apply to ALL the contacts with the same email address.
+func (service *ContactsService) HandleMailerWebhook(ctx context.Context, headers http.Header, payload []byte) (err error) {
+	logger := slogx.FromCtx(ctx)
+
+	webhookEvents, err := service.mailer.ParseAndVerifyWebhook(ctx, headers, payload)
+	if err != nil {
+		logger.Warn("contacts.HandleMailerWebhook: error verifying webhook", slogx.Err(err))
+		err = contacts.ErrMailerWebhookIsNotValid
+		return
+	}
+
+	for _, event := range webhookEvents {
+		if event.Type == mailer.WebhookEventTypeComplaint || event.Permanent {
+			for _, recipient := range event.Recipients {
+				err = service.markEmailAsBouncedOrComplained(ctx, recipient, event.Type)
+				if err != nil {
+					return
+				}
+			}
+		}
+
+		service.trackMailerWebhookEvent(ctx, event)
+	}
+
+	return nil
+}
+
+func (service *ContactsService) markEmailAsBouncedOrComplained(ctx context.Context, email string, eventType mailer.WebhookEventType) (err error) {
+	now := time.Now().UTC()
+	email = strings.ToLower(strings.TrimSpace(email))
+
+	err = service.db.Transaction(ctx, func(tx db.Tx) (txErr error) {
+		contactsWithEmail, txErr := service.repo.FindContactsByEmail(ctx, tx, email, true)
+		if txErr != nil {
+			return txErr
+		}
+
+		for _, contact := range contactsWithEmail {
+			switch eventType {
+			case mailer.WebhookEventTypeBounce:
+				if contact.BouncedAt != nil {
+					continue
+				}
+				contact.BouncedAt = &now
+			case mailer.WebhookEventTypeComplaint:
+				if contact.ComplainedAt != nil {
+					continue
+				}
+				contact.ComplainedAt = &now
+			}
+
+			contact.UpdatedAt = now
+			txErr = service.repo.UpdateContact(ctx, tx, contact)
+			if txErr != nil {
+				return fmt.Errorf("contacts.markEmailAsBouncedOrComplained: error updating contact: %w", txErr)
+			}
+		}
+
+		return nil
+	})
+	return
+}
+
+// trackMailerWebhookEvent records the event if the email was sent for a website, which is known thanks to
+// the tags added to the email when sending it.
+func (service *ContactsService) trackMailerWebhookEvent(ctx context.Context, event mailer.WebhookEvent) {
+	websiteID, err := guid.Parse(event.Tags[mailer.TagWebsiteID])
+	if err != nil {
+		return
+	}
+
+	var newsletterID *guid.GUID
+	if newsletterIDStr, ok := event.Tags[mailer.TagNewsletterID]; ok {
+		parsedNewsletterID, parseErr := guid.Parse(newsletterIDStr)
+		if parseErr != nil {
+			slogx.FromCtx(ctx).Warn("contacts.trackMailerWebhookEvent: newsletter_id tag is not valid",
+				slog.String("newsletter_id", newsletterIDStr))
+		} else {
+			newsletterID = &parsedNewsletterID
+		}
+	}
+
+	for range event.Recipients {
+		switch event.Type {
+		case mailer.WebhookEventTypeBounce:
+			service.eventsService.TrackEmailBounced(ctx, events.TrackEmailBouncedInput{
+				Permanent:    event.Permanent,
+				WebsiteID:    websiteID,
+				NewsletterID: newsletterID,
+			})
+		case mailer.WebhookEventTypeComplaint:
+			service.eventsService.TrackEmailComplained(ctx, events.TrackEmailComplainedInput{
+				WebsiteID:    websiteID,
+				NewsletterID: newsletterID,
+			})
+		}
+	}
+}
@@ -15,6 +15,8 @@ import (
 	"markdown.ninja/pkg/services/contacts"
 	"markdown.ninja/pkg/services/events"
 	"markdown.ninja/pkg/services/kernel"
+	"markdown.ninja/pkg/services/organizations"
+	"markdown.ninja/pkg/services/webhooks"
 )
 
 func (service *ContactsService) ImportContacts(ctx context.Context, input contacts.ImportContactsInput) (ret []contacts.Contact, err error) {
@@ -29,7 +31,7 @@ func (service *ContactsService) ImportContacts(ctx context.Context, input contac
 		return ret, errs.PermissionDenied("Please contact support to import contacts")
 	}
 
-	// err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, input.WebsiteID)
+	// err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, input.WebsiteID, kernel.PermissionManageContacts)
 	// if err != nil {
 	// 	return
 	// }
@@ -56,6 +58,11 @@ func (service *ContactsService) ImportContacts(ctx context.Context, input contac
 		return ret, contacts.ErrImportCsvHeaderisNotValid
 	}
 
+	labels, err := service.FindLabelsByIDs(ctx, service.db, input.WebsiteID, input.Labels)
+	if err != nil {
+		return
+	}
+
 	now := time.Now().UTC()
 	importedContacts := make([]contacts.Contact, 0, len(csvRecords))
 
@@ -125,6 +132,7 @@ func (service *ContactsService) ImportContacts(ctx context.Context, input contac
 	eventsToSave := make([]events.TrackSubscribedToNewsletterInput, 0, len(importedContacts))
 
 	err = service.db.Transaction(ctx, func(tx db.Tx) (txErr error) {
+		contactIDs := make([]guid.GUID, 0, len(importedContacts))
 
 		// TODO: improve perfs
 		for _, importedContact := range importedContacts {
@@ -141,18 +149,27 @@ func (service *ContactsService) ImportContacts(ctx context.Context, input contac
 				if txErr != nil {
 					return txErr
 				}
+				contactIDs = append(contactIDs, importedContact.ID)
 
 				if importedContact.SubscribedToNewsletterAt != nil {
 					trackEventInput := events.TrackSubscribedToNewsletterInput{
 						WebsiteID: input.WebsiteID,
 					}
 					eventsToSave = append(eventsToSave, trackEventInput)
+
+					txErr = service.dispatchSubscriberEvent(ctx, tx, webhooks.EventTypeSubscriberAdded, importedContact)
+					if txErr != nil {
+						return txErr
+					}
 				}
 
 			} else {
+				contactIDs = append(contactIDs, existingContact.ID)
+
 				// if contact exists but is not verified or not subscribed
 				if !existingContact.Verified ||
 					existingContact.SubscribedToNewsletterAt != importedContact.SubscribedToNewsletterAt {
+					wasSubscribedToNewsletter := existingContact.SubscribedToNewsletterAt != nil
 					existingContact.Verified = true
 					existingContact.FailedSignupAttempts = 0
 					existingContact.SubscribedToNewsletterAt = importedContact.SubscribedToNewsletterAt
@@ -167,11 +184,32 @@ func (service *ContactsService) ImportContacts(ctx context.Context, input contac
 						}
 						eventsToSave = append(eventsToSave, trackEventInput)
 					}
+
+					if !wasSubscribedToNewsletter && existingContact.SubscribedToNewsletterAt != nil {
+						txErr = service.dispatchSubscriberEvent(ctx, tx, webhooks.EventTypeSubscriberAdded, existingContact)
+					} else if wasSubscribedToNewsletter && existingContact.SubscribedToNewsletterAt == nil {
+						txErr = service.dispatchSubscriberEvent(ctx, tx, webhooks.EventTypeSubscriberRemoved, existingContact)
+					}
+					if txErr != nil {
+						return txErr
+					}
 				}
 			}
 		}
 
-		return nil
+		txErr = service.repo.AddLabelsToContacts(ctx, tx, contactIDs, labelsIDs(labels))
+		if txErr != nil {
+			return txErr
+		}
+
+		txErr = service.organizationsService.RecordAuditLog(ctx, tx, organizations.RecordAuditLogInput{
+			WebsiteID: &input.WebsiteID,
+			Action:    organizations.AuditLogActionContactsImport,
+			EntityID:  "",
+			Before:    nil,
+			After:     map[string]any{"contacts": len(contactIDs), "labels": labelsIDs(labels)},
+		})
+		return txErr
 	})
 	if err != nil {
 		return
@@ -181,6 +219,9 @@ func (service *ContactsService) ImportContacts(ctx context.Context, input contac
 		service.eventsService.TrackSubscribedToNewsletter(ctx, event)
 	}
 
+	for i := range importedContacts {
+		importedContacts[i].Labels = labels
+	}
 	ret = importedContacts
 
 	return
@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+	"slices"
+
+	"github.com/bloom42/stdx-go/db"
+	"github.com/bloom42/stdx-go/guid"
+	"markdown.ninja/pkg/services/contacts"
+)
+
+func labelsIDs(labels []contacts.Label) []guid.GUID {
+	ret := make([]guid.GUID, len(labels))
+	for i, label := range labels {
+		ret[i] = label.ID
+	}
+	return ret
+}
+
+func uniqueLabelIDs(labelIDs []guid.GUID) []guid.GUID {
+	ret := make([]guid.GUID, 0, len(labelIDs))
+	for _, labelID := range labelIDs {
+		if !slices.ContainsFunc(ret, labelID.Equal) {
+			ret = append(ret, labelID)
+		}
+	}
+	return ret
+}
+
+// attachLabelsToContacts fetches and sets the labels of all the given contacts
+func (service *ContactsService) attachLabelsToContacts(ctx context.Context, db db.Queryer, contactsList []contacts.Contact) (err error) {
+	contactIDs := make([]guid.GUID, len(contactsList))
+	for i, contact := range contactsList {
+		contactIDs[i] = contact.ID
+	}
+
+	contactsLabels, err := service.repo.FindLabelsForContacts(ctx, db, contactIDs)
+	if err != nil {
+		return
+	}
+
+	labelsByContact := make(map[guid.GUID][]contacts.Label, len(contactsList))
+	for _, contactLabel := range contactsLabels {
+		labelsByContact[contactLabel.ContactID] = append(labelsByContact[contactLabel.ContactID], contactLabel.Label)
+	}
+
+	for i := range contactsList {
+		contactsList[i].Labels = labelsByContact[contactsList[i].ID]
+		if contactsList[i].Labels == nil {
+			contactsList[i].Labels = []contacts.Label{}
+		}
+	}
+
+	return nil
+}
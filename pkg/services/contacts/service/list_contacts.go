@@ -8,18 +8,14 @@ import (
 	"markdown.ninja/pkg/server/httpctx"
 	"markdown.ninja/pkg/services/contacts"
 	"markdown.ninja/pkg/services/kernel"
+	"markdown.ninja/pkg/services/organizations"
 )
 
 func (service *ContactsService) ListContacts(ctx context.Context, input contacts.ListContactsInput) (ret kernel.PaginatedResult[contacts.Contact], err error) {
 	httpCtx := httpctx.FromCtx(ctx)
 
-	actorID, err := service.kernel.CurrentUserID(ctx)
-	if err != nil {
-		return
-	}
-
-	if !httpCtx.AccessToken.IsAdmin {
-		err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, input.WebsiteID)
+	if httpCtx.AccessToken == nil || !httpCtx.AccessToken.IsAdmin {
+		err = service.checkCanManageContacts(ctx, input.WebsiteID, organizations.ApiKeyScopeContactsRead)
 		if err != nil {
 			return
 		}
@@ -28,6 +24,11 @@ func (service *ContactsService) ListContacts(ctx context.Context, input contacts
 	limit := int64(math.MaxInt64)
 	searchQuery := strings.TrimSpace(input.Query)
 
-	ret.Data, err = service.repo.FindVerifiedContactsForWebsite(ctx, service.db, input.WebsiteID, searchQuery, limit)
+	ret.Data, err = service.repo.FindVerifiedContactsForWebsite(ctx, service.db, input.WebsiteID, searchQuery, input.Labels, limit)
+	if err != nil {
+		return
+	}
+
+	err = service.attachLabelsToContacts(ctx, service.db, ret.Data)
 	return
 }
@@ -0,0 +1,24 @@
+package service
+
+import (
+	"context"
+
+	"markdown.ninja/pkg/server/httpctx"
+	"markdown.ninja/pkg/services/contacts"
+	"markdown.ninja/pkg/services/kernel"
+	"markdown.ninja/pkg/services/organizations"
+)
+
+func (service *ContactsService) ListLabels(ctx context.Context, input contacts.ListLabelsInput) (ret kernel.PaginatedResult[contacts.Label], err error) {
+	httpCtx := httpctx.FromCtx(ctx)
+
+	if httpCtx.AccessToken == nil || !httpCtx.AccessToken.IsAdmin {
+		err = service.checkCanManageContacts(ctx, input.WebsiteID, organizations.ApiKeyScopeContactsRead)
+		if err != nil {
+			return
+		}
+	}
+
+	ret.Data, err = service.repo.FindLabelsForWebsite(ctx, service.db, input.WebsiteID)
+	return
+}
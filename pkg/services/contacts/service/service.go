@@ -15,7 +15,9 @@ import (
 	"markdown.ninja/pkg/services/emails"
 	"markdown.ninja/pkg/services/events"
 	"markdown.ninja/pkg/services/kernel"
+	"markdown.ninja/pkg/services/organizations"
 	"markdown.ninja/pkg/services/store"
+	"markdown.ninja/pkg/services/webhooks"
 	"markdown.ninja/pkg/services/websites"
 )
 
@@ -28,11 +30,13 @@ type ContactsService struct {
 	jwtProvider *jwt.Provider
 	pingoo      *pingoo.Client
 
-	kernel          kernel.PrivateService
-	websitesService websites.Service
-	storeService    store.Service
-	eventsService   events.Service
-	emailsService   emails.Service
+	kernel               kernel.PrivateService
+	websitesService      websites.Service
+	storeService         store.Service
+	eventsService        events.Service
+	emailsService        emails.Service
+	organizationsService organizations.Service
+	webhooksService      webhooks.Service
 
 	httpConfig               config.Http
 	verifyEmailEmailTemplate *template.Template
@@ -41,7 +45,8 @@ type ContactsService struct {
 func NewContactsService(conf config.Config, db db.DB, mailer mailer.Mailer, queue queue.Queue,
 	jwtProvider *jwt.Provider, kernel kernel.PrivateService,
 	websitesService websites.Service, eventsService events.Service,
-	emailsService emails.Service) (service *ContactsService, err error) {
+	emailsService emails.Service, organizationsService organizations.Service,
+	webhooksService webhooks.Service) (service *ContactsService, err error) {
 	repo := repository.NewContactsRepository()
 
 	verifyEmailEmailTemplate, err := template.New("contacts.verifyEmailEmailTemplate").Parse(templates.VerifyEmailEmailTemplate)
@@ -57,11 +62,13 @@ func NewContactsService(conf config.Config, db db.DB, mailer mailer.Mailer, queu
 		mailer:      mailer,
 		jwtProvider: jwtProvider,
 
-		kernel:          kernel,
-		websitesService: websitesService,
-		storeService:    nil,
-		eventsService:   eventsService,
-		emailsService:   emailsService,
+		kernel:               kernel,
+		websitesService:      websitesService,
+		storeService:         nil,
+		eventsService:        eventsService,
+		emailsService:        emailsService,
+		organizationsService: organizationsService,
+		webhooksService:      webhooksService,
 
 		httpConfig:               conf.HTTP,
 		verifyEmailEmailTemplate: verifyEmailEmailTemplate,
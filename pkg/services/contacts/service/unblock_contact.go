@@ -4,21 +4,18 @@ import (
 	"context"
 	"time"
 
+	"github.com/bloom42/stdx-go/db"
 	"markdown.ninja/pkg/services/contacts"
+	"markdown.ninja/pkg/services/organizations"
 )
 
 func (service *ContactsService) UnblockContact(ctx context.Context, input contacts.UnblockContactInput) (contact contacts.Contact, err error) {
-	actorID, err := service.kernel.CurrentUserID(ctx)
-	if err != nil {
-		return
-	}
-
 	contact, err = service.repo.FindContactByID(ctx, service.db, input.ID)
 	if err != nil {
 		return
 	}
 
-	err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, contact.WebsiteID)
+	err = service.checkCanManageContacts(ctx, contact.WebsiteID, organizations.ApiKeyScopeContactsWrite)
 	if err != nil {
 		return
 	}
@@ -28,10 +25,25 @@ func (service *ContactsService) UnblockContact(ctx context.Context, input contac
 		return
 	}
 
+	blockedAt := contact.BlockedAt
 	now := time.Now().UTC()
 	contact.UpdatedAt = now
 	contact.BlockedAt = nil
-	err = service.repo.UpdateContact(ctx, service.db, contact)
+	err = service.db.Transaction(ctx, func(tx db.Tx) (txErr error) {
+		txErr = service.repo.UpdateContact(ctx, tx, contact)
+		if txErr != nil {
+			return txErr
+		}
+
+		txErr = service.organizationsService.RecordAuditLog(ctx, tx, organizations.RecordAuditLogInput{
+			WebsiteID: &contact.WebsiteID,
+			Action:    organizations.AuditLogActionContactUnblock,
+			EntityID:  contact.ID.String(),
+			Before:    map[string]any{"blocked_at": blockedAt},
+			After:     map[string]any{"blocked_at": nil},
+		})
+		return txErr
+	})
 	if err != nil {
 		return
 	}
@@ -7,33 +7,91 @@ import (
 
 	"github.com/bloom42/stdx-go/countries"
 	"github.com/bloom42/stdx-go/db"
+	"github.com/bloom42/stdx-go/guid"
 	"github.com/bloom42/stdx-go/log/slogx"
 	"github.com/bloom42/stdx-go/queue"
 	"markdown.ninja/pkg/errs"
 	"markdown.ninja/pkg/services/contacts"
+	"markdown.ninja/pkg/services/organizations"
+	"markdown.ninja/pkg/services/webhooks"
 )
 
 func (service *ContactsService) UpdateContact(ctx context.Context, input contacts.UpdateContactInput) (contact contacts.Contact, err error) {
-	currentUserID, err := service.kernel.CurrentUserID(ctx)
+	contact, err = service.repo.FindContactByID(ctx, service.db, input.ID)
 	if err != nil {
 		return
 	}
 
-	contact, err = service.repo.FindContactByID(ctx, service.db, input.ID)
+	err = service.checkCanManageContacts(ctx, contact.WebsiteID, organizations.ApiKeyScopeContactsWrite)
 	if err != nil {
 		return
 	}
 
-	err = service.websitesService.CheckUserIsStaff(ctx, service.db, currentUserID, contact.WebsiteID)
-	if err != nil {
-		return
+	contactBefore := contact
+
+	var labels []contacts.Label
+	if input.Labels != nil {
+		labels, err = service.FindLabelsByIDs(ctx, service.db, contact.WebsiteID, *input.Labels)
+		if err != nil {
+			return
+		}
+
+		contactBefore.Labels, err = service.repo.FindLabelsForContact(ctx, service.db, contact.ID)
+		if err != nil {
+			return
+		}
 	}
 
-	err = service.UpdateContactInternal(ctx, service.db, &contact, input)
+	err = service.db.Transaction(ctx, func(tx db.Tx) (txErr error) {
+		txErr = service.UpdateContactInternal(ctx, tx, &contact, input)
+		if txErr != nil {
+			return txErr
+		}
+
+		if input.Labels != nil {
+			txErr = service.repo.DeleteLabelsForContact(ctx, tx, contact.ID)
+			if txErr != nil {
+				return txErr
+			}
+
+			txErr = service.repo.AddLabelsToContacts(ctx, tx, []guid.GUID{contact.ID}, labelsIDs(labels))
+			if txErr != nil {
+				return txErr
+			}
+			contact.Labels = labels
+		}
+
+		if contactBefore.SubscribedToNewsletterAt == nil && contact.SubscribedToNewsletterAt != nil {
+			txErr = service.dispatchSubscriberEvent(ctx, tx, webhooks.EventTypeSubscriberAdded, contact)
+		} else if contactBefore.SubscribedToNewsletterAt != nil && contact.SubscribedToNewsletterAt == nil {
+			txErr = service.dispatchSubscriberEvent(ctx, tx, webhooks.EventTypeSubscriberRemoved, contact)
+		}
+		if txErr != nil {
+			return txErr
+		}
+
+		txErr = service.organizationsService.RecordAuditLog(ctx, tx, organizations.RecordAuditLogInput{
+			WebsiteID: &contact.WebsiteID,
+			Action:    organizations.AuditLogActionContactUpdate,
+			EntityID:  contact.ID.String(),
+			Before:    contactBefore,
+			After:     contact,
+		})
+		return txErr
+	})
 	if err != nil {
 		return
 	}
 
+	if input.Labels != nil {
+		contact.Labels = labels
+	} else {
+		contact.Labels, err = service.repo.FindLabelsForContact(ctx, service.db, contact.ID)
+		if err != nil {
+			return
+		}
+	}
+
 	return
 }
 
@@ -81,6 +139,9 @@ func (service *ContactsService) UpdateContactInternal(ctx context.Context, db db
 				return err
 			}
 			contact.Email = email
+			// bounces and complaints are related to the previous email address
+			contact.BouncedAt = nil
+			contact.ComplainedAt = nil
 			updateStripeContact = true
 		}
 	}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"markdown.ninja/pkg/errs"
+	"markdown.ninja/pkg/services/contacts"
+	"markdown.ninja/pkg/services/organizations"
+)
+
+func (service *ContactsService) UpdateLabel(ctx context.Context, input contacts.UpdateLabelInput) (label contacts.Label, err error) {
+	label, err = service.repo.FindLabelByID(ctx, service.db, input.ID)
+	if err != nil {
+		return
+	}
+
+	err = service.checkCanManageContacts(ctx, label.WebsiteID, organizations.ApiKeyScopeContactsWrite)
+	if err != nil {
+		return
+	}
+
+	name := strings.TrimSpace(input.Name)
+	description := strings.TrimSpace(input.Description)
+	now := time.Now().UTC()
+
+	err = service.validateLabelName(name)
+	if err != nil {
+		return
+	}
+
+	err = service.validateLabelDescription(description)
+	if err != nil {
+		return
+	}
+
+	if name != label.Name {
+		var existingLabel contacts.Label
+		// check that label with same name doesn't already exists
+		existingLabel, err = service.repo.FindLabelByName(ctx, service.db, label.WebsiteID, name)
+		if err == nil && !existingLabel.ID.Equal(label.ID) {
+			err = contacts.ErrLabelAlreadyExists(name)
+		} else if err != nil {
+			if errs.IsNotFound(err) {
+				err = nil
+			}
+		}
+		if err != nil {
+			return
+		}
+	}
+
+	label.UpdatedAt = now
+	label.Name = name
+	label.Description = description
+
+	err = service.repo.UpdateLabel(ctx, service.db, label)
+	if err != nil {
+		return
+	}
+
+	return
+}
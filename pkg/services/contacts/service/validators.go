@@ -3,8 +3,10 @@ package service
 import (
 	"context"
 	"fmt"
+	"strings"
 	"unicode/utf8"
 
+	"github.com/bloom42/stdx-go/stringsx"
 	"markdown.ninja/pkg/errs"
 	"markdown.ninja/pkg/services/contacts"
 )
@@ -32,3 +34,45 @@ func (service *ContactsService) ValidateContactName(name string) error {
 
 	return nil
 }
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+// Labels
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func (service *ContactsService) validateLabelName(name string) error {
+	if len(name) < contacts.LabelNameMinSize {
+		return contacts.ErrLabelNameIsTooShort
+	}
+
+	if len(name) > contacts.LabelNameMaxSize {
+		return contacts.ErrLabelNameIsTooLong
+	}
+
+	if !utf8.ValidString(name) {
+		return contacts.ErrLabelNameIsNotValid
+	}
+
+	if !stringsx.IsLower(name) {
+		return contacts.ErrLabelNameMustBeLower
+	}
+
+	for _, char := range name {
+		if !strings.ContainsRune(contacts.LabelNameAlphabet, char) {
+			return contacts.ErrLabelNameIsNotValid
+		}
+	}
+
+	return nil
+}
+
+func (service *ContactsService) validateLabelDescription(description string) error {
+	if len(description) > contacts.LabelDescriptionMaxSize {
+		return contacts.ErrLabelDescriptionIsTooLong
+	}
+
+	if !utf8.ValidString(description) {
+		return contacts.ErrLabelDescriptionIsNotValid
+	}
+
+	return nil
+}
@@ -32,6 +32,9 @@ func (service *ContactsService) VerifyEmail(ctx context.Context, input contacts.
 
 	contact.UpdatedAt = time.Now().UTC()
 	contact.Email = jwtClaims.NewEmail
+	// bounces and complaints are related to the previous email address
+	contact.BouncedAt = nil
+	contact.ComplainedAt = nil
 	err = service.repo.UpdateContact(ctx, service.db, *contact)
 	if err != nil {
 		return
@@ -31,9 +31,10 @@ var (
 	ErrAssetIsAFolder = func(path string) error {
 		return errs.InvalidArgument(fmt.Sprintf("%s is a folder", path))
 	}
-	ErrCantDeleteTheAssetsFolder = errs.InvalidArgument("You can't delete the /assets folder")
-	ErrAssetIsNotAVideo          = errs.InvalidArgument("Asset is not a video.")
-	ErrAssetNameIsNotValid       = errs.InvalidArgument("name is not valid.")
+	ErrCantDeleteTheAssetsFolder     = errs.InvalidArgument("You can't delete the /assets folder")
+	ErrAssetIsNotAVideo              = errs.InvalidArgument("Asset is not a video.")
+	ErrAssetNameIsNotValid           = errs.InvalidArgument("name is not valid.")
+	ErrAssetIsNotATransformableImage = errs.InvalidArgument("Asset is not an image that can be transformed.")
 
 	// pages
 	ErrPageTypeIsNotValid                          = errs.InvalidArgument("Page type is not valid.")
@@ -54,6 +55,26 @@ var (
 	ErrOnlyPostsCanBeSentAsNewsletter              = errs.InvalidArgument("Only posts can be sent as newsletter")
 	ErrPageStatusIsNotValid                        = errs.InvalidArgument("status is not valid")
 	ErrSendAsNewsletterCantBeUpdatedAfterBeingSent = errs.InvalidArgument("sendAsNewsletter cannot be updated after the newsletter has been sent")
+	ErrPageTranslationKeyIsNotValid                = errs.InvalidArgument(fmt.Sprintf("Translation key is not valid. It must be at most %d characters long and only contain lowercase letters, numbers and the -_./ characters", PageTranslationKeyMaxSize))
+	ErrPageTranslationAlreadyExists                = func(translationKey, language string) error {
+		return errs.InvalidArgument(fmt.Sprintf("A page with the translation key \"%s\" already exists in this language (%s)", translationKey, language))
+	}
+	ErrPagePathIsNotInLanguagePrefix = func(pathPrefix string) error {
+		return errs.InvalidArgument(fmt.Sprintf("The URL of the pages in this language must start with %s", pathPrefix))
+	}
+
+	// Page revisions
+	ErrPageRevisionNotFound              = errs.NotFound("Page revision not found.")
+	ErrPageRevisionsAreNotForTheSamePage = errs.InvalidArgument("Revisions must belong to the same page.")
+	ErrPageRevisionIsTheCurrentVersion   = errs.InvalidArgument("This revision is already the current version of the page.")
+
+	// Preview tokens
+	ErrPreviewTokenIsNotValid          = errs.PermissionDenied("Preview token is not valid.")
+	ErrPreviewTokenExpiresInIsNotValid = errs.InvalidArgument(fmt.Sprintf("expires_in is not valid (min: 1, max: %d seconds)", int64(PreviewTokenMaxExpiresIn.Seconds())))
+
+	// Search
+	ErrSearchQueryIsTooLong  = errs.InvalidArgument(fmt.Sprintf("Search query is too long (max: %d characters)", SearchQueryMaxSize))
+	ErrSearchQueryIsNotValid = errs.InvalidArgument("Search query is not valid")
 
 	// Snippets
 	ErrSnippetWithNameAlreadyExists = func(name string) error {
@@ -74,4 +95,26 @@ var (
 	ErrTagNameIsTooLong         = errs.InvalidArgument(fmt.Sprintf("Tag name is too long (max: %d characters)", TagNameMaxSize))
 	ErrTagNameMustBeLower       = errs.InvalidArgument("Tag name must be lowercase")
 	ErrTagNameIsNotValid        = errs.InvalidArgument("Tag name is not valid.")
+
+	// Authors
+	ErrAuthorNotFound     = errs.NotFound("Author not found.")
+	ErrPageAuthorNotFound = func(slug string) error {
+		return errs.InvalidArgument(fmt.Sprintf("Author \"%s\" not found. Authors must be created before being added to a page.", slug))
+	}
+	ErrAuthorAlreadyExists = func(slug string) error {
+		return errs.InvalidArgument(fmt.Sprintf("Author \"%s\" already exists.", slug))
+	}
+	ErrAuthorSlugIsTooShort       = errs.InvalidArgument(fmt.Sprintf("Author slug is too short (min: %d characters)", AuthorSlugMinSize))
+	ErrAuthorSlugIsTooLong        = errs.InvalidArgument(fmt.Sprintf("Author slug is too long (max: %d characters)", AuthorSlugMaxSize))
+	ErrAuthorSlugMustBeLower      = errs.InvalidArgument("Author slug must be lowercase")
+	ErrAuthorSlugIsNotValid       = errs.InvalidArgument("Author slug is not valid.")
+	ErrAuthorNameIsTooShort       = errs.InvalidArgument("Author name can't be empty")
+	ErrAuthorNameIsTooLong        = errs.InvalidArgument(fmt.Sprintf("Author name is too long (max: %d characters)", AuthorNameMaxSize))
+	ErrAuthorNameIsNotValid       = errs.InvalidArgument("Author name is not valid")
+	ErrAuthorBioIsTooLong         = errs.InvalidArgument(fmt.Sprintf("Author bio is too long (max: %d characters)", AuthorBioMaxSize))
+	ErrAuthorBioIsNotValid        = errs.InvalidArgument("Author bio is not valid")
+	ErrAuthorAvatarIsNotAnImage   = errs.InvalidArgument("Author avatar must be an image")
+	ErrAuthorTooManySocialLinks   = errs.InvalidArgument(fmt.Sprintf("An author can't have more than %d social links", AuthorMaxSocialLinks))
+	ErrAuthorSocialLinkIsNotValid = errs.InvalidArgument("Social link is not valid")
+	ErrPageHasTooManyAuthors      = errs.InvalidArgument(fmt.Sprintf("A page can't have more than %d authors", PageMaxAuthors))
 )
@@ -1,6 +1,8 @@
 package content
 
 import (
+	"database/sql/driver"
+	"encoding/json"
 	"fmt"
 	"mime/multipart"
 	"path/filepath"
@@ -8,6 +10,7 @@ import (
 
 	"github.com/bloom42/stdx-go/guid"
 	"github.com/bloom42/stdx-go/timex"
+	"github.com/bloom42/stdx-go/uuid"
 	"markdown.ninja/pkg/services/kernel"
 )
 
@@ -31,6 +34,16 @@ const (
 	TagDescriptionMaxSize = 420
 	TagNameAlphabet       = "abcdefghijklmnopqrstuvwxyz0123456789-"
 
+	AuthorSlugMinSize           = 1
+	AuthorSlugMaxSize           = 42
+	AuthorSlugAlphabet          = "abcdefghijklmnopqrstuvwxyz0123456789-"
+	AuthorNameMaxSize           = 128
+	AuthorBioMaxSize            = 1_000
+	AuthorMaxSocialLinks        = 10
+	AuthorSocialLinkNameMaxSize = 42
+	AuthorSocialLinkUrlMaxSize  = 512
+	PageMaxAuthors              = 20
+
 	PageBodyMarkdownMaxSize   = 80_000 // 80_000 KB
 	PageTitleMaxSize          = 256
 	PageTitleMinSize          = 1
@@ -39,8 +52,58 @@ const (
 	PagePathMaxSize           = 256
 
 	PageDefaultLanguage = "en"
+
+	PageTranslationKeyMaxSize  = 128
+	PageTranslationKeyAlphabet = "abcdefghijklmnopqrstuvwxyz0123456789-_./"
+
+	// PaywallMarker can be placed in the body of paid-only pages to select the teaser shown to
+	// non-members. Without marker, the first paragraphs up to PaidPageTeaserMinSize are shown.
+	PaywallMarker         = "<!-- paywall -->"
+	PaidPageTeaserMinSize = 500
+
+	SearchQueryMaxSize    = 256
+	SearchDefaultLimit    = 20
+	SearchMaxLimit        = 50
+	SearchDefaultLanguage = "simple"
+
+	PreviewTokenDefaultExpiresIn = time.Hour
+	PreviewTokenMaxExpiresIn     = 7 * 24 * time.Hour
 )
 
+// SearchLanguageConfigs maps the ISO 639-1 language of a page to the PostgreSQL text search
+// configuration used to index it. Languages not in this list are indexed without stemming
+// ("simple" configuration).
+//
+// Migration 0052 indexed the existing pages with a copy of this list in SQL. Changing the configuration
+// of a language requires a migration that re-indexes the existing pages of this language, which must be
+// added to the migrations checked by TestSearchLanguageConfigsMatchMigrations.
+var SearchLanguageConfigs = map[string]string{
+	"ar": "arabic",
+	"da": "danish",
+	"de": "german",
+	"el": "greek",
+	"en": "english",
+	"es": "spanish",
+	"fi": "finnish",
+	"fr": "french",
+	"ga": "irish",
+	"hu": "hungarian",
+	"id": "indonesian",
+	"it": "italian",
+	"lt": "lithuanian",
+	"ne": "nepali",
+	"nl": "dutch",
+	"no": "norwegian",
+	"nb": "norwegian",
+	"nn": "norwegian",
+	"pt": "portuguese",
+	"ro": "romanian",
+	"ru": "russian",
+	"sv": "swedish",
+	"ta": "tamil",
+	"tr": "turkish",
+}
+
 type PageType string
 
 const (
@@ -197,6 +260,9 @@ type Asset struct {
 	Size int64 `db:"size" json:"size"`
 	// BLAKE3
 	Hash kernel.BytesHex `db:"hash" json:"hash"`
+	// Width and Height are the dimensions in pixels of image assets. null if the dimensions are unknown
+	Width  *int64 `db:"width" json:"width"`
+	Height *int64 `db:"height" json:"height"`
 
 	// Only valid when asset is a product's asset (product_id IS NOT NULL)
 	// ProductAssetType ProductAssetType `db:"product_asset_type"`
@@ -229,12 +295,17 @@ type Page struct {
 	MetadataHash     kernel.BytesHex `db:"metadata_hash" json:"metadata_hash"`
 	SendAsNewsletter bool            `db:"send_as_newsletter" json:"send_as_newsletter"`
 	NewsletterSentAt *time.Time      `db:"newsletter_sent_at" json:"newsletter_sent_at"`
+	// PaidOnly pages are only fully visible to contacts with an active subscription
+	PaidOnly bool `db:"paid_only" json:"paid_only"`
+	// Pages with the same TranslationKey are the translations of each other, in different languages
+	TranslationKey *string `db:"translation_key" json:"translation_key"`
 
 	// TitleDraft  string            `db:"title_draft" json:"title_draft"`
 
 	WebsiteID guid.GUID `db:"website_id" json:"-"`
 
-	Tags []Tag `db:"-" json:"tags"`
+	Tags    []Tag    `db:"-" json:"tags"`
+	Authors []Author `db:"-" json:"authors"`
 }
 
 func (page *Page) ModifiedAt() time.Time {
@@ -272,21 +343,69 @@ type TagPageRelation struct {
 	TagID  guid.GUID `db:"tag_id"`
 }
 
-// type Author struct {
-// 	ID        guid.GUID `db:"id" json:"id"`
-// 	CreatedAt time.Time `db:"created_at" json:"created_at"`
-// 	UpdatedAt time.Time `db:"updated_at" json:"updated_at"`
+type Author struct {
+	ID        guid.GUID `db:"id" json:"id"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+	UpdatedAt time.Time `db:"updated_at" json:"updated_at"`
+
+	// Slug is used to reference the author in the frontmatter of pages and in the URL of the
+	// author's page: /authors/{slug}
+	Slug        string            `db:"slug" json:"slug"`
+	Name        string            `db:"name" json:"name"`
+	Bio         string            `db:"bio" json:"bio"`
+	SocialLinks AuthorSocialLinks `db:"social_links" json:"social_links"`
 
-// 	Name string `db:"name" json:"name"`
-// 	Bio  string `db:"bio" json:"bio"`
+	// AvatarID is an image asset of the website
+	AvatarID  *guid.GUID `db:"avatar_id" json:"avatar_id"`
+	WebsiteID guid.GUID  `db:"website_id" json:"-"`
+}
 
-// 	WebsiteID guid.GUID `db:"website_id" json:"-"`
-// }
+type AuthorSocialLink struct {
+	// Name of the social network or website. e.g. Mastodon, GitHub...
+	Name string `json:"name"`
+	Url  string `json:"url"`
+}
 
-// type AuthorPageRelation struct {
-// 	PageID   guid.GUID `db:"page_id"`
-// 	AuthorID guid.GUID `db:"author_id"`
-// }
+type AuthorSocialLinks []AuthorSocialLink
+
+func (links *AuthorSocialLinks) Scan(val any) error {
+	switch v := val.(type) {
+	case []byte:
+		return json.Unmarshal(v, links)
+	case string:
+		return json.Unmarshal([]byte(v), links)
+	default:
+		return fmt.Errorf("AuthorSocialLinks.Scan: Unsupported type: %T", v)
+	}
+}
+
+func (links AuthorSocialLinks) Value() (driver.Value, error) {
+	if links == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(links)
+}
+
+type AuthorPageRelation struct {
+	// Position is the order of the author in the list of authors of the page
+	Position int64     `db:"position"`
+	PageID   guid.GUID `db:"page_id"`
+	AuthorID guid.GUID `db:"author_id"`
+}
+
+// PageTag is a tag with the page it is associated to. It is used to fetch the tags of many pages
+// at once.
+type PageTag struct {
+	Tag
+	PageID guid.GUID `db:"page_id"`
+}
+
+// PageAuthor is an author with the page it is associated to. It is used to fetch the authors of
+// many pages at once.
+type PageAuthor struct {
+	Author
+	PageID guid.GUID `db:"page_id"`
+}
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////
 // Service
@@ -295,31 +414,41 @@ type TagPageRelation struct {
 // Pages
 
 type CreatePageInput struct {
-	WebsiteID        guid.GUID `json:"website_id"`
-	Date             time.Time `json:"date"`
-	Type             PageType  `json:"type"`
-	Title            string    `json:"title"`
-	Path             string    `json:"path"`
-	Description      string    `json:"description"`
-	Language         string    `json:"language"`
-	Tags             []string  `json:"tags"`
-	Draft            bool      `json:"draft"`
-	BodyMarkdown     string    `json:"body_markdown"`
-	SendAsNewsletter bool      `json:"send_as_newsletter"`
+	WebsiteID   guid.GUID `json:"website_id"`
+	Date        time.Time `json:"date"`
+	Type        PageType  `json:"type"`
+	Title       string    `json:"title"`
+	Path        string    `json:"path"`
+	Description string    `json:"description"`
+	Language    string    `json:"language"`
+	Tags        []string  `json:"tags"`
+	// Authors are the slugs of the authors of the page
+	Authors          []string `json:"authors"`
+	Draft            bool     `json:"draft"`
+	BodyMarkdown     string   `json:"body_markdown"`
+	SendAsNewsletter bool     `json:"send_as_newsletter"`
+	PaidOnly         bool     `json:"paid_only"`
+	// TranslationKey links the page to its translations. Empty if the page has no translations.
+	TranslationKey *string `json:"translation_key"`
 }
 
 type UpdatePageInput struct {
-	PageID           guid.GUID  `json:"id"`
-	Date             time.Time  `json:"date"`
-	UpdatedAt        *time.Time `json:"updated_at"`
-	Title            string     `json:"title"`
-	Path             string     `json:"path"`
-	Draft            bool       `json:"draft"`
-	Description      *string    `json:"description"`
-	Language         string     `json:"language"`
-	Tags             []string   `json:"tags"`
-	BodyMarkdown     *string    `json:"body_markdown"`
-	SendAsNewsletter bool       `json:"send_as_newsletter"`
+	PageID      guid.GUID  `json:"id"`
+	Date        time.Time  `json:"date"`
+	UpdatedAt   *time.Time `json:"updated_at"`
+	Title       string     `json:"title"`
+	Path        string     `json:"path"`
+	Draft       bool       `json:"draft"`
+	Description *string    `json:"description"`
+	Language    string     `json:"language"`
+	Tags        []string   `json:"tags"`
+	// Authors are the slugs of the authors of the page
+	Authors          []string `json:"authors"`
+	BodyMarkdown     *string  `json:"body_markdown"`
+	SendAsNewsletter bool     `json:"send_as_newsletter"`
+	PaidOnly         bool     `json:"paid_only"`
+	// TranslationKey links the page to its translations. Empty if the page has no translations.
+	TranslationKey *string `json:"translation_key"`
 }
 
 type DeletePageInput struct {
@@ -346,6 +475,8 @@ type PageMetadata struct {
 	Language         string          `db:"language" json:"lang"`
 	SendAsNewsletter bool            `db:"send_as_newsletter" json:"send_as_newsletter"`
 	NewsletterSentAt *time.Time      `db:"newsletter_sent_at" json:"newsletter_sent_at"`
+	// PaidOnly pages are only fully visible to contacts with an active subscription
+	PaidOnly bool `db:"paid_only" json:"paid_only"`
 }
 
 func (page *PageMetadata) ModifiedAt() time.Time {
@@ -357,6 +488,139 @@ type ListPagesInput struct {
 	Query     string    `json:"query"`
 }
 
+type SearchPagesInput struct {
+	WebsiteID guid.GUID
+	Query     string
+	// if empty, published pages in all languages are searched
+	Language string
+	Types    []PageType
+	Limit    int64
+}
+
+// PagesFilter filters the pages returned by FindPagesMetadataWithFilter. Pages are sorted by date
+// then ID, in descending order.
+type PagesFilter struct {
+	Types []PageType
+	// if empty, only published pages are returned
+	Statuses []PageStatus
+	// Tag is the name of a tag
+	Tag      *string
+	Language *string
+	// From and To are inclusive
+	From *time.Time
+	To   *time.Time
+	// After is the position of the last page of the previous batch of results
+	After *PagesCursor
+	Limit int64
+}
+
+// PagesCursor is the position of a page in a list of pages sorted by date and ID
+type PagesCursor struct {
+	Date time.Time
+	ID   guid.GUID
+}
+
+// PageSearchResult is a published page matching a search query. Highlights are safe HTML where
+// the matching terms are wrapped in <mark> tags.
+type PageSearchResult struct {
+	PageMetadata
+	Rank           float64 `db:"rank" json:"rank"`
+	TitleHighlight string  `db:"title_highlight" json:"title_highlight"`
+	BodyHighlight  string  `db:"body_highlight" json:"body_highlight"`
+}
+
+// Page revisions
+
+// PageRevision is an immutable snapshot of the content of a page. A revision is saved each time a
+// page is created or its body or metadata change.
+type PageRevision struct {
+	ID        guid.GUID `db:"id" json:"id"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+
+	Date         time.Time           `db:"date" json:"date"`
+	Title        string              `db:"title" json:"title"`
+	Path         string              `db:"path" json:"path"`
+	Description  string              `db:"description" json:"description"`
+	Language     string              `db:"language" json:"language"`
+	Tags         PageRevisionStrings `db:"tags" json:"tags"`
+	Authors      PageRevisionStrings `db:"authors" json:"authors"`
+	BodyMarkdown string              `db:"body_markdown" json:"body_markdown"`
+	Size         int64               `db:"size" json:"size"`
+	BodyHash     kernel.BytesHex     `db:"body_hash" json:"body_hash"`
+	MetadataHash kernel.BytesHex     `db:"metadata_hash" json:"metadata_hash"`
+
+	// UserID is the user who created the revision. It is null if the revision was created with an
+	// API key.
+	UserID *uuid.UUID `db:"user_id" json:"user_id"`
+	PageID guid.GUID  `db:"page_id" json:"page_id"`
+}
+
+// PageRevisionMetadata is a PageRevision without its body
+type PageRevisionMetadata struct {
+	ID           guid.GUID       `db:"id" json:"id"`
+	CreatedAt    time.Time       `db:"created_at" json:"created_at"`
+	Title        string          `db:"title" json:"title"`
+	Path         string          `db:"path" json:"path"`
+	Size         int64           `db:"size" json:"size"`
+	BodyHash     kernel.BytesHex `db:"body_hash" json:"body_hash"`
+	MetadataHash kernel.BytesHex `db:"metadata_hash" json:"metadata_hash"`
+	UserID       *uuid.UUID      `db:"user_id" json:"user_id"`
+}
+
+// PageRevisionStrings is a list of names, such as the tags' names or the authors' slugs of a page
+// at the time of the revision
+type PageRevisionStrings []string
+
+func (strs *PageRevisionStrings) Scan(val any) error {
+	switch v := val.(type) {
+	case []byte:
+		return json.Unmarshal(v, strs)
+	case string:
+		return json.Unmarshal([]byte(v), strs)
+	default:
+		return fmt.Errorf("PageRevisionStrings.Scan: Unsupported type: %T", v)
+	}
+}
+
+func (strs PageRevisionStrings) Value() (driver.Value, error) {
+	if strs == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(strs)
+}
+
+type ListPageRevisionsInput struct {
+	PageID guid.GUID `json:"page_id"`
+	Limit  int64     `json:"limit"`
+	// After is the ID of the last revision of the previous batch of results
+	After *guid.GUID `json:"after"`
+}
+
+type GetPageRevisionInput struct {
+	ID guid.GUID `json:"id"`
+}
+
+type DiffPageRevisionsInput struct {
+	// From is the older revision
+	From guid.GUID `json:"from"`
+	// To is the newer revision
+	To guid.GUID `json:"to"`
+}
+
+type PageRevisionsDiff struct {
+	From PageRevisionMetadata `json:"from"`
+	To   PageRevisionMetadata `json:"to"`
+	// Diff is an unified diff of the two revisions. The metadata of the page are represented as
+	// a frontmatter before the body.
+	Diff            string `json:"diff"`
+	BodyChanged     bool   `json:"body_changed"`
+	MetadataChanged bool   `json:"metadata_changed"`
+}
+
+type RestorePageRevisionInput struct {
+	ID guid.GUID `json:"id"`
+}
+
 // Assets
 
 type UploadAssetInput struct {
@@ -429,6 +693,38 @@ type GetTagsInput struct {
 	WebsiteID guid.GUID `json:"website_id"`
 }
 
+// Authors
+
+type CreateAuthorInput struct {
+	WebsiteID   guid.GUID          `json:"website_id"`
+	Slug        string             `json:"slug"`
+	Name        string             `json:"name"`
+	Bio         string             `json:"bio"`
+	AvatarID    *guid.GUID         `json:"avatar_id"`
+	SocialLinks []AuthorSocialLink `json:"social_links"`
+}
+
+type UpdateAuthorInput struct {
+	ID          guid.GUID          `json:"id"`
+	Slug        string             `json:"slug"`
+	Name        string             `json:"name"`
+	Bio         string             `json:"bio"`
+	AvatarID    *guid.GUID         `json:"avatar_id"`
+	SocialLinks []AuthorSocialLink `json:"social_links"`
+}
+
+type DeleteAuthorInput struct {
+	ID guid.GUID `json:"id"`
+}
+
+type GetAuthorInput struct {
+	ID guid.GUID `json:"id"`
+}
+
+type ListAuthorsInput struct {
+	WebsiteID guid.GUID `json:"website_id"`
+}
+
 // Snippets
 
 type CreateSnippetInput struct {
@@ -456,3 +752,17 @@ type ListSnippetsInput struct {
 type GetAssetDataOptions struct {
 	Range *string
 }
+
+// Preview tokens
+
+type CreatePreviewTokenInput struct {
+	WebsiteID guid.GUID `json:"website_id"`
+	// ExpiresIn is the validity of the token in seconds. Default: 1 hour, max: 7 days
+	ExpiresIn *int64 `json:"expires_in"`
+}
+
+// PreviewToken gives access to the draft and scheduled pages of a website through the headless API
+type PreviewToken struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
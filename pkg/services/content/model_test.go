@@ -0,0 +1,45 @@
+package content
+
+import (
+	"regexp"
+	"testing"
+
+	"markdown.ninja/migrations"
+)
+
+// searchLanguageConfigsMigrations are the migrations that index pages with a SQL copy of
+// SearchLanguageConfigs, in the order they are applied
+var searchLanguageConfigsMigrations = []string{
+	"2025/0052.up.sql",
+}
+
+var sqlSearchLanguageConfigRegexp = regexp.MustCompile(`WHEN '([a-z]+)' THEN '([a-z]+)'`)
+
+func TestSearchLanguageConfigsMatchMigrations(t *testing.T) {
+	migrationsConfigs := map[string]string{}
+	for _, migration := range searchLanguageConfigsMigrations {
+		sql, err := migrations.MigrationsFs.ReadFile(migration)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		matches := sqlSearchLanguageConfigRegexp.FindAllStringSubmatch(string(sql), -1)
+		if len(matches) == 0 {
+			t.Fatalf("%s: no language configuration found", migration)
+		}
+		for _, match := range matches {
+			migrationsConfigs[match[1]] = match[2]
+		}
+	}
+
+	for language, config := range SearchLanguageConfigs {
+		if migrationsConfigs[language] != config {
+			t.Errorf("language %s: indexed with %q by the migrations instead of %q", language, migrationsConfigs[language], config)
+		}
+	}
+	for language := range migrationsConfigs {
+		if _, exists := SearchLanguageConfigs[language]; !exists {
+			t.Errorf("language %s: indexed by the migrations but missing from SearchLanguageConfigs", language)
+		}
+	}
+}
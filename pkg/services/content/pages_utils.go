@@ -2,12 +2,13 @@ package content
 
 import (
 	"encoding/binary"
+	"strings"
 	"time"
 
 	"github.com/bloom42/stdx-go/crypto/blake3"
 )
 
-func HashPageMetadata(pageType PageType, path string, date time.Time, sendAsNewsletter bool, language string, title string, description string, tags []string) [32]byte {
+func HashPageMetadata(pageType PageType, path string, date time.Time, sendAsNewsletter bool, paidOnly bool, language string, title string, description string, tags []string, authors []string, translationKey *string) [32]byte {
 	var hash [32]byte
 
 	hasher := blake3.New(32, nil)
@@ -21,8 +22,57 @@ func HashPageMetadata(pageType PageType, path string, date time.Time, sendAsNews
 	for _, tag := range tags {
 		hasher.Write([]byte(tag))
 	}
+	// authors are only hashed when present so the hashes of pages without authors don't change
+	if len(authors) != 0 {
+		hasher.Write([]byte("authors"))
+		for _, author := range authors {
+			hasher.Write([]byte(author))
+		}
+	}
+	// same for paidOnly
+	if paidOnly {
+		hasher.Write([]byte("paid_only"))
+	}
+	// and translationKey
+	if translationKey != nil {
+		hasher.Write([]byte("translation_key"))
+		hasher.Write([]byte(*translationKey))
+	}
 
 	hasher.Sum(hash[:0])
 
 	return hash
 }
+
+// SearchLanguageConfig returns the PostgreSQL text search configuration to use for the given language
+func SearchLanguageConfig(language string) string {
+	if config, ok := SearchLanguageConfigs[language]; ok {
+		return config
+	}
+	return SearchDefaultLanguage
+}
+
+// PaidPageTeaser returns the markdown shown instead of the body of a paid-only page to the visitors
+// without an active subscription. The teaser never contains the full body, even for short pages.
+func PaidPageTeaser(bodyMarkdown string) string {
+	if teaser, _, found := strings.Cut(bodyMarkdown, PaywallMarker); found {
+		return strings.TrimSpace(teaser)
+	}
+
+	blocks := strings.Split(strings.TrimSpace(bodyMarkdown), "\n\n")
+	teaserBlocks := len(blocks) / 2
+	teaserSize := 0
+	for i, block := range blocks[:teaserBlocks] {
+		teaserSize += len(block)
+		if teaserSize >= PaidPageTeaserMinSize {
+			teaserBlocks = i + 1
+			break
+		}
+	}
+	// we don't want to cut in the middle of a code block
+	for teaserBlocks > 0 && strings.Count(strings.Join(blocks[:teaserBlocks], ""), "```")%2 != 0 {
+		teaserBlocks -= 1
+	}
+
+	return strings.Join(blocks[:teaserBlocks], "\n\n")
+}
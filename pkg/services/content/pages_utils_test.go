@@ -0,0 +1,35 @@
+package content
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPaidPageTeaser(t *testing.T) {
+	body := "Intro\n\n" + PaywallMarker + "\n\nPaid content"
+	if teaser := PaidPageTeaser(body); teaser != "Intro" {
+		t.Errorf("unexpected teaser with marker: %q", teaser)
+	}
+
+	// short pages never leak their full body
+	body = "First paragraph\n\nSecond paragraph\n\nThird paragraph\n\nFourth paragraph"
+	if teaser := PaidPageTeaser(body); teaser != "First paragraph\n\nSecond paragraph" {
+		t.Errorf("unexpected teaser for short page: %q", teaser)
+	}
+	if teaser := PaidPageTeaser("Only one paragraph"); teaser != "" {
+		t.Errorf("unexpected teaser for single paragraph: %q", teaser)
+	}
+
+	// long pages are cut after PaidPageTeaserMinSize
+	longParagraph := strings.Repeat("a", PaidPageTeaserMinSize)
+	body = longParagraph + "\n\nSecond\n\nThird\n\nFourth"
+	if teaser := PaidPageTeaser(body); teaser != longParagraph {
+		t.Errorf("unexpected teaser for long page: %q", teaser)
+	}
+
+	// code blocks are not cut in the middle
+	body = "Intro\n\n```go\na()\n\nb()\n\nc()\n```\n\nEnd"
+	if teaser := PaidPageTeaser(body); teaser != "Intro" {
+		t.Errorf("unexpected teaser with code block: %q", teaser)
+	}
+}
@@ -12,12 +12,12 @@ import (
 
 func (repo *ContentRepository) CreateAsset(ctx context.Context, db db.Queryer, asset content.Asset) (err error) {
 	const query = `INSERT INTO assets
-			(id, created_at, updated_at, type, name, folder, media_type, size, hash,
+			(id, created_at, updated_at, type, name, folder, media_type, size, hash, width, height,
 				website_id, product_id)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)`
 
 	_, err = db.Exec(ctx, query, asset.ID, asset.CreatedAt, asset.UpdatedAt, asset.Type, asset.Name,
-		asset.Folder, asset.MediaType, asset.Size, asset.Hash,
+		asset.Folder, asset.MediaType, asset.Size, asset.Hash, asset.Width, asset.Height,
 		asset.WebsiteID, asset.ProductID)
 	if err != nil {
 		err = fmt.Errorf("content.CreateAsset: %w", err)
@@ -30,11 +30,11 @@ func (repo *ContentRepository) CreateAsset(ctx context.Context, db db.Queryer, a
 func (repo *ContentRepository) UpdateAsset(ctx context.Context, db db.Queryer, asset content.Asset) (err error) {
 	const query = `UPDATE assets
 		SET updated_at = $1, type = $2, name = $3, folder = $4, media_type = $5, size = $6,
-		hash = $7
-		WHERE id = $8`
+		hash = $7, width = $8, height = $9
+		WHERE id = $10`
 
 	_, err = db.Exec(ctx, query, asset.UpdatedAt, asset.Type, asset.Name, asset.Folder, asset.MediaType,
-		asset.Size, asset.Hash,
+		asset.Size, asset.Hash, asset.Width, asset.Height,
 		asset.ID)
 	if err != nil {
 		err = fmt.Errorf("content.UpdateAsset: %w", err)
@@ -0,0 +1,156 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/bloom42/stdx-go/db"
+	"github.com/bloom42/stdx-go/guid"
+	"markdown.ninja/pkg/services/content"
+)
+
+func (repo *ContentRepository) CreateAuthor(ctx context.Context, db db.Queryer, author content.Author) (err error) {
+	const query = `INSERT INTO authors
+				(id, created_at, updated_at, slug, name, bio, social_links, avatar_id, website_id)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+
+	_, err = db.Exec(ctx, query, author.ID, author.CreatedAt, author.UpdatedAt, author.Slug, author.Name,
+		author.Bio, author.SocialLinks, author.AvatarID, author.WebsiteID)
+	if err != nil {
+		return fmt.Errorf("content.CreateAuthor: %w", err)
+	}
+
+	return nil
+}
+
+func (repo *ContentRepository) UpdateAuthor(ctx context.Context, db db.Queryer, author content.Author) (err error) {
+	const query = `UPDATE authors
+		SET updated_at = $1, slug = $2, name = $3, bio = $4, social_links = $5, avatar_id = $6
+		WHERE id = $7`
+
+	_, err = db.Exec(ctx, query, author.UpdatedAt, author.Slug, author.Name, author.Bio, author.SocialLinks,
+		author.AvatarID, author.ID)
+	if err != nil {
+		return fmt.Errorf("content.UpdateAuthor: %w", err)
+	}
+
+	return nil
+}
+
+func (repo *ContentRepository) DeleteAuthor(ctx context.Context, db db.Queryer, authorID guid.GUID) (err error) {
+	const query = `DELETE FROM authors WHERE id = $1`
+
+	_, err = db.Exec(ctx, query, authorID)
+	if err != nil {
+		return fmt.Errorf("content.DeleteAuthor: %w", err)
+	}
+
+	return nil
+}
+
+func (repo *ContentRepository) FindAuthorByID(ctx context.Context, db db.Queryer, authorID guid.GUID) (author content.Author, err error) {
+	const query = "SELECT * FROM authors WHERE id = $1"
+
+	err = db.Get(ctx, &author, query, authorID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return author, content.ErrAuthorNotFound
+		} else {
+			return author, fmt.Errorf("content.FindAuthorByID: %w", err)
+		}
+	}
+
+	return author, nil
+}
+
+func (repo *ContentRepository) FindAuthorBySlug(ctx context.Context, db db.Queryer, websiteID guid.GUID, slug string) (author content.Author, err error) {
+	const query = "SELECT * FROM authors WHERE website_id = $1 AND slug = $2"
+
+	err = db.Get(ctx, &author, query, websiteID, slug)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return author, content.ErrAuthorNotFound
+		} else {
+			return author, fmt.Errorf("content.FindAuthorBySlug: %w", err)
+		}
+	}
+
+	return author, nil
+}
+
+func (repo *ContentRepository) FindAuthorsForWebsite(ctx context.Context, db db.Queryer, websiteID guid.GUID) (authors []content.Author, err error) {
+	authors = make([]content.Author, 0)
+	const query = `SELECT * FROM authors
+		WHERE website_id = $1
+		ORDER BY name
+	`
+
+	err = db.Select(ctx, &authors, query, websiteID)
+	if err != nil {
+		return authors, fmt.Errorf("content.FindAuthorsForWebsite: %w", err)
+	}
+
+	return authors, nil
+}
+
+func (repo *ContentRepository) FindAuthorsForPage(ctx context.Context, db db.Queryer, pageID guid.GUID) (authors []content.Author, err error) {
+	authors = make([]content.Author, 0, 2)
+	const query = `SELECT authors.* FROM authors
+			INNER JOIN pages_authors ON pages_authors.author_id = authors.id
+		WHERE pages_authors.page_id = $1
+		ORDER BY pages_authors.position
+	`
+
+	err = db.Select(ctx, &authors, query, pageID)
+	if err != nil {
+		return authors, fmt.Errorf("content.FindAuthorsForPage: %w", err)
+	}
+
+	return authors, nil
+}
+
+// FindAuthorsForPages returns the authors of all the given pages, ordered by page and position
+func (repo *ContentRepository) FindAuthorsForPages(ctx context.Context, db db.Queryer, pageIDs []guid.GUID) (authors []content.PageAuthor, err error) {
+	authors = make([]content.PageAuthor, 0, len(pageIDs))
+	if len(pageIDs) == 0 {
+		return
+	}
+
+	const query = `SELECT authors.*, pages_authors.page_id FROM authors
+			INNER JOIN pages_authors ON pages_authors.author_id = authors.id
+		WHERE pages_authors.page_id = ANY($1)
+		ORDER BY pages_authors.page_id, pages_authors.position
+	`
+
+	err = db.Select(ctx, &authors, query, pageIDs)
+	if err != nil {
+		return authors, fmt.Errorf("content.FindAuthorsForPages: %w", err)
+	}
+
+	return authors, nil
+}
+
+func (repo *ContentRepository) CreateAuthorPageRelation(ctx context.Context, db db.Queryer, relation content.AuthorPageRelation) (err error) {
+	const query = `INSERT INTO pages_authors
+				(position, page_id, author_id)
+			VALUES ($1, $2, $3)`
+
+	_, err = db.Exec(ctx, query, relation.Position, relation.PageID, relation.AuthorID)
+	if err != nil {
+		return fmt.Errorf("content.CreateAuthorPageRelation: %w", err)
+	}
+
+	return nil
+}
+
+func (repo *ContentRepository) DeleteAuthorPageRelationsForPage(ctx context.Context, db db.Queryer, pageID guid.GUID) (err error) {
+	const query = `DELETE FROM pages_authors WHERE page_id = $1`
+
+	_, err = db.Exec(ctx, query, pageID)
+	if err != nil {
+		return fmt.Errorf("content.DeleteAuthorPageRelationsForPage: %w", err)
+	}
+
+	return nil
+}
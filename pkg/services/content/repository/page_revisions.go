@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+
+	"github.com/bloom42/stdx-go/db"
+	"github.com/bloom42/stdx-go/guid"
+	"markdown.ninja/pkg/services/content"
+)
+
+func (repo *ContentRepository) CreatePageRevision(ctx context.Context, db db.Queryer, revision content.PageRevision) (err error) {
+	const query = `INSERT INTO page_revisions
+			(id, created_at, date, title, path, description, language, tags, authors, body_markdown, size,
+			body_hash, metadata_hash, user_id, page_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)`
+
+	_, err = db.Exec(ctx, query, revision.ID, revision.CreatedAt, revision.Date, revision.Title, revision.Path,
+		revision.Description, revision.Language, revision.Tags, revision.Authors, revision.BodyMarkdown, revision.Size,
+		revision.BodyHash, revision.MetadataHash, revision.UserID, revision.PageID)
+	if err != nil {
+		err = fmt.Errorf("content.CreatePageRevision: %w", err)
+		return
+	}
+
+	return
+}
+
+func (repo *ContentRepository) FindPageRevisionByID(ctx context.Context, db db.Queryer, revisionID guid.GUID) (revision content.PageRevision, err error) {
+	const query = "SELECT * FROM page_revisions WHERE id = $1"
+
+	err = db.Get(ctx, &revision, query, revisionID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			err = content.ErrPageRevisionNotFound
+		} else {
+			err = fmt.Errorf("content.FindPageRevisionByID: %w", err)
+		}
+		return
+	}
+
+	return
+}
+
+// FindLastPageRevision returns the most recent revision of a page, or content.ErrPageRevisionNotFound
+// if the page has no revision
+func (repo *ContentRepository) FindLastPageRevision(ctx context.Context, db db.Queryer, pageID guid.GUID) (revision content.PageRevisionMetadata, err error) {
+	const query = `SELECT id, created_at, title, path, size, body_hash, metadata_hash, user_id
+		FROM page_revisions
+		WHERE page_id = $1
+		ORDER BY created_at DESC, id DESC
+		LIMIT 1`
+
+	err = db.Get(ctx, &revision, query, pageID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			err = content.ErrPageRevisionNotFound
+		} else {
+			err = fmt.Errorf("content.FindLastPageRevision: %w", err)
+		}
+		return
+	}
+
+	return
+}
+
+// FindPageRevisionsMetadataForPage returns the revisions of a page, from the most recent to the oldest.
+// If after is not nil, only the revisions older than the revision after are returned.
+func (repo *ContentRepository) FindPageRevisionsMetadataForPage(ctx context.Context, db db.Queryer, pageID guid.GUID, limit int64, after *guid.GUID) (revisions []content.PageRevisionMetadata, err error) {
+	revisions = make([]content.PageRevisionMetadata, 0, 10)
+	query := `SELECT id, created_at, title, path, size, body_hash, metadata_hash, user_id
+		FROM page_revisions
+		WHERE page_id = $1`
+	args := []any{pageID}
+
+	if after != nil {
+		args = append(args, *after)
+		query += " AND (created_at, id) < (SELECT created_at, id FROM page_revisions WHERE id = $" + strconv.Itoa(len(args)) + ")"
+	}
+	args = append(args, limit)
+	query += " ORDER BY created_at DESC, id DESC LIMIT $" + strconv.Itoa(len(args))
+
+	err = db.Select(ctx, &revisions, query, args...)
+	if err != nil {
+		err = fmt.Errorf("content.FindPageRevisionsMetadataForPage: %w", err)
+		return
+	}
+
+	return
+}
+
+// DeleteOldPageRevisions deletes the revisions of a page, except the most recent revisionsToKeep
+func (repo *ContentRepository) DeleteOldPageRevisions(ctx context.Context, db db.Queryer, pageID guid.GUID, revisionsToKeep int64) (err error) {
+	const query = `DELETE FROM page_revisions
+		WHERE page_id = $1 AND id NOT IN (
+			SELECT id FROM page_revisions WHERE page_id = $1
+			ORDER BY created_at DESC, id DESC
+			LIMIT $2
+		)`
+
+	_, err = db.Exec(ctx, query, pageID, revisionsToKeep)
+	if err != nil {
+		err = fmt.Errorf("content.DeleteOldPageRevisions: %w", err)
+		return
+	}
+
+	return
+}
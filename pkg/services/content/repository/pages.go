@@ -15,13 +15,13 @@ func (repo *ContentRepository) CreatePage(ctx context.Context, db db.Queryer, pa
 	const query = `INSERT INTO pages
 			(id, created_at, updated_at, date, type, title, path,
 			description, language, size, body_hash, metadata_hash, status, send_as_newsletter,
-			newsletter_sent_at, body_markdown, website_id)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)`
+			newsletter_sent_at, paid_only, translation_key, body_markdown, website_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19)`
 
 	_, err = db.Exec(ctx, query, page.ID, page.CreatedAt, page.UpdatedAt, page.Date,
 		page.Type, page.Title, page.Path,
 		page.Description, page.Language, page.Size, page.BodyHash, page.MetadataHash, page.Status,
-		page.SendAsNewsletter, page.NewsletterSentAt, page.BodyMarkdown,
+		page.SendAsNewsletter, page.NewsletterSentAt, page.PaidOnly, page.TranslationKey, page.BodyMarkdown,
 		page.WebsiteID)
 	if err != nil {
 		err = fmt.Errorf("content.CreatePage: %w", err)
@@ -35,13 +35,14 @@ func (repo *ContentRepository) UpdatePage(ctx context.Context, db db.Queryer, pa
 	const query = `UPDATE pages
 		SET updated_at = $1, date = $2, type = $3, title = $4, path = $5,
 			description = $6, language = $7, size = $8, body_hash = $9, status = $10,
-			send_as_newsletter = $11, newsletter_sent_at = $12, body_markdown = $13, metadata_hash = $14
-		WHERE id = $15`
+			send_as_newsletter = $11, newsletter_sent_at = $12, body_markdown = $13, metadata_hash = $14,
+			paid_only = $15, translation_key = $16
+		WHERE id = $17`
 
 	_, err = db.Exec(ctx, query, page.UpdatedAt, page.Date, page.Type, page.Title, page.Path,
 		page.Description, page.Language,
 		page.Size, page.BodyHash, page.Status, page.SendAsNewsletter,
-		page.NewsletterSentAt, page.BodyMarkdown, page.MetadataHash,
+		page.NewsletterSentAt, page.BodyMarkdown, page.MetadataHash, page.PaidOnly, page.TranslationKey,
 		page.ID)
 	if err != nil {
 		err = fmt.Errorf("content.UpdatePage: %w", err)
@@ -100,7 +101,7 @@ func (repo *ContentRepository) FindPagesMetadataByTypeForWebsite(ctx context.Con
 	pages = make([]content.PageMetadata, 0)
 	const query = `SELECT pages.id, pages.created_at, pages.updated_at, pages.date, pages.type, pages.title,
 				pages.description, pages.path, pages.size, pages.body_hash, pages.metadata_hash,
-				pages.status, pages.language, pages.send_as_newsletter, pages.newsletter_sent_at
+				pages.status, pages.language, pages.send_as_newsletter, pages.newsletter_sent_at, pages.paid_only
 		FROM pages
 		WHERE website_id = $1 AND type = $2
 		ORDER BY date DESC
@@ -164,7 +165,7 @@ func (repo *ContentRepository) FindPublishedPagesMetadataForTag(ctx context.Cont
 	pages = make([]content.PageMetadata, 0, 10)
 	const query = `SELECT pages.id, pages.created_at, pages.updated_at, pages.date, pages.type, pages.title,
 				pages.description, pages.path, pages.size, pages.body_hash, pages.metadata_hash,
-				pages.status, pages.language, pages.send_as_newsletter, pages.newsletter_sent_at
+				pages.status, pages.language, pages.send_as_newsletter, pages.newsletter_sent_at, pages.paid_only
 				FROM pages
 			INNER JOIN pages_tags ON pages_tags.page_id = pages.id
 			WHERE pages_tags.tag_id = $1
@@ -181,11 +182,32 @@ func (repo *ContentRepository) FindPublishedPagesMetadataForTag(ctx context.Cont
 	return
 }
 
+func (repo *ContentRepository) FindPublishedPagesMetadataForAuthor(ctx context.Context, db db.Queryer, pageTypes []content.PageType, authorID guid.GUID) (pages []content.PageMetadata, err error) {
+	pages = make([]content.PageMetadata, 0, 10)
+	const query = `SELECT pages.id, pages.created_at, pages.updated_at, pages.date, pages.type, pages.title,
+				pages.description, pages.path, pages.size, pages.body_hash, pages.metadata_hash,
+				pages.status, pages.language, pages.send_as_newsletter, pages.newsletter_sent_at, pages.paid_only
+				FROM pages
+			INNER JOIN pages_authors ON pages_authors.page_id = pages.id
+			WHERE pages_authors.author_id = $1
+			AND type = ANY($2)
+			AND status = $3
+		ORDER BY date DESC`
+
+	err = db.Select(ctx, &pages, query, authorID, pageTypes, content.PageStatusPublished)
+	if err != nil {
+		err = fmt.Errorf("content.FindPublishedPagesMetadataForAuthor: %w", err)
+		return
+	}
+
+	return
+}
+
 func (repo *ContentRepository) FindPublishedPagesMetadataForWebsite(ctx context.Context, db db.Queryer,
 	websiteID guid.GUID, pageTypes []content.PageType, limit int64) (pages []content.PageMetadata, err error) {
 	pages = make([]content.PageMetadata, 0, 25)
 	const query = `SELECT id, created_at, updated_at, date, type, title, description, path, size,
-			body_hash, metadata_hash, status, language, send_as_newsletter, newsletter_sent_at
+			body_hash, metadata_hash, status, language, send_as_newsletter, newsletter_sent_at, paid_only
 		FROM pages
 		WHERE website_id = $1
 			AND type = ANY($2)
@@ -202,6 +224,115 @@ func (repo *ContentRepository) FindPublishedPagesMetadataForWebsite(ctx context.
 	return
 }
 
+// FindPagesMetadataWithFilter returns the metadata of the pages matching the filter, sorted by date
+// then ID, in descending order. If tagID is not nil, only the pages with this tag are returned.
+func (repo *ContentRepository) FindPagesMetadataWithFilter(ctx context.Context, db db.Queryer,
+	websiteID guid.GUID, filter content.PagesFilter, tagID *guid.GUID) (pages []content.PageMetadata, err error) {
+	pages = make([]content.PageMetadata, 0, min(filter.Limit, 100))
+	query := `SELECT id, created_at, updated_at, date, type, title, description, path, size,
+			body_hash, metadata_hash, status, language, send_as_newsletter, newsletter_sent_at, paid_only
+		FROM pages
+		WHERE website_id = $1
+			AND type = ANY($2)
+			AND status = ANY($3)`
+	args := []any{websiteID, filter.Types, filter.Statuses}
+
+	if tagID != nil {
+		args = append(args, *tagID)
+		query += fmt.Sprintf(" AND id IN (SELECT page_id FROM pages_tags WHERE tag_id = $%d)", len(args))
+	}
+	if filter.Language != nil {
+		args = append(args, *filter.Language)
+		query += fmt.Sprintf(" AND language = $%d", len(args))
+	}
+	if filter.From != nil {
+		args = append(args, *filter.From)
+		query += fmt.Sprintf(" AND date >= $%d", len(args))
+	}
+	if filter.To != nil {
+		args = append(args, *filter.To)
+		query += fmt.Sprintf(" AND date <= $%d", len(args))
+	}
+	if filter.After != nil {
+		args = append(args, filter.After.Date, filter.After.ID)
+		query += fmt.Sprintf(" AND (date, id) < ($%d, $%d)", len(args)-1, len(args))
+	}
+
+	args = append(args, filter.Limit)
+	query += fmt.Sprintf(" ORDER BY date DESC, id DESC LIMIT $%d", len(args))
+
+	err = db.Select(ctx, &pages, query, args...)
+	if err != nil {
+		err = fmt.Errorf("content.FindPagesMetadataWithFilter: %w", err)
+		return
+	}
+
+	return
+}
+
+// FindRelatedPostsMetadata returns the published posts that share the most tags with the given page,
+// then the most recent ones.
+func (repo *ContentRepository) FindRelatedPostsMetadata(ctx context.Context, db db.Queryer, websiteID, pageID guid.GUID, limit int64) (posts []content.PageMetadata, err error) {
+	posts = make([]content.PageMetadata, 0, limit)
+	const query = `SELECT pages.id, pages.created_at, pages.updated_at, pages.date, pages.type, pages.title,
+			pages.description, pages.path, pages.size, pages.body_hash, pages.metadata_hash, pages.status,
+			pages.language, pages.send_as_newsletter, pages.newsletter_sent_at, pages.paid_only
+		FROM pages
+			LEFT JOIN pages_tags ON pages_tags.page_id = pages.id
+				AND pages_tags.tag_id IN (SELECT tag_id FROM pages_tags WHERE page_id = $2)
+		WHERE pages.website_id = $1
+			AND pages.id != $2
+			AND pages.type = $3
+			AND pages.status = $4
+		GROUP BY pages.id
+		ORDER BY COUNT(pages_tags.tag_id) DESC, pages.date DESC, pages.id DESC
+		LIMIT $5`
+
+	err = db.Select(ctx, &posts, query, websiteID, pageID, content.PageTypePost, content.PageStatusPublished, limit)
+	if err != nil {
+		err = fmt.Errorf("content.FindRelatedPostsMetadata: %w", err)
+		return
+	}
+
+	return
+}
+
+// FindPagesMetadataByTranslationKey returns the pages of all statuses with the given translation key,
+// sorted by language.
+func (repo *ContentRepository) FindPagesMetadataByTranslationKey(ctx context.Context, db db.Queryer, websiteID guid.GUID, translationKey string) (pages []content.PageMetadata, err error) {
+	pages = make([]content.PageMetadata, 0, 5)
+	const query = `SELECT id, created_at, updated_at, date, type, title, description, path, size,
+			body_hash, metadata_hash, status, language, send_as_newsletter, newsletter_sent_at, paid_only
+		FROM pages
+		WHERE website_id = $1 AND translation_key = $2
+		ORDER BY language`
+
+	err = db.Select(ctx, &pages, query, websiteID, translationKey)
+	if err != nil {
+		err = fmt.Errorf("content.FindPagesMetadataByTranslationKey: %w", err)
+		return
+	}
+
+	return
+}
+
+func (repo *ContentRepository) FindPagesByIDs(ctx context.Context, db db.Queryer, websiteID guid.GUID, pageIDs []guid.GUID) (pages []content.Page, err error) {
+	pages = make([]content.Page, 0, len(pageIDs))
+	if len(pageIDs) == 0 {
+		return
+	}
+
+	const query = `SELECT * FROM pages WHERE website_id = $1 AND id = ANY($2)`
+
+	err = db.Select(ctx, &pages, query, websiteID, pageIDs)
+	if err != nil {
+		err = fmt.Errorf("content.FindPagesByIDs: %w", err)
+		return
+	}
+
+	return
+}
+
 func (repo *ContentRepository) FindScheduledPagesToPublish(ctx context.Context, db db.Queryer, forUpdate bool) (pages []content.Page, err error) {
 	pages = make([]content.Page, 0, 5)
 	now := time.Now().UTC()
@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/bloom42/stdx-go/db"
+	"github.com/bloom42/stdx-go/guid"
+	"markdown.ninja/pkg/services/content"
+)
+
+// searchVectorExpression computes the search vector of a page. The title is the most important,
+// then tags and description, then the body.
+// The query using it must select from pages and provide the language_config and body_text columns.
+const searchVectorExpression = `setweight(to_tsvector(language_config, pages.title), 'A')
+	|| setweight(to_tsvector(language_config, COALESCE(
+		(SELECT string_agg(tags.name, ' ') FROM pages_tags
+			INNER JOIN tags ON tags.id = pages_tags.tag_id
+			WHERE pages_tags.page_id = pages.id),
+		'')), 'B')
+	|| setweight(to_tsvector(language_config, pages.description), 'B')
+	|| setweight(to_tsvector(language_config, body_text), 'D')`
+
+// SearchHighlightStart and SearchHighlightStop are used to delimit the matching terms in highlights.
+// They are control characters that can't appear in the indexed text so the highlights can be safely
+// HTML-escaped before replacing them with <mark> tags.
+const (
+	SearchHighlightStart = "\x02"
+	SearchHighlightStop  = "\x03"
+)
+
+func (repo *ContentRepository) IndexPageForSearch(ctx context.Context, db db.Queryer, pageID guid.GUID,
+	languageConfig string, bodyText string) (err error) {
+	query := `INSERT INTO pages_search (page_id, updated_at, language_config, body_text, vector, website_id)
+		SELECT pages.id, $2, search.language_config, search.body_text, ` + searchVectorExpression + `, pages.website_id
+		FROM pages
+		CROSS JOIN LATERAL (SELECT $3::regconfig AS language_config, $4::text AS body_text) AS search
+		WHERE pages.id = $1
+		ON CONFLICT (page_id) DO UPDATE
+			SET updated_at = EXCLUDED.updated_at, language_config = EXCLUDED.language_config,
+				body_text = EXCLUDED.body_text, vector = EXCLUDED.vector`
+
+	_, err = db.Exec(ctx, query, pageID, time.Now().UTC(), languageConfig, bodyText)
+	if err != nil {
+		err = fmt.Errorf("content.IndexPageForSearch: %w", err)
+		return
+	}
+
+	return
+}
+
+// ReindexPagesForSearch recomputes the search vector of the given pages with their current title,
+// description and tags. It's used when tags are renamed or deleted.
+func (repo *ContentRepository) ReindexPagesForSearch(ctx context.Context, db db.Queryer, pageIDs []guid.GUID) (err error) {
+	if len(pageIDs) == 0 {
+		return nil
+	}
+
+	query := `UPDATE pages_search
+		SET updated_at = $2, vector = ` + searchVectorExpression + `
+		FROM pages
+		WHERE pages.id = pages_search.page_id AND pages_search.page_id = ANY($1)`
+
+	_, err = db.Exec(ctx, query, pageIDs, time.Now().UTC())
+	if err != nil {
+		err = fmt.Errorf("content.ReindexPagesForSearch: %w", err)
+		return
+	}
+
+	return
+}
+
+func (repo *ContentRepository) FindPageIDsForTag(ctx context.Context, db db.Queryer, tagID guid.GUID) (pageIDs []guid.GUID, err error) {
+	pageIDs = make([]guid.GUID, 0)
+	const query = "SELECT page_id FROM pages_tags WHERE tag_id = $1"
+
+	err = db.Select(ctx, &pageIDs, query, tagID)
+	if err != nil {
+		err = fmt.Errorf("content.FindPageIDsForTag: %w", err)
+		return
+	}
+
+	return
+}
+
+// SearchPublishedPages returns the published pages matching input.Query, ordered by relevance.
+// The query is parsed with websearch_to_tsquery so it supports "quoted phrases", OR and -exclusion,
+// and is stemmed with the text search configuration of each page.
+func (repo *ContentRepository) SearchPublishedPages(ctx context.Context, db db.Queryer, input content.SearchPagesInput) (results []content.PageSearchResult, err error) {
+	results = make([]content.PageSearchResult, 0, input.Limit)
+	headlineOptions := fmt.Sprintf(`StartSel="%s", StopSel="%s", HighlightAll=true`, SearchHighlightStart, SearchHighlightStop)
+	bodyHeadlineOptions := fmt.Sprintf(`StartSel="%s", StopSel="%s", MaxFragments=2, MaxWords=24, MinWords=12, FragmentDelimiter=" … "`,
+		SearchHighlightStart, SearchHighlightStop)
+
+	const query = `SELECT pages.id, pages.created_at, pages.updated_at, pages.date, pages.type, pages.title,
+			pages.description, pages.path, pages.size, pages.body_hash, pages.metadata_hash,
+			pages.status, pages.language, pages.send_as_newsletter, pages.newsletter_sent_at, pages.paid_only,
+			ts_rank_cd(pages_search.vector, search_query) AS rank,
+			ts_headline(pages_search.language_config, pages.title, search_query, $6) AS title_highlight,
+			ts_headline(pages_search.language_config, pages_search.body_text, search_query, $7) AS body_highlight
+		FROM pages_search
+		INNER JOIN pages ON pages.id = pages_search.page_id
+		CROSS JOIN LATERAL websearch_to_tsquery(pages_search.language_config, $2) AS search_query
+		WHERE pages_search.website_id = $1
+			AND pages.status = $3
+			AND pages.type = ANY($4)
+			AND ($5::text = '' OR pages.language = $5::text)
+			AND pages_search.vector @@ search_query
+		ORDER BY rank DESC, pages.date DESC
+		LIMIT $8`
+
+	err = db.Select(ctx, &results, query, input.WebsiteID, input.Query, content.PageStatusPublished, input.Types,
+		input.Language, headlineOptions, bodyHeadlineOptions, input.Limit)
+	if err != nil {
+		err = fmt.Errorf("content.SearchPublishedPages: %w", err)
+		return
+	}
+
+	return
+}
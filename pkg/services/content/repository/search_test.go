@@ -0,0 +1,230 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/bloom42/stdx-go/db"
+	"github.com/bloom42/stdx-go/guid"
+	"github.com/bloom42/stdx-go/migrate"
+	"markdown.ninja/migrations"
+	"markdown.ninja/pkg/services/content"
+	"markdown.ninja/pkg/services/kernel"
+	"markdown.ninja/pkg/services/organizations"
+	organizationsrepository "markdown.ninja/pkg/services/organizations/repository"
+	"markdown.ninja/pkg/services/websites"
+	websitesrepository "markdown.ninja/pkg/services/websites/repository"
+)
+
+// errRollbackTestTransaction is returned by the test transactions so nothing is saved in the database
+var errRollbackTestTransaction = errors.New("rollback test transaction")
+
+// withTestTransaction runs fn within a transaction of the database at DATABASE_URL that is always
+// rolled back. The test is skipped if DATABASE_URL is not set.
+func withTestTransaction(t *testing.T, fn func(ctx context.Context, tx db.Tx)) {
+	databaseUrl := os.Getenv("DATABASE_URL")
+	if databaseUrl == "" {
+		t.Skip("DATABASE_URL is not set")
+	}
+	ctx := context.Background()
+
+	database, err := db.Connect(databaseUrl, 2)
+	if err != nil {
+		t.Fatalf("connecting to database: %s", err)
+	}
+	defer database.Close()
+
+	dbMigrations, err := migrate.Load(migrations.MigrationsFs)
+	if err != nil {
+		t.Fatalf("loading migrations: %s", err)
+	}
+	err = db.Migrate(ctx, slog.New(slog.DiscardHandler), database, dbMigrations)
+	if err != nil {
+		t.Fatalf("applying migrations: %s", err)
+	}
+
+	err = database.Transaction(ctx, func(tx db.Tx) error {
+		fn(ctx, tx)
+		return errRollbackTestTransaction
+	})
+	if !errors.Is(err, errRollbackTestTransaction) {
+		t.Fatalf("running test transaction: %s", err)
+	}
+}
+
+func createTestWebsite(t *testing.T, ctx context.Context, tx db.Tx) websites.Website {
+	now := time.Now().UTC()
+	organization := organizations.Organization{
+		ID:        guid.NewTimeBased(),
+		CreatedAt: now,
+		UpdatedAt: now,
+		Name:      "Search",
+		Plan:      kernel.PlanFree.ID,
+	}
+	organizationsRepo := organizationsrepository.NewOrganizationsRepository()
+	err := organizationsRepo.CreateOrganization(ctx, tx, organization)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	website := websites.Website{
+		ID:             guid.NewTimeBased(),
+		CreatedAt:      now,
+		UpdatedAt:      now,
+		ModifiedAt:     now,
+		Name:           "Search",
+		Slug:           "search-" + guid.NewTimeBased().String(),
+		Language:       "en",
+		PrimaryDomain:  guid.NewTimeBased().String() + ".example.com",
+		Currency:       websites.CurrencyUSD,
+		Theme:          "blog",
+		OrganizationID: organization.ID,
+	}
+	websitesRepo := websitesrepository.NewWebsitesRepository()
+	err = websitesRepo.CreateWebsite(ctx, tx, website)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return website
+}
+
+// createTestPage creates and indexes a page (a published post by default) with bodyText as its search text
+func createTestPage(t *testing.T, ctx context.Context, tx db.Tx, website websites.Website, page content.Page, bodyText string) content.Page {
+	repo := NewContentRepository()
+	now := time.Now().UTC()
+
+	page.ID = guid.NewTimeBased()
+	page.CreatedAt = now
+	page.UpdatedAt = now
+	if page.Date.IsZero() {
+		page.Date = now
+	}
+	if page.Type == "" {
+		page.Type = content.PageTypePost
+	}
+	if page.Status == "" {
+		page.Status = content.PageStatusPublished
+	}
+	if page.Language == "" {
+		page.Language = "en"
+	}
+	page.Path = "/" + page.ID.String()
+	page.BodyHash = []byte{}
+	page.MetadataHash = []byte{}
+	page.WebsiteID = website.ID
+
+	err := repo.CreatePage(ctx, tx, page)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = repo.IndexPageForSearch(ctx, tx, page.ID, content.SearchLanguageConfig(page.Language), bodyText)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return page
+}
+
+func searchTestPages(t *testing.T, ctx context.Context, tx db.Tx, input content.SearchPagesInput) []guid.GUID {
+	if input.Types == nil {
+		input.Types = []content.PageType{content.PageTypePage, content.PageTypePost}
+	}
+	if input.Limit == 0 {
+		input.Limit = 10
+	}
+
+	repo := NewContentRepository()
+	results, err := repo.SearchPublishedPages(ctx, tx, input)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pageIDs := make([]guid.GUID, len(results))
+	for i, result := range results {
+		pageIDs[i] = result.ID
+	}
+	return pageIDs
+}
+
+func TestSearchPublishedPagesRanking(t *testing.T) {
+	withTestTransaction(t, func(ctx context.Context, tx db.Tx) {
+		website := createTestWebsite(t, ctx, tx)
+
+		bodyMatch := createTestPage(t, ctx, tx, website, content.Page{Title: "Weekly notes"},
+			"This week I learned how goroutines are scheduled")
+		descriptionMatch := createTestPage(t, ctx, tx, website, content.Page{Title: "Concurrency",
+			Description: "An introduction to goroutines"}, "Threads, channels and more")
+		titleMatch := createTestPage(t, ctx, tx, website, content.Page{Title: "Understanding goroutines"},
+			"A deep dive into the runtime")
+		createTestPage(t, ctx, tx, website, content.Page{Title: "Cooking"}, "How to bake bread")
+
+		pageIDs := searchTestPages(t, ctx, tx, content.SearchPagesInput{WebsiteID: website.ID, Query: "goroutine"})
+		expected := []guid.GUID{titleMatch.ID, descriptionMatch.ID, bodyMatch.ID}
+		if len(pageIDs) != len(expected) {
+			t.Fatalf("search returned %d pages, expected %d", len(pageIDs), len(expected))
+		}
+		for i := range expected {
+			if pageIDs[i] != expected[i] {
+				t.Errorf("result %d is %s, expected %s", i, pageIDs[i], expected[i])
+			}
+		}
+	})
+}
+
+func TestSearchPublishedPagesFilters(t *testing.T) {
+	withTestTransaction(t, func(ctx context.Context, tx db.Tx) {
+		website := createTestWebsite(t, ctx, tx)
+		otherWebsite := createTestWebsite(t, ctx, tx)
+
+		post := createTestPage(t, ctx, tx, website, content.Page{Title: "Gardening tips"}, "")
+		page := createTestPage(t, ctx, tx, website, content.Page{Title: "Gardening services",
+			Type: content.PageTypePage}, "")
+		frenchPost := createTestPage(t, ctx, tx, website, content.Page{Title: "Gardening en hiver",
+			Language: "fr"}, "")
+		createTestPage(t, ctx, tx, website, content.Page{Title: "Gardening draft",
+			Status: content.PageStatusDraft}, "")
+		createTestPage(t, ctx, tx, website, content.Page{Title: "Gardening scheduled",
+			Status: content.PageStatusScheduled}, "")
+		createTestPage(t, ctx, tx, otherWebsite, content.Page{Title: "Gardening elsewhere"}, "")
+
+		tests := []struct {
+			name     string
+			input    content.SearchPagesInput
+			expected []guid.GUID
+		}{
+			{"all published pages", content.SearchPagesInput{}, []guid.GUID{post.ID, page.ID, frenchPost.ID}},
+			{"posts only", content.SearchPagesInput{Types: []content.PageType{content.PageTypePost}},
+				[]guid.GUID{post.ID, frenchPost.ID}},
+			{"language", content.SearchPagesInput{Language: "fr"}, []guid.GUID{frenchPost.ID}},
+			{"no match", content.SearchPagesInput{Query: "cooking"}, []guid.GUID{}},
+			{"exclusion", content.SearchPagesInput{Query: "gardening -services -hiver"}, []guid.GUID{post.ID}},
+		}
+
+		for _, test := range tests {
+			test.input.WebsiteID = website.ID
+			if test.input.Query == "" {
+				test.input.Query = "gardening"
+			}
+
+			pageIDs := searchTestPages(t, ctx, tx, test.input)
+			if len(pageIDs) != len(test.expected) {
+				t.Errorf("%s: search returned %d pages, expected %d", test.name, len(pageIDs), len(test.expected))
+				continue
+			}
+			for _, expectedID := range test.expected {
+				found := false
+				for _, pageID := range pageIDs {
+					found = found || pageID == expectedID
+				}
+				if !found {
+					t.Errorf("%s: page %s is missing from the results", test.name, expectedID)
+				}
+			}
+		}
+	})
+}
@@ -79,6 +79,27 @@ func (repo *ContentRepository) FindTagsForPage(ctx context.Context, db db.Querye
 	return tags, nil
 }
 
+// FindTagsForPages returns the tags of all the given pages, ordered by page and name
+func (repo *ContentRepository) FindTagsForPages(ctx context.Context, db db.Queryer, pageIDs []guid.GUID) (tags []content.PageTag, err error) {
+	tags = make([]content.PageTag, 0, len(pageIDs))
+	if len(pageIDs) == 0 {
+		return
+	}
+
+	const query = `SELECT tags.*, pages_tags.page_id FROM tags
+			INNER JOIN pages_tags ON pages_tags.tag_id = tags.id
+		WHERE pages_tags.page_id = ANY($1)
+		ORDER BY pages_tags.page_id, tags.name
+	`
+
+	err = db.Select(ctx, &tags, query, pageIDs)
+	if err != nil {
+		return tags, fmt.Errorf("content.FindTagsForPages: %w", err)
+	}
+
+	return tags, nil
+}
+
 func (repo *ContentRepository) FindTagByName(ctx context.Context, db db.Queryer, websiteID guid.GUID, name string) (tag content.Tag, err error) {
 	const query = "SELECT * FROM tags WHERE website_id = $1 AND name = $2"
 
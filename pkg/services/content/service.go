@@ -6,6 +6,7 @@ import (
 
 	"github.com/bloom42/stdx-go/db"
 	"github.com/bloom42/stdx-go/guid"
+	"markdown.ninja/pkg/images"
 	"markdown.ninja/pkg/services/kernel"
 	"markdown.ninja/pkg/services/websites"
 )
@@ -20,6 +21,8 @@ type Service interface {
 	UploadAsset(ctx context.Context, input UploadAssetInput, bypassAuthCheck bool) (asset Asset, err error)
 	GetAsset(ctx context.Context, input GetAssetInput) (asset Asset, err error)
 	GetAssetData(ctx context.Context, asset Asset, options *GetAssetDataOptions) (ret io.ReadCloser, err error)
+	// GetImageVariant returns the image asset resized and converted according to options
+	GetImageVariant(ctx context.Context, asset Asset, options images.TransformOptions) (data []byte, format images.Format, err error)
 	// DeleteAssetI(ctx context.Context, tx db.Queryer, assetID guid.GUID) (err error)
 	DeleteWebsiteData(ctx context.Context, db db.Queryer, websiteID guid.GUID) (err error)
 	// GetVideoIframe(ctx context.Context, assetID guid.GUID) (iframeHtml string, err error)
@@ -46,15 +49,31 @@ type Service interface {
 	FindPageByPath(ctx context.Context, db db.Queryer, websiteID guid.GUID, path string) (page Page, err error)
 	FindPublishedPagesMetadata(ctx context.Context, db db.Queryer, websiteID guid.GUID, pageTypes []PageType, limit int64) (posts []PageMetadata, err error)
 	FindPublishedPagesMetadataForTag(ctx context.Context, db db.Queryer, websiteID guid.GUID, pageTypes []PageType, tag string) (pages []PageMetadata, err error)
+	FindPublishedPagesMetadataForAuthor(ctx context.Context, db db.Queryer, websiteID guid.GUID, pageTypes []PageType, authorSlug string) (pages []PageMetadata, err error)
 	FindPageByID(ctx context.Context, db db.Queryer, pageID guid.GUID) (page Page, err error)
 	FindLastPublishedPageOrPost(ctx context.Context, db db.Queryer, websiteID guid.GUID) (page Page, err error)
 	FindLastPublishedPost(ctx context.Context, db db.Queryer, websiteID guid.GUID) (page Page, err error)
 	ListPages(ctx context.Context, input ListPagesInput) (pages kernel.PaginatedResult[PageMetadata], err error)
 	ListPosts(ctx context.Context, input ListPagesInput) (posts kernel.PaginatedResult[PageMetadata], err error)
+	SearchPages(ctx context.Context, db db.Queryer, input SearchPagesInput) (results []PageSearchResult, err error)
+	// FindPagesMetadataWithFilter is the filtered and paginated version of FindPublishedPagesMetadata
+	FindPagesMetadataWithFilter(ctx context.Context, db db.Queryer, websiteID guid.GUID, filter PagesFilter) (pages []PageMetadata, err error)
+	// FindRelatedPostsMetadata returns the published posts related to a page, ranked by the number of tags
+	// they have in common with the page
+	FindRelatedPostsMetadata(ctx context.Context, db db.Queryer, websiteID, pageID guid.GUID, limit int64) (posts []PageMetadata, err error)
+	// FindPageTranslations returns the published versions of the page in other languages
+	FindPageTranslations(ctx context.Context, db db.Queryer, page Page) (translations []PageMetadata, err error)
+	FindPagesByIDs(ctx context.Context, db db.Queryer, websiteID guid.GUID, pageIDs []guid.GUID) (pages []Page, err error)
 	ValidatePageBodyMarkdown(body string) (err error)
 	GetPagesCountForWebsite(ctx context.Context, db db.Queryer, websiteID guid.GUID) (count int64, err error)
 	ValidatePageTitle(titel string) error
 
+	// Page revisions
+	ListPageRevisions(ctx context.Context, input ListPageRevisionsInput) (ret kernel.PaginatedResult[PageRevisionMetadata], err error)
+	GetPageRevision(ctx context.Context, input GetPageRevisionInput) (revision PageRevision, err error)
+	DiffPageRevisions(ctx context.Context, input DiffPageRevisionsInput) (ret PageRevisionsDiff, err error)
+	RestorePageRevision(ctx context.Context, input RestorePageRevisionInput) (page Page, err error)
+
 	// Tags
 	CreateTag(ctx context.Context, input CreateTagInput) (tag Tag, err error)
 	UpdateTag(ctx context.Context, input UpdateTagInput) (tag Tag, err error)
@@ -63,6 +82,23 @@ type Service interface {
 	FindTags(ctx context.Context, db db.Queryer, websiteID guid.GUID) (tags []Tag, err error)
 	FindTag(ctx context.Context, db db.Queryer, websiteID guid.GUID, tag string) (ret Tag, err error)
 	GetTags(ctx context.Context, input GetTagsInput) (tags []Tag, err error)
+	FindTagsForPages(ctx context.Context, db db.Queryer, pageIDs []guid.GUID) (tags map[guid.GUID][]Tag, err error)
+
+	// Authors
+	CreateAuthor(ctx context.Context, input CreateAuthorInput) (author Author, err error)
+	UpdateAuthor(ctx context.Context, input UpdateAuthorInput) (author Author, err error)
+	DeleteAuthor(ctx context.Context, input DeleteAuthorInput) (err error)
+	GetAuthor(ctx context.Context, input GetAuthorInput) (author Author, err error)
+	ListAuthors(ctx context.Context, input ListAuthorsInput) (ret kernel.PaginatedResult[Author], err error)
+	FindAuthors(ctx context.Context, db db.Queryer, websiteID guid.GUID) (authors []Author, err error)
+	FindAuthor(ctx context.Context, db db.Queryer, websiteID guid.GUID, slug string) (author Author, err error)
+	FindAuthorsForPage(ctx context.Context, db db.Queryer, pageID guid.GUID) (authors []Author, err error)
+	FindAuthorsForPages(ctx context.Context, db db.Queryer, pageIDs []guid.GUID) (authors map[guid.GUID][]Author, err error)
+
+	// Preview tokens
+	CreatePreviewToken(ctx context.Context, input CreatePreviewTokenInput) (token PreviewToken, err error)
+	// VerifyPreviewToken returns an error if the token is not a valid preview token for the website
+	VerifyPreviewToken(websiteID guid.GUID, token string) (err error)
 
 	// Snippets
 	CreateSnippet(ctx context.Context, input CreateSnippetInput) (snippet Snippet, err error)
@@ -70,7 +106,7 @@ type Service interface {
 	DeleteSnippet(ctx context.Context, input DeleteSnippetInput) (err error)
 	FindSnippets(ctx context.Context, db db.Queryer, websiteID guid.GUID) (snippets []Snippet, err error)
 	ListSnippets(ctx context.Context, input ListSnippetsInput) (ret kernel.PaginatedResult[Snippet], err error)
-	RenderMarkdown(website websites.Website, markdownInput string, snippets []Snippet, isEmail bool) (html string)
+	RenderMarkdown(ctx context.Context, website websites.Website, markdownInput string, snippets []Snippet, isEmail bool) (html string)
 	RenderSnippets(htmlInput string, snippets []Snippet, isEmail bool) (ret string)
 	SanitizeHtml(input string) string
 
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"github.com/bloom42/stdx-go/db"
+	"github.com/bloom42/stdx-go/guid"
+	"markdown.ninja/pkg/services/content"
+)
+
+// findAuthorsBySlugs returns the authors of the website matching slugs, in the same order.
+// Duplicated slugs are ignored and an error is returned if an author doesn't exist.
+func (service *ContentService) findAuthorsBySlugs(ctx context.Context, db db.Queryer, websiteID guid.GUID, slugs []string) (authors []content.Author, err error) {
+	authors = make([]content.Author, 0, len(slugs))
+	if len(slugs) == 0 {
+		return
+	}
+
+	siteAuthors, err := service.repo.FindAuthorsForWebsite(ctx, db, websiteID)
+	if err != nil {
+		return
+	}
+
+	siteAuthorsMap := make(map[string]content.Author, len(siteAuthors))
+	for _, author := range siteAuthors {
+		siteAuthorsMap[author.Slug] = author
+	}
+
+	alreadyAdded := make(map[string]bool, len(slugs))
+	for _, slug := range slugs {
+		slug = strings.ToLower(strings.TrimSpace(slug))
+		if slug == "" || alreadyAdded[slug] {
+			continue
+		}
+
+		author, exists := siteAuthorsMap[slug]
+		if !exists {
+			err = content.ErrPageAuthorNotFound(slug)
+			return
+		}
+
+		authors = append(authors, author)
+		alreadyAdded[slug] = true
+	}
+
+	if len(authors) > content.PageMaxAuthors {
+		err = content.ErrPageHasTooManyAuthors
+		return
+	}
+
+	return
+}
+
+// associateAuthorsToPage replaces the authors of the page by authors
+func (service *ContentService) associateAuthorsToPage(ctx context.Context, db db.Queryer, pageID guid.GUID, authors []content.Author) (err error) {
+	err = service.repo.DeleteAuthorPageRelationsForPage(ctx, db, pageID)
+	if err != nil {
+		return err
+	}
+
+	for position, author := range authors {
+		relation := content.AuthorPageRelation{
+			Position: int64(position),
+			PageID:   pageID,
+			AuthorID: author.ID,
+		}
+		err = service.repo.CreateAuthorPageRelation(ctx, db, relation)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// cleanAuthorSocialLinks trims the social links and removes the empty ones
+func cleanAuthorSocialLinks(links []content.AuthorSocialLink) content.AuthorSocialLinks {
+	ret := make(content.AuthorSocialLinks, 0, len(links))
+	for _, link := range links {
+		link.Name = strings.TrimSpace(link.Name)
+		link.Url = strings.TrimSpace(link.Url)
+		if link.Name == "" && link.Url == "" {
+			continue
+		}
+		ret = append(ret, link)
+	}
+	return ret
+}
+
+func authorsSlugs(authors []content.Author) []string {
+	slugs := make([]string, len(authors))
+	for i, author := range authors {
+		slugs[i] = author.Slug
+	}
+	return slugs
+}
+
+// validateAuthorAvatar checks that the avatar is an image asset of the website
+func (service *ContentService) validateAuthorAvatar(ctx context.Context, db db.Queryer, websiteID guid.GUID, avatarID *guid.GUID) (err error) {
+	if avatarID == nil {
+		return nil
+	}
+
+	avatar, err := service.FindWebsiteAssetByID(ctx, db, websiteID, *avatarID)
+	if err != nil {
+		return err
+	}
+
+	if avatar.Type != content.AssetTypeImage {
+		return content.ErrAuthorAvatarIsNotAnImage
+	}
+
+	return nil
+}
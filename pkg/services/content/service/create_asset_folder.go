@@ -11,13 +11,14 @@ import (
 	"markdown.ninja/pkg/server/httpctx"
 	"markdown.ninja/pkg/services/content"
 	"markdown.ninja/pkg/services/kernel"
+	"markdown.ninja/pkg/services/organizations"
 	"markdown.ninja/pkg/services/websites"
 )
 
 func (service *ContentService) CreateAssetFolder(ctx context.Context, input content.CreateAssetFolderInput) (folder content.Asset, err error) {
 	actorID, err := service.kernel.CurrentUserID(ctx)
 	if err == nil {
-		err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, input.WebsiteID)
+		err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, input.WebsiteID, kernel.PermissionWriteContent)
 		if err != nil {
 			return
 		}
@@ -34,7 +35,7 @@ func (service *ContentService) CreateAssetFolder(ctx context.Context, input cont
 			return
 		}
 
-		_, err = service.organizationsService.CheckCurrentApiKey(ctx, website.OrganizationID)
+		_, err = service.organizationsService.CheckCurrentApiKey(ctx, website.OrganizationID, website.ID, organizations.ApiKeyScopeAssetsWrite)
 		if err != nil {
 			return
 		}
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/bloom42/stdx-go/db"
+	"github.com/bloom42/stdx-go/guid"
+	"markdown.ninja/pkg/errs"
+	"markdown.ninja/pkg/services/content"
+	"markdown.ninja/pkg/services/kernel"
+)
+
+func (service *ContentService) CreateAuthor(ctx context.Context, input content.CreateAuthorInput) (author content.Author, err error) {
+	actorID, err := service.kernel.CurrentUserID(ctx)
+	if err != nil {
+		return
+	}
+	err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, input.WebsiteID, kernel.PermissionWriteContent)
+	if err != nil {
+		return
+	}
+
+	slug := strings.TrimSpace(input.Slug)
+	name := strings.TrimSpace(input.Name)
+	bio := strings.TrimSpace(input.Bio)
+	socialLinks := cleanAuthorSocialLinks(input.SocialLinks)
+	now := time.Now().UTC()
+
+	err = service.validateAuthorSlug(slug)
+	if err != nil {
+		return
+	}
+
+	err = service.validateAuthorName(name)
+	if err != nil {
+		return
+	}
+
+	err = service.validateAuthorBio(bio)
+	if err != nil {
+		return
+	}
+
+	err = service.validateAuthorSocialLinks(socialLinks)
+	if err != nil {
+		return
+	}
+
+	err = service.validateAuthorAvatar(ctx, service.db, input.WebsiteID, input.AvatarID)
+	if err != nil {
+		return
+	}
+
+	// check that an author with the same slug doesn't already exist
+	_, err = service.repo.FindAuthorBySlug(ctx, service.db, input.WebsiteID, slug)
+	if err == nil {
+		err = content.ErrAuthorAlreadyExists(slug)
+	} else {
+		if errs.IsNotFound(err) {
+			err = nil
+		}
+	}
+	if err != nil {
+		return
+	}
+
+	author = content.Author{
+		ID:          guid.NewTimeBased(),
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		Slug:        slug,
+		Name:        name,
+		Bio:         bio,
+		SocialLinks: socialLinks,
+		AvatarID:    input.AvatarID,
+		WebsiteID:   input.WebsiteID,
+	}
+
+	err = service.db.Transaction(ctx, func(tx db.Tx) (txErr error) {
+		txErr = service.repo.CreateAuthor(ctx, tx, author)
+		if txErr != nil {
+			if db.IsErrAlreadyExists(txErr) {
+				return content.ErrAuthorAlreadyExists(slug)
+			}
+			return txErr
+		}
+
+		txErr = service.websitesService.UpdateWebsiteModifiedAt(ctx, tx, author.WebsiteID, now)
+		return txErr
+	})
+
+	return
+}
@@ -26,7 +26,7 @@ func (service *ContentService) CreatePage(ctx context.Context, input content.Cre
 
 	actorID, err := service.kernel.CurrentUserID(ctx)
 	if err == nil {
-		err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, input.WebsiteID)
+		err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, input.WebsiteID, kernel.PermissionWriteContent)
 		if err != nil {
 			return
 		}
@@ -47,7 +47,7 @@ func (service *ContentService) CreatePage(ctx context.Context, input content.Cre
 			return
 		}
 
-		_, err = service.organizationsService.CheckCurrentApiKey(ctx, website.OrganizationID)
+		_, err = service.organizationsService.CheckCurrentApiKey(ctx, website.OrganizationID, website.ID, organizations.ApiKeyScopeContentWrite)
 		if err != nil {
 			return
 		}
@@ -97,10 +97,8 @@ func (service *ContentService) CreatePage(ctx context.Context, input content.Cre
 	size := int64(len(input.BodyMarkdown))
 	bodyHash := blake3.Sum256([]byte(bodyMarkdown))
 
-	bodyHtml, err := markdown.ToHtmlPage(
-		bodyMarkdown,
-		service.httpConfig.WebsitesBaseUrl.Scheme+"://"+website.PrimaryDomain+service.httpConfig.WebsitesPort,
-	)
+	websiteUrl := service.httpConfig.WebsitesBaseUrl.Scheme + "://" + website.PrimaryDomain + service.httpConfig.WebsitesPort
+	bodyHtml, err := markdown.ToHtmlPage(bodyMarkdown, websiteUrl, nil)
 	if err != nil {
 		return
 	}
@@ -134,6 +132,21 @@ func (service *ContentService) CreatePage(ctx context.Context, input content.Cre
 		return
 	}
 
+	err = service.validatePagePathForLanguage(website, path, language)
+	if err != nil {
+		return
+	}
+
+	translationKey, err := service.cleanPageTranslationKey(input.TranslationKey)
+	if err != nil {
+		return
+	}
+
+	err = service.checkPageTranslationIsAvailable(ctx, service.db, website.ID, guid.Empty, translationKey, language)
+	if err != nil {
+		return
+	}
+
 	description := strings.TrimSpace(input.Description)
 	err = service.validatePageDescription(description)
 	if err != nil {
@@ -156,6 +169,12 @@ func (service *ContentService) CreatePage(ctx context.Context, input content.Cre
 		return
 	}
 
+	authors, err := service.findAuthorsBySlugs(ctx, service.db, website.ID, input.Authors)
+	if err != nil {
+		return
+	}
+	authorSlugs := authorsSlugs(authors)
+
 	err = service.organizationsService.CheckBillingGatedAction(ctx, service.db, website.OrganizationID, organizations.BillingGatedActionCreatePage{
 		WebsiteID: website.ID,
 	})
@@ -163,7 +182,13 @@ func (service *ContentService) CreatePage(ctx context.Context, input content.Cre
 		return
 	}
 
-	metadataHash := content.HashPageMetadata(pageType, path, date, sendAsNewsletter, language, title, description, input.Tags)
+	revisionsPerPage, err := service.getRevisionsPerPage(ctx, service.db, website.OrganizationID)
+	if err != nil {
+		return
+	}
+
+	metadataHash := content.HashPageMetadata(pageType, path, date, sendAsNewsletter, input.PaidOnly, language, title, description, input.Tags, authorSlugs,
+		translationKey)
 
 	page = content.Page{
 		ID:               guid.NewTimeBased(),
@@ -182,7 +207,15 @@ func (service *ContentService) CreatePage(ctx context.Context, input content.Cre
 		BodyMarkdown:     bodyMarkdown,
 		SendAsNewsletter: sendAsNewsletter,
 		NewsletterSentAt: newsletterSentAt,
+		PaidOnly:         input.PaidOnly,
+		TranslationKey:   translationKey,
 		WebsiteID:        website.ID,
+		Authors:          authors,
+	}
+
+	searchText, err := service.getPageSearchText(page, bodyHtml, websiteUrl)
+	if err != nil {
+		return
 	}
 
 	var newsletter emails.Newsletter
@@ -201,12 +234,42 @@ func (service *ContentService) CreatePage(ctx context.Context, input content.Cre
 			return txErr
 		}
 
+		txErr = service.associateAuthorsToPage(ctx, tx, page.ID, authors)
+		if txErr != nil {
+			return txErr
+		}
+
+		// tags must be associated before indexing as they are part of the search vector
+		txErr = service.repo.IndexPageForSearch(ctx, tx, page.ID, content.SearchLanguageConfig(page.Language), searchText)
+		if txErr != nil {
+			return txErr
+		}
+
+		txErr = service.savePageRevision(ctx, tx, page, input.Tags, authorSlugs, revisionsPerPage)
+		if txErr != nil {
+			return txErr
+		}
+
 		txErr = service.websitesService.UpdateWebsiteModifiedAt(ctx, tx, website.ID, now)
 		if txErr != nil {
 			return txErr
 		}
 
-		return nil
+		if page.Status == content.PageStatusPublished {
+			txErr = service.dispatchPagePublishedEvent(ctx, tx, website, page)
+			if txErr != nil {
+				return txErr
+			}
+		}
+
+		txErr = service.organizationsService.RecordAuditLog(ctx, tx, organizations.RecordAuditLogInput{
+			WebsiteID: &website.ID,
+			Action:    organizations.AuditLogActionPageCreate,
+			EntityID:  page.ID.String(),
+			Before:    nil,
+			After:     pageToAuditLogState(page),
+		})
+		return txErr
 	})
 	if err != nil {
 		return
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"markdown.ninja/pkg/jwt"
+	"markdown.ninja/pkg/server/httpctx"
+	"markdown.ninja/pkg/services/content"
+	"markdown.ninja/pkg/services/kernel"
+	"markdown.ninja/pkg/services/organizations"
+	"markdown.ninja/pkg/services/websites"
+)
+
+func (service *ContentService) CreatePreviewToken(ctx context.Context, input content.CreatePreviewTokenInput) (ret content.PreviewToken, err error) {
+	actorID, err := service.kernel.CurrentUserID(ctx)
+	if err == nil {
+		err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, input.WebsiteID, kernel.PermissionWriteContent)
+		if err != nil {
+			return
+		}
+
+	} else {
+		var website websites.Website
+		httpCtx := httpctx.FromCtx(ctx)
+		if httpCtx.ApiKey == nil {
+			err = kernel.ErrPermissionDenied
+			return
+		}
+
+		website, err = service.websitesService.FindWebsiteByID(ctx, service.db, input.WebsiteID)
+		if err != nil {
+			return
+		}
+
+		_, err = service.organizationsService.CheckCurrentApiKey(ctx, website.OrganizationID, website.ID, organizations.ApiKeyScopeContentWrite)
+		if err != nil {
+			return
+		}
+	}
+
+	expiresIn := content.PreviewTokenDefaultExpiresIn
+	if input.ExpiresIn != nil {
+		if *input.ExpiresIn < 1 || *input.ExpiresIn > int64(content.PreviewTokenMaxExpiresIn.Seconds()) {
+			err = content.ErrPreviewTokenExpiresInIsNotValid
+			return
+		}
+		expiresIn = time.Duration(*input.ExpiresIn) * time.Second
+	}
+
+	expiresAt := time.Now().UTC().Add(expiresIn).Truncate(time.Second)
+	claims := jwtClaimsPreview{
+		Action:    jwtActionPreview,
+		WebsiteID: input.WebsiteID,
+	}
+	token, err := service.jwtProvider.NewSignedToken(claims, &jwt.TokenOptions{
+		ExpirationTime: &expiresAt,
+	})
+	if err != nil {
+		err = fmt.Errorf("content.CreatePreviewToken: generating token: %w", err)
+		return
+	}
+
+	ret = content.PreviewToken{
+		Token:     token,
+		ExpiresAt: expiresAt,
+	}
+	return ret, nil
+}
@@ -12,6 +12,7 @@ import (
 	"markdown.ninja/pkg/server/httpctx"
 	"markdown.ninja/pkg/services/content"
 	"markdown.ninja/pkg/services/kernel"
+	"markdown.ninja/pkg/services/organizations"
 	"markdown.ninja/pkg/services/websites"
 )
 
@@ -25,7 +26,7 @@ func (service *ContentService) CreateSnippet(ctx context.Context, input content.
 			return snippet, kernel.ErrPermissionDenied
 		}
 
-		// err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, input.WebsiteID)
+		// err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, input.WebsiteID, kernel.PermissionWriteContent)
 		// if err != nil {
 		// 	return
 		// }
@@ -41,7 +42,7 @@ func (service *ContentService) CreateSnippet(ctx context.Context, input content.
 			return
 		}
 
-		_, err = service.organizationsService.CheckCurrentApiKey(ctx, website.OrganizationID)
+		_, err = service.organizationsService.CheckCurrentApiKey(ctx, website.OrganizationID, website.ID, organizations.ApiKeyScopeContentWrite)
 		if err != nil {
 			return
 		}
@@ -96,6 +97,17 @@ func (service *ContentService) CreateSnippet(ctx context.Context, input content.
 		}
 
 		txErr = service.websitesService.UpdateWebsiteModifiedAt(ctx, tx, input.WebsiteID, now)
+		if txErr != nil {
+			return txErr
+		}
+
+		txErr = service.organizationsService.RecordAuditLog(ctx, tx, organizations.RecordAuditLogInput{
+			WebsiteID: &snippet.WebsiteID,
+			Action:    organizations.AuditLogActionSnippetCreate,
+			EntityID:  snippet.ID.String(),
+			Before:    nil,
+			After:     snippet,
+		})
 		return txErr
 	})
 	if err != nil {
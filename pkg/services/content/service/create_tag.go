@@ -9,6 +9,7 @@ import (
 	"github.com/bloom42/stdx-go/guid"
 	"markdown.ninja/pkg/errs"
 	"markdown.ninja/pkg/services/content"
+	"markdown.ninja/pkg/services/kernel"
 )
 
 func (service *ContentService) CreateTag(ctx context.Context, input content.CreateTagInput) (tag content.Tag, err error) {
@@ -16,7 +17,7 @@ func (service *ContentService) CreateTag(ctx context.Context, input content.Crea
 	if err != nil {
 		return
 	}
-	err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, input.WebsiteID)
+	err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, input.WebsiteID, kernel.PermissionWriteContent)
 	if err != nil {
 		return
 	}
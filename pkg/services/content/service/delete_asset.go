@@ -12,6 +12,7 @@ import (
 	"markdown.ninja/pkg/server/httpctx"
 	"markdown.ninja/pkg/services/content"
 	"markdown.ninja/pkg/services/kernel"
+	"markdown.ninja/pkg/services/organizations"
 	"markdown.ninja/pkg/services/websites"
 )
 
@@ -23,7 +24,7 @@ func (service *ContentService) DeleteAsset(ctx context.Context, input content.De
 
 	actorID, err := service.kernel.CurrentUserID(ctx)
 	if err == nil {
-		err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, assetToDelete.WebsiteID)
+		err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, assetToDelete.WebsiteID, kernel.PermissionWriteContent)
 		if err != nil {
 			return
 		}
@@ -40,7 +41,7 @@ func (service *ContentService) DeleteAsset(ctx context.Context, input content.De
 			return
 		}
 
-		_, err = service.organizationsService.CheckCurrentApiKey(ctx, website.OrganizationID)
+		_, err = service.organizationsService.CheckCurrentApiKey(ctx, website.OrganizationID, website.ID, organizations.ApiKeyScopeAssetsWrite)
 		if err != nil {
 			return
 		}
@@ -48,6 +49,17 @@ func (service *ContentService) DeleteAsset(ctx context.Context, input content.De
 
 	err = service.db.Transaction(ctx, func(tx db.Tx) (txErr error) {
 		txErr = service.deleteAssetInternal(ctx, tx, assetToDelete)
+		if txErr != nil {
+			return txErr
+		}
+
+		txErr = service.organizationsService.RecordAuditLog(ctx, tx, organizations.RecordAuditLogInput{
+			WebsiteID: &assetToDelete.WebsiteID,
+			Action:    organizations.AuditLogActionAssetDelete,
+			EntityID:  assetToDelete.ID.String(),
+			Before:    assetToDelete,
+			After:     nil,
+		})
 		return txErr
 	})
 	return err
@@ -106,6 +118,11 @@ func (service *ContentService) deleteAssetInternal(ctx context.Context, tx db.Tx
 					err = errs.Internal(errMessage, err)
 					return
 				}
+
+				err = service.deleteImageVariants(ctx, tx, child)
+				if err != nil {
+					return
+				}
 			}
 		}
 
@@ -131,6 +148,11 @@ func (service *ContentService) deleteAssetInternal(ctx context.Context, tx db.Tx
 			err = errs.Internal(errMessage, err)
 			return
 		}
+
+		err = service.deleteImageVariants(ctx, tx, assetToDelete)
+		if err != nil {
+			return
+		}
 	}
 
 	err = service.repo.DeleteAsset(ctx, tx, assetToDelete.ID)
@@ -140,3 +162,28 @@ func (service *ContentService) deleteAssetInternal(ctx context.Context, tx db.Tx
 
 	return
 }
+
+// deleteImageVariants deletes the resized and converted variants of an image asset
+func (service *ContentService) deleteImageVariants(ctx context.Context, tx db.Tx, asset content.Asset) (err error) {
+	if asset.Type != content.AssetTypeImage {
+		return
+	}
+
+	job := queue.NewJobInput{
+		Data: content.JobDeleteAssetsDataWithPrefix{
+			Prefix: service.getImageVariantsStoragePrefix(asset),
+		},
+		// retry every 2 hours for 48 hours
+		RetryDelay: opt.Ptr(int64(2 * 3600)),
+		RetryMax:   opt.Int64(24),
+	}
+	err = service.queue.Push(ctx, tx, job)
+	if err != nil {
+		errMessage := "content.deleteImageVariants: Pushing DeleteAssetsDataWithPrefix job to queue"
+		slogx.FromCtx(ctx).Error(errMessage, slogx.Err(err))
+		err = errs.Internal(errMessage, err)
+		return
+	}
+
+	return
+}
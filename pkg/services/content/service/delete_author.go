@@ -0,0 +1,42 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/bloom42/stdx-go/db"
+	"markdown.ninja/pkg/services/content"
+	"markdown.ninja/pkg/services/kernel"
+)
+
+func (service *ContentService) DeleteAuthor(ctx context.Context, input content.DeleteAuthorInput) (err error) {
+	actorID, err := service.kernel.CurrentUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	author, err := service.repo.FindAuthorByID(ctx, service.db, input.ID)
+	if err != nil {
+		return
+	}
+
+	err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, author.WebsiteID, kernel.PermissionWriteContent)
+	if err != nil {
+		return
+	}
+
+	now := time.Now().UTC()
+
+	err = service.db.Transaction(ctx, func(tx db.Tx) (txErr error) {
+		// relations with pages are deleted with ON DELETE CASCADE
+		txErr = service.repo.DeleteAuthor(ctx, tx, author.ID)
+		if txErr != nil {
+			return txErr
+		}
+
+		txErr = service.websitesService.UpdateWebsiteModifiedAt(ctx, tx, author.WebsiteID, now)
+		return txErr
+	})
+
+	return err
+}
@@ -8,6 +8,7 @@ import (
 	"markdown.ninja/pkg/server/httpctx"
 	"markdown.ninja/pkg/services/content"
 	"markdown.ninja/pkg/services/kernel"
+	"markdown.ninja/pkg/services/organizations"
 	"markdown.ninja/pkg/services/websites"
 )
 
@@ -22,7 +23,7 @@ func (service *ContentService) DeletePage(ctx context.Context, input content.Del
 
 	actorID, err := service.kernel.CurrentUserID(ctx)
 	if err == nil {
-		err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, page.WebsiteID)
+		err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, page.WebsiteID, kernel.PermissionWriteContent)
 		if err != nil && !httpCtx.AccessToken.IsAdmin {
 			return err
 		}
@@ -39,7 +40,7 @@ func (service *ContentService) DeletePage(ctx context.Context, input content.Del
 			return
 		}
 
-		_, err = service.organizationsService.CheckCurrentApiKey(ctx, website.OrganizationID)
+		_, err = service.organizationsService.CheckCurrentApiKey(ctx, website.OrganizationID, website.ID, organizations.ApiKeyScopeContentWrite)
 		if err != nil {
 			return
 		}
@@ -59,6 +60,17 @@ func (service *ContentService) DeletePage(ctx context.Context, input content.Del
 		}
 
 		txErr = service.websitesService.UpdateWebsiteModifiedAt(ctx, tx, page.WebsiteID, now)
+		if txErr != nil {
+			return txErr
+		}
+
+		txErr = service.organizationsService.RecordAuditLog(ctx, tx, organizations.RecordAuditLogInput{
+			WebsiteID: &page.WebsiteID,
+			Action:    organizations.AuditLogActionPageDelete,
+			EntityID:  page.ID.String(),
+			Before:    pageToAuditLogState(page),
+			After:     nil,
+		})
 		return txErr
 	})
 	return err
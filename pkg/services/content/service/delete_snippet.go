@@ -6,6 +6,8 @@ import (
 
 	"github.com/bloom42/stdx-go/db"
 	"markdown.ninja/pkg/services/content"
+	"markdown.ninja/pkg/services/kernel"
+	"markdown.ninja/pkg/services/organizations"
 )
 
 func (service *ContentService) DeleteSnippet(ctx context.Context, input content.DeleteSnippetInput) (err error) {
@@ -19,7 +21,7 @@ func (service *ContentService) DeleteSnippet(ctx context.Context, input content.
 		return
 	}
 
-	err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, snippet.WebsiteID)
+	err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, snippet.WebsiteID, kernel.PermissionWriteContent)
 	if err != nil {
 		return
 	}
@@ -33,6 +35,17 @@ func (service *ContentService) DeleteSnippet(ctx context.Context, input content.
 		}
 
 		txErr = service.websitesService.UpdateWebsiteModifiedAt(ctx, tx, snippet.WebsiteID, now)
+		if txErr != nil {
+			return txErr
+		}
+
+		txErr = service.organizationsService.RecordAuditLog(ctx, tx, organizations.RecordAuditLogInput{
+			WebsiteID: &snippet.WebsiteID,
+			Action:    organizations.AuditLogActionSnippetDelete,
+			EntityID:  snippet.ID.String(),
+			Before:    snippet,
+			After:     nil,
+		})
 		return txErr
 	})
 	return err
@@ -6,6 +6,7 @@ import (
 
 	"github.com/bloom42/stdx-go/db"
 	"markdown.ninja/pkg/services/content"
+	"markdown.ninja/pkg/services/kernel"
 )
 
 func (service *ContentService) DeleteTag(ctx context.Context, input content.DeleteTagInput) (err error) {
@@ -19,7 +20,7 @@ func (service *ContentService) DeleteTag(ctx context.Context, input content.Dele
 		return
 	}
 
-	err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, tag.WebsiteID)
+	err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, tag.WebsiteID, kernel.PermissionWriteContent)
 	if err != nil {
 		return
 	}
@@ -27,11 +28,21 @@ func (service *ContentService) DeleteTag(ctx context.Context, input content.Dele
 	now := time.Now().UTC()
 
 	err = service.db.Transaction(ctx, func(tx db.Tx) (txErr error) {
+		pageIDs, txErr := service.repo.FindPageIDsForTag(ctx, tx, tag.ID)
+		if txErr != nil {
+			return txErr
+		}
+
 		txErr = service.repo.DeleteTag(ctx, tx, tag.ID)
 		if txErr != nil {
 			return txErr
 		}
 
+		txErr = service.repo.ReindexPagesForSearch(ctx, tx, pageIDs)
+		if txErr != nil {
+			return txErr
+		}
+
 		txErr = service.websitesService.UpdateWebsiteModifiedAt(ctx, tx, tag.WebsiteID, now)
 		return txErr
 	})
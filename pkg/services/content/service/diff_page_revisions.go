@@ -0,0 +1,57 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"markdown.ninja/pkg/services/content"
+	"markdown.ninja/pkg/services/kernel"
+)
+
+func (service *ContentService) DiffPageRevisions(ctx context.Context, input content.DiffPageRevisionsInput) (ret content.PageRevisionsDiff, err error) {
+	actorID, err := service.kernel.CurrentUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	from, err := service.repo.FindPageRevisionByID(ctx, service.db, input.From)
+	if err != nil {
+		return
+	}
+
+	to, err := service.repo.FindPageRevisionByID(ctx, service.db, input.To)
+	if err != nil {
+		return
+	}
+
+	if !from.PageID.Equal(to.PageID) {
+		err = content.ErrPageRevisionsAreNotForTheSamePage
+		return
+	}
+
+	page, err := service.repo.FindPageByID(ctx, service.db, from.PageID)
+	if err != nil {
+		return
+	}
+
+	err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, page.WebsiteID, kernel.PermissionRead)
+	if err != nil {
+		return
+	}
+
+	diff, err := diffPageRevisions(from, to)
+	if err != nil {
+		err = fmt.Errorf("content.DiffPageRevisions: computing diff: %w", err)
+		return
+	}
+
+	ret = content.PageRevisionsDiff{
+		From:            convertPageRevisionMetadata(from),
+		To:              convertPageRevisionMetadata(to),
+		Diff:            diff,
+		BodyChanged:     !bytes.Equal(from.BodyHash, to.BodyHash),
+		MetadataChanged: !bytes.Equal(from.MetadataHash, to.MetadataHash),
+	}
+	return
+}
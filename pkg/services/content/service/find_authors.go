@@ -0,0 +1,36 @@
+package service
+
+import (
+	"context"
+
+	"github.com/bloom42/stdx-go/db"
+	"github.com/bloom42/stdx-go/guid"
+	"markdown.ninja/pkg/services/content"
+)
+
+func (service *ContentService) FindAuthors(ctx context.Context, db db.Queryer, websiteID guid.GUID) (authors []content.Author, err error) {
+	return service.repo.FindAuthorsForWebsite(ctx, db, websiteID)
+}
+
+func (service *ContentService) FindAuthor(ctx context.Context, db db.Queryer, websiteID guid.GUID, slug string) (author content.Author, err error) {
+	return service.repo.FindAuthorBySlug(ctx, db, websiteID, slug)
+}
+
+func (service *ContentService) FindAuthorsForPage(ctx context.Context, db db.Queryer, pageID guid.GUID) (authors []content.Author, err error) {
+	return service.repo.FindAuthorsForPage(ctx, db, pageID)
+}
+
+// FindAuthorsForPages returns the authors of each of the given pages, indexed by page ID
+func (service *ContentService) FindAuthorsForPages(ctx context.Context, db db.Queryer, pageIDs []guid.GUID) (authors map[guid.GUID][]content.Author, err error) {
+	pagesAuthors, err := service.repo.FindAuthorsForPages(ctx, db, pageIDs)
+	if err != nil {
+		return
+	}
+
+	authors = make(map[guid.GUID][]content.Author, len(pageIDs))
+	for _, pageAuthor := range pagesAuthors {
+		authors[pageAuthor.PageID] = append(authors[pageAuthor.PageID], pageAuthor.Author)
+	}
+
+	return
+}
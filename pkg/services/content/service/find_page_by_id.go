@@ -16,3 +16,8 @@ func (service *ContentService) FindPageByID(ctx context.Context, db db.Queryer,
 
 	return
 }
+
+// FindPagesByIDs returns the pages of the website with the given IDs, in no particular order
+func (service *ContentService) FindPagesByIDs(ctx context.Context, db db.Queryer, websiteID guid.GUID, pageIDs []guid.GUID) (pages []content.Page, err error) {
+	return service.repo.FindPagesByIDs(ctx, db, websiteID, pageIDs)
+}
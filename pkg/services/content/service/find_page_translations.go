@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+
+	"github.com/bloom42/stdx-go/db"
+	"github.com/bloom42/stdx-go/guid"
+	"markdown.ninja/pkg/services/content"
+)
+
+// FindPageTranslations returns the published versions of the page in other languages
+func (service *ContentService) FindPageTranslations(ctx context.Context, db db.Queryer, page content.Page) (translations []content.PageMetadata, err error) {
+	translations = []content.PageMetadata{}
+	if page.TranslationKey == nil {
+		return
+	}
+
+	pages, err := service.repo.FindPagesMetadataByTranslationKey(ctx, db, page.WebsiteID, *page.TranslationKey)
+	if err != nil {
+		return
+	}
+
+	for _, translation := range pages {
+		if !translation.ID.Equal(page.ID) && translation.Status == content.PageStatusPublished {
+			translations = append(translations, translation)
+		}
+	}
+
+	return
+}
+
+// checkPageTranslationIsAvailable checks that no other page of the website has the same translation key
+// in the same language
+func (service *ContentService) checkPageTranslationIsAvailable(ctx context.Context, db db.Queryer, websiteID, pageID guid.GUID,
+	translationKey *string, language string) (err error) {
+	if translationKey == nil {
+		return nil
+	}
+
+	pages, err := service.repo.FindPagesMetadataByTranslationKey(ctx, db, websiteID, *translationKey)
+	if err != nil {
+		return
+	}
+
+	for _, page := range pages {
+		if page.Language == language && !page.ID.Equal(pageID) {
+			return content.ErrPageTranslationAlreadyExists(*translationKey, language)
+		}
+	}
+
+	return nil
+}
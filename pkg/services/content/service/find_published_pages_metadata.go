@@ -32,3 +32,56 @@ func (service *ContentService) FindPublishedPagesMetadataForTag(ctx context.Cont
 
 	return
 }
+
+func (service *ContentService) FindPublishedPagesMetadataForAuthor(ctx context.Context, db db.Queryer, websiteID guid.GUID, pageTypes []content.PageType, authorSlug string) (pages []content.PageMetadata, err error) {
+	if !utf8.ValidString(authorSlug) {
+		err = content.ErrAuthorNotFound
+		return
+	}
+
+	author, err := service.repo.FindAuthorBySlug(ctx, db, websiteID, authorSlug)
+	if err != nil {
+		return
+	}
+
+	pages, err = service.repo.FindPublishedPagesMetadataForAuthor(ctx, db, pageTypes, author.ID)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+func (service *ContentService) FindRelatedPostsMetadata(ctx context.Context, db db.Queryer, websiteID, pageID guid.GUID, limit int64) (posts []content.PageMetadata, err error) {
+	posts, err = service.repo.FindRelatedPostsMetadata(ctx, db, websiteID, pageID, limit)
+	return posts, err
+}
+
+func (service *ContentService) FindPagesMetadataWithFilter(ctx context.Context, db db.Queryer, websiteID guid.GUID, filter content.PagesFilter) (pages []content.PageMetadata, err error) {
+	var tagID *guid.GUID
+
+	if len(filter.Statuses) == 0 {
+		filter.Statuses = []content.PageStatus{content.PageStatusPublished}
+	}
+
+	if filter.Tag != nil {
+		if !utf8.ValidString(*filter.Tag) {
+			err = content.ErrTagNotFound
+			return
+		}
+
+		var tag content.Tag
+		tag, err = service.repo.FindTagByName(ctx, db, websiteID, *filter.Tag)
+		if err != nil {
+			return
+		}
+		tagID = &tag.ID
+	}
+
+	pages, err = service.repo.FindPagesMetadataWithFilter(ctx, db, websiteID, filter, tagID)
+	if err != nil {
+		return
+	}
+
+	return
+}
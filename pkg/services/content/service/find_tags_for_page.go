@@ -16,3 +16,18 @@ func (service *ContentService) FindTagsForPage(ctx context.Context, db db.Querye
 
 	return
 }
+
+// FindTagsForPages returns the tags of each of the given pages, indexed by page ID
+func (service *ContentService) FindTagsForPages(ctx context.Context, db db.Queryer, pageIDs []guid.GUID) (tags map[guid.GUID][]content.Tag, err error) {
+	pagesTags, err := service.repo.FindTagsForPages(ctx, db, pageIDs)
+	if err != nil {
+		return
+	}
+
+	tags = make(map[guid.GUID][]content.Tag, len(pageIDs))
+	for _, pageTag := range pagesTags {
+		tags[pageTag.PageID] = append(tags[pageTag.PageID], pageTag.Tag)
+	}
+
+	return
+}
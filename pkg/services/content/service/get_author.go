@@ -0,0 +1,27 @@
+package service
+
+import (
+	"context"
+
+	"markdown.ninja/pkg/services/content"
+	"markdown.ninja/pkg/services/kernel"
+)
+
+func (service *ContentService) GetAuthor(ctx context.Context, input content.GetAuthorInput) (author content.Author, err error) {
+	actorID, err := service.kernel.CurrentUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	author, err = service.repo.FindAuthorByID(ctx, service.db, input.ID)
+	if err != nil {
+		return
+	}
+
+	err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, author.WebsiteID, kernel.PermissionRead)
+	if err != nil {
+		return
+	}
+
+	return
+}
@@ -0,0 +1,144 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"log/slog"
+	"path/filepath"
+	"time"
+
+	"github.com/bloom42/stdx-go/crypto/blake3"
+	"github.com/bloom42/stdx-go/log/slogx"
+	"markdown.ninja/pkg/images"
+	"markdown.ninja/pkg/services/content"
+	"markdown.ninja/pkg/storage"
+)
+
+// imageVariantGenerationTimeout is the maximum duration of the generation of an image variant,
+// including the time spent waiting for a transform slot
+const imageVariantGenerationTimeout = 2 * time.Minute
+
+// GetImageVariant returns the image asset resized and converted according to options.
+// Variants are generated on the first request and are then stored alongside the original asset.
+func (service *ContentService) GetImageVariant(ctx context.Context, asset content.Asset, options images.TransformOptions) (data []byte, format images.Format, err error) {
+	logger := slogx.FromCtx(ctx)
+
+	sourceFormat, isTransformable := images.SourceFormat(asset.MediaType)
+	if asset.Type != content.AssetTypeImage || !isTransformable {
+		err = content.ErrAssetIsNotATransformableImage
+		return
+	}
+	if asset.Size > images.MaxSourceSize {
+		err = images.ErrImageIsTooLarge
+		return
+	}
+
+	format, err = images.ResolveFormat(sourceFormat, options.Format)
+	if err != nil {
+		return
+	}
+	options.Format = format
+
+	storageKey := service.getImageVariantStorageKey(asset, options)
+	variant, err := service.storage.GetObject(ctx, storageKey, nil)
+	if err == nil {
+		defer variant.Close()
+		data, err = io.ReadAll(variant)
+		if err != nil {
+			err = fmt.Errorf("content.GetImageVariant: reading variant from storage: %w", err)
+			return
+		}
+		return data, format, nil
+	}
+	// the variant doesn't exist yet
+	err = nil
+
+	// avoid generating the same variant multiple times when it is requested concurrently.
+	// The variant is shared by all the callers so its generation must not be canceled when the request of
+	// the first caller is, and each caller stops waiting when its own request is canceled.
+	singleflightRes := service.imageVariantsSingleflightGroup.DoChan(storageKey, func() (any, error) {
+		generateCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), imageVariantGenerationTimeout)
+		defer cancel()
+		return service.generateImageVariant(generateCtx, asset, options, storageKey)
+	})
+
+	select {
+	case <-ctx.Done():
+		err = ctx.Err()
+		return
+	case res := <-singleflightRes:
+		if res.Err != nil {
+			err = res.Err
+			logger.Warn("content.GetImageVariant: generating image variant", slogx.Err(err),
+				slog.String("asset.id", asset.ID.String()), slog.String("options", options.String()))
+			return
+		}
+		return res.Val.([]byte), format, nil
+	}
+}
+
+func (service *ContentService) generateImageVariant(ctx context.Context, asset content.Asset, options images.TransformOptions,
+	storageKey string) (data []byte, err error) {
+	// transforming images is CPU and memory intensive so we limit the number of concurrent transforms
+	err = service.imageTransformsSemaphore.Acquire(ctx, 1)
+	if err != nil {
+		return
+	}
+	defer service.imageTransformsSemaphore.Release(1)
+
+	assetData, err := service.GetAssetData(ctx, asset, nil)
+	if err != nil {
+		return
+	}
+	defer assetData.Close()
+
+	sourceData, err := io.ReadAll(io.LimitReader(assetData, images.MaxSourceSize+1))
+	if err != nil {
+		err = fmt.Errorf("content.generateImageVariant: reading asset data: %w", err)
+		return
+	}
+
+	data, err = images.Transform(sourceData, options)
+	if err != nil {
+		return
+	}
+
+	dataSha256 := sha256.Sum256(data)
+	putObjectOptions := &storage.PutObjectOptions{
+		HashSha256: dataSha256[:],
+	}
+	err = service.storage.PutObject(ctx, storageKey, int64(len(data)), bytes.NewReader(data), putObjectOptions)
+	if err != nil {
+		err = fmt.Errorf("content.generateImageVariant: uploading variant to storage: %w", err)
+		return
+	}
+
+	return data, nil
+}
+
+// getImageVariantsStoragePrefix returns the prefix under which all the variants of an image are stored
+func (service *ContentService) getImageVariantsStoragePrefix(asset content.Asset) (prefix string) {
+	assetIDStr := asset.ID.String()
+	assetIDFirstChars := assetIDStr[:4]
+
+	prefix = filepath.Join(service.getStoragePrefixForWebsite(asset.WebsiteID), "image_variants", assetIDFirstChars, assetIDStr)
+	return
+}
+
+func (service *ContentService) getImageVariantStorageKey(asset content.Asset, options images.TransformOptions) (storageKey string) {
+	var hash [32]byte
+
+	// variants are identified by the hash of the asset's data, so they are regenerated when the asset is replaced
+	hasher := blake3.New(32, nil)
+	hasher.Write(asset.Hash)
+	hasher.Write([]byte(options.String()))
+	hasher.Sum(hash[:0])
+
+	variantID := base64.RawURLEncoding.EncodeToString(hash[:])
+	storageKey = filepath.Join(service.getImageVariantsStoragePrefix(asset), variantID+"."+string(options.Format))
+	return
+}
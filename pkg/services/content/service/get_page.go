@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"markdown.ninja/pkg/services/content"
+	"markdown.ninja/pkg/services/kernel"
 )
 
 func (service *ContentService) GetPage(ctx context.Context, input content.GetPageInput) (page content.Page, err error) {
@@ -17,7 +18,7 @@ func (service *ContentService) GetPage(ctx context.Context, input content.GetPag
 		return
 	}
 
-	err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, page.WebsiteID)
+	err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, page.WebsiteID, kernel.PermissionRead)
 	if err != nil {
 		return
 	}
@@ -27,5 +28,10 @@ func (service *ContentService) GetPage(ctx context.Context, input content.GetPag
 		return
 	}
 
+	page.Authors, err = service.repo.FindAuthorsForPage(ctx, service.db, input.ID)
+	if err != nil {
+		return
+	}
+
 	return
 }
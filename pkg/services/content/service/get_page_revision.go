@@ -0,0 +1,32 @@
+package service
+
+import (
+	"context"
+
+	"markdown.ninja/pkg/services/content"
+	"markdown.ninja/pkg/services/kernel"
+)
+
+func (service *ContentService) GetPageRevision(ctx context.Context, input content.GetPageRevisionInput) (revision content.PageRevision, err error) {
+	actorID, err := service.kernel.CurrentUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	revision, err = service.repo.FindPageRevisionByID(ctx, service.db, input.ID)
+	if err != nil {
+		return
+	}
+
+	page, err := service.repo.FindPageByID(ctx, service.db, revision.PageID)
+	if err != nil {
+		return
+	}
+
+	err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, page.WebsiteID, kernel.PermissionRead)
+	if err != nil {
+		return
+	}
+
+	return
+}
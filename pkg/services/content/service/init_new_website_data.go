@@ -7,6 +7,7 @@ import (
 	"github.com/bloom42/stdx-go/crypto/blake3"
 	"github.com/bloom42/stdx-go/db"
 	"github.com/bloom42/stdx-go/guid"
+	"markdown.ninja/pkg/markdown"
 	"markdown.ninja/pkg/services/content"
 	"markdown.ninja/pkg/services/websites"
 )
@@ -35,7 +36,7 @@ func (service *ContentService) InitNewWebsiteData(ctx context.Context, tx db.Que
 		BodyMarkdown: bodyMarkdown,
 		WebsiteID:    website.ID,
 	}
-	metadataHash := content.HashPageMetadata(homePage.Type, homePage.Path, homePage.Date, homePage.SendAsNewsletter, homePage.Language, homePage.Title, homePage.Description, []string{})
+	metadataHash := content.HashPageMetadata(homePage.Type, homePage.Path, homePage.Date, homePage.SendAsNewsletter, homePage.PaidOnly, homePage.Language, homePage.Title, homePage.Description, []string{}, []string{}, nil)
 	homePage.MetadataHash = metadataHash[:]
 
 	err = service.repo.CreatePage(ctx, tx, homePage)
@@ -43,6 +44,27 @@ func (service *ContentService) InitNewWebsiteData(ctx context.Context, tx db.Que
 		return
 	}
 
+	websiteUrl := service.httpConfig.WebsitesBaseUrl.Scheme + "://" + website.PrimaryDomain + service.httpConfig.WebsitesPort
+	bodyHtml, err := markdown.ToHtmlPage(bodyMarkdown, websiteUrl, nil)
+	if err != nil {
+		return
+	}
+	searchText, err := service.getPageSearchText(homePage, bodyHtml, websiteUrl)
+	if err != nil {
+		return
+	}
+
+	err = service.repo.IndexPageForSearch(ctx, tx, homePage.ID, content.SearchLanguageConfig(homePage.Language), searchText)
+	if err != nil {
+		return
+	}
+
+	// this is the first revision of the page so there is nothing to prune
+	err = service.savePageRevision(ctx, tx, homePage, []string{}, []string{}, 1)
+	if err != nil {
+		return
+	}
+
 	// create /assets folder
 	assetsFolder := content.Asset{
 		ID:        guid.NewTimeBased(),
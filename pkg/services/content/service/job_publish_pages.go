@@ -36,16 +36,20 @@ func (service *ContentService) JobPublishPages(ctx context.Context, input conten
 		}
 
 		postsToSendAsNewsletter = make([]postToSendAsNewsletter, 0, len(pagesToPublish))
+		websitesByID := make(map[guid.GUID]websites.Website)
 
 		for _, page := range pagesToPublish {
-			if page.SendAsNewsletter && page.NewsletterSentAt == nil {
-				var website websites.Website
+			website, websiteLoaded := websitesByID[page.WebsiteID]
+			if !websiteLoaded {
 				website, txErr = service.websitesService.FindWebsiteByID(ctx, tx, page.WebsiteID)
 				if txErr != nil {
-					txErr = fmt.Errorf("creating newsletter from page (%s): %w", page.ID.String(), txErr)
+					txErr = fmt.Errorf("finding website for page (%s): %w", page.ID.String(), txErr)
 					return txErr
 				}
+				websitesByID[page.WebsiteID] = website
+			}
 
+			if page.SendAsNewsletter && page.NewsletterSentAt == nil {
 				postToSend := postToSendAsNewsletter{
 					postID:        page.ID,
 					websiteDomain: website.PrimaryDomain,
@@ -60,6 +64,21 @@ func (service *ContentService) JobPublishPages(ctx context.Context, input conten
 				txErr = fmt.Errorf("updating page (%s): %w", page.ID.String(), txErr)
 				return txErr
 			}
+
+			txErr = service.dispatchPagePublishedEvent(ctx, tx, website, page)
+			if txErr != nil {
+				txErr = fmt.Errorf("dispatching page.published event for page (%s): %w", page.ID.String(), txErr)
+				return txErr
+			}
+		}
+
+		// invalidate the caches of the websites that depend on website.ModifiedAt
+		for websiteID := range websitesByID {
+			txErr = service.websitesService.UpdateWebsiteModifiedAt(ctx, tx, websiteID, now)
+			if txErr != nil {
+				txErr = fmt.Errorf("updating website's modified_at (%s): %w", websiteID.String(), txErr)
+				return txErr
+			}
 		}
 
 		return nil
@@ -9,13 +9,14 @@ import (
 	"markdown.ninja/pkg/server/httpctx"
 	"markdown.ninja/pkg/services/content"
 	"markdown.ninja/pkg/services/kernel"
+	"markdown.ninja/pkg/services/organizations"
 	"markdown.ninja/pkg/services/websites"
 )
 
 func (service *ContentService) ListAssets(ctx context.Context, input content.ListAssetsInput) (assets []content.Asset, err error) {
 	actorID, err := service.kernel.CurrentUserID(ctx)
 	if err == nil {
-		err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, input.WebsiteID)
+		err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, input.WebsiteID, kernel.PermissionRead)
 		if err != nil {
 			return
 		}
@@ -32,7 +33,7 @@ func (service *ContentService) ListAssets(ctx context.Context, input content.Lis
 			return
 		}
 
-		_, err = service.organizationsService.CheckCurrentApiKey(ctx, website.OrganizationID)
+		_, err = service.organizationsService.CheckCurrentApiKey(ctx, website.OrganizationID, website.ID, organizations.ApiKeyScopeAssetsRead)
 		if err != nil {
 			return
 		}
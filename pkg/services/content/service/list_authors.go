@@ -0,0 +1,46 @@
+package service
+
+import (
+	"context"
+
+	"markdown.ninja/pkg/server/httpctx"
+	"markdown.ninja/pkg/services/content"
+	"markdown.ninja/pkg/services/kernel"
+	"markdown.ninja/pkg/services/organizations"
+	"markdown.ninja/pkg/services/websites"
+)
+
+func (service *ContentService) ListAuthors(ctx context.Context, input content.ListAuthorsInput) (ret kernel.PaginatedResult[content.Author], err error) {
+	actorID, err := service.kernel.CurrentUserID(ctx)
+	if err == nil {
+		err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, input.WebsiteID, kernel.PermissionRead)
+		if err != nil {
+			return
+		}
+
+	} else {
+		var website websites.Website
+		httpCtx := httpctx.FromCtx(ctx)
+		if httpCtx.ApiKey == nil {
+			err = kernel.ErrPermissionDenied
+			return
+		}
+
+		website, err = service.websitesService.FindWebsiteByID(ctx, service.db, input.WebsiteID)
+		if err != nil {
+			return
+		}
+
+		_, err = service.organizationsService.CheckCurrentApiKey(ctx, website.OrganizationID, website.ID, organizations.ApiKeyScopeContentRead)
+		if err != nil {
+			return
+		}
+	}
+
+	ret.Data, err = service.repo.FindAuthorsForWebsite(ctx, service.db, input.WebsiteID)
+	if err != nil {
+		return
+	}
+
+	return
+}
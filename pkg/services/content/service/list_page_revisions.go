@@ -0,0 +1,42 @@
+package service
+
+import (
+	"context"
+
+	"markdown.ninja/pkg/errs"
+	"markdown.ninja/pkg/services/content"
+	"markdown.ninja/pkg/services/kernel"
+)
+
+func (service *ContentService) ListPageRevisions(ctx context.Context, input content.ListPageRevisionsInput) (ret kernel.PaginatedResult[content.PageRevisionMetadata], err error) {
+	actorID, err := service.kernel.CurrentUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	page, err := service.repo.FindPageByID(ctx, service.db, input.PageID)
+	if err != nil {
+		return
+	}
+
+	err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, page.WebsiteID, kernel.PermissionRead)
+	if err != nil {
+		return
+	}
+
+	limit := input.Limit
+	if limit < 0 {
+		return ret, errs.InvalidArgument("limit is not valid")
+	} else if limit > 1000 {
+		return ret, errs.InvalidArgument("limit is too high. max: 1000")
+	} else if limit == 0 {
+		limit = 100 // default value
+	}
+
+	ret.Data, err = service.repo.FindPageRevisionsMetadataForPage(ctx, service.db, page.ID, limit, input.After)
+	if err != nil {
+		return
+	}
+
+	return
+}
@@ -6,13 +6,14 @@ import (
 	"markdown.ninja/pkg/server/httpctx"
 	"markdown.ninja/pkg/services/content"
 	"markdown.ninja/pkg/services/kernel"
+	"markdown.ninja/pkg/services/organizations"
 	"markdown.ninja/pkg/services/websites"
 )
 
 func (service *ContentService) ListPages(ctx context.Context, input content.ListPagesInput) (ret kernel.PaginatedResult[content.PageMetadata], err error) {
 	actorID, err := service.kernel.CurrentUserID(ctx)
 	if err == nil {
-		err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, input.WebsiteID)
+		err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, input.WebsiteID, kernel.PermissionRead)
 		if err != nil {
 			return
 		}
@@ -30,7 +31,7 @@ func (service *ContentService) ListPages(ctx context.Context, input content.List
 			return
 		}
 
-		_, err = service.organizationsService.CheckCurrentApiKey(ctx, website.OrganizationID)
+		_, err = service.organizationsService.CheckCurrentApiKey(ctx, website.OrganizationID, website.ID, organizations.ApiKeyScopeContentRead)
 		if err != nil {
 			return
 		}
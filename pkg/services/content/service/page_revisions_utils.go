@@ -0,0 +1,130 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bloom42/stdx-go/db"
+	"github.com/bloom42/stdx-go/difflib"
+	"github.com/bloom42/stdx-go/guid"
+	"markdown.ninja/pkg/errs"
+	"markdown.ninja/pkg/services/content"
+)
+
+// savePageRevision saves the current state of page as a new revision, unless it's identical to the
+// last revision, and deletes the revisions over revisionsToKeep.
+func (service *ContentService) savePageRevision(ctx context.Context, tx db.Queryer, page content.Page, tags []string, authors []string, revisionsToKeep int64) (err error) {
+	lastRevision, err := service.repo.FindLastPageRevision(ctx, tx, page.ID)
+	if err == nil {
+		if bytes.Equal(lastRevision.BodyHash, page.BodyHash) && bytes.Equal(lastRevision.MetadataHash, page.MetadataHash) {
+			return nil
+		}
+	} else if errs.IsNotFound(err) {
+		err = nil
+	} else {
+		return err
+	}
+
+	if tags == nil {
+		tags = []string{}
+	}
+	if authors == nil {
+		authors = []string{}
+	}
+
+	revision := content.PageRevision{
+		ID:           guid.NewTimeBased(),
+		CreatedAt:    time.Now().UTC(),
+		Date:         page.Date,
+		Title:        page.Title,
+		Path:         page.Path,
+		Description:  page.Description,
+		Language:     page.Language,
+		Tags:         tags,
+		Authors:      authors,
+		BodyMarkdown: page.BodyMarkdown,
+		Size:         page.Size,
+		BodyHash:     page.BodyHash,
+		MetadataHash: page.MetadataHash,
+		UserID:       nil,
+		PageID:       page.ID,
+	}
+	// revisions created with an API key don't have an user
+	if userID, userErr := service.kernel.CurrentUserID(ctx); userErr == nil {
+		revision.UserID = &userID
+	}
+
+	err = service.repo.CreatePageRevision(ctx, tx, revision)
+	if err != nil {
+		return err
+	}
+
+	err = service.repo.DeleteOldPageRevisions(ctx, tx, page.ID, max(revisionsToKeep, 1))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// getRevisionsPerPage returns the number of revisions to keep for each page of an organization
+func (service *ContentService) getRevisionsPerPage(ctx context.Context, db db.Queryer, organizationID guid.GUID) (int64, error) {
+	plan, err := service.organizationsService.FindPlanForOrganization(ctx, db, organizationID)
+	if err != nil {
+		return 0, err
+	}
+
+	return plan.RevisionsPerPage, nil
+}
+
+// pageRevisionToText returns the text representation of a revision that is used to diff revisions.
+// Metadata are formatted as a frontmatter, like the files published with mdninja.
+func pageRevisionToText(revision content.PageRevision) string {
+	var text strings.Builder
+
+	text.WriteString("---\n")
+	fmt.Fprintf(&text, "title: %s\n", revision.Title)
+	fmt.Fprintf(&text, "path: %s\n", revision.Path)
+	fmt.Fprintf(&text, "date: %s\n", revision.Date.UTC().Format(time.RFC3339))
+	fmt.Fprintf(&text, "language: %s\n", revision.Language)
+	fmt.Fprintf(&text, "description: %s\n", revision.Description)
+	fmt.Fprintf(&text, "tags: [%s]\n", strings.Join(revision.Tags, ", "))
+	if len(revision.Authors) != 0 {
+		fmt.Fprintf(&text, "authors: [%s]\n", strings.Join(revision.Authors, ", "))
+	}
+	text.WriteString("---\n")
+	text.WriteString(revision.BodyMarkdown)
+
+	// difflib.SplitLines adds the last newline
+	return strings.TrimSuffix(text.String(), "\n")
+}
+
+func diffPageRevisions(from, to content.PageRevision) (string, error) {
+	diff := difflib.UnifiedDiff{
+		A:        difflib.SplitLines(pageRevisionToText(from)),
+		B:        difflib.SplitLines(pageRevisionToText(to)),
+		FromFile: from.ID.String(),
+		FromDate: from.CreatedAt.UTC().Format(time.RFC3339),
+		ToFile:   to.ID.String(),
+		ToDate:   to.CreatedAt.UTC().Format(time.RFC3339),
+		Context:  3,
+	}
+
+	return difflib.GetUnifiedDiffString(diff)
+}
+
+func convertPageRevisionMetadata(revision content.PageRevision) content.PageRevisionMetadata {
+	return content.PageRevisionMetadata{
+		ID:           revision.ID,
+		CreatedAt:    revision.CreatedAt,
+		Title:        revision.Title,
+		Path:         revision.Path,
+		Size:         revision.Size,
+		BodyHash:     revision.BodyHash,
+		MetadataHash: revision.MetadataHash,
+		UserID:       revision.UserID,
+	}
+}
@@ -0,0 +1,55 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bloom42/stdx-go/guid"
+	"markdown.ninja/pkg/services/content"
+)
+
+func TestDiffPageRevisions(t *testing.T) {
+	date := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	from := content.PageRevision{
+		ID:           guid.NewTimeBased(),
+		Date:         date,
+		Title:        "Hello",
+		Path:         "/hello",
+		Language:     "en",
+		Tags:         content.PageRevisionStrings{"go"},
+		BodyMarkdown: "first line\nsecond line\n",
+	}
+	to := from
+	to.ID = guid.NewTimeBased()
+	to.Title = "Hello World"
+	to.BodyMarkdown = "first line\nsecond line updated\n"
+
+	diff, err := diffPageRevisions(from, to)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedLines := []string{
+		"-title: Hello\n",
+		"+title: Hello World\n",
+		"-second line\n",
+		"+second line updated\n",
+	}
+	for _, line := range expectedLines {
+		if !strings.Contains(diff, line) {
+			t.Errorf("diff should contain %q. Got:\n%s", line, diff)
+		}
+	}
+	if strings.Contains(diff, "-first line") || strings.Contains(diff, "+first line") {
+		t.Errorf("unchanged lines should not be in the diff. Got:\n%s", diff)
+	}
+
+	diff, err = diffPageRevisions(from, from)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff != "" {
+		t.Errorf("diff of identical revisions should be empty. Got:\n%s", diff)
+	}
+}
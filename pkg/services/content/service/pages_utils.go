@@ -2,12 +2,17 @@ package service
 
 import (
 	"context"
+	"html"
 	"strings"
 	"time"
 
 	"github.com/bloom42/stdx-go/db"
 	"github.com/bloom42/stdx-go/guid"
+	"markdown.ninja/pkg/markdown"
 	"markdown.ninja/pkg/services/content"
+	"markdown.ninja/pkg/services/content/repository"
+	"markdown.ninja/pkg/services/webhooks"
+	"markdown.ninja/pkg/services/websites"
 )
 
 func (service *ContentService) getDescriptionFromContentHtml(ctx context.Context, db db.DB, websiteID guid.GUID, contentHtml string) (description string, err error) {
@@ -35,6 +40,50 @@ func (service *ContentService) getDescriptionFromContentHtml(ctx context.Context
 	return
 }
 
+// getSearchTextFromContentHtml returns the plain text of a page used for full-text search.
+// Snippets are removed as their content is not part of the page.
+func (service *ContentService) getSearchTextFromContentHtml(contentHtml string) string {
+	contentHtml = service.snippetsRegexp.ReplaceAllString(contentHtml, " ")
+	text := html.UnescapeString(service.htmlStripper.Sanitize(contentHtml))
+	// remove the characters used to delimit highlights so they can't be injected by the content
+	text = strings.NewReplacer(repository.SearchHighlightStart, "", repository.SearchHighlightStop, "").Replace(text)
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// getPageSearchText returns the plain text of the body of a page that is indexed for full-text search.
+// Search results are public, so only the teaser of paid-only pages is indexed. Otherwise their content
+// could be guessed by searching for words that only appear after the paywall.
+func (service *ContentService) getPageSearchText(page content.Page, bodyHtml string, websiteUrl string) (text string, err error) {
+	if page.PaidOnly {
+		bodyHtml, err = markdown.ToHtmlPage(content.PaidPageTeaser(page.BodyMarkdown), websiteUrl, nil)
+		if err != nil {
+			return
+		}
+	}
+
+	text = service.getSearchTextFromContentHtml(bodyHtml)
+	return
+}
+
+// cleanPageTranslationKey trims and validates the translation key of a page. Empty keys are converted to nil.
+func (service *ContentService) cleanPageTranslationKey(translationKey *string) (*string, error) {
+	if translationKey == nil {
+		return nil, nil
+	}
+
+	key := strings.ToLower(strings.TrimSpace(*translationKey))
+	if key == "" {
+		return nil, nil
+	}
+
+	err := service.validatePageTranslationKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &key, nil
+}
+
 func (service *ContentService) convertPageMetadata(input content.Page) (output content.PageMetadata) {
 	return content.PageMetadata{
 		ID:               input.ID,
@@ -50,6 +99,7 @@ func (service *ContentService) convertPageMetadata(input content.Page) (output c
 		Status:           input.Status,
 		SendAsNewsletter: input.SendAsNewsletter,
 		NewsletterSentAt: input.NewsletterSentAt,
+		PaidOnly:         input.PaidOnly,
 	}
 }
 
@@ -65,3 +115,29 @@ func (service *ContentService) convertPageMetadataMany(input []content.Page) (ou
 	}
 	return
 }
+
+// pageToAuditLogState returns the state of the page recorded in the audit log.
+// The markdown body is not included as its history is already tracked by the page revisions.
+func pageToAuditLogState(page content.Page) content.Page {
+	page.BodyMarkdown = ""
+	return page
+}
+
+// dispatchPagePublishedEvent sends the page.published event to the webhook endpoints of the website.
+// db should be the transaction that publishes the page.
+func (service *ContentService) dispatchPagePublishedEvent(ctx context.Context, db db.Queryer, website websites.Website, page content.Page) error {
+	websiteBaseUrl := service.httpConfig.WebsitesBaseUrl.Scheme + "://" + website.PrimaryDomain + service.httpConfig.WebsitesPort
+
+	return service.webhooksService.DispatchEvent(ctx, db, webhooks.DispatchEventInput{
+		WebsiteID: page.WebsiteID,
+		Type:      webhooks.EventTypePagePublished,
+		Data: webhooks.EventDataPage{
+			ID:    page.ID,
+			Type:  string(page.Type),
+			Path:  page.Path,
+			Url:   websiteBaseUrl + page.Path,
+			Title: page.Title,
+			Date:  page.Date,
+		},
+	})
+}
@@ -0,0 +1,41 @@
+package service
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/microcosm-cc/bluemonday"
+	"markdown.ninja/pkg/services/content"
+)
+
+func TestGetPageSearchText(t *testing.T) {
+	service := &ContentService{
+		snippetsRegexp: regexp.MustCompile("{{<.*>}}"),
+		htmlStripper:   bluemonday.StrictPolicy(),
+	}
+	websiteUrl := "https://example.com"
+	bodyMarkdown := "Free **introduction**\n\n{{< newsletter >}}\n\n" + content.PaywallMarker + "\n\nSecret premium content"
+
+	page := content.Page{BodyMarkdown: bodyMarkdown}
+	bodyHtml := "<p>Free <strong>introduction</strong></p>\n<p>{{< newsletter >}}</p>\n<p>Secret premium content</p>"
+	text, err := service.getPageSearchText(page, bodyHtml, websiteUrl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if text != "Free introduction Secret premium content" {
+		t.Errorf("unexpected search text for free page: %q", text)
+	}
+
+	page.PaidOnly = true
+	text, err = service.getPageSearchText(page, bodyHtml, websiteUrl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if text != "Free introduction" {
+		t.Errorf("unexpected search text for paid-only page: %q", text)
+	}
+	if strings.Contains(text, "Secret") {
+		t.Errorf("the content after the paywall of a paid-only page is indexed: %q", text)
+	}
+}
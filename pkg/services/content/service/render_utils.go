@@ -1,17 +1,26 @@
 package service
 
 import (
+	"context"
+	"path/filepath"
 	"strings"
 
+	"markdown.ninja/pkg/images"
 	"markdown.ninja/pkg/markdown"
 	"markdown.ninja/pkg/services/content"
 	"markdown.ninja/pkg/services/websites"
 )
 
-func (service *ContentService) RenderMarkdown(website websites.Website, markdownInput string, snippets []content.Snippet, isEmail bool) (html string) {
+func (service *ContentService) RenderMarkdown(ctx context.Context, website websites.Website, markdownInput string, snippets []content.Snippet, isEmail bool) (html string) {
+	var imageResolver markdown.ImageResolver
+	if !isEmail {
+		imageResolver = service.newMarkdownImageResolver(ctx, website)
+	}
+
 	html, err := markdown.ToHtmlPage(
 		markdownInput,
 		service.httpConfig.WebsitesBaseUrl.Scheme+"://"+website.PrimaryDomain+service.httpConfig.WebsitesPort,
+		imageResolver,
 	)
 	if err != nil {
 		html = `<!-- Error: Markdown is not valid -->`
@@ -59,3 +68,22 @@ func (service *ContentService) snippetsToMap(snippets []content.Snippet) map[str
 	}
 	return snippetsMap
 }
+
+// newMarkdownImageResolver returns a resolver that looks up the dimensions of the images of the website
+// so they can be rendered responsively
+func (service *ContentService) newMarkdownImageResolver(ctx context.Context, website websites.Website) markdown.ImageResolver {
+	return func(path string) (image markdown.Image, found bool) {
+		asset, err := service.repo.FindAssetByPath(ctx, service.db, website.ID, filepath.Dir(path), filepath.Base(path))
+		if err != nil || asset.Type != content.AssetTypeImage || asset.Width == nil || asset.Height == nil {
+			return image, false
+		}
+
+		sourceFormat, isTransformable := images.SourceFormat(asset.MediaType)
+		image = markdown.Image{
+			Width:     *asset.Width,
+			Height:    *asset.Height,
+			Resizable: isTransformable && images.CanEncode(sourceFormat) && asset.Size <= images.MaxSourceSize,
+		}
+		return image, true
+	}
+}
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"bytes"
+	"context"
+
+	"markdown.ninja/pkg/server/httpctx"
+	"markdown.ninja/pkg/services/content"
+	"markdown.ninja/pkg/services/kernel"
+	"markdown.ninja/pkg/services/organizations"
+	"markdown.ninja/pkg/services/websites"
+)
+
+// RestorePageRevision updates a page with the content of one of its revisions. The status of the
+// page is kept (a draft stays a draft) and the restore itself creates a new revision, so it can be
+// reverted.
+func (service *ContentService) RestorePageRevision(ctx context.Context, input content.RestorePageRevisionInput) (page content.Page, err error) {
+	revision, err := service.repo.FindPageRevisionByID(ctx, service.db, input.ID)
+	if err != nil {
+		return
+	}
+
+	page, err = service.repo.FindPageByID(ctx, service.db, revision.PageID)
+	if err != nil {
+		return
+	}
+
+	actorID, err := service.kernel.CurrentUserID(ctx)
+	if err == nil {
+		err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, page.WebsiteID, kernel.PermissionWriteContent)
+		if err != nil {
+			return
+		}
+	} else {
+		var website websites.Website
+		httpCtx := httpctx.FromCtx(ctx)
+		if httpCtx.ApiKey == nil {
+			err = kernel.ErrPermissionDenied
+			return
+		}
+
+		website, err = service.websitesService.FindWebsiteByID(ctx, service.db, page.WebsiteID)
+		if err != nil {
+			return
+		}
+
+		_, err = service.organizationsService.CheckCurrentApiKey(ctx, website.OrganizationID, website.ID, organizations.ApiKeyScopeContentWrite)
+		if err != nil {
+			return
+		}
+	}
+
+	if bytes.Equal(revision.BodyHash, page.BodyHash) && bytes.Equal(revision.MetadataHash, page.MetadataHash) {
+		err = content.ErrPageRevisionIsTheCurrentVersion
+		return
+	}
+
+	// authors may have been deleted since the revision was saved
+	siteAuthors, err := service.repo.FindAuthorsForWebsite(ctx, service.db, page.WebsiteID)
+	if err != nil {
+		return
+	}
+	siteAuthorsSlugs := make(map[string]bool, len(siteAuthors))
+	for _, author := range siteAuthors {
+		siteAuthorsSlugs[author.Slug] = true
+	}
+	authors := make([]string, 0, len(revision.Authors))
+	for _, slug := range revision.Authors {
+		if siteAuthorsSlugs[slug] {
+			authors = append(authors, slug)
+		}
+	}
+
+	updatePageInput := content.UpdatePageInput{
+		PageID:           page.ID,
+		Date:             revision.Date,
+		UpdatedAt:        nil,
+		Title:            revision.Title,
+		Path:             revision.Path,
+		Draft:            page.Status == content.PageStatusDraft,
+		Description:      &revision.Description,
+		Language:         revision.Language,
+		Tags:             revision.Tags,
+		Authors:          authors,
+		BodyMarkdown:     &revision.BodyMarkdown,
+		SendAsNewsletter: page.SendAsNewsletter,
+		PaidOnly:         page.PaidOnly,
+		TranslationKey:   page.TranslationKey,
+	}
+
+	return service.UpdatePage(ctx, updatePageInput)
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+	"html"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/bloom42/stdx-go/db"
+	"markdown.ninja/pkg/services/content"
+	"markdown.ninja/pkg/services/content/repository"
+)
+
+var searchHighlightReplacer = strings.NewReplacer(
+	repository.SearchHighlightStart, "<mark>",
+	repository.SearchHighlightStop, "</mark>",
+)
+
+// SearchPages performs a full-text search on the published pages of a website.
+// An empty query returns no results.
+func (service *ContentService) SearchPages(ctx context.Context, db db.Queryer, input content.SearchPagesInput) (results []content.PageSearchResult, err error) {
+	if !utf8.ValidString(input.Query) {
+		err = content.ErrSearchQueryIsNotValid
+		return
+	}
+
+	input.Query = strings.TrimSpace(input.Query)
+	if input.Query == "" {
+		results = []content.PageSearchResult{}
+		return
+	}
+
+	if len(input.Query) > content.SearchQueryMaxSize {
+		err = content.ErrSearchQueryIsTooLong
+		return
+	}
+
+	if strings.ContainsAny(input.Query, repository.SearchHighlightStart+repository.SearchHighlightStop) {
+		err = content.ErrSearchQueryIsNotValid
+		return
+	}
+
+	if input.Limit <= 0 {
+		input.Limit = content.SearchDefaultLimit
+	}
+	input.Limit = min(input.Limit, content.SearchMaxLimit)
+
+	if len(input.Types) == 0 {
+		input.Types = []content.PageType{content.PageTypePage, content.PageTypePost}
+	}
+
+	results, err = service.repo.SearchPublishedPages(ctx, db, input)
+	if err != nil {
+		return
+	}
+
+	for i := range results {
+		results[i].TitleHighlight = formatSearchHighlight(results[i].TitleHighlight)
+		results[i].BodyHighlight = formatSearchHighlight(results[i].BodyHighlight)
+	}
+
+	return
+}
+
+// formatSearchHighlight escapes the highlight returned by the database and wraps the matching
+// terms in <mark> tags
+func formatSearchHighlight(highlight string) string {
+	return searchHighlightReplacer.Replace(html.EscapeString(highlight))
+}
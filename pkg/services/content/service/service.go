@@ -2,13 +2,17 @@ package service
 
 import (
 	"fmt"
+	"markdown.ninja/pkg/jwt"
 	"regexp"
+	"runtime"
 	"text/template"
 
 	"github.com/bloom42/stdx-go/db"
 	"github.com/bloom42/stdx-go/queue"
 	"github.com/bloom42/stdx-go/set"
 	"github.com/microcosm-cc/bluemonday"
+	"golang.org/x/sync/semaphore"
+	"golang.org/x/sync/singleflight"
 	"markdown.ninja/cmd/mdninja-server/config"
 	"markdown.ninja/pkg/services/content"
 	"markdown.ninja/pkg/services/content/repository"
@@ -17,6 +21,7 @@ import (
 	"markdown.ninja/pkg/services/kernel"
 	"markdown.ninja/pkg/services/organizations"
 	"markdown.ninja/pkg/services/store"
+	"markdown.ninja/pkg/services/webhooks"
 	"markdown.ninja/pkg/services/websites"
 	"markdown.ninja/pkg/storage"
 )
@@ -27,11 +32,13 @@ type ContentService struct {
 	queue   queue.Queue
 	storage storage.Storage
 
+	jwtProvider          *jwt.Provider
 	kernel               kernel.PrivateService
 	websitesService      websites.Service
 	storeService         store.Service
 	emailsService        emails.Service
 	organizationsService organizations.Service
+	webhooksService      webhooks.Service
 
 	snippetNameBlocklist set.Set[string]
 	pageUrlBlocklist     set.Set[string]
@@ -40,10 +47,13 @@ type ContentService struct {
 	videoIframeTemplate  *template.Template
 	xssSanitizer         *bluemonday.Policy
 	httpConfig           config.Http
+
+	imageVariantsSingleflightGroup singleflight.Group
+	imageTransformsSemaphore       *semaphore.Weighted
 }
 
 func NewContentService(conf config.Config, db db.DB, queue queue.Queue, storage storage.Storage,
-	kernel kernel.PrivateService, organizationsService organizations.Service) (service *ContentService, err error) {
+	jwtProvider *jwt.Provider, kernel kernel.PrivateService, organizationsService organizations.Service) (service *ContentService, err error) {
 	repo := repository.NewContentRepository()
 
 	snippetNameBlocklist := set.NewFromSlice(content.SnippetNameBlocklist)
@@ -71,11 +81,13 @@ func NewContentService(conf config.Config, db db.DB, queue queue.Queue, storage
 		queue:   queue,
 		storage: storage,
 
+		jwtProvider:          jwtProvider,
 		kernel:               kernel,
 		organizationsService: organizationsService,
 		websitesService:      nil,
 		storeService:         nil,
 		emailsService:        nil,
+		webhooksService:      nil,
 
 		snippetNameBlocklist: snippetNameBlocklist,
 		pageUrlBlocklist:     pageUrlBlocklist,
@@ -84,14 +96,18 @@ func NewContentService(conf config.Config, db db.DB, queue queue.Queue, storage
 		videoIframeTemplate:  videoIframeTemplate,
 		xssSanitizer:         xssSanitizer,
 		httpConfig:           conf.HTTP,
+
+		imageVariantsSingleflightGroup: singleflight.Group{},
+		imageTransformsSemaphore:       semaphore.NewWeighted(int64(runtime.NumCPU())),
 	}
 
 	return
 }
 
 func (service *ContentService) InjectServices(websitesService websites.Service, storeService store.Service,
-	emailsService emails.Service) {
+	emailsService emails.Service, webhooksService webhooks.Service) {
 	service.websitesService = websitesService
 	service.storeService = storeService
 	service.emailsService = emailsService
+	service.webhooksService = webhooksService
 }
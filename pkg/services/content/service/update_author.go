@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/bloom42/stdx-go/db"
+	"markdown.ninja/pkg/errs"
+	"markdown.ninja/pkg/services/content"
+	"markdown.ninja/pkg/services/kernel"
+)
+
+// UpdateAuthor updates an author. As pages reference authors by their slug in their frontmatter,
+// changing the slug of an author requires to update the pages published with mdninja.
+func (service *ContentService) UpdateAuthor(ctx context.Context, input content.UpdateAuthorInput) (author content.Author, err error) {
+	actorID, err := service.kernel.CurrentUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	author, err = service.repo.FindAuthorByID(ctx, service.db, input.ID)
+	if err != nil {
+		return
+	}
+
+	err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, author.WebsiteID, kernel.PermissionWriteContent)
+	if err != nil {
+		return
+	}
+
+	slug := strings.TrimSpace(input.Slug)
+	name := strings.TrimSpace(input.Name)
+	bio := strings.TrimSpace(input.Bio)
+	socialLinks := cleanAuthorSocialLinks(input.SocialLinks)
+	now := time.Now().UTC()
+
+	err = service.validateAuthorSlug(slug)
+	if err != nil {
+		return
+	}
+
+	err = service.validateAuthorName(name)
+	if err != nil {
+		return
+	}
+
+	err = service.validateAuthorBio(bio)
+	if err != nil {
+		return
+	}
+
+	err = service.validateAuthorSocialLinks(socialLinks)
+	if err != nil {
+		return
+	}
+
+	err = service.validateAuthorAvatar(ctx, service.db, author.WebsiteID, input.AvatarID)
+	if err != nil {
+		return
+	}
+
+	if slug != author.Slug {
+		var existingAuthor content.Author
+		// check that an author with the same slug doesn't already exist
+		existingAuthor, err = service.repo.FindAuthorBySlug(ctx, service.db, author.WebsiteID, slug)
+		if err == nil && !existingAuthor.ID.Equal(author.ID) {
+			err = content.ErrAuthorAlreadyExists(slug)
+		} else if err != nil {
+			if errs.IsNotFound(err) {
+				err = nil
+			}
+		}
+		if err != nil {
+			return
+		}
+	}
+
+	author.UpdatedAt = now
+	author.Slug = slug
+	author.Name = name
+	author.Bio = bio
+	author.SocialLinks = socialLinks
+	author.AvatarID = input.AvatarID
+
+	err = service.db.Transaction(ctx, func(tx db.Tx) (txErr error) {
+		txErr = service.repo.UpdateAuthor(ctx, tx, author)
+		if txErr != nil {
+			return txErr
+		}
+
+		txErr = service.websitesService.UpdateWebsiteModifiedAt(ctx, tx, author.WebsiteID, now)
+		return txErr
+	})
+
+	return
+}
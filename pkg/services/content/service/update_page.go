@@ -16,6 +16,7 @@ import (
 	"markdown.ninja/pkg/services/content"
 	"markdown.ninja/pkg/services/emails"
 	"markdown.ninja/pkg/services/kernel"
+	"markdown.ninja/pkg/services/organizations"
 	"markdown.ninja/pkg/services/websites"
 )
 
@@ -30,7 +31,7 @@ func (service *ContentService) UpdatePage(ctx context.Context, input content.Upd
 			return
 		}
 
-		err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, page.WebsiteID)
+		err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, page.WebsiteID, kernel.PermissionWriteContent)
 		if err != nil {
 			return
 		}
@@ -56,7 +57,7 @@ func (service *ContentService) UpdatePage(ctx context.Context, input content.Upd
 			return
 		}
 
-		_, err = service.organizationsService.CheckCurrentApiKey(ctx, website.OrganizationID)
+		_, err = service.organizationsService.CheckCurrentApiKey(ctx, website.OrganizationID, website.ID, organizations.ApiKeyScopeContentWrite)
 		if err != nil {
 			return
 		}
@@ -69,6 +70,8 @@ func (service *ContentService) UpdatePage(ctx context.Context, input content.Upd
 		return
 	}
 
+	pageBefore := page
+
 	// TODO: clean and validate input
 	// TODO: validate tags
 	now := time.Now().UTC().Truncate(time.Second)
@@ -92,6 +95,20 @@ func (service *ContentService) UpdatePage(ctx context.Context, input content.Upd
 	if err != nil {
 		return
 	}
+	err = service.validatePagePathForLanguage(website, page.Path, page.Language)
+	if err != nil {
+		return
+	}
+
+	page.TranslationKey, err = service.cleanPageTranslationKey(input.TranslationKey)
+	if err != nil {
+		return
+	}
+
+	err = service.checkPageTranslationIsAvailable(ctx, service.db, website.ID, page.ID, page.TranslationKey, page.Language)
+	if err != nil {
+		return
+	}
 
 	sendNewsletter := false
 	if page.SendAsNewsletter != input.SendAsNewsletter && page.NewsletterSentAt != nil {
@@ -99,6 +116,7 @@ func (service *ContentService) UpdatePage(ctx context.Context, input content.Upd
 		return
 	}
 	page.SendAsNewsletter = input.SendAsNewsletter
+	page.PaidOnly = input.PaidOnly
 	if page.SendAsNewsletter {
 		emailsConfig, err := service.emailsService.FindWebsiteConfiguration(ctx, service.db, page.WebsiteID)
 		if err != nil {
@@ -134,10 +152,8 @@ func (service *ContentService) UpdatePage(ctx context.Context, input content.Upd
 		bodyHash := blake3.Sum256([]byte(page.BodyMarkdown))
 		page.BodyHash = bodyHash[:]
 	}
-	bodyHtml, err := markdown.ToHtmlPage(
-		page.BodyMarkdown,
-		service.httpConfig.WebsitesBaseUrl.Scheme+"://"+website.PrimaryDomain+service.httpConfig.WebsitesPort,
-	)
+	websiteUrl := service.httpConfig.WebsitesBaseUrl.Scheme + "://" + website.PrimaryDomain + service.httpConfig.WebsitesPort
+	bodyHtml, err := markdown.ToHtmlPage(page.BodyMarkdown, websiteUrl, nil)
 	if err != nil {
 		return
 	}
@@ -194,15 +210,38 @@ func (service *ContentService) UpdatePage(ctx context.Context, input content.Upd
 	if err != nil {
 		return
 	}
+	pageBefore.Tags = pageTags
+
+	pageBefore.Authors, err = service.repo.FindAuthorsForPage(ctx, service.db, page.ID)
+	if err != nil {
+		return
+	}
 
 	tagsDiff, err := service.diffTags(pageTags, siteTags, input.Tags)
 	if err != nil {
 		return
 	}
 
-	metadataHash := content.HashPageMetadata(page.Type, page.Path, page.Date, page.SendAsNewsletter, page.Language, page.Title, page.Description, input.Tags)
+	page.Authors, err = service.findAuthorsBySlugs(ctx, service.db, page.WebsiteID, input.Authors)
+	if err != nil {
+		return
+	}
+	authorSlugs := authorsSlugs(page.Authors)
+
+	revisionsPerPage, err := service.getRevisionsPerPage(ctx, service.db, website.OrganizationID)
+	if err != nil {
+		return
+	}
+
+	metadataHash := content.HashPageMetadata(page.Type, page.Path, page.Date, page.SendAsNewsletter, page.PaidOnly, page.Language, page.Title, page.Description, input.Tags, authorSlugs,
+		page.TranslationKey)
 	page.MetadataHash = metadataHash[:]
 
+	searchText, err := service.getPageSearchText(page, bodyHtml, websiteUrl)
+	if err != nil {
+		return
+	}
+
 	var newsletter emails.Newsletter
 
 	err = service.db.Transaction(ctx, func(tx db.Tx) (txErr error) {
@@ -216,6 +255,22 @@ func (service *ContentService) UpdatePage(ctx context.Context, input content.Upd
 			return txErr
 		}
 
+		txErr = service.associateAuthorsToPage(ctx, tx, page.ID, page.Authors)
+		if txErr != nil {
+			return txErr
+		}
+
+		// tags must be associated before indexing as they are part of the search vector
+		txErr = service.repo.IndexPageForSearch(ctx, tx, page.ID, content.SearchLanguageConfig(page.Language), searchText)
+		if txErr != nil {
+			return txErr
+		}
+
+		txErr = service.savePageRevision(ctx, tx, page, input.Tags, authorSlugs, revisionsPerPage)
+		if txErr != nil {
+			return txErr
+		}
+
 		txErr = service.websitesService.UpdateWebsiteModifiedAt(ctx, tx, page.WebsiteID, now)
 		if txErr != nil {
 			return txErr
@@ -226,7 +281,21 @@ func (service *ContentService) UpdatePage(ctx context.Context, input content.Upd
 			return txErr
 		}
 
-		return nil
+		if pageBefore.Status != content.PageStatusPublished && page.Status == content.PageStatusPublished {
+			txErr = service.dispatchPagePublishedEvent(ctx, tx, website, page)
+			if txErr != nil {
+				return txErr
+			}
+		}
+
+		txErr = service.organizationsService.RecordAuditLog(ctx, tx, organizations.RecordAuditLogInput{
+			WebsiteID: &page.WebsiteID,
+			Action:    organizations.AuditLogActionPageUpdate,
+			EntityID:  page.ID.String(),
+			Before:    pageToAuditLogState(pageBefore),
+			After:     pageToAuditLogState(page),
+		})
+		return txErr
 	})
 	if err != nil {
 		return
@@ -11,6 +11,7 @@ import (
 	"markdown.ninja/pkg/server/httpctx"
 	"markdown.ninja/pkg/services/content"
 	"markdown.ninja/pkg/services/kernel"
+	"markdown.ninja/pkg/services/organizations"
 	"markdown.ninja/pkg/services/websites"
 )
 
@@ -22,7 +23,7 @@ func (service *ContentService) UpdateSnippet(ctx context.Context, input content.
 
 	actorID, err := service.kernel.CurrentUserID(ctx)
 	if err == nil {
-		err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, snippet.WebsiteID)
+		err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, snippet.WebsiteID, kernel.PermissionWriteContent)
 		if err != nil {
 			return
 		}
@@ -39,12 +40,13 @@ func (service *ContentService) UpdateSnippet(ctx context.Context, input content.
 			return
 		}
 
-		_, err = service.organizationsService.CheckCurrentApiKey(ctx, website.OrganizationID)
+		_, err = service.organizationsService.CheckCurrentApiKey(ctx, website.OrganizationID, website.ID, organizations.ApiKeyScopeContentWrite)
 		if err != nil {
 			return
 		}
 	}
 
+	snippetBefore := snippet
 	now := time.Now().UTC()
 	name := strings.TrimSpace(input.Name)
 	snippetContent := strings.TrimSpace(input.Content)
@@ -89,6 +91,17 @@ func (service *ContentService) UpdateSnippet(ctx context.Context, input content.
 		}
 
 		txErr = service.websitesService.UpdateWebsiteModifiedAt(ctx, tx, snippet.WebsiteID, now)
+		if txErr != nil {
+			return txErr
+		}
+
+		txErr = service.organizationsService.RecordAuditLog(ctx, tx, organizations.RecordAuditLogInput{
+			WebsiteID: &snippet.WebsiteID,
+			Action:    organizations.AuditLogActionSnippetUpdate,
+			EntityID:  snippet.ID.String(),
+			Before:    snippetBefore,
+			After:     snippet,
+		})
 		return txErr
 	})
 	if err != nil {
@@ -6,8 +6,10 @@ import (
 	"time"
 
 	"github.com/bloom42/stdx-go/db"
+	"github.com/bloom42/stdx-go/guid"
 	"markdown.ninja/pkg/errs"
 	"markdown.ninja/pkg/services/content"
+	"markdown.ninja/pkg/services/kernel"
 )
 
 func (service *ContentService) UpdateTag(ctx context.Context, input content.UpdateTagInput) (tag content.Tag, err error) {
@@ -21,7 +23,7 @@ func (service *ContentService) UpdateTag(ctx context.Context, input content.Upda
 		return
 	}
 
-	err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, tag.WebsiteID)
+	err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, tag.WebsiteID, kernel.PermissionWriteContent)
 	if err != nil {
 		return
 	}
@@ -56,6 +58,7 @@ func (service *ContentService) UpdateTag(ctx context.Context, input content.Upda
 		}
 	}
 
+	nameChanged := name != tag.Name
 	tag.UpdatedAt = now
 	tag.Name = name
 	tag.Description = description
@@ -66,6 +69,19 @@ func (service *ContentService) UpdateTag(ctx context.Context, input content.Upda
 			return txErr
 		}
 
+		if nameChanged {
+			var pageIDs []guid.GUID
+			pageIDs, txErr = service.repo.FindPageIDsForTag(ctx, tx, tag.ID)
+			if txErr != nil {
+				return txErr
+			}
+
+			txErr = service.repo.ReindexPagesForSearch(ctx, tx, pageIDs)
+			if txErr != nil {
+				return txErr
+			}
+		}
+
 		txErr = service.websitesService.UpdateWebsiteModifiedAt(ctx, tx, tag.WebsiteID, now)
 		return txErr
 	})
@@ -13,6 +13,7 @@ import (
 	"github.com/bloom42/stdx-go/crypto/blake3"
 	"github.com/bloom42/stdx-go/guid"
 	"markdown.ninja/pkg/errs"
+	"markdown.ninja/pkg/images"
 	"markdown.ninja/pkg/server/httpctx"
 	"markdown.ninja/pkg/services/content"
 	"markdown.ninja/pkg/services/kernel"
@@ -28,7 +29,7 @@ func (service *ContentService) UploadAsset(ctx context.Context, input content.Up
 	if !bypassAuthCheck {
 		actorID, err := service.kernel.CurrentUserID(ctx)
 		if err == nil {
-			err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, input.WebsiteID)
+			err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, input.WebsiteID, kernel.PermissionWriteContent)
 			if err != nil {
 				return asset, err
 			}
@@ -48,7 +49,7 @@ func (service *ContentService) UploadAsset(ctx context.Context, input content.Up
 				return asset, err
 			}
 
-			_, err = service.organizationsService.CheckCurrentApiKey(ctx, website.OrganizationID)
+			_, err = service.organizationsService.CheckCurrentApiKey(ctx, website.OrganizationID, website.ID, organizations.ApiKeyScopeAssetsWrite)
 			if err != nil {
 				return asset, err
 			}
@@ -188,6 +189,21 @@ func (service *ContentService) UploadAsset(ctx context.Context, input content.Up
 		asset.Type = content.AssetTypeFile
 	}
 
+	// the dimensions of images are used to render responsive images
+	if asset.Type == content.AssetTypeImage {
+		_, err = input.Data.Seek(0, io.SeekStart)
+		if err != nil {
+			err = fmt.Errorf("content.UploadAsset: seeking(0) tmp file for image config: %w", err)
+			return
+		}
+
+		width, height, decodeErr := images.DecodeConfig(input.Data)
+		if decodeErr == nil {
+			asset.Width = &width
+			asset.Height = &height
+		}
+	}
+
 	err = service.organizationsService.CheckBillingGatedAction(ctx, service.db, website.OrganizationID, organizations.BillingGatedActionUploadAsset{
 		NewAssetSize: asset.Size,
 		WebsiteID:    website.ID,
@@ -2,6 +2,7 @@ package service
 
 import (
 	"fmt"
+	"net/url"
 	"strings"
 	"unicode"
 	"unicode/utf8"
@@ -150,6 +151,35 @@ func (service *ContentService) validatePagePath(path string) error {
 	return nil
 }
 
+func (service *ContentService) validatePageTranslationKey(translationKey string) error {
+	if len(translationKey) == 0 || len(translationKey) > content.PageTranslationKeyMaxSize {
+		return content.ErrPageTranslationKeyIsNotValid
+	}
+
+	for _, char := range translationKey {
+		if !strings.ContainsRune(content.PageTranslationKeyAlphabet, char) {
+			return content.ErrPageTranslationKeyIsNotValid
+		}
+	}
+
+	return nil
+}
+
+// validatePagePathForLanguage checks that the path of a page is in the path prefix of its language, if the
+// language has one.
+func (service *ContentService) validatePagePathForLanguage(website websites.Website, path, language string) error {
+	websiteLanguage, _ := website.FindLanguage(language)
+	if websiteLanguage.PathPrefix == "" {
+		return nil
+	}
+
+	if path != websiteLanguage.PathPrefix && !strings.HasPrefix(path, websiteLanguage.PathPrefix+"/") {
+		return content.ErrPagePathIsNotInLanguagePrefix(websiteLanguage.PathPrefix)
+	}
+
+	return nil
+}
+
 func (service *ContentService) validateLanguage(language string) error {
 	langs := languages.Get()
 
@@ -219,6 +249,83 @@ func (service *ContentService) validateTagDescription(description string) error
 	return nil
 }
 
+func (service *ContentService) validateAuthorSlug(slug string) error {
+	if len(slug) < content.AuthorSlugMinSize {
+		return content.ErrAuthorSlugIsTooShort
+	}
+
+	if len(slug) > content.AuthorSlugMaxSize {
+		return content.ErrAuthorSlugIsTooLong
+	}
+
+	if !utf8.ValidString(slug) {
+		return content.ErrAuthorSlugIsNotValid
+	}
+
+	if !stringsx.IsLower(slug) {
+		return content.ErrAuthorSlugMustBeLower
+	}
+
+	for _, char := range slug {
+		if !strings.ContainsRune(content.AuthorSlugAlphabet, char) {
+			return content.ErrAuthorSlugIsNotValid
+		}
+	}
+
+	return nil
+}
+
+func (service *ContentService) validateAuthorName(name string) error {
+	if len(name) == 0 {
+		return content.ErrAuthorNameIsTooShort
+	}
+
+	if len(name) > content.AuthorNameMaxSize {
+		return content.ErrAuthorNameIsTooLong
+	}
+
+	if !utf8.ValidString(name) || strings.ContainsAny(name, "\n\r") {
+		return content.ErrAuthorNameIsNotValid
+	}
+
+	return nil
+}
+
+func (service *ContentService) validateAuthorBio(bio string) error {
+	if len(bio) > content.AuthorBioMaxSize {
+		return content.ErrAuthorBioIsTooLong
+	}
+
+	if !utf8.ValidString(bio) {
+		return content.ErrAuthorBioIsNotValid
+	}
+
+	return nil
+}
+
+func (service *ContentService) validateAuthorSocialLinks(links []content.AuthorSocialLink) error {
+	if len(links) > content.AuthorMaxSocialLinks {
+		return content.ErrAuthorTooManySocialLinks
+	}
+
+	for _, link := range links {
+		if link.Name == "" || len(link.Name) > content.AuthorSocialLinkNameMaxSize || !utf8.ValidString(link.Name) {
+			return content.ErrAuthorSocialLinkIsNotValid
+		}
+
+		if len(link.Url) > content.AuthorSocialLinkUrlMaxSize {
+			return content.ErrAuthorSocialLinkIsNotValid
+		}
+
+		linkUrl, err := url.Parse(link.Url)
+		if err != nil || (linkUrl.Scheme != "https" && linkUrl.Scheme != "http" && linkUrl.Scheme != "mailto") {
+			return content.ErrAuthorSocialLinkIsNotValid
+		}
+	}
+
+	return nil
+}
+
 func (service *ContentService) validateSnippetName(name string) error {
 	if len(name) < content.SnippetNameMinLength {
 		return content.ErrSnippetNameIsNotValid
@@ -0,0 +1,28 @@
+package service
+
+import (
+	"github.com/bloom42/stdx-go/guid"
+	"markdown.ninja/pkg/services/content"
+)
+
+const jwtActionPreview = "preview"
+
+type jwtClaimsPreview struct {
+	Action    string    `json:"action"`
+	WebsiteID guid.GUID `json:"website_id"`
+}
+
+func (service *ContentService) VerifyPreviewToken(websiteID guid.GUID, token string) (err error) {
+	var claims jwtClaimsPreview
+
+	err = service.jwtProvider.ParseAndVerifyToken(token, &claims)
+	if err != nil {
+		return content.ErrPreviewTokenIsNotValid
+	}
+
+	if claims.Action != jwtActionPreview || !claims.WebsiteID.Equal(websiteID) {
+		return content.ErrPreviewTokenIsNotValid
+	}
+
+	return nil
+}
@@ -15,13 +15,17 @@ var (
 	ErrDomainAlreadyInUse            = errs.AlreadyExists("This email domain is already in use by another website. Please change and try again or contact support.")
 	ErrNoCustomEmailDomainConfigured = errs.InvalidArgument("No custom email domain set up")
 
+	// Tracking
+	ErrTrackingLinkIsNotValid = errs.InvalidArgument("Link is not valid")
+
 	// Newsletter
-	ErrNewsletterScheduledForIsInThePast = errs.InvalidArgument("You can't schedule a newsletter in the past")
-	ErrNewsletterAlreadySent             = errs.InvalidArgument("Newsletter already sent.")
-	ErrNewsletterNotFound                = errs.NotFound("Newsletter not found.")
-	ErrNewsletterSubjectIsTooLong        = errs.InvalidArgument(fmt.Sprintf("Subject is too long (max: %d characters)", NewsletterSubjectMaxSize))
-	ErrNewsletterSubjectIsTooShort       = errs.InvalidArgument(fmt.Sprintf("Subject is too short (min: %d characters)", NewsletterSubjectMinSize))
-	ErrNewsletterBodyIsTooLarge          = errs.InvalidArgument(fmt.Sprintf("Newsletter is too large (max: %d characters)", NewsletterContentMarkdownMaxSize))
-	ErrNewsletterSubjectIsNotValid       = errs.InvalidArgument("Newsletter subject is not valid")
-	ErrNewsletterBodyIsNotValid          = errs.InvalidArgument("Newsletter body is not valid")
+	ErrNewsletterScheduledForIsInThePast    = errs.InvalidArgument("You can't schedule a newsletter in the past")
+	ErrNewsletterAlreadySent                = errs.InvalidArgument("Newsletter already sent.")
+	ErrNewsletterNotFound                   = errs.NotFound("Newsletter not found.")
+	ErrNewsletterSubjectIsTooLong           = errs.InvalidArgument(fmt.Sprintf("Subject is too long (max: %d characters)", NewsletterSubjectMaxSize))
+	ErrNewsletterSubjectIsTooShort          = errs.InvalidArgument(fmt.Sprintf("Subject is too short (min: %d characters)", NewsletterSubjectMinSize))
+	ErrNewsletterBodyIsTooLarge             = errs.InvalidArgument(fmt.Sprintf("Newsletter is too large (max: %d characters)", NewsletterContentMarkdownMaxSize))
+	ErrNewsletterSubjectIsNotValid          = errs.InvalidArgument("Newsletter subject is not valid")
+	ErrNewsletterBodyIsNotValid             = errs.InvalidArgument("Newsletter body is not valid")
+	ErrNewsletterLabelIsIncludedAndExcluded = errs.InvalidArgument("A label can't be both included and excluded")
 )
@@ -1,6 +1,9 @@
 package emails
 
 import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
 	"time"
 
 	"github.com/bloom42/stdx-go/guid"
@@ -40,6 +43,9 @@ type WebsiteConfiguration struct {
 	FromDomain     string            `db:"from_domain" json:"-"`
 	DomainVerified bool              `db:"domain_verified" json:"domain_verified"`
 	DnsRecords     mailer.DnsRecords `db:"dns_records" json:"dns_records"`
+	// TrackOpens and TrackClicks enable open (tracking pixel) and click (links rewriting) tracking for newsletters
+	TrackOpens  bool `db:"track_opens" json:"track_opens"`
+	TrackClicks bool `db:"track_clicks" json:"track_clicks"`
 
 	WebsiteID guid.GUID `db:"website_id" json:"-"`
 }
@@ -57,11 +63,36 @@ type Newsletter struct {
 	SentAt         *time.Time      `db:"sent_at" json:"sent_at"`
 	LastTestSentAt *time.Time      `db:"last_test_sent_at" json:"last_test_sent_at"`
 	BodyMarkdown   string          `db:"body_markdown" json:"body_markdown"`
+	// If not empty, the newsletter is only sent to the contacts with at least one of these labels
+	IncludedLabels NewsletterLabels `db:"included_labels" json:"included_labels"`
+	// The newsletter is never sent to the contacts with any of these labels
+	ExcludedLabels NewsletterLabels `db:"excluded_labels" json:"excluded_labels"`
 
 	PostID    *guid.GUID `db:"post_id" json:"post_id"`
 	WebsiteID guid.GUID  `db:"website_id" json:"website_id"`
 }
 
+// NewsletterLabels is the list of the IDs of the contacts' labels used to segment a newsletter
+type NewsletterLabels []guid.GUID
+
+func (labels *NewsletterLabels) Scan(val any) error {
+	switch v := val.(type) {
+	case []byte:
+		return json.Unmarshal(v, labels)
+	case string:
+		return json.Unmarshal([]byte(v), labels)
+	default:
+		return fmt.Errorf("NewsletterLabels.Scan: Unsupported type: %T", v)
+	}
+}
+
+func (labels NewsletterLabels) Value() (driver.Value, error) {
+	if labels == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(labels)
+}
+
 ////////////////////////////////////////////////////////////////////////////////////////////////////
 // Service
 ////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -74,6 +105,8 @@ type UpdateWebsiteConfigurationInput struct {
 	WebsiteID   guid.GUID `json:"website_id"`
 	FromName    string    `json:"from_name"`
 	FromAddress string    `json:"from_address"`
+	TrackOpens  *bool     `json:"track_opens"`
+	TrackClicks *bool     `json:"track_clicks"`
 }
 
 type VerifyDnsConfigurationInput struct {
@@ -98,17 +131,21 @@ type SendNewsletterInput struct {
 }
 
 type CreateNewsletterInput struct {
-	WebsiteID    guid.GUID  `json:"website_id"`
-	ScheduledFor *time.Time `json:"scheduled_for"`
-	Subject      string     `json:"subject"`
-	BodyMarkdown string     `json:"body_markdown"`
+	WebsiteID      guid.GUID   `json:"website_id"`
+	ScheduledFor   *time.Time  `json:"scheduled_for"`
+	Subject        string      `json:"subject"`
+	BodyMarkdown   string      `json:"body_markdown"`
+	IncludedLabels []guid.GUID `json:"included_labels"`
+	ExcludedLabels []guid.GUID `json:"excluded_labels"`
 }
 
 type UpdateNewsletterInput struct {
-	ID           guid.GUID  `json:"id"`
-	ScheduledFor *time.Time `json:"scheduled_for"`
-	Subject      string     `json:"subject"`
-	BodyMarkdown *string    `json:"body_markdown"`
+	ID             guid.GUID    `json:"id"`
+	ScheduledFor   *time.Time   `json:"scheduled_for"`
+	Subject        string       `json:"subject"`
+	BodyMarkdown   *string      `json:"body_markdown"`
+	IncludedLabels *[]guid.GUID `json:"included_labels"`
+	ExcludedLabels *[]guid.GUID `json:"excluded_labels"`
 }
 
 type NewsletterMetadata struct {
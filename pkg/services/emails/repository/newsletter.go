@@ -14,13 +14,14 @@ import (
 func (repo *EmailsRepository) CreateNewsletter(ctx context.Context, db db.Queryer, newsletter emails.Newsletter) (err error) {
 	const query = `INSERT INTO newsletters
 			(id, created_at, updated_at, scheduled_for, subject, size,
-				hash, sent_at, last_test_sent_at, body_markdown, post_id, website_id)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`
+				hash, sent_at, last_test_sent_at, body_markdown, included_labels, excluded_labels,
+				post_id, website_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)`
 
 	_, err = db.Exec(ctx, query, newsletter.ID, newsletter.CreatedAt, newsletter.UpdatedAt,
 		newsletter.ScheduledFor, newsletter.Subject, newsletter.Size,
 		newsletter.Hash, newsletter.SentAt, newsletter.LastTestSentAt,
-		newsletter.BodyMarkdown,
+		newsletter.BodyMarkdown, newsletter.IncludedLabels, newsletter.ExcludedLabels,
 		newsletter.PostID, newsletter.WebsiteID)
 	if err != nil {
 		err = fmt.Errorf("emails.CreateNewsletter: %w", err)
@@ -76,12 +77,14 @@ func (repo *EmailsRepository) FindNewslettersByWebsiteID(ctx context.Context, db
 func (repo *EmailsRepository) UpdateNewsletter(ctx context.Context, db db.Queryer, newsletter emails.Newsletter) (err error) {
 	const query = `UPDATE newsletters
 		SET updated_at = $1, scheduled_for = $2, subject = $3, size = $4,
-			hash = $5, sent_at = $6, last_test_sent_at = $7, body_markdown = $8
-		WHERE id = $9`
+			hash = $5, sent_at = $6, last_test_sent_at = $7, body_markdown = $8,
+			included_labels = $9, excluded_labels = $10
+		WHERE id = $11`
 
 	_, err = db.Exec(ctx, query, newsletter.UpdatedAt, newsletter.ScheduledFor, newsletter.Subject,
 		newsletter.Size, newsletter.Hash, newsletter.SentAt,
 		newsletter.LastTestSentAt, newsletter.BodyMarkdown,
+		newsletter.IncludedLabels, newsletter.ExcludedLabels,
 		newsletter.ID)
 	if err != nil {
 		err = fmt.Errorf("emails.UpdateNewsletter: %w", err)
@@ -12,11 +12,13 @@ import (
 
 func (repo *EmailsRepository) CreateWebsiteConfiguration(ctx context.Context, db db.Queryer, config emails.WebsiteConfiguration) (err error) {
 	const query = `INSERT INTO emails_website_configuration
-			(created_at, updated_at, from_name, from_address, from_domain, domain_verified, dns_records, website_id)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+			(created_at, updated_at, from_name, from_address, from_domain, domain_verified, dns_records,
+			track_opens, track_clicks, website_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
 
 	_, err = db.Exec(ctx, query, config.CreatedAt, config.UpdatedAt, config.FromName, config.FromAddress,
-		config.FromDomain, config.DomainVerified, config.DnsRecords, config.WebsiteID)
+		config.FromDomain, config.DomainVerified, config.DnsRecords,
+		config.TrackOpens, config.TrackClicks, config.WebsiteID)
 	if err != nil {
 		err = fmt.Errorf("emails.CreateWebsiteConfiguration: %w", err)
 		return
@@ -60,11 +62,11 @@ func (repo *EmailsRepository) FindWebsiteConfigurationByDomain(ctx context.Conte
 func (repo *EmailsRepository) UpdateWebsiteConfiguration(ctx context.Context, db db.Queryer, config emails.WebsiteConfiguration) (err error) {
 	const query = `UPDATE emails_website_configuration
 		SET from_address = $1, from_domain = $2, domain_verified = $3, dns_records = $4,
-			updated_at = $5, from_name = $6
-		WHERE website_id = $7`
+			updated_at = $5, from_name = $6, track_opens = $7, track_clicks = $8
+		WHERE website_id = $9`
 
 	_, err = db.Exec(ctx, query, config.FromAddress, config.FromDomain, config.DomainVerified, config.DnsRecords,
-		config.UpdatedAt, config.FromName,
+		config.UpdatedAt, config.FromName, config.TrackOpens, config.TrackClicks,
 		config.WebsiteID)
 	if err != nil {
 		err = fmt.Errorf("emails.UpdateWebsiteConfiguration: %w", err)
@@ -29,6 +29,10 @@ type Service interface {
 	UpdateNewsletter(ctx context.Context, input UpdateNewsletterInput) (newsletter Newsletter, err error)
 	SendNewsletter(ctx context.Context, input SendNewsletterInput) (newsletter Newsletter, err error)
 
+	// Tracking
+	TrackNewsletterOpened(ctx context.Context, websiteID guid.GUID, token string) (err error)
+	TrackNewsletterClicked(ctx context.Context, websiteID guid.GUID, token string) (url string, err error)
+
 	// Jobs
 	JobDeleteWebsiteConfigurationData(ctx context.Context, input JobDeleteWebsiteConfigurationData) (err error)
 	JobSendNewsletter(ctx context.Context, input JobSendNewsletter) (err error)
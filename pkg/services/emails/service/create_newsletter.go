@@ -8,6 +8,7 @@ import (
 	"github.com/bloom42/stdx-go/crypto/blake3"
 	"github.com/bloom42/stdx-go/guid"
 	"markdown.ninja/pkg/services/emails"
+	"markdown.ninja/pkg/services/kernel"
 )
 
 // TODO: increase website's used storage? see also DeleteNewsletter and UpdateNewsletter
@@ -17,7 +18,7 @@ func (service *EmailsService) CreateNewsletter(ctx context.Context, input emails
 		return
 	}
 
-	err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, input.WebsiteID)
+	err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, input.WebsiteID, kernel.PermissionSendNewsletters)
 	if err != nil {
 		return
 	}
@@ -54,6 +55,11 @@ func (service *EmailsService) CreateNewsletter(ctx context.Context, input emails
 		return
 	}
 
+	includedLabels, excludedLabels, err := service.resolveNewsletterLabels(ctx, website.ID, input.IncludedLabels, input.ExcludedLabels)
+	if err != nil {
+		return
+	}
+
 	newsletter = emails.Newsletter{
 		ID:             guid.NewTimeBased(),
 		CreatedAt:      now,
@@ -65,6 +71,8 @@ func (service *EmailsService) CreateNewsletter(ctx context.Context, input emails
 		SentAt:         nil,
 		LastTestSentAt: nil,
 		BodyMarkdown:   bodyMarkdown,
+		IncludedLabels: includedLabels,
+		ExcludedLabels: excludedLabels,
 		WebsiteID:      website.ID,
 		PostID:         nil,
 	}
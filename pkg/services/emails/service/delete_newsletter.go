@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"markdown.ninja/pkg/services/emails"
+	"markdown.ninja/pkg/services/kernel"
 )
 
 // TODO: decrease website's used storage? see also CreateNewsletter and UpdateNewsletter
@@ -18,7 +19,7 @@ func (service *EmailsService) DeleteNewsletter(ctx context.Context, input emails
 		return
 	}
 
-	err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, newsletter.WebsiteID)
+	err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, newsletter.WebsiteID, kernel.PermissionSendNewsletters)
 	if err != nil {
 		return
 	}
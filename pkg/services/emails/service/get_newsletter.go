@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"markdown.ninja/pkg/services/emails"
+	"markdown.ninja/pkg/services/kernel"
 )
 
 func (service *EmailsService) GetNewsletter(ctx context.Context, input emails.GetNewsletterInput) (newsletter emails.Newsletter, err error) {
@@ -17,7 +18,7 @@ func (service *EmailsService) GetNewsletter(ctx context.Context, input emails.Ge
 		return
 	}
 
-	err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, newsletter.WebsiteID)
+	err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, newsletter.WebsiteID, kernel.PermissionRead)
 	if err != nil {
 		return
 	}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"markdown.ninja/pkg/services/emails"
+	"markdown.ninja/pkg/services/kernel"
 )
 
 func (service *EmailsService) GetWebsiteConfiguration(ctx context.Context, input emails.GetWebsiteConfigurationInput) (configuration emails.WebsiteConfiguration, err error) {
@@ -12,7 +13,7 @@ func (service *EmailsService) GetWebsiteConfiguration(ctx context.Context, input
 		return
 	}
 
-	err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, input.WebsiteID)
+	err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, input.WebsiteID, kernel.PermissionRead)
 	if err != nil {
 		return
 	}
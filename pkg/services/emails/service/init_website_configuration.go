@@ -20,6 +20,8 @@ func (service *EmailsService) InitWebsiteConfiguration(ctx context.Context, db d
 		FromDomain:     "",
 		DnsRecords:     []mailer.DnsRecord{},
 		DomainVerified: false,
+		TrackOpens:     true,
+		TrackClicks:    true,
 		WebsiteID:      websiteID,
 	}
 
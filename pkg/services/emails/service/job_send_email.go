@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/bloom42/stdx-go/email"
+	"markdown.ninja/pkg/mailer"
 	"markdown.ninja/pkg/services/emails"
 	"markdown.ninja/pkg/services/events"
 )
@@ -33,13 +34,30 @@ func (service *EmailsService) JobSendEmail(ctx context.Context, input emails.Job
 		Name:    input.ToName,
 		Address: input.ToAddress,
 	}
+
+	// tags are used to attribute bounces and complaints to websites and newsletters
+	headers := input.Headers
+	if input.WebsiteID != nil {
+		tags := map[string]string{
+			mailer.TagWebsiteID: input.WebsiteID.String(),
+		}
+		if input.NewsletterID != nil {
+			tags[mailer.TagNewsletterID] = input.NewsletterID.String()
+		}
+		headers = make(map[string][]string, len(input.Headers)+1)
+		for key, value := range input.Headers {
+			headers[key] = value
+		}
+		headers[mailer.HeaderTags] = []string{mailer.EncodeTags(tags)}
+	}
+
 	message := email.Email{
 		From:    from,
 		To:      []mail.Address{to},
 		Subject: input.Subject,
 		HTML:    []byte(input.BodyHtml),
 		Text:    bodyText,
-		Headers: input.Headers,
+		Headers: headers,
 	}
 
 	// we don't use retry here and prefer to instead rely on the retry mechanism of the queue because we don't
@@ -17,6 +17,7 @@ import (
 	"github.com/bloom42/stdx-go/log/slogx"
 	"github.com/bloom42/stdx-go/opt"
 	"github.com/bloom42/stdx-go/queue"
+	"github.com/bloom42/stdx-go/set"
 	"markdown.ninja/pkg/errs"
 	"markdown.ninja/pkg/markdown"
 	"markdown.ninja/pkg/services/contacts"
@@ -31,6 +32,8 @@ type newsletterRecipient struct {
 	Email           string
 	ContactID       *guid.GUID
 	UnsubscribeLink string
+	// RecipientID is used to track opens and clicks. nil for test emails.
+	RecipientID *guid.GUID
 }
 
 func (service *EmailsService) JobSendNewsletter(ctx context.Context, input emails.JobSendNewsletter) error {
@@ -92,7 +95,8 @@ func (service *EmailsService) JobSendNewsletter(ctx context.Context, input email
 		}
 	} else {
 		var recipientsContacts []contacts.Contact
-		recipientsContacts, err = service.contactsService.FindVerifiedAndSubscribedToNewsletterContacts(ctx, service.db, website.ID)
+		recipientsContacts, err = service.contactsService.FindVerifiedAndSubscribedToNewsletterContacts(ctx, service.db, website.ID,
+			newsletter.IncludedLabels, newsletter.ExcludedLabels)
 		if err != nil {
 			return err
 		}
@@ -105,11 +109,13 @@ func (service *EmailsService) JobSendNewsletter(ctx context.Context, input email
 					slog.String("contact.id", contact.ID.String()))
 				continue
 			}
+			recipientID := newsletterRecipientID(newsletter.ID, contact.ID)
 			recipients[i] = newsletterRecipient{
 				Name:            contact.Name,
 				Email:           contact.Email,
 				ContactID:       &contact.ID,
 				UnsubscribeLink: unsubscribeLink,
+				RecipientID:     &recipientID,
 			}
 		}
 	}
@@ -123,8 +129,34 @@ func (service *EmailsService) JobSendNewsletter(ctx context.Context, input email
 		return fmt.Errorf("emails.JobSendNewsletter: error converting markdown to HTML: %w", err)
 	}
 
+	// paid-only posts are sent in full only to the contacts with an active subscription, the other
+	// contacts receive a teaser
+	teaserHtml := ""
+	var contactIDsWithActiveSubscription set.Set[guid.GUID]
+	if !input.Test && newsletter.PostID != nil {
+		var post content.Page
+		post, err = service.contentService.FindPageByID(ctx, service.db, *newsletter.PostID)
+		if err != nil && !errs.IsNotFound(err) {
+			return err
+		}
+		if err == nil && post.PaidOnly {
+			teaserHtml, err = service.renderPaidPostNewsletterTeaser(website, post)
+			if err != nil {
+				return fmt.Errorf("emails.JobSendNewsletter: error rendering teaser: %w", err)
+			}
+
+			var contactIDs []guid.GUID
+			contactIDs, err = service.storeService.FindContactIDsWithActiveSubscription(ctx, service.db, website.ID)
+			if err != nil {
+				return err
+			}
+			contactIDsWithActiveSubscription = set.NewFromSlice(contactIDs)
+		}
+		err = nil
+	}
+
 	// TODO: do we really want to render all the snippets?
-	if strings.Contains(contentHtml, "{{<") {
+	if strings.Contains(contentHtml, "{{<") || strings.Contains(teaserHtml, "{{<") {
 		var snippets []content.Snippet
 		snippets, err = service.contentService.FindSnippets(ctx, service.db, newsletter.WebsiteID)
 		if err != nil {
@@ -132,6 +164,9 @@ func (service *EmailsService) JobSendNewsletter(ctx context.Context, input email
 		}
 		if len(snippets) != 0 {
 			contentHtml = service.contentService.RenderSnippets(contentHtml, snippets, true)
+			if teaserHtml != "" {
+				teaserHtml = service.contentService.RenderSnippets(teaserHtml, snippets, true)
+			}
 			// contentMarkdown = service.contentService.RenderSnippets(contentMarkdown, snippets, true)
 		}
 	}
@@ -158,10 +193,40 @@ func (service *EmailsService) JobSendNewsletter(ctx context.Context, input email
 				subject = "[Test] " + subject
 			}
 
+			baseContentHtml := contentHtml
+			if teaserHtml != "" &&
+				(recipient.ContactID == nil || !contactIDsWithActiveSubscription.Contains(*recipient.ContactID)) {
+				baseContentHtml = teaserHtml
+			}
+
+			recipientContentHtml := baseContentHtml
+			trackingPixelLink := ""
+			if recipient.RecipientID != nil {
+				if emailConfig.TrackClicks {
+					recipientContentHtml, err = service.rewriteNewsletterLinks(baseContentHtml, website.PrimaryDomain,
+						website.ID, newsletter.ID, *recipient.RecipientID)
+					if err != nil {
+						logger.Error("emails.JobSendNewsletter: error rewriting links", slogx.Err(err))
+						err = nil
+						recipientContentHtml = baseContentHtml
+					}
+				}
+				if emailConfig.TrackOpens {
+					trackingPixelLink, err = service.generateTrackingPixelLink(website.PrimaryDomain,
+						website.ID, newsletter.ID, *recipient.RecipientID)
+					if err != nil {
+						logger.Error("emails.JobSendNewsletter: error generating tracking pixel link", slogx.Err(err))
+						err = nil
+						trackingPixelLink = ""
+					}
+				}
+			}
+
 			emailData := templates.NewsletterEmailData{
-				Subject:         newsletter.Subject,
-				Content:         template.HTML(contentHtml),
-				UnsubscribeLink: template.URL(recipient.UnsubscribeLink),
+				Subject:           newsletter.Subject,
+				Content:           template.HTML(recipientContentHtml),
+				UnsubscribeLink:   template.URL(recipient.UnsubscribeLink),
+				TrackingPixelLink: template.URL(trackingPixelLink),
 			}
 			err = service.newsletterEmailTemplate.Execute(emailBodyBuffer, emailData)
 			if err != nil {
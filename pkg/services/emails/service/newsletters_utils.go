@@ -1,10 +1,15 @@
 package service
 
 import (
+	"context"
 	"fmt"
+	"slices"
 
 	"github.com/bloom42/stdx-go/guid"
+	"markdown.ninja/pkg/markdown"
+	"markdown.ninja/pkg/services/content"
 	"markdown.ninja/pkg/services/emails"
+	"markdown.ninja/pkg/services/websites"
 )
 
 func convertNewsletterMetadata(input []emails.Newsletter) (output []emails.NewsletterMetadata) {
@@ -34,3 +39,47 @@ func getWebsiteConfigurationCacheKey(websiteID guid.GUID) string {
 func getSenderApiTokenCacheKey(websiteID guid.GUID) string {
 	return fmt.Sprintf("SenderApiToken:%s", websiteID.String())
 }
+
+// resolveNewsletterLabels checks that all the labels exist for the given website and returns the
+// deduplicated lists of included and excluded labels
+func (service *EmailsService) resolveNewsletterLabels(ctx context.Context, websiteID guid.GUID, includedLabelIDs, excludedLabelIDs []guid.GUID) (includedLabels, excludedLabels emails.NewsletterLabels, err error) {
+	included, err := service.contactsService.FindLabelsByIDs(ctx, service.db, websiteID, includedLabelIDs)
+	if err != nil {
+		return
+	}
+
+	excluded, err := service.contactsService.FindLabelsByIDs(ctx, service.db, websiteID, excludedLabelIDs)
+	if err != nil {
+		return
+	}
+
+	includedLabels = make(emails.NewsletterLabels, len(included))
+	for i, label := range included {
+		includedLabels[i] = label.ID
+	}
+
+	excludedLabels = make(emails.NewsletterLabels, len(excluded))
+	for i, label := range excluded {
+		if slices.ContainsFunc(includedLabels, label.ID.Equal) {
+			err = emails.ErrNewsletterLabelIsIncludedAndExcluded
+			return
+		}
+		excludedLabels[i] = label.ID
+	}
+
+	return
+}
+
+// renderPaidPostNewsletterTeaser renders the email sent to the contacts without an active subscription
+// when a paid-only post is sent as a newsletter
+func (service *EmailsService) renderPaidPostNewsletterTeaser(website websites.Website, post content.Page) (string, error) {
+	websiteBaseUrl := service.httpConfig.WebsitesBaseUrl.Scheme + "://" + website.PrimaryDomain + service.httpConfig.WebsitesPort
+	teaserMarkdown := fmt.Sprintf(`%s
+
+---
+
+The rest of this post is reserved to paid subscribers. [Subscribe to read it online](%s%s).`,
+		content.PaidPageTeaser(post.BodyMarkdown), websiteBaseUrl, post.Path)
+
+	return markdown.ToHtmlEmail(websiteBaseUrl, teaserMarkdown)
+}
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/bloom42/stdx-go/db"
+	"github.com/bloom42/stdx-go/guid"
+	"markdown.ninja/pkg/services/contacts"
+	"markdown.ninja/pkg/services/emails"
+)
+
+// labelsContactsService is a fake contacts.Service that only implements FindLabelsByIDs
+type labelsContactsService struct {
+	contacts.Service
+	labels []contacts.Label
+}
+
+func (service labelsContactsService) FindLabelsByIDs(ctx context.Context, db db.Queryer, websiteID guid.GUID, labelIDs []guid.GUID) (labels []contacts.Label, err error) {
+	labels = []contacts.Label{}
+	for _, labelID := range labelIDs {
+		if slices.ContainsFunc(labels, func(label contacts.Label) bool { return label.ID.Equal(labelID) }) {
+			continue
+		}
+
+		index := slices.IndexFunc(service.labels, func(label contacts.Label) bool {
+			return label.ID.Equal(labelID) && label.WebsiteID.Equal(websiteID)
+		})
+		if index == -1 {
+			return nil, contacts.ErrLabelNotFound
+		}
+		labels = append(labels, service.labels[index])
+	}
+	return labels, nil
+}
+
+func TestResolveNewsletterLabels(t *testing.T) {
+	websiteID := guid.NewTimeBased()
+	now := time.Now().UTC()
+	newLabel := func(name string, websiteID guid.GUID) contacts.Label {
+		return contacts.Label{ID: guid.NewTimeBased(), CreatedAt: now, UpdatedAt: now, Name: name, WebsiteID: websiteID}
+	}
+	customers := newLabel("customers", websiteID)
+	beta := newLabel("beta", websiteID)
+	churned := newLabel("churned", websiteID)
+	otherWebsiteLabel := newLabel("customers", guid.NewTimeBased())
+
+	service := &EmailsService{
+		contactsService: labelsContactsService{labels: []contacts.Label{customers, beta, churned, otherWebsiteLabel}},
+	}
+
+	included, excluded, err := service.resolveNewsletterLabels(context.Background(), websiteID,
+		[]guid.GUID{customers.ID, beta.ID, customers.ID}, []guid.GUID{churned.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(included) != 2 || !included[0].Equal(customers.ID) || !included[1].Equal(beta.ID) {
+		t.Errorf("unexpected included labels: %v", included)
+	}
+	if len(excluded) != 1 || !excluded[0].Equal(churned.ID) {
+		t.Errorf("unexpected excluded labels: %v", excluded)
+	}
+
+	// no segmentation: the newsletter is sent to all the subscribers
+	included, excluded, err = service.resolveNewsletterLabels(context.Background(), websiteID, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if included == nil || excluded == nil || len(included) != 0 || len(excluded) != 0 {
+		t.Errorf("expected empty labels, got: %v, %v", included, excluded)
+	}
+
+	_, _, err = service.resolveNewsletterLabels(context.Background(), websiteID,
+		[]guid.GUID{customers.ID}, []guid.GUID{beta.ID, customers.ID})
+	if !errors.Is(err, emails.ErrNewsletterLabelIsIncludedAndExcluded) {
+		t.Errorf("label included and excluded: expected ErrNewsletterLabelIsIncludedAndExcluded, got: %v", err)
+	}
+
+	_, _, err = service.resolveNewsletterLabels(context.Background(), websiteID, []guid.GUID{otherWebsiteLabel.ID}, nil)
+	if !errors.Is(err, contacts.ErrLabelNotFound) {
+		t.Errorf("included label of another website: expected ErrLabelNotFound, got: %v", err)
+	}
+
+	_, _, err = service.resolveNewsletterLabels(context.Background(), websiteID, nil, []guid.GUID{guid.NewTimeBased()})
+	if !errors.Is(err, contacts.ErrLabelNotFound) {
+		t.Errorf("unknown excluded label: expected ErrLabelNotFound, got: %v", err)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"markdown.ninja/pkg/errs"
 	"markdown.ninja/pkg/server/httpctx"
 	"markdown.ninja/pkg/services/emails"
+	"markdown.ninja/pkg/services/kernel"
 	"markdown.ninja/pkg/services/organizations"
 )
 
@@ -32,7 +33,7 @@ func (service *EmailsService) SendNewsletter(ctx context.Context, input emails.S
 		return
 	}
 
-	err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, newsletter.WebsiteID)
+	err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, newsletter.WebsiteID, kernel.PermissionSendNewsletters)
 	if err != nil {
 		return
 	}
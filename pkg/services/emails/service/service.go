@@ -10,6 +10,7 @@ import (
 	"github.com/bloom42/stdx-go/queue"
 	"golang.org/x/sync/singleflight"
 	"markdown.ninja/cmd/mdninja-server/config"
+	"markdown.ninja/pkg/jwt"
 	"markdown.ninja/pkg/mailer"
 	"markdown.ninja/pkg/services/contacts"
 	"markdown.ninja/pkg/services/content"
@@ -18,16 +19,18 @@ import (
 	"markdown.ninja/pkg/services/events"
 	"markdown.ninja/pkg/services/kernel"
 	"markdown.ninja/pkg/services/organizations"
+	"markdown.ninja/pkg/services/store"
 	"markdown.ninja/pkg/services/websites"
 )
 
 type EmailsService struct {
-	config     config.Config
-	repo       repository.EmailsRepository
-	db         db.DB
-	queue      queue.Queue
-	mailer     mailer.Mailer
-	httpConfig config.Http
+	config      config.Config
+	repo        repository.EmailsRepository
+	db          db.DB
+	queue       queue.Queue
+	mailer      mailer.Mailer
+	httpConfig  config.Http
+	jwtProvider *jwt.Provider
 
 	kernel               kernel.PrivateService
 	websitesService      websites.Service
@@ -35,6 +38,7 @@ type EmailsService struct {
 	eventsService        events.Service
 	contentService       content.Service
 	organizationsService organizations.Service
+	storeService         store.Service
 
 	newsletterEmailTemplate    *template.Template
 	dnsResolver                *net.Resolver
@@ -42,7 +46,7 @@ type EmailsService struct {
 	sendEmailSingleflightGroup singleflight.Group
 }
 
-func NewEmailsService(conf config.Config, db db.DB, queue queue.Queue, mailer mailer.Mailer, dnsResolver *net.Resolver, kernel kernel.PrivateService,
+func NewEmailsService(conf config.Config, db db.DB, queue queue.Queue, mailer mailer.Mailer, jwtProvider *jwt.Provider, dnsResolver *net.Resolver, kernel kernel.PrivateService,
 	eventsService events.Service, contentService content.Service,
 	organizationsService organizations.Service) *EmailsService {
 	repo := repository.NewEmailsRepository()
@@ -53,12 +57,13 @@ func NewEmailsService(conf config.Config, db db.DB, queue queue.Queue, mailer ma
 	)
 
 	return &EmailsService{
-		config:     conf,
-		repo:       repo,
-		db:         db,
-		queue:      queue,
-		mailer:     mailer,
-		httpConfig: conf.HTTP,
+		config:      conf,
+		repo:        repo,
+		db:          db,
+		queue:       queue,
+		mailer:      mailer,
+		httpConfig:  conf.HTTP,
+		jwtProvider: jwtProvider,
 
 		kernel:               kernel,
 		websitesService:      nil,
@@ -66,6 +71,7 @@ func NewEmailsService(conf config.Config, db db.DB, queue queue.Queue, mailer ma
 		eventsService:        eventsService,
 		contentService:       contentService,
 		organizationsService: organizationsService,
+		storeService:         nil,
 
 		newsletterEmailTemplate:    newsletterEmailTemplate,
 		dnsResolver:                dnsResolver,
@@ -74,7 +80,8 @@ func NewEmailsService(conf config.Config, db db.DB, queue queue.Queue, mailer ma
 	}
 }
 
-func (service *EmailsService) InjectServices(websitesService websites.Service, contactsService contacts.Service) {
+func (service *EmailsService) InjectServices(websitesService websites.Service, contactsService contacts.Service, storeService store.Service) {
 	service.websitesService = websitesService
 	service.contactsService = contactsService
+	service.storeService = storeService
 }
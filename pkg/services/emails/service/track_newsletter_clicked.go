@@ -0,0 +1,32 @@
+package service
+
+import (
+	"context"
+
+	"github.com/bloom42/stdx-go/guid"
+	"markdown.ninja/pkg/services/emails"
+	"markdown.ninja/pkg/services/events"
+)
+
+// TrackNewsletterClicked records the click and returns the original URL of the link that the recipient should
+// be redirected to.
+func (service *EmailsService) TrackNewsletterClicked(ctx context.Context, websiteID guid.GUID, token string) (url string, err error) {
+	claims, err := service.parseAndVerifyTrackingToken(token, jwtActionTrackEmailClick)
+	if err != nil {
+		return
+	}
+
+	if !claims.WebsiteID.Equal(websiteID) || claims.Url == "" {
+		err = emails.ErrTrackingLinkIsNotValid
+		return
+	}
+
+	service.eventsService.TrackEmailClicked(ctx, events.TrackEmailClickedInput{
+		Url:          claims.Url,
+		WebsiteID:    claims.WebsiteID,
+		NewsletterID: claims.NewsletterID,
+		RecipientID:  claims.RecipientID,
+	})
+
+	return claims.Url, nil
+}
@@ -0,0 +1,29 @@
+package service
+
+import (
+	"context"
+
+	"github.com/bloom42/stdx-go/guid"
+	"markdown.ninja/pkg/services/emails"
+	"markdown.ninja/pkg/services/events"
+)
+
+func (service *EmailsService) TrackNewsletterOpened(ctx context.Context, websiteID guid.GUID, token string) (err error) {
+	claims, err := service.parseAndVerifyTrackingToken(token, jwtActionTrackEmailOpen)
+	if err != nil {
+		return
+	}
+
+	if !claims.WebsiteID.Equal(websiteID) {
+		err = emails.ErrTrackingLinkIsNotValid
+		return
+	}
+
+	service.eventsService.TrackEmailOpened(ctx, events.TrackEmailOpenedInput{
+		WebsiteID:    claims.WebsiteID,
+		NewsletterID: claims.NewsletterID,
+		RecipientID:  claims.RecipientID,
+	})
+
+	return nil
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"fmt"
+	"html"
+	"net/url"
+	"regexp"
+
+	"github.com/bloom42/stdx-go/crypto/blake3"
+	"github.com/bloom42/stdx-go/guid"
+	"markdown.ninja/pkg/jwt"
+	"markdown.ninja/pkg/services/emails"
+	"markdown.ninja/pkg/services/websites"
+)
+
+const (
+	jwtActionTrackEmailOpen  = "track_email_open"
+	jwtActionTrackEmailClick = "track_email_click"
+
+	trackEmailOpenPath  = websites.MarkdownNinjaPathPrefix + "/emails/open"
+	trackEmailClickPath = websites.MarkdownNinjaPathPrefix + "/emails/click"
+)
+
+var newsletterLinksRegexp = regexp.MustCompile(`href="(https?://[^"]+)"`)
+
+type jwtClaimsEmailTracking struct {
+	Action       string    `json:"action"`
+	WebsiteID    guid.GUID `json:"website_id"`
+	NewsletterID guid.GUID `json:"newsletter_id"`
+	RecipientID  guid.GUID `json:"recipient_id"`
+	Url          string    `json:"url,omitempty"`
+}
+
+// newsletterRecipientID returns an opaque ID for the recipient of a newsletter so that unique opens and
+// clicks can be counted without storing the ID of the contact in the events.
+func newsletterRecipientID(newsletterID, contactID guid.GUID) guid.GUID {
+	hash := blake3.Sum256(append(newsletterID.Bytes(), contactID.Bytes()...))
+	recipientID, _ := guid.FromBytes(hash[:16])
+	return recipientID
+}
+
+// generateTrackingLink generates a signed link to the open or click tracking endpoint of the website.
+// Tracking links don't expire but are no longer valid once the signing key has been rotated out.
+func (service *EmailsService) generateTrackingLink(websiteDomain, path string, claims jwtClaimsEmailTracking) (link string, err error) {
+	token, err := service.jwtProvider.NewSignedToken(claims, &jwt.TokenOptions{})
+	if err != nil {
+		err = fmt.Errorf("emails: generating tracking token: %w", err)
+		return
+	}
+
+	query := url.Values{}
+	query.Add("token", token)
+
+	linkUrl := url.URL{
+		Scheme:   service.httpConfig.WebsitesBaseUrl.Scheme,
+		Host:     fmt.Sprintf("%s%s", websiteDomain, service.httpConfig.WebsitesPort),
+		Path:     path,
+		RawQuery: query.Encode(),
+	}
+	return linkUrl.String(), nil
+}
+
+func (service *EmailsService) generateTrackingPixelLink(websiteDomain string, websiteID, newsletterID, recipientID guid.GUID) (string, error) {
+	return service.generateTrackingLink(websiteDomain, trackEmailOpenPath, jwtClaimsEmailTracking{
+		Action:       jwtActionTrackEmailOpen,
+		WebsiteID:    websiteID,
+		NewsletterID: newsletterID,
+		RecipientID:  recipientID,
+	})
+}
+
+// rewriteNewsletterLinks rewrites all the http(s) links of contentHtml to go through the click tracking endpoint
+func (service *EmailsService) rewriteNewsletterLinks(contentHtml, websiteDomain string, websiteID, newsletterID, recipientID guid.GUID) (ret string, err error) {
+	ret = newsletterLinksRegexp.ReplaceAllStringFunc(contentHtml, func(match string) string {
+		if err != nil {
+			return match
+		}
+
+		originalUrl := html.UnescapeString(newsletterLinksRegexp.FindStringSubmatch(match)[1])
+		trackingLink, linkErr := service.generateTrackingLink(websiteDomain, trackEmailClickPath, jwtClaimsEmailTracking{
+			Action:       jwtActionTrackEmailClick,
+			WebsiteID:    websiteID,
+			NewsletterID: newsletterID,
+			RecipientID:  recipientID,
+			Url:          originalUrl,
+		})
+		if linkErr != nil {
+			err = linkErr
+			return match
+		}
+
+		return `href="` + html.EscapeString(trackingLink) + `"`
+	})
+	return
+}
+
+func (service *EmailsService) parseAndVerifyTrackingToken(token, action string) (claims jwtClaimsEmailTracking, err error) {
+	err = service.jwtProvider.ParseAndVerifyToken(token, &claims)
+	if err != nil {
+		err = emails.ErrTrackingLinkIsNotValid
+		return
+	}
+
+	if claims.Action != action {
+		err = emails.ErrTrackingLinkIsNotValid
+		return
+	}
+
+	return claims, nil
+}
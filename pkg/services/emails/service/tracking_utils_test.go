@@ -0,0 +1,39 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/bloom42/stdx-go/guid"
+)
+
+func TestNewsletterRecipientID(t *testing.T) {
+	newsletterID := guid.NewTimeBased()
+	contactID := guid.NewTimeBased()
+
+	recipientID := newsletterRecipientID(newsletterID, contactID)
+	if recipientID.IsNil() {
+		t.Error("recipientID is nil")
+	}
+	if !recipientID.Equal(newsletterRecipientID(newsletterID, contactID)) {
+		t.Error("recipientID is not deterministic")
+	}
+	if recipientID.Equal(contactID) {
+		t.Error("recipientID is equal to contactID")
+	}
+	if recipientID.Equal(newsletterRecipientID(guid.NewTimeBased(), contactID)) {
+		t.Error("recipientID should be different for each newsletter")
+	}
+}
+
+func TestNewsletterLinksRegexp(t *testing.T) {
+	contentHtml := `<p><a href="https://example.com/a?b=1&amp;c=2">link</a> <a href="mailto:hello@example.com">mail</a>` +
+		` <a href="/relative">relative</a> <img src="https://example.com/image.png"></p>`
+
+	matches := newsletterLinksRegexp.FindAllStringSubmatch(contentHtml, -1)
+	if len(matches) != 1 {
+		t.Fatalf("expected 1 link, got: %d", len(matches))
+	}
+	if matches[0][1] != "https://example.com/a?b=1&amp;c=2" {
+		t.Errorf("unexpected link: %s", matches[0][1])
+	}
+}
@@ -6,7 +6,9 @@ import (
 	"time"
 
 	"github.com/bloom42/stdx-go/crypto/blake3"
+	"github.com/bloom42/stdx-go/guid"
 	"markdown.ninja/pkg/services/emails"
+	"markdown.ninja/pkg/services/kernel"
 )
 
 // TODO: update website's used storage? See also CreateNewsletter and DeleteNewsletter
@@ -21,7 +23,7 @@ func (service *EmailsService) UpdateNewsletter(ctx context.Context, input emails
 		return
 	}
 
-	err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, newsletter.WebsiteID)
+	err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, newsletter.WebsiteID, kernel.PermissionSendNewsletters)
 	if err != nil {
 		return
 	}
@@ -57,6 +59,22 @@ func (service *EmailsService) UpdateNewsletter(ctx context.Context, input emails
 		return
 	}
 
+	if input.IncludedLabels != nil || input.ExcludedLabels != nil {
+		includedLabelIDs := []guid.GUID(newsletter.IncludedLabels)
+		if input.IncludedLabels != nil {
+			includedLabelIDs = *input.IncludedLabels
+		}
+		excludedLabelIDs := []guid.GUID(newsletter.ExcludedLabels)
+		if input.ExcludedLabels != nil {
+			excludedLabelIDs = *input.ExcludedLabels
+		}
+
+		newsletter.IncludedLabels, newsletter.ExcludedLabels, err = service.resolveNewsletterLabels(ctx, newsletter.WebsiteID, includedLabelIDs, excludedLabelIDs)
+		if err != nil {
+			return
+		}
+	}
+
 	err = service.repo.UpdateNewsletter(ctx, service.db, newsletter)
 	if err != nil {
 		return
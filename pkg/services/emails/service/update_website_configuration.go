@@ -11,6 +11,7 @@ import (
 	"markdown.ninja/pkg/errs"
 	"markdown.ninja/pkg/mailer"
 	"markdown.ninja/pkg/services/emails"
+	"markdown.ninja/pkg/services/kernel"
 	"markdown.ninja/pkg/services/organizations"
 )
 
@@ -21,7 +22,7 @@ func (service *EmailsService) UpdateWebsiteConfiguration(ctx context.Context, in
 	}
 	logger := slogx.FromCtx(ctx)
 
-	err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, input.WebsiteID)
+	err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, input.WebsiteID, kernel.PermissionManageWebsites)
 	if err != nil {
 		return
 	}
@@ -48,6 +49,13 @@ func (service *EmailsService) UpdateWebsiteConfiguration(ctx context.Context, in
 
 	configuration.FromName = fromnName
 
+	if input.TrackOpens != nil {
+		configuration.TrackOpens = *input.TrackOpens
+	}
+	if input.TrackClicks != nil {
+		configuration.TrackClicks = *input.TrackClicks
+	}
+
 	if fromAddress == "" {
 		if configuration.FromDomain != "" {
 			err = service.mailer.RemoveDomain(ctx, configuration.FromDomain)
@@ -7,6 +7,7 @@ import (
 	"github.com/bloom42/stdx-go/log/slogx"
 	"markdown.ninja/pkg/errs"
 	"markdown.ninja/pkg/services/emails"
+	"markdown.ninja/pkg/services/kernel"
 )
 
 func (service *EmailsService) VerifyDnsConfiguration(ctx context.Context, input emails.VerifyDnsConfigurationInput) (configuration emails.WebsiteConfiguration, err error) {
@@ -16,7 +17,7 @@ func (service *EmailsService) VerifyDnsConfiguration(ctx context.Context, input
 		return
 	}
 
-	err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, input.WebsiteID)
+	err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, input.WebsiteID, kernel.PermissionManageWebsites)
 	if err != nil {
 		return
 	}
@@ -12,6 +12,8 @@ type NewsletterEmailData struct {
 	Subject         string
 	Content         template.HTML
 	UnsubscribeLink template.URL
+	// TrackingPixelLink is empty when open tracking is disabled
+	TrackingPixelLink template.URL
 }
 
 // <mjml>
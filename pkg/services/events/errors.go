@@ -0,0 +1,43 @@
+package events
+
+import (
+	"fmt"
+
+	"markdown.ninja/pkg/errs"
+)
+
+var (
+	// Goals
+	ErrGoalNotFound                      = errs.NotFound("Goal not found.")
+	ErrGoalNameIsEmpty                   = errs.InvalidArgument("Goal name is empty.")
+	ErrGoalNameIsTooLong                 = errs.InvalidArgument(fmt.Sprintf("Goal name is too long (max: %d characters)", GoalNameMaxLength))
+	ErrGoalTypeIsNotValid                = errs.InvalidArgument(fmt.Sprintf("Goal type is not valid. Valid values are [%s, %s]", GoalTypeCustomEvent, GoalTypePageView))
+	ErrGoalPathIsNotValid                = errs.InvalidArgument("Goal path is not valid. It must start with /")
+	ErrGoalPathIsTooLong                 = errs.InvalidArgument(fmt.Sprintf("Goal path is too long (max: %d characters)", GoalPathMaxLength))
+	ErrGoalAlreadyExists                 = errs.InvalidArgument("A goal with the same event or page already exists.")
+	ErrGoalsLimitReached                 = errs.InvalidArgument(fmt.Sprintf("Goals limit reached (max: %d per website).", GoalsMaxPerWebsite))
+	ErrCustomEventPropertiesAreTooMany   = errs.InvalidArgument(fmt.Sprintf("Too many properties (max: %d)", CustomEventPropertiesMaxCount))
+	ErrCustomEventPropertyKeyIsNotValid  = errs.InvalidArgument(fmt.Sprintf("Property names must be between 1 and %d characters", CustomEventPropertyKeyMaxSize))
+	ErrCustomEventPropertyValueIsTooLong = errs.InvalidArgument(fmt.Sprintf("Property values must be at most %d characters", CustomEventPropertyValueMaxSize))
+	ErrCustomEventPathIsNotValid         = errs.InvalidArgument(fmt.Sprintf("Path is not valid. It must start with / and be at most %d characters", EventPathMaxLength))
+
+	// Analytics exports
+	ErrAnalyticsExportNotFound         = errs.NotFound("Export not found.")
+	ErrAnalyticsExportIsNotReady       = errs.InvalidArgument("The export is still in progress. Please try again in a few moments.")
+	ErrAnalyticsExportTypeIsNotValid   = errs.InvalidArgument(fmt.Sprintf("Export type is not valid. Valid values are [%s, %s]", AnalyticsExportTypeAggregated, AnalyticsExportTypePageViews))
+	ErrAnalyticsExportFormatIsNotValid = errs.InvalidArgument(fmt.Sprintf("Export format is not valid. Valid values are [%s, %s]", AnalyticsExportFormatCsv, AnalyticsExportFormatJson))
+	ErrAnalyticsExportsLimitReached    = errs.InvalidArgument(fmt.Sprintf("Too many exports in progress (max: %d). Please wait for them to complete.", AnalyticsExportsMaxPending))
+
+	// Analytics reports
+	ErrAnalyticsReportFrequencyIsNotValid = errs.InvalidArgument(fmt.Sprintf("Report frequency is not valid. Valid values are [%s, %s, %s]", AnalyticsReportFrequencyNever, AnalyticsReportFrequencyWeekly, AnalyticsReportFrequencyMonthly))
+
+	// Analytics
+	ErrAnalyticsTimeRangeIsNotValid = errs.InvalidArgument("The end of the time range must be after its start")
+	ErrAnalyticsTimeRangeIsTooLong  = func(maxDays int64) error {
+		return errs.InvalidArgument(fmt.Sprintf("The time range is too long. Max: %d days", maxDays))
+	}
+	ErrAnalyticsGranularityIsNotValid    = errs.InvalidArgument("Granularity is not valid. Valid values are: hour, day, week, month")
+	ErrAnalyticsHourlyTimeRangeIsTooLong = func(maxDays int64) error {
+		return errs.InvalidArgument(fmt.Sprintf("The time range is too long for an hourly granularity. Max: %d days", maxDays))
+	}
+)
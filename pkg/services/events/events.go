@@ -25,6 +25,10 @@ const (
 	EventTypeOrderPlaced
 	EventTypeOrderCanceled
 	EventTypeOrderCompleted
+	EventTypeEmailOpened
+	EventTypeEmailClicked
+	EventTypeEmailBounced
+	EventTypeEmailComplained
 )
 
 // MarshalText implements encoding.TextMarshaler.
@@ -46,6 +50,14 @@ func (eventType EventType) MarshalText() (ret []byte, err error) {
 		ret = []byte("order_completed")
 	case EventTypeOrderCanceled:
 		ret = []byte("order_canceled")
+	case EventTypeEmailOpened:
+		ret = []byte("email_opened")
+	case EventTypeEmailClicked:
+		ret = []byte("email_clicked")
+	case EventTypeEmailBounced:
+		ret = []byte("email_bounced")
+	case EventTypeEmailComplained:
+		ret = []byte("email_complained")
 	default:
 		err = fmt.Errorf("Unknown EventType: %d", eventType)
 	}
@@ -76,6 +88,14 @@ func (eventType *EventType) UnmarshalText(data []byte) (err error) {
 		*eventType = EventTypeOrderCompleted
 	case "order_canceled":
 		*eventType = EventTypeOrderCanceled
+	case "email_opened":
+		*eventType = EventTypeEmailOpened
+	case "email_clicked":
+		*eventType = EventTypeEmailClicked
+	case "email_bounced":
+		*eventType = EventTypeEmailBounced
+	case "email_complained":
+		*eventType = EventTypeEmailComplained
 	default:
 		err = fmt.Errorf("Unknown EventType: %s", string(data))
 	}
@@ -85,7 +105,8 @@ func (eventType *EventType) UnmarshalText(data []byte) (err error) {
 type EventDataPageView struct{}
 
 type EventDataCustom struct {
-	EventName string `json:"event_name"`
+	EventName  string            `json:"event_name"`
+	Properties map[string]string `json:"properties,omitempty"`
 }
 
 type EventDataSubscribedToNewsletter struct {
@@ -99,6 +120,20 @@ type EventDataEmailSent struct {
 	ToAddress   string `json:"to_address"`
 }
 
+type EventDataEmailOpened struct {
+}
+
+type EventDataEmailClicked struct {
+	Url string `json:"url"`
+}
+
+type EventDataEmailBounced struct {
+	Permanent bool `json:"permanent"`
+}
+
+type EventDataEmailComplained struct {
+}
+
 type EventDataOrderPlaced struct {
 }
 
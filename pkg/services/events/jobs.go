@@ -1,6 +1,10 @@
 package events
 
-import "github.com/bloom42/stdx-go/guid"
+import (
+	"time"
+
+	"github.com/bloom42/stdx-go/guid"
+)
 
 type JobDeleteWebsiteEvents struct {
 	WebsiteID guid.GUID `json:"website_id"`
@@ -24,3 +28,46 @@ type JobRotateAnonymousIDSalt struct {
 func (JobRotateAnonymousIDSalt) JobType() string {
 	return "events.rotate_anonymous_id_salt"
 }
+
+type JobRollupPageViews struct {
+}
+
+func (JobRollupPageViews) JobType() string {
+	return "events.rollup_page_views"
+}
+
+type JobExportAnalytics struct {
+	ExportID guid.GUID `json:"export_id"`
+}
+
+func (JobExportAnalytics) JobType() string {
+	return "events.export_analytics"
+}
+
+type JobDeleteExpiredAnalyticsExports struct {
+}
+
+func (JobDeleteExpiredAnalyticsExports) JobType() string {
+	return "events.delete_expired_analytics_exports"
+}
+
+// JobDispatchSendAnalyticsReports pushes a JobSendAnalyticsReport job for each website with the given
+// report frequency.
+type JobDispatchSendAnalyticsReports struct {
+	Frequency AnalyticsReportFrequency `json:"frequency"`
+}
+
+func (JobDispatchSendAnalyticsReports) JobType() string {
+	return "events.dispatch_send_analytics_reports"
+}
+
+type JobSendAnalyticsReport struct {
+	WebsiteID guid.GUID                `json:"website_id"`
+	Frequency AnalyticsReportFrequency `json:"frequency"`
+	From      time.Time                `json:"from"`
+	To        time.Time                `json:"to"`
+}
+
+func (JobSendAnalyticsReport) JobType() string {
+	return "events.send_analytics_report"
+}
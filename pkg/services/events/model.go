@@ -2,6 +2,8 @@ package events
 
 import (
 	"fmt"
+	"net/netip"
+	"net/url"
 	"time"
 
 	"github.com/bloom42/stdx-go/guid"
@@ -12,6 +14,31 @@ type OperatingSystem int32
 
 const (
 	CustomEventNameMaxSize = 42
+	// custom events can have up to CustomEventPropertiesMaxCount properties
+	CustomEventPropertiesMaxCount   = 20
+	CustomEventPropertyKeyMaxSize   = 42
+	CustomEventPropertyValueMaxSize = 256
+	// EventPathMaxLength is the maximum length (in bytes) of the path of custom events
+	EventPathMaxLength = 1024
+
+	// UtmParameterMaxLength is the maximum length (in bytes) of the UTM parameters. Longer values are truncated
+	UtmParameterMaxLength = 200
+
+	GoalNameMaxLength  = 100
+	GoalPathMaxLength  = 1024
+	GoalsMaxPerWebsite = 20
+
+	// exports are deleted after AnalyticsExportRetention
+	AnalyticsExportRetention = 7 * 24 * time.Hour
+	// AnalyticsExportsMaxPending is the maximum number of exports of a website that can be in progress
+	AnalyticsExportsMaxPending = 3
+
+	// visitors are considered as current visitors if they have viewed a page during the last LiveVisitorTimeout
+	LiveVisitorTimeout = 5 * time.Minute
+	// LiveReferrersMaxCount is the number of referrals kept in the live referrers feed
+	LiveReferrersMaxCount = 20
+	// LiveAnalyticsRefreshInterval is the minimum interval between 2 updates of the live analytics
+	LiveAnalyticsRefreshInterval = 2 * time.Second
 )
 
 const (
@@ -179,7 +206,7 @@ func (browser *Browser) UnmarshalText(data []byte) (err error) {
 
 // the number of columns in database that the Event entity has.
 // Used when batching inserts
-const EventDatabaseColumns = 13
+const EventDatabaseColumns = 17
 
 type Event struct {
 	Time time.Time `db:"time" json:"time"`
@@ -203,19 +230,198 @@ type Event struct {
 	OperatingSystem *OperatingSystem `db:"operating_system" json:"operating_system"`
 	Referrer        *string          `db:"referrer" json:"referrer"`
 
+	// the UTM parameters of the page view, or of the page view the event is attributed to
+	UtmSource   *string `db:"utm_source" json:"utm_source"`
+	UtmMedium   *string `db:"utm_medium" json:"utm_medium"`
+	UtmCampaign *string `db:"utm_campaign" json:"utm_campaign"`
+	UtmTerm     *string `db:"utm_term" json:"utm_term"`
+	UtmContent  *string `db:"utm_content" json:"utm_content"`
+
 	WebsiteID    guid.GUID  `db:"website_id" json:"website_id"`
 	AnonymousID  *guid.GUID `db:"anonymous_id" json:"anonymous_id"`
 	OrderID      *guid.GUID `db:"order_id" json:"order_id"`
 	NewsletterID *guid.GUID `db:"newsletter_id" json:"newsletter_id"`
 }
 
-// Query parameters:
-// ref
-// UTM Medium
-// UTM Source
-// UTM Campaign
-// UTM Content
-// UTM Term
+type GoalType string
+
+const (
+	// GoalTypeCustomEvent goals are reached when a visitor triggers the custom event EventName
+	GoalTypeCustomEvent GoalType = "custom_event"
+	// GoalTypePageView goals are reached when a visitor views the page Path
+	GoalTypePageView GoalType = "page_view"
+
+	// built-in goals, available to all the websites
+	GoalTypeNewsletterSubscription GoalType = "newsletter_subscription"
+	GoalTypeOrderCompleted         GoalType = "order_completed"
+)
+
+type Goal struct {
+	ID        guid.GUID `db:"id" json:"id"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+	UpdatedAt time.Time `db:"updated_at" json:"updated_at"`
+
+	Name string   `db:"name" json:"name"`
+	Type GoalType `db:"type" json:"type"`
+	// EventName is only used by custom_event goals
+	EventName string `db:"event_name" json:"event_name"`
+	// Path is only used by page_view goals
+	Path string `db:"path" json:"path"`
+
+	WebsiteID guid.GUID `db:"website_id" json:"website_id"`
+}
+
+type AnalyticsExportType string
+
+const (
+	// AnalyticsExportTypeAggregated exports the number of page views and visitors per day, path, referrer,
+	// country, browser, operating system and campaign.
+	AnalyticsExportTypeAggregated AnalyticsExportType = "aggregated"
+	// AnalyticsExportTypePageViews exports the raw page views, without the anonymous IDs of the visitors
+	AnalyticsExportTypePageViews AnalyticsExportType = "page_views"
+)
+
+type AnalyticsExportFormat string
+
+const (
+	AnalyticsExportFormatCsv  AnalyticsExportFormat = "csv"
+	AnalyticsExportFormatJson AnalyticsExportFormat = "json"
+)
+
+func (format AnalyticsExportFormat) MediaType() string {
+	if format == AnalyticsExportFormatJson {
+		return "application/json"
+	}
+	return "text/csv"
+}
+
+// AnalyticsExport is produced by a background job and stored in the object storage until it expires.
+type AnalyticsExport struct {
+	ID        guid.GUID `db:"id" json:"id"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+	UpdatedAt time.Time `db:"updated_at" json:"updated_at"`
+
+	Type   AnalyticsExportType   `db:"type" json:"type"`
+	Format AnalyticsExportFormat `db:"format" json:"format"`
+	From   time.Time             `db:"from_time" json:"from"`
+	To     time.Time             `db:"to_time" json:"to"`
+	// CompletedAt is nil while the export is in progress
+	CompletedAt *time.Time `db:"completed_at" json:"completed_at"`
+	// Size of the exported file in bytes
+	Size int64 `db:"size" json:"size"`
+
+	WebsiteID guid.GUID `db:"website_id" json:"website_id"`
+}
+
+// Filename returns the name of the file downloaded by users. e.g. analytics_page_views_2025-01-01_2025-01-31.csv
+func (export AnalyticsExport) Filename() string {
+	return fmt.Sprintf("analytics_%s_%s_%s.%s", export.Type, export.From.UTC().Format(time.DateOnly),
+		export.To.UTC().Format(time.DateOnly), export.Format)
+}
+
+// ExportedPageView is a page view as exported. It doesn't contain any personal data.
+type ExportedPageView struct {
+	Time            time.Time        `db:"time" json:"time"`
+	Path            *string          `db:"path" json:"path"`
+	Referrer        *string          `db:"referrer" json:"referrer"`
+	Country         *string          `db:"country" json:"country"`
+	Browser         *Browser         `db:"browser" json:"browser"`
+	OperatingSystem *OperatingSystem `db:"operating_system" json:"operating_system"`
+	UtmSource       *string          `db:"utm_source" json:"utm_source"`
+	UtmMedium       *string          `db:"utm_medium" json:"utm_medium"`
+	UtmCampaign     *string          `db:"utm_campaign" json:"utm_campaign"`
+	UtmTerm         *string          `db:"utm_term" json:"utm_term"`
+	UtmContent      *string          `db:"utm_content" json:"utm_content"`
+}
+
+// ExportedAggregatedPageViews are the page views and visitors of a day for a given path, referrer...
+type ExportedAggregatedPageViews struct {
+	Day             time.Time        `db:"day" json:"day"`
+	Path            *string          `db:"path" json:"path"`
+	Referrer        *string          `db:"referrer" json:"referrer"`
+	Country         *string          `db:"country" json:"country"`
+	Browser         *Browser         `db:"browser" json:"browser"`
+	OperatingSystem *OperatingSystem `db:"operating_system" json:"operating_system"`
+	UtmSource       *string          `db:"utm_source" json:"utm_source"`
+	UtmMedium       *string          `db:"utm_medium" json:"utm_medium"`
+	UtmCampaign     *string          `db:"utm_campaign" json:"utm_campaign"`
+	PageViews       int64            `db:"page_views" json:"page_views"`
+	Visitors        int64            `db:"visitors" json:"visitors"`
+}
+
+type AnalyticsReportFrequency string
+
+const (
+	AnalyticsReportFrequencyNever   AnalyticsReportFrequency = "never"
+	AnalyticsReportFrequencyWeekly  AnalyticsReportFrequency = "weekly"
+	AnalyticsReportFrequencyMonthly AnalyticsReportFrequency = "monthly"
+)
+
+// AnalyticsReportSettings: the staffs of websites with a weekly or monthly frequency receive a digest of the
+// analytics of the previous week (every Monday) or month (on the first day of the month).
+type AnalyticsReportSettings struct {
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+	UpdatedAt time.Time `db:"updated_at" json:"updated_at"`
+
+	Frequency AnalyticsReportFrequency `db:"frequency" json:"frequency"`
+
+	WebsiteID guid.GUID `db:"website_id" json:"website_id"`
+}
+
+// LiveAnalytics are the real-time analytics of a website. They are computed in memory from the page views
+// as they are tracked.
+type LiveAnalytics struct {
+	CurrentVisitors int64 `json:"current_visitors"`
+	// Pages are the pages where the current visitors are, with the number of visitors on each page
+	Pages []Counter `json:"pages"`
+	// Referrers are the most recent visits from another website, most recent first
+	Referrers []LiveReferrer `json:"referrers"`
+}
+
+type LiveReferrer struct {
+	Time     time.Time `json:"time"`
+	Referrer string    `json:"referrer"`
+	Path     string    `json:"path"`
+	Country  string    `json:"country"`
+}
+
+// LivePageView is a page view as broadcasted to all the server instances to compute the live analytics.
+// The anonymous ID of the visitor never leaves the servers.
+type LivePageView struct {
+	Time        time.Time `db:"time" json:"time"`
+	WebsiteID   guid.GUID `db:"website_id" json:"website_id"`
+	AnonymousID guid.GUID `db:"anonymous_id" json:"anonymous_id"`
+	Path        string    `db:"path" json:"path"`
+	Referrer    string    `db:"referrer" json:"referrer"`
+	Country     string    `db:"country" json:"country"`
+}
+
+// UtmParameters are the campaign parameters (utm_source, utm_medium...) of the URL of a page view.
+// Empty parameters are stored as NULL.
+type UtmParameters struct {
+	Source   string `db:"utm_source" json:"utm_source"`
+	Medium   string `db:"utm_medium" json:"utm_medium"`
+	Campaign string `db:"utm_campaign" json:"utm_campaign"`
+	Term     string `db:"utm_term" json:"utm_term"`
+	Content  string `db:"utm_content" json:"utm_content"`
+}
+
+// IsEmpty returns true if the parameters don't identify a campaign. utm_term and utm_content alone are
+// not enough.
+func (utm UtmParameters) IsEmpty() bool {
+	return utm.Source == "" && utm.Medium == "" && utm.Campaign == ""
+}
+
+// UtmParametersFromQuery returns the (raw) UTM parameters of the given URL query
+func UtmParametersFromQuery(query url.Values) UtmParameters {
+	return UtmParameters{
+		Source:   query.Get("utm_source"),
+		Medium:   query.Get("utm_medium"),
+		Campaign: query.Get("utm_campaign"),
+		Term:     query.Get("utm_term"),
+		Content:  query.Get("utm_content"),
+	}
+}
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////
 // Service
@@ -228,11 +434,21 @@ type TrackPageViewInput struct {
 	HeaderReferrer       string
 	HeaderUserAgent      string
 	QueryParameterRef    string
+	Utm                  UtmParameters
 	IsTor                bool
 
 	WebsiteID guid.GUID
 }
 
+type TrackCustomEventInput struct {
+	Name            string
+	Path            string
+	Properties      map[string]string
+	HeaderUserAgent string
+
+	WebsiteID guid.GUID
+}
+
 type TrackEmailSentInput struct {
 	FromAddress string
 	ToAddress   string
@@ -241,7 +457,39 @@ type TrackEmailSentInput struct {
 	NewsletterID *guid.GUID
 }
 
+// TrackEmailOpenedInput and TrackEmailClickedInput use RecipientID, an opaque identifier of the recipient
+// of the email, to count unique opens and clicks. It is stored as the AnonymousID of the event.
+type TrackEmailOpenedInput struct {
+	WebsiteID    guid.GUID
+	NewsletterID guid.GUID
+	RecipientID  guid.GUID
+}
+
+type TrackEmailClickedInput struct {
+	Url string
+
+	WebsiteID    guid.GUID
+	NewsletterID guid.GUID
+	RecipientID  guid.GUID
+}
+
+type TrackEmailBouncedInput struct {
+	Permanent bool
+
+	WebsiteID    guid.GUID
+	NewsletterID *guid.GUID
+}
+
+type TrackEmailComplainedInput struct {
+	WebsiteID    guid.GUID
+	NewsletterID *guid.GUID
+}
+
+// TrackSubscribedToNewsletterInput: IpAddress and UserAgent are optional. When they are set, they identify
+// the visitor who subscribed so the subscription can be attributed to the campaign they came from.
 type TrackSubscribedToNewsletterInput struct {
+	IpAddress netip.Addr
+	UserAgent string
 	WebsiteID guid.GUID
 }
 
@@ -250,6 +498,7 @@ type TrackUnsubscribedFromNewsletterInput struct {
 }
 
 type TrackOrderPlacedInput struct {
+	IpAddress netip.Addr
 	UserAgent string
 	Country   string
 	OrderID   guid.GUID
@@ -282,11 +531,72 @@ type TrackOrderCanceledInput struct {
 // Headers              http.Header
 // }
 
+type AnalyticsGranularity string
+
+const (
+	AnalyticsGranularityHour  AnalyticsGranularity = "hour"
+	AnalyticsGranularityDay   AnalyticsGranularity = "day"
+	AnalyticsGranularityWeek  AnalyticsGranularity = "week"
+	AnalyticsGranularityMonth AnalyticsGranularity = "month"
+)
+
+const (
+	AnalyticsDefaultRange = 30 * 24 * time.Hour
+	AnalyticsMaxRange     = 2 * 366 * 24 * time.Hour
+	// AnalyticsHourlyMaxRange is the longest time range that can be requested with an hourly granularity
+	AnalyticsHourlyMaxRange = 7 * 24 * time.Hour
+)
+
+// AnalyticsReferrerDirect is the label of the visits without a referrer
+const AnalyticsReferrerDirect = "(direct)"
+
+// AnalyticsFilters restrict the page views used to compute the analytics.
+// They don't apply to the newsletters and emails statistics.
+type AnalyticsFilters struct {
+	Path            *string          `json:"path"`
+	Referrer        *string          `json:"referrer"`
+	Country         *string          `json:"country"`
+	Browser         *Browser         `json:"browser"`
+	OperatingSystem *OperatingSystem `json:"operating_system"`
+	UtmSource       *string          `json:"utm_source"`
+	UtmMedium       *string          `json:"utm_medium"`
+	UtmCampaign     *string          `json:"utm_campaign"`
+}
+
+// IsEmpty returns true if no filter is set
+func (filters AnalyticsFilters) IsEmpty() bool {
+	return filters.Path == nil && filters.Referrer == nil && filters.Country == nil && filters.Browser == nil &&
+		filters.OperatingSystem == nil && filters.UtmSource == nil && filters.UtmMedium == nil &&
+		filters.UtmCampaign == nil
+}
+
+// GetAnalyticsInput: if From is empty, it defaults to 30 days before To, and if To is empty it defaults
+// to now. The default Granularity depends on the length of the time range.
 type GetAnalyticsInput struct {
-	WebsiteID guid.GUID `json:"website_id"`
+	WebsiteID   guid.GUID             `json:"website_id"`
+	From        *time.Time            `json:"from"`
+	To          *time.Time            `json:"to"`
+	Granularity *AnalyticsGranularity `json:"granularity"`
+	// Compare returns the page views and visitors of the previous period of the same length
+	Compare bool             `json:"compare"`
+	Filters AnalyticsFilters `json:"filters"`
+}
+
+// PageViewsQuery selects the page views used to compute analytics, between From (inclusive) and To (exclusive).
+// Page views before RollupsEnd are read from the daily rollups, and the following ones from the raw events.
+type PageViewsQuery struct {
+	WebsiteID  guid.GUID
+	From       time.Time
+	To         time.Time
+	RollupsEnd time.Time
+	Filters    AnalyticsFilters
 }
 
 type AnalyticsData struct {
+	From        time.Time            `json:"from"`
+	To          time.Time            `json:"to"`
+	Granularity AnalyticsGranularity `json:"granularity"`
+
 	TotalPageViews int64                    `json:"total_page_views"`
 	PageViews      []Counter                `json:"page_views"`
 	TotalVisitors  int64                    `json:"total_visitors"`
@@ -297,6 +607,68 @@ type AnalyticsData struct {
 	Browsers       []CounterBrowser         `json:"browsers"`
 	OSes           []CounterOperatingSystem `json:"oses"`
 	NewSubscribers int64                    `json:"new_subscribers"`
+	Newsletters    []NewsletterStats        `json:"newsletters"`
+	EmailLinks     []Counter                `json:"email_links"`
+
+	Goals []GoalStats `json:"goals"`
+
+	UtmSources   []Counter       `json:"utm_sources"`
+	UtmMediums   []Counter       `json:"utm_mediums"`
+	UtmCampaigns []Counter       `json:"utm_campaigns"`
+	Campaigns    []CampaignStats `json:"campaigns"`
+
+	Previous *AnalyticsComparison `json:"previous"`
+}
+
+// GoalStats are the conversions of a goal.
+// Conversions is the number of unique visitors who reached the goal, except for the built-in goals which
+// are not tied to visitors and for which it is the number of subscriptions / completed orders.
+// ConversionRate is relative to the total number of visitors, regardless of the filters.
+type GoalStats struct {
+	// GoalID is nil for built-in goals
+	GoalID         *guid.GUID `json:"goal_id"`
+	Name           string     `json:"name"`
+	Type           GoalType   `json:"type"`
+	Conversions    int64      `json:"conversions"`
+	ConversionRate float64    `json:"conversion_rate"`
+}
+
+// CampaignStats are the visitors of a campaign (a combination of utm_source, utm_medium and utm_campaign),
+// and the subscriptions, completed orders and revenue attributed to it.
+// Revenue is the total amount of the completed orders, in the currency of the website.
+type CampaignStats struct {
+	Source        string `db:"utm_source" json:"utm_source"`
+	Medium        string `db:"utm_medium" json:"utm_medium"`
+	Campaign      string `db:"utm_campaign" json:"utm_campaign"`
+	Visitors      int64  `db:"visitors" json:"visitors"`
+	Subscriptions int64  `db:"subscriptions" json:"subscriptions"`
+	Orders        int64  `db:"orders" json:"orders"`
+	Revenue       int64  `db:"revenue" json:"revenue"`
+}
+
+// AnalyticsComparison contains the page views and visitors of the period preceding the requested time range
+type AnalyticsComparison struct {
+	From           time.Time `json:"from"`
+	To             time.Time `json:"to"`
+	TotalPageViews int64     `json:"total_page_views"`
+	PageViews      []Counter `json:"page_views"`
+	TotalVisitors  int64     `json:"total_visitors"`
+	Visitors       []Counter `json:"visitors"`
+}
+
+// NewsletterStats are the email statistics of a newsletter.
+// Opens and Clicks are the number of unique recipients who opened the newsletter / clicked on a link.
+type NewsletterStats struct {
+	NewsletterID  guid.GUID `db:"newsletter_id" json:"newsletter_id"`
+	Sent          int64     `db:"sent" json:"sent"`
+	Opens         int64     `db:"opens" json:"opens"`
+	Clicks        int64     `db:"clicks" json:"clicks"`
+	Bounces       int64     `db:"bounces" json:"bounces"`
+	Complaints    int64     `db:"complaints" json:"complaints"`
+	OpenRate      float64   `db:"-" json:"open_rate"`
+	ClickRate     float64   `db:"-" json:"click_rate"`
+	BounceRate    float64   `db:"-" json:"bounce_rate"`
+	ComplaintRate float64   `db:"-" json:"complaint_rate"`
 }
 
 type Counter struct {
@@ -305,11 +677,16 @@ type Counter struct {
 }
 
 type PageViewsAndVisitors struct {
-	Day       time.Time `db:"day"`
+	Time      time.Time `db:"time"`
 	PageViews int64     `db:"page_views"`
 	Visitors  int64     `db:"visitors"`
 }
 
+type PageViewsAndVisitorsTotals struct {
+	PageViews int64 `db:"page_views"`
+	Visitors  int64 `db:"visitors"`
+}
+
 type CounterOperatingSystem struct {
 	Label OperatingSystem `db:"label" json:"label"`
 	Count int64           `db:"count" json:"count"`
@@ -319,3 +696,55 @@ type CounterBrowser struct {
 	Label Browser `db:"label" json:"label"`
 	Count int64   `db:"count" json:"count"`
 }
+
+type CreateGoalInput struct {
+	WebsiteID guid.GUID `json:"website_id"`
+	Name      string    `json:"name"`
+	Type      GoalType  `json:"type"`
+	EventName string    `json:"event_name"`
+	Path      string    `json:"path"`
+}
+
+type UpdateGoalInput struct {
+	ID        guid.GUID `json:"id"`
+	Name      *string   `json:"name"`
+	EventName *string   `json:"event_name"`
+	Path      *string   `json:"path"`
+}
+
+type DeleteGoalInput struct {
+	ID guid.GUID `json:"id"`
+}
+
+type ListGoalsInput struct {
+	WebsiteID guid.GUID `json:"website_id"`
+}
+
+type CreateAnalyticsExportInput struct {
+	WebsiteID guid.GUID             `json:"website_id"`
+	Type      AnalyticsExportType   `json:"type"`
+	Format    AnalyticsExportFormat `json:"format"`
+	From      time.Time             `json:"from"`
+	To        time.Time             `json:"to"`
+}
+
+type ListAnalyticsExportsInput struct {
+	WebsiteID guid.GUID `json:"website_id"`
+}
+
+type DownloadAnalyticsExportInput struct {
+	ID guid.GUID `json:"id"`
+}
+
+type GetAnalyticsReportSettingsInput struct {
+	WebsiteID guid.GUID `json:"website_id"`
+}
+
+type UpdateAnalyticsReportSettingsInput struct {
+	WebsiteID guid.GUID                `json:"website_id"`
+	Frequency AnalyticsReportFrequency `json:"frequency"`
+}
+
+type StreamLiveAnalyticsInput struct {
+	WebsiteID guid.GUID `json:"website_id"`
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"slices"
+	"strconv"
 	"time"
 
 	"github.com/bloom42/stdx-go/db"
@@ -61,88 +63,79 @@ import (
 //
 // GROUP BY day, e.website_id
 // ORDER BY day;
-func (repo *EventsRepository) GetPageViewsAndVisitors(ctx context.Context, db db.Queryer, websiteID guid.GUID,
-	from, to time.Time) (ret []events.PageViewsAndVisitors, err error) {
+func (repo *EventsRepository) GetPageViewsAndVisitors(ctx context.Context, db db.Queryer, query events.PageViewsQuery,
+	granularity events.AnalyticsGranularity) (ret []events.PageViewsAndVisitors, err error) {
 	ret = []events.PageViewsAndVisitors{}
 
-	cacheKey := fmt.Sprintf("PageViewsAndVisitors-%s-%d-%d", websiteID.String(), from.Unix(), to.Unix())
+	cacheKey := pageViewsQueryCacheKey("PageViewsAndVisitors-"+string(granularity), query)
 	cacheRes := repo.cache.Get(cacheKey)
 	if cacheRes != nil {
 		return cacheRes.Value().([]events.PageViewsAndVisitors), nil
 	}
 
-	// const query = `
-	// WITH intervals AS (
-	// 	SELECT generate_series(
-	// 		date_trunc('day', $2::timestamp with time zone),
-	// 		date_trunc('day', $3::timestamp with time zone),
-	// 		'1 day'::interval
-	// 	) AS time_start
-	// )
-	// SELECT
-	// 	time_bucket('1 day', COALESCE(events.time, intervals.time_start)) as day,
-	// 	COALESCE(COUNT(events), 0) AS page_views,
-	// 	COALESCE(COUNT(DISTINCT events.anonymous_id), 0) AS visitors
-	// FROM intervals
-	// LEFT OUTER JOIN events ON time_bucket('1 day', events.time) = intervals.time_start
-	// 	AND events.website_id = $1
-	// 	AND events.type = $4
-	// GROUP BY day, events.website_id
-	// ORDER BY day;
-	// 	`
-
-	// 	const query = `
-	// WITH days AS (
-	// 	SELECT generate_series(
-	// 		date_trunc('day', $2::timestamp with time zone),
-	// 		date_trunc('day', $3::timestamp with time zone),
-	// 		'1 day'::interval
-	// 	) AS time_start
-	// ),
-	// events AS (
-	// 	SELECT time, anonymous_id FROM events WHERE events.time >= $2 AND events.time <= $3
-	// 		AND website_id = $1 AND type = $4
-	// )
-	// SELECT time_bucket('1 day', COALESCE(events.time, days.time_start)) as day,
-	// 		COALESCE(COUNT(events), 0) AS page_views,
-	// 		COALESCE(COUNT(DISTINCT events.anonymous_id), 0) AS visitors
-	// 	FROM days
-	// 	LEFT OUTER JOIN events ON time_bucket('1 day', events.time) = days.time_start
-	// 	GROUP BY day
-	// 	ORDER BY day;
-	// `
-
-	// performance can be further improved with hyperLogLog: https://docs.timescale.com/api/latest/hyperfunctions/approximate-count-distinct/hyperloglog
-	// requires TimescaleDB Toolkit
-	const query = `
-WITH time_range AS (
+	timeUnit := string(events.AnalyticsGranularityDay)
+	if granularity == events.AnalyticsGranularityHour {
+		timeUnit = string(events.AnalyticsGranularityHour)
+	}
+	cte, args := pageViewsCte(query, rollupDimensionTotal, timeUnit)
+	args = append(args, string(granularity), "1 "+string(granularity))
+	granularityArg := len(args) - 1
+	intervalArg := len(args)
+
+	// anonymous IDs are rotated every day so visitors can't be tracked across days, and the daily
+	// visitors can be summed.
+	sqlQuery := fmt.Sprintf(`
+WITH %s,
+buckets AS (
 	SELECT generate_series(
-		date_trunc('day', $3::timestamp with time zone),
-		date_trunc('day', $4::timestamp with time zone),
-		'1 day'::interval
-	) AS time_start
+		$2::TIMESTAMP WITH TIME ZONE,
+		$3::TIMESTAMP WITH TIME ZONE - '1 microsecond'::INTERVAL,
+		$%[3]d::INTERVAL
+	) AS time
 ),
-events AS (
-	SELECT time_bucket('1 day', time) AS event_day,
-		COALESCE(COUNT(*), 0) AS page_views,
-		anonymous_id AS visitors
-	FROM events
-	WHERE time >= $3 AND time <= $4
-		AND type = $1 AND website_id = $2
-	GROUP BY event_day, anonymous_id
+page_views_by_bucket AS (
+	SELECT date_trunc($%[2]d, time, 'UTC') AS time,
+		SUM(page_views) AS page_views,
+		SUM(visitors) AS visitors
+	FROM page_views
+	GROUP BY 1
 )
-SELECT COALESCE(events.event_day, time_range.time_start) as day,
-	COALESCE(SUM(events.page_views), 0) AS page_views,
-	COALESCE(COUNT(events.visitors), 0) AS visitors
-FROM time_range
-LEFT OUTER JOIN events ON events.event_day = time_range.time_start
-GROUP BY day
-ORDER BY day
+SELECT buckets.time,
+	COALESCE(page_views_by_bucket.page_views, 0)::BIGINT AS page_views,
+	COALESCE(page_views_by_bucket.visitors, 0)::BIGINT AS visitors
+FROM buckets
+LEFT OUTER JOIN page_views_by_bucket ON page_views_by_bucket.time = buckets.time
+ORDER BY buckets.time
+`, cte, granularityArg, intervalArg)
+
+	err = db.Select(ctx, &ret, sqlQuery, args...)
+	if err != nil {
+		err = fmt.Errorf("events.GetPageViewsAndVisitors: %w", err)
+		return
+	}
+
+	repo.cache.Set(cacheKey, ret, 2*time.Minute)
+
+	return
+}
+
+func (repo *EventsRepository) GetPageViewsAndVisitorsTotals(ctx context.Context, db db.Queryer,
+	query events.PageViewsQuery) (ret events.PageViewsAndVisitorsTotals, err error) {
+	cacheKey := pageViewsQueryCacheKey("PageViewsAndVisitorsTotals", query)
+	cacheRes := repo.cache.Get(cacheKey)
+	if cacheRes != nil {
+		return cacheRes.Value().(events.PageViewsAndVisitorsTotals), nil
+	}
+
+	cte, args := pageViewsCte(query, rollupDimensionTotal, string(events.AnalyticsGranularityDay))
+	sqlQuery := `WITH ` + cte + `
+	SELECT COALESCE(SUM(page_views), 0)::BIGINT AS page_views, COALESCE(SUM(visitors), 0)::BIGINT AS visitors
+	FROM page_views
 `
 
-	err = db.Select(ctx, &ret, query, events.EventTypePageView, websiteID, from, to)
+	err = db.Get(ctx, &ret, sqlQuery, args...)
 	if err != nil {
-		err = fmt.Errorf("events.GetPageViewsAndVisitors: %w", err)
+		err = fmt.Errorf("events.GetPageViewsAndVisitorsTotals: %w", err)
 		return
 	}
 
@@ -152,51 +145,64 @@ ORDER BY day
 }
 
 // if limit < 1 then no limit
-func (repo *EventsRepository) GetTopPages(ctx context.Context, db db.Queryer, websiteID guid.GUID,
-	from, to time.Time, limit int64) (ret []events.Counter, err error) {
-	ret = make([]events.Counter, 0, max(limit, 10))
+func (repo *EventsRepository) GetTopPages(ctx context.Context, db db.Queryer, query events.PageViewsQuery,
+	limit int64) (ret []events.Counter, err error) {
+	return getTopPageViewsDimension[events.Counter](ctx, repo, db, "TopPages", "path", query, limit)
+}
+
+// if limit < 1 then no limit
+func (repo *EventsRepository) GetTopCountries(ctx context.Context, db db.Queryer, query events.PageViewsQuery,
+	limit int64) (ret []events.Counter, err error) {
+	return getTopPageViewsDimension[events.Counter](ctx, repo, db, "TopCountries", "country", query, limit)
+}
+
+// if limit < 1 then no limit
+func (repo *EventsRepository) GetTopReferrers(ctx context.Context, db db.Queryer, query events.PageViewsQuery,
+	limit int64) (ret []events.Counter, err error) {
+	return getTopPageViewsDimension[events.Counter](ctx, repo, db, "TopReferrers", "referrer", query, limit)
+}
+
+// if limit < 1 then no limit
+func (repo *EventsRepository) GetTopBrowsers(ctx context.Context, db db.Queryer, query events.PageViewsQuery,
+	limit int64) (ret []events.CounterBrowser, err error) {
+	return getTopPageViewsDimension[events.CounterBrowser](ctx, repo, db, "TopBrowsers", "browser", query, limit)
+}
+
+// if limit < 1 then no limit
+func (repo *EventsRepository) GetTopOses(ctx context.Context, db db.Queryer, query events.PageViewsQuery,
+	limit int64) (ret []events.CounterOperatingSystem, err error) {
+	return getTopPageViewsDimension[events.CounterOperatingSystem](ctx, repo, db, "TopOses", "operating_system", query, limit)
+}
+
+// getTopPageViewsDimension returns the non-NULL values of column with the most visitors.
+// column is never user input.
+func getTopPageViewsDimension[T any](ctx context.Context, repo *EventsRepository, db db.Queryer, name, column string,
+	query events.PageViewsQuery, limit int64) (ret []T, err error) {
+	ret = make([]T, 0, max(limit, 10))
 	if limit < 1 {
 		limit = math.MaxInt64
 	}
 
-	cacheKey := fmt.Sprintf("TopPages-%s-%d-%d-%d", websiteID.String(), from.Unix(), to.Unix(), limit)
+	cacheKey := pageViewsQueryCacheKey(fmt.Sprintf("%s-%d", name, limit), query)
 	cacheRes := repo.cache.Get(cacheKey)
 	if cacheRes != nil {
-		return cacheRes.Value().([]events.Counter), nil
+		return cacheRes.Value().([]T), nil
 	}
-	// const query = `SELECT (data->>'page')::TEXT AS label, COUNT(DISTINCT anonymous_id) AS count
-	// 	FROM events
-	// 	WHERE website_id = $1 AND time >= $2 AND time <= $3 AND type = $5
-	// 	GROUP BY label
-	// 	ORDER BY count DESC
-	// 	LIMIT $4
-	// `
-	// const query = `
-	// 	SELECT label, COUNT(anonymous_id) FROM (
-	// 		SELECT (data->>'page')::TEXT AS label, anonymous_id
-	// 			FROM events
-	// 			WHERE time >= $2 AND time <= $3 AND website_id = $1 AND type = $5
-	// 			GROUP BY label, anonymous_id
-	// 	) AS subquery
-	// 	GROUP BY label
-	// 	ORDER BY count DESC
-	// 	LIMIT $4
-	// `
-	const query = `
-	SELECT label, COUNT(anonymous_id) FROM (
-		SELECT path AS label, anonymous_id
-			FROM events
-			WHERE time >= $2 AND time <= $3 AND website_id = $1 AND type = $5
-			GROUP BY label, anonymous_id
-	) AS subquery
+
+	cte, args := pageViewsCte(query, column, string(events.AnalyticsGranularityDay))
+	args = append(args, limit)
+	sqlQuery := fmt.Sprintf(`WITH %s
+	SELECT %[2]s AS label, SUM(visitors)::BIGINT AS count
+	FROM page_views
+	WHERE %[2]s IS NOT NULL
 	GROUP BY label
 	ORDER BY count DESC
-	LIMIT $4
-`
+	LIMIT $%[3]d
+`, cte, column, len(args))
 
-	err = db.Select(ctx, &ret, query, websiteID, from, to, limit, events.EventTypePageView)
+	err = db.Select(ctx, &ret, sqlQuery, args...)
 	if err != nil {
-		err = fmt.Errorf("events.GetTopPages: %w", err)
+		err = fmt.Errorf("events.Get%s: %w", name, err)
 		return
 	}
 
@@ -206,52 +212,49 @@ func (repo *EventsRepository) GetTopPages(ctx context.Context, db db.Queryer, we
 }
 
 // if limit < 1 then no limit
-func (repo *EventsRepository) GetTopCountries(ctx context.Context, db db.Queryer, websiteID guid.GUID,
-	from, to time.Time, limit int64) (ret []events.Counter, err error) {
-	ret = make([]events.Counter, 0, max(limit, 10))
-	if limit < 1 {
-		limit = math.MaxInt64
+func (repo *EventsRepository) GetTopUtmSources(ctx context.Context, db db.Queryer, query events.PageViewsQuery,
+	limit int64) (ret []events.Counter, err error) {
+	return getTopPageViewsDimension[events.Counter](ctx, repo, db, "TopUtmSources", "utm_source", query, limit)
+}
+
+// if limit < 1 then no limit
+func (repo *EventsRepository) GetTopUtmMediums(ctx context.Context, db db.Queryer, query events.PageViewsQuery,
+	limit int64) (ret []events.Counter, err error) {
+	return getTopPageViewsDimension[events.Counter](ctx, repo, db, "TopUtmMediums", "utm_medium", query, limit)
+}
+
+// if limit < 1 then no limit
+func (repo *EventsRepository) GetTopUtmCampaigns(ctx context.Context, db db.Queryer, query events.PageViewsQuery,
+	limit int64) (ret []events.Counter, err error) {
+	return getTopPageViewsDimension[events.Counter](ctx, repo, db, "TopUtmCampaigns", "utm_campaign", query, limit)
+}
+
+// GetPagesVisitors returns the number of unique visitors of each of the given paths
+func (repo *EventsRepository) GetPagesVisitors(ctx context.Context, db db.Queryer, query events.PageViewsQuery,
+	paths []string) (ret []events.Counter, err error) {
+	ret = make([]events.Counter, 0, len(paths))
+	if len(paths) == 0 {
+		return
 	}
 
-	cacheKey := fmt.Sprintf("TopCountries-%s-%d-%d-%d", websiteID.String(), from.Unix(), to.Unix(), limit)
+	cacheKey := pageViewsQueryCacheKey(fmt.Sprintf("PagesVisitors-%q", paths), query)
 	cacheRes := repo.cache.Get(cacheKey)
 	if cacheRes != nil {
 		return cacheRes.Value().([]events.Counter), nil
 	}
 
-	// const query = `SELECT (data->>'country_code')::TEXT AS label, COUNT(DISTINCT anonymous_id) AS count
-	// 	FROM events
-	// 	WHERE website_id = $1 AND time >= $2 AND time <= $3 AND type = $5
-	// 	GROUP BY label
-	// 	ORDER BY count DESC
-	// 	LIMIT $4
-	// `
-	// const query = `
-	// 	SELECT label, COUNT(anonymous_id) FROM (
-	// 		SELECT (data->>'country_code')::TEXT AS label, anonymous_id
-	// 			FROM events
-	// 			WHERE time >= $2 AND time <= $3 AND website_id = $1 AND type = $5
-	// 			GROUP BY label, anonymous_id
-	// 	) AS subquery
-	// 	GROUP BY label
-	// 	ORDER BY count DESC
-	// 	LIMIT $4
-	// `
-	const query = `
-		SELECT label, COUNT(anonymous_id) FROM (
-			SELECT country AS label, anonymous_id
-				FROM events
-				WHERE time >= $2 AND time <= $3 AND website_id = $1 AND type = $5
-				GROUP BY label, anonymous_id
-		) AS subquery
-		GROUP BY label
-		ORDER BY count DESC
-		LIMIT $4
-	`
+	cte, args := pageViewsCte(query, "path", string(events.AnalyticsGranularityDay))
+	args = append(args, paths)
+	sqlQuery := fmt.Sprintf(`WITH %s
+	SELECT path AS label, SUM(visitors)::BIGINT AS count
+	FROM page_views
+	WHERE path = ANY($%d)
+	GROUP BY label
+`, cte, len(args))
 
-	err = db.Select(ctx, &ret, query, websiteID, from, to, limit, events.EventTypePageView)
+	err = db.Select(ctx, &ret, sqlQuery, args...)
 	if err != nil {
-		err = fmt.Errorf("events.GetTopCountries: %w", err)
+		err = fmt.Errorf("events.GetPagesVisitors: %w", err)
 		return
 	}
 
@@ -260,53 +263,30 @@ func (repo *EventsRepository) GetTopCountries(ctx context.Context, db db.Queryer
 	return
 }
 
-// if limit < 1 then no limit
-func (repo *EventsRepository) GetTopReferrers(ctx context.Context, db db.Queryer, websiteID guid.GUID,
-	from, to time.Time, limit int64) (ret []events.Counter, err error) {
-	ret = make([]events.Counter, 0, max(limit, 10))
-	if limit < 1 {
-		limit = math.MaxInt64
+// GetCustomEventsVisitors returns the number of unique visitors who triggered each of the given custom events
+func (repo *EventsRepository) GetCustomEventsVisitors(ctx context.Context, db db.Queryer, websiteID guid.GUID,
+	from, to time.Time, eventNames []string) (ret []events.Counter, err error) {
+	ret = make([]events.Counter, 0, len(eventNames))
+	if len(eventNames) == 0 {
+		return
 	}
 
-	cacheKey := fmt.Sprintf("TopReferrers-%s-%d-%d-%d", websiteID.String(), from.Unix(), to.Unix(), limit)
+	cacheKey := fmt.Sprintf("CustomEventsVisitors-%s-%d-%d-%q", websiteID.String(), from.Unix(), to.Unix(),
+		eventNames)
 	cacheRes := repo.cache.Get(cacheKey)
 	if cacheRes != nil {
 		return cacheRes.Value().([]events.Counter), nil
 	}
 
-	// const query = `SELECT (data->>'referrer')::TEXT AS label, COUNT(DISTINCT anonymous_id) AS count
-	// 	FROM events
-	// 	WHERE website_id = $1 AND time >= $2 AND time <= $3 AND type = $5
-	// 	GROUP BY label
-	// 	ORDER BY count DESC
-	// 	LIMIT $4
-	// `
-	// const query = `
-	// 	SELECT label, COUNT(anonymous_id) FROM (
-	// 		SELECT (data->>'referrer')::TEXT AS label, anonymous_id
-	// 			FROM events
-	// 			WHERE time >= $2 AND time <= $3 AND website_id = $1 AND type = $5
-	// 			GROUP BY label, anonymous_id
-	// 	) AS subquery
-	// 	GROUP BY label
-	// 	ORDER BY count DESC
-	// 	LIMIT $4
-	// `
-	const query = `
-		SELECT label, COUNT(anonymous_id) FROM (
-			SELECT referrer AS label, anonymous_id
-				FROM events
-				WHERE time >= $2 AND time <= $3 AND website_id = $1 AND type = $5
-				GROUP BY label, anonymous_id
-		) AS subquery
+	const query = `SELECT data->>'event_name' AS label, COUNT(DISTINCT anonymous_id) AS count
+		FROM events
+		WHERE website_id = $1 AND time >= $2 AND time < $3 AND type = $4 AND data->>'event_name' = ANY($5)
 		GROUP BY label
-		ORDER BY count DESC
-		LIMIT $4
 	`
 
-	err = db.Select(ctx, &ret, query, websiteID, from, to, limit, events.EventTypePageView)
+	err = db.Select(ctx, &ret, query, websiteID, from, to, events.EventTypeCustom, eventNames)
 	if err != nil {
-		err = fmt.Errorf("events.GetTopReferrers: %w", err)
+		err = fmt.Errorf("events.GetCustomEventsVisitors: %w", err)
 		return
 	}
 
@@ -315,98 +295,170 @@ func (repo *EventsRepository) GetTopReferrers(ctx context.Context, db db.Queryer
 	return
 }
 
-// if limit < 1 then no limit
-func (repo *EventsRepository) GetTopBrowsers(ctx context.Context, db db.Queryer, websiteID guid.GUID,
-	from, to time.Time, limit int64) (ret []events.CounterBrowser, err error) {
-	ret = make([]events.CounterBrowser, 0, max(limit, 10))
-	if limit < 1 {
-		limit = math.MaxInt64
+// pageViewsCte returns a common table expression named page_views containing the number of page views and
+// of unique visitors selected by query for each day (or hour) and each value of the columns of dimension.
+// The columns of page_views that are not part of dimension are NULL. Its arguments start at $1.
+// Page views before query.RollupsEnd are read from the daily rollups and the following ones from the raw
+// events. As anonymous IDs are rotated every day, the rows can be summed to get the visitors of longer periods.
+// Rollups only contain unfiltered page views, so filtered queries only read the raw events.
+// timeUnit is either "day" or "hour". It must be "day" if rollups are used.
+func pageViewsCte(query events.PageViewsQuery, dimension string, timeUnit string) (cte string, args []any) {
+	rollupsEnd := query.RollupsEnd
+	if !query.Filters.IsEmpty() {
+		rollupsEnd = query.From
 	}
 
-	cacheKey := fmt.Sprintf("TopBrowsers-%s-%d-%d-%d", websiteID.String(), from.Unix(), to.Unix(), limit)
-	cacheRes := repo.cache.Get(cacheKey)
-	if cacheRes != nil {
-		return cacheRes.Value().([]events.CounterBrowser), nil
-	}
-
-	// const query = `SELECT (data->>'browser')::TEXT AS label, COUNT(DISTINCT anonymous_id) AS count
-	// 	FROM events
-	// 	WHERE website_id = $1 AND time >= $2 AND time <= $3 AND type = $5
-	// 	GROUP BY label
-	// 	ORDER BY count DESC
-	// 	LIMIT $4
-	// `
-
-	// const query = `
-	// 	SELECT label, COUNT(anonymous_id) FROM (
-	// 		SELECT (data->>'browser')::TEXT AS label, anonymous_id
-	// 			FROM events
-	// 			WHERE time >= $2 AND time <= $3 AND website_id = $1 AND type = $5
-	// 			GROUP BY label, anonymous_id
-	// 	) AS subquery
-	// 	GROUP BY label
-	// 	ORDER BY count DESC
-	// 	LIMIT $4
-	// `
-	const query = `
-	SELECT label, COUNT(anonymous_id) FROM (
-		SELECT browser AS label, anonymous_id
-			FROM events
-			WHERE time >= $2 AND time <= $3 AND website_id = $1 AND type = $5
-			GROUP BY label, anonymous_id
-	) AS subquery
-	GROUP BY label
-	ORDER BY count DESC
-	LIMIT $4
-`
+	args = []any{query.WebsiteID, query.From, query.To, rollupsEnd, events.EventTypePageView, dimension, timeUnit}
+	filters := ""
+	addFilter := func(column string, value any) {
+		args = append(args, value)
+		filters += fmt.Sprintf(" AND %s = $%d", column, len(args))
+	}
+
+	if query.Filters.Path != nil {
+		addFilter("path", *query.Filters.Path)
+	}
+	if query.Filters.Referrer != nil {
+		addFilter("referrer", *query.Filters.Referrer)
+	}
+	if query.Filters.Country != nil {
+		addFilter("country", *query.Filters.Country)
+	}
+	if query.Filters.Browser != nil {
+		addFilter("browser", int32(*query.Filters.Browser))
+	}
+	if query.Filters.OperatingSystem != nil {
+		addFilter("operating_system", int32(*query.Filters.OperatingSystem))
+	}
+	if query.Filters.UtmSource != nil {
+		addFilter("utm_source", *query.Filters.UtmSource)
+	}
+	if query.Filters.UtmMedium != nil {
+		addFilter("utm_medium", *query.Filters.UtmMedium)
+	}
+	if query.Filters.UtmCampaign != nil {
+		addFilter("utm_campaign", *query.Filters.UtmCampaign)
+	}
+
+	dimensionColumns := rollupDimensionColumns(dimension)
+	rollupsColumns := ""
+	eventsColumns := ""
+	for _, column := range pageViewsColumns {
+		rollupsColumns += column.Name + ", "
+		if slices.Contains(dimensionColumns, column.Name) {
+			eventsColumns += column.Name + ", "
+		} else {
+			eventsColumns += fmt.Sprintf("NULL::%s AS %s, ", column.SqlType, column.Name)
+		}
+	}
+
+	cte = `page_views AS (
+	SELECT day AS time, ` + rollupsColumns + `page_views, visitors
+		FROM events_daily_rollups
+		WHERE website_id = $1 AND dimension = $6 AND day >= $2::TIMESTAMP WITH TIME ZONE
+			AND day < LEAST($3::TIMESTAMP WITH TIME ZONE, $4::TIMESTAMP WITH TIME ZONE)
+	UNION ALL
+	SELECT date_trunc($7, time, 'UTC') AS time, ` + eventsColumns + `
+			COUNT(*) AS page_views, COUNT(DISTINCT anonymous_id) AS visitors
+		FROM events
+		WHERE website_id = $1 AND type = $5
+			AND time >= GREATEST($2::TIMESTAMP WITH TIME ZONE, $4::TIMESTAMP WITH TIME ZONE)
+			AND time < $3::TIMESTAMP WITH TIME ZONE` + filters + `
+		GROUP BY 1, 2, 3, 4, 5, 6, 7, 8, 9
+)`
+
+	return
+}
+
+func pageViewsQueryCacheKey(prefix string, query events.PageViewsQuery) string {
+	cacheKey := fmt.Sprintf("%s-%s-%d-%d-%d", prefix, query.WebsiteID.String(), query.From.Unix(), query.To.Unix(),
+		query.RollupsEnd.Unix())
+
+	if query.Filters.Path != nil {
+		cacheKey += "-path:" + strconv.Quote(*query.Filters.Path)
+	}
+	if query.Filters.Referrer != nil {
+		cacheKey += "-referrer:" + strconv.Quote(*query.Filters.Referrer)
+	}
+	if query.Filters.Country != nil {
+		cacheKey += "-country:" + strconv.Quote(*query.Filters.Country)
+	}
+	if query.Filters.Browser != nil {
+		cacheKey += fmt.Sprintf("-browser:%d", *query.Filters.Browser)
+	}
+	if query.Filters.OperatingSystem != nil {
+		cacheKey += fmt.Sprintf("-os:%d", *query.Filters.OperatingSystem)
+	}
+	if query.Filters.UtmSource != nil {
+		cacheKey += "-utm_source:" + strconv.Quote(*query.Filters.UtmSource)
+	}
+	if query.Filters.UtmMedium != nil {
+		cacheKey += "-utm_medium:" + strconv.Quote(*query.Filters.UtmMedium)
+	}
+	if query.Filters.UtmCampaign != nil {
+		cacheKey += "-utm_campaign:" + strconv.Quote(*query.Filters.UtmCampaign)
+	}
+
+	return cacheKey
+}
+
+func (repo *EventsRepository) GetNewSubscribersCount(ctx context.Context, db db.Queryer, websiteID guid.GUID, from, to time.Time) (newSubscribersCount int64, err error) {
+	return repo.GetEventsCount(ctx, db, websiteID, events.EventTypeSubscribedToNewsletter, from, to)
+}
 
-	err = db.Select(ctx, &ret, query, websiteID, from, to, limit, events.EventTypePageView)
+func (repo *EventsRepository) GetEventsCount(ctx context.Context, db db.Queryer, websiteID guid.GUID,
+	eventType events.EventType, from, to time.Time) (count int64, err error) {
+	cacheKey := fmt.Sprintf("EventsCount-%s-%d-%d-%d", websiteID.String(), eventType, from.Unix(), to.Unix())
+	if cacheRes := repo.cache.Get(cacheKey); cacheRes != nil {
+		return cacheRes.Value().(int64), nil
+	}
+
+	const query = `SELECT COUNT(*)
+		FROM events
+		WHERE website_id = $1 AND time >= $2 AND time < $3 AND type = $4`
+
+	err = db.Get(ctx, &count, query, websiteID, from, to, eventType)
 	if err != nil {
-		err = fmt.Errorf("events.GetTopBrowsers: %w", err)
+		err = fmt.Errorf("events.GetEventsCount: %w", err)
 		return
 	}
 
-	repo.cache.Set(cacheKey, ret, 2*time.Minute)
+	repo.cache.Set(cacheKey, count, 2*time.Minute)
 
 	return
 }
 
-// if limit < 1 then no limit
-func (repo *EventsRepository) GetTopOses(ctx context.Context, db db.Queryer, websiteID guid.GUID,
-	from, to time.Time, limit int64) (ret []events.CounterOperatingSystem, err error) {
-	ret = make([]events.CounterOperatingSystem, 0, max(limit, 10))
-	if limit < 1 {
-		limit = math.MaxInt64
-	}
+// GetNewslettersStats returns the email statistics of the newsletters sent between from (inclusive) and to (exclusive).
+// Opens also include the recipients who clicked on a link because some email clients block the tracking pixel.
+func (repo *EventsRepository) GetNewslettersStats(ctx context.Context, db db.Queryer, websiteID guid.GUID,
+	from, to time.Time) (ret []events.NewsletterStats, err error) {
+	ret = make([]events.NewsletterStats, 0)
 
-	cacheKey := fmt.Sprintf("TopOses-%s-%d-%d-%d", websiteID.String(), from.Unix(), to.Unix(), limit)
+	cacheKey := fmt.Sprintf("NewslettersStats-%s-%d-%d", websiteID.String(), from.Unix(), to.Unix())
 	cacheRes := repo.cache.Get(cacheKey)
 	if cacheRes != nil {
-		return cacheRes.Value().([]events.CounterOperatingSystem), nil
+		return cacheRes.Value().([]events.NewsletterStats), nil
 	}
 
-	// const query = `SELECT (data->>'os')::TEXT AS label, COUNT(DISTINCT anonymous_id) AS count
-	// 	FROM events
-	// 	WHERE website_id = $1 AND time >= $2 AND time <= $3 AND type = $5
-	// 	GROUP BY label
-	// 	ORDER BY count DESC
-	// 	LIMIT $4
-	// `
 	const query = `
-		SELECT label, COUNT(anonymous_id) FROM (
-			SELECT operating_system AS label, anonymous_id
-				FROM events
-				WHERE time >= $2 AND time <= $3 AND website_id = $1 AND type = $5
-				GROUP BY label, anonymous_id
-		) AS subquery
-		GROUP BY label
-		ORDER BY count DESC
-		LIMIT $4
+		SELECT newsletter_id,
+			COUNT(*) FILTER (WHERE type = $4) AS sent,
+			COUNT(DISTINCT anonymous_id) FILTER (WHERE type = $5 OR type = $6) AS opens,
+			COUNT(DISTINCT anonymous_id) FILTER (WHERE type = $6) AS clicks,
+			COUNT(*) FILTER (WHERE type = $7) AS bounces,
+			COUNT(*) FILTER (WHERE type = $8) AS complaints
+		FROM events
+		WHERE website_id = $1 AND time >= $2 AND time < $3 AND newsletter_id IS NOT NULL
+		GROUP BY newsletter_id
+		HAVING COUNT(*) FILTER (WHERE type = $4) > 0
+		ORDER BY MIN(time) DESC
 	`
 
-	err = db.Select(ctx, &ret, query, websiteID, from, to, limit, events.EventTypePageView)
+	err = db.Select(ctx, &ret, query, websiteID, from, to,
+		events.EventTypeEmailSent, events.EventTypeEmailOpened, events.EventTypeEmailClicked,
+		events.EventTypeEmailBounced, events.EventTypeEmailComplained)
 	if err != nil {
-		err = fmt.Errorf("events.GetTopOses: %w", err)
+		err = fmt.Errorf("events.GetNewslettersStats: %w", err)
 		return
 	}
 
@@ -415,23 +467,35 @@ func (repo *EventsRepository) GetTopOses(ctx context.Context, db db.Queryer, web
 	return
 }
 
-func (repo *EventsRepository) GetNewSubscribersCount(ctx context.Context, db db.Queryer, websiteID guid.GUID, from, to time.Time) (newSubscribersCount int64, err error) {
-	cacheKey := fmt.Sprintf("NewSubscribersCount-%s-%d-%d", websiteID.String(), from.Unix(), to.Unix())
-	if cacheRes := repo.cache.Get(cacheKey); cacheRes != nil {
-		return cacheRes.Value().(int64), nil
+// if limit < 1 then no limit
+func (repo *EventsRepository) GetTopEmailLinks(ctx context.Context, db db.Queryer, websiteID guid.GUID,
+	from, to time.Time, limit int64) (ret []events.Counter, err error) {
+	ret = make([]events.Counter, 0, max(limit, 10))
+	if limit < 1 {
+		limit = math.MaxInt64
 	}
 
-	const query = `SELECT COUNT(*)
+	cacheKey := fmt.Sprintf("TopEmailLinks-%s-%d-%d-%d", websiteID.String(), from.Unix(), to.Unix(), limit)
+	cacheRes := repo.cache.Get(cacheKey)
+	if cacheRes != nil {
+		return cacheRes.Value().([]events.Counter), nil
+	}
+
+	const query = `SELECT (data->>'url')::TEXT AS label, COUNT(DISTINCT anonymous_id) AS count
 		FROM events
-		WHERE website_id = $1 AND time >= $2 AND time <= $3 AND type = $4`
+		WHERE website_id = $1 AND time >= $2 AND time < $3 AND type = $5
+		GROUP BY label
+		ORDER BY count DESC
+		LIMIT $4
+	`
 
-	err = db.Get(ctx, &newSubscribersCount, query, websiteID, from, to, events.EventTypeSubscribedToNewsletter)
+	err = db.Select(ctx, &ret, query, websiteID, from, to, limit, events.EventTypeEmailClicked)
 	if err != nil {
-		err = fmt.Errorf("events.GetNewSubscribersCount: %w", err)
+		err = fmt.Errorf("events.GetTopEmailLinks: %w", err)
 		return
 	}
 
-	repo.cache.Set(cacheKey, newSubscribersCount, 2*time.Minute)
+	repo.cache.Set(cacheKey, ret, 2*time.Minute)
 
 	return
 }
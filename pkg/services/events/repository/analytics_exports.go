@@ -0,0 +1,197 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/bloom42/stdx-go/db"
+	"github.com/bloom42/stdx-go/guid"
+	"markdown.ninja/pkg/services/events"
+)
+
+func (repo *EventsRepository) CreateAnalyticsExport(ctx context.Context, db db.Queryer, export events.AnalyticsExport) (err error) {
+	const query = `INSERT INTO analytics_exports
+			(id, created_at, updated_at, type, format, from_time, to_time, completed_at, size, website_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
+
+	_, err = db.Exec(ctx, query, export.ID, export.CreatedAt, export.UpdatedAt, export.Type, export.Format,
+		export.From, export.To, export.CompletedAt, export.Size, export.WebsiteID)
+	if err != nil {
+		err = fmt.Errorf("events.CreateAnalyticsExport: %w", err)
+		return
+	}
+
+	return
+}
+
+func (repo *EventsRepository) UpdateAnalyticsExport(ctx context.Context, db db.Queryer, export events.AnalyticsExport) (err error) {
+	const query = `UPDATE analytics_exports
+		SET updated_at = $1, completed_at = $2, size = $3
+		WHERE id = $4`
+
+	_, err = db.Exec(ctx, query, export.UpdatedAt, export.CompletedAt, export.Size, export.ID)
+	if err != nil {
+		err = fmt.Errorf("events.UpdateAnalyticsExport: %w", err)
+		return
+	}
+
+	return
+}
+
+func (repo *EventsRepository) DeleteAnalyticsExport(ctx context.Context, db db.Queryer, exportID guid.GUID) (err error) {
+	const query = `DELETE FROM analytics_exports WHERE id = $1`
+
+	_, err = db.Exec(ctx, query, exportID)
+	if err != nil {
+		err = fmt.Errorf("events.DeleteAnalyticsExport: %w", err)
+		return
+	}
+
+	return
+}
+
+func (repo *EventsRepository) FindAnalyticsExportByID(ctx context.Context, db db.Queryer, exportID guid.GUID) (export events.AnalyticsExport, err error) {
+	const query = "SELECT * FROM analytics_exports WHERE id = $1"
+
+	err = db.Get(ctx, &export, query, exportID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			err = events.ErrAnalyticsExportNotFound
+		} else {
+			err = fmt.Errorf("events.FindAnalyticsExportByID: %w", err)
+		}
+		return
+	}
+
+	return
+}
+
+func (repo *EventsRepository) FindAnalyticsExportsForWebsite(ctx context.Context, db db.Queryer, websiteID guid.GUID) (exports []events.AnalyticsExport, err error) {
+	exports = []events.AnalyticsExport{}
+	const query = `SELECT * FROM analytics_exports
+		WHERE website_id = $1
+		ORDER BY id DESC`
+
+	err = db.Select(ctx, &exports, query, websiteID)
+	if err != nil {
+		err = fmt.Errorf("events.FindAnalyticsExportsForWebsite: %w", err)
+		return
+	}
+
+	return
+}
+
+func (repo *EventsRepository) GetPendingAnalyticsExportsCountForWebsite(ctx context.Context, db db.Queryer, websiteID guid.GUID) (count int64, err error) {
+	const query = `SELECT COUNT(*) FROM analytics_exports
+		WHERE website_id = $1 AND completed_at IS NULL`
+
+	err = db.Get(ctx, &count, query, websiteID)
+	if err != nil {
+		err = fmt.Errorf("events.GetPendingAnalyticsExportsCountForWebsite: %w", err)
+		return
+	}
+
+	return
+}
+
+func (repo *EventsRepository) FindAnalyticsExportsCreatedBefore(ctx context.Context, db db.Queryer, before time.Time) (exports []events.AnalyticsExport, err error) {
+	exports = []events.AnalyticsExport{}
+	const query = `SELECT * FROM analytics_exports WHERE created_at < $1`
+
+	err = db.Select(ctx, &exports, query, before)
+	if err != nil {
+		err = fmt.Errorf("events.FindAnalyticsExportsCreatedBefore: %w", err)
+		return
+	}
+
+	return
+}
+
+// ExportPageViews calls fn for each page view of the website between from (inclusive) and to (exclusive),
+// ordered by time.
+func (repo *EventsRepository) ExportPageViews(ctx context.Context, db db.Queryer, websiteID guid.GUID,
+	from, to time.Time, fn func(pageView events.ExportedPageView) error) (err error) {
+	const query = `SELECT time, path, referrer, country, browser, operating_system,
+			utm_source, utm_medium, utm_campaign, utm_term, utm_content
+		FROM events
+		WHERE website_id = $1 AND time >= $2 AND time < $3 AND type = $4
+		ORDER BY time`
+
+	rows, err := db.Query(ctx, query, websiteID, from, to, events.EventTypePageView)
+	if err != nil {
+		err = fmt.Errorf("events.ExportPageViews: %w", err)
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var pageView events.ExportedPageView
+		err = rows.Scan(&pageView.Time, &pageView.Path, &pageView.Referrer, &pageView.Country,
+			&pageView.Browser, &pageView.OperatingSystem, &pageView.UtmSource, &pageView.UtmMedium,
+			&pageView.UtmCampaign, &pageView.UtmTerm, &pageView.UtmContent)
+		if err != nil {
+			err = fmt.Errorf("events.ExportPageViews: scanning row: %w", err)
+			return
+		}
+
+		err = fn(pageView)
+		if err != nil {
+			return
+		}
+	}
+
+	err = rows.Err()
+	if err != nil {
+		err = fmt.Errorf("events.ExportPageViews: %w", err)
+		return
+	}
+
+	return
+}
+
+// ExportAggregatedPageViews calls fn for each combination of day, path, referrer, country, browser,
+// operating system and campaign of the page views selected by query, ordered by day.
+func (repo *EventsRepository) ExportAggregatedPageViews(ctx context.Context, db db.Queryer, query events.PageViewsQuery,
+	fn func(row events.ExportedAggregatedPageViews) error) (err error) {
+	cte, args := pageViewsCte(query, rollupDimensionAll, string(events.AnalyticsGranularityDay))
+	sqlQuery := `WITH ` + cte + `
+	SELECT date_trunc('day', time, 'UTC') AS day, path, referrer, country, browser, operating_system,
+		utm_source, utm_medium, utm_campaign,
+		SUM(page_views)::BIGINT AS page_views, SUM(visitors)::BIGINT AS visitors
+	FROM page_views
+	GROUP BY 1, 2, 3, 4, 5, 6, 7, 8, 9
+	ORDER BY day
+`
+
+	rows, err := db.Query(ctx, sqlQuery, args...)
+	if err != nil {
+		err = fmt.Errorf("events.ExportAggregatedPageViews: %w", err)
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row events.ExportedAggregatedPageViews
+		err = rows.Scan(&row.Day, &row.Path, &row.Referrer, &row.Country, &row.Browser, &row.OperatingSystem,
+			&row.UtmSource, &row.UtmMedium, &row.UtmCampaign, &row.PageViews, &row.Visitors)
+		if err != nil {
+			err = fmt.Errorf("events.ExportAggregatedPageViews: scanning row: %w", err)
+			return
+		}
+
+		err = fn(row)
+		if err != nil {
+			return
+		}
+	}
+
+	err = rows.Err()
+	if err != nil {
+		err = fmt.Errorf("events.ExportAggregatedPageViews: %w", err)
+		return
+	}
+
+	return
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/bloom42/stdx-go/db"
+	"github.com/bloom42/stdx-go/guid"
+	"markdown.ninja/pkg/services/events"
+)
+
+// FindAnalyticsReportSettings returns the report settings of the website, and nil if the website has no
+// settings.
+func (repo *EventsRepository) FindAnalyticsReportSettings(ctx context.Context, db db.Queryer, websiteID guid.GUID) (settings *events.AnalyticsReportSettings, err error) {
+	const query = "SELECT * FROM analytics_report_settings WHERE website_id = $1"
+
+	settings = new(events.AnalyticsReportSettings)
+	err = db.Get(ctx, settings, query, websiteID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("events.FindAnalyticsReportSettings: %w", err)
+	}
+
+	return
+}
+
+func (repo *EventsRepository) UpsertAnalyticsReportSettings(ctx context.Context, db db.Queryer, settings events.AnalyticsReportSettings) (err error) {
+	const query = `INSERT INTO analytics_report_settings
+			(website_id, created_at, updated_at, frequency)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (website_id) DO UPDATE
+			SET updated_at = EXCLUDED.updated_at, frequency = EXCLUDED.frequency`
+
+	_, err = db.Exec(ctx, query, settings.WebsiteID, settings.CreatedAt, settings.UpdatedAt, settings.Frequency)
+	if err != nil {
+		err = fmt.Errorf("events.UpsertAnalyticsReportSettings: %w", err)
+		return
+	}
+
+	return
+}
+
+func (repo *EventsRepository) FindWebsiteIDsWithAnalyticsReportFrequency(ctx context.Context, db db.Queryer,
+	frequency events.AnalyticsReportFrequency) (websiteIDs []guid.GUID, err error) {
+	websiteIDs = []guid.GUID{}
+	const query = `SELECT website_id FROM analytics_report_settings WHERE frequency = $1`
+
+	err = db.Select(ctx, &websiteIDs, query, frequency)
+	if err != nil {
+		err = fmt.Errorf("events.FindWebsiteIDsWithAnalyticsReportFrequency: %w", err)
+		return
+	}
+
+	return
+}
+
+// GetRevenue returns the total amount of the orders completed between from (inclusive) and to (exclusive)
+func (repo *EventsRepository) GetRevenue(ctx context.Context, db db.Queryer, websiteID guid.GUID,
+	from, to time.Time) (revenue int64, err error) {
+	const query = `SELECT COALESCE(SUM((data->>'total_amount')::BIGINT), 0)::BIGINT
+		FROM events
+		WHERE website_id = $1 AND time >= $2 AND time < $3 AND type = $4`
+
+	err = db.Get(ctx, &revenue, query, websiteID, from, to, events.EventTypeOrderCompleted)
+	if err != nil {
+		err = fmt.Errorf("events.GetRevenue: %w", err)
+		return
+	}
+
+	return
+}
@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/bloom42/stdx-go/db"
+	"github.com/bloom42/stdx-go/guid"
+	"markdown.ninja/pkg/services/events"
+)
+
+// GetCampaignsStats returns the campaigns with the most visitors, with the subscriptions and completed orders
+// attributed to them.
+// if limit < 1 then no limit
+func (repo *EventsRepository) GetCampaignsStats(ctx context.Context, db db.Queryer, query events.PageViewsQuery,
+	limit int64) (ret []events.CampaignStats, err error) {
+	ret = make([]events.CampaignStats, 0, max(limit, 10))
+	if limit < 1 {
+		limit = math.MaxInt64
+	}
+
+	cacheKey := pageViewsQueryCacheKey(fmt.Sprintf("CampaignsStats-%d", limit), query)
+	cacheRes := repo.cache.Get(cacheKey)
+	if cacheRes != nil {
+		return cacheRes.Value().([]events.CampaignStats), nil
+	}
+
+	cte, args := pageViewsCte(query, rollupDimensionCampaign, string(events.AnalyticsGranularityDay))
+	args = append(args, events.EventTypeSubscribedToNewsletter, events.EventTypeOrderCompleted, limit)
+	subscribedArg := len(args) - 2
+	orderCompletedArg := len(args) - 1
+	limitArg := len(args)
+
+	sqlQuery := fmt.Sprintf(`WITH %s,
+campaigns_visitors AS (
+	SELECT COALESCE(utm_source, '') AS utm_source, COALESCE(utm_medium, '') AS utm_medium,
+		COALESCE(utm_campaign, '') AS utm_campaign, SUM(visitors)::BIGINT AS visitors
+	FROM page_views
+	WHERE COALESCE(utm_source, utm_medium, utm_campaign) IS NOT NULL
+	GROUP BY 1, 2, 3
+),
+campaigns_conversions AS (
+	SELECT COALESCE(utm_source, '') AS utm_source, COALESCE(utm_medium, '') AS utm_medium,
+		COALESCE(utm_campaign, '') AS utm_campaign,
+		COUNT(*) FILTER (WHERE type = $%[2]d) AS subscriptions,
+		COUNT(*) FILTER (WHERE type = $%[3]d) AS orders,
+		COALESCE(SUM((data->>'total_amount')::BIGINT) FILTER (WHERE type = $%[3]d), 0) AS revenue
+	FROM events
+	WHERE website_id = $1 AND time >= $2 AND time < $3 AND (type = $%[2]d OR type = $%[3]d)
+		AND COALESCE(utm_source, utm_medium, utm_campaign) IS NOT NULL
+	GROUP BY 1, 2, 3
+)
+SELECT utm_source, utm_medium, utm_campaign,
+	COALESCE(campaigns_visitors.visitors, 0) AS visitors,
+	COALESCE(campaigns_conversions.subscriptions, 0) AS subscriptions,
+	COALESCE(campaigns_conversions.orders, 0) AS orders,
+	COALESCE(campaigns_conversions.revenue, 0)::BIGINT AS revenue
+FROM campaigns_visitors
+FULL OUTER JOIN campaigns_conversions USING (utm_source, utm_medium, utm_campaign)
+ORDER BY visitors DESC, revenue DESC
+LIMIT $%[4]d
+`, cte, subscribedArg, orderCompletedArg, limitArg)
+
+	err = db.Select(ctx, &ret, sqlQuery, args...)
+	if err != nil {
+		err = fmt.Errorf("events.GetCampaignsStats: %w", err)
+		return
+	}
+
+	repo.cache.Set(cacheKey, ret, 2*time.Minute)
+
+	return
+}
+
+// FindVisitorUtmParameters returns the UTM parameters of the last page view of the visitor that has some,
+// since the given time, or nil if there is none.
+func (repo *EventsRepository) FindVisitorUtmParameters(ctx context.Context, db db.Queryer, websiteID,
+	anonymousID guid.GUID, since time.Time) (ret *events.UtmParameters, err error) {
+	const query = `SELECT COALESCE(utm_source, '') AS utm_source, COALESCE(utm_medium, '') AS utm_medium,
+			COALESCE(utm_campaign, '') AS utm_campaign, COALESCE(utm_term, '') AS utm_term,
+			COALESCE(utm_content, '') AS utm_content
+		FROM events
+		WHERE website_id = $1 AND anonymous_id = $2 AND time >= $3 AND type = $4
+			AND COALESCE(utm_source, utm_medium, utm_campaign) IS NOT NULL
+		ORDER BY time DESC
+		LIMIT 1
+	`
+
+	ret = new(events.UtmParameters)
+	err = db.Get(ctx, ret, query, websiteID, anonymousID, since, events.EventTypePageView)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("events.FindVisitorUtmParameters: %w", err)
+	}
+
+	return
+}
+
+// FindOrderUtmParameters returns the UTM parameters of the campaign the order was attributed to when it was
+// placed, or nil if there is none.
+func (repo *EventsRepository) FindOrderUtmParameters(ctx context.Context, db db.Queryer, websiteID,
+	orderID guid.GUID) (ret *events.UtmParameters, err error) {
+	const query = `SELECT COALESCE(utm_source, '') AS utm_source, COALESCE(utm_medium, '') AS utm_medium,
+			COALESCE(utm_campaign, '') AS utm_campaign, COALESCE(utm_term, '') AS utm_term,
+			COALESCE(utm_content, '') AS utm_content
+		FROM events
+		WHERE website_id = $1 AND order_id = $2 AND type = $3
+			AND COALESCE(utm_source, utm_medium, utm_campaign) IS NOT NULL
+		LIMIT 1
+	`
+
+	ret = new(events.UtmParameters)
+	err = db.Get(ctx, ret, query, websiteID, orderID, events.EventTypeOrderPlaced)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("events.FindOrderUtmParameters: %w", err)
+	}
+
+	return
+}
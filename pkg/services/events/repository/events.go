@@ -18,10 +18,11 @@ import (
 func (repo *EventsRepository) SaveEvents(ctx context.Context, db db.Queryer, eventsInput []events.Event) error {
 	const query = `INSERT INTO events
 		(time, type, data, website_id, anonymous_id, order_id, newsletter_id,
-			path, country, browser, operating_system, referrer)
+			path, country, browser, operating_system, referrer,
+			utm_source, utm_medium, utm_campaign, utm_term, utm_content)
 		SELECT * FROM UNNEST($1::TIMESTAMP WITH TIME ZONE[], $2::BIGINT[], $3::JSONB[], $4::UUID[],
 			$5::UUID[], $6::UUID[], $7::UUID[], $8::TEXT[], $9::TEXT[], $10::INT[],
-			$11::INT[], $12::TEXT[]
+			$11::INT[], $12::TEXT[], $13::TEXT[], $14::TEXT[], $15::TEXT[], $16::TEXT[], $17::TEXT[]
 		)`
 	var err error
 
@@ -66,9 +67,25 @@ func (repo *EventsRepository) SaveEvents(ctx context.Context, db db.Queryer, eve
 	referrers := slices.AppendSeq(make([]*string, 0, len(eventsInput)), iterx.Map(slices.Values(eventsInput), func(event events.Event) *string {
 		return event.Referrer
 	}))
+	utmSources := slices.AppendSeq(make([]*string, 0, len(eventsInput)), iterx.Map(slices.Values(eventsInput), func(event events.Event) *string {
+		return event.UtmSource
+	}))
+	utmMediums := slices.AppendSeq(make([]*string, 0, len(eventsInput)), iterx.Map(slices.Values(eventsInput), func(event events.Event) *string {
+		return event.UtmMedium
+	}))
+	utmCampaigns := slices.AppendSeq(make([]*string, 0, len(eventsInput)), iterx.Map(slices.Values(eventsInput), func(event events.Event) *string {
+		return event.UtmCampaign
+	}))
+	utmTerms := slices.AppendSeq(make([]*string, 0, len(eventsInput)), iterx.Map(slices.Values(eventsInput), func(event events.Event) *string {
+		return event.UtmTerm
+	}))
+	utmContents := slices.AppendSeq(make([]*string, 0, len(eventsInput)), iterx.Map(slices.Values(eventsInput), func(event events.Event) *string {
+		return event.UtmContent
+	}))
 
 	_, err = db.Exec(ctx, query, times, types, data, websiteIds, anonymousIDs, orderIDs, newsletterIDs,
-		paths, countries, browsers, operatingSystems, referrers)
+		paths, countries, browsers, operatingSystems, referrers,
+		utmSources, utmMediums, utmCampaigns, utmTerms, utmContents)
 	if err != nil {
 		return fmt.Errorf("events.SaveEvent: error inserting events: %w", err)
 	}
@@ -114,6 +131,7 @@ func (repo *EventsRepository) SaveEvents(ctx context.Context, db db.Queryer, eve
 
 func (repo *EventsRepository) DeleteWebsiteEvents(ctx context.Context, db db.Queryer, websiteID guid.GUID) (err error) {
 	const query = "DELETE FROM events WHERE website_id = $1"
+	const rollupsQuery = "DELETE FROM events_daily_rollups WHERE website_id = $1"
 
 	_, err = db.Exec(ctx, query, websiteID)
 	if err != nil {
@@ -121,11 +139,18 @@ func (repo *EventsRepository) DeleteWebsiteEvents(ctx context.Context, db db.Que
 		return
 	}
 
+	_, err = db.Exec(ctx, rollupsQuery, websiteID)
+	if err != nil {
+		err = fmt.Errorf("events.DeleteWebsiteEvent: deleting rollups: %w", err)
+		return
+	}
+
 	return
 }
 
 func (repo *EventsRepository) DeleteOrganizationEvents(ctx context.Context, db db.Queryer, organizationID guid.GUID) (err error) {
 	const query = "DELETE FROM events WHERE website_id = ANY(SELECT id FROM websites WHERE organization_id = $1)"
+	const rollupsQuery = "DELETE FROM events_daily_rollups WHERE website_id = ANY(SELECT id FROM websites WHERE organization_id = $1)"
 
 	_, err = db.Exec(ctx, query, organizationID)
 	if err != nil {
@@ -133,6 +158,12 @@ func (repo *EventsRepository) DeleteOrganizationEvents(ctx context.Context, db d
 		return
 	}
 
+	_, err = db.Exec(ctx, rollupsQuery, organizationID)
+	if err != nil {
+		err = fmt.Errorf("events.DeleteWebsiteEvent: deleting rollups: %w", err)
+		return
+	}
+
 	return
 }
 
@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/bloom42/stdx-go/db"
+	"github.com/bloom42/stdx-go/guid"
+	"markdown.ninja/pkg/services/events"
+)
+
+func (repo *EventsRepository) CreateGoal(ctx context.Context, db db.Queryer, goal events.Goal) (err error) {
+	const query = `INSERT INTO goals
+			(id, created_at, updated_at, name, type, event_name, path, website_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+
+	_, err = db.Exec(ctx, query, goal.ID, goal.CreatedAt, goal.UpdatedAt, goal.Name, goal.Type, goal.EventName,
+		goal.Path, goal.WebsiteID)
+	if err != nil {
+		err = fmt.Errorf("events.CreateGoal: %w", err)
+		return
+	}
+
+	return
+}
+
+func (repo *EventsRepository) UpdateGoal(ctx context.Context, db db.Queryer, goal events.Goal) (err error) {
+	const query = `UPDATE goals
+		SET updated_at = $1, name = $2, event_name = $3, path = $4
+		WHERE id = $5`
+
+	_, err = db.Exec(ctx, query, goal.UpdatedAt, goal.Name, goal.EventName, goal.Path, goal.ID)
+	if err != nil {
+		err = fmt.Errorf("events.UpdateGoal: %w", err)
+		return
+	}
+
+	return
+}
+
+func (repo *EventsRepository) DeleteGoal(ctx context.Context, db db.Queryer, goalID guid.GUID) (err error) {
+	const query = `DELETE FROM goals WHERE id = $1`
+
+	_, err = db.Exec(ctx, query, goalID)
+	if err != nil {
+		err = fmt.Errorf("events.DeleteGoal: %w", err)
+		return
+	}
+
+	return
+}
+
+func (repo *EventsRepository) FindGoalByID(ctx context.Context, db db.Queryer, goalID guid.GUID) (goal events.Goal, err error) {
+	const query = "SELECT * FROM goals WHERE id = $1"
+
+	err = db.Get(ctx, &goal, query, goalID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			err = events.ErrGoalNotFound
+		} else {
+			err = fmt.Errorf("events.FindGoalByID: %w", err)
+		}
+		return
+	}
+
+	return
+}
+
+func (repo *EventsRepository) FindGoalsForWebsite(ctx context.Context, db db.Queryer, websiteID guid.GUID) (goals []events.Goal, err error) {
+	goals = []events.Goal{}
+	const query = `SELECT * FROM goals
+		WHERE website_id = $1
+		ORDER BY id`
+
+	err = db.Select(ctx, &goals, query, websiteID)
+	if err != nil {
+		err = fmt.Errorf("events.FindGoalsForWebsite: %w", err)
+		return
+	}
+
+	return
+}
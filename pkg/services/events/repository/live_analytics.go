@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/bloom42/stdx-go/db"
+	"github.com/bloom42/stdx-go/guid"
+	"markdown.ninja/pkg/services/events"
+)
+
+// NotifyLivePageViews sends the payloads to all the server instances listening on the channel.
+// Notifications are only delivered when (and if) the current transaction commits.
+func (repo *EventsRepository) NotifyLivePageViews(ctx context.Context, db db.Queryer, channel string, payloads []string) (err error) {
+	const query = `SELECT pg_notify($1, payload) FROM UNNEST($2::TEXT[]) AS payload`
+
+	_, err = db.Exec(ctx, query, channel, payloads)
+	if err != nil {
+		err = fmt.Errorf("events.NotifyLivePageViews: %w", err)
+		return
+	}
+
+	return
+}
+
+// FindLivePageViews returns the page views of the website since the given time, oldest first.
+func (repo *EventsRepository) FindLivePageViews(ctx context.Context, db db.Queryer, websiteID guid.GUID, since time.Time) (pageViews []events.LivePageView, err error) {
+	pageViews = []events.LivePageView{}
+	const query = `SELECT time, website_id, anonymous_id, COALESCE(path, '') AS path,
+			COALESCE(referrer, '') AS referrer, COALESCE(country, '') AS country
+		FROM events
+		WHERE website_id = $1 AND type = $2 AND time >= $3 AND anonymous_id IS NOT NULL
+		ORDER BY time`
+
+	err = db.Select(ctx, &pageViews, query, websiteID, events.EventTypePageView, since)
+	if err != nil {
+		err = fmt.Errorf("events.FindLivePageViews: %w", err)
+		return
+	}
+
+	return
+}
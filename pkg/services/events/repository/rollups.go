@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/bloom42/stdx-go/db"
+	"markdown.ninja/pkg/services/events"
+)
+
+// The rollups store the number of page views and of unique visitors per day and per website for several
+// dimensions (see migrations/2025/0073.up.sql). Anonymous IDs are not stored: as they are rotated
+// every day, the daily numbers of unique visitors can be summed over several days.
+const (
+	// all the page views of the website
+	rollupDimensionTotal = "total"
+	// each combination of utm_source, utm_medium and utm_campaign
+	rollupDimensionCampaign = "campaign"
+	// each combination of all the columns
+	rollupDimensionAll = "all"
+	// the other dimensions are named after their single column: path, referrer...
+)
+
+// pageViewsColumns are the columns of the page views that analytics can be grouped by
+var pageViewsColumns = []struct {
+	Name    string
+	SqlType string
+}{
+	{"path", "TEXT"},
+	{"referrer", "TEXT"},
+	{"country", "TEXT"},
+	{"browser", "INT"},
+	{"operating_system", "INT"},
+	{"utm_source", "TEXT"},
+	{"utm_medium", "TEXT"},
+	{"utm_campaign", "TEXT"},
+}
+
+// rollupDimensionColumns returns the columns of the page views that are grouped by for the dimension
+func rollupDimensionColumns(dimension string) []string {
+	switch dimension {
+	case rollupDimensionTotal:
+		return []string{}
+	case rollupDimensionCampaign:
+		return []string{"utm_source", "utm_medium", "utm_campaign"}
+	case rollupDimensionAll:
+		columns := make([]string, len(pageViewsColumns))
+		for i, column := range pageViewsColumns {
+			columns[i] = column.Name
+		}
+		return columns
+	default:
+		return []string{dimension}
+	}
+}
+
+// RollupPageViews (re)computes the rollups of the page views of all the websites for the day starting at day.
+// It should be called within a transaction.
+func (repo *EventsRepository) RollupPageViews(ctx context.Context, db db.Queryer, day time.Time) (err error) {
+	const deleteQuery = "DELETE FROM events_daily_rollups WHERE day = $1"
+	// GROUPING() returns a bitmask where the bit of a column (path is the most significant) is 1 if the
+	// column is not part of the grouping set.
+	const insertQuery = `INSERT INTO events_daily_rollups
+		(day, website_id, dimension, path, referrer, country, browser, operating_system,
+			utm_source, utm_medium, utm_campaign, page_views, visitors)
+	SELECT $1::TIMESTAMP WITH TIME ZONE, website_id,
+		CASE GROUPING(path, referrer, country, browser, operating_system, utm_source, utm_medium, utm_campaign)
+			WHEN 255 THEN 'total'
+			WHEN 127 THEN 'path'
+			WHEN 191 THEN 'referrer'
+			WHEN 223 THEN 'country'
+			WHEN 239 THEN 'browser'
+			WHEN 247 THEN 'operating_system'
+			WHEN 251 THEN 'utm_source'
+			WHEN 253 THEN 'utm_medium'
+			WHEN 254 THEN 'utm_campaign'
+			WHEN 248 THEN 'campaign'
+			ELSE 'all'
+		END,
+		path, referrer, country, browser, operating_system, utm_source, utm_medium, utm_campaign,
+		COUNT(*), COUNT(DISTINCT anonymous_id)
+	FROM events
+	WHERE type = $3 AND time >= $1::TIMESTAMP WITH TIME ZONE AND time < $2::TIMESTAMP WITH TIME ZONE
+	GROUP BY website_id, GROUPING SETS (
+		(), (path), (referrer), (country), (browser), (operating_system), (utm_source), (utm_medium), (utm_campaign),
+		(utm_source, utm_medium, utm_campaign),
+		(path, referrer, country, browser, operating_system, utm_source, utm_medium, utm_campaign)
+	)
+	`
+
+	_, err = db.Exec(ctx, deleteQuery, day)
+	if err != nil {
+		err = fmt.Errorf("events.RollupPageViews: deleting existing rollups: %w", err)
+		return
+	}
+
+	_, err = db.Exec(ctx, insertQuery, day, day.AddDate(0, 0, 1), events.EventTypePageView)
+	if err != nil {
+		err = fmt.Errorf("events.RollupPageViews: inserting rollups: %w", err)
+		return
+	}
+
+	return
+}
+
+// FindOldestPageViewTime returns the time of the oldest page view, or nil if there is none.
+func (repo *EventsRepository) FindOldestPageViewTime(ctx context.Context, db db.Queryer) (ret *time.Time, err error) {
+	const query = "SELECT MIN(time) FROM events WHERE type = $1"
+
+	err = db.Get(ctx, &ret, query, events.EventTypePageView)
+	if err != nil {
+		err = fmt.Errorf("events.FindOldestPageViewTime: %w", err)
+		return
+	}
+
+	return
+}
@@ -2,16 +2,23 @@ package events
 
 import (
 	"context"
+	"io"
 	"time"
 
 	"github.com/bloom42/stdx-go/db"
 	"github.com/bloom42/stdx-go/guid"
+	"markdown.ninja/pkg/services/kernel"
 )
 
 type Service interface {
 	Push(ctx context.Context, event Event)
 	TrackPageView(ctx context.Context, input TrackPageViewInput)
+	TrackCustomEvent(ctx context.Context, input TrackCustomEventInput) (err error)
 	TrackEmailSent(ctx context.Context, input TrackEmailSentInput)
+	TrackEmailOpened(ctx context.Context, input TrackEmailOpenedInput)
+	TrackEmailClicked(ctx context.Context, input TrackEmailClickedInput)
+	TrackEmailBounced(ctx context.Context, input TrackEmailBouncedInput)
+	TrackEmailComplained(ctx context.Context, input TrackEmailComplainedInput)
 	TrackSubscribedToNewsletter(ctx context.Context, input TrackSubscribedToNewsletterInput)
 	TrackUnsubscribedFromNewsletter(ctx context.Context, input TrackUnsubscribedFromNewsletterInput)
 	TrackOrderPlaced(ctx context.Context, input TrackOrderPlacedInput)
@@ -23,6 +30,19 @@ type Service interface {
 	// TrackEventInBackground(ctx context.Context, input TrackEventInput)
 	// TrackEvent(ctx context.Context, input TrackEventInput) (err error)
 	GetAnalyticsData(ctx context.Context, input GetAnalyticsInput) (ret AnalyticsData, err error)
+	CreateGoal(ctx context.Context, input CreateGoalInput) (goal Goal, err error)
+	UpdateGoal(ctx context.Context, input UpdateGoalInput) (goal Goal, err error)
+	DeleteGoal(ctx context.Context, input DeleteGoalInput) (err error)
+	ListGoals(ctx context.Context, input ListGoalsInput) (ret kernel.PaginatedResult[Goal], err error)
+	CreateAnalyticsExport(ctx context.Context, input CreateAnalyticsExportInput) (export AnalyticsExport, err error)
+	ListAnalyticsExports(ctx context.Context, input ListAnalyticsExportsInput) (ret kernel.PaginatedResult[AnalyticsExport], err error)
+	// DownloadAnalyticsExport returns the export and its data. data needs to be closed by the caller.
+	DownloadAnalyticsExport(ctx context.Context, input DownloadAnalyticsExportInput) (export AnalyticsExport, data io.ReadCloser, err error)
+	GetAnalyticsReportSettings(ctx context.Context, input GetAnalyticsReportSettingsInput) (settings AnalyticsReportSettings, err error)
+	UpdateAnalyticsReportSettings(ctx context.Context, input UpdateAnalyticsReportSettingsInput) (settings AnalyticsReportSettings, err error)
+	// StreamLiveAnalytics calls send with the live analytics of the website each time they change, until ctx is
+	// canceled or send returns an error.
+	StreamLiveAnalytics(ctx context.Context, input StreamLiveAnalyticsInput, send func(data LiveAnalytics) error) (err error)
 	ScheduleDeletionOfWebsiteData(ctx context.Context, db db.Queryer, websiteID guid.GUID) (err error)
 	ScheduleDeletionOfOrganizationData(ctx context.Context, db db.Queryer, organizationID guid.GUID) (err error)
 	GetEmailsSentCountForOrganization(ctx context.Context, db db.Queryer, organizationID guid.GUID, from, to time.Time) (count int64, err error)
@@ -31,4 +51,9 @@ type Service interface {
 	JobDeleteWebsiteEvents(ctx context.Context, input JobDeleteWebsiteEvents) (err error)
 	JobDeleteOrganizationEvents(ctx context.Context, input JobDeleteOrganizationEvents) (err error)
 	JobRotateAnonymousIDSalt(ctx context.Context, input JobRotateAnonymousIDSalt) error
+	JobRollupPageViews(ctx context.Context, input JobRollupPageViews) (err error)
+	JobExportAnalytics(ctx context.Context, input JobExportAnalytics) (err error)
+	JobDeleteExpiredAnalyticsExports(ctx context.Context, input JobDeleteExpiredAnalyticsExports) (err error)
+	JobDispatchSendAnalyticsReports(ctx context.Context, input JobDispatchSendAnalyticsReports) (err error)
+	JobSendAnalyticsReport(ctx context.Context, input JobSendAnalyticsReport) (err error)
 }
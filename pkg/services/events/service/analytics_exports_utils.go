@@ -0,0 +1,133 @@
+package service
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"markdown.ninja/pkg/services/events"
+)
+
+// analyticsExportStorageKey returns the storage key of the export. Exports are stored under the prefix of
+// the website so they are deleted with the other data of the website.
+func analyticsExportStorageKey(export events.AnalyticsExport) string {
+	return fmt.Sprintf("websites/%s/analytics_exports/%s.%s", export.WebsiteID.String(), export.ID.String(), export.Format)
+}
+
+var exportedPageViewCsvHeader = []string{"time", "path", "referrer", "country", "browser", "operating_system",
+	"utm_source", "utm_medium", "utm_campaign", "utm_term", "utm_content"}
+
+func exportedPageViewCsvRecord(pageView events.ExportedPageView) []string {
+	return []string{
+		pageView.Time.UTC().Format(time.RFC3339),
+		csvString(pageView.Path),
+		csvString(pageView.Referrer),
+		csvString(pageView.Country),
+		csvStringer(pageView.Browser),
+		csvStringer(pageView.OperatingSystem),
+		csvString(pageView.UtmSource),
+		csvString(pageView.UtmMedium),
+		csvString(pageView.UtmCampaign),
+		csvString(pageView.UtmTerm),
+		csvString(pageView.UtmContent),
+	}
+}
+
+var exportedAggregatedPageViewsCsvHeader = []string{"day", "path", "referrer", "country", "browser", "operating_system",
+	"utm_source", "utm_medium", "utm_campaign", "page_views", "visitors"}
+
+func exportedAggregatedPageViewsCsvRecord(row events.ExportedAggregatedPageViews) []string {
+	return []string{
+		row.Day.UTC().Format(time.DateOnly),
+		csvString(row.Path),
+		csvString(row.Referrer),
+		csvString(row.Country),
+		csvStringer(row.Browser),
+		csvStringer(row.OperatingSystem),
+		csvString(row.UtmSource),
+		csvString(row.UtmMedium),
+		csvString(row.UtmCampaign),
+		strconv.FormatInt(row.PageViews, 10),
+		strconv.FormatInt(row.Visitors, 10),
+	}
+}
+
+func csvString(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+func csvStringer[T fmt.Stringer](value *T) string {
+	if value == nil {
+		return ""
+	}
+	return (*value).String()
+}
+
+// analyticsExportEncoder streams the rows of an export either as CSV, with a header, or as a JSON array.
+type analyticsExportEncoder struct {
+	format      events.AnalyticsExportFormat
+	output      io.Writer
+	csvWriter   *csv.Writer
+	jsonEncoder *json.Encoder
+	rows        int64
+}
+
+func newAnalyticsExportEncoder(output io.Writer, format events.AnalyticsExportFormat, csvHeader []string) (encoder *analyticsExportEncoder, err error) {
+	encoder = &analyticsExportEncoder{
+		format:      format,
+		output:      output,
+		csvWriter:   nil,
+		jsonEncoder: nil,
+		rows:        0,
+	}
+
+	switch format {
+	case events.AnalyticsExportFormatCsv:
+		encoder.csvWriter = csv.NewWriter(output)
+		err = encoder.csvWriter.Write(csvHeader)
+	case events.AnalyticsExportFormatJson:
+		encoder.jsonEncoder = json.NewEncoder(output)
+		_, err = io.WriteString(output, "[\n")
+	default:
+		err = events.ErrAnalyticsExportFormatIsNotValid
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return encoder, nil
+}
+
+// Encode writes a row. csvRecord is only used for the CSV format.
+func (encoder *analyticsExportEncoder) Encode(row any, csvRecord []string) (err error) {
+	if encoder.format == events.AnalyticsExportFormatCsv {
+		return encoder.csvWriter.Write(csvRecord)
+	}
+
+	if encoder.rows != 0 {
+		_, err = io.WriteString(encoder.output, ",")
+		if err != nil {
+			return err
+		}
+	}
+	encoder.rows += 1
+
+	// json.Encoder terminates each value with a newline
+	return encoder.jsonEncoder.Encode(row)
+}
+
+func (encoder *analyticsExportEncoder) Close() (err error) {
+	if encoder.format == events.AnalyticsExportFormatCsv {
+		encoder.csvWriter.Flush()
+		return encoder.csvWriter.Error()
+	}
+
+	_, err = io.WriteString(encoder.output, "]\n")
+	return err
+}
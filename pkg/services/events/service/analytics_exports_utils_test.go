@@ -0,0 +1,59 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bloom42/stdx-go/opt"
+	"markdown.ninja/pkg/services/events"
+)
+
+func TestAnalyticsExportEncoder(t *testing.T) {
+	pageViews := []events.ExportedPageView{
+		{Time: time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC), Path: opt.Ptr("/"), Browser: opt.Ptr(events.BrowserOther)},
+		{Time: time.Date(2025, 1, 2, 10, 0, 0, 0, time.UTC), Path: opt.Ptr("/blog"), UtmSource: opt.Ptr("newsletter")},
+	}
+
+	encode := func(format events.AnalyticsExportFormat, rows []events.ExportedPageView) string {
+		var output bytes.Buffer
+		encoder, err := newAnalyticsExportEncoder(&output, format, exportedPageViewCsvHeader)
+		if err != nil {
+			t.Fatalf("newAnalyticsExportEncoder(%s): %v", format, err)
+		}
+		for _, row := range rows {
+			err = encoder.Encode(row, exportedPageViewCsvRecord(row))
+			if err != nil {
+				t.Fatalf("Encode(%s): %v", format, err)
+			}
+		}
+		err = encoder.Close()
+		if err != nil {
+			t.Fatalf("Close(%s): %v", format, err)
+		}
+		return output.String()
+	}
+
+	for _, rows := range [][]events.ExportedPageView{nil, pageViews} {
+		var decoded []events.ExportedPageView
+		jsonOutput := encode(events.AnalyticsExportFormatJson, rows)
+		err := json.Unmarshal([]byte(jsonOutput), &decoded)
+		if err != nil {
+			t.Errorf("JSON export is not valid: %v\n%s", err, jsonOutput)
+		} else if len(decoded) != len(rows) {
+			t.Errorf("JSON export: got %d rows, expected %d", len(decoded), len(rows))
+		}
+	}
+
+	csvLines := strings.Split(strings.TrimSpace(encode(events.AnalyticsExportFormatCsv, pageViews)), "\n")
+	expectedCsvLines := []string{
+		"time,path,referrer,country,browser,operating_system,utm_source,utm_medium,utm_campaign,utm_term,utm_content",
+		"2025-01-01T10:00:00Z,/,,,other,,,,,,",
+		"2025-01-02T10:00:00Z,/blog,,,,,newsletter,,,,",
+	}
+	if strings.Join(csvLines, "\n") != strings.Join(expectedCsvLines, "\n") {
+		t.Errorf("CSV export: got\n%s\nexpected\n%s", strings.Join(csvLines, "\n"), strings.Join(expectedCsvLines, "\n"))
+	}
+}
@@ -0,0 +1,33 @@
+package service
+
+import (
+	"time"
+
+	"markdown.ninja/pkg/services/events"
+)
+
+// analyticsReportPeriod returns the last full week (starting on Monday) or month before now, in UTC.
+func analyticsReportPeriod(now time.Time, frequency events.AnalyticsReportFrequency) (from, to time.Time) {
+	granularity := events.AnalyticsGranularityWeek
+	if frequency == events.AnalyticsReportFrequencyMonthly {
+		granularity = events.AnalyticsGranularityMonth
+	}
+
+	to = truncateTime(now, granularity)
+	if granularity == events.AnalyticsGranularityMonth {
+		from = to.AddDate(0, -1, 0)
+	} else {
+		from = to.AddDate(0, 0, -7)
+	}
+
+	return
+}
+
+// analyticsReportPeriodLabel returns a human-readable label of the period of a report.
+// e.g. "Week of January 6, 2025" or "January 2025"
+func analyticsReportPeriodLabel(from time.Time, frequency events.AnalyticsReportFrequency) string {
+	if frequency == events.AnalyticsReportFrequencyMonthly {
+		return from.UTC().Format("January 2006")
+	}
+	return "Week of " + from.UTC().Format("January 2, 2006")
+}
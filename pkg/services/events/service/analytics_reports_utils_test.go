@@ -0,0 +1,46 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"markdown.ninja/pkg/services/events"
+)
+
+func TestAnalyticsReportPeriod(t *testing.T) {
+	date := func(year int, month time.Month, day, hour int) time.Time {
+		return time.Date(year, month, day, hour, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		now          time.Time
+		frequency    events.AnalyticsReportFrequency
+		expectedFrom time.Time
+		expectedTo   time.Time
+	}{
+		// 2025-03-10 is a Monday
+		{date(2025, 3, 10, 8), events.AnalyticsReportFrequencyWeekly, date(2025, 3, 3, 0), date(2025, 3, 10, 0)},
+		{date(2025, 3, 12, 15), events.AnalyticsReportFrequencyWeekly, date(2025, 3, 3, 0), date(2025, 3, 10, 0)},
+		{date(2025, 1, 1, 8), events.AnalyticsReportFrequencyMonthly, date(2024, 12, 1, 0), date(2025, 1, 1, 0)},
+		{date(2025, 3, 1, 8), events.AnalyticsReportFrequencyMonthly, date(2025, 2, 1, 0), date(2025, 3, 1, 0)},
+	}
+
+	for _, test := range tests {
+		from, to := analyticsReportPeriod(test.now, test.frequency)
+		if !from.Equal(test.expectedFrom) || !to.Equal(test.expectedTo) {
+			t.Errorf("analyticsReportPeriod(%s, %s): got [%s, %s), expected [%s, %s)", test.now, test.frequency,
+				from, to, test.expectedFrom, test.expectedTo)
+		}
+	}
+}
+
+func TestAnalyticsReportPeriodLabel(t *testing.T) {
+	from := time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC)
+
+	if label := analyticsReportPeriodLabel(from, events.AnalyticsReportFrequencyWeekly); label != "Week of January 6, 2025" {
+		t.Errorf("analyticsReportPeriodLabel (weekly): got %s", label)
+	}
+	if label := analyticsReportPeriodLabel(from, events.AnalyticsReportFrequencyMonthly); label != "January 2025" {
+		t.Errorf("analyticsReportPeriodLabel (monthly): got %s", label)
+	}
+}
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"time"
+
+	"markdown.ninja/pkg/services/events"
+)
+
+// normalizeAnalyticsTimeRange returns the time range [from, to) covering the requested time range, aligned
+// on its granularity. The period (hour, day...) containing input.To is included.
+func normalizeAnalyticsTimeRange(now time.Time, input events.GetAnalyticsInput) (from, to time.Time, granularity events.AnalyticsGranularity, err error) {
+	to = now.UTC()
+	if input.To != nil {
+		to = input.To.UTC()
+	}
+	from = to.Add(-events.AnalyticsDefaultRange)
+	if input.From != nil {
+		from = input.From.UTC()
+	}
+
+	if !to.After(from) {
+		err = events.ErrAnalyticsTimeRangeIsNotValid
+		return
+	}
+	if to.Sub(from) > events.AnalyticsMaxRange {
+		err = events.ErrAnalyticsTimeRangeIsTooLong(int64(events.AnalyticsMaxRange / (24 * time.Hour)))
+		return
+	}
+
+	if input.Granularity != nil {
+		granularity = *input.Granularity
+		switch granularity {
+		case events.AnalyticsGranularityHour, events.AnalyticsGranularityDay, events.AnalyticsGranularityWeek,
+			events.AnalyticsGranularityMonth:
+		default:
+			err = events.ErrAnalyticsGranularityIsNotValid
+			return
+		}
+	} else {
+		granularity = defaultAnalyticsGranularity(to.Sub(from))
+	}
+
+	if granularity == events.AnalyticsGranularityHour && to.Sub(from) > events.AnalyticsHourlyMaxRange {
+		err = events.ErrAnalyticsHourlyTimeRangeIsTooLong(int64(events.AnalyticsHourlyMaxRange / (24 * time.Hour)))
+		return
+	}
+
+	from = truncateTime(from, granularity)
+	to = addGranularity(truncateTime(to, granularity), granularity)
+
+	return
+}
+
+// previousAnalyticsTimeRange returns the period of the same length preceding [from, to), aligned on granularity
+func previousAnalyticsTimeRange(from, to time.Time, granularity events.AnalyticsGranularity) (previousFrom, previousTo time.Time) {
+	previousFrom = truncateTime(from.Add(-to.Sub(from)), granularity)
+	return previousFrom, from
+}
+
+func defaultAnalyticsGranularity(length time.Duration) events.AnalyticsGranularity {
+	switch {
+	case length <= 2*24*time.Hour:
+		return events.AnalyticsGranularityHour
+	case length <= 90*24*time.Hour:
+		return events.AnalyticsGranularityDay
+	case length <= 366*24*time.Hour:
+		return events.AnalyticsGranularityWeek
+	default:
+		return events.AnalyticsGranularityMonth
+	}
+}
+
+// truncateTime returns the start of the period containing t. Weeks start on Monday, as with Postgres' date_trunc
+func truncateTime(t time.Time, granularity events.AnalyticsGranularity) time.Time {
+	t = t.UTC()
+
+	switch granularity {
+	case events.AnalyticsGranularityHour:
+		return t.Truncate(time.Hour)
+	case events.AnalyticsGranularityWeek:
+		day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+		daysSinceMonday := (int(day.Weekday()) + 6) % 7
+		return day.AddDate(0, 0, -daysSinceMonday)
+	case events.AnalyticsGranularityMonth:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	}
+}
+
+func addGranularity(t time.Time, granularity events.AnalyticsGranularity) time.Time {
+	switch granularity {
+	case events.AnalyticsGranularityHour:
+		return t.Add(time.Hour)
+	case events.AnalyticsGranularityWeek:
+		return t.AddDate(0, 0, 7)
+	case events.AnalyticsGranularityMonth:
+		return t.AddDate(0, 1, 0)
+	default:
+		return t.AddDate(0, 0, 1)
+	}
+}
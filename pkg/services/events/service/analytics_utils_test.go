@@ -0,0 +1,75 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bloom42/stdx-go/opt"
+	"markdown.ninja/pkg/services/events"
+)
+
+func TestNormalizeAnalyticsTimeRange(t *testing.T) {
+	now := time.Date(2025, 3, 12, 15, 42, 0, 0, time.UTC)
+	date := func(year int, month time.Month, day, hour int) time.Time {
+		return time.Date(year, month, day, hour, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		from                *time.Time
+		to                  *time.Time
+		granularity         *events.AnalyticsGranularity
+		expectedFrom        time.Time
+		expectedTo          time.Time
+		expectedGranularity events.AnalyticsGranularity
+	}{
+		{nil, nil, nil, date(2025, 2, 10, 0), date(2025, 3, 13, 0), events.AnalyticsGranularityDay},
+		{opt.Ptr(date(2025, 3, 11, 9)), nil, nil, date(2025, 3, 11, 9), date(2025, 3, 12, 16), events.AnalyticsGranularityHour},
+		{opt.Ptr(date(2025, 1, 1, 0)), opt.Ptr(date(2025, 1, 31, 0)), nil, date(2025, 1, 1, 0), date(2025, 2, 1, 0), events.AnalyticsGranularityDay},
+		// 2024-06-05 is a Wednesday
+		{opt.Ptr(date(2024, 6, 5, 0)), nil, nil, date(2024, 6, 3, 0), date(2025, 3, 17, 0), events.AnalyticsGranularityWeek},
+		{opt.Ptr(date(2023, 6, 5, 0)), nil, nil, date(2023, 6, 1, 0), date(2025, 4, 1, 0), events.AnalyticsGranularityMonth},
+		{opt.Ptr(date(2025, 1, 15, 0)), nil, opt.Ptr(events.AnalyticsGranularityMonth), date(2025, 1, 1, 0), date(2025, 4, 1, 0), events.AnalyticsGranularityMonth},
+	}
+
+	for _, test := range tests {
+		input := events.GetAnalyticsInput{From: test.from, To: test.to, Granularity: test.granularity}
+		from, to, granularity, err := normalizeAnalyticsTimeRange(now, input)
+		if err != nil {
+			t.Errorf("normalizeAnalyticsTimeRange(%v, %v): unexpected error: %v", test.from, test.to, err)
+			continue
+		}
+		if !from.Equal(test.expectedFrom) || !to.Equal(test.expectedTo) || granularity != test.expectedGranularity {
+			t.Errorf("normalizeAnalyticsTimeRange(%v, %v) = (%s, %s, %s), expected (%s, %s, %s)", test.from, test.to,
+				from, to, granularity, test.expectedFrom, test.expectedTo, test.expectedGranularity)
+		}
+	}
+}
+
+func TestNormalizeAnalyticsTimeRangeErrors(t *testing.T) {
+	now := time.Date(2025, 3, 12, 15, 42, 0, 0, time.UTC)
+
+	tests := []events.GetAnalyticsInput{
+		{From: opt.Ptr(now), To: opt.Ptr(now.Add(-time.Hour))},
+		{From: opt.Ptr(now), To: opt.Ptr(now)},
+		{From: opt.Ptr(now.AddDate(-3, 0, 0))},
+		{From: opt.Ptr(now.AddDate(0, 0, -10)), Granularity: opt.Ptr(events.AnalyticsGranularityHour)},
+		{Granularity: opt.Ptr(events.AnalyticsGranularity("year"))},
+	}
+
+	for _, input := range tests {
+		_, _, _, err := normalizeAnalyticsTimeRange(now, input)
+		if err == nil {
+			t.Errorf("normalizeAnalyticsTimeRange(%v, %v, %v): expected error", input.From, input.To, input.Granularity)
+		}
+	}
+}
+
+func TestPreviousAnalyticsTimeRange(t *testing.T) {
+	from := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 3, 11, 0, 0, 0, 0, time.UTC)
+
+	previousFrom, previousTo := previousAnalyticsTimeRange(from, to, events.AnalyticsGranularityDay)
+	if !previousFrom.Equal(time.Date(2025, 2, 19, 0, 0, 0, 0, time.UTC)) || !previousTo.Equal(from) {
+		t.Errorf("previousAnalyticsTimeRange = (%s, %s)", previousFrom, previousTo)
+	}
+}
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/bloom42/stdx-go/db"
+	"github.com/bloom42/stdx-go/guid"
+	"github.com/bloom42/stdx-go/queue"
+	"markdown.ninja/pkg/services/events"
+	"markdown.ninja/pkg/services/kernel"
+	"markdown.ninja/pkg/services/organizations"
+)
+
+func (service *Service) CreateAnalyticsExport(ctx context.Context, input events.CreateAnalyticsExportInput) (export events.AnalyticsExport, err error) {
+	actorID, err := service.kernel.CurrentUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, input.WebsiteID, kernel.PermissionRead)
+	if err != nil {
+		return
+	}
+
+	err = service.validateAnalyticsExportType(input.Type)
+	if err != nil {
+		return
+	}
+
+	err = service.validateAnalyticsExportFormat(input.Format)
+	if err != nil {
+		return
+	}
+
+	err = service.validateAnalyticsExportTimeRange(input.From, input.To)
+	if err != nil {
+		return
+	}
+
+	pendingExports, err := service.repo.GetPendingAnalyticsExportsCountForWebsite(ctx, service.db, input.WebsiteID)
+	if err != nil {
+		return
+	}
+	if pendingExports >= events.AnalyticsExportsMaxPending {
+		err = events.ErrAnalyticsExportsLimitReached
+		return
+	}
+
+	now := time.Now().UTC()
+	export = events.AnalyticsExport{
+		ID:          guid.NewTimeBased(),
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		Type:        input.Type,
+		Format:      input.Format,
+		From:        input.From.UTC(),
+		To:          input.To.UTC(),
+		CompletedAt: nil,
+		Size:        0,
+		WebsiteID:   input.WebsiteID,
+	}
+
+	err = service.db.Transaction(ctx, func(tx db.Tx) (txErr error) {
+		txErr = service.repo.CreateAnalyticsExport(ctx, tx, export)
+		if txErr != nil {
+			return txErr
+		}
+
+		txErr = service.queue.Push(ctx, tx, queue.NewJobInput{
+			Data: events.JobExportAnalytics{
+				ExportID: export.ID,
+			},
+		})
+		if txErr != nil {
+			return txErr
+		}
+
+		txErr = service.organizationsService.RecordAuditLog(ctx, tx, organizations.RecordAuditLogInput{
+			WebsiteID: &export.WebsiteID,
+			Action:    organizations.AuditLogActionAnalyticsExportCreate,
+			EntityID:  export.ID.String(),
+			Before:    nil,
+			After:     export,
+		})
+		return txErr
+	})
+	if err != nil {
+		return
+	}
+
+	return
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/bloom42/stdx-go/db"
+	"github.com/bloom42/stdx-go/guid"
+	"markdown.ninja/pkg/services/events"
+	"markdown.ninja/pkg/services/kernel"
+	"markdown.ninja/pkg/services/organizations"
+)
+
+func (service *Service) CreateGoal(ctx context.Context, input events.CreateGoalInput) (goal events.Goal, err error) {
+	actorID, err := service.kernel.CurrentUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, input.WebsiteID, kernel.PermissionManageWebsites)
+	if err != nil {
+		return
+	}
+
+	now := time.Now().UTC()
+	goal = events.Goal{
+		ID:        guid.NewTimeBased(),
+		CreatedAt: now,
+		UpdatedAt: now,
+		Name:      strings.TrimSpace(input.Name),
+		Type:      input.Type,
+		EventName: "",
+		Path:      "",
+		WebsiteID: input.WebsiteID,
+	}
+
+	err = service.validateGoalName(goal.Name)
+	if err != nil {
+		return
+	}
+
+	switch goal.Type {
+	case events.GoalTypeCustomEvent:
+		goal.EventName = strings.TrimSpace(input.EventName)
+		err = service.validateCustomEventName(goal.EventName)
+	case events.GoalTypePageView:
+		goal.Path = strings.TrimSpace(input.Path)
+		err = service.validateGoalPath(goal.Path)
+	default:
+		err = events.ErrGoalTypeIsNotValid
+	}
+	if err != nil {
+		return
+	}
+
+	existingGoals, err := service.repo.FindGoalsForWebsite(ctx, service.db, input.WebsiteID)
+	if err != nil {
+		return
+	}
+	if len(existingGoals) >= events.GoalsMaxPerWebsite {
+		err = events.ErrGoalsLimitReached
+		return
+	}
+	if goalAlreadyExists(existingGoals, goal) {
+		err = events.ErrGoalAlreadyExists
+		return
+	}
+
+	err = service.db.Transaction(ctx, func(tx db.Tx) (txErr error) {
+		txErr = service.repo.CreateGoal(ctx, tx, goal)
+		if txErr != nil {
+			return txErr
+		}
+
+		txErr = service.organizationsService.RecordAuditLog(ctx, tx, organizations.RecordAuditLogInput{
+			WebsiteID: &goal.WebsiteID,
+			Action:    organizations.AuditLogActionGoalCreate,
+			EntityID:  goal.ID.String(),
+			Before:    nil,
+			After:     goal,
+		})
+		return txErr
+	})
+	if err != nil {
+		return
+	}
+
+	return
+}
@@ -0,0 +1,49 @@
+package service
+
+import (
+	"context"
+
+	"github.com/bloom42/stdx-go/db"
+	"markdown.ninja/pkg/services/events"
+	"markdown.ninja/pkg/services/kernel"
+	"markdown.ninja/pkg/services/organizations"
+)
+
+// DeleteGoal deletes a goal. The events themselves are kept.
+func (service *Service) DeleteGoal(ctx context.Context, input events.DeleteGoalInput) (err error) {
+	actorID, err := service.kernel.CurrentUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	goal, err := service.repo.FindGoalByID(ctx, service.db, input.ID)
+	if err != nil {
+		return
+	}
+
+	err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, goal.WebsiteID, kernel.PermissionManageWebsites)
+	if err != nil {
+		return
+	}
+
+	err = service.db.Transaction(ctx, func(tx db.Tx) (txErr error) {
+		txErr = service.repo.DeleteGoal(ctx, tx, goal.ID)
+		if txErr != nil {
+			return txErr
+		}
+
+		txErr = service.organizationsService.RecordAuditLog(ctx, tx, organizations.RecordAuditLogInput{
+			WebsiteID: &goal.WebsiteID,
+			Action:    organizations.AuditLogActionGoalDelete,
+			EntityID:  goal.ID.String(),
+			Before:    goal,
+			After:     nil,
+		})
+		return txErr
+	})
+	if err != nil {
+		return
+	}
+
+	return
+}
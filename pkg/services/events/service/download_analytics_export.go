@@ -0,0 +1,45 @@
+package service
+
+import (
+	"context"
+	"io"
+	"log/slog"
+
+	"github.com/bloom42/stdx-go/log/slogx"
+	"markdown.ninja/pkg/errs"
+	"markdown.ninja/pkg/services/events"
+	"markdown.ninja/pkg/services/kernel"
+)
+
+func (service *Service) DownloadAnalyticsExport(ctx context.Context, input events.DownloadAnalyticsExportInput) (export events.AnalyticsExport, data io.ReadCloser, err error) {
+	actorID, err := service.kernel.CurrentUserID(ctx)
+	if err != nil {
+		return
+	}
+	logger := slogx.FromCtx(ctx)
+
+	export, err = service.repo.FindAnalyticsExportByID(ctx, service.db, input.ID)
+	if err != nil {
+		return
+	}
+
+	err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, export.WebsiteID, kernel.PermissionRead)
+	if err != nil {
+		return
+	}
+
+	if export.CompletedAt == nil {
+		err = events.ErrAnalyticsExportIsNotReady
+		return
+	}
+
+	data, err = service.storage.GetObject(ctx, analyticsExportStorageKey(export), nil)
+	if err != nil {
+		errMessage := "events.DownloadAnalyticsExport: error getting export from storage"
+		logger.Error(errMessage, slogx.Err(err), slog.String("analytics_export.id", export.ID.String()))
+		err = errs.Internal(errMessage, err)
+		return
+	}
+
+	return
+}
@@ -2,12 +2,16 @@ package service
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 	"slices"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/bloom42/stdx-go/db"
+	"github.com/bloom42/stdx-go/log/slogx"
+	"github.com/jackc/pgx/v5/pgconn"
 	"markdown.ninja/pkg/services/events"
 )
 
@@ -81,18 +85,37 @@ func (service *Service) flushEventsBufferInBackground(ctx context.Context, logge
 
 			events := service.eventsBuffer.Flush()
 			if len(events) != 0 {
-				go service.saveBufferedEvents(context.Background(), events)
+				go service.saveBufferedEvents(slogx.ToCtx(context.Background(), logger), events)
 			}
 		}
 	}()
 }
 
 func (service *Service) saveBufferedEvents(ctx context.Context, eventsInput []events.Event) {
+	eventsInput = slices.DeleteFunc(eventsInput, func(event events.Event) bool {
+		return !eventCanBeSaved(event)
+	})
 	if len(eventsInput) == 0 {
 		return
 	}
 
-	err := service.db.Transaction(ctx, func(tx db.Tx) (txErr error) {
+	err := service.saveEvents(ctx, eventsInput)
+	if err != nil {
+		if isDataException(err) {
+			service.saveEventsDroppingInvalid(ctx, eventsInput)
+			return
+		}
+
+		// if an error happened, we buffer back the events
+		service.eventsBuffer.PushMany(eventsInput)
+		return
+	}
+
+	service.publishLivePageViews(ctx, eventsInput)
+}
+
+func (service *Service) saveEvents(ctx context.Context, eventsInput []events.Event) (err error) {
+	return service.db.Transaction(ctx, func(tx db.Tx) (txErr error) {
 		for eventsChunk := range slices.Chunk(eventsInput, 25_000) {
 			txErr = service.repo.SaveEvents(ctx, tx, eventsChunk)
 			if txErr != nil {
@@ -102,8 +125,39 @@ func (service *Service) saveBufferedEvents(ctx context.Context, eventsInput []ev
 
 		return nil
 	})
-	if err != nil {
-		// if an error happened, we buffer back the events
-		service.eventsBuffer.PushMany(eventsInput)
+}
+
+// saveEventsDroppingInvalid saves the events of a batch that has been refused by the database because of its
+// data. The batch is split in halves until the invalid events are isolated and dropped: retrying them would
+// fail forever and block all the other events.
+func (service *Service) saveEventsDroppingInvalid(ctx context.Context, eventsInput []events.Event) {
+	logger := slogx.FromCtx(ctx)
+
+	if len(eventsInput) == 1 {
+		logger.Warn("events.saveEventsDroppingInvalid: dropping event that can't be saved",
+			slog.String("website.id", eventsInput[0].WebsiteID.String()), slog.Int64("event.type", int64(eventsInput[0].Type)))
+		return
+	}
+
+	middle := len(eventsInput) / 2
+	for _, half := range [][]events.Event{eventsInput[:middle], eventsInput[middle:]} {
+		err := service.saveEvents(ctx, half)
+		if err != nil {
+			if isDataException(err) {
+				service.saveEventsDroppingInvalid(ctx, half)
+				continue
+			}
+			service.eventsBuffer.PushMany(half)
+			continue
+		}
+
+		service.publishLivePageViews(ctx, half)
 	}
 }
+
+// isDataException returns true if err is a Postgres data exception (SQLSTATE class 22), such as invalid
+// characters in a text value.
+func isDataException(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && strings.HasPrefix(pgErr.Code, "22")
+}
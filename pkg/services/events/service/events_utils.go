@@ -1,10 +1,12 @@
 package service
 
 import (
+	"context"
 	"net/netip"
 	"net/url"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/bloom42/stdx-go/crypto/blake3"
 	"github.com/bloom42/stdx-go/guid"
@@ -154,3 +156,108 @@ func (service *Service) cleanupReferrer(websitePrimaryDomain, referrerHeader, re
 
 	return
 }
+
+// cleanupUtmParameters removes invalid characters from the UTM parameters and truncates them to
+// events.UtmParameterMaxLength bytes.
+func cleanupUtmParameters(utm events.UtmParameters) events.UtmParameters {
+	return events.UtmParameters{
+		Source:   cleanupUtmParameter(utm.Source),
+		Medium:   cleanupUtmParameter(utm.Medium),
+		Campaign: cleanupUtmParameter(utm.Campaign),
+		Term:     cleanupUtmParameter(utm.Term),
+		Content:  cleanupUtmParameter(utm.Content),
+	}
+}
+
+func cleanupUtmParameter(value string) string {
+	value = cleanupEventString(value)
+	if len(value) > events.UtmParameterMaxLength {
+		// truncating may split a multi-byte character, which is then removed
+		value = strings.ToValidUTF8(value[:events.UtmParameterMaxLength], "")
+	}
+	return strings.TrimSpace(value)
+}
+
+// cleanupEventString removes the characters that Postgres refuses to store in TEXT and JSONB columns
+// (NUL bytes and invalid UTF-8) and trims the whitespaces.
+func cleanupEventString(value string) string {
+	return strings.TrimSpace(strings.ReplaceAll(strings.ToValidUTF8(value, ""), "\x00", ""))
+}
+
+func cleanupCustomEventProperties(properties map[string]string) map[string]string {
+	cleanedProperties := make(map[string]string, len(properties))
+	for key, value := range properties {
+		cleanedProperties[cleanupEventString(key)] = cleanupEventString(value)
+	}
+	return cleanedProperties
+}
+
+// eventCanBeSaved returns false if the event contains strings that Postgres would refuse to store.
+// Such events would make the whole batch fail so they need to be dropped.
+func eventCanBeSaved(event events.Event) bool {
+	for _, value := range []*string{event.Path, event.Country, event.Referrer, event.UtmSource,
+		event.UtmMedium, event.UtmCampaign, event.UtmTerm, event.UtmContent} {
+		if value != nil && !isValidEventString(*value) {
+			return false
+		}
+	}
+
+	if data, isCustomEvent := event.Data.(events.EventDataCustom); isCustomEvent {
+		if !isValidEventString(data.EventName) {
+			return false
+		}
+		for key, value := range data.Properties {
+			if !isValidEventString(key) || !isValidEventString(value) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func isValidEventString(value string) bool {
+	return utf8.ValidString(value) && !strings.ContainsRune(value, 0)
+}
+
+// setEventUtmParameters sets the UTM parameters of the event. Empty parameters are stored as NULL.
+func setEventUtmParameters(event *events.Event, utm events.UtmParameters) {
+	event.UtmSource = nilIfEmpty(utm.Source)
+	event.UtmMedium = nilIfEmpty(utm.Medium)
+	event.UtmCampaign = nilIfEmpty(utm.Campaign)
+	event.UtmTerm = nilIfEmpty(utm.Term)
+	event.UtmContent = nilIfEmpty(utm.Content)
+}
+
+func nilIfEmpty(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+// attributeEventToVisitor sets the anonymous ID of the visitor identified by ipAddress and userAgent on the
+// event, and attributes the event to the campaign of the last page view of the visitor with UTM parameters.
+// As anonymous IDs change every time the salt is rotated (daily), events can only be attributed to the page
+// views of the current salt window.
+func (service *Service) attributeEventToVisitor(ctx context.Context, event *events.Event, ipAddress netip.Addr,
+	userAgent string) (err error) {
+	anonymousID := getAnonymousID(*service.anonymousIDSalt.Load(), getAnonymousIdInput{
+		time:      event.Time,
+		websiteID: event.WebsiteID,
+		IpAddress: ipAddress,
+		UserAgent: strings.TrimSpace(userAgent),
+	})
+	event.AnonymousID = &anonymousID
+
+	utm, err := service.repo.FindVisitorUtmParameters(ctx, service.eventsDb, event.WebsiteID, anonymousID,
+		event.Time.Add(-24*time.Hour))
+	if err != nil {
+		return
+	}
+
+	if utm != nil {
+		setEventUtmParameters(event, *utm)
+	}
+	return
+}
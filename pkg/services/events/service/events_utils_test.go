@@ -3,13 +3,85 @@ package service
 import (
 	"encoding/binary"
 	"net/netip"
+	"strings"
 	"testing"
 	"time"
+	"unicode/utf8"
 
 	"github.com/bloom42/stdx-go/guid"
 	"github.com/bloom42/stdx-go/xxh3"
+	"markdown.ninja/pkg/services/events"
 )
 
+func TestCleanupUtmParameter(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"", ""},
+		{"newsletter", "newsletter"},
+		{"  Spring Sale ", "Spring Sale"},
+		{"sale\x00", "sale"},
+		{"invalid\xffutf8", "invalidutf8"},
+		{strings.Repeat("a", events.UtmParameterMaxLength+10), strings.Repeat("a", events.UtmParameterMaxLength)},
+		// the last character is cut in half and removed
+		{strings.Repeat("a", events.UtmParameterMaxLength-1) + "é", strings.Repeat("a", events.UtmParameterMaxLength-1)},
+	}
+
+	for _, test := range tests {
+		output := cleanupUtmParameter(test.input)
+		if output != test.expected {
+			t.Errorf("cleanupUtmParameter(%q): got %q, expected %q", test.input, output, test.expected)
+		}
+		if !utf8.ValidString(output) {
+			t.Errorf("cleanupUtmParameter(%q): output is not valid UTF-8", test.input)
+		}
+	}
+}
+
+func TestCleanupEventString(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"", ""},
+		{" /blog ", "/blog"},
+		{"/blog\x00", "/blog"},
+		{"click\x00\xffed", "clicked"},
+	}
+
+	for _, test := range tests {
+		output := cleanupEventString(test.input)
+		if output != test.expected {
+			t.Errorf("cleanupEventString(%q): got %q, expected %q", test.input, output, test.expected)
+		}
+	}
+}
+
+func TestEventCanBeSaved(t *testing.T) {
+	validPath := "/blog"
+	invalidPath := "/blog\x00"
+	invalidUtf8 := "invalid\xff"
+
+	tests := []struct {
+		event    events.Event
+		expected bool
+	}{
+		{events.Event{Path: &validPath}, true},
+		{events.Event{Path: &invalidPath}, false},
+		{events.Event{Path: &validPath, Referrer: &invalidUtf8}, false},
+		{events.Event{Data: events.EventDataCustom{EventName: "signup", Properties: map[string]string{"plan": "pro"}}}, true},
+		{events.Event{Data: events.EventDataCustom{EventName: "signup", Properties: map[string]string{"plan": "pro\x00"}}}, false},
+		{events.Event{Data: events.EventDataCustom{EventName: "signup\x00"}}, false},
+	}
+
+	for i, test := range tests {
+		if output := eventCanBeSaved(test.event); output != test.expected {
+			t.Errorf("eventCanBeSaved (test %d): got %t, expected %t", i, output, test.expected)
+		}
+	}
+}
+
 func BenchmarkGetAnonymousID(b *testing.B) {
 	ipAddress, _ := netip.ParseAddr("0.0.0.0")
 	input := getAnonymousIdInput{
@@ -2,10 +2,14 @@ package service
 
 import (
 	"context"
+	"errors"
 	"time"
 
+	"github.com/bloom42/stdx-go/opt"
 	"golang.org/x/sync/errgroup"
 	"markdown.ninja/pkg/services/events"
+	"markdown.ninja/pkg/services/kernel"
+	"markdown.ninja/pkg/settings"
 )
 
 func (service *Service) GetAnalyticsData(ctx context.Context, input events.GetAnalyticsInput) (ret events.AnalyticsData, err error) {
@@ -14,42 +18,59 @@ func (service *Service) GetAnalyticsData(ctx context.Context, input events.GetAn
 		return
 	}
 
-	err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, input.WebsiteID)
+	err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, input.WebsiteID, kernel.PermissionRead)
 	if err != nil {
 		return
 	}
 
-	now := time.Now().UTC()
-	from := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()).Add(-30 * 24 * time.Hour)
-	to := time.Date(now.Year(), now.Month(), now.Day(), 23, 59, 59, 0, now.Location())
-
-	pageViewsAndVisitors, err := service.repo.GetPageViewsAndVisitors(ctx, service.eventsDb, input.WebsiteID, from, to)
+	from, to, granularity, err := normalizeAnalyticsTimeRange(time.Now().UTC(), input)
 	if err != nil {
 		return
 	}
 
+	// page views before rollupsEnd are read from the daily rollups. Rollups can't be used with an
+	// hourly granularity.
+	rollupsEnd := from
+	if granularity != events.AnalyticsGranularityHour {
+		rollupsEnd, err = service.getRollupsEnd(ctx)
+		if err != nil {
+			return
+		}
+	}
+
+	filters := input.Filters
+	if filters.Referrer != nil && *filters.Referrer == events.AnalyticsReferrerDirect {
+		filters.Referrer = opt.Ptr("")
+	}
+
+	pageViewsQuery := events.PageViewsQuery{
+		WebsiteID:  input.WebsiteID,
+		From:       from,
+		To:         to,
+		RollupsEnd: rollupsEnd,
+		Filters:    filters,
+	}
+
 	ret = events.AnalyticsData{
-		PageViews:      make([]events.Counter, 0, len(pageViewsAndVisitors)),
-		Visitors:       make([]events.Counter, 0, len(pageViewsAndVisitors)),
+		From:           from,
+		To:             to,
+		Granularity:    granularity,
+		PageViews:      []events.Counter{},
+		Visitors:       []events.Counter{},
 		Pages:          []events.Counter{},
 		Referrers:      []events.Counter{},
 		Countries:      []events.Counter{},
 		Browsers:       []events.CounterBrowser{},
 		OSes:           []events.CounterOperatingSystem{},
 		NewSubscribers: 0,
-	}
-
-	for _, dailyData := range pageViewsAndVisitors {
-		ret.PageViews = append(ret.PageViews, events.Counter{
-			Label: dailyData.Day.Format(time.RFC3339),
-			Count: dailyData.PageViews,
-		})
-		ret.TotalPageViews += dailyData.PageViews
-		ret.Visitors = append(ret.Visitors, events.Counter{
-			Label: dailyData.Day.Format(time.RFC3339),
-			Count: dailyData.Visitors,
-		})
-		ret.TotalVisitors += dailyData.Visitors
+		Newsletters:    []events.NewsletterStats{},
+		EmailLinks:     []events.Counter{},
+		Goals:          []events.GoalStats{},
+		UtmSources:     []events.Counter{},
+		UtmMediums:     []events.Counter{},
+		UtmCampaigns:   []events.Counter{},
+		Campaigns:      []events.CampaignStats{},
+		Previous:       nil,
 	}
 
 	errGroup, ctx := errgroup.WithContext(ctx)
@@ -58,26 +79,75 @@ func (service *Service) GetAnalyticsData(ctx context.Context, input events.GetAn
 
 	errGroup.Go(func() error {
 		var taskErr error
-		ret.Pages, taskErr = service.repo.GetTopPages(ctx, service.eventsDb, input.WebsiteID, from, to, 10)
+		ret.PageViews, ret.Visitors, taskErr = service.getPageViewsAndVisitorsTimeSeries(ctx, pageViewsQuery, granularity)
 		return taskErr
 	})
 
+	errGroup.Go(func() error {
+		totals, taskErr := service.repo.GetPageViewsAndVisitorsTotals(ctx, service.eventsDb, pageViewsQuery)
+		if taskErr != nil {
+			return taskErr
+		}
+
+		ret.TotalPageViews = totals.PageViews
+		ret.TotalVisitors = totals.Visitors
+		return nil
+	})
+
+	if input.Compare {
+		previousPageViewsQuery := pageViewsQuery
+		previousPageViewsQuery.From, previousPageViewsQuery.To = previousAnalyticsTimeRange(from, to, granularity)
+		if granularity == events.AnalyticsGranularityHour {
+			previousPageViewsQuery.RollupsEnd = previousPageViewsQuery.From
+		}
+		ret.Previous = &events.AnalyticsComparison{
+			From:      previousPageViewsQuery.From,
+			To:        previousPageViewsQuery.To,
+			PageViews: []events.Counter{},
+			Visitors:  []events.Counter{},
+		}
+
+		errGroup.Go(func() error {
+			var taskErr error
+			ret.Previous.PageViews, ret.Previous.Visitors, taskErr = service.getPageViewsAndVisitorsTimeSeries(ctx,
+				previousPageViewsQuery, granularity)
+			return taskErr
+		})
+
+		errGroup.Go(func() error {
+			totals, taskErr := service.repo.GetPageViewsAndVisitorsTotals(ctx, service.eventsDb, previousPageViewsQuery)
+			if taskErr != nil {
+				return taskErr
+			}
+
+			ret.Previous.TotalPageViews = totals.PageViews
+			ret.Previous.TotalVisitors = totals.Visitors
+			return nil
+		})
+	}
+
 	errGroup.Go(func() error {
 		var taskErr error
-		ret.Countries, taskErr = service.repo.GetTopCountries(ctx, service.eventsDb, input.WebsiteID, from, to, 10)
+		ret.Pages, taskErr = service.repo.GetTopPages(ctx, service.eventsDb, pageViewsQuery, 10)
 		return taskErr
 	})
 
 	errGroup.Go(func() error {
 		var taskErr error
-		ret.Referrers, taskErr = service.repo.GetTopReferrers(ctx, service.eventsDb, input.WebsiteID, from, to, 10)
+		ret.Countries, taskErr = service.repo.GetTopCountries(ctx, service.eventsDb, pageViewsQuery, 10)
+		return taskErr
+	})
+
+	errGroup.Go(func() error {
+		var taskErr error
+		ret.Referrers, taskErr = service.repo.GetTopReferrers(ctx, service.eventsDb, pageViewsQuery, 10)
 		if taskErr != nil {
 			return taskErr
 		}
 
 		for i, tuple := range ret.Referrers {
 			if tuple.Label == "" {
-				ret.Referrers[i].Label = "(direct)"
+				ret.Referrers[i].Label = events.AnalyticsReferrerDirect
 				break
 			}
 		}
@@ -86,13 +156,41 @@ func (service *Service) GetAnalyticsData(ctx context.Context, input events.GetAn
 
 	errGroup.Go(func() error {
 		var taskErr error
-		ret.Browsers, taskErr = service.repo.GetTopBrowsers(ctx, service.eventsDb, input.WebsiteID, from, to, 10)
+		ret.Browsers, taskErr = service.repo.GetTopBrowsers(ctx, service.eventsDb, pageViewsQuery, 10)
+		return taskErr
+	})
+
+	errGroup.Go(func() error {
+		var taskErr error
+		ret.OSes, taskErr = service.repo.GetTopOses(ctx, service.eventsDb, pageViewsQuery, 10)
+		return taskErr
+	})
+
+	errGroup.Go(func() error {
+		var taskErr error
+		ret.UtmSources, taskErr = service.repo.GetTopUtmSources(ctx, service.eventsDb, pageViewsQuery, 10)
+		return taskErr
+	})
+
+	errGroup.Go(func() error {
+		var taskErr error
+		ret.UtmMediums, taskErr = service.repo.GetTopUtmMediums(ctx, service.eventsDb, pageViewsQuery, 10)
+		return taskErr
+	})
+
+	errGroup.Go(func() error {
+		var taskErr error
+		ret.UtmCampaigns, taskErr = service.repo.GetTopUtmCampaigns(ctx, service.eventsDb, pageViewsQuery, 10)
 		return taskErr
 	})
 
 	errGroup.Go(func() error {
+		// subscriptions and orders have no path, referrer... so, as for goals, filters don't apply to campaigns
+		campaignsQuery := pageViewsQuery
+		campaignsQuery.Filters = events.AnalyticsFilters{}
+
 		var taskErr error
-		ret.OSes, taskErr = service.repo.GetTopOses(ctx, service.eventsDb, input.WebsiteID, from, to, 10)
+		ret.Campaigns, taskErr = service.repo.GetCampaignsStats(ctx, service.eventsDb, campaignsQuery, 10)
 		return taskErr
 	})
 
@@ -102,6 +200,36 @@ func (service *Service) GetAnalyticsData(ctx context.Context, input events.GetAn
 		return taskErr
 	})
 
+	errGroup.Go(func() error {
+		var taskErr error
+		ret.Newsletters, taskErr = service.repo.GetNewslettersStats(ctx, service.eventsDb, input.WebsiteID, from, to)
+		if taskErr != nil {
+			return taskErr
+		}
+
+		for i, newsletter := range ret.Newsletters {
+			if newsletter.Sent != 0 {
+				ret.Newsletters[i].OpenRate = float64(newsletter.Opens) / float64(newsletter.Sent)
+				ret.Newsletters[i].ClickRate = float64(newsletter.Clicks) / float64(newsletter.Sent)
+				ret.Newsletters[i].BounceRate = float64(newsletter.Bounces) / float64(newsletter.Sent)
+				ret.Newsletters[i].ComplaintRate = float64(newsletter.Complaints) / float64(newsletter.Sent)
+			}
+		}
+		return nil
+	})
+
+	errGroup.Go(func() error {
+		var taskErr error
+		ret.EmailLinks, taskErr = service.repo.GetTopEmailLinks(ctx, service.eventsDb, input.WebsiteID, from, to, 10)
+		return taskErr
+	})
+
+	errGroup.Go(func() error {
+		var taskErr error
+		ret.Goals, taskErr = service.getGoalsStats(ctx, pageViewsQuery)
+		return taskErr
+	})
+
 	err = errGroup.Wait()
 	if err != nil {
 		return
@@ -109,3 +237,136 @@ func (service *Service) GetAnalyticsData(ctx context.Context, input events.GetAn
 
 	return
 }
+
+// getRollupsEnd returns the time until which page views have been rolled up. Page views before this time
+// must be read from the daily rollups.
+func (service *Service) getRollupsEnd(ctx context.Context) (rollupsEnd time.Time, err error) {
+	rollupsSetting, err := settings.Get[events.SettingDailyRollups](ctx, service.db)
+	if err != nil && !errors.Is(err, settings.ErrSettingNotFound) {
+		return
+	}
+
+	return rollupsSetting.RolledUpUntil, nil
+}
+
+func (service *Service) getPageViewsAndVisitorsTimeSeries(ctx context.Context, query events.PageViewsQuery,
+	granularity events.AnalyticsGranularity) (pageViews, visitors []events.Counter, err error) {
+	pageViewsAndVisitors, err := service.repo.GetPageViewsAndVisitors(ctx, service.eventsDb, query, granularity)
+	if err != nil {
+		return
+	}
+
+	pageViews = make([]events.Counter, 0, len(pageViewsAndVisitors))
+	visitors = make([]events.Counter, 0, len(pageViewsAndVisitors))
+	for _, bucket := range pageViewsAndVisitors {
+		pageViews = append(pageViews, events.Counter{
+			Label: bucket.Time.UTC().Format(time.RFC3339),
+			Count: bucket.PageViews,
+		})
+		visitors = append(visitors, events.Counter{
+			Label: bucket.Time.UTC().Format(time.RFC3339),
+			Count: bucket.Visitors,
+		})
+	}
+
+	return
+}
+
+// getGoalsStats returns the conversions of the built-in goals followed by the goals of the website.
+// Filters don't apply to goals.
+func (service *Service) getGoalsStats(ctx context.Context, query events.PageViewsQuery) (ret []events.GoalStats, err error) {
+	query.Filters = events.AnalyticsFilters{}
+
+	goals, err := service.repo.FindGoalsForWebsite(ctx, service.db, query.WebsiteID)
+	if err != nil {
+		return
+	}
+
+	eventNames := make([]string, 0, len(goals))
+	paths := make([]string, 0, len(goals))
+	for _, goal := range goals {
+		switch goal.Type {
+		case events.GoalTypeCustomEvent:
+			eventNames = append(eventNames, goal.EventName)
+		case events.GoalTypePageView:
+			paths = append(paths, goal.Path)
+		}
+	}
+
+	customEventsVisitors, err := service.repo.GetCustomEventsVisitors(ctx, service.eventsDb, query.WebsiteID,
+		query.From, query.To, eventNames)
+	if err != nil {
+		return
+	}
+
+	pagesVisitors, err := service.repo.GetPagesVisitors(ctx, service.eventsDb, query, paths)
+	if err != nil {
+		return
+	}
+
+	newSubscribers, err := service.repo.GetEventsCount(ctx, service.eventsDb, query.WebsiteID,
+		events.EventTypeSubscribedToNewsletter, query.From, query.To)
+	if err != nil {
+		return
+	}
+
+	completedOrders, err := service.repo.GetEventsCount(ctx, service.eventsDb, query.WebsiteID,
+		events.EventTypeOrderCompleted, query.From, query.To)
+	if err != nil {
+		return
+	}
+
+	totals, err := service.repo.GetPageViewsAndVisitorsTotals(ctx, service.eventsDb, query)
+	if err != nil {
+		return
+	}
+
+	ret = make([]events.GoalStats, 0, len(goals)+2)
+	ret = append(ret,
+		events.GoalStats{
+			GoalID:      nil,
+			Name:        "Newsletter subscription",
+			Type:        events.GoalTypeNewsletterSubscription,
+			Conversions: newSubscribers,
+		},
+		events.GoalStats{
+			GoalID:      nil,
+			Name:        "Order completed",
+			Type:        events.GoalTypeOrderCompleted,
+			Conversions: completedOrders,
+		},
+	)
+
+	for _, goal := range goals {
+		goalStats := events.GoalStats{
+			GoalID:      &goal.ID,
+			Name:        goal.Name,
+			Type:        goal.Type,
+			Conversions: 0,
+		}
+		switch goal.Type {
+		case events.GoalTypeCustomEvent:
+			goalStats.Conversions = findCounter(customEventsVisitors, goal.EventName)
+		case events.GoalTypePageView:
+			goalStats.Conversions = findCounter(pagesVisitors, goal.Path)
+		}
+		ret = append(ret, goalStats)
+	}
+
+	if totals.Visitors != 0 {
+		for i := range ret {
+			ret[i].ConversionRate = float64(ret[i].Conversions) / float64(totals.Visitors)
+		}
+	}
+
+	return
+}
+
+func findCounter(counters []events.Counter, label string) int64 {
+	for _, counter := range counters {
+		if counter.Label == label {
+			return counter.Count
+		}
+	}
+	return 0
+}
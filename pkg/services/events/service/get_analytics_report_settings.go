@@ -0,0 +1,46 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/bloom42/stdx-go/guid"
+	"markdown.ninja/pkg/services/events"
+	"markdown.ninja/pkg/services/kernel"
+)
+
+func (service *Service) GetAnalyticsReportSettings(ctx context.Context, input events.GetAnalyticsReportSettingsInput) (settings events.AnalyticsReportSettings, err error) {
+	actorID, err := service.kernel.CurrentUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, input.WebsiteID, kernel.PermissionRead)
+	if err != nil {
+		return
+	}
+
+	existingSettings, err := service.repo.FindAnalyticsReportSettings(ctx, service.db, input.WebsiteID)
+	if err != nil {
+		return
+	}
+
+	if existingSettings == nil {
+		settings = defaultAnalyticsReportSettings(input.WebsiteID, time.Now().UTC())
+	} else {
+		settings = *existingSettings
+	}
+
+	return
+}
+
+// defaultAnalyticsReportSettings returns the settings of websites that have never configured their reports:
+// reports are opt-in.
+func defaultAnalyticsReportSettings(websiteID guid.GUID, now time.Time) events.AnalyticsReportSettings {
+	return events.AnalyticsReportSettings{
+		CreatedAt: now,
+		UpdatedAt: now,
+		Frequency: events.AnalyticsReportFrequencyNever,
+		WebsiteID: websiteID,
+	}
+}
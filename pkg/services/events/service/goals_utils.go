@@ -0,0 +1,18 @@
+package service
+
+import (
+	"markdown.ninja/pkg/services/events"
+)
+
+// goalAlreadyExists returns true if another goal of existingGoals tracks the same custom event or page as goal
+func goalAlreadyExists(existingGoals []events.Goal, goal events.Goal) bool {
+	for _, existingGoal := range existingGoals {
+		if existingGoal.ID == goal.ID || existingGoal.Type != goal.Type {
+			continue
+		}
+		if existingGoal.EventName == goal.EventName && existingGoal.Path == goal.Path {
+			return true
+		}
+	}
+	return false
+}
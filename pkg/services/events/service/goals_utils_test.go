@@ -0,0 +1,35 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/bloom42/stdx-go/guid"
+	"markdown.ninja/pkg/services/events"
+)
+
+func TestGoalAlreadyExists(t *testing.T) {
+	existingGoals := []events.Goal{
+		{ID: guid.NewTimeBased(), Type: events.GoalTypeCustomEvent, EventName: "download_pdf"},
+		{ID: guid.NewTimeBased(), Type: events.GoalTypePageView, Path: "/pricing"},
+	}
+
+	tests := []struct {
+		goal     events.Goal
+		expected bool
+	}{
+		{events.Goal{ID: guid.NewTimeBased(), Type: events.GoalTypeCustomEvent, EventName: "download_pdf"}, true},
+		{events.Goal{ID: guid.NewTimeBased(), Type: events.GoalTypeCustomEvent, EventName: "click_cta"}, false},
+		{events.Goal{ID: guid.NewTimeBased(), Type: events.GoalTypePageView, Path: "/pricing"}, true},
+		{events.Goal{ID: guid.NewTimeBased(), Type: events.GoalTypePageView, Path: "/about"}, false},
+		// a goal never conflicts with itself
+		{existingGoals[0], false},
+	}
+
+	for _, test := range tests {
+		result := goalAlreadyExists(existingGoals, test.goal)
+		if result != test.expected {
+			t.Errorf("goalAlreadyExists(%s %s%s) = %v, expected %v", test.goal.Type, test.goal.EventName,
+				test.goal.Path, result, test.expected)
+		}
+	}
+}
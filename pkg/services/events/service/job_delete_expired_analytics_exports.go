@@ -0,0 +1,33 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"markdown.ninja/pkg/services/events"
+)
+
+func (service *Service) JobDeleteExpiredAnalyticsExports(ctx context.Context, input events.JobDeleteExpiredAnalyticsExports) (err error) {
+	expiredExports, err := service.repo.FindAnalyticsExportsCreatedBefore(ctx, service.db,
+		time.Now().UTC().Add(-events.AnalyticsExportRetention))
+	if err != nil {
+		return
+	}
+
+	for _, export := range expiredExports {
+		// exports that are not completed have no data in the storage
+		if export.CompletedAt != nil {
+			err = service.storage.DeleteObject(ctx, analyticsExportStorageKey(export))
+			if err != nil {
+				return
+			}
+		}
+
+		err = service.repo.DeleteAnalyticsExport(ctx, service.db, export.ID)
+		if err != nil {
+			return
+		}
+	}
+
+	return
+}
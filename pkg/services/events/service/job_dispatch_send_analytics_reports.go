@@ -0,0 +1,43 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/bloom42/stdx-go/queue"
+	"markdown.ninja/pkg/services/events"
+)
+
+func (service *Service) JobDispatchSendAnalyticsReports(ctx context.Context, input events.JobDispatchSendAnalyticsReports) (err error) {
+	err = service.validateAnalyticsReportFrequency(input.Frequency)
+	if err != nil || input.Frequency == events.AnalyticsReportFrequencyNever {
+		return err
+	}
+
+	websiteIDs, err := service.repo.FindWebsiteIDsWithAnalyticsReportFrequency(ctx, service.db, input.Frequency)
+	if err != nil {
+		return err
+	}
+
+	from, to := analyticsReportPeriod(time.Now().UTC(), input.Frequency)
+	jobs := make([]queue.NewJobInput, 0, len(websiteIDs))
+	for _, websiteID := range websiteIDs {
+		job := queue.NewJobInput{
+			Data: events.JobSendAnalyticsReport{
+				WebsiteID: websiteID,
+				Frequency: input.Frequency,
+				From:      from,
+				To:        to,
+			},
+		}
+		jobs = append(jobs, job)
+	}
+
+	err = service.queue.PushMany(ctx, nil, jobs)
+	if err != nil {
+		return fmt.Errorf("pushing jobs to queue: %w", err)
+	}
+
+	return nil
+}
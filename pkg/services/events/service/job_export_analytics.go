@@ -0,0 +1,128 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"markdown.ninja/pkg/services/events"
+)
+
+// JobExportAnalytics writes the export to a temporary file, so large exports are never held in memory,
+// and then uploads it to the storage.
+func (service *Service) JobExportAnalytics(ctx context.Context, input events.JobExportAnalytics) (err error) {
+	export, err := service.repo.FindAnalyticsExportByID(ctx, service.db, input.ExportID)
+	if err != nil {
+		if errors.Is(err, events.ErrAnalyticsExportNotFound) {
+			// the export may have been deleted with its website
+			return nil
+		}
+		return err
+	}
+
+	if export.CompletedAt != nil {
+		return nil
+	}
+
+	tmpFile, err := os.CreateTemp("", "markdown_ninja_tmp_analytics_export")
+	if err != nil {
+		return fmt.Errorf("events.JobExportAnalytics: error creating tmp file: %w", err)
+	}
+	// best effort cleanup
+	defer os.Remove(tmpFile.Name())
+	defer tmpFile.Close()
+
+	bufferedWriter := bufio.NewWriter(tmpFile)
+	err = service.writeAnalyticsExport(ctx, bufferedWriter, export)
+	if err != nil {
+		return err
+	}
+
+	err = bufferedWriter.Flush()
+	if err != nil {
+		return fmt.Errorf("events.JobExportAnalytics: writing export to tmp file: %w", err)
+	}
+
+	size, err := tmpFile.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return fmt.Errorf("events.JobExportAnalytics: getting size of tmp file: %w", err)
+	}
+
+	_, err = tmpFile.Seek(0, io.SeekStart)
+	if err != nil {
+		return fmt.Errorf("events.JobExportAnalytics: seeking tmp file: %w", err)
+	}
+
+	err = service.storage.PutObject(ctx, analyticsExportStorageKey(export), size, tmpFile, nil)
+	if err != nil {
+		return fmt.Errorf("events.JobExportAnalytics: uploading export to storage: %w", err)
+	}
+
+	now := time.Now().UTC()
+	export.UpdatedAt = now
+	export.CompletedAt = &now
+	export.Size = size
+	err = service.repo.UpdateAnalyticsExport(ctx, service.db, export)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (service *Service) writeAnalyticsExport(ctx context.Context, output io.Writer, export events.AnalyticsExport) (err error) {
+	var encoder *analyticsExportEncoder
+
+	switch export.Type {
+	case events.AnalyticsExportTypePageViews:
+		encoder, err = newAnalyticsExportEncoder(output, export.Format, exportedPageViewCsvHeader)
+		if err != nil {
+			return err
+		}
+
+		err = service.repo.ExportPageViews(ctx, service.eventsDb, export.WebsiteID, export.From, export.To,
+			func(pageView events.ExportedPageView) error {
+				return encoder.Encode(pageView, exportedPageViewCsvRecord(pageView))
+			})
+
+	case events.AnalyticsExportTypeAggregated:
+		encoder, err = newAnalyticsExportEncoder(output, export.Format, exportedAggregatedPageViewsCsvHeader)
+		if err != nil {
+			return err
+		}
+
+		rollupsEnd, rollupsErr := service.getRollupsEnd(ctx)
+		if rollupsErr != nil {
+			return rollupsErr
+		}
+
+		query := events.PageViewsQuery{
+			WebsiteID:  export.WebsiteID,
+			From:       export.From,
+			To:         export.To,
+			RollupsEnd: rollupsEnd,
+			Filters:    events.AnalyticsFilters{},
+		}
+		err = service.repo.ExportAggregatedPageViews(ctx, service.eventsDb, query,
+			func(row events.ExportedAggregatedPageViews) error {
+				return encoder.Encode(row, exportedAggregatedPageViewsCsvRecord(row))
+			})
+
+	default:
+		return events.ErrAnalyticsExportTypeIsNotValid
+	}
+	if err != nil {
+		return fmt.Errorf("events.JobExportAnalytics: exporting analytics: %w", err)
+	}
+
+	err = encoder.Close()
+	if err != nil {
+		return fmt.Errorf("events.JobExportAnalytics: closing encoder: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,67 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/bloom42/stdx-go/db"
+	"github.com/bloom42/stdx-go/log/slogx"
+	"markdown.ninja/pkg/services/events"
+	"markdown.ninja/pkg/settings"
+)
+
+// rollupPageViewsMaxDaysPerJob limits the number of days rolled up by a single job so the backfill of
+// existing events is spread over multiple (hourly) runs.
+// Jobs are never re-queued immediately to make sure that 2 jobs never roll up the same day concurrently.
+const rollupPageViewsMaxDaysPerJob = 31
+
+// JobRollupPageViews rolls up the page views of all the days that are over and have not been rolled up yet.
+func (service *Service) JobRollupPageViews(ctx context.Context, input events.JobRollupPageViews) (err error) {
+	logger := slogx.FromCtx(ctx)
+	today := truncateTime(time.Now().UTC(), events.AnalyticsGranularityDay)
+
+	rollupsSetting, err := settings.Get[events.SettingDailyRollups](ctx, service.db)
+	if err != nil {
+		if !errors.Is(err, settings.ErrSettingNotFound) {
+			return err
+		}
+
+		// the first time, we start from the oldest page view
+		oldestPageViewTime, findErr := service.repo.FindOldestPageViewTime(ctx, service.eventsDb)
+		if findErr != nil {
+			return findErr
+		}
+
+		rollupsSetting.RolledUpUntil = today
+		if oldestPageViewTime != nil {
+			rollupsSetting.RolledUpUntil = truncateTime(*oldestPageViewTime, events.AnalyticsGranularityDay)
+		}
+		err = nil
+	}
+
+	for i := 0; i < rollupPageViewsMaxDaysPerJob && rollupsSetting.RolledUpUntil.Before(today); i += 1 {
+		day := rollupsSetting.RolledUpUntil
+		err = service.eventsDb.Transaction(ctx, func(tx db.Tx) (txErr error) {
+			return service.repo.RollupPageViews(ctx, tx, day)
+		})
+		if err != nil {
+			return err
+		}
+
+		rollupsSetting.RolledUpUntil = day.AddDate(0, 0, 1)
+		err = settings.Set(ctx, service.db, rollupsSetting)
+		if err != nil {
+			return err
+		}
+		logger.Debug("events: page views rolled up", slog.Time("day", day))
+	}
+
+	err = settings.Set(ctx, service.db, rollupsSetting)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
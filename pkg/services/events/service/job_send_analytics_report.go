@@ -0,0 +1,143 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/bloom42/stdx-go/queue"
+	"markdown.ninja/pkg/services/emails"
+	"markdown.ninja/pkg/services/events"
+	"markdown.ninja/pkg/services/events/templates"
+	"markdown.ninja/pkg/services/websites"
+)
+
+// JobSendAnalyticsReport sends the digest of the analytics of the website for the given period to all the
+// staffs who have access to the website.
+func (service *Service) JobSendAnalyticsReport(ctx context.Context, input events.JobSendAnalyticsReport) (err error) {
+	// the settings may have been updated since the job has been dispatched
+	reportSettings, err := service.repo.FindAnalyticsReportSettings(ctx, service.db, input.WebsiteID)
+	if err != nil {
+		return err
+	}
+	if reportSettings == nil || reportSettings.Frequency != input.Frequency {
+		return nil
+	}
+
+	website, err := service.websitesService.FindWebsiteByID(ctx, service.db, input.WebsiteID)
+	if err != nil {
+		if errors.Is(err, websites.ErrWebsiteNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	staffs, err := service.organizationsService.FindStaffsForOrganization(ctx, service.db, website.OrganizationID)
+	if err != nil {
+		return err
+	}
+
+	rollupsEnd, err := service.getRollupsEnd(ctx)
+	if err != nil {
+		return err
+	}
+
+	pageViewsQuery := events.PageViewsQuery{
+		WebsiteID:  website.ID,
+		From:       input.From,
+		To:         input.To,
+		RollupsEnd: rollupsEnd,
+		Filters:    events.AnalyticsFilters{},
+	}
+
+	totals, err := service.repo.GetPageViewsAndVisitorsTotals(ctx, service.eventsDb, pageViewsQuery)
+	if err != nil {
+		return err
+	}
+
+	topPages, err := service.repo.GetTopPages(ctx, service.eventsDb, pageViewsQuery, 5)
+	if err != nil {
+		return err
+	}
+
+	newSubscribers, err := service.repo.GetNewSubscribersCount(ctx, service.eventsDb, website.ID, input.From, input.To)
+	if err != nil {
+		return err
+	}
+
+	revenue, err := service.repo.GetRevenue(ctx, service.eventsDb, website.ID, input.From, input.To)
+	if err != nil {
+		return err
+	}
+
+	webappUrl := url.URL{
+		Scheme: service.httpConfig.WebappBaseUrl.Scheme,
+		Host:   fmt.Sprintf("%s%s", service.httpConfig.WebappDomain, service.httpConfig.WebappPort),
+	}
+	analyticsUrl := webappUrl
+	analyticsUrl.Path = fmt.Sprintf("/websites/%s", website.ID.String())
+	settingsUrl := webappUrl
+	settingsUrl.Path = fmt.Sprintf("/websites/%s/settings/analytics", website.ID.String())
+
+	templateData := templates.AnalyticsReportEmailData{
+		WebsiteName:    website.Name,
+		Period:         analyticsReportPeriodLabel(input.From, input.Frequency),
+		PageViews:      totals.PageViews,
+		Visitors:       totals.Visitors,
+		NewSubscribers: newSubscribers,
+		Revenue:        fmt.Sprintf("%d %s", revenue, website.Currency),
+		TopPages:       make([]templates.AnalyticsReportEmailPage, 0, len(topPages)),
+		AnalyticsUrl:   analyticsUrl.String(),
+		SettingsUrl:    settingsUrl.String(),
+	}
+	for _, page := range topPages {
+		templateData.TopPages = append(templateData.TopPages, templates.AnalyticsReportEmailPage{
+			Path:      page.Label,
+			PageViews: page.Count,
+		})
+	}
+
+	var htmlContent bytes.Buffer
+	err = service.analyticsReportEmailTemplate.Execute(&htmlContent, templateData)
+	if err != nil {
+		return fmt.Errorf("executing email template: %w", err)
+	}
+
+	subject := fmt.Sprintf("%s analytics: %s", website.Name, templateData.Period)
+	jobs := make([]queue.NewJobInput, 0, len(staffs))
+	for _, staff := range staffs {
+		// staffs restricted to another website of the organization don't receive the report
+		if staff.WebsiteID != nil && !staff.WebsiteID.Equal(website.ID) {
+			continue
+		}
+
+		sendEmailJob := queue.NewJobInput{
+			Data: emails.JobSendEmail{
+				Type: emails.EmailTypeTransactional,
+				// left empty because it's a transactional email
+				FromAddress:    "",
+				FromName:       "",
+				ToAddress:      staff.Email,
+				ToName:         staff.Name,
+				Subject:        subject,
+				BodyHtml:       htmlContent.String(),
+				BodyText:       nil,
+				Headers:        nil,
+				WebsiteID:      nil,
+				ContactID:      nil,
+				NewsletterID:   nil,
+				OrganizationID: &website.OrganizationID,
+			},
+		}
+		jobs = append(jobs, sendEmailJob)
+	}
+
+	err = service.queue.PushMany(ctx, nil, jobs)
+	if err != nil {
+		return fmt.Errorf("pushing jobs to queue: %w", err)
+	}
+
+	return nil
+}
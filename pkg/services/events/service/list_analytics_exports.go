@@ -0,0 +1,27 @@
+package service
+
+import (
+	"context"
+
+	"markdown.ninja/pkg/services/events"
+	"markdown.ninja/pkg/services/kernel"
+)
+
+func (service *Service) ListAnalyticsExports(ctx context.Context, input events.ListAnalyticsExportsInput) (ret kernel.PaginatedResult[events.AnalyticsExport], err error) {
+	actorID, err := service.kernel.CurrentUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, input.WebsiteID, kernel.PermissionRead)
+	if err != nil {
+		return
+	}
+
+	ret.Data, err = service.repo.FindAnalyticsExportsForWebsite(ctx, service.db, input.WebsiteID)
+	if err != nil {
+		return
+	}
+
+	return
+}
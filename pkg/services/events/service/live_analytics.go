@@ -0,0 +1,245 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/bloom42/stdx-go/guid"
+	"github.com/bloom42/stdx-go/log/slogx"
+	"github.com/jackc/pgx/v5"
+	"markdown.ninja/pkg/services/events"
+)
+
+// Page views are broadcasted to all the server instances with Postgres' NOTIFY once they are saved.
+// Each server instance LISTENs to the notifications only while it has subscribers, using a dedicated
+// connection, and computes in memory the live analytics of the websites that its subscribers are watching.
+
+type liveAnalyticsHub struct {
+	mutex    sync.Mutex
+	websites map[guid.GUID]*liveWebsiteAnalytics
+	// subscribed wakes up the listener when a website gets its first subscriber
+	subscribed chan struct{}
+	// stopListening stops the current listening session, if any
+	stopListening context.CancelFunc
+}
+
+func newLiveAnalyticsHub() *liveAnalyticsHub {
+	return &liveAnalyticsHub{
+		mutex:         sync.Mutex{},
+		websites:      map[guid.GUID]*liveWebsiteAnalytics{},
+		subscribed:    make(chan struct{}, 1),
+		stopListening: nil,
+	}
+}
+
+// subscribeToLiveAnalytics registers a subscriber for the website. The live analytics of the website are
+// seeded with the page views of the last events.LiveVisitorTimeout when the website has no subscribers yet.
+func (service *Service) subscribeToLiveAnalytics(ctx context.Context, websiteID guid.GUID) (err error) {
+	hub := service.liveAnalytics
+
+	hub.mutex.Lock()
+	if website, exists := hub.websites[websiteID]; exists {
+		website.subscribers += 1
+		hub.mutex.Unlock()
+		return nil
+	}
+	hub.mutex.Unlock()
+
+	now := time.Now().UTC()
+	pageViews, err := service.repo.FindLivePageViews(ctx, service.eventsDb, websiteID, now.Add(-events.LiveVisitorTimeout))
+	if err != nil {
+		return err
+	}
+
+	hub.mutex.Lock()
+	website, exists := hub.websites[websiteID]
+	if !exists {
+		website = newLiveWebsiteAnalytics()
+		for _, pageView := range pageViews {
+			website.addPageView(pageView, now)
+		}
+		hub.websites[websiteID] = website
+	}
+	website.subscribers += 1
+	hub.mutex.Unlock()
+
+	select {
+	case hub.subscribed <- struct{}{}:
+	default:
+	}
+
+	return nil
+}
+
+func (service *Service) unsubscribeFromLiveAnalytics(websiteID guid.GUID) {
+	hub := service.liveAnalytics
+
+	hub.mutex.Lock()
+	defer hub.mutex.Unlock()
+
+	website, exists := hub.websites[websiteID]
+	if !exists {
+		return
+	}
+
+	website.subscribers -= 1
+	if website.subscribers <= 0 {
+		delete(hub.websites, websiteID)
+	}
+
+	if len(hub.websites) == 0 && hub.stopListening != nil {
+		hub.stopListening()
+		hub.stopListening = nil
+	}
+}
+
+// getLiveAnalytics returns the live analytics of the website and their version, which changes each time
+// the live analytics change.
+func (service *Service) getLiveAnalytics(websiteID guid.GUID, now time.Time) (data events.LiveAnalytics, version uint64) {
+	hub := service.liveAnalytics
+
+	hub.mutex.Lock()
+	defer hub.mutex.Unlock()
+
+	website, exists := hub.websites[websiteID]
+	if !exists {
+		return events.LiveAnalytics{Pages: []events.Counter{}, Referrers: []events.LiveReferrer{}}, 0
+	}
+
+	website.pruneVisitors(now)
+	return website.snapshot(), website.version
+}
+
+func (hub *liveAnalyticsHub) handleNotification(payload string) (err error) {
+	var pageViews []events.LivePageView
+
+	err = json.Unmarshal([]byte(payload), &pageViews)
+	if err != nil {
+		return fmt.Errorf("decoding live page views: %w", err)
+	}
+
+	now := time.Now().UTC()
+
+	hub.mutex.Lock()
+	for _, pageView := range pageViews {
+		if website, exists := hub.websites[pageView.WebsiteID]; exists {
+			website.addPageView(pageView, now)
+		}
+	}
+	hub.mutex.Unlock()
+
+	return nil
+}
+
+// publishLivePageViews broadcasts the page views to all the server instances. It is best effort: the
+// live analytics are not critical so errors are only logged.
+func (service *Service) publishLivePageViews(ctx context.Context, eventsInput []events.Event) {
+	logger := slogx.FromCtx(ctx)
+
+	pageViews := make([]events.LivePageView, 0, len(eventsInput))
+	for _, event := range eventsInput {
+		if pageView, ok := newLivePageView(event); ok {
+			pageViews = append(pageViews, pageView)
+		}
+	}
+	if len(pageViews) == 0 {
+		return
+	}
+
+	payloads, err := encodeLivePageViewsNotifications(pageViews)
+	if err != nil {
+		logger.Error("events.publishLivePageViews: error encoding notifications", slogx.Err(err))
+		return
+	}
+
+	err = service.repo.NotifyLivePageViews(ctx, service.db, liveAnalyticsNotificationChannel, payloads)
+	if err != nil {
+		logger.Error("events.publishLivePageViews: error sending notifications", slogx.Err(err))
+		return
+	}
+}
+
+func (service *Service) listenToLivePageViewsInBackground(ctx context.Context) {
+	logger := slogx.FromCtx(ctx)
+	hub := service.liveAnalytics
+
+	for {
+		hub.mutex.Lock()
+		hasSubscribers := len(hub.websites) != 0
+		var sessionCtx context.Context
+		if hasSubscribers {
+			sessionCtx, hub.stopListening = context.WithCancel(ctx)
+		}
+		hub.mutex.Unlock()
+
+		if !hasSubscribers {
+			select {
+			case <-ctx.Done():
+				return
+			case <-hub.subscribed:
+				continue
+			}
+		}
+
+		err := service.listenToLivePageViews(sessionCtx)
+
+		hub.mutex.Lock()
+		if hub.stopListening != nil {
+			hub.stopListening()
+			hub.stopListening = nil
+		}
+		hub.mutex.Unlock()
+
+		if err != nil {
+			logger.Error("events.listenToLivePageViewsInBackground: error listening to live page views", slogx.Err(err))
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(5 * time.Second):
+			}
+		}
+	}
+}
+
+// listenToLivePageViews listens to the notifications until ctx is canceled
+func (service *Service) listenToLivePageViews(ctx context.Context) (err error) {
+	logger := slogx.FromCtx(ctx)
+
+	// we use a dedicated connection to not hold a connection of the pool
+	conn, err := pgx.Connect(ctx, service.databaseUrl)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil
+		}
+		return fmt.Errorf("connecting to database: %w", err)
+	}
+	defer conn.Close(context.Background())
+
+	_, err = conn.Exec(ctx, "LISTEN "+liveAnalyticsNotificationChannel)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil
+		}
+		return fmt.Errorf("listening to notifications: %w", err)
+	}
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("waiting for notification: %w", err)
+		}
+
+		err = service.liveAnalytics.handleNotification(notification.Payload)
+		if err != nil {
+			logger.Warn("events.listenToLivePageViews: invalid notification", slogx.Err(err),
+				slog.Int("notification.size", len(notification.Payload)))
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package service
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/bloom42/stdx-go/guid"
+	"markdown.ninja/pkg/services/events"
+)
+
+const (
+	liveAnalyticsNotificationChannel = "events_live_page_views"
+	// Postgres rejects notifications with a payload larger than 8000 bytes
+	liveAnalyticsNotificationMaxSize = 7_900
+	// the fields of the live page views are truncated so a single page view always fits in a notification
+	livePageViewFieldMaxLength = 256
+	liveAnalyticsPagesMaxCount = 50
+)
+
+// newLivePageView returns the live page view of the event and false if the event is not a page view
+// of an identified visitor.
+func newLivePageView(event events.Event) (pageView events.LivePageView, ok bool) {
+	if event.Type != events.EventTypePageView || event.AnonymousID == nil {
+		return pageView, false
+	}
+
+	pageView = events.LivePageView{
+		Time:        event.Time,
+		WebsiteID:   event.WebsiteID,
+		AnonymousID: *event.AnonymousID,
+		Path:        truncateLivePageViewField(event.Path),
+		Referrer:    truncateLivePageViewField(event.Referrer),
+		Country:     truncateLivePageViewField(event.Country),
+	}
+	return pageView, true
+}
+
+func truncateLivePageViewField(value *string) string {
+	if value == nil {
+		return ""
+	}
+	if len(*value) > livePageViewFieldMaxLength {
+		// truncating may split a multi-byte character, which is then removed
+		return strings.ToValidUTF8((*value)[:livePageViewFieldMaxLength], "")
+	}
+	return *value
+}
+
+// encodeLivePageViewsNotifications encodes the page views as JSON arrays, each one fitting in the payload
+// of a single notification.
+func encodeLivePageViewsNotifications(pageViews []events.LivePageView) (payloads []string, err error) {
+	payloads = []string{}
+	var payload strings.Builder
+
+	for _, pageView := range pageViews {
+		pageViewJson, err := json.Marshal(pageView)
+		if err != nil {
+			return nil, fmt.Errorf("encoding live page view to JSON: %w", err)
+		}
+
+		// +2 for the separator and the closing bracket
+		if payload.Len() != 0 && payload.Len()+len(pageViewJson)+2 > liveAnalyticsNotificationMaxSize {
+			payload.WriteByte(']')
+			payloads = append(payloads, payload.String())
+			payload.Reset()
+		}
+
+		if payload.Len() == 0 {
+			payload.WriteByte('[')
+		} else {
+			payload.WriteByte(',')
+		}
+		payload.Write(pageViewJson)
+	}
+
+	if payload.Len() != 0 {
+		payload.WriteByte(']')
+		payloads = append(payloads, payload.String())
+	}
+
+	return payloads, nil
+}
+
+// liveWebsiteAnalytics is the in-memory state of the live analytics of a website. It is only kept while
+// the website has subscribers on this server instance.
+type liveWebsiteAnalytics struct {
+	subscribers int
+	// the last page view of each current visitor
+	visitors map[guid.GUID]events.LivePageView
+	// most recent first
+	referrers []events.LiveReferrer
+	// version is incremented each time the live analytics change
+	version uint64
+}
+
+func newLiveWebsiteAnalytics() *liveWebsiteAnalytics {
+	return &liveWebsiteAnalytics{
+		subscribers: 0,
+		visitors:    map[guid.GUID]events.LivePageView{},
+		referrers:   make([]events.LiveReferrer, 0, events.LiveReferrersMaxCount),
+		version:     0,
+	}
+}
+
+func (website *liveWebsiteAnalytics) addPageView(pageView events.LivePageView, now time.Time) {
+	if pageView.Time.Before(now.Add(-events.LiveVisitorTimeout)) {
+		return
+	}
+
+	if previousPageView, exists := website.visitors[pageView.AnonymousID]; !exists || !pageView.Time.Before(previousPageView.Time) {
+		website.visitors[pageView.AnonymousID] = pageView
+	}
+
+	if pageView.Referrer != "" {
+		referrer := events.LiveReferrer{
+			Time:     pageView.Time,
+			Referrer: pageView.Referrer,
+			Path:     pageView.Path,
+			Country:  pageView.Country,
+		}
+		// page views are not always received in order
+		index, _ := slices.BinarySearchFunc(website.referrers, referrer.Time, func(item events.LiveReferrer, target time.Time) int {
+			return target.Compare(item.Time)
+		})
+		if index < events.LiveReferrersMaxCount {
+			website.referrers = slices.Insert(website.referrers, index, referrer)
+			if len(website.referrers) > events.LiveReferrersMaxCount {
+				website.referrers = website.referrers[:events.LiveReferrersMaxCount]
+			}
+		}
+	}
+
+	website.version += 1
+}
+
+// pruneVisitors removes the visitors who haven't viewed a page during the last events.LiveVisitorTimeout
+func (website *liveWebsiteAnalytics) pruneVisitors(now time.Time) {
+	deadline := now.Add(-events.LiveVisitorTimeout)
+	for anonymousID, pageView := range website.visitors {
+		if pageView.Time.Before(deadline) {
+			delete(website.visitors, anonymousID)
+			website.version += 1
+		}
+	}
+}
+
+func (website *liveWebsiteAnalytics) snapshot() events.LiveAnalytics {
+	visitorsPerPage := make(map[string]int64, len(website.visitors))
+	for _, pageView := range website.visitors {
+		visitorsPerPage[pageView.Path] += 1
+	}
+
+	pages := make([]events.Counter, 0, len(visitorsPerPage))
+	for path, count := range visitorsPerPage {
+		pages = append(pages, events.Counter{Label: path, Count: count})
+	}
+	slices.SortFunc(pages, func(a, b events.Counter) int {
+		return cmp.Or(cmp.Compare(b.Count, a.Count), strings.Compare(a.Label, b.Label))
+	})
+	if len(pages) > liveAnalyticsPagesMaxCount {
+		pages = pages[:liveAnalyticsPagesMaxCount]
+	}
+
+	return events.LiveAnalytics{
+		CurrentVisitors: int64(len(website.visitors)),
+		Pages:           pages,
+		Referrers:       slices.Clone(website.referrers),
+	}
+}
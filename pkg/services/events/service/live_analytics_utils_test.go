@@ -0,0 +1,122 @@
+package service
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bloom42/stdx-go/guid"
+	"markdown.ninja/pkg/services/events"
+)
+
+func TestLiveWebsiteAnalytics(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	visitor1 := guid.NewRandom()
+	visitor2 := guid.NewRandom()
+	visitor3 := guid.NewRandom()
+
+	website := newLiveWebsiteAnalytics()
+	website.addPageView(events.LivePageView{Time: now.Add(-time.Minute), AnonymousID: visitor1, Path: "/a", Referrer: "google.com"}, now)
+	website.addPageView(events.LivePageView{Time: now, AnonymousID: visitor1, Path: "/b"}, now)
+	website.addPageView(events.LivePageView{Time: now.Add(-30 * time.Second), AnonymousID: visitor2, Path: "/b", Referrer: "news.ycombinator.com"}, now)
+	website.addPageView(events.LivePageView{Time: now.Add(-4 * time.Minute), AnonymousID: visitor3, Path: "/c"}, now)
+	// too old
+	website.addPageView(events.LivePageView{Time: now.Add(-10 * time.Minute), AnonymousID: guid.NewRandom(), Path: "/d"}, now)
+
+	data := website.snapshot()
+	if data.CurrentVisitors != 3 {
+		t.Errorf("current visitors: got %d, expected 3", data.CurrentVisitors)
+	}
+	if len(data.Pages) != 2 || data.Pages[0] != (events.Counter{Label: "/b", Count: 2}) ||
+		data.Pages[1] != (events.Counter{Label: "/c", Count: 1}) {
+		t.Errorf("pages: got %v", data.Pages)
+	}
+	if len(data.Referrers) != 2 || data.Referrers[0].Referrer != "news.ycombinator.com" || data.Referrers[1].Referrer != "google.com" {
+		t.Errorf("referrers: got %v", data.Referrers)
+	}
+
+	version := website.version
+	website.pruneVisitors(now.Add(2 * time.Minute))
+	data = website.snapshot()
+	if data.CurrentVisitors != 2 {
+		t.Errorf("current visitors after pruning: got %d, expected 2", data.CurrentVisitors)
+	}
+	if website.version == version {
+		t.Error("version should change when visitors are pruned")
+	}
+}
+
+func TestLiveWebsiteAnalyticsReferrersLimit(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	website := newLiveWebsiteAnalytics()
+
+	for i := range events.LiveReferrersMaxCount + 10 {
+		website.addPageView(events.LivePageView{
+			Time:        now.Add(-time.Duration(i) * time.Second),
+			AnonymousID: guid.NewRandom(),
+			Path:        "/",
+			Referrer:    "example.com",
+		}, now)
+	}
+
+	if len(website.referrers) != events.LiveReferrersMaxCount {
+		t.Fatalf("referrers: got %d, expected %d", len(website.referrers), events.LiveReferrersMaxCount)
+	}
+	for i := 1; i < len(website.referrers); i += 1 {
+		if website.referrers[i].Time.After(website.referrers[i-1].Time) {
+			t.Fatalf("referrers are not sorted from the most recent")
+		}
+	}
+	if !website.referrers[0].Time.Equal(now) {
+		t.Errorf("most recent referrer: got %s, expected %s", website.referrers[0].Time, now)
+	}
+}
+
+func TestEncodeLivePageViewsNotifications(t *testing.T) {
+	longValue := strings.Repeat("é", 1000)
+	event := events.Event{
+		Time:        time.Now().UTC(),
+		Type:        events.EventTypePageView,
+		Path:        &longValue,
+		Referrer:    &longValue,
+		Country:     &longValue,
+		WebsiteID:   guid.NewRandom(),
+		AnonymousID: new(guid.GUID),
+	}
+	pageView, ok := newLivePageView(event)
+	if !ok {
+		t.Fatal("newLivePageView: page view not converted")
+	}
+
+	pageViews := make([]events.LivePageView, 100)
+	for i := range pageViews {
+		pageViews[i] = pageView
+	}
+
+	payloads, err := encodeLivePageViewsNotifications(pageViews)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decodedPageViews := 0
+	for _, payload := range payloads {
+		if len(payload) > liveAnalyticsNotificationMaxSize {
+			t.Errorf("payload is too large: %d bytes", len(payload))
+		}
+		var decoded []events.LivePageView
+		err = json.Unmarshal([]byte(payload), &decoded)
+		if err != nil {
+			t.Fatalf("decoding payload: %s", err)
+		}
+		decodedPageViews += len(decoded)
+	}
+	if decodedPageViews != len(pageViews) {
+		t.Errorf("got %d page views, expected %d", decodedPageViews, len(pageViews))
+	}
+
+	event.Type = events.EventTypeOrderCompleted
+	if _, ok := newLivePageView(event); ok {
+		t.Error("newLivePageView: only page views should be converted")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"html/template"
 	"log/slog"
 	"sync/atomic"
 	"time"
@@ -12,11 +13,15 @@ import (
 	"github.com/bloom42/stdx-go/db"
 	"github.com/bloom42/stdx-go/log/slogx"
 	"github.com/bloom42/stdx-go/queue"
+	"markdown.ninja/cmd/mdninja-server/config"
 	"markdown.ninja/pkg/services/events"
 	"markdown.ninja/pkg/services/events/repository"
+	"markdown.ninja/pkg/services/events/templates"
 	"markdown.ninja/pkg/services/kernel"
+	"markdown.ninja/pkg/services/organizations"
 	"markdown.ninja/pkg/services/websites"
 	"markdown.ninja/pkg/settings"
+	"markdown.ninja/pkg/storage"
 )
 
 type Service struct {
@@ -24,22 +29,35 @@ type Service struct {
 	eventsDb db.DB
 	db       db.DB
 	queue    queue.Queue
+	storage  storage.Storage
 
-	kernel          kernel.PrivateService
-	websitesService websites.Service
+	httpConfig config.Http
+
+	kernel               kernel.PrivateService
+	websitesService      websites.Service
+	organizationsService organizations.Service
 
 	// TODO: Use a better concurrent data structure?
 	eventsBuffer eventsBuffer
 	botMatcher   *ahocorasick.Matcher
 
+	liveAnalytics *liveAnalyticsHub
+	// used to LISTEN to the live page views with a dedicated connection
+	databaseUrl string
+
 	// a random salt used to generate anonymousIDs
 	// the salt is currently rotated daily
 	anonymousIDSalt atomic.Pointer[string]
+
+	analyticsReportEmailTemplate *template.Template
 }
 
-func NewService(ctx context.Context, db db.DB, eventsDb db.DB, queue queue.Queue, kernel kernel.PrivateService) (service *Service, err error) {
+func NewService(ctx context.Context, conf config.Config, db db.DB, eventsDb db.DB, queue queue.Queue,
+	storage storage.Storage, kernel kernel.PrivateService) (service *Service, err error) {
 	repo := repository.NewEventsRepository()
 
+	analyticsReportEmailTemplate := template.Must(template.New("events.AnalyticsReportEmailTemplate").Parse(templates.AnalyticsReportEmailTemplate))
+
 	botMatcher := ahocorasick.NewStringMatcher(botsFingerprints)
 
 	anonymousIDSaltSetting, err := settings.Get[events.SettingAnonymousIDSalt](ctx, db)
@@ -59,24 +77,34 @@ func NewService(ctx context.Context, db db.DB, eventsDb db.DB, queue queue.Queue
 		eventsDb: eventsDb,
 		db:       db,
 		queue:    queue,
+		storage:  storage,
+
+		httpConfig: conf.HTTP,
 
 		kernel: kernel,
 
 		eventsBuffer:    newEventsBuffer(),
 		botMatcher:      botMatcher,
 		anonymousIDSalt: atomic.Pointer[string]{},
+
+		liveAnalytics: newLiveAnalyticsHub(),
+		databaseUrl:   conf.Database.Url,
+
+		analyticsReportEmailTemplate: analyticsReportEmailTemplate,
 	}
 	service.anonymousIDSalt.Store(&anonymousIDSaltSetting.Salt)
 
 	logger := slogx.FromCtx(ctx)
 	service.flushEventsBufferInBackground(ctx, logger)
 	go service.refreshAnonymousIDSaltInBackground(ctx)
+	go service.listenToLivePageViewsInBackground(ctx)
 
 	return
 }
 
-func (service *Service) InjectServices(websitesService websites.Service) {
+func (service *Service) InjectServices(websitesService websites.Service, organizationsService organizations.Service) {
 	service.websitesService = websitesService
+	service.organizationsService = organizationsService
 }
 
 func (service *Service) refreshAnonymousIDSaltInBackground(ctx context.Context) {
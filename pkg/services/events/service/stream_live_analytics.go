@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"markdown.ninja/pkg/services/events"
+	"markdown.ninja/pkg/services/kernel"
+)
+
+// the live analytics are sent at least every liveAnalyticsHeartbeatInterval, even if they haven't changed,
+// so clients and proxies don't consider the connection as dead.
+const liveAnalyticsHeartbeatInterval = 15 * time.Second
+
+func (service *Service) StreamLiveAnalytics(ctx context.Context, input events.StreamLiveAnalyticsInput, send func(data events.LiveAnalytics) error) (err error) {
+	actorID, err := service.kernel.CurrentUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, input.WebsiteID, kernel.PermissionRead)
+	if err != nil {
+		return
+	}
+
+	err = service.subscribeToLiveAnalytics(ctx, input.WebsiteID)
+	if err != nil {
+		return
+	}
+	defer service.unsubscribeFromLiveAnalytics(input.WebsiteID)
+
+	data, lastVersion := service.getLiveAnalytics(input.WebsiteID, time.Now().UTC())
+	err = send(data)
+	if err != nil {
+		return
+	}
+	lastSentAt := time.Now()
+
+	ticker := time.NewTicker(events.LiveAnalyticsRefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		data, version := service.getLiveAnalytics(input.WebsiteID, time.Now().UTC())
+		if version == lastVersion && time.Since(lastSentAt) < liveAnalyticsHeartbeatInterval {
+			continue
+		}
+
+		err = send(data)
+		if err != nil {
+			return
+		}
+		lastVersion = version
+		lastSentAt = time.Now()
+	}
+}
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"markdown.ninja/pkg/server/httpctx"
+	"markdown.ninja/pkg/services/events"
+)
+
+// TrackCustomEvent validates the event synchronously so clients get an error if the event is not valid.
+// Events sent by bots are silently ignored.
+func (service *Service) TrackCustomEvent(ctx context.Context, input events.TrackCustomEventInput) (err error) {
+	now := time.Now().UTC()
+	eventName := cleanupEventString(input.Name)
+	path := cleanupEventString(input.Path)
+	properties := cleanupCustomEventProperties(input.Properties)
+	userAgent := strings.TrimSpace(input.HeaderUserAgent)
+	httpCtx := httpctx.FromCtx(ctx)
+
+	err = service.validateCustomEventName(eventName)
+	if err != nil {
+		return
+	}
+
+	err = service.validateCustomEventPath(path)
+	if err != nil {
+		return
+	}
+
+	err = service.validateCustomEventProperties(properties)
+	if err != nil {
+		return
+	}
+
+	browser, os, isBot := service.parseUserAgent(userAgent)
+	if isBot {
+		return nil
+	}
+
+	getAnonymousIdInput := getAnonymousIdInput{
+		time:      now,
+		websiteID: input.WebsiteID,
+		IpAddress: httpCtx.Client.IP,
+		UserAgent: userAgent,
+	}
+	anonymousId := getAnonymousID(*service.anonymousIDSalt.Load(), getAnonymousIdInput)
+
+	event := events.Event{
+		Time: now,
+		Type: events.EventTypeCustom,
+		Data: events.EventDataCustom{
+			EventName:  eventName,
+			Properties: properties,
+		},
+
+		Path:            &path,
+		Country:         &httpCtx.Client.CountryCode,
+		Browser:         &browser,
+		OperatingSystem: &os,
+
+		WebsiteID:   input.WebsiteID,
+		AnonymousID: &anonymousId,
+	}
+
+	service.eventsBuffer.Push(event)
+
+	return nil
+}
@@ -0,0 +1,27 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"markdown.ninja/pkg/services/events"
+)
+
+func (service *Service) TrackEmailBounced(ctx context.Context, input events.TrackEmailBouncedInput) {
+	go service.trackEmailBouncedInBackground(ctx, input)
+}
+
+func (service *Service) trackEmailBouncedInBackground(ctx context.Context, input events.TrackEmailBouncedInput) {
+	now := time.Now().UTC()
+	event := events.Event{
+		Time: now,
+		Type: events.EventTypeEmailBounced,
+		Data: events.EventDataEmailBounced{
+			Permanent: input.Permanent,
+		},
+		WebsiteID:    input.WebsiteID,
+		NewsletterID: input.NewsletterID,
+	}
+
+	service.eventsBuffer.Push(event)
+}
@@ -0,0 +1,36 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/bloom42/stdx-go/log/slogx"
+	"markdown.ninja/pkg/services/events"
+)
+
+func (service *Service) TrackEmailClicked(ctx context.Context, input events.TrackEmailClickedInput) {
+	go service.trackEmailClickedInBackground(ctx, input)
+}
+
+func (service *Service) trackEmailClickedInBackground(ctx context.Context, input events.TrackEmailClickedInput) {
+	logger := slogx.FromCtx(ctx)
+
+	if input.Url == "" {
+		logger.Error("events.trackEmailClickedInBackground: url is empty")
+		return
+	}
+
+	now := time.Now().UTC()
+	event := events.Event{
+		Time: now,
+		Type: events.EventTypeEmailClicked,
+		Data: events.EventDataEmailClicked{
+			Url: input.Url,
+		},
+		WebsiteID:    input.WebsiteID,
+		AnonymousID:  &input.RecipientID,
+		NewsletterID: &input.NewsletterID,
+	}
+
+	service.eventsBuffer.Push(event)
+}
@@ -0,0 +1,26 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"markdown.ninja/pkg/services/events"
+)
+
+func (service *Service) TrackEmailOpened(ctx context.Context, input events.TrackEmailOpenedInput) {
+	go service.trackEmailOpenedInBackground(ctx, input)
+}
+
+func (service *Service) trackEmailOpenedInBackground(ctx context.Context, input events.TrackEmailOpenedInput) {
+	now := time.Now().UTC()
+	event := events.Event{
+		Time:         now,
+		Type:         events.EventTypeEmailOpened,
+		Data:         events.EventDataEmailOpened{},
+		WebsiteID:    input.WebsiteID,
+		AnonymousID:  &input.RecipientID,
+		NewsletterID: &input.NewsletterID,
+	}
+
+	service.eventsBuffer.Push(event)
+}
@@ -4,14 +4,16 @@ import (
 	"context"
 	"time"
 
+	"github.com/bloom42/stdx-go/log/slogx"
 	"markdown.ninja/pkg/services/events"
 )
 
 func (service *Service) TrackOrderCompleted(ctx context.Context, input events.TrackOrderCompletedInput) {
-	go service.trackOrderCompletedInBackground(ctx, input)
+	go service.trackOrderCompletedInBackground(context.WithoutCancel(ctx), input)
 }
 
 func (service *Service) trackOrderCompletedInBackground(ctx context.Context, input events.TrackOrderCompletedInput) {
+	logger := slogx.FromCtx(ctx)
 	now := time.Now().UTC()
 	event := events.Event{
 		Time: now,
@@ -23,5 +25,14 @@ func (service *Service) trackOrderCompletedInBackground(ctx context.Context, inp
 		OrderID:   &input.OrderID,
 	}
 
+	// orders are usually completed by a webhook, without the visitor, so they are attributed to the
+	// campaign the order was attributed to when it was placed.
+	utm, err := service.repo.FindOrderUtmParameters(ctx, service.eventsDb, input.WebsiteID, input.OrderID)
+	if err != nil {
+		logger.Error("events.trackOrderCompletedInBackground: error finding the campaign of the order", slogx.Err(err))
+	} else if utm != nil {
+		setEventUtmParameters(&event, *utm)
+	}
+
 	service.eventsBuffer.Push(event)
 }
@@ -4,14 +4,16 @@ import (
 	"context"
 	"time"
 
+	"github.com/bloom42/stdx-go/log/slogx"
 	"markdown.ninja/pkg/services/events"
 )
 
 func (service *Service) TrackOrderPlaced(ctx context.Context, input events.TrackOrderPlacedInput) {
-	go service.trackOrderPlacedInBackground(input)
+	go service.trackOrderPlacedInBackground(context.WithoutCancel(ctx), input)
 }
 
-func (service *Service) trackOrderPlacedInBackground(input events.TrackOrderPlacedInput) {
+func (service *Service) trackOrderPlacedInBackground(ctx context.Context, input events.TrackOrderPlacedInput) {
+	logger := slogx.FromCtx(ctx)
 	now := time.Now().UTC()
 
 	browser, os, _ := service.parseUserAgent(input.UserAgent)
@@ -27,5 +29,13 @@ func (service *Service) trackOrderPlacedInBackground(input events.TrackOrderPlac
 		OrderID:         &input.OrderID,
 	}
 
+	// the campaign of the order is then copied to the order_completed event
+	if input.IpAddress.IsValid() && input.UserAgent != "" {
+		err := service.attributeEventToVisitor(ctx, &event, input.IpAddress, input.UserAgent)
+		if err != nil {
+			logger.Error("events.trackOrderPlacedInBackground: error attributing event to visitor", slogx.Err(err))
+		}
+	}
+
 	service.eventsBuffer.Push(event)
 }
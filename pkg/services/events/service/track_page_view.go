@@ -19,11 +19,13 @@ func (service *Service) TrackPageView(ctx context.Context, input events.TrackPag
 func (service *Service) trackPageViewInBackground(ctx context.Context, input events.TrackPageViewInput) {
 	logger := slogx.FromCtx(ctx)
 	now := time.Now().UTC()
-	path := strings.TrimSpace(input.Path)
+	// the path is decoded so it may contain NUL bytes (%00)
+	path := cleanupEventString(input.Path)
 	userAgent := strings.TrimSpace(input.HeaderUserAgent)
 	websitePrimaryDomain := input.WebsitePrimaryDomain
 	headerReferrer := strings.ToLower(strings.TrimSpace(input.HeaderReferrer))
 	queryParameterRef := strings.ToLower(strings.TrimSpace(input.QueryParameterRef))
+	utm := cleanupUtmParameters(input.Utm)
 	var referrer string
 	httpCtx := httpctx.FromCtx(ctx)
 
@@ -61,6 +63,7 @@ func (service *Service) trackPageViewInBackground(ctx context.Context, input eve
 		WebsiteID:   input.WebsiteID,
 		AnonymousID: &anonymousId,
 	}
+	setEventUtmParameters(&event, utm)
 
 	service.eventsBuffer.Push(event)
 }
@@ -4,14 +4,16 @@ import (
 	"context"
 	"time"
 
+	"github.com/bloom42/stdx-go/log/slogx"
 	"markdown.ninja/pkg/services/events"
 )
 
 func (service *Service) TrackSubscribedToNewsletter(ctx context.Context, input events.TrackSubscribedToNewsletterInput) {
-	go service.trackSubscribedToNewsletterInBackground(ctx, input)
+	go service.trackSubscribedToNewsletterInBackground(context.WithoutCancel(ctx), input)
 }
 
 func (service *Service) trackSubscribedToNewsletterInBackground(ctx context.Context, input events.TrackSubscribedToNewsletterInput) {
+	logger := slogx.FromCtx(ctx)
 	now := time.Now().UTC()
 	event := events.Event{
 		Time:      now,
@@ -20,5 +22,13 @@ func (service *Service) trackSubscribedToNewsletterInBackground(ctx context.Cont
 		WebsiteID: input.WebsiteID,
 	}
 
+	if input.IpAddress.IsValid() && input.UserAgent != "" {
+		err := service.attributeEventToVisitor(ctx, &event, input.IpAddress, input.UserAgent)
+		if err != nil {
+			// the event is still tracked, just not attributed to a campaign
+			logger.Error("events.trackSubscribedToNewsletterInBackground: error attributing event to visitor", slogx.Err(err))
+		}
+	}
+
 	service.eventsBuffer.Push(event)
 }
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/bloom42/stdx-go/db"
+	"markdown.ninja/pkg/services/events"
+	"markdown.ninja/pkg/services/kernel"
+	"markdown.ninja/pkg/services/organizations"
+)
+
+func (service *Service) UpdateAnalyticsReportSettings(ctx context.Context, input events.UpdateAnalyticsReportSettingsInput) (settings events.AnalyticsReportSettings, err error) {
+	actorID, err := service.kernel.CurrentUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, input.WebsiteID, kernel.PermissionManageWebsites)
+	if err != nil {
+		return
+	}
+
+	err = service.validateAnalyticsReportFrequency(input.Frequency)
+	if err != nil {
+		return
+	}
+
+	now := time.Now().UTC()
+	existingSettings, err := service.repo.FindAnalyticsReportSettings(ctx, service.db, input.WebsiteID)
+	if err != nil {
+		return
+	}
+
+	var settingsBefore any
+	if existingSettings == nil {
+		settings = defaultAnalyticsReportSettings(input.WebsiteID, now)
+	} else {
+		settings = *existingSettings
+		settingsBefore = *existingSettings
+	}
+
+	settings.UpdatedAt = now
+	settings.Frequency = input.Frequency
+
+	err = service.db.Transaction(ctx, func(tx db.Tx) (txErr error) {
+		txErr = service.repo.UpsertAnalyticsReportSettings(ctx, tx, settings)
+		if txErr != nil {
+			return txErr
+		}
+
+		txErr = service.organizationsService.RecordAuditLog(ctx, tx, organizations.RecordAuditLogInput{
+			WebsiteID: &settings.WebsiteID,
+			Action:    organizations.AuditLogActionAnalyticsReportUpdate,
+			EntityID:  settings.WebsiteID.String(),
+			Before:    settingsBefore,
+			After:     settings,
+		})
+		return txErr
+	})
+	if err != nil {
+		return
+	}
+
+	return
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/bloom42/stdx-go/db"
+	"markdown.ninja/pkg/services/events"
+	"markdown.ninja/pkg/services/kernel"
+	"markdown.ninja/pkg/services/organizations"
+)
+
+// UpdateGoal updates the name and the custom event or page of a goal. The type of a goal can't be changed.
+func (service *Service) UpdateGoal(ctx context.Context, input events.UpdateGoalInput) (goal events.Goal, err error) {
+	actorID, err := service.kernel.CurrentUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	goal, err = service.repo.FindGoalByID(ctx, service.db, input.ID)
+	if err != nil {
+		return
+	}
+	goalBefore := goal
+
+	err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, goal.WebsiteID, kernel.PermissionManageWebsites)
+	if err != nil {
+		return
+	}
+
+	if input.Name != nil {
+		goal.Name = strings.TrimSpace(*input.Name)
+		err = service.validateGoalName(goal.Name)
+		if err != nil {
+			return
+		}
+	}
+
+	if input.EventName != nil && goal.Type == events.GoalTypeCustomEvent {
+		goal.EventName = strings.TrimSpace(*input.EventName)
+		err = service.validateCustomEventName(goal.EventName)
+		if err != nil {
+			return
+		}
+	}
+
+	if input.Path != nil && goal.Type == events.GoalTypePageView {
+		goal.Path = strings.TrimSpace(*input.Path)
+		err = service.validateGoalPath(goal.Path)
+		if err != nil {
+			return
+		}
+	}
+
+	existingGoals, err := service.repo.FindGoalsForWebsite(ctx, service.db, goal.WebsiteID)
+	if err != nil {
+		return
+	}
+	if goalAlreadyExists(existingGoals, goal) {
+		err = events.ErrGoalAlreadyExists
+		return
+	}
+
+	goal.UpdatedAt = time.Now().UTC()
+	err = service.db.Transaction(ctx, func(tx db.Tx) (txErr error) {
+		txErr = service.repo.UpdateGoal(ctx, tx, goal)
+		if txErr != nil {
+			return txErr
+		}
+
+		txErr = service.organizationsService.RecordAuditLog(ctx, tx, organizations.RecordAuditLogInput{
+			WebsiteID: &goal.WebsiteID,
+			Action:    organizations.AuditLogActionGoalUpdate,
+			EntityID:  goal.ID.String(),
+			Before:    goalBefore,
+			After:     goal,
+		})
+		return txErr
+	})
+	if err != nil {
+		return
+	}
+
+	return
+}
@@ -2,6 +2,9 @@ package service
 
 import (
 	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
 
 	"markdown.ninja/pkg/errs"
 	"markdown.ninja/pkg/services/events"
@@ -18,3 +21,96 @@ func (service *Service) validateCustomEventName(eventName string) (err error) {
 
 	return nil
 }
+
+func (service *Service) validateCustomEventProperties(properties map[string]string) (err error) {
+	if len(properties) > events.CustomEventPropertiesMaxCount {
+		return events.ErrCustomEventPropertiesAreTooMany
+	}
+
+	for key, value := range properties {
+		if key == "" || len(key) > events.CustomEventPropertyKeyMaxSize {
+			return events.ErrCustomEventPropertyKeyIsNotValid
+		}
+		if len(value) > events.CustomEventPropertyValueMaxSize {
+			return events.ErrCustomEventPropertyValueIsTooLong
+		}
+	}
+
+	return nil
+}
+
+// the path of custom events is optional
+func (service *Service) validateCustomEventPath(path string) (err error) {
+	if path == "" {
+		return nil
+	}
+
+	if !strings.HasPrefix(path, "/") || len(path) > events.EventPathMaxLength {
+		return events.ErrCustomEventPathIsNotValid
+	}
+
+	return nil
+}
+
+func (service *Service) validateGoalName(name string) (err error) {
+	if name == "" {
+		return events.ErrGoalNameIsEmpty
+	}
+
+	if utf8.RuneCountInString(name) > events.GoalNameMaxLength {
+		return events.ErrGoalNameIsTooLong
+	}
+
+	return nil
+}
+
+func (service *Service) validateGoalPath(path string) (err error) {
+	if len(path) > events.GoalPathMaxLength {
+		return events.ErrGoalPathIsTooLong
+	}
+
+	if !strings.HasPrefix(path, "/") {
+		return events.ErrGoalPathIsNotValid
+	}
+
+	return nil
+}
+
+func (service *Service) validateAnalyticsExportType(exportType events.AnalyticsExportType) (err error) {
+	switch exportType {
+	case events.AnalyticsExportTypeAggregated, events.AnalyticsExportTypePageViews:
+		return nil
+	default:
+		return events.ErrAnalyticsExportTypeIsNotValid
+	}
+}
+
+func (service *Service) validateAnalyticsExportFormat(format events.AnalyticsExportFormat) (err error) {
+	switch format {
+	case events.AnalyticsExportFormatCsv, events.AnalyticsExportFormatJson:
+		return nil
+	default:
+		return events.ErrAnalyticsExportFormatIsNotValid
+	}
+}
+
+func (service *Service) validateAnalyticsExportTimeRange(from, to time.Time) (err error) {
+	if !to.After(from) {
+		return events.ErrAnalyticsTimeRangeIsNotValid
+	}
+
+	if to.Sub(from) > events.AnalyticsMaxRange {
+		return events.ErrAnalyticsTimeRangeIsTooLong(int64(events.AnalyticsMaxRange / (24 * time.Hour)))
+	}
+
+	return nil
+}
+
+func (service *Service) validateAnalyticsReportFrequency(frequency events.AnalyticsReportFrequency) (err error) {
+	switch frequency {
+	case events.AnalyticsReportFrequencyNever, events.AnalyticsReportFrequencyWeekly, events.AnalyticsReportFrequencyMonthly:
+		return nil
+	default:
+		return events.ErrAnalyticsReportFrequencyIsNotValid
+	}
+}
@@ -1,5 +1,7 @@
 package events
 
+import "time"
+
 type SettingAnonymousIDSalt struct {
 	Salt string `json:"salt"`
 }
@@ -7,3 +9,13 @@ type SettingAnonymousIDSalt struct {
 func (setting SettingAnonymousIDSalt) Key() string {
 	return "events.anonymous_id_salt"
 }
+
+// SettingDailyRollups tracks the progress of the daily rollups of page views.
+// All the days before RolledUpUntil have been rolled up.
+type SettingDailyRollups struct {
+	RolledUpUntil time.Time `json:"rolled_up_until"`
+}
+
+func (setting SettingDailyRollups) Key() string {
+	return "events.daily_rollups"
+}
@@ -0,0 +1,71 @@
+package templates
+
+import (
+	_ "embed"
+)
+
+//go:embed analytics_report_email.html
+var AnalyticsReportEmailTemplate string
+
+type AnalyticsReportEmailData struct {
+	WebsiteName    string
+	Period         string
+	PageViews      int64
+	Visitors       int64
+	NewSubscribers int64
+	Revenue        string
+	TopPages       []AnalyticsReportEmailPage
+	AnalyticsUrl   string
+	SettingsUrl    string
+}
+
+type AnalyticsReportEmailPage struct {
+	Path      string
+	PageViews int64
+}
+
+// <mjml>
+//   <mj-body>
+//     <mj-section>
+//       <mj-column>
+//         <mj-text align="center" font-size="22px" color="#424242" font-family="helvetica" font-weight="700">{{ .WebsiteName }} analytics</mj-text>
+//         <mj-text align="center" font-size="16px" color="#757575" font-family="helvetica">{{ .Period }}</mj-text>
+//         <mj-divider border-color="#dddddd" border-width="2px"></mj-divider>
+//         <mj-table font-size="18px" color="#424242" font-family="helvetica" line-height="28px" padding-top="30px">
+//           <tr>
+//             <td>Page views</td>
+//             <td style="text-align:right;font-weight:700;">{{ .PageViews }}</td>
+//           </tr>
+//           <tr>
+//             <td>Visitors</td>
+//             <td style="text-align:right;font-weight:700;">{{ .Visitors }}</td>
+//           </tr>
+//           <tr>
+//             <td>New subscribers</td>
+//             <td style="text-align:right;font-weight:700;">{{ .NewSubscribers }}</td>
+//           </tr>
+//           <tr>
+//             <td>Revenue</td>
+//             <td style="text-align:right;font-weight:700;">{{ .Revenue }}</td>
+//           </tr>
+//         </mj-table>
+//         {{ if .TopPages }}
+//         <mj-text font-size="18px" color="#424242" font-family="helvetica" font-weight="700" padding-top="30px">Top pages</mj-text>
+//         <mj-table font-size="16px" color="#424242" font-family="helvetica" line-height="26px">
+//           {{ range .TopPages }}
+//           <tr>
+//             <td style="word-break:break-all;">{{ .Path }}</td>
+//             <td style="text-align:right;padding-left:15px;">{{ .PageViews }}</td>
+//           </tr>
+//           {{ end }}
+//         </mj-table>
+//         {{ end }}
+//         <mj-text font-size="16px" color="#424242" font-family="helvetica" line-height="1.5" padding-top="30px">
+//           See the full analytics on your <a href="{{ .AnalyticsUrl }}">dashboard</a>. <br /> <br />
+
+//           You receive this email because you are a staff of the website. The reports can be disabled in the <a href="{{ .SettingsUrl }}">analytics settings</a> of the website.
+//         </mj-text>
+//       </mj-column>
+//     </mj-section>
+//   </mj-body>
+// </mjml>
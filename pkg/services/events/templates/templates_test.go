@@ -0,0 +1,12 @@
+package templates
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAnalyticsReportEmailTemplate(t *testing.T) {
+	if strings.TrimSpace(AnalyticsReportEmailTemplate) == "" {
+		t.Error("AnalyticsReportEmailTemplate is empty")
+	}
+}
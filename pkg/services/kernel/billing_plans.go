@@ -23,6 +23,8 @@ type Plan struct {
 	SelfServe               bool  `json:"-"`
 	MaxAssetSize            int64 `json:"-"`
 	CustomDomainsPerWebsite int64 `json:"-"`
+	// Number of revisions kept for each page. Older revisions are deleted.
+	RevisionsPerPage int64 `json:"-"`
 }
 
 var PlanFree = Plan{
@@ -39,6 +41,7 @@ var PlanFree = Plan{
 	AllowedAssets:           50,
 	MaxAssetSize:            1_000_000, // 1 MB
 	CustomDomainsPerWebsite: 0,
+	RevisionsPerPage:        10,
 
 	Features: []string{
 		"1 Website",
@@ -64,6 +67,7 @@ var PlanPro = Plan{
 	AllowedAssets:           3000,
 	MaxAssetSize:            MaxAssetSize,
 	CustomDomainsPerWebsite: 10,
+	RevisionsPerPage:        100,
 
 	Features: []string{
 		// 'No additional transaction fees',
@@ -94,6 +98,7 @@ var PlanEnterprise = Plan{
 	AllowedAssets:           200_000,
 	MaxAssetSize:            MaxAssetSize,
 	CustomDomainsPerWebsite: 50,
+	RevisionsPerPage:        1000,
 
 	Features: []string{
 		"Unlimited Staffs",
@@ -0,0 +1,30 @@
+package kernel
+
+// Permission is an action that staffs can be allowed to perform depending on their role.
+// See organizations.StaffRole for the permissions granted to each role.
+type Permission int64
+
+const (
+	// PermissionRead allows to read the content, newsletters and analytics of the websites.
+	// Contacts and store data require PermissionManageContacts and PermissionManageStore.
+	PermissionRead Permission = iota
+	// PermissionWriteContent allows to manage pages, assets, snippets, tags and authors
+	PermissionWriteContent
+	// PermissionSendNewsletters allows to manage and send newsletters
+	PermissionSendNewsletters
+	// PermissionManageContacts allows to read, manage, import and export contacts and labels
+	PermissionManageContacts
+	// PermissionManageStore allows to read orders, refunds and coupons, to manage products and coupons, and
+	// the access of contacts to products
+	PermissionManageStore
+	// PermissionIssueRefunds allows to refund orders
+	PermissionIssueRefunds
+	// PermissionManageWebsites allows to create, configure and delete websites
+	PermissionManageWebsites
+	// PermissionManageStaffs allows to invite and remove staffs, and to manage API keys
+	PermissionManageStaffs
+	// PermissionManageBilling allows to manage the subscription and to delete the organization
+	PermissionManageBilling
+	// PermissionReadAuditLogs allows to list and export the audit logs of the organization
+	PermissionReadAuditLogs
+)
@@ -19,9 +19,10 @@ var (
 	ErrUserIsAlreadyStaff = func(email, organizationName string) error {
 		return errs.PermissionDenied(fmt.Sprintf("%s is already staff of %s.", email, organizationName))
 	}
-	ErrCantRemoveLastStaff         = errs.InvalidArgument("You can't remove last staff.")
-	ErrStaffRoleIsNotValid         = errs.InvalidArgument("Role is not valid.")
-	ErrCantRemoveLastAdministrator = errs.InvalidArgument("You can't remove the last administrator from the organization.")
+	ErrCantRemoveLastStaff   = errs.InvalidArgument("You can't remove last staff.")
+	ErrStaffRoleIsNotValid   = errs.InvalidArgument("Role is not valid.")
+	ErrCantRemoveLastOwner   = errs.InvalidArgument("You can't remove the last owner from the organization.")
+	ErrStaffRoleCantBeScoped = errs.InvalidArgument("Owners and administrators can't be restricted to a single website.")
 
 	// Staff invitations
 	ErrStaffInvitationNotFound = errs.NotFound("Invitation not found.")
@@ -35,7 +36,12 @@ var (
 	ErrApiKeyAlreadyExists  = func(name string) error {
 		return errs.InvalidArgument(fmt.Sprintf("API Key %s already exists", name))
 	}
-	ErrApiKeyNameIsNotValid = errs.InvalidArgument("Api Key name is not valid.")
+	ErrApiKeyNameIsNotValid  = errs.InvalidArgument("Api Key name is not valid.")
+	ErrApiKeyScopesAreEmpty  = errs.InvalidArgument("Api Key needs at least one scope.")
+	ErrApiKeyScopeIsNotValid = func(scope ApiKeyScope) error {
+		return errs.InvalidArgument(fmt.Sprintf("Api Key scope is not valid: %s", scope))
+	}
+	ErrApiKeyHasExpired = errs.PermissionDenied("Api Key has expired.")
 
 	// Billing
 	ErrPlanIsNotValid = errs.InvalidArgument("Plan is not valid")
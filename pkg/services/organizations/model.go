@@ -4,9 +4,12 @@ import (
 	"database/sql/driver"
 	"encoding/json"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/bloom42/stdx-go/guid"
+	"github.com/bloom42/stdx-go/set"
 	"github.com/bloom42/stdx-go/uuid"
 	"markdown.ninja/pkg/services/kernel"
 )
@@ -29,14 +32,31 @@ type StaffRole int64
 
 const (
 	StaffRoleUnknown StaffRole = iota
+	// StaffRoleAdministrator can do everything except managing the billing and deleting the organization
 	StaffRoleAdministrator
+	// StaffRoleOwner can do everything
+	StaffRoleOwner
+	// StaffRoleEditor can write content, send newsletters and manage contacts
+	StaffRoleEditor
+	// StaffRoleAuthor can only write content
+	StaffRoleAuthor
+	// StaffRoleViewer has a read-only access
+	StaffRoleViewer
 )
 
 // MarshalText implements encoding.TextMarshaler.
 func (role StaffRole) MarshalText() (ret []byte, err error) {
 	switch role {
+	case StaffRoleOwner:
+		ret = []byte("owner")
 	case StaffRoleAdministrator:
 		ret = []byte("administrator")
+	case StaffRoleEditor:
+		ret = []byte("editor")
+	case StaffRoleAuthor:
+		ret = []byte("author")
+	case StaffRoleViewer:
+		ret = []byte("viewer")
 	default:
 		ret = []byte("unknown")
 		err = fmt.Errorf("Unknown StaffRole: %d", role)
@@ -52,8 +72,16 @@ func (role StaffRole) String() string {
 // UnmarshalText implements encoding.TextUnmarshaler.
 func (role *StaffRole) UnmarshalText(data []byte) (err error) {
 	switch string(data) {
+	case "owner":
+		*role = StaffRoleOwner
 	case "administrator":
 		*role = StaffRoleAdministrator
+	case "editor":
+		*role = StaffRoleEditor
+	case "author":
+		*role = StaffRoleAuthor
+	case "viewer":
+		*role = StaffRoleViewer
 	default:
 		*role = StaffRoleUnknown
 		err = fmt.Errorf("Unknown StaffRole: %s", string(data))
@@ -61,6 +89,39 @@ func (role *StaffRole) UnmarshalText(data []byte) (err error) {
 	return nil
 }
 
+// Can returns true if the role is granted the permission
+func (role StaffRole) Can(permission kernel.Permission) bool {
+	permissions, ok := rolesPermissions[role]
+	if !ok {
+		return false
+	}
+	return permissions.Contains(permission)
+}
+
+var rolesPermissions = map[StaffRole]set.Set[kernel.Permission]{
+	StaffRoleOwner: set.NewFromSlice([]kernel.Permission{
+		kernel.PermissionRead, kernel.PermissionWriteContent, kernel.PermissionSendNewsletters,
+		kernel.PermissionManageContacts, kernel.PermissionManageStore, kernel.PermissionIssueRefunds,
+		kernel.PermissionManageWebsites, kernel.PermissionManageStaffs, kernel.PermissionManageBilling,
+		kernel.PermissionReadAuditLogs,
+	}),
+	StaffRoleAdministrator: set.NewFromSlice([]kernel.Permission{
+		kernel.PermissionRead, kernel.PermissionWriteContent, kernel.PermissionSendNewsletters,
+		kernel.PermissionManageContacts, kernel.PermissionManageStore, kernel.PermissionIssueRefunds,
+		kernel.PermissionManageWebsites, kernel.PermissionManageStaffs, kernel.PermissionReadAuditLogs,
+	}),
+	StaffRoleEditor: set.NewFromSlice([]kernel.Permission{
+		kernel.PermissionRead, kernel.PermissionWriteContent, kernel.PermissionSendNewsletters,
+		kernel.PermissionManageContacts,
+	}),
+	StaffRoleAuthor: set.NewFromSlice([]kernel.Permission{
+		kernel.PermissionRead, kernel.PermissionWriteContent,
+	}),
+	StaffRoleViewer: set.NewFromSlice([]kernel.Permission{
+		kernel.PermissionRead,
+	}),
+}
+
 ////////////////////////////////////////////////////////////////////////////////////////////////////
 // Entities
 ////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -108,6 +169,19 @@ type Staff struct {
 
 	UserID         uuid.UUID `db:"user_id" json:"user_id"`
 	OrganizationID guid.GUID `db:"organization_id" json:"organization_id"`
+	// WebsiteID restricts the access of the staff to a single website of the organization when not null
+	WebsiteID *guid.GUID `db:"website_id" json:"website_id"`
+}
+
+// Can returns true if the staff is granted the permission for the whole organization.
+// Staffs restricted to a single website can't perform organization-wide actions.
+func (staff Staff) Can(permission kernel.Permission) bool {
+	return staff.WebsiteID == nil && staff.Role.Can(permission)
+}
+
+// CanOnWebsite returns true if the staff is granted the permission for the given website
+func (staff Staff) CanOnWebsite(websiteID guid.GUID, permission kernel.Permission) bool {
+	return (staff.WebsiteID == nil || staff.WebsiteID.Equal(websiteID)) && staff.Role.Can(permission)
 }
 
 type ApiKey struct {
@@ -121,9 +195,201 @@ type ApiKey struct {
 	// BLAKE3
 	Hash []byte `db:"hash" json:"-"`
 
+	Scopes ApiKeyScopes `db:"scopes" json:"scopes"`
+	// If not empty, the API key can only access these websites
+	Websites   ApiKeyWebsites `db:"websites" json:"websites"`
+	LastUsedAt *time.Time     `db:"last_used_at" json:"last_used_at"`
+	LastUsedIP *string        `db:"last_used_ip" json:"last_used_ip"`
+
 	OrganizationID guid.GUID `db:"organization_id" json:"-"`
 }
 
+// Can returns true if the API key has the given scope and can access the website
+func (apiKey ApiKey) Can(websiteID guid.GUID, scope ApiKeyScope) bool {
+	if !slices.Contains(apiKey.Scopes, scope) {
+		return false
+	}
+
+	return len(apiKey.Websites) == 0 || slices.ContainsFunc(apiKey.Websites, websiteID.Equal)
+}
+
+type ApiKeyScope string
+
+const (
+	ApiKeyScopeWebsitesRead  ApiKeyScope = "websites:read"
+	ApiKeyScopeWebsitesWrite ApiKeyScope = "websites:write"
+	ApiKeyScopeContentRead   ApiKeyScope = "content:read"
+	ApiKeyScopeContentWrite  ApiKeyScope = "content:write"
+	ApiKeyScopeAssetsRead    ApiKeyScope = "assets:read"
+	ApiKeyScopeAssetsWrite   ApiKeyScope = "assets:write"
+	ApiKeyScopeStoreRead     ApiKeyScope = "store:read"
+	ApiKeyScopeStoreWrite    ApiKeyScope = "store:write"
+	ApiKeyScopeContactsRead  ApiKeyScope = "contacts:read"
+	ApiKeyScopeContactsWrite ApiKeyScope = "contacts:write"
+)
+
+var AllApiKeyScopes = []ApiKeyScope{
+	ApiKeyScopeWebsitesRead,
+	ApiKeyScopeWebsitesWrite,
+	ApiKeyScopeContentRead,
+	ApiKeyScopeContentWrite,
+	ApiKeyScopeAssetsRead,
+	ApiKeyScopeAssetsWrite,
+	ApiKeyScopeStoreRead,
+	ApiKeyScopeStoreWrite,
+	ApiKeyScopeContactsRead,
+	ApiKeyScopeContactsWrite,
+}
+
+type ApiKeyScopes []ApiKeyScope
+
+func (scopes *ApiKeyScopes) Scan(val any) error {
+	switch v := val.(type) {
+	case []byte:
+		return json.Unmarshal(v, scopes)
+	case string:
+		return json.Unmarshal([]byte(v), scopes)
+	default:
+		return fmt.Errorf("ApiKeyScopes.Scan: Unsupported type: %T", v)
+	}
+}
+
+func (scopes ApiKeyScopes) Value() (driver.Value, error) {
+	if scopes == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(scopes)
+}
+
+// ApiKeyWebsites is the list of the IDs of the websites an API key is restricted to
+type ApiKeyWebsites []guid.GUID
+
+func (websites *ApiKeyWebsites) Scan(val any) error {
+	switch v := val.(type) {
+	case []byte:
+		return json.Unmarshal(v, websites)
+	case string:
+		return json.Unmarshal([]byte(v), websites)
+	default:
+		return fmt.Errorf("ApiKeyWebsites.Scan: Unsupported type: %T", v)
+	}
+}
+
+func (websites ApiKeyWebsites) Value() (driver.Value, error) {
+	if websites == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(websites)
+}
+
+// AuditLogEntry records an action performed by a staff or an API key. Audit logs are append-only.
+type AuditLogEntry struct {
+	ID        guid.GUID `db:"id" json:"id"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+
+	Action     AuditLogAction  `db:"action" json:"action"`
+	EntityType string          `db:"entity_type" json:"entity_type"`
+	EntityID   string          `db:"entity_id" json:"entity_id"`
+	Changes    AuditLogChanges `db:"changes" json:"changes"`
+
+	// ActorUserID is null when the action was performed with an API key
+	ActorUserID   *uuid.UUID `db:"actor_user_id" json:"actor_user_id"`
+	ActorApiKeyID *guid.GUID `db:"actor_api_key_id" json:"actor_api_key_id"`
+	ActorIP       string     `db:"actor_ip" json:"actor_ip"`
+
+	WebsiteID      *guid.GUID `db:"website_id" json:"website_id"`
+	OrganizationID guid.GUID  `db:"organization_id" json:"organization_id"`
+}
+
+// AuditLogAction is in the form entity_type.verb
+type AuditLogAction string
+
+const (
+	AuditLogActionOrganizationUpdate    AuditLogAction = "organization.update"
+	AuditLogActionStaffInvite           AuditLogAction = "staff.invite"
+	AuditLogActionStaffRemove           AuditLogAction = "staff.remove"
+	AuditLogActionStaffInvitationDelete AuditLogAction = "staff_invitation.delete"
+	AuditLogActionApiKeyCreate          AuditLogAction = "api_key.create"
+	AuditLogActionApiKeyUpdate          AuditLogAction = "api_key.update"
+	AuditLogActionApiKeyDelete          AuditLogAction = "api_key.delete"
+
+	AuditLogActionWebsiteCreate    AuditLogAction = "website.create"
+	AuditLogActionWebsiteUpdate    AuditLogAction = "website.update"
+	AuditLogActionWebsiteDelete    AuditLogAction = "website.delete"
+	AuditLogActionDomainAdd        AuditLogAction = "domain.add"
+	AuditLogActionDomainRemove     AuditLogAction = "domain.remove"
+	AuditLogActionDomainSetPrimary AuditLogAction = "domain.set_primary"
+	AuditLogActionRedirectsSave    AuditLogAction = "redirects.save"
+
+	AuditLogActionWebhookEndpointCreate AuditLogAction = "webhook_endpoint.create"
+	AuditLogActionWebhookEndpointUpdate AuditLogAction = "webhook_endpoint.update"
+	AuditLogActionWebhookEndpointDelete AuditLogAction = "webhook_endpoint.delete"
+
+	AuditLogActionGoalCreate AuditLogAction = "goal.create"
+	AuditLogActionGoalUpdate AuditLogAction = "goal.update"
+	AuditLogActionGoalDelete AuditLogAction = "goal.delete"
+
+	AuditLogActionAnalyticsExportCreate AuditLogAction = "analytics_export.create"
+	AuditLogActionAnalyticsReportUpdate AuditLogAction = "analytics_report.update"
+
+	AuditLogActionPageCreate    AuditLogAction = "page.create"
+	AuditLogActionPageUpdate    AuditLogAction = "page.update"
+	AuditLogActionPageDelete    AuditLogAction = "page.delete"
+	AuditLogActionSnippetCreate AuditLogAction = "snippet.create"
+	AuditLogActionSnippetUpdate AuditLogAction = "snippet.update"
+	AuditLogActionSnippetDelete AuditLogAction = "snippet.delete"
+	AuditLogActionAssetDelete   AuditLogAction = "asset.delete"
+
+	AuditLogActionProductCreate       AuditLogAction = "product.create"
+	AuditLogActionProductUpdate       AuditLogAction = "product.update"
+	AuditLogActionProductDelete       AuditLogAction = "product.delete"
+	AuditLogActionProductAccessGive   AuditLogAction = "product.give_access"
+	AuditLogActionProductAccessRemove AuditLogAction = "product.remove_access"
+	AuditLogActionCouponCreate        AuditLogAction = "coupon.create"
+	AuditLogActionCouponUpdate        AuditLogAction = "coupon.update"
+	AuditLogActionRefundCreate        AuditLogAction = "refund.create"
+
+	AuditLogActionContactCreate  AuditLogAction = "contact.create"
+	AuditLogActionContactUpdate  AuditLogAction = "contact.update"
+	AuditLogActionContactDelete  AuditLogAction = "contact.delete"
+	AuditLogActionContactBlock   AuditLogAction = "contact.block"
+	AuditLogActionContactUnblock AuditLogAction = "contact.unblock"
+	AuditLogActionContactsImport AuditLogAction = "contact.import"
+	AuditLogActionContactsExport AuditLogAction = "contact.export"
+)
+
+// EntityType returns the type of the entity targeted by the action. e.g. page for page.delete
+func (action AuditLogAction) EntityType() string {
+	entityType, _, _ := strings.Cut(string(action), ".")
+	return entityType
+}
+
+// AuditLogChanges are the fields of an entity that have been modified by an action
+type AuditLogChanges map[string]AuditLogChange
+
+type AuditLogChange struct {
+	Before json.RawMessage `json:"before"`
+	After  json.RawMessage `json:"after"`
+}
+
+func (changes *AuditLogChanges) Scan(val any) error {
+	switch v := val.(type) {
+	case []byte:
+		return json.Unmarshal(v, changes)
+	case string:
+		return json.Unmarshal([]byte(v), changes)
+	default:
+		return fmt.Errorf("AuditLogChanges.Scan: Unsupported type: %T", v)
+	}
+}
+
+func (changes AuditLogChanges) Value() (driver.Value, error) {
+	if changes == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(changes)
+}
+
 type StaffInvitation struct {
 	ID        guid.GUID `db:"id" json:"id"`
 	CreatedAt time.Time `db:"created_at" json:"created_at"`
@@ -132,8 +398,9 @@ type StaffInvitation struct {
 	Role         StaffRole `db:"role" json:"role"`
 	InviteeEmail string    `db:"invitee_email" json:"invitee_email"`
 
-	OrganizationID guid.GUID `db:"organization_id" json:"organization_id"`
-	InviterID      uuid.UUID `db:"inviter_id" json:"inviter_id"`
+	OrganizationID guid.GUID  `db:"organization_id" json:"organization_id"`
+	InviterID      uuid.UUID  `db:"inviter_id" json:"inviter_id"`
+	WebsiteID      *guid.GUID `db:"website_id" json:"website_id"`
 }
 
 type StaffInvitationWithOrganizationDetails struct {
@@ -244,8 +511,10 @@ type ApiKeyWithToken struct {
 // }
 
 type CreateApiKeyInput struct {
-	OrganizationID guid.GUID `json:"organization_id"`
-	Name           string    `json:"name"`
+	OrganizationID guid.GUID     `json:"organization_id"`
+	Name           string        `json:"name"`
+	Scopes         []ApiKeyScope `json:"scopes"`
+	Websites       []guid.GUID   `json:"websites"`
 }
 
 type DeleteApiKeyInput struct {
@@ -253,8 +522,10 @@ type DeleteApiKeyInput struct {
 }
 
 type UpdateApiKeyInput struct {
-	ID   guid.GUID `json:"id"`
-	Name string    `json:"name"`
+	ID       guid.GUID      `json:"id"`
+	Name     *string        `json:"name"`
+	Scopes   *[]ApiKeyScope `json:"scopes"`
+	Websites *[]guid.GUID   `json:"websites"`
 }
 
 type ListStaffInvitationsForOrganizationInput struct {
@@ -264,6 +535,10 @@ type ListStaffInvitationsForOrganizationInput struct {
 type InviteStaffsInput struct {
 	OrganizationID guid.GUID `json:"organization_id"`
 	Emails         []string  `json:"emails"`
+	// Role defaults to StaffRoleAdministrator
+	Role *StaffRole `json:"role"`
+	// WebsiteID optionally restricts the access of the new staffs to a single website
+	WebsiteID *guid.GUID `json:"website_id"`
 }
 
 type AcceptStaffInvitationInput struct {
@@ -321,3 +596,42 @@ type BillingUsage struct {
 	AllowedEmails  int64 `json:"allowed_emails"`
 	UsedEmails     int64 `json:"used_emails"`
 }
+
+type RecordAuditLogInput struct {
+	// OrganizationID is optional when WebsiteID is provided
+	OrganizationID guid.GUID
+	WebsiteID      *guid.GUID
+	Action         AuditLogAction
+	EntityID       string
+	// Before and After are the states of the entity before and after the action. They are serialized to JSON
+	// to compute the changes. Before is nil for creations and After is nil for deletions.
+	Before any
+	After  any
+}
+
+type AuditLogsFilter struct {
+	OrganizationID guid.GUID       `json:"organization_id"`
+	WebsiteID      *guid.GUID      `json:"website_id"`
+	ActorUserID    *uuid.UUID      `json:"actor_user_id"`
+	ActorApiKeyID  *guid.GUID      `json:"actor_api_key_id"`
+	Action         *AuditLogAction `json:"action"`
+	EntityType     *string         `json:"entity_type"`
+	EntityID       *string         `json:"entity_id"`
+	From           *time.Time      `json:"from"`
+	To             *time.Time      `json:"to"`
+}
+
+type ListAuditLogsInput struct {
+	AuditLogsFilter
+	Limit int64      `json:"limit"`
+	After *guid.GUID `json:"after"`
+}
+
+type ExportAuditLogsInput struct {
+	AuditLogsFilter
+}
+
+type ExportAuditLogsOutput struct {
+	// CSV
+	AuditLogs string `json:"audit_logs"`
+}
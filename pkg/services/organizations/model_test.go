@@ -0,0 +1,126 @@
+package organizations
+
+import (
+	"testing"
+
+	"github.com/bloom42/stdx-go/guid"
+	"markdown.ninja/pkg/services/kernel"
+)
+
+var allTestPermissions = []kernel.Permission{
+	kernel.PermissionRead,
+	kernel.PermissionWriteContent,
+	kernel.PermissionSendNewsletters,
+	kernel.PermissionManageContacts,
+	kernel.PermissionManageStore,
+	kernel.PermissionIssueRefunds,
+	kernel.PermissionManageWebsites,
+	kernel.PermissionManageStaffs,
+	kernel.PermissionManageBilling,
+	kernel.PermissionReadAuditLogs,
+}
+
+// expectedRolesPermissions is intentionally written independently of rolesPermissions so that
+// granting or revoking a permission to a role requires to update this test.
+var expectedRolesPermissions = map[StaffRole][]kernel.Permission{
+	StaffRoleOwner: allTestPermissions,
+	StaffRoleAdministrator: {
+		kernel.PermissionRead, kernel.PermissionWriteContent, kernel.PermissionSendNewsletters,
+		kernel.PermissionManageContacts, kernel.PermissionManageStore, kernel.PermissionIssueRefunds,
+		kernel.PermissionManageWebsites, kernel.PermissionManageStaffs, kernel.PermissionReadAuditLogs,
+	},
+	StaffRoleEditor: {
+		kernel.PermissionRead, kernel.PermissionWriteContent, kernel.PermissionSendNewsletters,
+		kernel.PermissionManageContacts,
+	},
+	StaffRoleAuthor:  {kernel.PermissionRead, kernel.PermissionWriteContent},
+	StaffRoleViewer:  {kernel.PermissionRead},
+	StaffRoleUnknown: {},
+}
+
+func isPermissionExpected(role StaffRole, permission kernel.Permission) bool {
+	for _, expectedPermission := range expectedRolesPermissions[role] {
+		if expectedPermission == permission {
+			return true
+		}
+	}
+	return false
+}
+
+func TestStaffRoleCan(t *testing.T) {
+	for role := range expectedRolesPermissions {
+		for _, permission := range allTestPermissions {
+			expected := isPermissionExpected(role, permission)
+			if role.Can(permission) != expected {
+				t.Errorf("%s.Can(%d): expected %t", role, permission, expected)
+			}
+		}
+	}
+}
+
+func TestStaffCanOnWebsite(t *testing.T) {
+	websiteID := guid.NewTimeBased()
+	otherWebsiteID := guid.NewTimeBased()
+
+	for role := range expectedRolesPermissions {
+		organizationStaff := Staff{Role: role, WebsiteID: nil}
+		websiteStaff := Staff{Role: role, WebsiteID: &websiteID}
+
+		for _, permission := range allTestPermissions {
+			expected := isPermissionExpected(role, permission)
+
+			if organizationStaff.CanOnWebsite(websiteID, permission) != expected {
+				t.Errorf("organization-wide %s: CanOnWebsite(website, %d): expected %t", role, permission, expected)
+			}
+			if organizationStaff.CanOnWebsite(otherWebsiteID, permission) != expected {
+				t.Errorf("organization-wide %s: CanOnWebsite(other website, %d): expected %t", role, permission, expected)
+			}
+			if organizationStaff.Can(permission) != expected {
+				t.Errorf("organization-wide %s: Can(%d): expected %t", role, permission, expected)
+			}
+
+			if websiteStaff.CanOnWebsite(websiteID, permission) != expected {
+				t.Errorf("website-scoped %s: CanOnWebsite(website, %d): expected %t", role, permission, expected)
+			}
+			if websiteStaff.CanOnWebsite(otherWebsiteID, permission) {
+				t.Errorf("website-scoped %s: CanOnWebsite(other website, %d): expected false", role, permission)
+			}
+			if websiteStaff.Can(permission) {
+				t.Errorf("website-scoped %s: Can(%d): expected false", role, permission)
+			}
+		}
+	}
+}
+
+func TestApiKeyCan(t *testing.T) {
+	websiteID := guid.NewTimeBased()
+	otherWebsiteID := guid.NewTimeBased()
+
+	organizationKey := ApiKey{Scopes: ApiKeyScopes{ApiKeyScopeContentRead}}
+	websiteKey := ApiKey{
+		Scopes:   ApiKeyScopes{ApiKeyScopeContentRead, ApiKeyScopeContactsWrite},
+		Websites: ApiKeyWebsites{websiteID},
+	}
+
+	tests := []struct {
+		name      string
+		apiKey    ApiKey
+		websiteID guid.GUID
+		scope     ApiKeyScope
+		expected  bool
+	}{
+		{"organization-wide key with the scope", organizationKey, websiteID, ApiKeyScopeContentRead, true},
+		{"organization-wide key on any website", organizationKey, otherWebsiteID, ApiKeyScopeContentRead, true},
+		{"organization-wide key without the scope", organizationKey, websiteID, ApiKeyScopeContentWrite, false},
+		{"website-scoped key with the scope", websiteKey, websiteID, ApiKeyScopeContactsWrite, true},
+		{"website-scoped key on another website", websiteKey, otherWebsiteID, ApiKeyScopeContentRead, false},
+		{"website-scoped key without the scope", websiteKey, websiteID, ApiKeyScopeContactsRead, false},
+		{"key without scopes", ApiKey{}, websiteID, ApiKeyScopeContentRead, false},
+	}
+
+	for _, test := range tests {
+		if test.apiKey.Can(test.websiteID, test.scope) != test.expected {
+			t.Errorf("%s: Can(%s): expected %t", test.name, test.scope, test.expected)
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"time"
 
 	"github.com/bloom42/stdx-go/db"
 	"github.com/bloom42/stdx-go/guid"
@@ -12,11 +13,13 @@ import (
 
 func (repo *OrganizationsRepository) CreateApiKey(ctx context.Context, dbConn db.Queryer, apiKey organizations.ApiKey) (err error) {
 	const query = `INSERT INTO api_keys
-			(id, created_at, updated_at, expires_at, name, version, hash, organization_id)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+			(id, created_at, updated_at, expires_at, name, version, hash, scopes, websites, last_used_at, last_used_ip,
+				organization_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`
 
 	_, err = dbConn.Exec(ctx, query, apiKey.ID, apiKey.CreatedAt, apiKey.UpdatedAt, apiKey.ExpiresAt, apiKey.Name,
-		apiKey.Version, apiKey.Hash, apiKey.OrganizationID)
+		apiKey.Version, apiKey.Hash, apiKey.Scopes, apiKey.Websites, apiKey.LastUsedAt, apiKey.LastUsedIP,
+		apiKey.OrganizationID)
 	if err != nil {
 		if db.IsErrAlreadyExists(err) {
 			err = organizations.ErrApiKeyAlreadyExists(apiKey.Name)
@@ -31,10 +34,11 @@ func (repo *OrganizationsRepository) CreateApiKey(ctx context.Context, dbConn db
 }
 
 func (repo *OrganizationsRepository) UpdateApiKey(ctx context.Context, dbConn db.Queryer, apiKey organizations.ApiKey) (err error) {
-	const query = `UPDATE api_keys SET updated_at = $1, expires_at = $2, name = $3
-		WHERE id = $4`
+	const query = `UPDATE api_keys SET updated_at = $1, expires_at = $2, name = $3, scopes = $4, websites = $5
+		WHERE id = $6`
 
-	_, err = dbConn.Exec(ctx, query, apiKey.UpdatedAt, apiKey.ExpiresAt, apiKey.Name, apiKey.ID)
+	_, err = dbConn.Exec(ctx, query, apiKey.UpdatedAt, apiKey.ExpiresAt, apiKey.Name, apiKey.Scopes, apiKey.Websites,
+		apiKey.ID)
 	if err != nil {
 		if db.IsErrAlreadyExists(err) {
 			err = organizations.ErrApiKeyAlreadyExists(apiKey.Name)
@@ -48,6 +52,18 @@ func (repo *OrganizationsRepository) UpdateApiKey(ctx context.Context, dbConn db
 	return
 }
 
+func (repo *OrganizationsRepository) UpdateApiKeyLastUsed(ctx context.Context, db db.Queryer, apiKeyID guid.GUID, lastUsedAt time.Time, lastUsedIP string) (err error) {
+	const query = `UPDATE api_keys SET last_used_at = $1, last_used_ip = $2 WHERE id = $3`
+
+	_, err = db.Exec(ctx, query, lastUsedAt, lastUsedIP, apiKeyID)
+	if err != nil {
+		err = fmt.Errorf("organizations.UpdateApiKeyLastUsed: %w", err)
+		return
+	}
+
+	return
+}
+
 func (repo *OrganizationsRepository) DeleteApiKey(ctx context.Context, db db.Queryer, apiKeyID guid.GUID) (err error) {
 	const query = `DELETE FROM api_keys WHERE id = $1`
 
@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/bloom42/stdx-go/db"
+	"github.com/bloom42/stdx-go/guid"
+	"markdown.ninja/pkg/services/organizations"
+)
+
+func (repo *OrganizationsRepository) CreateAuditLogEntry(ctx context.Context, db db.Queryer, entry organizations.AuditLogEntry) (err error) {
+	const query = `INSERT INTO audit_logs
+			(id, created_at, action, entity_type, entity_id, changes, actor_user_id, actor_api_key_id, actor_ip,
+				website_id, organization_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`
+
+	_, err = db.Exec(ctx, query, entry.ID, entry.CreatedAt, entry.Action, entry.EntityType, entry.EntityID,
+		entry.Changes, entry.ActorUserID, entry.ActorApiKeyID, entry.ActorIP, entry.WebsiteID, entry.OrganizationID)
+	if err != nil {
+		err = fmt.Errorf("organizations.CreateAuditLogEntry: %w", err)
+		return
+	}
+
+	return
+}
+
+// FindAuditLogs returns the audit logs matching the filter, from the most recent to the oldest.
+// limit is ignored if < 1.
+func (repo *OrganizationsRepository) FindAuditLogs(ctx context.Context, db db.Queryer, filter organizations.AuditLogsFilter, limit int64, after *guid.GUID) (ret []organizations.AuditLogEntry, err error) {
+	ret = make([]organizations.AuditLogEntry, 0)
+	query := `SELECT * FROM audit_logs WHERE organization_id = $1`
+	args := []any{filter.OrganizationID}
+
+	addCondition := func(condition string, arg any) {
+		args = append(args, arg)
+		query += " AND " + condition + " $" + strconv.Itoa(len(args))
+	}
+
+	if filter.WebsiteID != nil {
+		addCondition("website_id =", *filter.WebsiteID)
+	}
+	if filter.ActorUserID != nil {
+		addCondition("actor_user_id =", *filter.ActorUserID)
+	}
+	if filter.ActorApiKeyID != nil {
+		addCondition("actor_api_key_id =", *filter.ActorApiKeyID)
+	}
+	if filter.Action != nil {
+		addCondition("action =", *filter.Action)
+	}
+	if filter.EntityType != nil {
+		addCondition("entity_type =", *filter.EntityType)
+	}
+	if filter.EntityID != nil {
+		addCondition("entity_id =", *filter.EntityID)
+	}
+	if filter.From != nil {
+		addCondition("created_at >=", *filter.From)
+	}
+	if filter.To != nil {
+		addCondition("created_at <", *filter.To)
+	}
+	if after != nil {
+		addCondition("id <", *after)
+	}
+
+	query += " ORDER BY id DESC"
+	if limit > 0 {
+		args = append(args, limit)
+		query += " LIMIT $" + strconv.Itoa(len(args))
+	}
+
+	err = db.Select(ctx, &ret, query, args...)
+	if err != nil {
+		err = fmt.Errorf("organizations.FindAuditLogs: %w", err)
+		return
+	}
+
+	return
+}
@@ -17,15 +17,16 @@ func (repo *OrganizationsRepository) CreateStaffInvitations(ctx context.Context,
 	}
 
 	query := `INSERT INTO staff_invitations
-            (id, created_at, updated_at, role, invitee_email, organization_id, inviter_id) VALUES`
+            (id, created_at, updated_at, role, invitee_email, organization_id, inviter_id, website_id) VALUES`
 
-	args := make([]any, 0, len(invitations)*7)
+	args := make([]any, 0, len(invitations)*8)
 	for _, invitation := range invitations {
 		args = append(args, invitation.ID, invitation.CreatedAt, invitation.UpdatedAt,
-			invitation.Role, invitation.InviteeEmail, invitation.OrganizationID, invitation.InviterID)
+			invitation.Role, invitation.InviteeEmail, invitation.OrganizationID, invitation.InviterID,
+			invitation.WebsiteID)
 	}
 
-	query, err = dbx.BuildQuery(query, 7, args)
+	query, err = dbx.BuildQuery(query, 8, args)
 	if err != nil {
 		return fmt.Errorf("organizations.CreateStaffInvitations: %w", err)
 	}
@@ -13,11 +13,11 @@ import (
 
 func (repo *OrganizationsRepository) CreateStaff(ctx context.Context, db db.Queryer, staff organizations.Staff) (err error) {
 	const query = `INSERT INTO staffs
-	(created_at, updated_at, role, user_id, organization_id)
-	VALUES ($1, $2, $3, $4, $5)`
+	(created_at, updated_at, role, user_id, organization_id, website_id)
+	VALUES ($1, $2, $3, $4, $5, $6)`
 
 	_, err = db.Exec(ctx, query, staff.CreatedAt, staff.UpdatedAt,
-		staff.Role, staff.UserID, staff.OrganizationID)
+		staff.Role, staff.UserID, staff.OrganizationID, staff.WebsiteID)
 	if err != nil {
 		err = fmt.Errorf("organizations.CreateStaff: %w", err)
 		return
@@ -32,12 +32,17 @@ type Service interface {
 	AddStaffs(ctx context.Context, input AddStaffsInput) (ret []StaffWithDetails, err error)
 
 	// ApiKeys
-	CheckCurrentApiKey(ctx context.Context, organizationID guid.GUID) (apiKey ApiKey, err error)
+	CheckCurrentApiKey(ctx context.Context, organizationID, websiteID guid.GUID, scope ApiKeyScope) (apiKey ApiKey, err error)
 	CreateApiKey(ctx context.Context, input CreateApiKeyInput) (newApiKey ApiKeyWithToken, err error)
 	VerifyApiKey(ctx context.Context, tokenStr string) (apiKey ApiKey, err error)
 	DeleteApiKey(ctx context.Context, input DeleteApiKeyInput) (err error)
 	UpdateApiKey(ctx context.Context, input UpdateApiKeyInput) (apiKey ApiKey, err error)
 
+	// Audit logs
+	RecordAuditLog(ctx context.Context, db db.Queryer, input RecordAuditLogInput) (err error)
+	ListAuditLogs(ctx context.Context, input ListAuditLogsInput) (ret kernel.PaginatedResult[AuditLogEntry], err error)
+	ExportAuditLogs(ctx context.Context, input ExportAuditLogsInput) (ret ExportAuditLogsOutput, err error)
+
 	// Billing
 	HandleStripeEvent(ctx context.Context, stripeEvent stripe.Event) (err error)
 	UpdateSubscription(ctx context.Context, input UpdateSubscriptionInput) (ret UpdateSubscriptionOutput, err error)
@@ -46,6 +51,7 @@ type Service interface {
 	SyncStripe(ctx context.Context, input SyncStripeInput) (err error)
 	GetBillingUsage(ctx context.Context, input GetBillingUsageInput) (usage BillingUsage, err error)
 	CheckBillingGatedAction(ctx context.Context, db db.Queryer, organizationID guid.GUID, action BillingGatedAction) (err error)
+	FindPlanForOrganization(ctx context.Context, db db.Queryer, organizationID guid.GUID) (plan kernel.Plan, err error)
 
 	// Jobs
 	JobSendStaffInvitations(ctx context.Context, input JobSendStaffInvitations) (err error)
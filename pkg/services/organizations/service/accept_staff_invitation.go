@@ -44,9 +44,10 @@ func (service *OrganizationsService) AcceptStaffInvitation(ctx context.Context,
 	staff := organizations.Staff{
 		CreatedAt:      now,
 		UpdatedAt:      now,
-		Role:           organizations.StaffRoleAdministrator,
+		Role:           invitation.Role,
 		UserID:         actorID,
 		OrganizationID: invitation.OrganizationID,
+		WebsiteID:      invitation.WebsiteID,
 	}
 
 	err = service.db.Transaction(ctx, func(tx db.Tx) (txErr error) {
@@ -72,7 +72,7 @@ func (service *OrganizationsService) AddStaffs(ctx context.Context, input organi
 			newStaff := organizations.Staff{
 				CreatedAt:      now,
 				UpdatedAt:      now,
-				Role:           organizations.StaffRoleAdministrator,
+				Role:           organizations.StaffRoleOwner,
 				UserID:         userID,
 				OrganizationID: input.OrganizationID,
 			}
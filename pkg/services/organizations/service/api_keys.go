@@ -6,6 +6,7 @@ import (
 	base32std "encoding/base32"
 	"encoding/binary"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
@@ -13,9 +14,11 @@ import (
 	"github.com/bloom42/stdx-go/crypto"
 	"github.com/bloom42/stdx-go/crypto/blake3"
 	"github.com/bloom42/stdx-go/guid"
+	"github.com/bloom42/stdx-go/slicesx"
 	"markdown.ninja/pkg/server/httpctx"
 	"markdown.ninja/pkg/services/kernel"
 	"markdown.ninja/pkg/services/organizations"
+	"markdown.ninja/pkg/services/websites"
 )
 
 const (
@@ -35,7 +38,9 @@ type parsedApiKey struct {
 	secret  []byte
 }
 
-func (service *OrganizationsService) CheckCurrentApiKey(ctx context.Context, organizationID guid.GUID) (apiKey organizations.ApiKey, err error) {
+// CheckCurrentApiKey verifies that the API key of the current request belongs to the organization of the website,
+// and that it has the scope required to perform the action on this website.
+func (service *OrganizationsService) CheckCurrentApiKey(ctx context.Context, organizationID, websiteID guid.GUID, scope organizations.ApiKeyScope) (apiKey organizations.ApiKey, err error) {
 	httpCtx := httpctx.FromCtx(ctx)
 	if httpCtx == nil || httpCtx.ApiKey == nil {
 		err = organizations.ErrApiKeyIsMissing
@@ -49,10 +54,37 @@ func (service *OrganizationsService) CheckCurrentApiKey(ctx context.Context, org
 		return
 	}
 
+	if !apiKey.Can(websiteID, scope) {
+		err = kernel.ErrPermissionDenied
+		return
+	}
+
+	return
+}
+
+// cleanAndValidateApiKeyWebsites verifies that all the websites belong to the organization and removes duplicates
+func (service *OrganizationsService) cleanAndValidateApiKeyWebsites(ctx context.Context, organizationID guid.GUID, websiteIDs []guid.GUID) (ret organizations.ApiKeyWebsites, err error) {
+	ret = slicesx.Unique(websiteIDs)
+	if len(ret) == 0 {
+		return
+	}
+
+	organizationWebsites, err := service.websitesService.FindWebsitesForOrganization(ctx, service.db, organizationID)
+	if err != nil {
+		return
+	}
+
+	for _, websiteID := range ret {
+		if !slices.ContainsFunc(organizationWebsites, func(website websites.Website) bool { return website.ID.Equal(websiteID) }) {
+			err = websites.ErrWebsiteNotFound
+			return
+		}
+	}
+
 	return
 }
 
-func (service *OrganizationsService) generateApiKey(organizationID guid.GUID, name string) (ret organizations.ApiKeyWithToken, err error) {
+func (service *OrganizationsService) generateApiKey(organizationID guid.GUID, name string, scopes organizations.ApiKeyScopes, websiteIDs organizations.ApiKeyWebsites) (ret organizations.ApiKeyWithToken, err error) {
 	var secret [apiKeySecretSize]byte
 	var tokenData [apiKeyIdSize + apiKeySecretSize]byte
 	// we use time-based UUIDs because we need as much performance as possible
@@ -82,6 +114,10 @@ func (service *OrganizationsService) generateApiKey(organizationID guid.GUID, na
 			Name:           name,
 			Version:        1,
 			Hash:           hash[:],
+			Scopes:         scopes,
+			Websites:       websiteIDs,
+			LastUsedAt:     nil,
+			LastUsedIP:     nil,
 			OrganizationID: organizationID,
 		},
 		Token: token,
@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bloom42/stdx-go/db"
+	"github.com/bloom42/stdx-go/guid"
+	"markdown.ninja/pkg/errs"
+	"markdown.ninja/pkg/server/httpctx"
+	"markdown.ninja/pkg/services/kernel"
+	"markdown.ninja/pkg/services/organizations"
+	"markdown.ninja/pkg/services/websites"
+)
+
+// organizationWebsitesService is a fake websites.Service that only implements FindWebsitesForOrganization
+type organizationWebsitesService struct {
+	websites.Service
+	websites []websites.Website
+}
+
+func (service organizationWebsitesService) FindWebsitesForOrganization(ctx context.Context, db db.Queryer, organizationID guid.GUID) (ret []websites.Website, err error) {
+	for _, website := range service.websites {
+		if website.OrganizationID.Equal(organizationID) {
+			ret = append(ret, website)
+		}
+	}
+	return
+}
+
+func TestCleanAndValidateApiKeyScopes(t *testing.T) {
+	service := &OrganizationsService{}
+
+	scopes, err := service.cleanAndValidateApiKeyScopes([]organizations.ApiKeyScope{
+		organizations.ApiKeyScopeContentRead, organizations.ApiKeyScopeContactsWrite, organizations.ApiKeyScopeContentRead,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(scopes) != 2 {
+		t.Errorf("duplicate scopes should be removed, got: %v", scopes)
+	}
+
+	_, err = service.cleanAndValidateApiKeyScopes(organizations.AllApiKeyScopes)
+	if err != nil {
+		t.Errorf("all the scopes should be valid: %s", err)
+	}
+
+	_, err = service.cleanAndValidateApiKeyScopes(nil)
+	if !errors.Is(err, organizations.ErrApiKeyScopesAreEmpty) {
+		t.Errorf("empty scopes: expected ErrApiKeyScopesAreEmpty, got: %v", err)
+	}
+
+	var invalidArgumentErr *errs.InvalidArgumentError
+	for _, scope := range []organizations.ApiKeyScope{"", "content", "content:admin", "CONTENT:READ", "*"} {
+		_, err = service.cleanAndValidateApiKeyScopes([]organizations.ApiKeyScope{organizations.ApiKeyScopeContentRead, scope})
+		if !errors.As(err, &invalidArgumentErr) {
+			t.Errorf("scope %q should not be valid, got: %v", scope, err)
+		}
+	}
+}
+
+func TestCleanAndValidateApiKeyWebsites(t *testing.T) {
+	organizationID := guid.NewTimeBased()
+	website := websites.Website{ID: guid.NewTimeBased(), OrganizationID: organizationID}
+	otherWebsite := websites.Website{ID: guid.NewTimeBased(), OrganizationID: organizationID}
+	otherOrganizationWebsite := websites.Website{ID: guid.NewTimeBased(), OrganizationID: guid.NewTimeBased()}
+
+	service := &OrganizationsService{
+		websitesService: organizationWebsitesService{
+			websites: []websites.Website{website, otherWebsite, otherOrganizationWebsite},
+		},
+	}
+
+	websiteIDs, err := service.cleanAndValidateApiKeyWebsites(context.Background(), organizationID, nil)
+	if err != nil || len(websiteIDs) != 0 {
+		t.Errorf("no websites: expected an unrestricted key, got: %v (%v)", websiteIDs, err)
+	}
+
+	websiteIDs, err = service.cleanAndValidateApiKeyWebsites(context.Background(), organizationID,
+		[]guid.GUID{website.ID, otherWebsite.ID, website.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(websiteIDs) != 2 {
+		t.Errorf("duplicate websites should be removed, got: %v", websiteIDs)
+	}
+
+	_, err = service.cleanAndValidateApiKeyWebsites(context.Background(), organizationID,
+		[]guid.GUID{website.ID, otherOrganizationWebsite.ID})
+	if !errors.Is(err, websites.ErrWebsiteNotFound) {
+		t.Errorf("website of another organization: expected ErrWebsiteNotFound, got: %v", err)
+	}
+
+	_, err = service.cleanAndValidateApiKeyWebsites(context.Background(), organizationID, []guid.GUID{guid.NewTimeBased()})
+	if !errors.Is(err, websites.ErrWebsiteNotFound) {
+		t.Errorf("unknown website: expected ErrWebsiteNotFound, got: %v", err)
+	}
+}
+
+func TestCheckCurrentApiKey(t *testing.T) {
+	service := &OrganizationsService{}
+	organizationID := guid.NewTimeBased()
+	websiteID := guid.NewTimeBased()
+	otherWebsiteID := guid.NewTimeBased()
+
+	organizationKey := &organizations.ApiKey{
+		OrganizationID: organizationID,
+		Scopes:         organizations.ApiKeyScopes{organizations.ApiKeyScopeContentRead},
+	}
+	websiteKey := &organizations.ApiKey{
+		OrganizationID: organizationID,
+		Scopes:         organizations.ApiKeyScopes{organizations.ApiKeyScopeContentRead},
+		Websites:       organizations.ApiKeyWebsites{websiteID},
+	}
+
+	tests := []struct {
+		name           string
+		apiKey         *organizations.ApiKey
+		organizationID guid.GUID
+		websiteID      guid.GUID
+		scope          organizations.ApiKeyScope
+		expectedErr    error
+	}{
+		{"organization-wide key", organizationKey, organizationID, otherWebsiteID, organizations.ApiKeyScopeContentRead, nil},
+		{"website-scoped key", websiteKey, organizationID, websiteID, organizations.ApiKeyScopeContentRead, nil},
+		{"missing key", nil, organizationID, websiteID, organizations.ApiKeyScopeContentRead, organizations.ErrApiKeyIsMissing},
+		{"other organization", organizationKey, guid.NewTimeBased(), websiteID, organizations.ApiKeyScopeContentRead, kernel.ErrPermissionDenied},
+		{"missing scope", organizationKey, organizationID, websiteID, organizations.ApiKeyScopeContentWrite, kernel.ErrPermissionDenied},
+		{"other website", websiteKey, organizationID, otherWebsiteID, organizations.ApiKeyScopeContentRead, kernel.ErrPermissionDenied},
+	}
+
+	for _, test := range tests {
+		ctx := context.WithValue(context.Background(), httpctx.CtxKey, &httpctx.Context{ApiKey: test.apiKey})
+
+		apiKey, err := service.CheckCurrentApiKey(ctx, test.organizationID, test.websiteID, test.scope)
+		if test.expectedErr == nil {
+			if err != nil {
+				t.Errorf("%s: unexpected error: %s", test.name, err)
+			} else if !apiKey.OrganizationID.Equal(organizationID) {
+				t.Errorf("%s: the current API key should be returned", test.name)
+			}
+		} else if !errors.Is(err, test.expectedErr) {
+			t.Errorf("%s: expected %v, got: %v", test.name, test.expectedErr, err)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"markdown.ninja/pkg/services/organizations"
+)
+
+// computeAuditLogChanges returns the top-level fields that differ between the JSON representations of before
+// and after.
+func computeAuditLogChanges(before, after any) (changes organizations.AuditLogChanges, err error) {
+	beforeFields, err := auditLogFields(before)
+	if err != nil {
+		return
+	}
+
+	afterFields, err := auditLogFields(after)
+	if err != nil {
+		return
+	}
+
+	changes = make(organizations.AuditLogChanges)
+	for field, beforeValue := range beforeFields {
+		afterValue, exists := afterFields[field]
+		if !exists || !bytes.Equal(beforeValue, afterValue) {
+			changes[field] = organizations.AuditLogChange{Before: beforeValue, After: afterValue}
+		}
+	}
+	for field, afterValue := range afterFields {
+		if _, exists := beforeFields[field]; !exists {
+			changes[field] = organizations.AuditLogChange{Before: nil, After: afterValue}
+		}
+	}
+
+	return
+}
+
+// auditLogFields serializes the state of an entity to JSON. Objects are split by field and other values
+// (e.g. lists) are stored under the "value" key.
+func auditLogFields(state any) (fields map[string]json.RawMessage, err error) {
+	fields = make(map[string]json.RawMessage)
+	if state == nil {
+		return
+	}
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		err = fmt.Errorf("organizations: error serializing audit log state: %w", err)
+		return
+	}
+
+	switch {
+	case bytes.Equal(data, []byte("null")):
+		return
+	case data[0] == '{':
+		err = json.Unmarshal(data, &fields)
+		if err != nil {
+			err = fmt.Errorf("organizations: error parsing audit log state: %w", err)
+			return
+		}
+	default:
+		fields["value"] = data
+	}
+
+	return
+}
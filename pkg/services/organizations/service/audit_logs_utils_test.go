@@ -0,0 +1,63 @@
+package service
+
+import (
+	"testing"
+)
+
+type auditLogTestEntity struct {
+	Name   string   `json:"name"`
+	Domain string   `json:"domain"`
+	Tags   []string `json:"tags"`
+}
+
+func TestComputeAuditLogChanges(t *testing.T) {
+	before := auditLogTestEntity{Name: "Website", Domain: "old.example.com", Tags: []string{"a"}}
+	after := auditLogTestEntity{Name: "Website", Domain: "new.example.com", Tags: []string{"a"}}
+
+	changes, err := computeAuditLogChanges(before, after)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 1 {
+		t.Fatalf("expected 1 change, got: %d (%v)", len(changes), changes)
+	}
+	if string(changes["domain"].Before) != `"old.example.com"` || string(changes["domain"].After) != `"new.example.com"` {
+		t.Errorf("unexpected change for domain: %s -> %s", changes["domain"].Before, changes["domain"].After)
+	}
+
+	// creation
+	changes, err = computeAuditLogChanges(nil, after)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 3 || changes["name"].Before != nil || string(changes["name"].After) != `"Website"` {
+		t.Errorf("unexpected changes for creation: %v", changes)
+	}
+
+	// deletion
+	var deleted *auditLogTestEntity
+	changes, err = computeAuditLogChanges(&before, deleted)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 3 || changes["tags"].After != nil || string(changes["tags"].Before) != `["a"]` {
+		t.Errorf("unexpected changes for deletion: %v", changes)
+	}
+
+	// values that are not objects
+	changes, err = computeAuditLogChanges([]string{"/a"}, []string{"/a", "/b"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 1 || string(changes["value"].After) != `["/a","/b"]` {
+		t.Errorf("unexpected changes for lists: %v", changes)
+	}
+
+	changes, err = computeAuditLogChanges(before, before)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 0 {
+		t.Errorf("expected no changes, got: %v", changes)
+	}
+}
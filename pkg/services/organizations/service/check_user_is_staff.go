@@ -22,3 +22,19 @@ func (service *OrganizationsService) CheckUserIsStaff(ctx context.Context, db db
 
 	return
 }
+
+// checkUserPermission checks that the user is staff of the organization and is granted the permission
+// for the whole organization.
+func (service *OrganizationsService) checkUserPermission(ctx context.Context, db db.Queryer, userID uuid.UUID, organizationID guid.GUID, permission kernel.Permission) (staff organizations.Staff, err error) {
+	staff, err = service.CheckUserIsStaff(ctx, db, userID, organizationID)
+	if err != nil {
+		return
+	}
+
+	if !staff.Can(permission) {
+		err = kernel.ErrPermissionDenied
+		return
+	}
+
+	return
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"strings"
 
+	"github.com/bloom42/stdx-go/db"
 	"markdown.ninja/pkg/errs"
+	"markdown.ninja/pkg/services/kernel"
 	"markdown.ninja/pkg/services/organizations"
 )
 
@@ -14,7 +16,7 @@ func (service *OrganizationsService) CreateApiKey(ctx context.Context, input org
 		return
 	}
 
-	_, err = service.CheckUserIsStaff(ctx, service.db, actorID, input.OrganizationID)
+	_, err = service.checkUserPermission(ctx, service.db, actorID, input.OrganizationID, kernel.PermissionManageStaffs)
 	if err != nil {
 		return
 	}
@@ -25,6 +27,16 @@ func (service *OrganizationsService) CreateApiKey(ctx context.Context, input org
 		return
 	}
 
+	scopes, err := service.cleanAndValidateApiKeyScopes(input.Scopes)
+	if err != nil {
+		return
+	}
+
+	websiteIDs, err := service.cleanAndValidateApiKeyWebsites(ctx, input.OrganizationID, input.Websites)
+	if err != nil {
+		return
+	}
+
 	existingApiKeys, err := service.repo.FindApiKeysForOrganization(ctx, service.db, input.OrganizationID)
 	if err != nil {
 		return
@@ -34,12 +46,26 @@ func (service *OrganizationsService) CreateApiKey(ctx context.Context, input org
 		return
 	}
 
-	apiKey, err = service.generateApiKey(input.OrganizationID, name)
+	apiKey, err = service.generateApiKey(input.OrganizationID, name, scopes, websiteIDs)
 	if err != nil {
 		return
 	}
 
-	err = service.repo.CreateApiKey(ctx, service.db, apiKey.ApiKey)
+	err = service.db.Transaction(ctx, func(tx db.Tx) (txErr error) {
+		txErr = service.repo.CreateApiKey(ctx, tx, apiKey.ApiKey)
+		if txErr != nil {
+			return txErr
+		}
+
+		txErr = service.RecordAuditLog(ctx, tx, organizations.RecordAuditLogInput{
+			OrganizationID: apiKey.OrganizationID,
+			Action:         organizations.AuditLogActionApiKeyCreate,
+			EntityID:       apiKey.ID.String(),
+			Before:         nil,
+			After:          apiKey.ApiKey,
+		})
+		return txErr
+	})
 	if err != nil {
 		return
 	}
@@ -105,7 +105,7 @@ func (service *OrganizationsService) CreateOrganization(ctx context.Context, inp
 	staff := organizations.Staff{
 		CreatedAt:      now,
 		UpdatedAt:      now,
-		Role:           organizations.StaffRoleAdministrator,
+		Role:           organizations.StaffRoleOwner,
 		UserID:         actorID,
 		OrganizationID: organization.ID,
 	}
@@ -3,6 +3,8 @@ package service
 import (
 	"context"
 
+	"github.com/bloom42/stdx-go/db"
+	"markdown.ninja/pkg/services/kernel"
 	"markdown.ninja/pkg/services/organizations"
 )
 
@@ -17,12 +19,26 @@ func (service *OrganizationsService) DeleteApiKey(ctx context.Context, input org
 		return
 	}
 
-	_, err = service.CheckUserIsStaff(ctx, service.db, actorID, apiKey.OrganizationID)
+	_, err = service.checkUserPermission(ctx, service.db, actorID, apiKey.OrganizationID, kernel.PermissionManageStaffs)
 	if err != nil {
 		return
 	}
 
-	err = service.repo.DeleteApiKey(ctx, service.db, apiKey.ID)
+	err = service.db.Transaction(ctx, func(tx db.Tx) (txErr error) {
+		txErr = service.repo.DeleteApiKey(ctx, tx, apiKey.ID)
+		if txErr != nil {
+			return txErr
+		}
+
+		txErr = service.RecordAuditLog(ctx, tx, organizations.RecordAuditLogInput{
+			OrganizationID: apiKey.OrganizationID,
+			Action:         organizations.AuditLogActionApiKeyDelete,
+			EntityID:       apiKey.ID.String(),
+			Before:         apiKey,
+			After:          nil,
+		})
+		return txErr
+	})
 	if err != nil {
 		return
 	}
@@ -20,7 +20,7 @@ func (service *OrganizationsService) DeleteOrganization(ctx context.Context, inp
 		return
 	}
 
-	if staff.Role != organizations.StaffRoleAdministrator {
+	if !staff.Can(kernel.PermissionManageBilling) {
 		err = kernel.ErrPermissionDenied
 		return
 	}
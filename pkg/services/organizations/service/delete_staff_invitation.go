@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/bloom42/stdx-go/crypto"
+	"github.com/bloom42/stdx-go/db"
 	"markdown.ninja/pkg/server/httpctx"
 	"markdown.ninja/pkg/services/kernel"
 	"markdown.ninja/pkg/services/organizations"
@@ -31,12 +32,27 @@ func (service *OrganizationsService) DeleteStaffInvitation(ctx context.Context,
 			return
 		}
 
-		if actorStaff.Role != organizations.StaffRoleAdministrator {
+		if !actorStaff.Can(kernel.PermissionManageStaffs) {
 			return kernel.ErrPermissionDenied
 		}
 	}
 
-	err = service.repo.DeleteStaffInvitation(ctx, service.db, invitation.ID)
+	err = service.db.Transaction(ctx, func(tx db.Tx) (txErr error) {
+		txErr = service.repo.DeleteStaffInvitation(ctx, tx, invitation.ID)
+		if txErr != nil {
+			return txErr
+		}
+
+		txErr = service.RecordAuditLog(ctx, tx, organizations.RecordAuditLogInput{
+			OrganizationID: invitation.OrganizationID,
+			WebsiteID:      invitation.WebsiteID,
+			Action:         organizations.AuditLogActionStaffInvitationDelete,
+			EntityID:       invitation.ID.String(),
+			Before:         invitation,
+			After:          nil,
+		})
+		return txErr
+	})
 	if err != nil {
 		return
 	}
@@ -0,0 +1,89 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"time"
+
+	"github.com/bloom42/stdx-go/log/slogx"
+	"markdown.ninja/pkg/errs"
+	"markdown.ninja/pkg/services/kernel"
+	"markdown.ninja/pkg/services/organizations"
+)
+
+func (service *OrganizationsService) ExportAuditLogs(ctx context.Context, input organizations.ExportAuditLogsInput) (ret organizations.ExportAuditLogsOutput, err error) {
+	actorID, err := service.kernel.CurrentUserID(ctx)
+	if err != nil {
+		return
+	}
+	logger := slogx.FromCtx(ctx)
+
+	_, err = service.checkUserPermission(ctx, service.db, actorID, input.OrganizationID, kernel.PermissionReadAuditLogs)
+	if err != nil {
+		return
+	}
+
+	entries, err := service.repo.FindAuditLogs(ctx, service.db, input.AuditLogsFilter, 0, nil)
+	if err != nil {
+		return
+	}
+
+	csvBuffer := bytes.NewBuffer(make([]byte, 0, len(entries)*200))
+	csvWriter := csv.NewWriter(csvBuffer)
+
+	err = csvWriter.Write([]string{"id", "created_at", "action", "entity_type", "entity_id", "website_id",
+		"actor_user_id", "actor_api_key_id", "actor_ip", "changes"})
+	if err != nil {
+		errMessage := "organizations.ExportAuditLogs: writing header to csvWriter"
+		logger.Error(errMessage, slogx.Err(err))
+		err = errs.Internal(errMessage, err)
+		return
+	}
+
+	for _, entry := range entries {
+		var websiteID, actorUserID, actorApiKeyID string
+		if entry.WebsiteID != nil {
+			websiteID = entry.WebsiteID.String()
+		}
+		if entry.ActorUserID != nil {
+			actorUserID = entry.ActorUserID.String()
+		}
+		if entry.ActorApiKeyID != nil {
+			actorApiKeyID = entry.ActorApiKeyID.String()
+		}
+
+		var changes []byte
+		changes, err = json.Marshal(entry.Changes)
+		if err != nil {
+			errMessage := "organizations.ExportAuditLogs: encoding changes to JSON"
+			logger.Error(errMessage, slogx.Err(err))
+			err = errs.Internal(errMessage, err)
+			return
+		}
+
+		err = csvWriter.Write([]string{entry.ID.String(), entry.CreatedAt.UTC().Format(time.RFC3339),
+			string(entry.Action), entry.EntityType, entry.EntityID, websiteID, actorUserID, actorApiKeyID,
+			entry.ActorIP, string(changes)})
+		if err != nil {
+			errMessage := "organizations.ExportAuditLogs: writing data to csvWriter"
+			logger.Error(errMessage, slogx.Err(err))
+			err = errs.Internal(errMessage, err)
+			return
+		}
+	}
+
+	csvWriter.Flush()
+	err = csvWriter.Error()
+	if err != nil {
+		errMessage := "organizations.ExportAuditLogs: flushing csvWriter"
+		logger.Error(errMessage, slogx.Err(err))
+		err = errs.Internal(errMessage, err)
+		return
+	}
+
+	ret.AuditLogs = csvBuffer.String()
+
+	return
+}
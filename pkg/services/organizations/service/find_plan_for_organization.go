@@ -0,0 +1,29 @@
+package service
+
+import (
+	"context"
+
+	"github.com/bloom42/stdx-go/db"
+	"github.com/bloom42/stdx-go/guid"
+	"markdown.ninja/pkg/services/kernel"
+)
+
+// FindPlanForOrganization returns the billing plan of an organization.
+// When self-hosted, all the organizations have the limits of the enterprise plan.
+func (service *OrganizationsService) FindPlanForOrganization(ctx context.Context, db db.Queryer, organizationID guid.GUID) (plan kernel.Plan, err error) {
+	if service.isSelfHosted {
+		return kernel.PlanEnterprise, nil
+	}
+
+	organization, err := service.repo.FindOrganizationByID(ctx, db, organizationID, false)
+	if err != nil {
+		return
+	}
+
+	plan, ok := kernel.AllPlans[organization.Plan]
+	if !ok {
+		plan = kernel.PlanFree
+	}
+
+	return plan, nil
+}
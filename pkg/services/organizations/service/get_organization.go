@@ -2,10 +2,12 @@ package service
 
 import (
 	"context"
+	"slices"
 
 	"github.com/bloom42/stdx-go/guid"
 	"markdown.ninja/pkg/errs"
 	"markdown.ninja/pkg/server/httpctx"
+	"markdown.ninja/pkg/services/kernel"
 	"markdown.ninja/pkg/services/organizations"
 )
 
@@ -14,6 +16,16 @@ func (service *OrganizationsService) GetOrganization(ctx context.Context, input
 	var organizationID guid.GUID
 
 	if httpCtx.ApiKey != nil {
+		// API keys can only read the organization if they are not restricted to some websites,
+		// and can't read the API keys and the staffs of the organization.
+		if len(httpCtx.ApiKey.Websites) != 0 ||
+			!slices.Contains(httpCtx.ApiKey.Scopes, organizations.ApiKeyScopeWebsitesRead) ||
+			input.ApiKeys || input.Staffs {
+			return org, kernel.ErrPermissionDenied
+		}
+		if input.ID != nil && !input.ID.Equal(httpCtx.ApiKey.OrganizationID) {
+			return org, kernel.ErrPermissionDenied
+		}
 		organizationID = httpCtx.ApiKey.OrganizationID
 	} else {
 		actorID, err := service.kernel.CurrentUserID(ctx)
@@ -27,10 +39,14 @@ func (service *OrganizationsService) GetOrganization(ctx context.Context, input
 		organizationID = *input.ID
 
 		if !httpCtx.AccessToken.IsAdmin {
-			_, err = service.CheckUserIsStaff(ctx, service.db, actorID, organizationID)
+			staff, err := service.CheckUserIsStaff(ctx, service.db, actorID, organizationID)
 			if err != nil {
 				return org, err
 			}
+
+			if input.ApiKeys && !staff.Can(kernel.PermissionManageStaffs) {
+				return org, kernel.ErrPermissionDenied
+			}
 		}
 	}
 
@@ -39,6 +55,10 @@ func (service *OrganizationsService) GetOrganization(ctx context.Context, input
 		return
 	}
 
+	if httpCtx.ApiKey != nil {
+		org.BillingInformation = organizations.BillingInformation{}
+	}
+
 	if input.ApiKeys {
 		org.ApiKeys, err = service.repo.FindApiKeysForOrganization(ctx, service.db, org.ID)
 		if err != nil {
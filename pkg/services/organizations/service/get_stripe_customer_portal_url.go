@@ -33,7 +33,7 @@ func (service *OrganizationsService) GetStripeCustomerPortalUrl(ctx context.Cont
 			return
 		}
 
-		if staff.Role != organizations.StaffRoleAdministrator {
+		if !staff.Can(kernel.PermissionManageBilling) {
 			err = kernel.ErrPermissionDenied
 			return
 		}
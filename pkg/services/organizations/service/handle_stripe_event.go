@@ -166,6 +166,13 @@ func (service *OrganizationsService) handleStripeEventInvoice(ctx context.Contex
 		return service.storeService.HandleStripeEvent(ctx, stripeEvent)
 	}
 
+	// invoices of subscriptions inherit the metadata of the subscription
+	if stripeInvoice.SubscriptionDetails != nil {
+		if _, isWebsiteEvent := stripeInvoice.SubscriptionDetails.Metadata["markdown_ninja_website_id"]; isWebsiteEvent {
+			return service.storeService.HandleStripeEvent(ctx, stripeEvent)
+		}
+	}
+
 	if stripeInvoice.PaymentIntent == nil {
 		logger.Error("organizations.handleStripeEventInvoice: invoice.payment_intent is null",
 			slog.String("invoice.id", stripeInvoice.ID),
@@ -5,12 +5,14 @@ import (
 	"strings"
 	"time"
 
+	"github.com/bloom42/stdx-go/db"
 	"github.com/bloom42/stdx-go/guid"
 	"github.com/bloom42/stdx-go/log/slogx"
 	"github.com/bloom42/stdx-go/queue"
 	"github.com/bloom42/stdx-go/slicesx"
 	"markdown.ninja/pkg/services/kernel"
 	"markdown.ninja/pkg/services/organizations"
+	"markdown.ninja/pkg/services/websites"
 )
 
 func (service *OrganizationsService) InviteStaffs(ctx context.Context, input organizations.InviteStaffsInput) (invitations []organizations.StaffInvitation, err error) {
@@ -26,11 +28,43 @@ func (service *OrganizationsService) InviteStaffs(ctx context.Context, input org
 		return
 	}
 
-	if staff.Role != organizations.StaffRoleAdministrator {
+	if !staff.Can(kernel.PermissionManageStaffs) {
 		err = kernel.ErrPermissionDenied
 		return
 	}
 
+	role := organizations.StaffRoleAdministrator
+	if input.Role != nil {
+		role = *input.Role
+	}
+	err = service.validateStaffRole(role)
+	if err != nil {
+		return
+	}
+
+	// only owners can invite other owners
+	if role == organizations.StaffRoleOwner && staff.Role != organizations.StaffRoleOwner {
+		err = kernel.ErrPermissionDenied
+		return
+	}
+
+	if input.WebsiteID != nil {
+		if role == organizations.StaffRoleOwner || role == organizations.StaffRoleAdministrator {
+			err = organizations.ErrStaffRoleCantBeScoped
+			return
+		}
+
+		var website websites.Website
+		website, err = service.websitesService.FindWebsiteByID(ctx, service.db, *input.WebsiteID)
+		if err != nil {
+			return
+		}
+		if !website.OrganizationID.Equal(input.OrganizationID) {
+			err = websites.ErrWebsiteNotFound
+			return
+		}
+	}
+
 	emails := slicesx.Unique(input.Emails)
 
 	existingStaffs, err := service.getStaffsWithDetails(ctx, service.db, input.OrganizationID)
@@ -85,15 +119,37 @@ func (service *OrganizationsService) InviteStaffs(ctx context.Context, input org
 			ID:             invitationID,
 			CreatedAt:      now,
 			UpdatedAt:      now,
-			Role:           organizations.StaffRoleAdministrator,
+			Role:           role,
 			InviteeEmail:   email,
 			OrganizationID: input.OrganizationID,
 			InviterID:      actorID,
+			WebsiteID:      input.WebsiteID,
 		}
 		invitations = append(invitations, invitation)
 	}
 
-	err = service.repo.CreateStaffInvitations(ctx, service.db, invitations)
+	err = service.db.Transaction(ctx, func(tx db.Tx) (txErr error) {
+		txErr = service.repo.CreateStaffInvitations(ctx, tx, invitations)
+		if txErr != nil {
+			return txErr
+		}
+
+		for _, invitation := range invitations {
+			txErr = service.RecordAuditLog(ctx, tx, organizations.RecordAuditLogInput{
+				OrganizationID: invitation.OrganizationID,
+				WebsiteID:      invitation.WebsiteID,
+				Action:         organizations.AuditLogActionStaffInvite,
+				EntityID:       invitation.ID.String(),
+				Before:         nil,
+				After:          invitation,
+			})
+			if txErr != nil {
+				return txErr
+			}
+		}
+
+		return nil
+	})
 	if err != nil {
 		return
 	}
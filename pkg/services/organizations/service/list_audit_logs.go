@@ -0,0 +1,37 @@
+package service
+
+import (
+	"context"
+
+	"markdown.ninja/pkg/errs"
+	"markdown.ninja/pkg/services/kernel"
+	"markdown.ninja/pkg/services/organizations"
+)
+
+func (service *OrganizationsService) ListAuditLogs(ctx context.Context, input organizations.ListAuditLogsInput) (ret kernel.PaginatedResult[organizations.AuditLogEntry], err error) {
+	actorID, err := service.kernel.CurrentUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	_, err = service.checkUserPermission(ctx, service.db, actorID, input.OrganizationID, kernel.PermissionReadAuditLogs)
+	if err != nil {
+		return
+	}
+
+	limit := input.Limit
+	if limit < 0 {
+		return ret, errs.InvalidArgument("limit is not valid")
+	} else if limit > 1000 {
+		return ret, errs.InvalidArgument("limit is too high. max: 1000")
+	} else if limit == 0 {
+		limit = 100 // default value
+	}
+
+	ret.Data, err = service.repo.FindAuditLogs(ctx, service.db, input.AuditLogsFilter, limit, input.After)
+	if err != nil {
+		return
+	}
+
+	return
+}
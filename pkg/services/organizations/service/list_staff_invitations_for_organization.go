@@ -13,7 +13,7 @@ func (service *OrganizationsService) ListStaffInvitationsForOrganization(ctx con
 		return
 	}
 
-	_, err = service.CheckUserIsStaff(ctx, service.db, actorID, input.OrganizationID)
+	_, err = service.checkUserPermission(ctx, service.db, actorID, input.OrganizationID, kernel.PermissionManageStaffs)
 	if err != nil {
 		return
 	}
@@ -0,0 +1,63 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/bloom42/stdx-go/db"
+	"github.com/bloom42/stdx-go/guid"
+	"markdown.ninja/pkg/server/httpctx"
+	"markdown.ninja/pkg/services/organizations"
+)
+
+// RecordAuditLog appends an entry to the audit log of the organization. The actor and their IP address
+// are extracted from the context.
+func (service *OrganizationsService) RecordAuditLog(ctx context.Context, db db.Queryer, input organizations.RecordAuditLogInput) (err error) {
+	organizationID := input.OrganizationID
+	if organizationID.IsNil() {
+		if input.WebsiteID == nil {
+			return errors.New("organizations.RecordAuditLog: organization_id or website_id is required")
+		}
+
+		website, err := service.websitesService.FindWebsiteByID(ctx, db, *input.WebsiteID)
+		if err != nil {
+			return err
+		}
+		organizationID = website.OrganizationID
+	}
+
+	changes, err := computeAuditLogChanges(input.Before, input.After)
+	if err != nil {
+		return err
+	}
+
+	entry := organizations.AuditLogEntry{
+		ID:             guid.NewTimeBased(),
+		CreatedAt:      time.Now().UTC(),
+		Action:         input.Action,
+		EntityType:     input.Action.EntityType(),
+		EntityID:       input.EntityID,
+		Changes:        changes,
+		ActorUserID:    nil,
+		ActorApiKeyID:  nil,
+		ActorIP:        "",
+		WebsiteID:      input.WebsiteID,
+		OrganizationID: organizationID,
+	}
+
+	httpCtx := httpctx.FromCtx(ctx)
+	if httpCtx != nil {
+		entry.ActorIP = httpCtx.Client.IPStr
+		if httpCtx.ApiKey != nil {
+			apiKeyID := httpCtx.ApiKey.ID
+			entry.ActorApiKeyID = &apiKeyID
+		} else if httpCtx.AccessToken != nil {
+			userID := httpCtx.AccessToken.UserID
+			entry.ActorUserID = &userID
+		}
+	}
+
+	err = service.repo.CreateAuditLogEntry(ctx, db, entry)
+	return err
+}
@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 
+	"github.com/bloom42/stdx-go/db"
 	"markdown.ninja/pkg/server/httpctx"
 	"markdown.ninja/pkg/services/kernel"
 	"markdown.ninja/pkg/services/organizations"
@@ -16,6 +17,11 @@ func (service *OrganizationsService) RemoveStaff(ctx context.Context, input orga
 		return
 	}
 
+	staffToRemove, err := service.repo.FindStaff(ctx, service.db, input.UserID, input.OrganizationID)
+	if err != nil {
+		return
+	}
+
 	accessToken := httpCtx.AccessToken
 	if !accessToken.IsAdmin {
 		actorStaff, err := service.CheckUserIsStaff(ctx, service.db, actorID, input.OrganizationID)
@@ -23,14 +29,14 @@ func (service *OrganizationsService) RemoveStaff(ctx context.Context, input orga
 			return err
 		}
 
-		if actorStaff.Role != organizations.StaffRoleAdministrator {
+		if !actorStaff.Can(kernel.PermissionManageStaffs) {
 			return kernel.ErrPermissionDenied
 		}
-	}
 
-	staffToRemove, err := service.repo.FindStaff(ctx, service.db, input.UserID, input.OrganizationID)
-	if err != nil {
-		return
+		// only owners can remove owners
+		if staffToRemove.Role == organizations.StaffRoleOwner && actorStaff.Role != organizations.StaffRoleOwner {
+			return kernel.ErrPermissionDenied
+		}
 	}
 
 	staffs, err := service.getStaffsWithDetails(ctx, service.db, input.OrganizationID)
@@ -38,19 +44,33 @@ func (service *OrganizationsService) RemoveStaff(ctx context.Context, input orga
 		return
 	}
 
-	adminsCount := 0
+	ownersCount := 0
 	for _, staff := range staffs {
-		if staff.Staff.Role == organizations.StaffRoleAdministrator {
-			adminsCount += 1
+		if staff.Staff.Role == organizations.StaffRoleOwner {
+			ownersCount += 1
 		}
 	}
 
-	if staffToRemove.Role == organizations.StaffRoleAdministrator && adminsCount < 2 {
-		err = organizations.ErrCantRemoveLastAdministrator
+	if staffToRemove.Role == organizations.StaffRoleOwner && ownersCount < 2 {
+		err = organizations.ErrCantRemoveLastOwner
 		return
 	}
 
-	err = service.repo.DeleteStaff(ctx, service.db, staffToRemove.UserID, staffToRemove.OrganizationID)
+	err = service.db.Transaction(ctx, func(tx db.Tx) (txErr error) {
+		txErr = service.repo.DeleteStaff(ctx, tx, staffToRemove.UserID, staffToRemove.OrganizationID)
+		if txErr != nil {
+			return txErr
+		}
+
+		txErr = service.RecordAuditLog(ctx, tx, organizations.RecordAuditLogInput{
+			OrganizationID: staffToRemove.OrganizationID,
+			Action:         organizations.AuditLogActionStaffRemove,
+			EntityID:       staffToRemove.UserID.String(),
+			Before:         staffToRemove,
+			After:          nil,
+		})
+		return txErr
+	})
 	if err != nil {
 		return
 	}
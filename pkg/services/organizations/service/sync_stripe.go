@@ -31,7 +31,7 @@ func (service *OrganizationsService) SyncStripe(ctx context.Context, input organ
 			return
 		}
 
-		if staff.Role != organizations.StaffRoleAdministrator {
+		if !staff.Can(kernel.PermissionManageBilling) {
 			err = kernel.ErrPermissionDenied
 			return
 		}
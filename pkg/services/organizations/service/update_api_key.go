@@ -5,6 +5,8 @@ import (
 	"strings"
 	"time"
 
+	"github.com/bloom42/stdx-go/db"
+	"markdown.ninja/pkg/services/kernel"
 	"markdown.ninja/pkg/services/organizations"
 )
 
@@ -18,21 +20,52 @@ func (service *OrganizationsService) UpdateApiKey(ctx context.Context, input org
 	if err != nil {
 		return
 	}
+	apiKeyBefore := apiKey
 
-	_, err = service.CheckUserIsStaff(ctx, service.db, actorID, apiKey.OrganizationID)
+	_, err = service.checkUserPermission(ctx, service.db, actorID, apiKey.OrganizationID, kernel.PermissionManageStaffs)
 	if err != nil {
 		return
 	}
 
-	name := strings.TrimSpace(input.Name)
-	err = service.validateApiKeyName(name)
-	if err != nil {
-		return
+	if input.Name != nil {
+		name := strings.TrimSpace(*input.Name)
+		err = service.validateApiKeyName(name)
+		if err != nil {
+			return
+		}
+		apiKey.Name = name
+	}
+
+	if input.Scopes != nil {
+		apiKey.Scopes, err = service.cleanAndValidateApiKeyScopes(*input.Scopes)
+		if err != nil {
+			return
+		}
+	}
+
+	if input.Websites != nil {
+		apiKey.Websites, err = service.cleanAndValidateApiKeyWebsites(ctx, apiKey.OrganizationID, *input.Websites)
+		if err != nil {
+			return
+		}
 	}
 
-	apiKey.Name = name
 	apiKey.UpdatedAt = time.Now().UTC()
-	err = service.repo.UpdateApiKey(ctx, service.db, apiKey)
+	err = service.db.Transaction(ctx, func(tx db.Tx) (txErr error) {
+		txErr = service.repo.UpdateApiKey(ctx, tx, apiKey)
+		if txErr != nil {
+			return txErr
+		}
+
+		txErr = service.RecordAuditLog(ctx, tx, organizations.RecordAuditLogInput{
+			OrganizationID: apiKey.OrganizationID,
+			Action:         organizations.AuditLogActionApiKeyUpdate,
+			EntityID:       apiKey.ID.String(),
+			Before:         apiKeyBefore,
+			After:          apiKey,
+		})
+		return txErr
+	})
 	if err != nil {
 		return
 	}
@@ -30,7 +30,7 @@ func (service *OrganizationsService) UpdateOrganization(ctx context.Context, inp
 			return
 		}
 
-		if staff.Role != organizations.StaffRoleAdministrator {
+		if !staff.Can(kernel.PermissionManageBilling) {
 			err = kernel.ErrPermissionDenied
 			return
 		}
@@ -53,6 +53,7 @@ func (service *OrganizationsService) UpdateOrganization(ctx context.Context, inp
 	if err != nil {
 		return
 	}
+	orgBefore := org
 
 	if input.Name != nil {
 		name := strings.TrimSpace(*input.Name)
@@ -130,6 +131,17 @@ func (service *OrganizationsService) UpdateOrganization(ctx context.Context, inp
 		return
 	}
 
+	err = service.RecordAuditLog(ctx, tx, organizations.RecordAuditLogInput{
+		OrganizationID: org.ID,
+		Action:         organizations.AuditLogActionOrganizationUpdate,
+		EntityID:       org.ID.String(),
+		Before:         orgBefore,
+		After:          org,
+	})
+	if err != nil {
+		return
+	}
+
 	err = tx.Commit()
 	if err != nil {
 		err = fmt.Errorf("organizations.UpdateOrganization: Comitting DB transaction: %w", err)
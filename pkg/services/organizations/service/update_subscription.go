@@ -38,7 +38,7 @@ func (service *OrganizationsService) UpdateSubscription(ctx context.Context, inp
 			return
 		}
 
-		if staff.Role != organizations.StaffRoleAdministrator {
+		if !staff.Can(kernel.PermissionManageBilling) {
 			err = kernel.ErrPermissionDenied
 			return
 		}
@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 	"unicode/utf8"
@@ -10,6 +11,7 @@ import (
 	"github.com/bloom42/stdx-go/countries"
 	"github.com/bloom42/stdx-go/money/vat"
 	"github.com/bloom42/stdx-go/retry"
+	"github.com/bloom42/stdx-go/slicesx"
 	"markdown.ninja/pkg/errs"
 	"markdown.ninja/pkg/services/kernel"
 	"markdown.ninja/pkg/services/organizations"
@@ -31,6 +33,16 @@ func (service *OrganizationsService) validateOrganizationName(name string) error
 	return nil
 }
 
+func (service *OrganizationsService) validateStaffRole(role organizations.StaffRole) error {
+	switch role {
+	case organizations.StaffRoleOwner, organizations.StaffRoleAdministrator, organizations.StaffRoleEditor,
+		organizations.StaffRoleAuthor, organizations.StaffRoleViewer:
+		return nil
+	default:
+		return organizations.ErrStaffRoleIsNotValid
+	}
+}
+
 func (service *OrganizationsService) validateApiKeyName(name string) error {
 	if len(name) < organizations.ApiKeyNameMinLength {
 		return organizations.ErrApiKeyNameIsTooShort
@@ -47,6 +59,21 @@ func (service *OrganizationsService) validateApiKeyName(name string) error {
 	return nil
 }
 
+func (service *OrganizationsService) cleanAndValidateApiKeyScopes(scopes []organizations.ApiKeyScope) (ret organizations.ApiKeyScopes, err error) {
+	ret = slicesx.Unique(scopes)
+	if len(ret) == 0 {
+		return nil, organizations.ErrApiKeyScopesAreEmpty
+	}
+
+	for _, scope := range ret {
+		if !slices.Contains(organizations.AllApiKeyScopes, scope) {
+			return nil, organizations.ErrApiKeyScopeIsNotValid(scope)
+		}
+	}
+
+	return ret, nil
+}
+
 func (service *OrganizationsService) cleanAndValidateBillingInformation(ctx context.Context, actorIsAdmin bool, billingInfo *organizations.BillingInformation) error {
 	billingInfo.Name = strings.TrimSpace(billingInfo.Name)
 	if billingInfo.Name == "" {
@@ -2,11 +2,18 @@ package service
 
 import (
 	"context"
+	"time"
 
+	"github.com/bloom42/stdx-go/log/slogx"
 	"markdown.ninja/pkg/errs"
+	"markdown.ninja/pkg/server/httpctx"
 	"markdown.ninja/pkg/services/organizations"
 )
 
+// we don't update the last usage of API keys on each request to avoid a write to the database for every
+// API call. e.g. when publishing a website with hundreds of pages.
+const apiKeyLastUsedUpdateInterval = time.Minute
+
 func (service *OrganizationsService) VerifyApiKey(ctx context.Context, tokenStr string) (apiKey organizations.ApiKey, err error) {
 	parsedApiKey, err := service.parseApiKey(tokenStr)
 	if err != nil {
@@ -31,5 +38,28 @@ func (service *OrganizationsService) VerifyApiKey(ctx context.Context, tokenStr
 		return
 	}
 
+	now := time.Now().UTC()
+	if apiKey.ExpiresAt != nil && apiKey.ExpiresAt.Before(now) {
+		err = organizations.ErrApiKeyHasExpired
+		return
+	}
+
+	var clientIP string
+	if httpCtx := httpctx.FromCtx(ctx); httpCtx != nil {
+		clientIP = httpCtx.Client.IPStr
+	}
+	if apiKey.LastUsedAt == nil || now.Sub(*apiKey.LastUsedAt) > apiKeyLastUsedUpdateInterval ||
+		apiKey.LastUsedIP == nil || *apiKey.LastUsedIP != clientIP {
+		// failing to track the usage of an API key should not block the request
+		updateErr := service.repo.UpdateApiKeyLastUsed(ctx, service.db, apiKey.ID, now, clientIP)
+		if updateErr != nil {
+			logger := slogx.FromCtx(ctx)
+			logger.Error("organizations.VerifyApiKey: updating last usage of API key", slogx.Err(updateErr))
+		} else {
+			apiKey.LastUsedAt = &now
+			apiKey.LastUsedIP = &clientIP
+		}
+	}
+
 	return
 }
@@ -1,6 +1,11 @@
 package site
 
-import "markdown.ninja/pkg/errs"
+import (
+	"fmt"
+	"strings"
+
+	"markdown.ninja/pkg/errs"
+)
 
 var (
 	// Contacts
@@ -15,4 +20,25 @@ var (
 
 	// content
 	ErrRangeRequestIsNotValid = errs.InvalidArgument("Range request is not valid")
+
+	// headless API
+	ErrHeadlessFieldIsNotValid = func(field string) error {
+		return errs.InvalidArgument(fmt.Sprintf("Field \"%s\" is not valid. Valid fields: %s", field, strings.Join(HeadlessPageFields, ", ")))
+	}
+	ErrHeadlessBodyFormatIsNotValid = errs.InvalidArgument(fmt.Sprintf("format is not valid. Valid values: [%s, %s]", HeadlessBodyFormatHtml, HeadlessBodyFormatMarkdown))
+	ErrHeadlessLimitIsNotValid      = errs.InvalidArgument(fmt.Sprintf("limit is not valid (min: 1, max: %d)", HeadlessPagesMaxLimit))
+	ErrHeadlessCursorIsNotValid     = errs.InvalidArgument("after is not a valid cursor")
+	ErrHeadlessDateIsNotValid       = func(param string) error {
+		return errs.InvalidArgument(fmt.Sprintf("%s is not a valid date. Use the YYYY-MM-DD or the RFC 3339 format", param))
+	}
+	ErrHeadlessDraftsRequirePreviewToken = errs.InvalidArgument("A preview_token is required to access drafts")
+
+	// GraphQL
+	ErrGraphqlQueryIsMissing                      = errs.InvalidArgument("query is missing")
+	ErrGraphqlQueryIsTooLong                      = errs.InvalidArgument(fmt.Sprintf("query is too long (max: %d bytes)", GraphqlQueryMaxLength))
+	ErrGraphqlVariablesAreNotValid                = errs.InvalidArgument("variables must be a JSON object")
+	ErrGraphqlExtensionsAreNotValid               = errs.InvalidArgument("extensions are not valid")
+	ErrGraphqlPersistedQueryIsNotValid            = errs.InvalidArgument("persistedQuery.sha256Hash does not match the query")
+	ErrGraphqlPersistedQueryVersionIsNotSupported = errs.InvalidArgument("persistedQuery.version is not supported")
+	ErrGraphqlRelatedPostsLimitIsNotValid         = errs.InvalidArgument(fmt.Sprintf("limit is not valid (min: 1, max: %d)", GraphqlRelatedPostsMaxLimit))
 )
@@ -1,6 +1,7 @@
 package site
 
 import (
+	"encoding/json"
 	"html/template"
 	"regexp"
 	"time"
@@ -24,6 +25,19 @@ const (
 
 	// allows for file size up to 999,999,999,999 bytes
 	RangeHeaderMaxSize = 31
+
+	HeadlessPagesDefaultLimit = 20
+	HeadlessPagesMaxLimit     = 100
+
+	// GraphqlQueryMaxLength is the maximum length in bytes of GraphQL queries
+	GraphqlQueryMaxLength = 20_000
+	// GraphqlMaxDepth is the maximum nesting of the selection sets of GraphQL queries
+	GraphqlMaxDepth = 10
+	// GraphqlMaxCost is the maximum cost of GraphQL queries. Fields that need a database query cost 10,
+	// and the cost of the selections of a list is multiplied by its (maximum) size.
+	GraphqlMaxCost = 5_000
+	// GraphqlRelatedPostsMaxLimit is the maximum number of related posts of a page
+	GraphqlRelatedPostsMaxLimit = 20
 )
 
 var (
@@ -31,6 +45,27 @@ var (
 	RangeHeaderRegexp  = regexp.MustCompile(`bytes=(\d+)-(\d*)`)
 )
 
+// HeadlessPageFields are the fields of pages that can be selected with the fields parameter of the
+// headless content API
+var HeadlessPageFields = []string{
+	"id",
+	"type",
+	"status",
+	"date",
+	"modified_at",
+	"title",
+	"path",
+	"url",
+	"description",
+	"language",
+	"paid_only",
+	"body_hash",
+	"metadata_hash",
+	"tags",
+	"authors",
+	"body",
+}
+
 ////////////////////////////////////////////////////////////////////////////////////////////////////
 // Model
 ////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -54,12 +89,25 @@ type Website struct {
 	Logo         *string                    `json:"logo"`
 	PoweredBy    bool                       `json:"powered_by"`
 	Theme        string                     `json:"theme"`
+	// Languages is empty if the website is not multilingual
+	Languages []WebsiteLanguage `json:"languages"`
 
 	// TODO
 	Header template.HTML `json:"-"`
 	Footer template.HTML `json:"-"`
 }
 
+// WebsiteLanguage is a language of a multilingual website, used to render language switchers
+type WebsiteLanguage struct {
+	Code string `json:"code"`
+	// Name is the native name of the language. e.g. Français
+	Name string `json:"name"`
+	// Url is the home of the language: the path prefix of the language if it has one, or the website's home
+	Url template.URL `json:"url"`
+	// Navigation is the translated navigation of the website. null if the website's navigation should be used
+	Navigation *websites.WebsiteNavigation `json:"navigation"`
+}
+
 type PageMetadata struct {
 	Date         time.Time        `json:"date"`
 	ModifiedAt   time.Time        `json:"modified_at"`
@@ -71,12 +119,118 @@ type PageMetadata struct {
 	Language     string           `json:"language"`
 	BodyHash     kernel.BytesHex  `json:"body_hash"`
 	MetadataHash kernel.BytesHex  `json:"metadata_hash"`
+	PaidOnly     bool             `json:"paid_only"`
 }
 
 type Page struct {
 	PageMetadata
-	Tags []Tag  `json:"tags"`
+	Tags    []Tag    `json:"tags"`
+	Authors []Author `json:"authors"`
+	// Body only contains a teaser of paid-only pages if the visitor doesn't have an active subscription
 	Body string `json:"body"`
+	// Paywall is not null when Body is a teaser
+	Paywall *Paywall `json:"paywall"`
+	// Translations are the versions of the page in other languages
+	Translations []PageTranslation `json:"translations"`
+}
+
+type PageTranslation struct {
+	Language string       `json:"language"`
+	Title    string       `json:"title"`
+	Url      template.URL `json:"url"`
+}
+
+// HeadlessPage is a page returned by the headless content API. It only contains the fields
+// selected by the client.
+type HeadlessPage map[string]any
+
+type HeadlessPagesResult struct {
+	Data []HeadlessPage `json:"data"`
+	// NextCursor is null when there are no more pages
+	NextCursor *string `json:"next_cursor"`
+}
+
+// GraphqlInput is a GraphQL request, either sent as the JSON body of a POST request or as the query
+// parameters of a GET request. variables and extensions are JSON-encoded in query parameters.
+type GraphqlInput struct {
+	Query         *string           `json:"query" schema:"query"`
+	OperationName *string           `json:"operationName" schema:"operationName"`
+	Variables     GraphqlVariables  `json:"variables" schema:"variables"`
+	Extensions    GraphqlExtensions `json:"extensions" schema:"extensions"`
+}
+
+// GraphqlVariables is a struct and not a map because maps can't be decoded from query parameters
+type GraphqlVariables struct {
+	Values map[string]any
+}
+
+func (variables GraphqlVariables) MarshalJSON() ([]byte, error) {
+	return json.Marshal(variables.Values)
+}
+
+func (variables *GraphqlVariables) UnmarshalText(data []byte) error {
+	return variables.UnmarshalJSON(data)
+}
+
+func (variables *GraphqlVariables) UnmarshalJSON(data []byte) error {
+	var values map[string]any
+	err := json.Unmarshal(data, &values)
+	if err != nil {
+		return ErrGraphqlVariablesAreNotValid
+	}
+	variables.Values = values
+	return nil
+}
+
+type GraphqlExtensions struct {
+	// PersistedQuery is compatible with the Automatic Persisted Queries of Apollo
+	PersistedQuery *GraphqlPersistedQuery `json:"persistedQuery"`
+}
+
+type GraphqlPersistedQuery struct {
+	Version int64 `json:"version"`
+	// Sha256Hash is the hex-encoded SHA-256 hash of the query
+	Sha256Hash string `json:"sha256Hash"`
+}
+
+func (extensions *GraphqlExtensions) UnmarshalText(data []byte) error {
+	return extensions.UnmarshalJSON(data)
+}
+
+func (extensions *GraphqlExtensions) UnmarshalJSON(data []byte) error {
+	// avoid infinite recursion
+	type graphqlExtensions GraphqlExtensions
+	var ret graphqlExtensions
+
+	err := json.Unmarshal(data, &ret)
+	if err != nil {
+		return ErrGraphqlExtensionsAreNotValid
+	}
+	*extensions = GraphqlExtensions(ret)
+	return nil
+}
+
+type HeadlessBodyFormat string
+
+const (
+	HeadlessBodyFormatHtml     HeadlessBodyFormat = "html"
+	HeadlessBodyFormatMarkdown HeadlessBodyFormat = "markdown"
+)
+
+type Paywall struct {
+	Currency websites.Currency  `json:"currency"`
+	Plans    []SubscriptionPlan `json:"plans"`
+}
+
+// SubscriptionPlan is a subscription product that can be purchased to get access to paid-only pages
+type SubscriptionPlan struct {
+	ProductID   guid.GUID `json:"product_id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	// MonthlyPrice and YearlyPrice are in the currency of the website. YearlyPrice is 0 if the plan
+	// can't be paid yearly.
+	MonthlyPrice int64 `json:"monthly_price"`
+	YearlyPrice  int64 `json:"yearly_price"`
 }
 
 type Tag struct {
@@ -84,6 +238,16 @@ type Tag struct {
 	Description string `json:"description"`
 }
 
+type Author struct {
+	Slug        string                     `json:"slug"`
+	Name        string                     `json:"name"`
+	Bio         string                     `json:"bio"`
+	Avatar      *string                    `json:"avatar"`
+	SocialLinks []content.AuthorSocialLink `json:"social_links"`
+	// Url is the URL of the author's page: /authors/{slug}
+	Url template.URL `json:"url"`
+}
+
 type Contact struct {
 	Name                   string `json:"name"`
 	Email                  string `json:"email"`
@@ -134,14 +298,20 @@ type ProductPage struct {
 ////////////////////////////////////////////////////////////////////////////////////////////////////
 
 type ListPagesInput struct {
-	Tag  *string           `schema:"tag"`
-	Type *content.PageType `schema:"type"`
+	Tag *string `schema:"tag"`
+	// Author is the slug of an author
+	Author *string           `schema:"author"`
+	Type   *content.PageType `schema:"type"`
 }
 
 type GetPageInput struct {
 	Slug *string `schema:"slug"`
 }
 
+type GetAuthorInput struct {
+	Slug *string `schema:"slug"`
+}
+
 type ServeContentInput struct {
 	Path string
 }
@@ -150,6 +320,18 @@ type TrackEventPageViewInput struct {
 	Path              string `json:"path"`
 	HeaderReferrer    string `json:"header_referrer"`
 	QueryParameterRef string `json:"query_parameter_ref"`
+	UtmSource         string `json:"utm_source"`
+	UtmMedium         string `json:"utm_medium"`
+	UtmCampaign       string `json:"utm_campaign"`
+	UtmTerm           string `json:"utm_term"`
+	UtmContent        string `json:"utm_content"`
+}
+
+type TrackEventCustomInput struct {
+	Name string `json:"name"`
+	// Path is the path of the page where the event happened
+	Path       string            `json:"path"`
+	Properties map[string]string `json:"properties"`
 }
 
 type LoginInput struct {
@@ -205,5 +387,42 @@ type GetProductInput struct {
 }
 
 type SearchInput struct {
-	Query string `json:"query"`
+	Query    string            `schema:"q"`
+	Language *string           `schema:"lang"`
+	Type     *content.PageType `schema:"type"`
+}
+
+type HeadlessListPagesInput struct {
+	Type *content.PageType `schema:"type"`
+	Tag  *string           `schema:"tag"`
+	// Language is the language of the pages
+	Language *string `schema:"lang"`
+	// From and To are inclusive and are either dates (YYYY-MM-DD) or RFC 3339 timestamps
+	From *string `schema:"from"`
+	To   *string `schema:"to"`
+	// After is the next_cursor returned with the previous batch of results
+	After *string `schema:"after"`
+	Limit *int64  `schema:"limit"`
+	// Fields is a comma-separated list of fields. By default all the fields but body are returned.
+	Fields *string             `schema:"fields"`
+	Format *HeadlessBodyFormat `schema:"format"`
+	// Drafts also returns draft and scheduled pages. It requires a preview token.
+	Drafts       bool    `schema:"drafts"`
+	PreviewToken *string `schema:"preview_token"`
+}
+
+type HeadlessGetPageInput struct {
+	Path *string `schema:"path"`
+	// Fields is a comma-separated list of fields. By default all the fields are returned.
+	Fields *string             `schema:"fields"`
+	Format *HeadlessBodyFormat `schema:"format"`
+	// PreviewToken gives access to draft and scheduled pages
+	PreviewToken *string `schema:"preview_token"`
+}
+
+type SearchResult struct {
+	PageMetadata
+	// TitleHighlight and BodyHighlight are HTML where the matching terms are wrapped in <mark> tags
+	TitleHighlight string `json:"title_highlight"`
+	BodyHighlight  string `json:"body_highlight"`
 }
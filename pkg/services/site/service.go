@@ -2,6 +2,7 @@ package site
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 
 	"markdown.ninja/pkg/services/kernel"
@@ -30,14 +31,26 @@ type Service interface {
 	// Content
 	GetPage(ctx context.Context, input GetPageInput) (ret Page, err error)
 	ListTags(ctx context.Context, input kernel.EmptyInput) (ret kernel.PaginatedResult[Tag], err error)
+	GetAuthor(ctx context.Context, input GetAuthorInput) (ret Author, err error)
 	ListPages(ctx context.Context, input ListPagesInput) (ret kernel.PaginatedResult[PageMetadata], err error)
+	Search(ctx context.Context, input SearchInput) (ret kernel.PaginatedResult[SearchResult], err error)
+	// HeadlessListPages and HeadlessGetPage are the authenticated headless content API. They require
+	// an API key with the content:read scope.
+	HeadlessListPages(ctx context.Context, input HeadlessListPagesInput) (ret HeadlessPagesResult, err error)
+	HeadlessGetPage(ctx context.Context, input HeadlessGetPageInput) (ret HeadlessPage, err error)
+	// Graphql is the read-only GraphQL API. It requires an API key with the content:read scope.
+	// The JSON response is returned as is.
+	Graphql(ctx context.Context, input GraphqlInput) (ret json.RawMessage, err error)
 	ServeContent(res http.ResponseWriter, req *http.Request)
 	ServePreview(res http.ResponseWriter, req *http.Request)
+	ServeEmailOpen(res http.ResponseWriter, req *http.Request)
+	ServeEmailClick(res http.ResponseWriter, req *http.Request)
 
 	// Others
 	// TrackEventPageView is needed by special pages (ex: /blog) that don't require a headless API
 	// call
 	TrackEventPageView(ctx context.Context, input TrackEventPageViewInput) (err error)
+	TrackEventCustom(ctx context.Context, input TrackEventCustomInput) (err error)
 
 	// Jobs
 	JobSendLoginEmail(ctx context.Context, data JobSendLoginEmail) (err error)
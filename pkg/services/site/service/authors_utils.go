@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"html/template"
+
+	"github.com/bloom42/stdx-go/guid"
+	"markdown.ninja/pkg/errs"
+	"markdown.ninja/pkg/services/content"
+	"markdown.ninja/pkg/services/site"
+	"markdown.ninja/pkg/services/websites"
+)
+
+func (service *SiteService) findAuthorsForPage(ctx context.Context, website websites.Website, pageID guid.GUID) (ret []site.Author, err error) {
+	authors, err := service.contentService.FindAuthorsForPage(ctx, service.db, pageID)
+	if err != nil {
+		return
+	}
+
+	return service.convertAuthors(ctx, website, authors)
+}
+
+// findAuthorsForPages returns the authors of the given pages indexed by page ID. Each author is
+// converted only once.
+func (service *SiteService) findAuthorsForPages(ctx context.Context, website websites.Website, pageIDs []guid.GUID) (ret map[guid.GUID][]site.Author, err error) {
+	pagesAuthors, err := service.contentService.FindAuthorsForPages(ctx, service.db, pageIDs)
+	if err != nil {
+		return
+	}
+
+	uniqueAuthors := make([]content.Author, 0)
+	authorsIndexes := make(map[guid.GUID]int)
+	for _, authors := range pagesAuthors {
+		for _, author := range authors {
+			if _, exists := authorsIndexes[author.ID]; !exists {
+				authorsIndexes[author.ID] = len(uniqueAuthors)
+				uniqueAuthors = append(uniqueAuthors, author)
+			}
+		}
+	}
+
+	convertedAuthors, err := service.convertAuthors(ctx, website, uniqueAuthors)
+	if err != nil {
+		return
+	}
+
+	ret = make(map[guid.GUID][]site.Author, len(pagesAuthors))
+	for pageID, authors := range pagesAuthors {
+		ret[pageID] = make([]site.Author, len(authors))
+		for i, author := range authors {
+			ret[pageID][i] = convertedAuthors[authorsIndexes[author.ID]]
+		}
+	}
+
+	return ret, nil
+}
+
+// convertAuthors converts the authors and resolves the URLs of their avatars.
+func (service *SiteService) convertAuthors(ctx context.Context, website websites.Website, authors []content.Author) (ret []site.Author, err error) {
+	ret = make([]site.Author, 0, len(authors))
+	websiteUrl := service.httpConfig.WebsitesBaseUrl.Scheme + "://" + website.PrimaryDomain + service.httpConfig.WebsitesPort
+	avatars := make(map[guid.GUID]string, len(authors))
+
+	for _, author := range authors {
+		var avatar *string
+		if author.AvatarID != nil {
+			avatarUrl, avatarAlreadyFetched := avatars[*author.AvatarID]
+			if !avatarAlreadyFetched {
+				var asset content.Asset
+				asset, err = service.contentService.FindWebsiteAssetByID(ctx, service.db, website.ID, *author.AvatarID)
+				if err != nil {
+					if !errs.IsNotFound(err) {
+						return
+					}
+					err = nil
+				} else {
+					avatarUrl = websiteUrl + asset.Path()
+				}
+				avatars[*author.AvatarID] = avatarUrl
+			}
+			if avatarUrl != "" {
+				avatar = &avatarUrl
+			}
+		}
+
+		socialLinks := []content.AuthorSocialLink(author.SocialLinks)
+		if socialLinks == nil {
+			socialLinks = []content.AuthorSocialLink{}
+		}
+
+		ret = append(ret, site.Author{
+			Slug:        author.Slug,
+			Name:        author.Name,
+			Bio:         author.Bio,
+			Avatar:      avatar,
+			SocialLinks: socialLinks,
+			Url:         template.URL(websiteUrl + "/authors/" + author.Slug),
+		})
+	}
+
+	return ret, nil
+}
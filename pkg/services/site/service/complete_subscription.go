@@ -15,6 +15,7 @@ import (
 	"markdown.ninja/pkg/services/events"
 	"markdown.ninja/pkg/services/kernel"
 	"markdown.ninja/pkg/services/site"
+	"markdown.ninja/pkg/services/webhooks"
 )
 
 // TODO: event
@@ -87,6 +88,8 @@ func (service *SiteService) CompleteSubscription(ctx context.Context, input site
 		countryCode = contact.CountryCode
 	}
 
+	alreadySubscribedToNewsletter := contact.SubscribedToNewsletterAt != nil
+
 	var sessionCookie *http.Cookie
 	err = service.db.Transaction(ctx, func(tx db.Tx) (txErr error) {
 		updateContactInput := contacts.UpdateContactInput{
@@ -102,6 +105,21 @@ func (service *SiteService) CompleteSubscription(ctx context.Context, input site
 			return txErr
 		}
 
+		if !alreadySubscribedToNewsletter {
+			txErr = service.webhooksService.DispatchEvent(ctx, tx, webhooks.DispatchEventInput{
+				WebsiteID: website.ID,
+				Type:      webhooks.EventTypeSubscriberAdded,
+				Data: webhooks.EventDataSubscriber{
+					ContactID: contact.ID,
+					Email:     contact.Email,
+					Name:      contact.Name,
+				},
+			})
+			if txErr != nil {
+				return txErr
+			}
+		}
+
 		createSessionInput := contacts.CreateSessionInput{
 			Verified:  true,
 			ContactID: contact.ID,
@@ -121,6 +139,8 @@ func (service *SiteService) CompleteSubscription(ctx context.Context, input site
 	retContact = service.convertContact(contact)
 
 	trackEventInput := events.TrackSubscribedToNewsletterInput{
+		IpAddress: httpCtx.Client.IP,
+		UserAgent: httpCtx.Client.UserAgent,
 		WebsiteID: website.ID,
 	}
 	service.eventsService.TrackSubscribedToNewsletter(ctx, trackEventInput)
@@ -48,8 +48,8 @@ func (service *SiteService) convertOrders(input []store.Order) []site.Order {
 	return ret
 }
 
-func (service *SiteService) convertProduct(website websites.Website, input store.Product) (ret site.Product) {
-	pages := service.convertProductPages(website, input.Content)
+func (service *SiteService) convertProduct(ctx context.Context, website websites.Website, input store.Product) (ret site.Product) {
+	pages := service.convertProductPages(ctx, website, input.Content)
 
 	ret = site.Product{
 		ID:          input.ID,
@@ -62,27 +62,27 @@ func (service *SiteService) convertProduct(website websites.Website, input store
 	return ret
 }
 
-func (service *SiteService) convertProducts(website websites.Website, input []store.Product) (ret []site.Product) {
+func (service *SiteService) convertProducts(ctx context.Context, website websites.Website, input []store.Product) (ret []site.Product) {
 	ret = make([]site.Product, len(input))
 
 	for i, item := range input {
-		ret[i] = service.convertProduct(website, item)
+		ret[i] = service.convertProduct(ctx, website, item)
 	}
 
 	return ret
 }
 
-func (service *SiteService) convertProductPage(website websites.Website, input store.ProductPage) (ret site.ProductPage) {
+func (service *SiteService) convertProductPage(ctx context.Context, website websites.Website, input store.ProductPage) (ret site.ProductPage) {
 	ret = site.ProductPage{
 		ID:       input.ID,
 		Position: input.Position,
 		Title:    input.Title,
-		Body:     service.contentService.RenderMarkdown(website, input.BodyMarkdown, nil, false),
+		Body:     service.contentService.RenderMarkdown(ctx, website, input.BodyMarkdown, nil, false),
 	}
 	return ret
 }
 
-func (service *SiteService) convertProductPages(website websites.Website, input []store.ProductPage) (ret []site.ProductPage) {
+func (service *SiteService) convertProductPages(ctx context.Context, website websites.Website, input []store.ProductPage) (ret []site.ProductPage) {
 	if input == nil {
 		return ret
 	}
@@ -90,7 +90,7 @@ func (service *SiteService) convertProductPages(website websites.Website, input
 	ret = make([]site.ProductPage, len(input))
 
 	for i, item := range input {
-		ret[i] = service.convertProductPage(website, item)
+		ret[i] = service.convertProductPage(ctx, website, item)
 	}
 
 	return ret
@@ -111,37 +111,58 @@ func (service *SiteService) convertWebsite(input websites.Website) site.Website
 		Logo:         input.Logo,
 		PoweredBy:    input.PoweredBy,
 		Theme:        input.Theme,
+		Languages:    service.convertWebsiteLanguages(input, template.URL(url)),
 	}
 }
 
-func (service *SiteService) convertPage(ctx context.Context, website websites.Website, input content.Page, tags []content.Tag, snippets []content.Snippet) (ret site.Page) {
-	logger := slogx.FromCtx(ctx)
-
+// convertPage renders the body of the page. If the visitor doesn't have access to the page (see pageAccess),
+// only the teaser of the page is rendered.
+func (service *SiteService) convertPage(ctx context.Context, website websites.Website, input content.Page, tags []content.Tag, authors []site.Author,
+	translations []site.PageTranslation, snippets []content.Snippet, hasAccess bool) (ret site.Page) {
 	if tags == nil {
 		tags = []content.Tag{}
 	}
-
-	var bodyHtml string
-
-	bodyHtmlCacheKey := generatePageBodyHtmlCacheKey(input)
-	cachedBodyHtml := service.pagesBodyHtmlCache.Get(bodyHtmlCacheKey)
-	if cachedBodyHtml != nil {
-		logger.Debug("site.convertPage: HTML page body cache hit")
-		bodyHtml = string(cachedBodyHtml.Value())
-	} else {
-		logger.Debug("site.convertPage: HTML page body cache miss")
-		bodyHtml = service.contentService.RenderMarkdown(website, input.BodyMarkdown, snippets, false)
-		service.pagesBodyHtmlCache.Set(bodyHtmlCacheKey, []byte(bodyHtml), memorycache.DefaultTTL)
+	if authors == nil {
+		authors = []site.Author{}
+	}
+	if translations == nil {
+		translations = []site.PageTranslation{}
 	}
 
 	ret = site.Page{
 		PageMetadata: service.convertPageToMetadata(website, input),
 		Tags:         service.convertTags(tags),
-		Body:         bodyHtml,
+		Authors:      authors,
+		Body:         service.renderPageBodyHtml(ctx, website, input, snippets, hasAccess),
+		Translations: translations,
 	}
 	return ret
 }
 
+// renderPageBodyHtml renders the body of the page, or only its teaser if the visitor doesn't have access
+// to the page. The rendered HTML is cached.
+func (service *SiteService) renderPageBodyHtml(ctx context.Context, website websites.Website, page content.Page,
+	snippets []content.Snippet, hasAccess bool) (bodyHtml string) {
+	logger := slogx.FromCtx(ctx)
+
+	bodyMarkdown := page.BodyMarkdown
+	bodyHtmlCacheKey := generatePageBodyHtmlCacheKey(page)
+	if !hasAccess {
+		bodyMarkdown = content.PaidPageTeaser(page.BodyMarkdown)
+		bodyHtmlCacheKey = "teaser-" + bodyHtmlCacheKey
+	}
+	cachedBodyHtml := service.pagesBodyHtmlCache.Get(bodyHtmlCacheKey)
+	if cachedBodyHtml != nil {
+		logger.Debug("site.renderPageBodyHtml: HTML page body cache hit")
+		return string(cachedBodyHtml.Value())
+	}
+
+	logger.Debug("site.renderPageBodyHtml: HTML page body cache miss")
+	bodyHtml = service.contentService.RenderMarkdown(ctx, website, bodyMarkdown, snippets, false)
+	service.pagesBodyHtmlCache.Set(bodyHtmlCacheKey, []byte(bodyHtml), memorycache.DefaultTTL)
+	return bodyHtml
+}
+
 func (service *SiteService) convertTags(input []content.Tag) []site.Tag {
 	ret := make([]site.Tag, len(input))
 
@@ -169,6 +190,7 @@ func (service *SiteService) convertPageToMetadata(website websites.Website, page
 		Url:          template.URL(url),
 		BodyHash:     page.BodyHash,
 		MetadataHash: page.MetadataHash,
+		PaidOnly:     page.PaidOnly,
 	}
 }
 
@@ -186,6 +208,7 @@ func (service *SiteService) convertPageMetadata(website websites.Website, page c
 		Url:          template.URL(url),
 		BodyHash:     page.BodyHash,
 		MetadataHash: page.MetadataHash,
+		PaidOnly:     page.PaidOnly,
 	}
 }
 
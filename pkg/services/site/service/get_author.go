@@ -0,0 +1,59 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"strconv"
+	"time"
+
+	"github.com/bloom42/stdx-go/httpx"
+	"markdown.ninja/pkg/server/cachecontrol"
+	"markdown.ninja/pkg/server/httpctx"
+	"markdown.ninja/pkg/services/content"
+	"markdown.ninja/pkg/services/site"
+)
+
+// GetAuthor returns the public profile of an author. The pages of the author can be fetched with
+// ListPages.
+func (service *SiteService) GetAuthor(ctx context.Context, input site.GetAuthorInput) (ret site.Author, err error) {
+	httpCtx := httpctx.FromCtx(ctx)
+	hostname := httpCtx.Hostname
+	cacheControl := cachecontrol.HeadlessApiAuthors
+	contact := service.contactsService.CurrentContact(ctx)
+
+	if input.Slug == nil {
+		err = content.ErrAuthorNotFound
+		return
+	}
+
+	website, err := service.websitesService.FindWebsiteByDomain(ctx, service.db, hostname)
+	if err != nil {
+		return
+	}
+
+	// authors are part of the website's content so they update website.ModifiedAt
+	modifiedAt := website.ModifiedAt.Truncate(time.Second)
+	etag := base64.RawURLEncoding.EncodeToString([]byte(*input.Slug + strconv.FormatInt(modifiedAt.UnixMilli(), 10)))
+	if contact == nil && httpCtx.Request.IfNoneMatch != nil && *httpCtx.Request.IfNoneMatch == etag {
+		httpCtx.Response.CacheHit = &httpctx.CacheHit{
+			CacheControl: cacheControl,
+			ETag:         etag,
+		}
+		return
+	}
+
+	author, err := service.contentService.FindAuthor(ctx, service.db, website.ID, *input.Slug)
+	if err != nil {
+		return
+	}
+
+	authors, err := service.convertAuthors(ctx, website, []content.Author{author})
+	if err != nil {
+		return
+	}
+
+	httpCtx.Response.Headers.Set(httpx.HeaderCacheControl, cacheControl)
+	httpCtx.Response.Headers.Set(httpx.HeaderETag, strconv.Quote(etag))
+
+	return authors[0], nil
+}
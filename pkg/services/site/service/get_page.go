@@ -50,15 +50,21 @@ func (service *SiteService) GetPage(ctx context.Context, input site.GetPageInput
 		HeaderReferrer:       httpCtx.Headers.Get(httpx.HeaderReferer),
 		HeaderUserAgent:      httpCtx.Client.UserAgent,
 		QueryParameterRef:    httpCtx.Url.Query().Get("ref"),
+		Utm:                  events.UtmParametersFromQuery(httpCtx.Url.Query()),
 		WebsiteID:            website.ID,
 	}
 	service.eventsService.TrackPageView(ctx, trackEventInput)
 
+	hasAccess, err := service.pageAccess(ctx, website, page, service.contactsService.CurrentContact(ctx))
+	if err != nil {
+		return
+	}
+
 	// handle caching
 	// ThemeHash is not needed as it's an API call. There is no theme/frontend involved.
-	// Nothing in the returned response depends on the data of the contact, so we don't need to check
-	// if contact is present or not.
-	etag := computePageEtag(&page, website.ModifiedAt, nil, nil)
+	// The only thing in the returned response that depends on the contact is whether they have access
+	// to a paid-only page, so the contact itself is not needed.
+	etag := computePageEtag(&page, website.ModifiedAt, nil, nil, hasAccess)
 	if httpCtx.Request.IfNoneMatch != nil && *httpCtx.Request.IfNoneMatch == etag {
 		httpCtx.Response.CacheHit = &httpctx.CacheHit{
 			CacheControl: cacheControl,
@@ -77,6 +83,16 @@ func (service *SiteService) GetPage(ctx context.Context, input site.GetPageInput
 		return
 	}
 
+	authors, err := service.findAuthorsForPage(ctx, website, page.ID)
+	if err != nil {
+		return
+	}
+
+	translations, err := service.findPageTranslations(ctx, website, page)
+	if err != nil {
+		return
+	}
+
 	snippets, err := service.contentService.FindSnippets(ctx, service.db, website.ID)
 	if err != nil {
 		return
@@ -85,7 +101,13 @@ func (service *SiteService) GetPage(ctx context.Context, input site.GetPageInput
 	httpCtx.Response.Headers.Set(httpx.HeaderCacheControl, cacheControl)
 	httpCtx.Response.Headers.Set(httpx.HeaderETag, strconv.Quote(etag))
 
-	ret = service.convertPage(ctx, website, page, tags, snippets)
+	ret = service.convertPage(ctx, website, page, tags, authors, translations, snippets, hasAccess)
+	if !hasAccess {
+		ret.Paywall, err = service.findPaywall(ctx, website)
+		if err != nil {
+			return
+		}
+	}
 	service.pagesCache.Set(etag, ret, memorycache.DefaultTTL)
 
 	return ret, nil
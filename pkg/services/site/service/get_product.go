@@ -30,7 +30,7 @@ func (service *SiteService) GetProduct(ctx context.Context, input site.GetProduc
 		return
 	}
 
-	ret = service.convertProduct(website, product)
+	ret = service.convertProduct(ctx, website, product)
 
 	return ret, nil
 }
@@ -0,0 +1,168 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/bloom42/stdx-go/crypto/blake3"
+	"github.com/bloom42/stdx-go/httpx"
+	"github.com/bloom42/stdx-go/memorycache"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"markdown.ninja/pkg/server/cachecontrol"
+	"markdown.ninja/pkg/server/httpctx"
+	"markdown.ninja/pkg/services/site"
+	"markdown.ninja/pkg/services/websites"
+)
+
+func (service *SiteService) Graphql(ctx context.Context, input site.GraphqlInput) (ret json.RawMessage, err error) {
+	httpCtx := httpctx.FromCtx(ctx)
+	hostname := httpCtx.Hostname
+	cacheControl := cachecontrol.HeadlessApiContent
+
+	website, err := service.websitesService.FindWebsiteByDomain(ctx, service.db, hostname)
+	if err != nil {
+		return
+	}
+
+	err = service.checkHeadlessApiKey(ctx, website)
+	if err != nil {
+		return
+	}
+
+	query, found, err := service.resolveGraphqlQuery(input)
+	if err != nil {
+		return
+	}
+	if !found {
+		// the client needs to send the query in full
+		persistedQueryNotFoundErr := gqlerrors.NewFormattedError("PersistedQueryNotFound")
+		persistedQueryNotFoundErr.Extensions = map[string]any{"code": "PERSISTED_QUERY_NOT_FOUND"}
+		return json.Marshal(graphql.Result{
+			Errors: []gqlerrors.FormattedError{persistedQueryNotFoundErr},
+		})
+	}
+
+	operationName := ""
+	if input.OperationName != nil {
+		operationName = *input.OperationName
+	}
+
+	cacheKey, err := generateGraphqlResponseCacheKey(website, query, operationName, input.Variables)
+	if err != nil {
+		return
+	}
+
+	cachedResponse := service.graphqlResponsesCache.Get(cacheKey)
+	if cachedResponse != nil {
+		ret = cachedResponse.Value()
+	} else {
+		request := &graphqlRequest{
+			website:   website,
+			cacheable: true,
+		}
+		response := executeGraphqlQuery(context.WithValue(ctx, graphqlRequestContextKey{}, request),
+			service.graphqlSchema, query, operationName, input.Variables.Values)
+
+		ret, err = json.Marshal(response)
+		if err != nil {
+			err = fmt.Errorf("site.Graphql: encoding response: %w", err)
+			return
+		}
+
+		// responses with errors are not cached as errors may be transient
+		if request.cacheable && len(response.Errors) == 0 {
+			service.graphqlResponsesCache.Set(cacheKey, ret, memorycache.DefaultTTL)
+		}
+	}
+
+	// handle caching
+	etagHash := blake3.Sum256(ret)
+	etag := base64.RawURLEncoding.EncodeToString(etagHash[:])
+	if httpCtx.Request.IfNoneMatch != nil && *httpCtx.Request.IfNoneMatch == etag {
+		httpCtx.Response.CacheHit = &httpctx.CacheHit{
+			CacheControl: cacheControl,
+			ETag:         etag,
+		}
+		return ret, nil
+	}
+
+	httpCtx.Response.Headers.Set(httpx.HeaderCacheControl, cacheControl)
+	httpCtx.Response.Headers.Set(httpx.HeaderETag, strconv.Quote(etag))
+
+	return ret, nil
+}
+
+// resolveGraphqlQuery returns the query of the request, either sent in full or as a persisted query
+// (https://www.apollographql.com/docs/apollo-server/performance/apq). found is false if the hash of
+// the persisted query is not known.
+func (service *SiteService) resolveGraphqlQuery(input site.GraphqlInput) (query string, found bool, err error) {
+	persistedQuery := input.Extensions.PersistedQuery
+	if persistedQuery != nil && persistedQuery.Version != 1 {
+		err = site.ErrGraphqlPersistedQueryVersionIsNotSupported
+		return
+	}
+
+	if input.Query == nil || strings.TrimSpace(*input.Query) == "" {
+		if persistedQuery == nil {
+			err = site.ErrGraphqlQueryIsMissing
+			return
+		}
+
+		cachedQuery := service.graphqlPersistedQueriesCache.Get(strings.ToLower(persistedQuery.Sha256Hash))
+		if cachedQuery == nil {
+			return "", false, nil
+		}
+		return cachedQuery.Value(), true, nil
+	}
+
+	query = *input.Query
+	if len(query) > site.GraphqlQueryMaxLength {
+		err = site.ErrGraphqlQueryIsTooLong
+		return
+	}
+
+	if persistedQuery != nil {
+		queryHash := sha256.Sum256([]byte(query))
+		queryHashHex := hex.EncodeToString(queryHash[:])
+		if !strings.EqualFold(queryHashHex, persistedQuery.Sha256Hash) {
+			err = site.ErrGraphqlPersistedQueryIsNotValid
+			return
+		}
+
+		service.graphqlPersistedQueriesCache.Set(queryHashHex, query, memorycache.DefaultTTL)
+	}
+
+	return query, true, nil
+}
+
+// generateGraphqlResponseCacheKey generates the key of a response in the cache. As all the content exposed
+// in cached responses updates website.ModifiedAt, responses are invalidated when the website is modified.
+func generateGraphqlResponseCacheKey(website websites.Website, query, operationName string, variables site.GraphqlVariables) (string, error) {
+	var hash [32]byte
+
+	// maps are encoded with sorted keys so the encoding is deterministic
+	variablesJson, err := json.Marshal(variables)
+	if err != nil {
+		return "", fmt.Errorf("site.generateGraphqlResponseCacheKey: encoding variables: %w", err)
+	}
+
+	hasher := blake3.New(32, nil)
+	hasher.Write(website.ID.Bytes())
+	binary.Write(hasher, binary.LittleEndian, website.ModifiedAt.UnixMicro())
+	for _, value := range []string{query, operationName, string(variablesJson)} {
+		// the values are prefixed by their length to avoid collisions
+		binary.Write(hasher, binary.LittleEndian, int64(len(value)))
+		hasher.Write([]byte(value))
+	}
+	hasher.Sum(hash[:0])
+
+	return base64.RawURLEncoding.EncodeToString(hash[:]), nil
+}
@@ -0,0 +1,670 @@
+package service
+
+import (
+	"context"
+	"encoding"
+	"errors"
+	"fmt"
+
+	"github.com/bloom42/stdx-go/guid"
+	"github.com/bloom42/stdx-go/log/slogx"
+	"github.com/graphql-go/graphql"
+	"markdown.ninja/pkg/errs"
+	"markdown.ninja/pkg/services/content"
+	"markdown.ninja/pkg/services/organizations"
+	"markdown.ninja/pkg/services/site"
+	"markdown.ninja/pkg/services/store"
+	"markdown.ninja/pkg/services/websites"
+)
+
+const (
+	// graphqlDatabaseCost is the cost of the fields that need a database query
+	graphqlDatabaseCost = 10
+	// graphqlRenderCost is the cost of the fields that render Markdown
+	graphqlRenderCost               = 5
+	graphqlRelatedPostsDefaultLimit = 5
+)
+
+// graphqlFieldsCosts are the costs of the fields of the schema, indexed by "Type.field".
+// Fields that are not listed have a base cost of 1.
+var graphqlFieldsCosts = map[string]graphqlFieldCost{
+	"Query.page":     {cost: graphqlDatabaseCost},
+	"Query.pages":    {cost: graphqlDatabaseCost, listSize: listSizeFromLimit},
+	"Query.tags":     {cost: graphqlDatabaseCost, listSize: listSize(50)},
+	"Query.tag":      {cost: graphqlDatabaseCost},
+	"Query.snippets": {cost: graphqlDatabaseCost, listSize: listSize(20)},
+	"Query.products": {cost: graphqlDatabaseCost, listSize: listSize(20)},
+	"Query.product":  {cost: graphqlDatabaseCost},
+	"Query.assets":   {cost: graphqlDatabaseCost, listSize: listSize(100)},
+
+	"Tag.pages":          {cost: graphqlDatabaseCost, listSize: listSizeFromLimit},
+	"Page.body":          {cost: graphqlDatabaseCost},
+	"Page.tags":          {cost: graphqlDatabaseCost},
+	"Page.authors":       {cost: graphqlDatabaseCost},
+	"Page.related_posts": {cost: graphqlDatabaseCost, listSize: listSizeFromLimit},
+	// the size of the list is accounted by the field that returns the connection
+	"PageConnection.nodes": {listSize: listSize(1)},
+	"Product.pages":        {cost: graphqlDatabaseCost, listSize: listSize(20)},
+	"ProductPage.body":     {cost: graphqlRenderCost},
+}
+
+type graphqlRequestContextKey struct{}
+
+// graphqlRequest is the state of a GraphQL request, shared by the resolvers. Resolvers are executed
+// sequentially so it doesn't need to be synchronized.
+type graphqlRequest struct {
+	website websites.Website
+	// snippets are loaded the first time the body of a page is rendered
+	snippets []content.Snippet
+	// cacheable is false if the response contains products or assets as they don't update
+	// website.ModifiedAt
+	cacheable bool
+}
+
+func graphqlRequestFromCtx(ctx context.Context) *graphqlRequest {
+	return ctx.Value(graphqlRequestContextKey{}).(*graphqlRequest)
+}
+
+type graphqlPage struct {
+	site.PageMetadata
+	ID guid.GUID `json:"id"`
+}
+
+// Resolve implements graphql.FieldResolver as the default resolver doesn't read the fields of embedded structs
+func (page graphqlPage) Resolve(params graphql.ResolveParams) (any, error) {
+	if params.Info.FieldName == "id" {
+		return page.ID, nil
+	}
+	params.Source = page.PageMetadata
+	return graphql.DefaultResolveFn(params)
+}
+
+type graphqlPageConnection struct {
+	Nodes []graphqlPage `json:"nodes"`
+	// NextCursor is null when there are no more pages
+	NextCursor *string `json:"next_cursor"`
+}
+
+// graphqlError is an error returned by a resolver, with a code like the REST API
+type graphqlError struct {
+	message string
+	code    string
+}
+
+func (err *graphqlError) Error() string {
+	return err.message
+}
+
+// Extensions implements gqlerrors.ExtendedError
+func (err *graphqlError) Extensions() map[string]any {
+	return map[string]any{"code": err.code}
+}
+
+func nonNull(graphqlType graphql.Type) graphql.Type {
+	return graphql.NewNonNull(graphqlType)
+}
+
+// listOf returns the type of a non-null list of non-null items
+func listOf(graphqlType graphql.Type) graphql.Type {
+	return nonNull(graphql.NewList(nonNull(graphqlType)))
+}
+
+// graphqlEnum creates an enum type whose values are named after their text representation
+func graphqlEnum[T ~string | ~int64](name string, values ...T) *graphql.Enum {
+	enumValues := make(graphql.EnumValueConfigMap, len(values))
+	for _, value := range values {
+		valueName := fmt.Sprint(value)
+		if textMarshaler, ok := any(value).(encoding.TextMarshaler); ok {
+			text, _ := textMarshaler.MarshalText()
+			valueName = string(text)
+		}
+		enumValues[valueName] = &graphql.EnumValueConfig{Value: value}
+	}
+	return graphql.NewEnum(graphql.EnumConfig{Name: name, Values: enumValues})
+}
+
+func listSize(size int64) func(args map[string]any) int64 {
+	return func(args map[string]any) int64 {
+		return size
+	}
+}
+
+func listSizeFromLimit(args map[string]any) int64 {
+	limit, _ := args["limit"].(int)
+	return int64(limit)
+}
+
+// graphqlResolver converts the errors returned by resolvers to GraphQL errors with a code, like the
+// REST API, and hides the details of internal errors
+func graphqlResolver(resolve graphql.FieldResolveFn) graphql.FieldResolveFn {
+	return func(params graphql.ResolveParams) (ret any, err error) {
+		ret, err = resolve(params)
+		if err != nil {
+			return nil, convertGraphqlError(params.Context, err)
+		}
+		return ret, nil
+	}
+}
+
+func convertGraphqlError(ctx context.Context, err error) *graphqlError {
+	var code string
+	message := err.Error()
+
+	switch err.(type) {
+	case *errs.NotFoundError:
+		code = "NOT_FOUND"
+	case *errs.InvalidArgumentError:
+		code = "INVALID_ARGUMENT"
+	case *errs.PermissionDeniedError:
+		code = "PERMISSION_DENIED"
+	case *errs.AuthenticationRequiredError:
+		code = "AUTHENTICATION_REQUIRED"
+	default:
+		code = "INTERNAL"
+		message = "Internal Error. Please try again and contact support if the problem persists."
+		if !errors.Is(err, context.Canceled) {
+			slogx.FromCtx(ctx).Error("site.Graphql: error resolving field", slogx.Err(err))
+		}
+	}
+
+	return &graphqlError{
+		message: message,
+		code:    code,
+	}
+}
+
+// newGraphqlSchema creates the schema of the GraphQL API. Only published pages and active products are
+// exposed.
+func (service *SiteService) newGraphqlSchema() (graphql.Schema, error) {
+	pageType := graphqlEnum("PageType", content.PageTypePage, content.PageTypePost)
+	bodyFormat := graphqlEnum("BodyFormat", site.HeadlessBodyFormatHtml, site.HeadlessBodyFormatMarkdown)
+	productType := graphqlEnum("ProductType", store.ProductTypeBook, store.ProductTypeCourse,
+		store.ProductTypeDigitalDownload, store.ProductTypeSubscription, store.ProductTypeBundle)
+	assetType := graphqlEnum("AssetType", content.AssetTypeFile, content.AssetTypeImage, content.AssetTypeAudio,
+		content.AssetTypeVideo, content.AssetTypeFolder)
+
+	pagesArgs := graphql.FieldConfigArgument{
+		"type":  {Type: pageType},
+		"lang":  {Type: graphql.String},
+		"limit": {Type: graphql.Int, DefaultValue: site.HeadlessPagesDefaultLimit},
+		"after": {Type: graphql.String},
+	}
+
+	website := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Website",
+		Fields: graphql.Fields{
+			"name":        {Type: nonNull(graphql.String)},
+			"description": {Type: nonNull(graphql.String)},
+			"url":         {Type: nonNull(graphql.String)},
+			"language":    {Type: nonNull(graphql.String)},
+			"logo":        {Type: graphql.String},
+		},
+	})
+
+	socialLink := graphql.NewObject(graphql.ObjectConfig{
+		Name: "SocialLink",
+		Fields: graphql.Fields{
+			"name": {Type: nonNull(graphql.String)},
+			"url":  {Type: nonNull(graphql.String)},
+		},
+	})
+
+	author := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Author",
+		Fields: graphql.Fields{
+			"slug":         {Type: nonNull(graphql.String)},
+			"name":         {Type: nonNull(graphql.String)},
+			"bio":          {Type: nonNull(graphql.String)},
+			"avatar":       {Type: graphql.String},
+			"url":          {Type: nonNull(graphql.String)},
+			"social_links": {Type: listOf(socialLink)},
+		},
+	})
+
+	// Tag, Page and PageConnection reference each other so their fields are defined once they are all created
+	var tag, page, pageConnection *graphql.Object
+
+	pageConnection = graphql.NewObject(graphql.ObjectConfig{
+		Name: "PageConnection",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"nodes":       {Type: listOf(page)},
+				"next_cursor": {Type: graphql.String},
+			}
+		}),
+	})
+
+	tag = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Tag",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"name":        {Type: nonNull(graphql.String)},
+				"description": {Type: nonNull(graphql.String)},
+				"pages": {
+					Type: nonNull(pageConnection),
+					Args: pagesArgs,
+					Resolve: graphqlResolver(func(params graphql.ResolveParams) (any, error) {
+						tag := params.Source.(site.Tag)
+						return service.resolveGraphqlPages(params.Context, params.Args, &tag.Name)
+					}),
+				},
+			}
+		}),
+	})
+
+	page = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Page",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":          {Type: nonNull(graphql.ID)},
+				"type":        {Type: nonNull(pageType)},
+				"title":       {Type: nonNull(graphql.String)},
+				"path":        {Type: nonNull(graphql.String)},
+				"url":         {Type: nonNull(graphql.String)},
+				"description": {Type: nonNull(graphql.String)},
+				"language":    {Type: nonNull(graphql.String)},
+				"date":        {Type: nonNull(graphql.DateTime)},
+				"modified_at": {Type: nonNull(graphql.DateTime)},
+				"paid_only":   {Type: nonNull(graphql.Boolean)},
+				"body": {
+					Type: nonNull(graphql.String),
+					Args: graphql.FieldConfigArgument{
+						"format": {Type: bodyFormat, DefaultValue: site.HeadlessBodyFormatHtml},
+					},
+					Resolve: graphqlResolver(func(params graphql.ResolveParams) (any, error) {
+						request := graphqlRequestFromCtx(params.Context)
+						page, err := service.contentService.FindPageByID(params.Context, service.db, params.Source.(graphqlPage).ID)
+						if err != nil {
+							return nil, err
+						}
+
+						if params.Args["format"] == site.HeadlessBodyFormatMarkdown {
+							return page.BodyMarkdown, nil
+						}
+
+						snippets, err := service.loadGraphqlSnippets(params.Context, request)
+						if err != nil {
+							return nil, err
+						}
+						// API keys are only given to the staff of the website so paid-only pages are returned in full
+						return service.renderPageBodyHtml(params.Context, request.website, page, snippets, true), nil
+					}),
+				},
+				"tags": {
+					Type: listOf(tag),
+					Resolve: graphqlResolver(func(params graphql.ResolveParams) (any, error) {
+						tags, err := service.contentService.FindTagsForPage(params.Context, service.db, params.Source.(graphqlPage).ID)
+						if err != nil {
+							return nil, err
+						}
+						return service.convertTags(tags), nil
+					}),
+				},
+				"authors": {
+					Type: listOf(author),
+					Resolve: graphqlResolver(func(params graphql.ResolveParams) (any, error) {
+						request := graphqlRequestFromCtx(params.Context)
+						pageID := params.Source.(graphqlPage).ID
+						authors, err := service.findAuthorsForPages(params.Context, request.website, []guid.GUID{pageID})
+						if err != nil {
+							return nil, err
+						}
+						return authors[pageID], nil
+					}),
+				},
+				"related_posts": {
+					Type: listOf(page),
+					Args: graphql.FieldConfigArgument{
+						"limit": {Type: graphql.Int, DefaultValue: graphqlRelatedPostsDefaultLimit},
+					},
+					Resolve: graphqlResolver(func(params graphql.ResolveParams) (any, error) {
+						request := graphqlRequestFromCtx(params.Context)
+						limit := int64(params.Args["limit"].(int))
+						if limit < 1 || limit > site.GraphqlRelatedPostsMaxLimit {
+							return nil, site.ErrGraphqlRelatedPostsLimitIsNotValid
+						}
+
+						posts, err := service.contentService.FindRelatedPostsMetadata(params.Context, service.db, request.website.ID,
+							params.Source.(graphqlPage).ID, limit)
+						if err != nil {
+							return nil, err
+						}
+						return service.convertGraphqlPages(request.website, posts), nil
+					}),
+				},
+			}
+		}),
+	})
+
+	snippet := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Snippet",
+		Fields: graphql.Fields{
+			"name":    {Type: nonNull(graphql.String)},
+			"content": {Type: nonNull(graphql.String)},
+		},
+	})
+
+	productPage := graphql.NewObject(graphql.ObjectConfig{
+		Name: "ProductPage",
+		Fields: graphql.Fields{
+			"id":       {Type: nonNull(graphql.ID)},
+			"position": {Type: nonNull(graphql.Int)},
+			"title":    {Type: nonNull(graphql.String)},
+			"body": {
+				Type: nonNull(graphql.String),
+				Args: graphql.FieldConfigArgument{
+					"format": {Type: bodyFormat, DefaultValue: site.HeadlessBodyFormatHtml},
+				},
+				Resolve: graphqlResolver(func(params graphql.ResolveParams) (any, error) {
+					request := graphqlRequestFromCtx(params.Context)
+					productPage := params.Source.(store.ProductPage)
+					if params.Args["format"] == site.HeadlessBodyFormatMarkdown {
+						return productPage.BodyMarkdown, nil
+					}
+
+					snippets, err := service.loadGraphqlSnippets(params.Context, request)
+					if err != nil {
+						return nil, err
+					}
+					return service.contentService.RenderMarkdown(params.Context, request.website, productPage.BodyMarkdown, snippets, false), nil
+				}),
+			},
+		},
+	})
+
+	product := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Product",
+		Fields: graphql.Fields{
+			"id":           {Type: nonNull(graphql.ID)},
+			"name":         {Type: nonNull(graphql.String)},
+			"description":  {Type: nonNull(graphql.String)},
+			"type":         {Type: nonNull(productType)},
+			"price":        {Type: nonNull(graphql.Int)},
+			"yearly_price": {Type: nonNull(graphql.Int)},
+			"pages": {
+				Type: listOf(productPage),
+				Resolve: graphqlResolver(func(params graphql.ResolveParams) (any, error) {
+					product, err := service.storeService.FindProductWithContent(params.Context, service.db, params.Source.(store.Product).ID)
+					if err != nil {
+						return nil, err
+					}
+					return product.Content, nil
+				}),
+			},
+		},
+	})
+
+	asset := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Asset",
+		Fields: graphql.Fields{
+			"id":         {Type: nonNull(graphql.ID)},
+			"type":       {Type: nonNull(assetType)},
+			"name":       {Type: nonNull(graphql.String)},
+			"folder":     {Type: nonNull(graphql.String)},
+			"media_type": {Type: nonNull(graphql.String)},
+			"size":       {Type: nonNull(graphql.Int)},
+			"path": {
+				Type: nonNull(graphql.String),
+				Resolve: func(params graphql.ResolveParams) (any, error) {
+					return params.Source.(content.Asset).Path(), nil
+				},
+			},
+			"url": {
+				Type: nonNull(graphql.String),
+				Resolve: func(params graphql.ResolveParams) (any, error) {
+					request := graphqlRequestFromCtx(params.Context)
+					return string(service.convertWebsite(request.website).Url) + params.Source.(content.Asset).Path(), nil
+				},
+			},
+		},
+	})
+
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"website": {
+				Type: nonNull(website),
+				Resolve: func(params graphql.ResolveParams) (any, error) {
+					return service.convertWebsite(graphqlRequestFromCtx(params.Context).website), nil
+				},
+			},
+			"page": {
+				Type: page,
+				Args: graphql.FieldConfigArgument{
+					"path": {Type: nonNull(graphql.String)},
+				},
+				Resolve: graphqlResolver(func(params graphql.ResolveParams) (any, error) {
+					request := graphqlRequestFromCtx(params.Context)
+					page, err := service.contentService.FindPageByPath(params.Context, service.db, request.website.ID, params.Args["path"].(string))
+					if err != nil {
+						if errs.IsNotFound(err) {
+							return nil, nil
+						}
+						return nil, err
+					}
+					if page.Status != content.PageStatusPublished {
+						return nil, nil
+					}
+
+					return graphqlPage{
+						PageMetadata: service.convertPageToMetadata(request.website, page),
+						ID:           page.ID,
+					}, nil
+				}),
+			},
+			"pages": {
+				Type: nonNull(pageConnection),
+				Args: graphql.FieldConfigArgument{
+					"type":  pagesArgs["type"],
+					"tag":   {Type: graphql.String},
+					"lang":  pagesArgs["lang"],
+					"limit": pagesArgs["limit"],
+					"after": pagesArgs["after"],
+				},
+				Resolve: graphqlResolver(func(params graphql.ResolveParams) (any, error) {
+					var tag *string
+					if tagArg, ok := params.Args["tag"].(string); ok {
+						tag = &tagArg
+					}
+					return service.resolveGraphqlPages(params.Context, params.Args, tag)
+				}),
+			},
+			"tags": {
+				Type: listOf(tag),
+				Resolve: graphqlResolver(func(params graphql.ResolveParams) (any, error) {
+					tags, err := service.contentService.FindTags(params.Context, service.db, graphqlRequestFromCtx(params.Context).website.ID)
+					if err != nil {
+						return nil, err
+					}
+					return service.convertTags(tags), nil
+				}),
+			},
+			"tag": {
+				Type: tag,
+				Args: graphql.FieldConfigArgument{
+					"name": {Type: nonNull(graphql.String)},
+				},
+				Resolve: graphqlResolver(func(params graphql.ResolveParams) (any, error) {
+					tag, err := service.contentService.FindTag(params.Context, service.db, graphqlRequestFromCtx(params.Context).website.ID,
+						params.Args["name"].(string))
+					if err != nil {
+						if errs.IsNotFound(err) {
+							return nil, nil
+						}
+						return nil, err
+					}
+					return service.convertTags([]content.Tag{tag})[0], nil
+				}),
+			},
+			"snippets": {
+				Type: listOf(snippet),
+				Resolve: graphqlResolver(func(params graphql.ResolveParams) (any, error) {
+					return service.loadGraphqlSnippets(params.Context, graphqlRequestFromCtx(params.Context))
+				}),
+			},
+			"products": {
+				Type: listOf(product),
+				Resolve: graphqlResolver(func(params graphql.ResolveParams) (any, error) {
+					request := graphqlRequestFromCtx(params.Context)
+					request.cacheable = false
+
+					_, err := service.organizationsService.CheckCurrentApiKey(params.Context, request.website.OrganizationID,
+						request.website.ID, organizations.ApiKeyScopeStoreRead)
+					if err != nil {
+						return nil, err
+					}
+
+					products, err := service.storeService.FindProductsForWebsite(params.Context, service.db, request.website.ID, 1_000)
+					if err != nil {
+						return nil, err
+					}
+
+					activeProducts := make([]store.Product, 0, len(products))
+					for _, product := range products {
+						if product.Status == store.ProductStatusActive {
+							activeProducts = append(activeProducts, product)
+						}
+					}
+					return activeProducts, nil
+				}),
+			},
+			"product": {
+				Type: product,
+				Args: graphql.FieldConfigArgument{
+					"id": {Type: nonNull(graphql.ID)},
+				},
+				Resolve: graphqlResolver(func(params graphql.ResolveParams) (any, error) {
+					request := graphqlRequestFromCtx(params.Context)
+					request.cacheable = false
+
+					_, err := service.organizationsService.CheckCurrentApiKey(params.Context, request.website.OrganizationID,
+						request.website.ID, organizations.ApiKeyScopeStoreRead)
+					if err != nil {
+						return nil, err
+					}
+
+					productID, err := guid.Parse(params.Args["id"].(string))
+					if err != nil {
+						return nil, nil
+					}
+
+					product, err := service.storeService.FindProduct(params.Context, service.db, productID)
+					if err != nil {
+						if errs.IsNotFound(err) {
+							return nil, nil
+						}
+						return nil, err
+					}
+					if !product.WebsiteID.Equal(request.website.ID) || product.Status != store.ProductStatusActive {
+						return nil, nil
+					}
+
+					return product, nil
+				}),
+			},
+			"assets": {
+				Type: listOf(asset),
+				Args: graphql.FieldConfigArgument{
+					"folder": {Type: graphql.String},
+				},
+				Resolve: graphqlResolver(func(params graphql.ResolveParams) (any, error) {
+					request := graphqlRequestFromCtx(params.Context)
+					request.cacheable = false
+
+					input := content.ListAssetsInput{WebsiteID: request.website.ID}
+					if folder, ok := params.Args["folder"].(string); ok {
+						input.Folder = &folder
+					}
+					// ListAssets verifies that the API key has the assets:read scope
+					return service.contentService.ListAssets(params.Context, input)
+				}),
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{Query: query})
+}
+
+// resolveGraphqlPages returns the published pages of the website, most recent first.
+// It uses the same cursors as the headless API.
+func (service *SiteService) resolveGraphqlPages(ctx context.Context, args map[string]any, tag *string) (ret graphqlPageConnection, err error) {
+	request := graphqlRequestFromCtx(ctx)
+
+	filter := content.PagesFilter{
+		Types:    []content.PageType{content.PageTypePage, content.PageTypePost},
+		Statuses: []content.PageStatus{content.PageStatusPublished},
+		Tag:      tag,
+		Limit:    int64(args["limit"].(int)),
+	}
+
+	if filter.Limit < 1 || filter.Limit > site.HeadlessPagesMaxLimit {
+		err = site.ErrHeadlessLimitIsNotValid
+		return
+	}
+
+	if pageType, ok := args["type"].(content.PageType); ok {
+		filter.Types = []content.PageType{pageType}
+	}
+
+	if language, ok := args["lang"].(string); ok {
+		filter.Language = &language
+	}
+
+	if after, ok := args["after"].(string); ok {
+		var cursor content.PagesCursor
+		cursor, err = decodeHeadlessCursor(after)
+		if err != nil {
+			return
+		}
+		filter.After = &cursor
+	}
+
+	// we fetch one more page to know if there is a next batch of results
+	limit := filter.Limit
+	filter.Limit += 1
+	pages, err := service.contentService.FindPagesMetadataWithFilter(ctx, service.db, request.website.ID, filter)
+	if err != nil {
+		if errs.IsNotFound(err) && tag != nil {
+			// the tag doesn't exist
+			return graphqlPageConnection{Nodes: []graphqlPage{}}, nil
+		}
+		return
+	}
+
+	if int64(len(pages)) > limit {
+		pages = pages[:limit]
+		nextCursor := encodeHeadlessCursor(pages[len(pages)-1])
+		ret.NextCursor = &nextCursor
+	}
+
+	ret.Nodes = service.convertGraphqlPages(request.website, pages)
+	return ret, nil
+}
+
+func (service *SiteService) convertGraphqlPages(website websites.Website, pages []content.PageMetadata) []graphqlPage {
+	ret := make([]graphqlPage, len(pages))
+	for i, page := range pages {
+		ret[i] = graphqlPage{
+			PageMetadata: service.convertPageMetadata(website, page),
+			ID:           page.ID,
+		}
+	}
+	return ret
+}
+
+func (service *SiteService) loadGraphqlSnippets(ctx context.Context, request *graphqlRequest) (snippets []content.Snippet, err error) {
+	if request.snippets != nil {
+		return request.snippets, nil
+	}
+
+	snippets, err = service.contentService.FindSnippets(ctx, service.db, request.website.ID)
+	if err != nil {
+		return nil, err
+	}
+	if snippets == nil {
+		snippets = []content.Snippet{}
+	}
+
+	request.snippets = snippets
+	return snippets, nil
+}
@@ -0,0 +1,259 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+	"markdown.ninja/pkg/services/site"
+)
+
+// graphqlDefaultListSize is the number of items assumed for the lists without a listSize function
+const graphqlDefaultListSize = 10
+
+type graphqlFieldCost struct {
+	// cost is the additional cost of resolving the field, e.g. 10 for a field that needs a database query.
+	// Each field has a base cost of 1.
+	cost int64
+	// listSize returns the maximum number of items returned by a list field (or a connection object),
+	// given the arguments of the field. It is used to compute the cost of the selections of the field.
+	// graphqlDefaultListSize is used for lists if nil.
+	listSize func(args map[string]any) int64
+}
+
+// graphqlInputType is implemented by the scalar and enum types of graphql-go
+type graphqlInputType interface {
+	ParseValue(value any) any
+	ParseLiteral(valueAST ast.Value) any
+}
+
+// executeGraphqlQuery parses, validates and executes a query. Queries that are too deep or too expensive
+// are rejected before being executed.
+func executeGraphqlQuery(ctx context.Context, schema graphql.Schema, query, operationName string, variables map[string]any) *graphql.Result {
+	document, err := parser.Parse(parser.ParseParams{
+		Source: source.NewSource(&source.Source{Body: []byte(query), Name: "GraphQL request"}),
+	})
+	if err != nil {
+		return &graphql.Result{Errors: gqlerrors.FormatErrors(err)}
+	}
+
+	validationResult := graphql.ValidateDocument(&schema, document, nil)
+	if !validationResult.IsValid {
+		return &graphql.Result{Errors: validationResult.Errors}
+	}
+
+	err = checkGraphqlQueryLimits(schema, graphqlFieldsCosts, document, operationName, variables,
+		site.GraphqlMaxDepth, site.GraphqlMaxCost)
+	if err != nil {
+		return &graphql.Result{Errors: gqlerrors.FormatErrors(err)}
+	}
+
+	return graphql.Execute(graphql.ExecuteParams{
+		Schema:        schema,
+		AST:           document,
+		OperationName: operationName,
+		Args:          variables,
+		Context:       ctx,
+	})
+}
+
+// graphqlQueryAnalyzer computes the depth and the cost of the operations of a validated document
+type graphqlQueryAnalyzer struct {
+	schema            graphql.Schema
+	costs             map[string]graphqlFieldCost
+	fragments         map[string]*ast.FragmentDefinition
+	visitingFragments map[string]bool
+	variables         map[string]any
+	variablesDefaults map[string]ast.Value
+
+	maxDepth int
+	maxCost  int64
+	tooDeep  bool
+	cost     int64
+}
+
+// checkGraphqlQueryLimits verifies that the operation of the document doesn't exceed maxDepth and maxCost.
+// All the operations are checked if operationName is empty. The document must have been validated.
+func checkGraphqlQueryLimits(schema graphql.Schema, costs map[string]graphqlFieldCost, document *ast.Document,
+	operationName string, variables map[string]any, maxDepth int, maxCost int64) error {
+	analyzer := &graphqlQueryAnalyzer{
+		schema:            schema,
+		costs:             costs,
+		fragments:         map[string]*ast.FragmentDefinition{},
+		visitingFragments: map[string]bool{},
+		variables:         variables,
+		maxDepth:          maxDepth,
+		maxCost:           maxCost,
+	}
+
+	operations := []*ast.OperationDefinition{}
+	for _, definition := range document.Definitions {
+		switch definition := definition.(type) {
+		case *ast.FragmentDefinition:
+			analyzer.fragments[definition.Name.Value] = definition
+		case *ast.OperationDefinition:
+			if operationName == "" || (definition.Name != nil && definition.Name.Value == operationName) {
+				operations = append(operations, definition)
+			}
+		}
+	}
+
+	for _, operation := range operations {
+		if operation.Operation != ast.OperationTypeQuery {
+			return errors.New("Only queries are supported.")
+		}
+
+		analyzer.variablesDefaults = map[string]ast.Value{}
+		for _, variableDefinition := range operation.VariableDefinitions {
+			if variableDefinition.DefaultValue != nil {
+				analyzer.variablesDefaults[variableDefinition.Variable.Name.Value] = variableDefinition.DefaultValue
+			}
+		}
+		analyzer.analyzeSelectionSet(schema.QueryType(), operation.SelectionSet, 1, 1)
+	}
+
+	if analyzer.tooDeep {
+		return fmt.Errorf("Query is too deep. The maximum depth is %d.", maxDepth)
+	}
+	if analyzer.cost > maxCost {
+		return fmt.Errorf("Query is too expensive. The maximum cost is %d.", maxCost)
+	}
+	return nil
+}
+
+// analyzeSelectionSet adds the cost of the selections of an object to the cost of the query.
+// multiplier is the number of times the selection set is expected to be executed.
+func (analyzer *graphqlQueryAnalyzer) analyzeSelectionSet(parentType *graphql.Object, selectionSet *ast.SelectionSet, depth int, multiplier int64) {
+	if selectionSet == nil {
+		return
+	}
+	if depth > analyzer.maxDepth {
+		analyzer.tooDeep = true
+		return
+	}
+
+	for _, selection := range selectionSet.Selections {
+		// stop as soon as the query is too expensive to avoid wasting resources on malicious queries
+		if analyzer.tooDeep || analyzer.cost > analyzer.maxCost {
+			return
+		}
+
+		switch selection := selection.(type) {
+		case *ast.Field:
+			analyzer.analyzeField(parentType, selection, depth, multiplier)
+
+		case *ast.InlineFragment:
+			// our schema doesn't have interfaces and unions so fragments always apply to the parent type
+			analyzer.analyzeSelectionSet(parentType, selection.SelectionSet, depth, multiplier)
+
+		case *ast.FragmentSpread:
+			fragment, exists := analyzer.fragments[selection.Name.Value]
+			if !exists || analyzer.visitingFragments[fragment.Name.Value] {
+				continue
+			}
+			analyzer.visitingFragments[fragment.Name.Value] = true
+			analyzer.analyzeSelectionSet(parentType, fragment.SelectionSet, depth, multiplier)
+			delete(analyzer.visitingFragments, fragment.Name.Value)
+		}
+	}
+}
+
+func (analyzer *graphqlQueryAnalyzer) analyzeField(parentType *graphql.Object, selection *ast.Field, depth int, multiplier int64) {
+	field := analyzer.fieldDefinition(parentType, selection.Name.Value)
+	if field == nil {
+		return
+	}
+
+	fieldCost := analyzer.costs[parentType.Name()+"."+field.Name]
+	analyzer.cost = saturatingAdd(analyzer.cost, saturatingMul(multiplier, 1+fieldCost.cost))
+
+	objectType, isObject := graphql.GetNamed(field.Type).(*graphql.Object)
+	if !isObject {
+		return
+	}
+
+	childrenMultiplier := multiplier
+	if fieldCost.listSize != nil {
+		args := analyzer.argumentValues(field, selection.Arguments)
+		childrenMultiplier = saturatingMul(multiplier, max(fieldCost.listSize(args), 1))
+	} else if _, isList := graphqlNullableType(field.Type).(*graphql.List); isList {
+		childrenMultiplier = saturatingMul(multiplier, graphqlDefaultListSize)
+	}
+
+	analyzer.analyzeSelectionSet(objectType, selection.SelectionSet, depth+1, childrenMultiplier)
+}
+
+// fieldDefinition returns the definition of a field of parentType, including the introspection fields
+func (analyzer *graphqlQueryAnalyzer) fieldDefinition(parentType *graphql.Object, name string) *graphql.FieldDefinition {
+	switch name {
+	case graphql.TypeNameMetaFieldDef.Name:
+		return graphql.TypeNameMetaFieldDef
+	case graphql.SchemaMetaFieldDef.Name:
+		if parentType == analyzer.schema.QueryType() {
+			return graphql.SchemaMetaFieldDef
+		}
+	case graphql.TypeMetaFieldDef.Name:
+		if parentType == analyzer.schema.QueryType() {
+			return graphql.TypeMetaFieldDef
+		}
+	}
+	return parentType.Fields()[name]
+}
+
+// argumentValues returns the values of the arguments of a field, as they are passed to its resolver
+func (analyzer *graphqlQueryAnalyzer) argumentValues(field *graphql.FieldDefinition, arguments []*ast.Argument) map[string]any {
+	args := make(map[string]any, len(field.Args))
+
+	for _, definition := range field.Args {
+		args[definition.Name()] = definition.DefaultValue
+		inputType, isInputType := graphqlNullableType(definition.Type).(graphqlInputType)
+		if !isInputType {
+			continue
+		}
+
+		for _, argument := range arguments {
+			if argument.Name.Value != definition.Name() {
+				continue
+			}
+
+			variable, isVariable := argument.Value.(*ast.Variable)
+			if !isVariable {
+				args[definition.Name()] = inputType.ParseLiteral(argument.Value)
+			} else if value, provided := analyzer.variables[variable.Name.Value]; provided {
+				args[definition.Name()] = inputType.ParseValue(value)
+			} else if defaultValue, hasDefault := analyzer.variablesDefaults[variable.Name.Value]; hasDefault {
+				args[definition.Name()] = inputType.ParseLiteral(defaultValue)
+			}
+		}
+	}
+
+	return args
+}
+
+func graphqlNullableType(graphqlType graphql.Type) graphql.Type {
+	if nonNull, isNonNull := graphqlType.(*graphql.NonNull); isNonNull {
+		return nonNull.OfType
+	}
+	return graphqlType
+}
+
+func saturatingAdd(a, b int64) int64 {
+	if a > math.MaxInt64-b {
+		return math.MaxInt64
+	}
+	return a + b
+}
+
+// saturatingMul multiplies two positive integers
+func saturatingMul(a, b int64) int64 {
+	if a != 0 && b > math.MaxInt64/a {
+		return math.MaxInt64
+	}
+	return a * b
+}
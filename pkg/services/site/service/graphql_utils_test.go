@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/parser"
+)
+
+func newTestGraphqlSchema(t *testing.T) graphql.Schema {
+	t.Helper()
+
+	// the resolvers are not executed so they don't need the dependencies of the service
+	schema, err := (&SiteService{}).newGraphqlSchema()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return schema
+}
+
+func TestGraphqlFieldsCosts(t *testing.T) {
+	schema := newTestGraphqlSchema(t)
+
+	for field := range graphqlFieldsCosts {
+		typeName, fieldName, _ := strings.Cut(field, ".")
+		object, isObject := schema.Type(typeName).(*graphql.Object)
+		if !isObject {
+			t.Errorf("%s: type %s doesn't exist", field, typeName)
+			continue
+		}
+		if _, exists := object.Fields()[fieldName]; !exists {
+			t.Errorf("%s: field %s doesn't exist", field, fieldName)
+		}
+	}
+}
+
+func TestCheckGraphqlQueryLimits(t *testing.T) {
+	schema := newTestGraphqlSchema(t)
+
+	// pages: 11, nodes: 100 * 1, body: 100 * 11
+	pagesWithBodyCost := int64(11 + 100 + 1100)
+
+	tests := []struct {
+		name      string
+		query     string
+		variables map[string]any
+		maxDepth  int
+		maxCost   int64
+		valid     bool
+	}{
+		{"cheap query", `{ website { name } }`, nil, 2, 2, true},
+		{"too deep", `{ website { name } }`, nil, 1, 100, false},
+		{"cost", `{ pages(limit: 100) { nodes { body } } }`, nil, 10, pagesWithBodyCost, true},
+		{"too expensive", `{ pages(limit: 100) { nodes { body } } }`, nil, 10, pagesWithBodyCost - 1, false},
+		{"limit from a variable", `query($limit: Int) { pages(limit: $limit) { nodes { body } } }`,
+			map[string]any{"limit": float64(100)}, 10, pagesWithBodyCost - 1, false},
+		{"default value of a variable", `query($limit: Int = 100) { pages(limit: $limit) { nodes { body } } }`,
+			nil, 10, pagesWithBodyCost - 1, false},
+		// the default limit of pages is 20
+		{"default value of an argument", `{ pages { nodes { body } } }`, nil, 10, pagesWithBodyCost - 1, true},
+		{"fragments", `{ pages(limit: 100) { ...Pages } } fragment Pages on PageConnection { nodes { body } }`,
+			nil, 10, pagesWithBodyCost - 1, false},
+		{"nested lists", `{ pages(limit: 100) { nodes { tags { pages(limit: 100) { nodes { title } } } } } }`,
+			nil, 10, 5_000, false},
+		{"introspection", `{ __schema { types { fields { type { ofType { name } } } } } }`, nil, 4, 5_000, false},
+	}
+
+	for _, test := range tests {
+		document, err := parser.Parse(parser.ParseParams{Source: test.query})
+		if err != nil {
+			t.Fatalf("%s: %s", test.name, err)
+		}
+
+		err = checkGraphqlQueryLimits(schema, graphqlFieldsCosts, document, "", test.variables, test.maxDepth, test.maxCost)
+		if test.valid && err != nil {
+			t.Errorf("%s: unexpected error: %s", test.name, err)
+		} else if !test.valid && err == nil {
+			t.Errorf("%s: the query should exceed the limits", test.name)
+		}
+	}
+}
+
+func TestExecuteGraphqlQuery(t *testing.T) {
+	schema := newTestGraphqlSchema(t)
+
+	tests := []struct {
+		query    string
+		expected string
+	}{
+		{`{ __typename }`, `{"data":{"__typename":"Query"}}`},
+		{`{ website { name }`, `Syntax Error`},
+		{`{ website { isbn } }`, `Cannot query field \"isbn\" on type \"Website\"`},
+		{`{ page { title } }`, `argument \"path\" of type \"String!\" is required`},
+		{`mutation { website { name } }`, `Only queries are supported`},
+	}
+
+	for _, test := range tests {
+		result := executeGraphqlQuery(context.Background(), schema, test.query, "", nil)
+		response, err := json.Marshal(result)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(response), test.expected) {
+			t.Errorf("%s: expected response containing %s, got: %s", test.query, test.expected, response)
+		}
+	}
+}
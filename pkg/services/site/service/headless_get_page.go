@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/bloom42/stdx-go/httpx"
+	"markdown.ninja/pkg/server/cachecontrol"
+	"markdown.ninja/pkg/server/httpctx"
+	"markdown.ninja/pkg/services/content"
+	"markdown.ninja/pkg/services/site"
+)
+
+func (service *SiteService) HeadlessGetPage(ctx context.Context, input site.HeadlessGetPageInput) (ret site.HeadlessPage, err error) {
+	httpCtx := httpctx.FromCtx(ctx)
+	hostname := httpCtx.Hostname
+	cacheControl := cachecontrol.HeadlessApiContent
+
+	if input.Path == nil {
+		err = content.ErrPageNotFound
+		return
+	}
+
+	website, err := service.websitesService.FindWebsiteByDomain(ctx, service.db, hostname)
+	if err != nil {
+		return
+	}
+
+	err = service.checkHeadlessApiKey(ctx, website)
+	if err != nil {
+		return
+	}
+
+	fields, err := parseHeadlessFields(input.Fields, site.HeadlessPageFields)
+	if err != nil {
+		return
+	}
+
+	format, err := parseHeadlessBodyFormat(input.Format)
+	if err != nil {
+		return
+	}
+
+	if input.PreviewToken != nil {
+		err = service.contentService.VerifyPreviewToken(website.ID, *input.PreviewToken)
+		if err != nil {
+			return
+		}
+	}
+
+	page, err := service.contentService.FindPageByPath(ctx, service.db, website.ID, *input.Path)
+	if err != nil {
+		return
+	}
+
+	if page.Status != content.PageStatusPublished && input.PreviewToken == nil {
+		err = content.ErrPageNotFound
+		return
+	}
+
+	pageMetadata := content.PageMetadata{
+		ID:           page.ID,
+		CreatedAt:    page.CreatedAt,
+		UpdatedAt:    page.UpdatedAt,
+		Date:         page.Date,
+		Type:         page.Type,
+		Title:        page.Title,
+		Description:  page.Description,
+		Path:         page.Path,
+		Size:         page.Size,
+		BodyHash:     page.BodyHash,
+		MetadataHash: page.MetadataHash,
+		Status:       page.Status,
+		Language:     page.Language,
+		PaidOnly:     page.PaidOnly,
+	}
+
+	// handle caching
+	etag := generateEtagForHeadlessPages(httpCtx.Url, website.ModifiedAt, []content.PageMetadata{pageMetadata})
+	if httpCtx.Request.IfNoneMatch != nil && *httpCtx.Request.IfNoneMatch == etag {
+		httpCtx.Response.CacheHit = &httpctx.CacheHit{
+			CacheControl: cacheControl,
+			ETag:         etag,
+		}
+		return ret, nil
+	}
+
+	pages, err := service.convertHeadlessPages(ctx, website, []content.PageMetadata{pageMetadata}, fields, format)
+	if err != nil {
+		return
+	}
+
+	httpCtx.Response.Headers.Set(httpx.HeaderCacheControl, cacheControl)
+	httpCtx.Response.Headers.Set(httpx.HeaderETag, strconv.Quote(etag))
+
+	return pages[0], nil
+}
@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/bloom42/stdx-go/httpx"
+	"markdown.ninja/pkg/server/cachecontrol"
+	"markdown.ninja/pkg/server/httpctx"
+	"markdown.ninja/pkg/services/content"
+	"markdown.ninja/pkg/services/site"
+)
+
+var headlessListPagesDefaultFields = []string{
+	"id", "type", "status", "date", "modified_at", "title", "path", "url", "description", "language",
+	"paid_only", "body_hash", "metadata_hash", "tags", "authors",
+}
+
+func (service *SiteService) HeadlessListPages(ctx context.Context, input site.HeadlessListPagesInput) (ret site.HeadlessPagesResult, err error) {
+	httpCtx := httpctx.FromCtx(ctx)
+	hostname := httpCtx.Hostname
+	cacheControl := cachecontrol.HeadlessApiContent
+
+	website, err := service.websitesService.FindWebsiteByDomain(ctx, service.db, hostname)
+	if err != nil {
+		return
+	}
+
+	err = service.checkHeadlessApiKey(ctx, website)
+	if err != nil {
+		return
+	}
+
+	fields, err := parseHeadlessFields(input.Fields, headlessListPagesDefaultFields)
+	if err != nil {
+		return
+	}
+
+	format, err := parseHeadlessBodyFormat(input.Format)
+	if err != nil {
+		return
+	}
+
+	filter := content.PagesFilter{
+		Types:    []content.PageType{content.PageTypePage, content.PageTypePost},
+		Statuses: []content.PageStatus{content.PageStatusPublished},
+		Tag:      input.Tag,
+		Language: input.Language,
+		Limit:    site.HeadlessPagesDefaultLimit,
+	}
+
+	if input.Type != nil {
+		if *input.Type != content.PageTypePage &&
+			*input.Type != content.PageTypePost {
+			err = content.ErrPageTypeIsNotValid
+			return
+		}
+		filter.Types = []content.PageType{*input.Type}
+	}
+
+	if input.Limit != nil {
+		if *input.Limit < 1 || *input.Limit > site.HeadlessPagesMaxLimit {
+			err = site.ErrHeadlessLimitIsNotValid
+			return
+		}
+		filter.Limit = *input.Limit
+	}
+
+	filter.From, err = parseHeadlessDate("from", input.From, false)
+	if err != nil {
+		return
+	}
+
+	filter.To, err = parseHeadlessDate("to", input.To, true)
+	if err != nil {
+		return
+	}
+
+	if input.After != nil {
+		var cursor content.PagesCursor
+		cursor, err = decodeHeadlessCursor(*input.After)
+		if err != nil {
+			return
+		}
+		filter.After = &cursor
+	}
+
+	if input.Drafts {
+		if input.PreviewToken == nil {
+			err = site.ErrHeadlessDraftsRequirePreviewToken
+			return
+		}
+
+		err = service.contentService.VerifyPreviewToken(website.ID, *input.PreviewToken)
+		if err != nil {
+			return
+		}
+		filter.Statuses = append(filter.Statuses, content.PageStatusDraft, content.PageStatusScheduled)
+	}
+
+	// we fetch one more page to know if there is a next batch of results
+	limit := filter.Limit
+	filter.Limit += 1
+	pages, err := service.contentService.FindPagesMetadataWithFilter(ctx, service.db, website.ID, filter)
+	if err != nil {
+		return
+	}
+
+	if int64(len(pages)) > limit {
+		pages = pages[:limit]
+		nextCursor := encodeHeadlessCursor(pages[len(pages)-1])
+		ret.NextCursor = &nextCursor
+	}
+
+	// handle caching
+	etag := generateEtagForHeadlessPages(httpCtx.Url, website.ModifiedAt, pages)
+	if httpCtx.Request.IfNoneMatch != nil && *httpCtx.Request.IfNoneMatch == etag {
+		httpCtx.Response.CacheHit = &httpctx.CacheHit{
+			CacheControl: cacheControl,
+			ETag:         etag,
+		}
+		return ret, nil
+	}
+
+	ret.Data, err = service.convertHeadlessPages(ctx, website, pages, fields, format)
+	if err != nil {
+		return
+	}
+
+	httpCtx.Response.Headers.Set(httpx.HeaderCacheControl, cacheControl)
+	httpCtx.Response.Headers.Set(httpx.HeaderETag, strconv.Quote(etag))
+
+	return ret, nil
+}
@@ -0,0 +1,244 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/bloom42/stdx-go/crypto/blake3"
+	"github.com/bloom42/stdx-go/guid"
+	"markdown.ninja/pkg/services/content"
+	"markdown.ninja/pkg/services/organizations"
+	"markdown.ninja/pkg/services/site"
+	"markdown.ninja/pkg/services/websites"
+)
+
+const headlessCursorSize = 8 + guid.Size
+
+// checkHeadlessApiKey verifies that the request is authenticated with an API key that can read the
+// content of the website
+func (service *SiteService) checkHeadlessApiKey(ctx context.Context, website websites.Website) (err error) {
+	_, err = service.organizationsService.CheckCurrentApiKey(ctx, website.OrganizationID, website.ID, organizations.ApiKeyScopeContentRead)
+	return err
+}
+
+// parseHeadlessFields parses a comma-separated list of fields. defaultFields are returned if fields
+// is empty.
+func parseHeadlessFields(fields *string, defaultFields []string) (ret []string, err error) {
+	if fields == nil || strings.TrimSpace(*fields) == "" {
+		return defaultFields, nil
+	}
+
+	ret = make([]string, 0, len(site.HeadlessPageFields))
+	for field := range strings.SplitSeq(*fields, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		if !slices.Contains(site.HeadlessPageFields, field) {
+			return nil, site.ErrHeadlessFieldIsNotValid(field)
+		}
+		if !slices.Contains(ret, field) {
+			ret = append(ret, field)
+		}
+	}
+
+	return ret, nil
+}
+
+func parseHeadlessBodyFormat(format *site.HeadlessBodyFormat) (ret site.HeadlessBodyFormat, err error) {
+	if format == nil {
+		return site.HeadlessBodyFormatHtml, nil
+	}
+
+	switch *format {
+	case site.HeadlessBodyFormatHtml, site.HeadlessBodyFormatMarkdown:
+		return *format, nil
+	default:
+		return ret, site.ErrHeadlessBodyFormatIsNotValid
+	}
+}
+
+// parseHeadlessDate parses a date (YYYY-MM-DD) or an RFC 3339 timestamp. As date ranges are inclusive,
+// a date is converted to the end of the day if endOfDay is true.
+func parseHeadlessDate(param string, value *string, endOfDay bool) (ret *time.Time, err error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	date, err := time.Parse(time.DateOnly, *value)
+	if err == nil {
+		if endOfDay {
+			date = date.Add(24*time.Hour - time.Microsecond)
+		}
+		return &date, nil
+	}
+
+	date, err = time.Parse(time.RFC3339, *value)
+	if err != nil {
+		return nil, site.ErrHeadlessDateIsNotValid(param)
+	}
+
+	date = date.UTC()
+	return &date, nil
+}
+
+// encodeHeadlessCursor encodes the position of a page. Postgres timestamps have a microsecond precision
+// so the date is encoded in microseconds.
+func encodeHeadlessCursor(page content.PageMetadata) string {
+	cursor := make([]byte, 0, headlessCursorSize)
+	cursor = binary.BigEndian.AppendUint64(cursor, uint64(page.Date.UnixMicro()))
+	cursor = append(cursor, page.ID.Bytes()...)
+	return base64.RawURLEncoding.EncodeToString(cursor)
+}
+
+func decodeHeadlessCursor(cursor string) (ret content.PagesCursor, err error) {
+	cursorBytes, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil || len(cursorBytes) != headlessCursorSize {
+		err = site.ErrHeadlessCursorIsNotValid
+		return
+	}
+
+	ret.Date = time.UnixMicro(int64(binary.BigEndian.Uint64(cursorBytes[:8]))).UTC()
+	ret.ID, err = guid.FromBytes(cursorBytes[8:])
+	if err != nil {
+		err = site.ErrHeadlessCursorIsNotValid
+		return
+	}
+
+	return ret, nil
+}
+
+// generateEtagForHeadlessPages computes the ETag of a response of the headless API from the pages
+// it contains. The URL contains the selected fields and the format of the body, and websiteModifiedAt
+// changes when tags, authors or snippets are modified.
+func generateEtagForHeadlessPages(url *url.URL, websiteModifiedAt time.Time, pages []content.PageMetadata) string {
+	var hash [32]byte
+
+	hasher := blake3.New(32, nil)
+	hasher.Write([]byte(url.String()))
+	binary.Write(hasher, binary.LittleEndian, websiteModifiedAt.UnixMicro())
+	for _, page := range pages {
+		hasher.Write(page.ID.Bytes())
+		binary.Write(hasher, binary.LittleEndian, page.UpdatedAt.UnixMicro())
+		binary.Write(hasher, binary.LittleEndian, page.Date.UnixMicro())
+		hasher.Write([]byte(page.Status))
+		hasher.Write(page.BodyHash)
+		hasher.Write(page.MetadataHash)
+	}
+	hasher.Sum(hash[:0])
+
+	return base64.RawURLEncoding.EncodeToString(hash[:])
+}
+
+// convertHeadlessPages converts pages to the format of the headless API. Tags, authors and bodies
+// are loaded only if they are selected. API keys are only given to the staff of the website so
+// paid-only pages are returned in full.
+func (service *SiteService) convertHeadlessPages(ctx context.Context, website websites.Website, pages []content.PageMetadata,
+	fields []string, format site.HeadlessBodyFormat) (ret []site.HeadlessPage, err error) {
+	ret = make([]site.HeadlessPage, 0, len(pages))
+	if len(pages) == 0 {
+		return ret, nil
+	}
+
+	pageIDs := make([]guid.GUID, len(pages))
+	for i, page := range pages {
+		pageIDs[i] = page.ID
+	}
+
+	var pagesTags map[guid.GUID][]content.Tag
+	if slices.Contains(fields, "tags") {
+		pagesTags, err = service.contentService.FindTagsForPages(ctx, service.db, pageIDs)
+		if err != nil {
+			return
+		}
+	}
+
+	var pagesAuthors map[guid.GUID][]site.Author
+	if slices.Contains(fields, "authors") {
+		pagesAuthors, err = service.findAuthorsForPages(ctx, website, pageIDs)
+		if err != nil {
+			return
+		}
+	}
+
+	var pagesWithBody map[guid.GUID]content.Page
+	var snippets []content.Snippet
+	if slices.Contains(fields, "body") {
+		var fullPages []content.Page
+		fullPages, err = service.contentService.FindPagesByIDs(ctx, service.db, website.ID, pageIDs)
+		if err != nil {
+			return
+		}
+		pagesWithBody = make(map[guid.GUID]content.Page, len(fullPages))
+		for _, page := range fullPages {
+			pagesWithBody[page.ID] = page
+		}
+
+		if format == site.HeadlessBodyFormatHtml {
+			snippets, err = service.contentService.FindSnippets(ctx, service.db, website.ID)
+			if err != nil {
+				return
+			}
+		}
+	}
+
+	for _, page := range pages {
+		metadata := service.convertPageMetadata(website, page)
+		headlessPage := make(site.HeadlessPage, len(fields))
+
+		for _, field := range fields {
+			switch field {
+			case "id":
+				headlessPage[field] = page.ID
+			case "type":
+				headlessPage[field] = metadata.Type
+			case "status":
+				headlessPage[field] = page.Status
+			case "date":
+				headlessPage[field] = metadata.Date
+			case "modified_at":
+				headlessPage[field] = metadata.ModifiedAt
+			case "title":
+				headlessPage[field] = metadata.Title
+			case "path":
+				headlessPage[field] = metadata.Path
+			case "url":
+				headlessPage[field] = metadata.Url
+			case "description":
+				headlessPage[field] = metadata.Description
+			case "language":
+				headlessPage[field] = metadata.Language
+			case "paid_only":
+				headlessPage[field] = metadata.PaidOnly
+			case "body_hash":
+				headlessPage[field] = metadata.BodyHash
+			case "metadata_hash":
+				headlessPage[field] = metadata.MetadataHash
+			case "tags":
+				headlessPage[field] = service.convertTags(pagesTags[page.ID])
+			case "authors":
+				authors := pagesAuthors[page.ID]
+				if authors == nil {
+					authors = []site.Author{}
+				}
+				headlessPage[field] = authors
+			case "body":
+				pageWithBody := pagesWithBody[page.ID]
+				if format == site.HeadlessBodyFormatMarkdown {
+					headlessPage[field] = pageWithBody.BodyMarkdown
+				} else {
+					headlessPage[field] = service.renderPageBodyHtml(ctx, website, pageWithBody, snippets, true)
+				}
+			}
+		}
+
+		ret = append(ret, headlessPage)
+	}
+
+	return ret, nil
+}
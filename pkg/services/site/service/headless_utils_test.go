@@ -0,0 +1,98 @@
+package service
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/bloom42/stdx-go/guid"
+	"markdown.ninja/pkg/services/content"
+)
+
+func TestHeadlessCursor(t *testing.T) {
+	page := content.PageMetadata{
+		ID:   guid.NewTimeBased(),
+		Date: time.Date(2025, 3, 14, 15, 9, 26, 535_897_000, time.UTC),
+	}
+
+	cursor, err := decodeHeadlessCursor(encodeHeadlessCursor(page))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cursor.ID.Equal(page.ID) {
+		t.Errorf("cursor.ID (%s) != page.ID (%s)", cursor.ID, page.ID)
+	}
+	if !cursor.Date.Equal(page.Date) {
+		t.Errorf("cursor.Date (%s) != page.Date (%s)", cursor.Date, page.Date)
+	}
+
+	for _, invalidCursor := range []string{"", "abc", "!!!!", encodeHeadlessCursor(page) + "AA"} {
+		_, err = decodeHeadlessCursor(invalidCursor)
+		if err == nil {
+			t.Errorf("cursor %q should not be valid", invalidCursor)
+		}
+	}
+}
+
+func TestParseHeadlessFields(t *testing.T) {
+	defaultFields := []string{"id", "title"}
+
+	fields, err := parseHeadlessFields(nil, defaultFields)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(fields, defaultFields) {
+		t.Errorf("expected default fields, got %v", fields)
+	}
+
+	input := " title, body,,title ,tags"
+	fields, err = parseHeadlessFields(&input, defaultFields)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"title", "body", "tags"}
+	if !slices.Equal(fields, expected) {
+		t.Errorf("expected %v, got %v", expected, fields)
+	}
+
+	input = "title,body_markdown"
+	_, err = parseHeadlessFields(&input, defaultFields)
+	if err == nil {
+		t.Error("body_markdown should not be a valid field")
+	}
+}
+
+func TestParseHeadlessDate(t *testing.T) {
+	value := "2025-02-01"
+
+	from, err := parseHeadlessDate("from", &value, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !from.Equal(time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected from date: %s", from)
+	}
+
+	to, err := parseHeadlessDate("to", &value, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !to.Equal(time.Date(2025, 2, 1, 23, 59, 59, 999_999_000, time.UTC)) {
+		t.Errorf("unexpected to date: %s", to)
+	}
+
+	value = "2025-02-01T10:00:00+02:00"
+	to, err = parseHeadlessDate("to", &value, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !to.Equal(time.Date(2025, 2, 1, 8, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected to date: %s", to)
+	}
+
+	value = "01/02/2025"
+	_, err = parseHeadlessDate("from", &value, false)
+	if err == nil {
+		t.Error("01/02/2025 should not be a valid date")
+	}
+}
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"html/template"
+	"net/url"
+
+	"github.com/bloom42/stdx-go/languages"
+	"golang.org/x/text/language"
+	"markdown.ninja/pkg/services/content"
+	"markdown.ninja/pkg/services/site"
+	"markdown.ninja/pkg/services/websites"
+)
+
+// convertWebsiteLanguages returns the languages of the website, starting with its default language.
+// It returns an empty list if the website is not multilingual.
+func (service *SiteService) convertWebsiteLanguages(website websites.Website, websiteUrl template.URL) []site.WebsiteLanguage {
+	if len(website.Languages) == 0 {
+		return []site.WebsiteLanguage{}
+	}
+
+	codes := website.LanguageCodes()
+	ret := make([]site.WebsiteLanguage, len(codes))
+	for i, code := range codes {
+		websiteLanguage, _ := website.FindLanguage(code)
+		ret[i] = site.WebsiteLanguage{
+			Code:       code,
+			Name:       languages.Get()[code].NativeName,
+			Url:        websiteUrl + template.URL(websiteLanguage.PathPrefix),
+			Navigation: websiteLanguage.Navigation,
+		}
+	}
+
+	return ret
+}
+
+// findPageTranslations returns the published versions of the page in other languages
+func (service *SiteService) findPageTranslations(ctx context.Context, website websites.Website, page content.Page) (ret []site.PageTranslation, err error) {
+	translations, err := service.contentService.FindPageTranslations(ctx, service.db, page)
+	if err != nil {
+		return
+	}
+
+	websiteUrl := service.convertWebsite(website).Url
+	ret = make([]site.PageTranslation, len(translations))
+	for i, translation := range translations {
+		ret[i] = site.PageTranslation{
+			Language: translation.Language,
+			Title:    translation.Title,
+			Url:      websiteUrl + template.URL(translation.Path),
+		}
+	}
+
+	return ret, nil
+}
+
+// matchAcceptLanguage returns the language of the website that best matches the Accept-Language
+// header of the request, or the default language of the website if none matches.
+func matchAcceptLanguage(website websites.Website, acceptLanguage string) string {
+	codes := website.LanguageCodes()
+
+	preferredTags, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(preferredTags) == 0 {
+		return codes[0]
+	}
+
+	supportedTags := make([]language.Tag, len(codes))
+	for i, code := range codes {
+		supportedTags[i] = language.Make(code)
+	}
+
+	_, index, confidence := language.NewMatcher(supportedTags).Match(preferredTags...)
+	if confidence == language.No {
+		return codes[0]
+	}
+
+	return codes[index]
+}
+
+// isInternalReferer returns true if the referer is a page of the website
+func isInternalReferer(website websites.Website, referer string) bool {
+	if referer == "" {
+		return false
+	}
+
+	refererUrl, err := url.Parse(referer)
+	if err != nil {
+		return false
+	}
+
+	return refererUrl.Hostname() == website.PrimaryDomain
+}
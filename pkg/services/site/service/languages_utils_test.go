@@ -0,0 +1,61 @@
+package service
+
+import (
+	"testing"
+
+	"markdown.ninja/pkg/services/websites"
+)
+
+func TestMatchAcceptLanguage(t *testing.T) {
+	website := websites.Website{
+		Language:      "en",
+		PrimaryDomain: "example.com",
+		Languages: websites.WebsiteLanguages{
+			{Code: "fr", PathPrefix: "/fr"},
+			{Code: "de", PathPrefix: "/de"},
+		},
+	}
+
+	tests := []struct {
+		acceptLanguage string
+		expected       string
+	}{
+		{"", "en"},
+		{"fr-FR,fr;q=0.9,en;q=0.8", "fr"},
+		{"de", "de"},
+		{"en-US,en;q=0.9", "en"},
+		{"ja,zh;q=0.5", "en"},
+		{"es;q=0.9,de;q=0.5", "de"},
+		{"!!invalid!!", "en"},
+	}
+
+	for _, test := range tests {
+		language := matchAcceptLanguage(website, test.acceptLanguage)
+		if language != test.expected {
+			t.Errorf("matchAcceptLanguage(%q) = %q, expected %q", test.acceptLanguage, language, test.expected)
+		}
+	}
+}
+
+func TestIsInternalReferer(t *testing.T) {
+	website := websites.Website{PrimaryDomain: "example.com"}
+
+	tests := []struct {
+		referer  string
+		expected bool
+	}{
+		{"", false},
+		{"https://example.com/fr", true},
+		{"http://example.com:8080/", true},
+		{"https://www.google.com/", false},
+		{"https://example.com.evil.com/", false},
+		{"::invalid", false},
+	}
+
+	for _, test := range tests {
+		isInternal := isInternalReferer(website, test.referer)
+		if isInternal != test.expected {
+			t.Errorf("isInternalReferer(%q) = %v, expected %v", test.referer, isInternal, test.expected)
+		}
+	}
+}
@@ -24,6 +24,6 @@ func (service *SiteService) ListMyProducts(ctx context.Context, input kernel.Emp
 		return ret, err
 	}
 
-	ret.Data = service.convertProducts(website, products)
+	ret.Data = service.convertProducts(ctx, website, products)
 	return ret, nil
 }
@@ -45,6 +45,24 @@ func (service *SiteService) ListPages(ctx context.Context, input site.ListPagesI
 			HeaderReferrer:       httpCtx.Headers.Get(httpx.HeaderReferer),
 			HeaderUserAgent:      httpCtx.Client.UserAgent,
 			QueryParameterRef:    httpCtx.Url.Query().Get("ref"),
+			Utm:                  events.UtmParametersFromQuery(httpCtx.Url.Query()),
+			WebsiteID:            website.ID,
+		}
+		service.eventsService.TrackPageView(ctx, trackEventInput)
+	} else if input.Author != nil {
+		_, err = service.contentService.FindAuthor(ctx, service.db, website.ID, *input.Author)
+		if err != nil {
+			return ret, err
+		}
+
+		trackEventInput := events.TrackPageViewInput{
+			WebsitePrimaryDomain: website.PrimaryDomain,
+			Path:                 "/authors/" + *input.Author,
+			IpAddress:            httpCtx.Client.IPStr,
+			HeaderReferrer:       httpCtx.Headers.Get(httpx.HeaderReferer),
+			HeaderUserAgent:      httpCtx.Client.UserAgent,
+			QueryParameterRef:    httpCtx.Url.Query().Get("ref"),
+			Utm:                  events.UtmParametersFromQuery(httpCtx.Url.Query()),
 			WebsiteID:            website.ID,
 		}
 		service.eventsService.TrackPageView(ctx, trackEventInput)
@@ -61,7 +79,7 @@ func (service *SiteService) ListPages(ctx context.Context, input site.ListPagesI
 		}
 		pageTypes = []content.PageType{*input.Type}
 
-		if *input.Type == content.PageTypePost && input.Tag == nil {
+		if *input.Type == content.PageTypePost && input.Tag == nil && input.Author == nil {
 			trackEventInput := events.TrackPageViewInput{
 				WebsitePrimaryDomain: website.PrimaryDomain,
 				Path:                 "/blog",
@@ -69,6 +87,7 @@ func (service *SiteService) ListPages(ctx context.Context, input site.ListPagesI
 				HeaderReferrer:       httpCtx.Headers.Get(httpx.HeaderReferer),
 				HeaderUserAgent:      httpCtx.Client.UserAgent,
 				QueryParameterRef:    httpCtx.Url.Query().Get("ref"),
+				Utm:                  events.UtmParametersFromQuery(httpCtx.Url.Query()),
 				WebsiteID:            website.ID,
 			}
 			service.eventsService.TrackPageView(ctx, trackEventInput)
@@ -103,6 +122,11 @@ func (service *SiteService) ListPages(ctx context.Context, input site.ListPagesI
 		if err != nil {
 			return ret, err
 		}
+	} else if input.Author != nil {
+		pages, err = service.contentService.FindPublishedPagesMetadataForAuthor(ctx, service.db, website.ID, pageTypes, *input.Author)
+		if err != nil {
+			return ret, err
+		}
 	} else {
 		pages, err = service.contentService.FindPublishedPagesMetadata(ctx, service.db, website.ID, pageTypes, 20_000)
 		if err != nil {
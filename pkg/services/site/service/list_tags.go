@@ -32,6 +32,7 @@ func (service *SiteService) ListTags(ctx context.Context, input kernel.EmptyInpu
 		HeaderReferrer:       httpCtx.Headers.Get(httpx.HeaderReferer),
 		HeaderUserAgent:      httpCtx.Client.UserAgent,
 		QueryParameterRef:    httpCtx.Url.Query().Get("ref"),
+		Utm:                  events.UtmParametersFromQuery(httpCtx.Url.Query()),
 		WebsiteID:            website.ID,
 	}
 	service.eventsService.TrackPageView(ctx, trackEventInput)
@@ -0,0 +1,48 @@
+package service
+
+import (
+	"context"
+
+	"markdown.ninja/pkg/services/contacts"
+	"markdown.ninja/pkg/services/content"
+	"markdown.ninja/pkg/services/site"
+	"markdown.ninja/pkg/services/websites"
+)
+
+// pageAccess returns true if the visitor can read the full body of the page.
+// Paid-only pages are reserved to the contacts with an active subscription.
+func (service *SiteService) pageAccess(ctx context.Context, website websites.Website, page content.Page, contact *contacts.Contact) (hasAccess bool, err error) {
+	if !page.PaidOnly {
+		return true, nil
+	}
+
+	if contact == nil {
+		return false, nil
+	}
+
+	return service.storeService.ContactHasActiveSubscription(ctx, service.db, website.ID, contact.ID)
+}
+
+// findPaywall returns the subscription plans that can be purchased to read paid-only pages
+func (service *SiteService) findPaywall(ctx context.Context, website websites.Website) (paywall *site.Paywall, err error) {
+	products, err := service.storeService.FindActiveSubscriptionProductsForWebsite(ctx, service.db, website.ID)
+	if err != nil {
+		return
+	}
+
+	paywall = &site.Paywall{
+		Currency: website.Currency,
+		Plans:    make([]site.SubscriptionPlan, len(products)),
+	}
+	for i, product := range products {
+		paywall.Plans[i] = site.SubscriptionPlan{
+			ProductID:    product.ID,
+			Name:         product.Name,
+			Description:  product.Description,
+			MonthlyPrice: product.Price,
+			YearlyPrice:  product.YearlyPrice,
+		}
+	}
+
+	return
+}
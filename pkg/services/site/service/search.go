@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/bloom42/stdx-go/httpx"
+	"markdown.ninja/pkg/server/cachecontrol"
+	"markdown.ninja/pkg/server/httpctx"
+	"markdown.ninja/pkg/services/content"
+	"markdown.ninja/pkg/services/kernel"
+	"markdown.ninja/pkg/services/site"
+)
+
+func (service *SiteService) Search(ctx context.Context, input site.SearchInput) (ret kernel.PaginatedResult[site.SearchResult], err error) {
+	httpCtx := httpctx.FromCtx(ctx)
+	hostname := httpCtx.Hostname
+	cacheControl := cachecontrol.HeadlessApiSearch
+	contact := service.contactsService.CurrentContact(ctx)
+
+	website, err := service.websitesService.FindWebsiteByDomain(ctx, service.db, hostname)
+	if err != nil {
+		return ret, err
+	}
+
+	searchInput := content.SearchPagesInput{
+		WebsiteID: website.ID,
+		Query:     input.Query,
+		Language:  "",
+		Types:     []content.PageType{content.PageTypePage, content.PageTypePost},
+		Limit:     content.SearchDefaultLimit,
+	}
+	if input.Type != nil {
+		if *input.Type != content.PageTypePage &&
+			*input.Type != content.PageTypePost {
+			err = content.ErrPageTypeIsNotValid
+			return
+		}
+		searchInput.Types = []content.PageType{*input.Type}
+	}
+	if input.Language != nil {
+		searchInput.Language = *input.Language
+	}
+
+	// results only change when the content of the website changes
+	etag := generateEtagForListPages(httpCtx.Url, website.ModifiedAt.Truncate(time.Second))
+
+	if contact == nil && httpCtx.Request.IfNoneMatch != nil && *httpCtx.Request.IfNoneMatch == etag {
+		httpCtx.Response.CacheHit = &httpctx.CacheHit{
+			CacheControl: cacheControl,
+			ETag:         etag,
+		}
+		return ret, nil
+	}
+
+	results, err := service.contentService.SearchPages(ctx, service.db, searchInput)
+	if err != nil {
+		return ret, err
+	}
+
+	ret.Data = make([]site.SearchResult, len(results))
+	for i, result := range results {
+		bodyHighlight := result.BodyHighlight
+		// search results are cached for all the visitors, so we never show excerpts of paid-only pages
+		if result.PaidOnly {
+			bodyHighlight = ""
+		}
+		ret.Data[i] = site.SearchResult{
+			PageMetadata:   service.convertPageMetadata(website, result.PageMetadata),
+			TitleHighlight: result.TitleHighlight,
+			BodyHighlight:  bodyHighlight,
+		}
+	}
+
+	httpCtx.Response.Headers.Set(httpx.HeaderCacheControl, cacheControl)
+	httpCtx.Response.Headers.Set(httpx.HeaderETag, strconv.Quote(etag))
+
+	return ret, nil
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/bloom42/stdx-go/log/slogx"
 	"github.com/bloom42/stdx-go/memorycache"
 	"markdown.ninja/pkg/errs"
+	"markdown.ninja/pkg/images"
 	"markdown.ninja/pkg/server/cachecontrol"
 	"markdown.ninja/pkg/server/httpctx"
 	"markdown.ninja/pkg/services/content"
@@ -79,6 +80,18 @@ func (service *SiteService) serveAsset(ctx context.Context, res http.ResponseWri
 		return
 	}
 
+	imageTransformOptions, err := images.ParseTransformOptions(httpCtx.Url.Query())
+	if err != nil {
+		service.serveError(ctx, res, []byte(err.Error()), http.StatusBadRequest)
+		return
+	}
+	// assets that can't be transformed are served unchanged
+	if _, isTransformable := images.SourceFormat(asset.MediaType); imageTransformOptions != nil &&
+		asset.Type == content.AssetTypeImage && isTransformable && asset.Size <= images.MaxSourceSize {
+		service.serveImageVariant(ctx, res, req, website, hostname, url, asset, *imageTransformOptions)
+		return
+	}
+
 	rangeHeader := strings.TrimSpace(req.Header.Get(httpx.HeaderRange))
 
 	etag := generateAssetEtag(&asset, rangeHeader)
@@ -194,7 +207,7 @@ func (service *SiteService) servePartialAsset(ctx context.Context, res http.Resp
 			slog.String("website.id", website.ID.String()), slog.String("asset.id", asset.ID.String()),
 			slog.String("range", requestedRange),
 		)
-		err = fmt.Errorf("error getting asset data with range [%s]: %w", requestedRange)
+		err = fmt.Errorf("error getting asset data with range [%s]: %w", requestedRange, err)
 		service.serveInternalError(ctx, res, err, hostname, url)
 		return
 	}
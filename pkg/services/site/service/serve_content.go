@@ -3,6 +3,7 @@ package service
 import (
 	"fmt"
 	"net/http"
+	"net/url"
 	"strings"
 	"time"
 	"unicode/utf8"
@@ -49,6 +50,7 @@ func (service *SiteService) ServeContent(res http.ResponseWriter, req *http.Requ
 		HeaderReferrer:       httpCtx.Headers.Get(httpx.HeaderReferer),
 		HeaderUserAgent:      httpCtx.Client.UserAgent,
 		QueryParameterRef:    httpCtx.Url.Query().Get("ref"),
+		Utm:                  events.UtmParametersFromQuery(httpCtx.Url.Query()),
 		WebsiteID:            website.ID,
 	}
 
@@ -103,13 +105,22 @@ func (service *SiteService) ServeContent(res http.ResponseWriter, req *http.Requ
 		return
 	}
 
-	redirect := service.websitesService.MatchRedirect(ctx, hostname, path, redirects)
+	redirect := service.websitesService.MatchRedirect(ctx, hostname, path, req.URL.RawQuery, redirects)
 	if redirect != nil {
-		// Sleep a little bit to avoid DoS in case of loop
-		time.Sleep(100 * time.Millisecond)
-		res.Header().Set(httpx.HeaderCacheControl, cachecontrol.NoCache)
-		http.Redirect(res, req, redirect.To, int(redirect.Status))
-		return
+		if redirect.Status == http.StatusOK {
+			// rewrites serve the content of another path without changing the URL.
+			// Captures are escaped in destinations while paths are looked up unescaped.
+			path, _, _ = strings.Cut(redirect.To, "?")
+			if unescapedPath, err := url.PathUnescape(path); err == nil {
+				path = unescapedPath
+			}
+		} else {
+			// Sleep a little bit to avoid DoS in case of loop
+			time.Sleep(100 * time.Millisecond)
+			res.Header().Set(httpx.HeaderCacheControl, cachecontrol.NoCache)
+			http.Redirect(res, req, redirect.To, int(redirect.Status))
+			return
+		}
 	}
 
 	if strings.HasPrefix(path, "/assets") {
@@ -122,6 +133,22 @@ func (service *SiteService) ServeContent(res http.ResponseWriter, req *http.Requ
 		return
 	}
 
+	// redirect visitors landing on the home page of a multilingual website to the home page of
+	// their preferred language. Visitors coming from another page of the website are not redirected
+	// so they can switch language.
+	if path == "/" && len(website.Languages) != 0 {
+		res.Header().Add("Vary", httpx.HeaderAcceptLanguage)
+		if !isInternalReferer(website, httpCtx.Headers.Get(httpx.HeaderReferer)) {
+			preferredLanguage := matchAcceptLanguage(website, httpCtx.Headers.Get(httpx.HeaderAcceptLanguage))
+			websiteLanguage, _ := website.FindLanguage(preferredLanguage)
+			if websiteLanguage.PathPrefix != "" {
+				res.Header().Set(httpx.HeaderCacheControl, cachecontrol.NoCache)
+				http.Redirect(res, req, websiteLanguage.PathPrefix, http.StatusFound)
+				return
+			}
+		}
+	}
+
 	// check if page is found at url
 	page, err := service.contentService.FindPageByPath(ctx, service.db, website.ID, path)
 	if err != nil {
@@ -141,19 +168,35 @@ func (service *SiteService) ServeContent(res http.ResponseWriter, req *http.Requ
 			}
 		}
 
+		if websiteLanguage, subpath, found := website.FindLanguageForPath(path); found {
+			switch subpath {
+			case "/sitemap.xml":
+				service.serveSitemap(ctx, res, website, &websiteLanguage, hostname, path)
+				return
+
+			case "/feed.xml":
+				service.serveFeed(ctx, res, website, websites.FeedTypeRss, &websiteLanguage, hostname, path)
+				return
+
+			case "/feed.json":
+				service.serveFeed(ctx, res, website, websites.FeedTypeJson, &websiteLanguage, hostname, path)
+				return
+			}
+		}
+
 		// switch if faster than if / else if chains
 		// https://stackoverflow.com/questions/29566229/go-switch-string-efficiency
 		switch path {
 		case "/sitemap.xml":
-			service.serveSitemap(ctx, res, website, hostname, path)
+			service.serveSitemap(ctx, res, website, nil, hostname, path)
 			return
 
 		case "/feed.xml":
-			service.serveFeed(ctx, res, website, websites.FeedTypeRss, hostname, path)
+			service.serveFeed(ctx, res, website, websites.FeedTypeRss, nil, hostname, path)
 			return
 
 		case "/feed.json":
-			service.serveFeed(ctx, res, website, websites.FeedTypeJson, hostname, path)
+			service.serveFeed(ctx, res, website, websites.FeedTypeJson, nil, hostname, path)
 			return
 
 		case "/rss", "/rss.xml":
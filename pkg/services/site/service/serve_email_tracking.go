@@ -0,0 +1,68 @@
+package service
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/bloom42/stdx-go/httpx"
+	"github.com/bloom42/stdx-go/log/slogx"
+	"markdown.ninja/pkg/errs"
+	"markdown.ninja/pkg/server/cachecontrol"
+	"markdown.ninja/pkg/server/httpctx"
+)
+
+// 1x1 transparent GIF
+var emailTrackingPixel = []byte{
+	0x47, 0x49, 0x46, 0x38, 0x39, 0x61, 0x01, 0x00, 0x01, 0x00, 0x80, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0xff, 0xff, 0xff, 0x21, 0xf9, 0x04, 0x01, 0x00, 0x00, 0x00, 0x00, 0x2c, 0x00, 0x00, 0x00, 0x00,
+	0x01, 0x00, 0x01, 0x00, 0x00, 0x02, 0x02, 0x44, 0x01, 0x00, 0x3b,
+}
+
+// ServeEmailOpen records that a newsletter has been opened and always serves the tracking pixel, even
+// if the token is not valid, in order to not display a broken image in the email.
+func (service *SiteService) ServeEmailOpen(res http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	logger := slogx.FromCtx(ctx)
+	httpCtx := httpctx.FromCtx(ctx)
+
+	website, err := service.websitesService.FindWebsiteByDomain(ctx, service.db, httpCtx.Hostname)
+	if err == nil {
+		err = service.emailsService.TrackNewsletterOpened(ctx, website.ID, req.URL.Query().Get("token"))
+	}
+	if err != nil && !errs.IsNotFound(err) {
+		logger.Debug("site.ServeEmailOpen: error tracking email open", slogx.Err(err))
+	}
+
+	res.Header().Set(httpx.HeaderCacheControl, cachecontrol.NoCache)
+	res.Header().Set(httpx.HeaderContentType, "image/gif")
+	res.Header().Set(httpx.HeaderContentLength, strconv.FormatInt(int64(len(emailTrackingPixel)), 10))
+	res.WriteHeader(http.StatusOK)
+	res.Write(emailTrackingPixel)
+}
+
+// ServeEmailClick records that a link of a newsletter has been clicked and redirects to the original URL.
+// If the token is not valid (e.g. forged or signed with a key that has been rotated out), the recipient
+// is redirected to the homepage of the website.
+func (service *SiteService) ServeEmailClick(res http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	httpCtx := httpctx.FromCtx(ctx)
+	hostname := httpCtx.Hostname
+
+	website, err := service.websitesService.FindWebsiteByDomain(ctx, service.db, hostname)
+	if err != nil {
+		if errs.IsNotFound(err) {
+			service.serveSiteNotFoundError(ctx, res)
+			return
+		}
+		service.serveInternalError(ctx, res, err, hostname, httpCtx.Url.Path)
+		return
+	}
+
+	redirectUrl, err := service.emailsService.TrackNewsletterClicked(ctx, website.ID, req.URL.Query().Get("token"))
+	if err != nil {
+		redirectUrl = "/"
+	}
+
+	res.Header().Set(httpx.HeaderCacheControl, cachecontrol.NoCache)
+	http.Redirect(res, req, redirectUrl, http.StatusFound)
+}
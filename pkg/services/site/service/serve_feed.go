@@ -8,10 +8,12 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/bloom42/stdx-go/crypto/blake3"
 	"github.com/bloom42/stdx-go/feeds"
+	"github.com/bloom42/stdx-go/guid"
 	"github.com/bloom42/stdx-go/httpx"
 	"github.com/bloom42/stdx-go/log/slogx"
 	"github.com/bloom42/stdx-go/memorycache"
@@ -23,8 +25,10 @@ import (
 	"markdown.ninja/pkg/services/websites"
 )
 
+// serveFeed serves the feed of the website. If language is not nil, only the posts in this language are
+// included in the feed.
 func (service *SiteService) serveFeed(ctx context.Context, res http.ResponseWriter, website websites.Website,
-	feedType websites.FeedType, hostname, url string) {
+	feedType websites.FeedType, language *websites.WebsiteLanguage, hostname, url string) {
 	host := service.httpConfig.WebsitesBaseUrl.Scheme + "://" + website.PrimaryDomain + service.httpConfig.WebsitesPort
 	cacheControl := cachecontrol.WebsiteFeed
 	httpCtx := httpctx.FromCtx(ctx)
@@ -50,7 +54,7 @@ func (service *SiteService) serveFeed(ctx context.Context, res http.ResponseWrit
 		mediaType = httpx.MediaTypeJson
 	}
 
-	etag := generateFeedEtag(&website, modifiedAt)
+	etag := generateFeedEtag(&website, modifiedAt, language)
 	res.Header().Set(httpx.HeaderCacheControl, cacheControl)
 	res.Header().Set(httpx.HeaderETag, strconv.Quote(etag))
 	res.Header().Set(httpx.HeaderContentType, mediaType)
@@ -76,7 +80,30 @@ func (service *SiteService) serveFeed(ctx context.Context, res http.ResponseWrit
 		return
 	}
 
-	posts, err := service.contentService.FindPublishedPagesMetadata(ctx, service.db, website.ID, []content.PageType{content.PageTypePost}, 50)
+	var posts []content.PageMetadata
+	feedLanguage := website.Language
+	feedLink := host
+	if language != nil {
+		feedLanguage = language.Code
+		feedLink = host + language.PathPrefix
+		posts, err = service.contentService.FindPagesMetadataWithFilter(ctx, service.db, website.ID, content.PagesFilter{
+			Types:    []content.PageType{content.PageTypePost},
+			Language: &language.Code,
+			Limit:    50,
+		})
+	} else {
+		posts, err = service.contentService.FindPublishedPagesMetadata(ctx, service.db, website.ID, []content.PageType{content.PageTypePost}, 50)
+	}
+	if err != nil {
+		service.serveInternalError(ctx, res, err, hostname, url)
+		return
+	}
+
+	postIDs := make([]guid.GUID, len(posts))
+	for i, post := range posts {
+		postIDs[i] = post.ID
+	}
+	postsAuthors, err := service.findAuthorsForPages(ctx, website, postIDs)
 	if err != nil {
 		service.serveInternalError(ctx, res, err, hostname, url)
 		return
@@ -84,11 +111,11 @@ func (service *SiteService) serveFeed(ctx context.Context, res http.ResponseWrit
 
 	feed := &feeds.Feed{
 		Title:       website.Name,
-		Link:        &feeds.Link{Href: host},
+		Link:        &feeds.Link{Href: feedLink},
 		Description: website.Description,
 		// Author:      &feeds.Author{Name: "TODO", Email: "TODO"},
 		Created:  website.CreatedAt.Truncate(time.Hour),
-		Language: website.Language,
+		Language: feedLanguage,
 	}
 	feed.Items = make([]*feeds.Item, len(posts))
 
@@ -101,9 +128,17 @@ func (service *SiteService) serveFeed(ctx context.Context, res http.ResponseWrit
 			Title:       page.Title,
 			Link:        &feeds.Link{Href: host + page.Path},
 			Description: page.Description,
-			// Author:      &feeds.Author{Name: "TODO", Email: "TODO"},
-			Created: page.Date,
-			Updated: page.ModifiedAt().UTC().Truncate(time.Minute),
+			Created:     page.Date,
+			Updated:     page.ModifiedAt().UTC().Truncate(time.Minute),
+		}
+
+		// RSS only supports a single author per item so the names of all the authors are joined
+		if pageAuthors := postsAuthors[page.ID]; len(pageAuthors) != 0 {
+			authorsNames := make([]string, len(pageAuthors))
+			for j, author := range pageAuthors {
+				authorsNames[j] = author.Name
+			}
+			feed.Items[i].Author = &feeds.Author{Name: strings.Join(authorsNames, ", ")}
 		}
 	}
 
@@ -118,7 +153,28 @@ func (service *SiteService) serveFeed(ctx context.Context, res http.ResponseWrit
 		}
 
 	case websites.FeedTypeJson:
-		feedContent, err = feed.ToJSON()
+		jsonFeed := (&feeds.JSON{Feed: feed}).JSONFeed()
+		// JSON Feed supports multiple authors with their avatar and URL
+		for i, item := range jsonFeed.Items {
+			pageAuthors := postsAuthors[posts[i].ID]
+			if len(pageAuthors) == 0 {
+				continue
+			}
+
+			item.Authors = make([]*feeds.JSONAuthor, len(pageAuthors))
+			for j, author := range pageAuthors {
+				item.Authors[j] = &feeds.JSONAuthor{
+					Name: author.Name,
+					Url:  string(author.Url),
+				}
+				if author.Avatar != nil {
+					item.Authors[j].Avatar = *author.Avatar
+				}
+			}
+			item.Author = item.Authors[0]
+		}
+
+		feedContent, err = jsonFeed.ToJSON()
 		if err != nil {
 			err = fmt.Errorf("error encoding feed to JSON: %w", err)
 		}
@@ -140,12 +196,15 @@ func (service *SiteService) serveFeed(ctx context.Context, res http.ResponseWrit
 
 }
 
-func generateFeedEtag(website *websites.Website, modifiedAt time.Time) string {
+func generateFeedEtag(website *websites.Website, modifiedAt time.Time, language *websites.WebsiteLanguage) string {
 	var hash [32]byte
 
 	hasher := blake3.New(32, nil)
 	binary.Write(hasher, binary.LittleEndian, modifiedAt.Unix())
 	hasher.Write(website.ID[:])
+	if language != nil {
+		hasher.Write([]byte(language.Code))
+	}
 	hasher.Sum(hash[:0])
 
 	return base64.RawURLEncoding.EncodeToString(hash[:])
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/bloom42/stdx-go/crypto/blake3"
+	"github.com/bloom42/stdx-go/httpx"
+	"github.com/bloom42/stdx-go/log/slogx"
+	"github.com/bloom42/stdx-go/memorycache"
+	"markdown.ninja/pkg/errs"
+	"markdown.ninja/pkg/images"
+	"markdown.ninja/pkg/server/cachecontrol"
+	"markdown.ninja/pkg/server/httpctx"
+	"markdown.ninja/pkg/services/content"
+	"markdown.ninja/pkg/services/websites"
+)
+
+// serveImageVariant serves an image asset resized and converted according to options.
+// If no format is requested, the most efficient format accepted by the client is used.
+func (service *SiteService) serveImageVariant(ctx context.Context, res http.ResponseWriter, req *http.Request,
+	website websites.Website, hostname, url string, asset content.Asset, options images.TransformOptions) {
+	contact := service.contactsService.CurrentContact(ctx)
+	httpCtx := httpctx.FromCtx(ctx)
+	logger := slogx.FromCtx(ctx)
+
+	if options.Format == "" {
+		options.Format = images.PreferredFormat(req.Header.Get(httpx.HeaderAccept))
+		// the response depends on the formats accepted by the client
+		res.Header().Set("Vary", httpx.HeaderAccept)
+	}
+
+	sourceFormat, _ := images.SourceFormat(asset.MediaType)
+	format, err := images.ResolveFormat(sourceFormat, options.Format)
+	if err != nil {
+		service.serveError(ctx, res, []byte(err.Error()), http.StatusBadRequest)
+		return
+	}
+	options.Format = format
+	// the quality is ignored by lossless formats: it must not create distinct variants
+	if !format.IsLossy() {
+		options.Quality = images.DefaultQuality
+	}
+
+	etag := generateImageVariantEtag(&asset, options)
+	res.Header().Set(httpx.HeaderCacheControl, cachecontrol.WebsiteAsset)
+	res.Header().Set(httpx.HeaderETag, strconv.Quote(etag))
+
+	if contact == nil &&
+		httpCtx.Request.IfNoneMatch != nil && *httpCtx.Request.IfNoneMatch == etag {
+		res.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	var variant []byte
+	if cachedVariant := service.assetsCache.Get(etag); cachedVariant != nil {
+		logger.Debug("site.serveImageVariant: memory cache hit")
+		variant = cachedVariant.Value()
+	} else {
+		variant, _, err = service.contentService.GetImageVariant(ctx, asset, options)
+		if err != nil {
+			var invalidArgumentErr *errs.InvalidArgumentError
+			if errors.As(err, &invalidArgumentErr) {
+				service.serveError(ctx, res, []byte(err.Error()), http.StatusBadRequest)
+				return
+			}
+			service.serveInternalError(ctx, res, err, hostname, url)
+			return
+		}
+
+		// cache variants smaller or equal to 1 MB
+		if len(variant) <= 1_000_000 {
+			service.assetsCache.Set(etag, variant, memorycache.DefaultTTL)
+		}
+	}
+
+	filename := asset.Name
+	if format != sourceFormat {
+		filename = strings.TrimSuffix(asset.Name, path.Ext(asset.Name)) + format.Extension()
+	}
+	res.Header().Set(httpx.HeaderContentDisposition, fmt.Sprintf("filename=%s", strconv.Quote(filename)))
+	res.Header().Set(httpx.HeaderContentType, format.MediaType())
+	res.Header().Set(httpx.HeaderContentLength, strconv.FormatInt(int64(len(variant)), 10))
+	res.WriteHeader(http.StatusOK)
+	res.Write(variant)
+}
+
+func generateImageVariantEtag(asset *content.Asset, options images.TransformOptions) string {
+	var hash [32]byte
+
+	hasher := blake3.New(32, nil)
+	hasher.Write(asset.Hash)
+	hasher.Write([]byte(options.String()))
+	hasher.Sum(hash[:0])
+
+	return base64.RawURLEncoding.EncodeToString(hash[:])
+}
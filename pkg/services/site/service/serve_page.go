@@ -34,7 +34,13 @@ func (service *SiteService) servePage(ctx context.Context, res http.ResponseWrit
 		return
 	}
 
-	etag := computePageEtag(&page, website.ModifiedAt, service.themes[website.Theme].Hash, contact)
+	hasAccess, err := service.pageAccess(ctx, website, page, contact)
+	if err != nil {
+		service.serveInternalError(ctx, res, err, hostname, url)
+		return
+	}
+
+	etag := computePageEtag(&page, website.ModifiedAt, service.themes[website.Theme].Hash, contact, hasAccess)
 	if statusCode == http.StatusOK &&
 		httpCtx.Request.IfNoneMatch != nil && *httpCtx.Request.IfNoneMatch == etag {
 		res.Header().Set(httpx.HeaderCacheControl, cacheControl)
@@ -64,13 +70,32 @@ func (service *SiteService) servePage(ctx context.Context, res http.ResponseWrit
 		return
 	}
 
+	authors, err := service.findAuthorsForPage(ctx, website, page.ID)
+	if err != nil {
+		service.serveInternalError(ctx, res, err, hostname, url)
+		return
+	}
+
+	translations, err := service.findPageTranslations(ctx, website, page)
+	if err != nil {
+		service.serveInternalError(ctx, res, err, hostname, url)
+		return
+	}
+
 	snippets, err := service.contentService.FindSnippets(ctx, service.db, website.ID)
 	if err != nil {
 		service.serveInternalError(ctx, res, err, hostname, url)
 		return
 	}
 
-	sitePage := service.convertPage(ctx, website, page, tags, snippets)
+	sitePage := service.convertPage(ctx, website, page, tags, authors, translations, snippets, hasAccess)
+	if !hasAccess {
+		sitePage.Paywall, err = service.findPaywall(ctx, website)
+		if err != nil {
+			service.serveInternalError(ctx, res, err, hostname, url)
+			return
+		}
+	}
 
 	contentBuffer := bytes.NewBuffer(make([]byte, 0, 50_000))
 	template := service.themes[website.Theme].IndexTemplate
@@ -118,11 +143,12 @@ func (service *SiteService) servePage(ctx context.Context, res http.ResponseWrit
 	res.Write(contentBytes)
 }
 
-// the etag for a page is computed from its ID, the hash of its content, the last time the website has been modified
-// and the hash of the theme files, so if any of these things has changed, the etag value will change.
+// the etag for a page is computed from its ID, the hash of its content, the last time the website has been modified,
+// the hash of the theme files and whether the visitor has access to a paid-only page, so if any of these things
+// has changed, the etag value will change.
 // Page MUST NOT be null. If page is null, then, to avoid segfaulting, a random ETAG will be returned
 // which defeats the purpose of generating an etag.
-func computePageEtag(page *content.Page, siteModifiedAt time.Time, themeHash []byte, contact *contacts.Contact) (etag string) {
+func computePageEtag(page *content.Page, siteModifiedAt time.Time, themeHash []byte, contact *contacts.Contact, hasAccess bool) (etag string) {
 	var hash [32]byte
 
 	if page == nil {
@@ -140,6 +166,9 @@ func computePageEtag(page *content.Page, siteModifiedAt time.Time, themeHash []b
 	if contact != nil {
 		hasher.Write(contact.ID.Bytes())
 	}
+	if page.PaidOnly {
+		binary.Write(hasher, binary.LittleEndian, hasAccess)
+	}
 	hasher.Sum(hash[:0])
 
 	return base64.RawURLEncoding.EncodeToString(hash[:])
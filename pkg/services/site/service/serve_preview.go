@@ -62,13 +62,26 @@ func (service *SiteService) ServePreview(res http.ResponseWriter, req *http.Requ
 		return
 	}
 
+	authors, err := service.findAuthorsForPage(ctx, website, page.ID)
+	if err != nil {
+		service.serveInternalError(ctx, res, err, hostname, url)
+		return
+	}
+
+	translations, err := service.findPageTranslations(ctx, website, page)
+	if err != nil {
+		service.serveInternalError(ctx, res, err, hostname, url)
+		return
+	}
+
 	snippets, err := service.contentService.FindSnippets(ctx, service.db, website.ID)
 	if err != nil {
 		service.serveInternalError(ctx, res, err, hostname, url)
 		return
 	}
 
-	sitePage := service.convertPage(ctx, website, page, tags, snippets)
+	// previews are accessed with the ID of the page, which is only known by the staff of the website
+	sitePage := service.convertPage(ctx, website, page, tags, authors, translations, snippets, true)
 
 	templateData, err := service.convertPageTemplateData(website, &sitePage, tags, contact, httpCtx.Client.CountryCode)
 	if err != nil {
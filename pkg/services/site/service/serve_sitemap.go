@@ -25,8 +25,9 @@ import (
 )
 
 // TODO: improve location with site's primary domain
+// If language is not nil, only the pages in this language are listed in the sitemap.
 func (service *SiteService) serveSitemap(ctx context.Context, res http.ResponseWriter, website websites.Website,
-	hostname, url string) {
+	language *websites.WebsiteLanguage, hostname, url string) {
 	host := service.httpConfig.WebsitesBaseUrl.Scheme + "://" + website.PrimaryDomain + service.httpConfig.WebsitesPort
 	cacheControl := cachecontrol.WebsiteSitemap
 	httpCtx := httpctx.FromCtx(ctx)
@@ -45,7 +46,7 @@ func (service *SiteService) serveSitemap(ctx context.Context, res http.ResponseW
 		modifiedAt = timex.Max(lastPost.ModifiedAt(), modifiedAt).Truncate(time.Second)
 	}
 
-	etag := generateSitemapEtag(&website, modifiedAt)
+	etag := generateSitemapEtag(&website, modifiedAt, language)
 	res.Header().Set(httpx.HeaderCacheControl, cacheControl)
 	res.Header().Set(httpx.HeaderETag, strconv.Quote(etag))
 
@@ -71,13 +72,17 @@ func (service *SiteService) serveSitemap(ctx context.Context, res http.ResponseW
 		return
 	}
 
-	pages, err := service.contentService.FindPublishedPagesMetadata(ctx, service.db, website.ID, []content.PageType{content.PageTypePage, content.PageTypePost}, math.MaxInt32)
-	if err != nil {
-		service.serveInternalError(ctx, res, err, hostname, url)
-		return
+	var pages []content.PageMetadata
+	pageTypes := []content.PageType{content.PageTypePage, content.PageTypePost}
+	if language != nil {
+		pages, err = service.contentService.FindPagesMetadataWithFilter(ctx, service.db, website.ID, content.PagesFilter{
+			Types:    pageTypes,
+			Language: &language.Code,
+			Limit:    math.MaxInt32,
+		})
+	} else {
+		pages, err = service.contentService.FindPublishedPagesMetadata(ctx, service.db, website.ID, pageTypes, math.MaxInt32)
 	}
-
-	tags, err := service.contentService.FindTags(ctx, service.db, website.ID)
 	if err != nil {
 		service.serveInternalError(ctx, res, err, hostname, url)
 		return
@@ -91,15 +96,37 @@ func (service *SiteService) serveSitemap(ctx context.Context, res http.ResponseW
 			LastMod: &pageModifiedAt,
 		})
 	}
-	sitemapFile.Add(sitemap.URL{
-		Loc:     host + "/tags",
-		LastMod: opt.Time(modifiedAt.UTC().Truncate(time.Minute)),
-	})
-	for _, tag := range tags {
+
+	// tags and authors are not translated so they are only listed in the main sitemap
+	if language == nil {
+		tags, err := service.contentService.FindTags(ctx, service.db, website.ID)
+		if err != nil {
+			service.serveInternalError(ctx, res, err, hostname, url)
+			return
+		}
+
+		authors, err := service.contentService.FindAuthors(ctx, service.db, website.ID)
+		if err != nil {
+			service.serveInternalError(ctx, res, err, hostname, url)
+			return
+		}
+
 		sitemapFile.Add(sitemap.URL{
-			Loc:     host + "/tags/" + tag.Name,
-			LastMod: opt.Time(tag.UpdatedAt.UTC().Truncate(time.Minute)),
+			Loc:     host + "/tags",
+			LastMod: opt.Time(modifiedAt.UTC().Truncate(time.Minute)),
 		})
+		for _, tag := range tags {
+			sitemapFile.Add(sitemap.URL{
+				Loc:     host + "/tags/" + tag.Name,
+				LastMod: opt.Time(tag.UpdatedAt.UTC().Truncate(time.Minute)),
+			})
+		}
+		for _, author := range authors {
+			sitemapFile.Add(sitemap.URL{
+				Loc:     host + "/authors/" + author.Slug,
+				LastMod: opt.Time(author.UpdatedAt.UTC().Truncate(time.Minute)),
+			})
+		}
 	}
 
 	sitemapXML, err := sitemapFile.String()
@@ -117,12 +144,15 @@ func (service *SiteService) serveSitemap(ctx context.Context, res http.ResponseW
 	res.Write([]byte(sitemapXML))
 }
 
-func generateSitemapEtag(website *websites.Website, modifiedAt time.Time) string {
+func generateSitemapEtag(website *websites.Website, modifiedAt time.Time, language *websites.WebsiteLanguage) string {
 	var hash [32]byte
 
 	hasher := blake3.New(32, nil)
 	binary.Write(hasher, binary.LittleEndian, modifiedAt.Unix())
 	hasher.Write(website.ID[:])
+	if language != nil {
+		hasher.Write([]byte(language.Code))
+	}
 	hasher.Sum(hash[:0])
 
 	return base64.RawURLEncoding.EncodeToString(hash[:])
@@ -17,6 +17,10 @@ type pageTemplateData struct {
 	Description string
 	Language    string
 	SocialImage string
+	// Alternates are the hreflang links of the page. Empty if the page has no translations.
+	Alternates []pageAlternate
+	// FeedsUrl is the URL under which the feeds of the language of the page are served
+	FeedsUrl template.URL
 
 	Website           site.Website
 	Page              *site.Page
@@ -27,6 +31,11 @@ type pageTemplateData struct {
 	// ClientMetadata template.HTML
 }
 
+type pageAlternate struct {
+	Hreflang string
+	Url      template.URL
+}
+
 // // clientMetadata are rendered on the page for debugging purpose
 // type clientMetadata struct {
 // 	IP        string    `json:"ip"`
@@ -56,10 +65,11 @@ func (service *SiteService) convertPageTemplateData(website websites.Website,
 	title := websiteData.Name
 	description := websiteData.Description
 	language := websiteData.Language
+	feedsUrl := websiteData.Url
+	alternates := []pageAlternate{}
 	var markdowNinjaData site.MarkdowNinjaData
 
 	markdowNinjaData.Country = country
-	markdowNinjaData.Website = websiteData
 
 	if page != nil {
 		url = page.Url
@@ -70,9 +80,18 @@ func (service *SiteService) convertPageTemplateData(website websites.Website,
 		}
 		language = page.Language
 
+		websiteLanguage, _ := website.FindLanguage(page.Language)
+		if websiteLanguage.Navigation != nil {
+			websiteData.Navigation = *websiteLanguage.Navigation
+		}
+		feedsUrl += template.URL(websiteLanguage.PathPrefix)
+		alternates = convertPageAlternates(website, page)
+
 		markdowNinjaData.Page = page
 	}
 
+	markdowNinjaData.Website = websiteData
+
 	if contact != nil {
 		markdowNinjaData.Contact = contact
 	}
@@ -88,6 +107,8 @@ func (service *SiteService) convertPageTemplateData(website websites.Website,
 		Title:       title,
 		Description: description,
 		Language:    language,
+		Alternates:  alternates,
+		FeedsUrl:    feedsUrl,
 		// SocialImage:       "",
 		Website:           websiteData,
 		Page:              page,
@@ -97,3 +118,31 @@ func (service *SiteService) convertPageTemplateData(website websites.Website,
 	}
 	return
 }
+
+// convertPageAlternates returns the hreflang links of a page: the page itself, its translations and the
+// version in the default language of the website as x-default.
+func convertPageAlternates(website websites.Website, page *site.Page) []pageAlternate {
+	if len(page.Translations) == 0 {
+		return []pageAlternate{}
+	}
+
+	alternates := make([]pageAlternate, 0, len(page.Translations)+2)
+	alternates = append(alternates, pageAlternate{Hreflang: page.Language, Url: page.Url})
+	defaultUrl := template.URL("")
+	if page.Language == website.Language {
+		defaultUrl = page.Url
+	}
+
+	for _, translation := range page.Translations {
+		alternates = append(alternates, pageAlternate{Hreflang: translation.Language, Url: translation.Url})
+		if translation.Language == website.Language {
+			defaultUrl = translation.Url
+		}
+	}
+
+	if defaultUrl != "" {
+		alternates = append(alternates, pageAlternate{Hreflang: "x-default", Url: defaultUrl})
+	}
+
+	return alternates
+}
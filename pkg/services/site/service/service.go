@@ -13,6 +13,7 @@ import (
 	"github.com/bloom42/stdx-go/db"
 	"github.com/bloom42/stdx-go/memorycache"
 	"github.com/bloom42/stdx-go/queue"
+	"github.com/graphql-go/graphql"
 	"github.com/klauspost/compress/zstd"
 	"markdown.ninja/cmd/mdninja-server/config"
 	"markdown.ninja/pkg/mailer"
@@ -21,9 +22,11 @@ import (
 	"markdown.ninja/pkg/services/emails"
 	"markdown.ninja/pkg/services/events"
 	"markdown.ninja/pkg/services/kernel"
+	"markdown.ninja/pkg/services/organizations"
 	"markdown.ninja/pkg/services/site"
 	"markdown.ninja/pkg/services/site/templates"
 	"markdown.ninja/pkg/services/store"
+	"markdown.ninja/pkg/services/webhooks"
 	"markdown.ninja/pkg/services/websites"
 	themespkg "markdown.ninja/themes"
 )
@@ -34,13 +37,15 @@ type SiteService struct {
 	queue  queue.Queue
 	mailer mailer.Mailer
 
-	kernel          kernel.PrivateService
-	websitesService websites.Service
-	eventsService   events.Service
-	contentService  content.Service
-	contactsService contacts.Service
-	emailsService   emails.Service
-	storeService    store.Service
+	kernel               kernel.PrivateService
+	websitesService      websites.Service
+	eventsService        events.Service
+	contentService       content.Service
+	contactsService      contacts.Service
+	emailsService        emails.Service
+	storeService         store.Service
+	webhooksService      webhooks.Service
+	organizationsService organizations.Service
 
 	snippetsRegexp         *regexp.Regexp
 	loginEmailTemplate     *template.Template
@@ -58,6 +63,12 @@ type SiteService struct {
 	pagesCache         *memorycache.Cache[string, site.Page]
 	feedsCache         *memorycache.Cache[string, []byte]
 	sitemapsCache      *memorycache.Cache[string, []byte]
+	// GraphQL responses, indexed by website, website.ModifiedAt and request
+	graphqlResponsesCache *memorycache.Cache[string, []byte]
+	// queries of the GraphQL persisted queries, indexed by their SHA-256 hash
+	graphqlPersistedQueriesCache *memorycache.Cache[string, string]
+
+	graphqlSchema graphql.Schema
 
 	// pages are cached compressed to reduce the memory usage
 	cacheZstdCompressor   *zstd.Encoder
@@ -74,7 +85,8 @@ type defaultWebsiteIcon struct {
 func NewSiteService(conf config.Config, db db.DB, queue queue.Queue, mailer mailer.Mailer, logger *slog.Logger,
 	kernel kernel.PrivateService, websitesService websites.Service, contentService content.Service,
 	eventsService events.Service, contactsService contacts.Service,
-	emailsService emails.Service, storeService store.Service) (service *SiteService, err error) {
+	emailsService emails.Service, storeService store.Service,
+	webhooksService webhooks.Service, organizationsService organizations.Service) (service *SiteService, err error) {
 
 	snippetsRegexp := regexp.MustCompile("{{<.*>}}")
 
@@ -159,18 +171,32 @@ func NewSiteService(conf config.Config, db db.DB, queue queue.Queue, mailer mail
 		memorycache.WithGetHook(zstdDecompressGetHook),
 	)
 
+	graphqlResponsesCache := memorycache.New(
+		memorycache.WithTTL[string, []byte](48*time.Hour),
+		memorycache.WithCapacity[string, []byte](10_000),
+		memorycache.WithInsertHook(zstdCompressInsertHook),
+		memorycache.WithGetHook(zstdDecompressGetHook),
+	)
+
+	graphqlPersistedQueriesCache := memorycache.New(
+		memorycache.WithTTL[string, string](48*time.Hour),
+		memorycache.WithCapacity[string, string](10_000),
+	)
+
 	service = &SiteService{
 		db:     db,
 		queue:  queue,
 		mailer: mailer,
 
-		kernel:          kernel,
-		eventsService:   eventsService,
-		websitesService: websitesService,
-		contentService:  contentService,
-		contactsService: contactsService,
-		emailsService:   emailsService,
-		storeService:    storeService,
+		kernel:               kernel,
+		eventsService:        eventsService,
+		websitesService:      websitesService,
+		contentService:       contentService,
+		contactsService:      contactsService,
+		emailsService:        emailsService,
+		storeService:         storeService,
+		webhooksService:      webhooksService,
+		organizationsService: organizationsService,
 
 		snippetsRegexp:         snippetsRegexp,
 		loginEmailTemplate:     loginEmailTemplate,
@@ -187,8 +213,18 @@ func NewSiteService(conf config.Config, db db.DB, queue queue.Queue, mailer mail
 		feedsCache:         feedsCache,
 		sitemapsCache:      sitemapsCache,
 
+		graphqlResponsesCache:        graphqlResponsesCache,
+		graphqlPersistedQueriesCache: graphqlPersistedQueriesCache,
+
 		themes: themes,
 	}
+	// the resolvers of the schema need the service
+	service.graphqlSchema, err = service.newGraphqlSchema()
+	if err != nil {
+		err = fmt.Errorf("site: creating GraphQL schema: %w", err)
+		return
+	}
+
 	return
 }
 
@@ -0,0 +1,33 @@
+package service
+
+import (
+	"context"
+
+	"markdown.ninja/pkg/server/httpctx"
+	"markdown.ninja/pkg/services/events"
+	"markdown.ninja/pkg/services/site"
+)
+
+func (service *SiteService) TrackEventCustom(ctx context.Context, input site.TrackEventCustomInput) (err error) {
+	httpCtx := httpctx.FromCtx(ctx)
+	hostname := httpCtx.Hostname
+
+	website, err := service.websitesService.FindWebsiteByDomain(ctx, service.db, hostname)
+	if err != nil {
+		return
+	}
+
+	trackEventInput := events.TrackCustomEventInput{
+		Name:            input.Name,
+		Path:            input.Path,
+		Properties:      input.Properties,
+		HeaderUserAgent: httpCtx.Client.UserAgent,
+		WebsiteID:       website.ID,
+	}
+	err = service.eventsService.TrackCustomEvent(ctx, trackEventInput)
+	if err != nil {
+		return
+	}
+
+	return
+}
@@ -24,7 +24,14 @@ func (service *SiteService) TrackEventPageView(ctx context.Context, input site.T
 		HeaderReferrer:       input.HeaderReferrer,
 		HeaderUserAgent:      httpCtx.Client.UserAgent,
 		QueryParameterRef:    input.QueryParameterRef,
-		WebsiteID:            website.ID,
+		Utm: events.UtmParameters{
+			Source:   input.UtmSource,
+			Medium:   input.UtmMedium,
+			Campaign: input.UtmCampaign,
+			Term:     input.UtmTerm,
+			Content:  input.UtmContent,
+		},
+		WebsiteID: website.ID,
 	}
 	service.eventsService.TrackPageView(ctx, trackEventInput)
 
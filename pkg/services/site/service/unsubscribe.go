@@ -4,11 +4,13 @@ import (
 	"context"
 	"time"
 
+	"github.com/bloom42/stdx-go/log/slogx"
 	"github.com/bloom42/stdx-go/opt"
 	"markdown.ninja/pkg/server/httpctx"
 	"markdown.ninja/pkg/services/contacts"
 	"markdown.ninja/pkg/services/events"
 	"markdown.ninja/pkg/services/site"
+	"markdown.ninja/pkg/services/webhooks"
 )
 
 func (service *SiteService) Unsubscribe(ctx context.Context, input site.UnsubscribeInput) (err error) {
@@ -67,5 +69,19 @@ func (service *SiteService) Unsubscribe(ctx context.Context, input site.Unsubscr
 	}
 	service.eventsService.TrackUnsubscribedFromNewsletter(ctx, trackEventInput)
 
+	dispatchErr := service.webhooksService.DispatchEvent(ctx, service.db, webhooks.DispatchEventInput{
+		WebsiteID: website.ID,
+		Type:      webhooks.EventTypeSubscriberRemoved,
+		Data: webhooks.EventDataSubscriber{
+			ContactID: contact.ID,
+			Email:     contact.Email,
+			Name:      contact.Name,
+		},
+	})
+	if dispatchErr != nil {
+		logger := slogx.FromCtx(ctx)
+		logger.Error("site.Unsubscribe: error dispatching webhook event", slogx.Err(dispatchErr))
+	}
+
 	return
 }
@@ -12,6 +12,7 @@ import (
 	"markdown.ninja/pkg/services/events"
 	"markdown.ninja/pkg/services/kernel"
 	"markdown.ninja/pkg/services/site"
+	"markdown.ninja/pkg/services/webhooks"
 )
 
 func (service *SiteService) UpdateMyAccount(ctx context.Context, input site.UpdateMyAccount) (retContact site.Contact, err error) {
@@ -22,7 +23,7 @@ func (service *SiteService) UpdateMyAccount(ctx context.Context, input site.Upda
 	}
 
 	unsubscribedFromNewsletter := false
-	subscribedToNewsletter := true
+	subscribedToNewsletter := false
 	httpCtx := httpctx.FromCtx(ctx)
 	domain := httpCtx.Hostname
 	sendVerifyEmailEmail := false
@@ -107,6 +108,8 @@ func (service *SiteService) UpdateMyAccount(ctx context.Context, input site.Upda
 	// we track event at the end to be sure that the transaction succeeded
 	if subscribedToNewsletter {
 		trackEventInput := events.TrackSubscribedToNewsletterInput{
+			IpAddress: httpCtx.Client.IP,
+			UserAgent: httpCtx.Client.UserAgent,
 			WebsiteID: website.ID,
 		}
 		service.eventsService.TrackSubscribedToNewsletter(ctx, trackEventInput)
@@ -117,5 +120,25 @@ func (service *SiteService) UpdateMyAccount(ctx context.Context, input site.Upda
 		service.eventsService.TrackUnsubscribedFromNewsletter(ctx, trackEventInput)
 	}
 
+	if subscribedToNewsletter || unsubscribedFromNewsletter {
+		webhookEventType := webhooks.EventTypeSubscriberAdded
+		if unsubscribedFromNewsletter {
+			webhookEventType = webhooks.EventTypeSubscriberRemoved
+		}
+		dispatchErr := service.webhooksService.DispatchEvent(ctx, service.db, webhooks.DispatchEventInput{
+			WebsiteID: website.ID,
+			Type:      webhookEventType,
+			Data: webhooks.EventDataSubscriber{
+				ContactID: contact.ID,
+				Email:     contact.Email,
+				Name:      contact.Name,
+			},
+		})
+		if dispatchErr != nil {
+			logger := slogx.FromCtx(ctx)
+			logger.Error("site.UpdateMyAccount: error dispatching webhook event", slogx.Err(dispatchErr))
+		}
+	}
+
 	return
 }
@@ -24,6 +24,22 @@ var (
 	}
 	ErrProductAccessNotFound       = errs.NotFound("Product access not found")
 	ErrCantDeleteProductWithOrders = errs.InvalidArgument("A product can't be deleted once orders have been placed.")
+	ErrCantDeleteBundledProduct    = errs.InvalidArgument("A product can't be deleted while it is part of a bundle.")
+
+	// Bundles
+	ErrProductIsNotABundle      = errs.InvalidArgument("Product is not a bundle.")
+	ErrBundleMustContainProduct = errs.InvalidArgument("A bundle must contain at least 1 product")
+	ErrProductCantBeBundled     = func(productName string) error {
+		return errs.InvalidArgument(fmt.Sprintf("%s can't be part of a bundle", productName))
+	}
+
+	// Subscriptions
+	ErrSubscriptionNotFound             = errs.NotFound("Subscription not found")
+	ErrProductIsNotASubscription        = errs.InvalidArgument("Product is not a subscription.")
+	ErrSubscriptionMustBeOrderedAlone   = errs.InvalidArgument("A subscription can't be ordered with other products")
+	ErrSubscriptionIntervalIsNotValid   = errs.InvalidArgument(fmt.Sprintf("Subscription interval is not valid. Valid values are: [%s, %s]", SubscriptionIntervalMonth, SubscriptionIntervalYear))
+	ErrYearlySubscriptionIsNotAvailable = errs.InvalidArgument("This subscription can't be paid yearly")
+	ErrAlreadySubscribedToProduct       = errs.InvalidArgument("You are already subscribed to this product")
 
 	// Orders
 	ErrOrderNotFound       = errs.NotFound("Order not found")
@@ -62,4 +78,6 @@ var (
 	ErrCouponCodeAlreadyExists     = func(code string) error {
 		return errs.InvalidArgument(fmt.Sprintf("A Coupon with the code \"%s\" already exists", code))
 	}
+	ErrCouponHasExpired          = errs.InvalidArgument("Coupon has expired")
+	ErrCouponDoesNotApplyToOrder = errs.InvalidArgument("Coupon doesn't apply to any of the ordered products")
 )
@@ -35,8 +35,8 @@ const (
 	ProductTypeBook ProductType = iota
 	ProductTypeCourse
 	ProductTypeDigitalDownload
-	// ProductTypeBundle
-	// ProductTypeSubscription
+	ProductTypeSubscription
+	ProductTypeBundle
 )
 
 // MarshalText implements encoding.TextMarshaler.
@@ -48,6 +48,10 @@ func (productType ProductType) MarshalText() (ret []byte, err error) {
 		ret = []byte("course")
 	case ProductTypeDigitalDownload:
 		ret = []byte("download")
+	case ProductTypeSubscription:
+		ret = []byte("subscription")
+	case ProductTypeBundle:
+		ret = []byte("bundle")
 	default:
 		err = fmt.Errorf("Unknown ProductType: %d", productType)
 	}
@@ -68,6 +72,10 @@ func (productType *ProductType) UnmarshalText(data []byte) (err error) {
 		*productType = ProductTypeCourse
 	case "download":
 		*productType = ProductTypeDigitalDownload
+	case "subscription":
+		*productType = ProductTypeSubscription
+	case "bundle":
+		*productType = ProductTypeBundle
 	default:
 		err = fmt.Errorf("Unknown ProductType: %s", string(data))
 	}
@@ -167,6 +175,34 @@ func (orderStatus *OrderStatus) UnmarshalText(data []byte) (err error) {
 	return nil
 }
 
+type SubscriptionInterval string
+
+const (
+	SubscriptionIntervalMonth SubscriptionInterval = "month"
+	SubscriptionIntervalYear  SubscriptionInterval = "year"
+)
+
+// SubscriptionStatus mirrors the status of the Stripe subscription
+// See https://docs.stripe.com/billing/subscriptions/overview#subscription-statuses
+type SubscriptionStatus string
+
+const (
+	SubscriptionStatusIncomplete        SubscriptionStatus = "incomplete"
+	SubscriptionStatusIncompleteExpired SubscriptionStatus = "incomplete_expired"
+	SubscriptionStatusTrialing          SubscriptionStatus = "trialing"
+	SubscriptionStatusActive            SubscriptionStatus = "active"
+	SubscriptionStatusPastDue           SubscriptionStatus = "past_due"
+	SubscriptionStatusCanceled          SubscriptionStatus = "canceled"
+	SubscriptionStatusUnpaid            SubscriptionStatus = "unpaid"
+	SubscriptionStatusPaused            SubscriptionStatus = "paused"
+)
+
+// GivesAccess returns true if the subscriber should have access to the subscribed product.
+// past_due subscriptions lose access until the payment is retried successfully.
+func (status SubscriptionStatus) GivesAccess() bool {
+	return status == SubscriptionStatusActive || status == SubscriptionStatusTrialing
+}
+
 type RefundStatus string
 
 const (
@@ -214,16 +250,21 @@ type Product struct {
 	CreatedAt time.Time `db:"created_at" json:"created_at"`
 	UpdatedAt time.Time `db:"updated_at" json:"updated_at"`
 
-	Name        string        `db:"name" json:"name"`
-	Description string        `db:"description" json:"description"`
-	Type        ProductType   `db:"type" json:"type"`
-	Price       int64         `db:"price" json:"price"`
+	Name        string      `db:"name" json:"name"`
+	Description string      `db:"description" json:"description"`
+	Type        ProductType `db:"type" json:"type"`
+	// For subscriptions, Price is the monthly price
+	Price int64 `db:"price" json:"price"`
+	// YearlyPrice is only used by subscriptions. 0 means that the subscription can't be paid yearly.
+	YearlyPrice int64         `db:"yearly_price" json:"yearly_price"`
 	Status      ProductStatus `db:"status" json:"status"`
 
 	WebsiteID guid.GUID `db:"website_id" json:"-"`
 
 	Content []ProductPage   `json:"content"`
 	Assets  []content.Asset `json:"assets"`
+	// BundledProducts are the IDs of the products included in a bundle. Only set for bundles.
+	BundledProducts []guid.GUID `json:"bundled_products"`
 }
 
 type ProductPage struct {
@@ -247,6 +288,24 @@ type ContactProductAccess struct {
 	ProductID guid.GUID `db:"product_id"`
 }
 
+type Subscription struct {
+	ID        guid.GUID `db:"id" json:"id"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+	UpdatedAt time.Time `db:"updated_at" json:"updated_at"`
+
+	Status            SubscriptionStatus   `db:"status" json:"status"`
+	BillingInterval   SubscriptionInterval `db:"billing_interval" json:"billing_interval"`
+	CurrentPeriodEnd  time.Time            `db:"current_period_end" json:"current_period_end"`
+	CancelAtPeriodEnd bool                 `db:"cancel_at_period_end" json:"cancel_at_period_end"`
+	CanceledAt        *time.Time           `db:"canceled_at" json:"canceled_at"`
+
+	StripeSubscriptionID string `db:"stripe_subscription_id" json:"-"`
+
+	WebsiteID guid.GUID `db:"website_id" json:"-"`
+	ContactID guid.GUID `db:"contact_id" json:"contact_id"`
+	ProductID guid.GUID `db:"product_id" json:"product_id"`
+}
+
 type Order struct {
 	ID        guid.GUID `db:"id" json:"id"`
 	CreatedAt time.Time `db:"created_at" json:"created_at"`
@@ -267,8 +326,9 @@ type Order struct {
 	StripeInvoiceID         *string `db:"stripe_invoice_id" json:"stripe_invoice_id"`
 	StripeInvoiceUrl        *string `db:"stripe_invoice_url" json:"stripe_invoice_url"`
 
-	WebsiteID guid.GUID `db:"website_id" json:"-"`
-	ContactID guid.GUID `db:"contact_id" json:"contact_id"`
+	WebsiteID guid.GUID  `db:"website_id" json:"-"`
+	ContactID guid.GUID  `db:"contact_id" json:"contact_id"`
+	CouponID  *guid.GUID `db:"coupon_id" json:"coupon_id"`
 
 	LineItems []OrderLineItem `db:"-" json:"line_items"`
 	Refunds   []Refund        `db:"-" json:"refunds"`
@@ -333,6 +393,11 @@ type CouponProductRelation struct {
 	ProductID guid.GUID `db:"product_id"`
 }
 
+type BundleProductRelation struct {
+	BundleID  guid.GUID `db:"bundle_id"`
+	ProductID guid.GUID `db:"product_id"`
+}
+
 type Refund struct {
 	ID        guid.GUID `db:"id" json:"id"`
 	CreatedAt time.Time `db:"created_at" json:"created_at"`
@@ -387,6 +452,9 @@ type CreateProductInput struct {
 	Description string      `json:"description"`
 	Type        ProductType `json:"type"`
 	Price       int64       `json:"price"`
+	YearlyPrice int64       `json:"yearly_price"`
+	// BundledProducts is required for bundles
+	BundledProducts []guid.GUID `json:"bundled_products"`
 }
 
 type GetProductInput struct {
@@ -403,6 +471,9 @@ type UpdateProductInput struct {
 	Description *string        `json:"description"`
 	Status      *ProductStatus `json:"status"`
 	Price       *int64         `json:"price"`
+	YearlyPrice *int64         `json:"yearly_price"`
+	// BundledProducts replaces the products of a bundle when not nil
+	BundledProducts []guid.GUID `json:"bundled_products"`
 }
 
 type CreateCouponInput struct {
@@ -486,6 +557,10 @@ type PlaceOrderInput struct {
 	Products              []guid.GUID `json:"products"`
 	Email                 *string     `json:"email"`
 	SubscribeToNewsletter bool        `json:"subscribe_to_newsletter"`
+	// SubscriptionInterval is used when ordering a subscription. Defaults to SubscriptionIntervalMonth
+	SubscriptionInterval *SubscriptionInterval `json:"subscription_interval"`
+	// Coupon is the optional code of a coupon to apply to the order
+	Coupon *string `json:"coupon"`
 }
 
 type PlaceOrderOutput struct {
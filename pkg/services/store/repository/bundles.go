@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bloom42/stdx-go/db"
+	"github.com/bloom42/stdx-go/guid"
+	"markdown.ninja/pkg/services/store"
+)
+
+func (repo *StoreRepository) CreateBundleProductRelation(ctx context.Context, db db.Queryer, relation store.BundleProductRelation) (err error) {
+	const query = `INSERT INTO bundles_products
+			(bundle_id, product_id)
+		VALUES ($1, $2)`
+
+	_, err = db.Exec(ctx, query, relation.BundleID, relation.ProductID)
+	if err != nil {
+		err = fmt.Errorf("store.CreateBundleProductRelation: %w", err)
+		return
+	}
+
+	return
+}
+
+func (repo *StoreRepository) DeleteBundleProductRelations(ctx context.Context, db db.Queryer, bundleID guid.GUID) (err error) {
+	const query = `DELETE FROM bundles_products WHERE bundle_id = $1`
+
+	_, err = db.Exec(ctx, query, bundleID)
+	if err != nil {
+		err = fmt.Errorf("store.DeleteBundleProductRelations: %w", err)
+		return
+	}
+
+	return
+}
+
+func (repo *StoreRepository) FindBundleProductRelations(ctx context.Context, db db.Queryer, bundleIDs []guid.GUID) (ret []store.BundleProductRelation, err error) {
+	ret = make([]store.BundleProductRelation, 0)
+	if len(bundleIDs) == 0 {
+		return
+	}
+
+	const query = `SELECT * FROM bundles_products WHERE bundle_id = ANY ($1)`
+
+	err = db.Select(ctx, &ret, query, bundleIDs)
+	if err != nil {
+		err = fmt.Errorf("store.FindBundleProductRelations: %w", err)
+		return
+	}
+
+	return
+}
+
+// FindProductIDsInOtherBundlesOfContact returns the IDs of the products included in the bundles that the
+// contact has access to, except the bundle excludedBundleID.
+func (repo *StoreRepository) FindProductIDsInOtherBundlesOfContact(ctx context.Context, db db.Queryer, contactID, excludedBundleID guid.GUID) (ret []guid.GUID, err error) {
+	ret = make([]guid.GUID, 0)
+	const query = `SELECT DISTINCT bundles_products.product_id FROM bundles_products
+		INNER JOIN contact_product_access ON contact_product_access.product_id = bundles_products.bundle_id
+		WHERE contact_product_access.contact_id = $1 AND bundles_products.bundle_id != $2
+	`
+
+	err = db.Select(ctx, &ret, query, contactID, excludedBundleID)
+	if err != nil {
+		err = fmt.Errorf("store.FindProductIDsInOtherBundlesOfContact: %w", err)
+		return
+	}
+
+	return
+}
+
+func (repo *StoreRepository) ProductIsBundled(ctx context.Context, db db.Queryer, productID guid.GUID) (bundled bool, err error) {
+	const query = `SELECT EXISTS (SELECT 1 FROM bundles_products WHERE product_id = $1)`
+
+	err = db.Get(ctx, &bundled, query, productID)
+	if err != nil {
+		err = fmt.Errorf("store.ProductIsBundled: %w", err)
+		return
+	}
+
+	return
+}
@@ -59,6 +59,22 @@ func (repo *StoreRepository) FindCouponByCode(ctx context.Context, db db.Queryer
 	return
 }
 
+func (repo *StoreRepository) FindWebsiteCouponByCode(ctx context.Context, db db.Queryer, websiteID guid.GUID, code string) (coupon store.Coupon, err error) {
+	const query = "SELECT * FROM coupons WHERE website_id = $1 AND code = $2"
+
+	err = db.Get(ctx, &coupon, query, websiteID, code)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			err = store.ErrCouponNotFound
+		} else {
+			err = fmt.Errorf("store.FindWebsiteCouponByCode: %w", err)
+		}
+		return
+	}
+
+	return
+}
+
 func (repo *StoreRepository) FindCouponsByWebsiteID(ctx context.Context, db db.Queryer, websiteID guid.GUID) (ret []store.Coupon, err error) {
 	ret = []store.Coupon{}
 	const query = `SELECT * FROM coupons
@@ -15,13 +15,13 @@ func (repo *StoreRepository) CreateOrder(ctx context.Context, db db.Queryer, ord
 	const query = `INSERT INTO orders
 			(id, created_at, updated_at, total_amount, currency, notes, status, completed_at, canceled_at,
 				email, billing_address, stripe_checkout_session_id, stripe_payment_intent_id, stripe_invoice_id, stripe_invoice_url,
-				contact_id, website_id)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)`
+				contact_id, website_id, coupon_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)`
 
 	_, err = db.Exec(ctx, query, order.ID, order.CreatedAt, order.UpdatedAt, order.TotalAmount, order.Currency,
 		order.Notes, order.Status, order.CompletedAt, order.CanceledAt,
 		order.Email, order.BillingAddress, order.StripeCheckoutSessionID, order.StripPaymentItentID, order.StripeInvoiceID, order.StripeInvoiceUrl,
-		order.ContactID, order.WebsiteID)
+		order.ContactID, order.WebsiteID, order.CouponID)
 	if err != nil {
 		err = fmt.Errorf("store.CreateOrder: %w", err)
 		return
@@ -107,6 +107,28 @@ func (repo *StoreRepository) FindOrdersWithStatusForContact(ctx context.Context,
 	return
 }
 
+// FindProductIDsPurchasedByContact returns the IDs of the products ordered by the contact in completed
+// orders that have not been refunded, except in the order excludedOrderID.
+func (repo *StoreRepository) FindProductIDsPurchasedByContact(ctx context.Context, db db.Queryer, contactID guid.GUID, excludedOrderID guid.GUID) (ret []guid.GUID, err error) {
+	ret = make([]guid.GUID, 0)
+	const query = `SELECT DISTINCT order_line_items.product_id FROM order_line_items
+		INNER JOIN orders ON orders.id = order_line_items.order_id
+		WHERE orders.contact_id = $1 AND orders.status = $2 AND orders.id != $3
+			AND NOT EXISTS (
+				SELECT 1 FROM refunds WHERE refunds.order_id = orders.id AND refunds.status NOT IN ($4, $5)
+			)
+	`
+
+	err = db.Select(ctx, &ret, query, contactID, store.OrderStatusCompleted, excludedOrderID,
+		store.RefundStatusFailed, store.RefundStatusCanceled)
+	if err != nil {
+		err = fmt.Errorf("store.FindProductIDsPurchasedByContact: %w", err)
+		return
+	}
+
+	return
+}
+
 func (repo *StoreRepository) FindOrdersForProduct(ctx context.Context, db db.Queryer, productID guid.GUID) (ret []store.Order, err error) {
 	ret = make([]store.Order, 0, 10)
 	const query = `SELECT * FROM orders WHERE id = ANY (
@@ -142,6 +164,22 @@ func (repo *StoreRepository) FindOrderByID(ctx context.Context, db db.Queryer, o
 	return
 }
 
+func (repo *StoreRepository) FindOrderByStripeInvoiceID(ctx context.Context, db db.Queryer, stripeInvoiceID string) (order store.Order, err error) {
+	const query = "SELECT * FROM orders WHERE stripe_invoice_id = $1"
+
+	err = db.Get(ctx, &order, query, stripeInvoiceID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			err = store.ErrOrderNotFound
+		} else {
+			err = fmt.Errorf("store.FindOrderByStripeInvoiceID: %w", err)
+		}
+		return
+	}
+
+	return
+}
+
 func (repo *StoreRepository) GetWebsiteRevenue(ctx context.Context, db db.Queryer, websiteID guid.GUID, from, to time.Time) (revenue int64, err error) {
 	const query = `SELECT
 		(SELECT COALESCE(SUM(total_amount), 0) AS sales FROM orders
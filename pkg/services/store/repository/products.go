@@ -12,11 +12,11 @@ import (
 
 func (repo *StoreRepository) CreateProduct(ctx context.Context, db db.Queryer, product store.Product) (err error) {
 	const query = `INSERT INTO products
-			(id, created_at, updated_at, name, description, type, status, price, website_id)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+			(id, created_at, updated_at, name, description, type, status, price, yearly_price, website_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
 
 	_, err = db.Exec(ctx, query, product.ID, product.CreatedAt, product.UpdatedAt,
-		product.Name, product.Description, product.Type, product.Status, product.Price,
+		product.Name, product.Description, product.Type, product.Status, product.Price, product.YearlyPrice,
 		product.WebsiteID)
 	if err != nil {
 		err = fmt.Errorf("store.CreateProduct: %w", err)
@@ -28,12 +28,12 @@ func (repo *StoreRepository) CreateProduct(ctx context.Context, db db.Queryer, p
 
 func (repo *StoreRepository) UpdateProduct(ctx context.Context, db db.Queryer, product store.Product) (err error) {
 	const query = `UPDATE products
-		SET updated_at = $1, name = $2, description = $3, status = $4, price = $5
-		WHERE id = $6
+		SET updated_at = $1, name = $2, description = $3, status = $4, price = $5, yearly_price = $6
+		WHERE id = $7
 `
 
 	_, err = db.Exec(ctx, query, product.UpdatedAt, product.Name, product.Description,
-		product.Status, product.Price,
+		product.Status, product.Price, product.YearlyPrice,
 		product.ID)
 	if err != nil {
 		err = fmt.Errorf("store.UpdateProduct: %w", err)
@@ -76,6 +76,22 @@ func (repo *StoreRepository) FindProductsByWebsiteID(ctx context.Context, db db.
 	return
 }
 
+func (repo *StoreRepository) FindActiveSubscriptionProductsForWebsite(ctx context.Context, db db.Queryer, websiteID guid.GUID) (ret []store.Product, err error) {
+	ret = make([]store.Product, 0)
+	const query = `SELECT * FROM products
+		WHERE website_id = $1 AND type = $2 AND status = $3
+		ORDER BY price
+	`
+
+	err = db.Select(ctx, &ret, query, websiteID, store.ProductTypeSubscription, store.ProductStatusActive)
+	if err != nil {
+		err = fmt.Errorf("store.FindActiveSubscriptionProductsForWebsite: %w", err)
+		return
+	}
+
+	return
+}
+
 func (repo *StoreRepository) FindWebsiteProductsIn(ctx context.Context, db db.Queryer, websiteID guid.GUID, productIDs []guid.GUID) (ret []store.Product, err error) {
 	const query = `SELECT * FROM products
 		WHERE website_id = $1 AND id = ANY ($2)`
@@ -113,10 +129,12 @@ func (repo *StoreRepository) FindProductsForContact(ctx context.Context, db db.Q
 	ret = make([]store.Product, 0)
 	const query = `SELECT * FROM products WHERE id = ANY (
 		SELECT product_id FROM contact_product_access WHERE contact_id = $1
+		UNION
+		SELECT product_id FROM subscriptions WHERE contact_id = $1 AND status IN ($2, $3)
 	)
 	ORDER BY name`
 
-	err = db.Select(ctx, &ret, query, contactID)
+	err = db.Select(ctx, &ret, query, contactID, store.SubscriptionStatusActive, store.SubscriptionStatusTrialing)
 	if err != nil {
 		err = fmt.Errorf("store.FindProductsForContact: %w", err)
 		return
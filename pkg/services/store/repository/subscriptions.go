@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/bloom42/stdx-go/db"
+	"github.com/bloom42/stdx-go/guid"
+	"markdown.ninja/pkg/services/store"
+)
+
+// UpsertSubscription creates the subscription or updates it if a subscription with the same
+// stripe_subscription_id already exists
+func (repo *StoreRepository) UpsertSubscription(ctx context.Context, db db.Queryer, subscription store.Subscription) (err error) {
+	const query = `INSERT INTO subscriptions
+			(id, created_at, updated_at, status, billing_interval, current_period_end, cancel_at_period_end, canceled_at,
+				stripe_subscription_id, website_id, contact_id, product_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		ON CONFLICT (stripe_subscription_id) DO UPDATE
+			SET updated_at = EXCLUDED.updated_at, status = EXCLUDED.status, billing_interval = EXCLUDED.billing_interval,
+				current_period_end = EXCLUDED.current_period_end, cancel_at_period_end = EXCLUDED.cancel_at_period_end,
+				canceled_at = EXCLUDED.canceled_at`
+
+	_, err = db.Exec(ctx, query, subscription.ID, subscription.CreatedAt, subscription.UpdatedAt,
+		subscription.Status, subscription.BillingInterval, subscription.CurrentPeriodEnd, subscription.CancelAtPeriodEnd,
+		subscription.CanceledAt, subscription.StripeSubscriptionID,
+		subscription.WebsiteID, subscription.ContactID, subscription.ProductID)
+	if err != nil {
+		err = fmt.Errorf("store.UpsertSubscription: %w", err)
+		return
+	}
+
+	return
+}
+
+func (repo *StoreRepository) FindSubscriptionByStripeSubscriptionID(ctx context.Context, db db.Queryer, stripeSubscriptionID string) (subscription store.Subscription, err error) {
+	const query = "SELECT * FROM subscriptions WHERE stripe_subscription_id = $1"
+
+	err = db.Get(ctx, &subscription, query, stripeSubscriptionID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			err = store.ErrSubscriptionNotFound
+		} else {
+			err = fmt.Errorf("store.FindSubscriptionByStripeSubscriptionID: %w", err)
+		}
+		return
+	}
+
+	return
+}
+
+func (repo *StoreRepository) FindSubscriptionsForContact(ctx context.Context, db db.Queryer, contactID guid.GUID) (ret []store.Subscription, err error) {
+	ret = make([]store.Subscription, 0)
+	const query = `SELECT * FROM subscriptions
+		WHERE contact_id = $1
+		ORDER BY created_at DESC`
+
+	err = db.Select(ctx, &ret, query, contactID)
+	if err != nil {
+		err = fmt.Errorf("store.FindSubscriptionsForContact: %w", err)
+		return
+	}
+
+	return
+}
+
+func (repo *StoreRepository) ContactHasActiveSubscriptionToProduct(ctx context.Context, db db.Queryer, contactID, productID guid.GUID) (ret bool, err error) {
+	const query = `SELECT EXISTS (
+		SELECT 1 FROM subscriptions
+		WHERE contact_id = $1 AND product_id = $2 AND status IN ($3, $4)
+	)`
+
+	err = db.Get(ctx, &ret, query, contactID, productID, store.SubscriptionStatusActive, store.SubscriptionStatusTrialing)
+	if err != nil {
+		err = fmt.Errorf("store.ContactHasActiveSubscriptionToProduct: %w", err)
+		return
+	}
+
+	return
+}
+
+func (repo *StoreRepository) ContactHasActiveSubscription(ctx context.Context, db db.Queryer, websiteID, contactID guid.GUID) (ret bool, err error) {
+	const query = `SELECT EXISTS (
+		SELECT 1 FROM subscriptions
+		WHERE website_id = $1 AND contact_id = $2 AND status IN ($3, $4)
+	)`
+
+	err = db.Get(ctx, &ret, query, websiteID, contactID, store.SubscriptionStatusActive, store.SubscriptionStatusTrialing)
+	if err != nil {
+		err = fmt.Errorf("store.ContactHasActiveSubscription: %w", err)
+		return
+	}
+
+	return
+}
+
+func (repo *StoreRepository) FindContactIDsWithActiveSubscription(ctx context.Context, db db.Queryer, websiteID guid.GUID) (ret []guid.GUID, err error) {
+	ret = make([]guid.GUID, 0)
+	const query = `SELECT DISTINCT contact_id FROM subscriptions
+		WHERE website_id = $1 AND status IN ($2, $3)`
+
+	err = db.Select(ctx, &ret, query, websiteID, store.SubscriptionStatusActive, store.SubscriptionStatusTrialing)
+	if err != nil {
+		err = fmt.Errorf("store.FindContactIDsWithActiveSubscription: %w", err)
+		return
+	}
+
+	return
+}
@@ -39,6 +39,11 @@ type Service interface {
 	GetWebsiteRevenue(ctx context.Context, db db.Queryer, websiteID guid.GUID, from, to time.Time) (revenue int64, err error)
 	GetOrder(ctx context.Context, input GetOrderInput) (order Order, err error)
 
+	// Subscriptions
+	ContactHasActiveSubscription(ctx context.Context, db db.Queryer, websiteID, contactID guid.GUID) (hasActiveSubscription bool, err error)
+	FindContactIDsWithActiveSubscription(ctx context.Context, db db.Queryer, websiteID guid.GUID) (contactIDs []guid.GUID, err error)
+	FindActiveSubscriptionProductsForWebsite(ctx context.Context, db db.Queryer, websiteID guid.GUID) (products []Product, err error)
+
 	// Refunds
 	ListRefunds(ctx context.Context, input ListRefundsInput) (ret kernel.PaginatedResult[Refund], err error)
 	CreateRefund(ctx context.Context, input CreateRefundInput) (refund Refund, err error)
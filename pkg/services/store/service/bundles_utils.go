@@ -0,0 +1,204 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/bloom42/stdx-go/db"
+	"github.com/bloom42/stdx-go/guid"
+	"github.com/bloom42/stdx-go/set"
+	"github.com/bloom42/stdx-go/slicesx"
+	"markdown.ninja/pkg/errs"
+	"markdown.ninja/pkg/services/store"
+)
+
+// validateBundledProducts checks that all the products exist in the website and can be part of a bundle
+func (service *StoreService) validateBundledProducts(ctx context.Context, db db.Queryer, websiteID guid.GUID, productIDs []guid.GUID) (ret []guid.GUID, err error) {
+	ret = slicesx.Unique(productIDs)
+	if len(ret) == 0 {
+		err = store.ErrBundleMustContainProduct
+		return
+	}
+
+	products, err := service.repo.FindWebsiteProductsIn(ctx, db, websiteID, ret)
+	if err != nil {
+		return
+	}
+
+	if len(products) != len(ret) {
+		err = store.ErrProductNotFound
+		return
+	}
+
+	for _, product := range products {
+		// subscriptions grant access only while they are paid, so they can't be sold as a one-time purchase
+		if product.Type == store.ProductTypeBundle || product.Type == store.ProductTypeSubscription {
+			err = store.ErrProductCantBeBundled(product.Name)
+			return
+		}
+	}
+
+	return
+}
+
+// setBundledProducts replaces the products of the bundle
+func (service *StoreService) setBundledProducts(ctx context.Context, db db.Queryer, bundleID guid.GUID, productIDs []guid.GUID) (err error) {
+	err = service.repo.DeleteBundleProductRelations(ctx, db, bundleID)
+	if err != nil {
+		return
+	}
+
+	for _, productID := range productIDs {
+		relation := store.BundleProductRelation{
+			BundleID:  bundleID,
+			ProductID: productID,
+		}
+		err = service.repo.CreateBundleProductRelation(ctx, db, relation)
+		if err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// expandBundles returns the IDs of the products, plus the IDs of the products included in the bundles
+// among them.
+func (service *StoreService) expandBundles(ctx context.Context, db db.Queryer, productIDs []guid.GUID) (ret []guid.GUID, err error) {
+	relations, err := service.repo.FindBundleProductRelations(ctx, db, productIDs)
+	if err != nil {
+		return
+	}
+
+	ret = make([]guid.GUID, 0, len(productIDs)+len(relations))
+	ret = append(ret, productIDs...)
+	for _, relation := range relations {
+		ret = append(ret, relation.ProductID)
+	}
+	ret = slicesx.Unique(ret)
+
+	return
+}
+
+// giveContactAccessToProducts gives the contact access to the products and to the products included in
+// the bundles among them.
+func (service *StoreService) giveContactAccessToProducts(ctx context.Context, db db.Queryer, contactID guid.GUID, productIDs []guid.GUID) (err error) {
+	now := time.Now().UTC()
+
+	productIDs, err = service.expandBundles(ctx, db, productIDs)
+	if err != nil {
+		return
+	}
+
+	for _, productID := range productIDs {
+		_, err = service.repo.FindContactProductAccess(ctx, db, contactID, productID)
+		if err == nil {
+			// if contact already has access to product we don't need to create product-access relation
+			continue
+		} else {
+			if !errs.IsNotFound(err) {
+				return
+			}
+			err = nil
+		}
+
+		contactProductAccess := store.ContactProductAccess{
+			CreatedAt: now,
+			ContactID: contactID,
+			ProductID: productID,
+		}
+		err = service.repo.CreateContactProductAccess(ctx, db, contactProductAccess)
+		if err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// revokeContactAccessToOrderProducts removes the access of the contact of the order to the products of
+// the order, including the products of the bundles. Access to the products that the contact has also
+// purchased in other orders is kept.
+func (service *StoreService) revokeContactAccessToOrderProducts(ctx context.Context, db db.Queryer, order store.Order) (err error) {
+	orderProducts, err := service.repo.FindProductsForOrder(ctx, db, order.ID)
+	if err != nil {
+		return
+	}
+
+	productIDs := make([]guid.GUID, 0, len(orderProducts))
+	for _, product := range orderProducts {
+		// access to subscriptions is managed by the subscription itself
+		if product.Type != store.ProductTypeSubscription {
+			productIDs = append(productIDs, product.ID)
+		}
+	}
+
+	productIDs, err = service.expandBundles(ctx, db, productIDs)
+	if err != nil {
+		return
+	}
+
+	productsPurchasedInOtherOrders, err := service.repo.FindProductIDsPurchasedByContact(ctx, db, order.ContactID, order.ID)
+	if err != nil {
+		return
+	}
+	productsPurchasedInOtherOrders, err = service.expandBundles(ctx, db, productsPurchasedInOtherOrders)
+	if err != nil {
+		return
+	}
+	productsToKeep := set.NewFromSlice(productsPurchasedInOtherOrders)
+
+	for _, productID := range productIDs {
+		if productsToKeep.Contains(productID) {
+			continue
+		}
+
+		err = service.repo.DeleteAccessToProduct(ctx, db, store.ContactProductAccess{
+			ContactID: order.ContactID,
+			ProductID: productID,
+		})
+		if err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// revokeContactAccessToBundledProducts removes the access of the contact to the products of a bundle once
+// its access to the bundle has been removed. Access to the products that the contact has also purchased,
+// or that are included in other bundles the contact has access to, is kept.
+func (service *StoreService) revokeContactAccessToBundledProducts(ctx context.Context, db db.Queryer, contactID, bundleID guid.GUID, bundledProductIDs []guid.GUID) (err error) {
+	purchasedProducts, err := service.repo.FindProductIDsPurchasedByContact(ctx, db, contactID, guid.Empty)
+	if err != nil {
+		return
+	}
+	purchasedProducts = slices.DeleteFunc(purchasedProducts, bundleID.Equal)
+	productsToKeepIDs, err := service.expandBundles(ctx, db, purchasedProducts)
+	if err != nil {
+		return
+	}
+
+	productsInOtherBundles, err := service.repo.FindProductIDsInOtherBundlesOfContact(ctx, db, contactID, bundleID)
+	if err != nil {
+		return
+	}
+	productsToKeep := set.NewFromSlice(append(productsToKeepIDs, productsInOtherBundles...))
+
+	for _, productID := range bundledProductIDs {
+		if productsToKeep.Contains(productID) {
+			continue
+		}
+
+		err = service.repo.DeleteAccessToProduct(ctx, db, store.ContactProductAccess{
+			ContactID: contactID,
+			ProductID: productID,
+		})
+		if err != nil {
+			return
+		}
+	}
+
+	return
+}
@@ -0,0 +1,313 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/bloom42/stdx-go/db"
+	"github.com/bloom42/stdx-go/guid"
+	"github.com/bloom42/stdx-go/migrate"
+	"markdown.ninja/migrations"
+	"markdown.ninja/pkg/services/contacts"
+	contactsrepository "markdown.ninja/pkg/services/contacts/repository"
+	"markdown.ninja/pkg/services/kernel"
+	"markdown.ninja/pkg/services/organizations"
+	organizationsrepository "markdown.ninja/pkg/services/organizations/repository"
+	"markdown.ninja/pkg/services/store"
+	"markdown.ninja/pkg/services/store/repository"
+	"markdown.ninja/pkg/services/websites"
+	websitesrepository "markdown.ninja/pkg/services/websites/repository"
+)
+
+// errRollbackTestTransaction is returned by the test transactions so nothing is saved in the database
+var errRollbackTestTransaction = errors.New("rollback test transaction")
+
+// withTestTransaction runs fn within a transaction of the database at DATABASE_URL that is always
+// rolled back. The test is skipped if DATABASE_URL is not set.
+func withTestTransaction(t *testing.T, fn func(ctx context.Context, tx db.Tx)) {
+	databaseUrl := os.Getenv("DATABASE_URL")
+	if databaseUrl == "" {
+		t.Skip("DATABASE_URL is not set")
+	}
+	ctx := context.Background()
+
+	database, err := db.Connect(databaseUrl, 2)
+	if err != nil {
+		t.Fatalf("connecting to database: %s", err)
+	}
+	defer database.Close()
+
+	dbMigrations, err := migrate.Load(migrations.MigrationsFs)
+	if err != nil {
+		t.Fatalf("loading migrations: %s", err)
+	}
+	err = db.Migrate(ctx, slog.New(slog.DiscardHandler), database, dbMigrations)
+	if err != nil {
+		t.Fatalf("applying migrations: %s", err)
+	}
+
+	err = database.Transaction(ctx, func(tx db.Tx) error {
+		fn(ctx, tx)
+		return errRollbackTestTransaction
+	})
+	if !errors.Is(err, errRollbackTestTransaction) {
+		t.Fatalf("running test transaction: %s", err)
+	}
+}
+
+// bundlesTestStore holds a website with a contact, and creates the products and orders of the tests
+type bundlesTestStore struct {
+	t       *testing.T
+	ctx     context.Context
+	tx      db.Tx
+	service *StoreService
+	website websites.Website
+	contact contacts.Contact
+}
+
+func newBundlesTestStore(t *testing.T, ctx context.Context, tx db.Tx) *bundlesTestStore {
+	now := time.Now().UTC()
+	organization := organizations.Organization{
+		ID:        guid.NewTimeBased(),
+		CreatedAt: now,
+		UpdatedAt: now,
+		Name:      "Bundles",
+		Plan:      kernel.PlanFree.ID,
+	}
+	organizationsRepo := organizationsrepository.NewOrganizationsRepository()
+	err := organizationsRepo.CreateOrganization(ctx, tx, organization)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	website := websites.Website{
+		ID:             guid.NewTimeBased(),
+		CreatedAt:      now,
+		UpdatedAt:      now,
+		ModifiedAt:     now,
+		Name:           "Bundles",
+		Slug:           "bundles-" + guid.NewTimeBased().String(),
+		Language:       "en",
+		PrimaryDomain:  guid.NewTimeBased().String() + ".example.com",
+		Currency:       websites.CurrencyUSD,
+		Theme:          "blog",
+		OrganizationID: organization.ID,
+	}
+	websitesRepo := websitesrepository.NewWebsitesRepository()
+	err = websitesRepo.CreateWebsite(ctx, tx, website)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	contact := contacts.Contact{
+		ID:        guid.NewTimeBased(),
+		CreatedAt: now,
+		UpdatedAt: now,
+		Name:      "Customer",
+		Email:     "customer@example.com",
+		Verified:  true,
+		WebsiteID: website.ID,
+	}
+	contactsRepo := contactsrepository.NewContactsRepository()
+	err = contactsRepo.CreateContact(ctx, tx, contact)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &bundlesTestStore{
+		t:       t,
+		ctx:     ctx,
+		tx:      tx,
+		service: &StoreService{repo: repository.NewStoreRepository()},
+		website: website,
+		contact: contact,
+	}
+}
+
+// createProduct creates a product. The product is a bundle of bundledProducts if any.
+func (testStore *bundlesTestStore) createProduct(name string, bundledProducts ...guid.GUID) store.Product {
+	now := time.Now().UTC()
+	product := store.Product{
+		ID:        guid.NewTimeBased(),
+		CreatedAt: now,
+		UpdatedAt: now,
+		Name:      name,
+		Type:      store.ProductTypeDigitalDownload,
+		Status:    store.ProductStatusActive,
+		Price:     1000,
+		WebsiteID: testStore.website.ID,
+	}
+	if len(bundledProducts) != 0 {
+		product.Type = store.ProductTypeBundle
+	}
+
+	err := testStore.service.repo.CreateProduct(testStore.ctx, testStore.tx, product)
+	if err != nil {
+		testStore.t.Fatal(err)
+	}
+	err = testStore.service.setBundledProducts(testStore.ctx, testStore.tx, product.ID, bundledProducts)
+	if err != nil {
+		testStore.t.Fatal(err)
+	}
+
+	return product
+}
+
+// completeOrder creates a completed order of the products and gives the contact access to them, the
+// same way complete_order does
+func (testStore *bundlesTestStore) completeOrder(products ...store.Product) store.Order {
+	now := time.Now().UTC()
+	order := store.Order{
+		ID:                      guid.NewTimeBased(),
+		CreatedAt:               now,
+		UpdatedAt:               now,
+		Currency:                websites.CurrencyUSD,
+		Status:                  store.OrderStatusCompleted,
+		CompletedAt:             &now,
+		Email:                   testStore.contact.Email,
+		StripeCheckoutSessionID: guid.NewTimeBased().String(),
+		WebsiteID:               testStore.website.ID,
+		ContactID:               testStore.contact.ID,
+	}
+	err := testStore.service.repo.CreateOrder(testStore.ctx, testStore.tx, order)
+	if err != nil {
+		testStore.t.Fatal(err)
+	}
+
+	productIDs := make([]guid.GUID, 0, len(products))
+	for _, product := range products {
+		err = testStore.service.repo.CreateOrderLineItem(testStore.ctx, testStore.tx, store.OrderLineItem{
+			ProductName:          product.Name,
+			OriginalProductPrice: product.Price,
+			Quantity:             1,
+			OrderID:              order.ID,
+			ProductID:            product.ID,
+		})
+		if err != nil {
+			testStore.t.Fatal(err)
+		}
+		productIDs = append(productIDs, product.ID)
+	}
+
+	err = testStore.service.giveContactAccessToProducts(testStore.ctx, testStore.tx, testStore.contact.ID, productIDs)
+	if err != nil {
+		testStore.t.Fatal(err)
+	}
+
+	return order
+}
+
+func (testStore *bundlesTestStore) giveAccess(product store.Product) {
+	err := testStore.service.giveContactAccessToProducts(testStore.ctx, testStore.tx, testStore.contact.ID, []guid.GUID{product.ID})
+	if err != nil {
+		testStore.t.Fatal(err)
+	}
+}
+
+func (testStore *bundlesTestStore) checkAccess(product store.Product, expected bool) {
+	testStore.t.Helper()
+
+	_, err := testStore.service.repo.FindContactProductAccess(testStore.ctx, testStore.tx, testStore.contact.ID, product.ID)
+	hasAccess := err == nil
+	if err != nil && !errors.Is(err, store.ErrProductAccessNotFound) {
+		testStore.t.Fatal(err)
+	}
+	if hasAccess != expected {
+		testStore.t.Errorf("access to %s: expected %t, got %t", product.Name, expected, hasAccess)
+	}
+}
+
+func TestRefundOfBundleRevokesAccessToBundledProducts(t *testing.T) {
+	withTestTransaction(t, func(ctx context.Context, tx db.Tx) {
+		testStore := newBundlesTestStore(t, ctx, tx)
+		ebook := testStore.createProduct("ebook")
+		course := testStore.createProduct("course")
+		bundle := testStore.createProduct("bundle", ebook.ID, course.ID)
+		unrelatedProduct := testStore.createProduct("unrelated product")
+		freeProduct := testStore.createProduct("free product")
+
+		order := testStore.completeOrder(bundle)
+		testStore.completeOrder(unrelatedProduct)
+		testStore.giveAccess(freeProduct)
+		testStore.checkAccess(ebook, true)
+		testStore.checkAccess(course, true)
+
+		err := testStore.service.revokeContactAccessToOrderProducts(ctx, tx, order)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		testStore.checkAccess(bundle, false)
+		testStore.checkAccess(ebook, false)
+		testStore.checkAccess(course, false)
+		testStore.checkAccess(unrelatedProduct, true)
+		testStore.checkAccess(freeProduct, true)
+	})
+}
+
+func TestRefundOfBundleKeepsProductsPurchasedInOtherOrders(t *testing.T) {
+	withTestTransaction(t, func(ctx context.Context, tx db.Tx) {
+		testStore := newBundlesTestStore(t, ctx, tx)
+		ebook := testStore.createProduct("ebook")
+		course := testStore.createProduct("course")
+		video := testStore.createProduct("video")
+		bundle := testStore.createProduct("bundle", ebook.ID, course.ID, video.ID)
+		otherBundle := testStore.createProduct("other bundle", video.ID)
+
+		order := testStore.completeOrder(bundle)
+		testStore.completeOrder(course)
+		testStore.completeOrder(otherBundle)
+
+		err := testStore.service.revokeContactAccessToOrderProducts(ctx, tx, order)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		testStore.checkAccess(bundle, false)
+		testStore.checkAccess(ebook, false)
+		// purchased separately
+		testStore.checkAccess(course, true)
+		// included in another purchased bundle
+		testStore.checkAccess(otherBundle, true)
+		testStore.checkAccess(video, true)
+	})
+}
+
+func TestRemoveAccessToBundleRevokesAccessToBundledProducts(t *testing.T) {
+	withTestTransaction(t, func(ctx context.Context, tx db.Tx) {
+		testStore := newBundlesTestStore(t, ctx, tx)
+		ebook := testStore.createProduct("ebook")
+		course := testStore.createProduct("course")
+		bundle := testStore.createProduct("bundle", ebook.ID, course.ID)
+		otherBundle := testStore.createProduct("other bundle", course.ID)
+		unrelatedProduct := testStore.createProduct("unrelated product")
+
+		testStore.giveAccess(bundle)
+		testStore.giveAccess(otherBundle)
+		testStore.giveAccess(unrelatedProduct)
+
+		err := testStore.service.repo.DeleteAccessToProduct(ctx, tx, store.ContactProductAccess{
+			ContactID: testStore.contact.ID,
+			ProductID: bundle.ID,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = testStore.service.revokeContactAccessToBundledProducts(ctx, tx, testStore.contact.ID, bundle.ID,
+			[]guid.GUID{ebook.ID, course.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		testStore.checkAccess(bundle, false)
+		testStore.checkAccess(ebook, false)
+		// included in another bundle the contact has access to
+		testStore.checkAccess(course, true)
+		testStore.checkAccess(otherBundle, true)
+		testStore.checkAccess(unrelatedProduct, true)
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/bloom42/stdx-go/db"
 	"github.com/bloom42/stdx-go/guid"
 	"markdown.ninja/pkg/errs"
+	"markdown.ninja/pkg/services/kernel"
 	"markdown.ninja/pkg/services/store"
 )
 
@@ -15,11 +16,19 @@ func (service *StoreService) CheckProductAccess(ctx context.Context, db db.Query
 		// check if contact has access to product
 		_, err = service.repo.FindContactProductAccess(ctx, db, currentContact.ID, productID)
 		if err != nil {
-			if errs.IsNotFound(err) {
-				return store.ErrProductNotFound
+			if !errs.IsNotFound(err) {
+				return err
 			}
 
-			return err
+			// subscriptions only give access to the product while they are active
+			var hasActiveSubscription bool
+			hasActiveSubscription, err = service.repo.ContactHasActiveSubscriptionToProduct(ctx, db, currentContact.ID, productID)
+			if err != nil {
+				return err
+			}
+			if !hasActiveSubscription {
+				return store.ErrProductNotFound
+			}
 		}
 	} else {
 		// check that the authenticated user has access to product
@@ -34,7 +43,7 @@ func (service *StoreService) CheckProductAccess(ctx context.Context, db db.Query
 			return err
 		}
 
-		err = service.websitesService.CheckUserIsStaff(ctx, service.db, currentUserID, product.WebsiteID)
+		err = service.websitesService.CheckUserIsStaff(ctx, service.db, currentUserID, product.WebsiteID, kernel.PermissionRead)
 		if err != nil {
 			return err
 		}
@@ -3,12 +3,10 @@ package service
 import (
 	"context"
 	"fmt"
-	"time"
-
 	"log/slog"
+	"time"
 
 	"github.com/bloom42/stdx-go/countries"
-	"github.com/bloom42/stdx-go/db"
 	"github.com/bloom42/stdx-go/guid"
 	"github.com/bloom42/stdx-go/log/slogx"
 	"github.com/bloom42/stdx-go/opt"
@@ -16,12 +14,12 @@ import (
 	"github.com/bloom42/stdx-go/retry"
 	"github.com/stripe/stripe-go/v81"
 	"github.com/stripe/stripe-go/v81/checkout/session"
-	"markdown.ninja/pkg/errs"
 	"markdown.ninja/pkg/server/httpctx"
 	"markdown.ninja/pkg/services/contacts"
 	"markdown.ninja/pkg/services/events"
 	"markdown.ninja/pkg/services/kernel"
 	"markdown.ninja/pkg/services/store"
+	"markdown.ninja/pkg/services/webhooks"
 )
 
 func (service *StoreService) CompleteOrder(ctx context.Context, input store.CompleteOrderInput) (err error) {
@@ -80,6 +78,8 @@ func (service *StoreService) completeOrder(ctx context.Context, orderID guid.GUI
 	getStripeCheckoutSessionParam := stripe.CheckoutSessionParams{}
 	getStripeCheckoutSessionParam.AddExpand("payment_intent")
 	getStripeCheckoutSessionParam.AddExpand("payment_intent.invoice")
+	getStripeCheckoutSessionParam.AddExpand("invoice")
+	getStripeCheckoutSessionParam.AddExpand("subscription")
 	getStripeCheckoutSessionParam.AddExpand("customer")
 	getStripeCheckoutSessionParam.AddExpand("line_items")
 	getStripeCheckoutSessionParam.AddExpand("line_items.data.price.product")
@@ -95,14 +95,21 @@ func (service *StoreService) completeOrder(ctx context.Context, orderID guid.GUI
 		return fmt.Errorf("store.completeOrder: error getting stripe checkout session: %w", err)
 	}
 
+	// the invoice of subscriptions is attached to the checkout session, not to the payment intent
+	stripeInvoice := stripeCheckoutSession.Invoice
+	if stripeCheckoutSession.PaymentIntent != nil && stripeCheckoutSession.PaymentIntent.Invoice != nil {
+		stripeInvoice = stripeCheckoutSession.PaymentIntent.Invoice
+	}
+	isSubscription := stripeCheckoutSession.Mode == stripe.CheckoutSessionModeSubscription
+
 	if order.Status == store.OrderStatusCompleted {
 		// if order is already completed but stripe invoice is not saved yet
 		if order.StripeInvoiceID == nil || order.StripeInvoiceUrl == nil ||
 			(order.StripeInvoiceUrl != nil && *order.StripeInvoiceUrl == "") {
-			if stripeCheckoutSession.PaymentIntent != nil && stripeCheckoutSession.PaymentIntent.Invoice != nil {
+			if stripeInvoice != nil {
 				order.UpdatedAt = now
-				order.StripeInvoiceID = &stripeCheckoutSession.PaymentIntent.Invoice.ID
-				order.StripeInvoiceUrl = &stripeCheckoutSession.PaymentIntent.Invoice.HostedInvoiceURL
+				order.StripeInvoiceID = &stripeInvoice.ID
+				order.StripeInvoiceUrl = &stripeInvoice.HostedInvoiceURL
 				err = service.repo.UpdateOrder(ctx, tx, order)
 				if err != nil {
 					return err
@@ -193,25 +200,40 @@ func (service *StoreService) completeOrder(ctx context.Context, orderID guid.GUI
 
 	logger.Debug("store.completeOrder: successfully fetched stripe.CheckoutSession", checkoutSessionLogArgs...)
 
-	if stripeCheckoutSession.PaymentIntent == nil {
-		return nil
-	}
+	if isSubscription {
+		if stripeCheckoutSession.Subscription == nil {
+			return nil
+		}
 
-	if stripeCheckoutSession.PaymentIntent.Status != stripe.PaymentIntentStatusSucceeded {
-		// return store.ErrOrderIsNotCompleted
-		logger.Error(fmt.Sprintf("store.completeOrder: invalid Stripe payment intent status: %s. expected: succeeded", stripeCheckoutSession.PaymentIntent.Status))
-		return nil
+		if stripeCheckoutSession.PaymentStatus != stripe.CheckoutSessionPaymentStatusPaid {
+			logger.Error(fmt.Sprintf("store.completeOrder: invalid Stripe checkout session payment status: %s. expected: paid", stripeCheckoutSession.PaymentStatus))
+			return nil
+		}
+	} else {
+		if stripeCheckoutSession.PaymentIntent == nil {
+			return nil
+		}
+
+		if stripeCheckoutSession.PaymentIntent.Status != stripe.PaymentIntentStatusSucceeded {
+			// return store.ErrOrderIsNotCompleted
+			logger.Error(fmt.Sprintf("store.completeOrder: invalid Stripe payment intent status: %s. expected: succeeded", stripeCheckoutSession.PaymentIntent.Status))
+			return nil
+		}
 	}
 
 	order.UpdatedAt = now
-	order.StripPaymentItentID = &stripeCheckoutSession.PaymentIntent.ID
-	order.TotalAmount = stripeCheckoutSession.PaymentIntent.Amount / 100
+	if isSubscription {
+		order.TotalAmount = stripeCheckoutSession.AmountTotal / 100
+	} else {
+		order.StripPaymentItentID = &stripeCheckoutSession.PaymentIntent.ID
+		order.TotalAmount = stripeCheckoutSession.PaymentIntent.Amount / 100
+	}
 	order.BillingAddress = billingAddress
 	order.CompletedAt = &now
 	order.Status = store.OrderStatusCompleted
-	if stripeCheckoutSession.PaymentIntent.Invoice != nil {
-		order.StripeInvoiceID = &stripeCheckoutSession.PaymentIntent.Invoice.ID
-		order.StripeInvoiceUrl = &stripeCheckoutSession.PaymentIntent.Invoice.HostedInvoiceURL
+	if stripeInvoice != nil {
+		order.StripeInvoiceID = &stripeInvoice.ID
+		order.StripeInvoiceUrl = &stripeInvoice.HostedInvoiceURL
 	}
 
 	products, err := service.repo.FindProductsForOrder(ctx, tx, order.ID)
@@ -264,32 +286,43 @@ func (service *StoreService) completeOrder(ctx context.Context, orderID guid.GUI
 		return err
 	}
 
-	// give the customer access to the purchased products
+	// give the customer access to the purchased products, including the products of the bundles
+	purchasedProductIDs := make([]guid.GUID, 0, len(products))
 	for _, product := range products {
-		_, err = service.repo.FindContactProductAccess(ctx, tx, order.ContactID, product.ID)
-		if err == nil {
-			// if contact already has access to product we don't need to create product-access relation
-			continue
-		} else {
-			if !errs.IsNotFound(err) {
+		if product.Type == store.ProductTypeSubscription {
+			// access to subscriptions is granted as long as the subscription is active
+			err = service.upsertSubscription(ctx, tx, stripeCheckoutSession.Subscription, order.WebsiteID, order.ContactID, product.ID)
+			if err != nil {
 				return err
 			}
-			err = nil
+			continue
 		}
 
-		contactProductAccess := store.ContactProductAccess{
-			CreatedAt: now,
-			ContactID: order.ContactID,
-			ProductID: product.ID,
-		}
-		err = service.repo.CreateContactProductAccess(ctx, tx, contactProductAccess)
+		purchasedProductIDs = append(purchasedProductIDs, product.ID)
+	}
+
+	err = service.giveContactAccessToProducts(ctx, tx, order.ContactID, purchasedProductIDs)
+	if err != nil {
+		return err
+	}
+
+	err = service.dispatchOrderCompletedEvent(ctx, tx, order, products)
+	if err != nil {
+		return err
+	}
+
+	if contactSubscribedToNewsletter {
+		err = service.webhooksService.DispatchEvent(ctx, tx, webhooks.DispatchEventInput{
+			WebsiteID: order.WebsiteID,
+			Type:      webhooks.EventTypeSubscriberAdded,
+			Data: webhooks.EventDataSubscriber{
+				ContactID: contact.ID,
+				Email:     contact.Email,
+				Name:      contact.Name,
+			},
+		})
 		if err != nil {
-			if db.IsErrAlreadyExists(err) {
-				// nothing has to be done if contact already has access to product
-				err = nil
-			} else {
-				return err
-			}
+			return err
 		}
 	}
 
@@ -0,0 +1,13 @@
+package service
+
+import (
+	"context"
+
+	"github.com/bloom42/stdx-go/db"
+	"github.com/bloom42/stdx-go/guid"
+)
+
+func (service *StoreService) ContactHasActiveSubscription(ctx context.Context, db db.Queryer, websiteID, contactID guid.GUID) (hasActiveSubscription bool, err error) {
+	hasActiveSubscription, err = service.repo.ContactHasActiveSubscription(ctx, db, websiteID, contactID)
+	return
+}
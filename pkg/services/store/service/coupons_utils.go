@@ -5,6 +5,7 @@ import (
 
 	"github.com/bloom42/stdx-go/db"
 	"github.com/bloom42/stdx-go/guid"
+	"github.com/bloom42/stdx-go/set"
 	"markdown.ninja/pkg/services/store"
 )
 
@@ -30,6 +31,44 @@ func (service *StoreService) hydrateCoupon(ctx context.Context, db db.Queryer, c
 	return
 }
 
+// findProductsDiscountedByCoupon returns the IDs of the ordered products that the coupon applies to.
+// A coupon applies to a bundle if it targets the bundle itself or any of the products of the bundle.
+func (service *StoreService) findProductsDiscountedByCoupon(ctx context.Context, db db.Queryer, coupon store.Coupon, orderedProducts []store.Product) (ret set.Set[guid.GUID], err error) {
+	ret = set.New[guid.GUID]()
+
+	couponProducts, err := service.repo.FindProductsForCoupon(ctx, db, coupon.ID)
+	if err != nil {
+		return
+	}
+
+	couponProductIDs := set.New[guid.GUID]()
+	for _, product := range couponProducts {
+		couponProductIDs.Insert(product.ID)
+	}
+
+	bundleIDs := []guid.GUID{}
+	for _, product := range orderedProducts {
+		if couponProductIDs.Contains(product.ID) {
+			ret.Insert(product.ID)
+		} else if product.Type == store.ProductTypeBundle {
+			bundleIDs = append(bundleIDs, product.ID)
+		}
+	}
+
+	bundlesRelations, err := service.repo.FindBundleProductRelations(ctx, db, bundleIDs)
+	if err != nil {
+		return
+	}
+
+	for _, relation := range bundlesRelations {
+		if couponProductIDs.Contains(relation.ProductID) {
+			ret.Insert(relation.BundleID)
+		}
+	}
+
+	return
+}
+
 func (service *StoreService) diffProducts(currentProducts []store.Product, websiteProducts []store.Product, newProducts []guid.GUID) (diff productsDiff, err error) {
 	diff = productsDiff{
 		couponProductRelationsToCreate: []store.Product{},
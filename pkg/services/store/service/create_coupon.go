@@ -11,6 +11,7 @@ import (
 	"markdown.ninja/pkg/errs"
 	"markdown.ninja/pkg/server/httpctx"
 	"markdown.ninja/pkg/services/kernel"
+	"markdown.ninja/pkg/services/organizations"
 	"markdown.ninja/pkg/services/store"
 )
 
@@ -29,7 +30,7 @@ func (service *StoreService) CreateCoupon(ctx context.Context, input store.Creat
 		return
 	}
 
-	err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, input.WebsiteID)
+	err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, input.WebsiteID, kernel.PermissionManageStore)
 	if err != nil {
 		return
 	}
@@ -119,6 +120,17 @@ func (service *StoreService) CreateCoupon(ctx context.Context, input store.Creat
 		}
 
 		txErr = service.hydrateCoupon(ctx, tx, &coupon)
+		if txErr != nil {
+			return txErr
+		}
+
+		txErr = service.organizationsService.RecordAuditLog(ctx, tx, organizations.RecordAuditLogInput{
+			WebsiteID: &coupon.WebsiteID,
+			Action:    organizations.AuditLogActionCouponCreate,
+			EntityID:  coupon.ID.String(),
+			Before:    nil,
+			After:     coupon,
+		})
 		return txErr
 	})
 	if err != nil {
@@ -11,6 +11,7 @@ import (
 	"github.com/bloom42/stdx-go/guid"
 	"markdown.ninja/pkg/server/httpctx"
 	"markdown.ninja/pkg/services/kernel"
+	"markdown.ninja/pkg/services/organizations"
 	"markdown.ninja/pkg/services/store"
 )
 
@@ -27,7 +28,7 @@ func (service *StoreService) CreateProduct(ctx context.Context, input store.Crea
 		err = kernel.ErrPermissionDenied
 	}
 
-	err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, input.WebsiteID)
+	err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, input.WebsiteID, kernel.PermissionManageStore)
 	if err != nil {
 		return
 	}
@@ -58,6 +59,31 @@ func (service *StoreService) CreateProduct(ctx context.Context, input store.Crea
 		return
 	}
 
+	var yearlyPrice int64
+	if input.YearlyPrice != 0 {
+		if productType != store.ProductTypeSubscription {
+			err = store.ErrProductIsNotASubscription
+			return
+		}
+
+		yearlyPrice = input.YearlyPrice
+		err = service.validateProductPrice(yearlyPrice)
+		if err != nil {
+			return
+		}
+	}
+
+	var bundledProducts []guid.GUID
+	if productType == store.ProductTypeBundle {
+		bundledProducts, err = service.validateBundledProducts(ctx, service.db, input.WebsiteID, input.BundledProducts)
+		if err != nil {
+			return
+		}
+	} else if len(input.BundledProducts) != 0 {
+		err = store.ErrProductIsNotABundle
+		return
+	}
+
 	product = store.Product{
 		ID:          guid.NewTimeBased(),
 		CreatedAt:   now,
@@ -67,7 +93,10 @@ func (service *StoreService) CreateProduct(ctx context.Context, input store.Crea
 		Type:        productType,
 		Status:      store.ProductStatusDraft,
 		Price:       price,
+		YearlyPrice: yearlyPrice,
 		WebsiteID:   input.WebsiteID,
+
+		BundledProducts: bundledProducts,
 	}
 
 	err = service.db.Transaction(ctx, func(tx db.Tx) (txErr error) {
@@ -81,7 +110,21 @@ func (service *StoreService) CreateProduct(ctx context.Context, input store.Crea
 			return txErr
 		}
 
-		return nil
+		if product.Type == store.ProductTypeBundle {
+			txErr = service.setBundledProducts(ctx, tx, product.ID, bundledProducts)
+			if txErr != nil {
+				return txErr
+			}
+		}
+
+		txErr = service.organizationsService.RecordAuditLog(ctx, tx, organizations.RecordAuditLogInput{
+			WebsiteID: &product.WebsiteID,
+			Action:    organizations.AuditLogActionProductCreate,
+			EntityID:  product.ID.String(),
+			Before:    nil,
+			After:     product,
+		})
+		return txErr
 	})
 	if err != nil {
 		return
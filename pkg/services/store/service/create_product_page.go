@@ -37,7 +37,7 @@ func (service *StoreService) CreateProductPage(ctx context.Context, input store.
 		return
 	}
 
-	err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, product.WebsiteID)
+	err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, product.WebsiteID, kernel.PermissionManageStore)
 	if err != nil {
 		return
 	}
@@ -61,6 +61,7 @@ func (service *StoreService) CreateProductPage(ctx context.Context, input store.
 	_, err = markdown.ToHtmlPage(
 		bodyMarkdown,
 		service.httpConfig.WebsitesBaseUrl.Scheme+"://"+website.PrimaryDomain+service.httpConfig.WebsitesPort,
+		nil,
 	)
 	if err != nil {
 		return
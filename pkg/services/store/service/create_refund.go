@@ -5,11 +5,13 @@ import (
 	"log/slog"
 	"time"
 
+	"github.com/bloom42/stdx-go/db"
 	"github.com/bloom42/stdx-go/guid"
 	"github.com/bloom42/stdx-go/log/slogx"
 	"github.com/bloom42/stdx-go/queue"
 	"markdown.ninja/pkg/server/httpctx"
 	"markdown.ninja/pkg/services/kernel"
+	"markdown.ninja/pkg/services/organizations"
 	"markdown.ninja/pkg/services/store"
 )
 
@@ -34,7 +36,7 @@ func (service *StoreService) CreateRefund(ctx context.Context, input store.Creat
 		return
 	}
 
-	err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, order.WebsiteID)
+	err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, order.WebsiteID, kernel.PermissionIssueRefunds)
 	if err != nil {
 		return
 	}
@@ -50,7 +52,7 @@ func (service *StoreService) CreateRefund(ctx context.Context, input store.Creat
 	}
 
 	// As of now, we allow only 1 refund per order
-	if len(previousRefundsForOrder) == 0 {
+	if len(previousRefundsForOrder) != 0 {
 		err = store.ErrOrderAlreadyRefunded
 		return
 	}
@@ -88,7 +90,27 @@ func (service *StoreService) CreateRefund(ctx context.Context, input store.Creat
 		WebsiteID:      order.WebsiteID,
 		OrderID:        order.ID,
 	}
-	err = service.repo.CreateRefund(ctx, service.db, refund)
+	err = service.db.Transaction(ctx, func(tx db.Tx) (txErr error) {
+		txErr = service.repo.CreateRefund(ctx, tx, refund)
+		if txErr != nil {
+			return txErr
+		}
+
+		// refunded customers lose access to the products of the order, including the products of bundles
+		txErr = service.revokeContactAccessToOrderProducts(ctx, tx, order)
+		if txErr != nil {
+			return txErr
+		}
+
+		txErr = service.organizationsService.RecordAuditLog(ctx, tx, organizations.RecordAuditLogInput{
+			WebsiteID: &refund.WebsiteID,
+			Action:    organizations.AuditLogActionRefundCreate,
+			EntityID:  refund.ID.String(),
+			Before:    nil,
+			After:     refund,
+		})
+		return txErr
+	})
 	if err != nil {
 		return
 	}
@@ -5,6 +5,8 @@ import (
 
 	"github.com/bloom42/stdx-go/db"
 	"markdown.ninja/pkg/errs"
+	"markdown.ninja/pkg/services/kernel"
+	"markdown.ninja/pkg/services/organizations"
 	"markdown.ninja/pkg/services/store"
 )
 
@@ -20,7 +22,7 @@ func (service *StoreService) DeleteProduct(ctx context.Context, input store.Dele
 		return
 	}
 
-	err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, product.WebsiteID)
+	err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, product.WebsiteID, kernel.PermissionManageStore)
 	if err != nil {
 		return
 	}
@@ -35,6 +37,16 @@ func (service *StoreService) DeleteProduct(ctx context.Context, input store.Dele
 		return
 	}
 
+	bundled, err := service.repo.ProductIsBundled(ctx, service.db, product.ID)
+	if err != nil {
+		return
+	}
+
+	if bundled {
+		err = store.ErrCantDeleteBundledProduct
+		return
+	}
+
 	assets, err := service.contentService.FindProductAssets(ctx, service.db, product.ID)
 	if err != nil {
 		return
@@ -59,6 +71,17 @@ func (service *StoreService) DeleteProduct(ctx context.Context, input store.Dele
 		}
 
 		errTx = service.repo.DeleteProduct(ctx, tx, product.ID)
+		if errTx != nil {
+			return errTx
+		}
+
+		errTx = service.organizationsService.RecordAuditLog(ctx, tx, organizations.RecordAuditLogInput{
+			WebsiteID: &product.WebsiteID,
+			Action:    organizations.AuditLogActionProductDelete,
+			EntityID:  product.ID.String(),
+			Before:    product,
+			After:     nil,
+		})
 		return errTx
 	})
 	if err != nil {
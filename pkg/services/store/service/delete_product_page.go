@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/bloom42/stdx-go/db"
+	"markdown.ninja/pkg/services/kernel"
 	"markdown.ninja/pkg/services/store"
 )
 
@@ -24,7 +25,7 @@ func (service *StoreService) DeleteProductPage(ctx context.Context, input store.
 		return
 	}
 
-	err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, product.WebsiteID)
+	err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, product.WebsiteID, kernel.PermissionManageStore)
 	if err != nil {
 		return
 	}
@@ -0,0 +1,14 @@
+package service
+
+import (
+	"context"
+
+	"github.com/bloom42/stdx-go/db"
+	"github.com/bloom42/stdx-go/guid"
+	"markdown.ninja/pkg/services/store"
+)
+
+func (service *StoreService) FindActiveSubscriptionProductsForWebsite(ctx context.Context, db db.Queryer, websiteID guid.GUID) (products []store.Product, err error) {
+	products, err = service.repo.FindActiveSubscriptionProductsForWebsite(ctx, db, websiteID)
+	return
+}
@@ -0,0 +1,13 @@
+package service
+
+import (
+	"context"
+
+	"github.com/bloom42/stdx-go/db"
+	"github.com/bloom42/stdx-go/guid"
+)
+
+func (service *StoreService) FindContactIDsWithActiveSubscription(ctx context.Context, db db.Queryer, websiteID guid.GUID) (contactIDs []guid.GUID, err error) {
+	contactIDs, err = service.repo.FindContactIDsWithActiveSubscription(ctx, db, websiteID)
+	return
+}
@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 
+	"markdown.ninja/pkg/services/kernel"
 	"markdown.ninja/pkg/services/store"
 )
 
@@ -17,7 +18,7 @@ func (service *StoreService) GetCoupon(ctx context.Context, input store.GetCoupo
 		return
 	}
 
-	err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, coupon.WebsiteID)
+	err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, coupon.WebsiteID, kernel.PermissionManageStore)
 	if err != nil {
 		return
 	}
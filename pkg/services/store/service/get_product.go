@@ -5,6 +5,7 @@ import (
 
 	"markdown.ninja/pkg/server/httpctx"
 	"markdown.ninja/pkg/services/kernel"
+	"markdown.ninja/pkg/services/organizations"
 	"markdown.ninja/pkg/services/store"
 	"markdown.ninja/pkg/services/websites"
 )
@@ -17,7 +18,7 @@ func (service *StoreService) GetProduct(ctx context.Context, input store.GetProd
 			return
 		}
 
-		err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, product.WebsiteID)
+		err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, product.WebsiteID, kernel.PermissionRead)
 		if err != nil {
 			return
 		}
@@ -39,7 +40,7 @@ func (service *StoreService) GetProduct(ctx context.Context, input store.GetProd
 			return
 		}
 
-		_, err = service.organizationsService.CheckCurrentApiKey(ctx, website.OrganizationID)
+		_, err = service.organizationsService.CheckCurrentApiKey(ctx, website.OrganizationID, website.ID, organizations.ApiKeyScopeStoreRead)
 		if err != nil {
 			return
 		}
@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 
+	"markdown.ninja/pkg/services/kernel"
 	"markdown.ninja/pkg/services/store"
 )
 
@@ -22,7 +23,7 @@ func (service *StoreService) GetProductPage(ctx context.Context, input store.Get
 		return
 	}
 
-	err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, product.WebsiteID)
+	err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, product.WebsiteID, kernel.PermissionRead)
 	if err != nil {
 		return
 	}
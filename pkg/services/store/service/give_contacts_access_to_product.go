@@ -3,14 +3,16 @@ package service
 import (
 	"context"
 	"strings"
-	"time"
 
 	"github.com/bloom42/stdx-go/countries"
 	"github.com/bloom42/stdx-go/db"
+	"github.com/bloom42/stdx-go/guid"
 	"github.com/bloom42/stdx-go/opt"
 	"github.com/bloom42/stdx-go/slicesx"
 	"markdown.ninja/pkg/errs"
 	"markdown.ninja/pkg/services/contacts"
+	"markdown.ninja/pkg/services/kernel"
+	"markdown.ninja/pkg/services/organizations"
 	"markdown.ninja/pkg/services/store"
 )
 
@@ -25,15 +27,13 @@ func (service *StoreService) GiveContactsAccessToProduct(ctx context.Context, in
 		return
 	}
 
-	err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, product.WebsiteID)
+	err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, product.WebsiteID, kernel.PermissionManageStore)
 	if err != nil {
 		return
 	}
 
 	emails := slicesx.Unique(input.Emails)
 
-	now := time.Now().UTC()
-
 	err = service.db.Transaction(ctx, func(tx db.Tx) (txErr error) {
 		for _, email := range emails {
 			email = strings.ToLower(strings.TrimSpace(email))
@@ -79,29 +79,21 @@ func (service *StoreService) GiveContactsAccessToProduct(ctx context.Context, in
 				}
 			}
 
-			_, txErr = service.repo.FindContactProductAccess(ctx, tx, contact.ID, product.ID)
-			if txErr == nil {
-				// if contact already has access to product we do nothing
-				continue
-			} else {
-				if !errs.IsNotFound(txErr) {
-					return txErr
-				}
-				txErr = nil
-			}
-
-			contactProductAccess := store.ContactProductAccess{
-				CreatedAt: now,
-				ContactID: contact.ID,
-				ProductID: product.ID,
-			}
-			txErr = service.repo.CreateContactProductAccess(ctx, tx, contactProductAccess)
+			// if the product is a bundle, the contact also gets access to the products of the bundle
+			txErr = service.giveContactAccessToProducts(ctx, tx, contact.ID, []guid.GUID{product.ID})
 			if txErr != nil {
 				return txErr
 			}
 		}
 
-		return nil
+		txErr = service.organizationsService.RecordAuditLog(ctx, tx, organizations.RecordAuditLogInput{
+			WebsiteID: &product.WebsiteID,
+			Action:    organizations.AuditLogActionProductAccessGive,
+			EntityID:  product.ID.String(),
+			Before:    nil,
+			After:     map[string][]string{"emails": emails},
+		})
+		return txErr
 	})
 	if err != nil {
 		return
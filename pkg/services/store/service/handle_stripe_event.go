@@ -7,11 +7,16 @@ import (
 	"log/slog"
 	"time"
 
+	"github.com/bloom42/stdx-go/db"
 	"github.com/bloom42/stdx-go/guid"
 	"github.com/bloom42/stdx-go/log/slogx"
 	"github.com/bloom42/stdx-go/retry"
 	"github.com/stripe/stripe-go/v81"
 	"github.com/stripe/stripe-go/v81/paymentintent"
+	stripesubscription "github.com/stripe/stripe-go/v81/subscription"
+	"markdown.ninja/pkg/errs"
+	"markdown.ninja/pkg/services/events"
+	"markdown.ninja/pkg/services/store"
 )
 
 func (service *StoreService) HandleStripeEvent(ctx context.Context, stripeEvent stripe.Event) error {
@@ -24,6 +29,10 @@ func (service *StoreService) HandleStripeEvent(ctx context.Context, stripeEvent
 		return service.handleStripeEventCheckoutSession(ctx, stripeEvent)
 	case "invoice.paid":
 		return service.handleStripeEventInvoice(ctx, stripeEvent)
+	case "customer.subscription.created",
+		"customer.subscription.updated",
+		"customer.subscription.deleted":
+		return service.handleStripeEventSubscription(ctx, stripeEvent)
 	}
 
 	return nil
@@ -109,6 +118,10 @@ func (service *StoreService) handleStripeEventInvoice(ctx context.Context, strip
 		return nil
 	}
 
+	if stripeInvoice.Subscription != nil && stripeInvoice.SubscriptionDetails != nil {
+		return service.handleStripeEventSubscriptionInvoice(ctx, stripeInvoice)
+	}
+
 	if stripeInvoice.PaymentIntent == nil {
 		logger.Error("store.handleStripeEventInvoice: invoice.payment_intent is null")
 		return nil
@@ -149,3 +162,198 @@ func (service *StoreService) handleStripeEventInvoice(ctx context.Context, strip
 
 	return service.completeOrder(ctx, orderID, websiteID)
 }
+
+// handleStripeEventSubscription keeps the local subscription in sync with Stripe: renewals, failed payments
+// (past_due, unpaid) and cancellations are all notified with these events.
+func (service *StoreService) handleStripeEventSubscription(ctx context.Context, stripeEvent stripe.Event) error {
+	logger := slogx.FromCtx(ctx)
+
+	stripeSubscription := &stripe.Subscription{}
+	err := json.Unmarshal(stripeEvent.Data.Raw, stripeSubscription)
+	if err != nil {
+		return fmt.Errorf("store.handleStripeEventSubscription: error parsing event JSON: %w", err)
+	}
+
+	logger = logger.With(
+		slog.Group("stripe.event",
+			slog.String("id", stripeEvent.ID),
+			slog.String("type", string(stripeEvent.Type)),
+		),
+		slog.Group("stripe.subscription",
+			slog.String("id", stripeSubscription.ID),
+			slog.String("status", string(stripeSubscription.Status)),
+		),
+	)
+
+	ctx = slogx.ToCtx(ctx, logger)
+
+	if _, isOrganizationEvent := stripeSubscription.Metadata["markdown_ninja_organization_id"]; isOrganizationEvent {
+		// for now only log it to avoid infinite calls betewen the two services
+		logger.Error("store.handleStripeEventSubscription: received an organization event")
+		return nil
+	}
+
+	websiteID, contactID, productID, err := parseStripeSubscriptionMetadata(stripeSubscription.Metadata)
+	if err != nil {
+		logger.Error("store.handleStripeEventSubscription: invalid subscription metadata", slogx.Err(err))
+		return nil
+	}
+
+	product, err := service.repo.FindProductByID(ctx, service.db, productID)
+	if err != nil {
+		return err
+	}
+	if !product.WebsiteID.Equal(websiteID) {
+		logger.Error("store.handleStripeEventSubscription: product doesn't belong to website",
+			slog.String("product.id", productID.String()), slog.String("website.id", websiteID.String()))
+		return nil
+	}
+
+	// events may be delivered out of order, so we fetch the latest state of the subscription
+	err = retry.Do(func() (retryErr error) {
+		stripeSubscription, retryErr = stripesubscription.Get(stripeSubscription.ID, nil)
+		return retryErr
+	}, retry.Context(ctx), retry.Attempts(3), retry.Delay(20*time.Millisecond))
+	if err != nil {
+		return fmt.Errorf("store.handleStripeEventSubscription: error getting stripe subscription: %w", err)
+	}
+
+	return service.upsertSubscription(ctx, service.db, stripeSubscription, websiteID, contactID, productID)
+}
+
+func (service *StoreService) handleStripeEventSubscriptionInvoice(ctx context.Context, stripeInvoice *stripe.Invoice) error {
+	logger := slogx.FromCtx(ctx)
+
+	websiteID, contactID, productID, err := parseStripeSubscriptionMetadata(stripeInvoice.SubscriptionDetails.Metadata)
+	if err != nil {
+		logger.Error("store.handleStripeEventSubscriptionInvoice: invalid subscription metadata", slogx.Err(err))
+		return nil
+	}
+
+	switch stripeInvoice.BillingReason {
+	case stripe.InvoiceBillingReasonSubscriptionCreate:
+		// the first invoice is paid during the checkout
+		orderIDStr := stripeInvoice.SubscriptionDetails.Metadata["markdown_ninja_order_id"]
+		orderID, err := guid.Parse(orderIDStr)
+		if err != nil {
+			return fmt.Errorf("store.handleStripeEventSubscriptionInvoice: error parsing subscription_details.metadata[markdown_ninja_order_id] (%s) for invoice [%s]: %w", orderIDStr, stripeInvoice.ID, err)
+		}
+		return service.completeOrder(ctx, orderID, websiteID)
+
+	case stripe.InvoiceBillingReasonSubscriptionCycle:
+		return service.createSubscriptionRenewalOrder(ctx, stripeInvoice, websiteID, contactID, productID)
+	}
+
+	return nil
+}
+
+// createSubscriptionRenewalOrder records the payment of a subscription renewal as a completed order
+// so it appears in the revenue of the website. Stripe may deliver the same event multiple times, so
+// at most one order is created per invoice.
+func (service *StoreService) createSubscriptionRenewalOrder(ctx context.Context, stripeInvoice *stripe.Invoice,
+	websiteID, contactID, productID guid.GUID) error {
+	now := time.Now().UTC()
+
+	_, err := service.repo.FindOrderByStripeInvoiceID(ctx, service.db, stripeInvoice.ID)
+	if err == nil {
+		return nil
+	} else if !errs.IsNotFound(err) {
+		return err
+	}
+
+	website, err := service.websitesService.FindWebsiteByID(ctx, service.db, websiteID)
+	if err != nil {
+		return err
+	}
+
+	product, err := service.repo.FindProductByID(ctx, service.db, productID)
+	if err != nil {
+		return err
+	}
+	if !product.WebsiteID.Equal(websiteID) {
+		return store.ErrProductNotFound
+	}
+
+	contact, err := service.contactsService.FindContact(ctx, service.db, contactID)
+	if err != nil {
+		return err
+	}
+
+	var stripePaymentIntentID *string
+	if stripeInvoice.PaymentIntent != nil {
+		stripePaymentIntentID = &stripeInvoice.PaymentIntent.ID
+	}
+
+	order := store.Order{
+		ID:                      guid.NewTimeBased(),
+		CreatedAt:               now,
+		UpdatedAt:               now,
+		TotalAmount:             stripeInvoice.AmountPaid / 100,
+		Currency:                website.Currency,
+		Notes:                   "",
+		Status:                  store.OrderStatusCompleted,
+		CompletedAt:             &now,
+		CanceledAt:              nil,
+		Email:                   contact.Email,
+		BillingAddress:          contact.BillingAddress,
+		StripeCheckoutSessionID: "",
+		StripPaymentItentID:     stripePaymentIntentID,
+		StripeInvoiceID:         &stripeInvoice.ID,
+		StripeInvoiceUrl:        &stripeInvoice.HostedInvoiceURL,
+		WebsiteID:               websiteID,
+		ContactID:               contactID,
+	}
+	err = service.db.Transaction(ctx, func(tx db.Tx) (errTx error) {
+		errTx = service.repo.CreateOrder(ctx, tx, order)
+		if errTx != nil {
+			return errTx
+		}
+
+		lineItem := store.OrderLineItem{
+			ProductName:          product.Name,
+			OriginalProductPrice: order.TotalAmount,
+			Quantity:             1,
+			OrderID:              order.ID,
+			ProductID:            product.ID,
+		}
+		errTx = service.repo.CreateOrderLineItem(ctx, tx, lineItem)
+		if errTx != nil {
+			return errTx
+		}
+
+		return service.dispatchOrderCompletedEvent(ctx, tx, order, []store.Product{product})
+	})
+	if err != nil {
+		return err
+	}
+
+	service.eventsService.TrackOrderCompleted(ctx, events.TrackOrderCompletedInput{
+		OrderID:     order.ID,
+		WebsiteID:   order.WebsiteID,
+		TotalAmount: order.TotalAmount,
+	})
+
+	return nil
+}
+
+func parseStripeSubscriptionMetadata(metadata map[string]string) (websiteID, contactID, productID guid.GUID, err error) {
+	websiteID, err = guid.Parse(metadata["markdown_ninja_website_id"])
+	if err != nil {
+		err = fmt.Errorf("error parsing metadata[markdown_ninja_website_id]: %w", err)
+		return
+	}
+
+	contactID, err = guid.Parse(metadata["markdown_ninja_contact_id"])
+	if err != nil {
+		err = fmt.Errorf("error parsing metadata[markdown_ninja_contact_id]: %w", err)
+		return
+	}
+
+	productID, err = guid.Parse(metadata["markdown_ninja_product_id"])
+	if err != nil {
+		err = fmt.Errorf("error parsing metadata[markdown_ninja_product_id]: %w", err)
+		return
+	}
+
+	return
+}
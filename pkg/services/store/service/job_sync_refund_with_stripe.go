@@ -5,9 +5,11 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/bloom42/stdx-go/db"
 	"github.com/stripe/stripe-go/v81"
 	striperefund "github.com/stripe/stripe-go/v81/refund"
 	"markdown.ninja/pkg/services/store"
+	"markdown.ninja/pkg/services/webhooks"
 )
 
 func (service *StoreService) JobSyncRefundWithStripe(ctx context.Context, input store.JobSyncRefundWithStripe) (err error) {
@@ -37,7 +39,27 @@ func (service *StoreService) JobSyncRefundWithStripe(ctx context.Context, input
 			failureReason := store.RefundFailureReason(stripeRefund.FailureReason)
 			refund.FailureReason = &failureReason
 		}
-		err = service.repo.UpdateRefund(ctx, service.db, refund)
+		err = service.db.Transaction(ctx, func(tx db.Tx) (txErr error) {
+			txErr = service.repo.UpdateRefund(ctx, tx, refund)
+			if txErr != nil {
+				return txErr
+			}
+
+			if refund.Status == store.RefundStatusSucceeded {
+				txErr = service.webhooksService.DispatchEvent(ctx, tx, webhooks.DispatchEventInput{
+					WebsiteID: refund.WebsiteID,
+					Type:      webhooks.EventTypeRefundSucceeded,
+					Data: webhooks.EventDataRefund{
+						ID:       refund.ID,
+						Amount:   refund.Amount,
+						Currency: string(refund.Currency),
+						Reason:   string(refund.Reason),
+						OrderID:  refund.OrderID,
+					},
+				})
+			}
+			return txErr
+		})
 		if err != nil {
 			return
 		}
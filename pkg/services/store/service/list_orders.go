@@ -16,7 +16,7 @@ func (service *StoreService) ListOrders(ctx context.Context, input store.ListOrd
 		return ret, err
 	}
 
-	err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, input.WebsiteID)
+	err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, input.WebsiteID, kernel.PermissionManageStore)
 	if err != nil {
 		return ret, err
 	}
@@ -14,7 +14,7 @@ func (service *StoreService) ListProducts(ctx context.Context, input store.ListP
 		return
 	}
 
-	err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, input.WebsiteID)
+	err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, input.WebsiteID, kernel.PermissionRead)
 	if err != nil {
 		return
 	}
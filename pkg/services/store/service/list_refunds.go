@@ -13,7 +13,7 @@ func (service *StoreService) ListRefunds(ctx context.Context, input store.ListRe
 		return
 	}
 
-	err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, input.WebsiteID)
+	err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, input.WebsiteID, kernel.PermissionManageStore)
 	if err != nil {
 		return
 	}
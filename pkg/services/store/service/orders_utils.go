@@ -1,10 +1,13 @@
 package service
 
 import (
+	"context"
 	"fmt"
 
+	"github.com/bloom42/stdx-go/db"
 	"github.com/bloom42/stdx-go/guid"
 	"markdown.ninja/pkg/services/store"
+	"markdown.ninja/pkg/services/webhooks"
 )
 
 func (service *StoreService) generateCompleteOrderUrl(domain string, orderID guid.GUID) string {
@@ -33,3 +36,28 @@ func convertOrderToMetadata(order store.Order) store.OrderMetadata {
 		ContactID:   order.ContactID,
 	}
 }
+
+// dispatchOrderCompletedEvent sends the order.completed event to the webhook endpoints of the website.
+// db should be the transaction that completes the order.
+func (service *StoreService) dispatchOrderCompletedEvent(ctx context.Context, db db.Queryer, order store.Order, products []store.Product) error {
+	eventProducts := make([]webhooks.EventDataOrderProduct, 0, len(products))
+	for _, product := range products {
+		eventProducts = append(eventProducts, webhooks.EventDataOrderProduct{
+			ID:   product.ID,
+			Name: product.Name,
+		})
+	}
+
+	return service.webhooksService.DispatchEvent(ctx, db, webhooks.DispatchEventInput{
+		WebsiteID: order.WebsiteID,
+		Type:      webhooks.EventTypeOrderCompleted,
+		Data: webhooks.EventDataOrder{
+			ID:          order.ID,
+			TotalAmount: order.TotalAmount,
+			Currency:    string(order.Currency),
+			Email:       order.Email,
+			ContactID:   order.ContactID,
+			Products:    eventProducts,
+		},
+	})
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/bloom42/stdx-go/db"
 	"github.com/bloom42/stdx-go/guid"
 	"github.com/bloom42/stdx-go/log/slogx"
+	"github.com/bloom42/stdx-go/set"
 	"github.com/bloom42/stdx-go/slicesx"
 	"github.com/stripe/stripe-go/v81"
 	"github.com/stripe/stripe-go/v81/checkout/session"
@@ -73,6 +74,93 @@ func (service *StoreService) PlaceOrder(ctx context.Context, input store.PlaceOr
 		return
 	}
 
+	// subscriptions are billed with their own Stripe checkout session so they can't be mixed
+	// with one-time purchases
+	var subscriptionProduct *store.Product
+	subscriptionInterval := store.SubscriptionIntervalMonth
+	for _, product := range orderedProducts {
+		if product.Type == store.ProductTypeSubscription {
+			if len(orderedProducts) != 1 {
+				err = store.ErrSubscriptionMustBeOrderedAlone
+				return
+			}
+			subscriptionProduct = &product
+		}
+	}
+
+	if subscriptionProduct != nil {
+		if input.SubscriptionInterval != nil {
+			subscriptionInterval = *input.SubscriptionInterval
+		}
+		if subscriptionInterval != store.SubscriptionIntervalMonth && subscriptionInterval != store.SubscriptionIntervalYear {
+			err = store.ErrSubscriptionIntervalIsNotValid
+			return
+		}
+		if subscriptionInterval == store.SubscriptionIntervalYear && subscriptionProduct.YearlyPrice == 0 {
+			err = store.ErrYearlySubscriptionIsNotAvailable
+			return
+		}
+
+		if contactIsAuthenticated {
+			var alreadySubscribed bool
+			alreadySubscribed, err = service.repo.ContactHasActiveSubscriptionToProduct(ctx, service.db, customer.ID, subscriptionProduct.ID)
+			if err != nil {
+				return
+			}
+			if alreadySubscribed {
+				err = store.ErrAlreadySubscribedToProduct
+				return
+			}
+		}
+	}
+
+	// productPrice returns the price of the product for the selected subscription interval
+	productPrice := func(product store.Product) int64 {
+		if product.Type == store.ProductTypeSubscription && subscriptionInterval == store.SubscriptionIntervalYear {
+			return product.YearlyPrice
+		}
+		return product.Price
+	}
+
+	var couponID *guid.GUID
+	var couponDiscount int64
+	discountedProducts := set.New[guid.GUID]()
+	if input.Coupon != nil && strings.TrimSpace(*input.Coupon) != "" {
+		var coupon store.Coupon
+		couponCode := strings.ToUpper(strings.TrimSpace(*input.Coupon))
+		coupon, err = service.repo.FindWebsiteCouponByCode(ctx, service.db, website.ID, couponCode)
+		if err != nil {
+			return
+		}
+
+		now := time.Now().UTC()
+		if (coupon.ExpiresAt != nil && coupon.ExpiresAt.Before(now)) ||
+			(coupon.ArchivedAt != nil && coupon.ArchivedAt.Before(now)) {
+			err = store.ErrCouponHasExpired
+			return
+		}
+
+		discountedProducts, err = service.findProductsDiscountedByCoupon(ctx, service.db, coupon, orderedProducts)
+		if err != nil {
+			return
+		}
+
+		if len(discountedProducts) == 0 {
+			err = store.ErrCouponDoesNotApplyToOrder
+			return
+		}
+		couponDiscount = coupon.Discount
+		couponID = &coupon.ID
+	}
+
+	// productUnitAmount returns the price of the product in cents, with the coupon's discount applied
+	productUnitAmount := func(product store.Product) int64 {
+		if discountedProducts.Contains(product.ID) {
+			return productPrice(product) * (100 - couponDiscount)
+		}
+		return productPrice(product) * 100
+	}
+
 	var totalAmount int64
 	for _, product := range orderedProducts {
 		if product.Status != store.ProductStatusActive {
@@ -80,8 +168,9 @@ func (service *StoreService) PlaceOrder(ctx context.Context, input store.PlaceOr
 			return
 		}
 
-		totalAmount += product.Price
+		totalAmount += productUnitAmount(product)
 	}
+	totalAmount = totalAmount / 100
 
 	orderID := guid.NewTimeBased()
 
@@ -91,7 +180,7 @@ func (service *StoreService) PlaceOrder(ctx context.Context, input store.PlaceOr
 			err = store.ErrProductIsNotAvailable(product.Name)
 			return
 		}
-		stripeItems[i] = &stripe.CheckoutSessionLineItemParams{
+		stripeItem := &stripe.CheckoutSessionLineItemParams{
 			PriceData: &stripe.CheckoutSessionLineItemPriceDataParams{
 				// Stripe uses lowercase codes for currencies. See stripe.CurrencyUSD for example.
 				Currency: stripe.String(strings.ToLower(string(website.Currency))),
@@ -104,14 +193,21 @@ func (service *StoreService) PlaceOrder(ctx context.Context, input store.PlaceOr
 						"markdown_ninja_contact_id": customer.ID.String(),
 					},
 				},
-				UnitAmount: stripe.Int64(product.Price * 100),
+				UnitAmount: stripe.Int64(productUnitAmount(product)),
 			},
 			Quantity: stripe.Int64(1),
-			AdjustableQuantity: &stripe.CheckoutSessionLineItemAdjustableQuantityParams{
+		}
+		if product.Type == store.ProductTypeSubscription {
+			stripeItem.PriceData.Recurring = &stripe.CheckoutSessionLineItemPriceDataRecurringParams{
+				Interval: stripe.String(string(subscriptionInterval)),
+			}
+		} else {
+			stripeItem.AdjustableQuantity = &stripe.CheckoutSessionLineItemAdjustableQuantityParams{
 				Enabled: stripe.Bool(true),
 				Minimum: stripe.Int64(1),
-			},
+			}
 		}
+		stripeItems[i] = stripeItem
 	}
 
 	var customerEmail *string
@@ -196,6 +292,20 @@ func (service *StoreService) PlaceOrder(ctx context.Context, input store.PlaceOr
 			"markdown_ninja_contact_id": customer.ID.String(),
 		},
 	}
+	if subscriptionProduct != nil {
+		// payment intents and invoices are managed by the subscription
+		createStripeCheckoutSessionParams.Mode = stripe.String(string(stripe.CheckoutSessionModeSubscription))
+		createStripeCheckoutSessionParams.PaymentIntentData = nil
+		createStripeCheckoutSessionParams.InvoiceCreation = nil
+		createStripeCheckoutSessionParams.SubscriptionData = &stripe.CheckoutSessionSubscriptionDataParams{
+			Metadata: map[string]string{
+				"markdown_ninja_website_id": website.ID.String(),
+				"markdown_ninja_order_id":   orderID.String(),
+				"markdown_ninja_contact_id": customer.ID.String(),
+				"markdown_ninja_product_id": subscriptionProduct.ID.String(),
+			},
+		}
+	}
 	createStripeCheckoutSessionParams.AddExpand("payment_intent")
 	stripeCheckoutSession, err := session.New(createStripeCheckoutSessionParams)
 	if err != nil {
@@ -224,6 +334,7 @@ func (service *StoreService) PlaceOrder(ctx context.Context, input store.PlaceOr
 		StripeInvoiceUrl:        nil,
 		WebsiteID:               website.ID,
 		ContactID:               customer.ID,
+		CouponID:                couponID,
 	}
 	err = service.db.Transaction(ctx, func(tx db.Tx) (errTx error) {
 		errTx = service.repo.CreateOrder(ctx, tx, order)
@@ -235,7 +346,7 @@ func (service *StoreService) PlaceOrder(ctx context.Context, input store.PlaceOr
 			// TODO: we need a way to adjust quantities before creating the stripe checkout session
 			lineItem := store.OrderLineItem{
 				ProductName:          product.Name,
-				OriginalProductPrice: product.Price,
+				OriginalProductPrice: productPrice(product),
 				Quantity:             1,
 				OrderID:              order.ID,
 				ProductID:            product.ID,
@@ -255,6 +366,7 @@ func (service *StoreService) PlaceOrder(ctx context.Context, input store.PlaceOr
 	service.eventsService.TrackOrderPlaced(ctx, events.TrackOrderPlacedInput{
 		OrderID:   order.ID,
 		WebsiteID: order.WebsiteID,
+		IpAddress: httpCtx.Client.IP,
 		UserAgent: httpCtx.Client.UserAgent,
 		Country:   httpCtx.Client.CountryCode,
 	})
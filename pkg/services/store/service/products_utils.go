@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/bloom42/stdx-go/db"
+	"github.com/bloom42/stdx-go/guid"
 	"markdown.ninja/pkg/services/store"
 )
 
@@ -18,5 +19,18 @@ func (service *StoreService) hydrateProduct(ctx context.Context, db db.Queryer,
 		return err
 	}
 
+	if product.Type == store.ProductTypeBundle {
+		var relations []store.BundleProductRelation
+		relations, err = service.repo.FindBundleProductRelations(ctx, db, []guid.GUID{product.ID})
+		if err != nil {
+			return err
+		}
+
+		product.BundledProducts = make([]guid.GUID, len(relations))
+		for i, relation := range relations {
+			product.BundledProducts[i] = relation.ProductID
+		}
+	}
+
 	return nil
 }
@@ -4,8 +4,11 @@ import (
 	"context"
 
 	"github.com/bloom42/stdx-go/db"
+	"github.com/bloom42/stdx-go/guid"
 	"github.com/bloom42/stdx-go/slicesx"
 	"markdown.ninja/pkg/services/contacts"
+	"markdown.ninja/pkg/services/kernel"
+	"markdown.ninja/pkg/services/organizations"
 	"markdown.ninja/pkg/services/store"
 )
 
@@ -20,13 +23,27 @@ func (service *StoreService) RemoveAccessToProduct(ctx context.Context, input st
 		return
 	}
 
-	err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, product.WebsiteID)
+	err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, product.WebsiteID, kernel.PermissionManageStore)
 	if err != nil {
 		return
 	}
 
 	emails := slicesx.Unique(input.Emails)
 
+	// removing access to a bundle also removes access to the products of the bundle that the contact
+	// doesn't have otherwise
+	var bundledProductIDs []guid.GUID
+	if product.Type == store.ProductTypeBundle {
+		var relations []store.BundleProductRelation
+		relations, err = service.repo.FindBundleProductRelations(ctx, service.db, []guid.GUID{product.ID})
+		if err != nil {
+			return
+		}
+		for _, relation := range relations {
+			bundledProductIDs = append(bundledProductIDs, relation.ProductID)
+		}
+	}
+
 	err = service.db.Transaction(ctx, func(tx db.Tx) (errTx error) {
 		// TODO: improve performance
 		for _, email := range emails {
@@ -46,9 +63,23 @@ func (service *StoreService) RemoveAccessToProduct(ctx context.Context, input st
 			if errTx != nil {
 				return errTx
 			}
+
+			if len(bundledProductIDs) != 0 {
+				errTx = service.revokeContactAccessToBundledProducts(ctx, tx, contact.ID, product.ID, bundledProductIDs)
+				if errTx != nil {
+					return errTx
+				}
+			}
 		}
 
-		return nil
+		errTx = service.organizationsService.RecordAuditLog(ctx, tx, organizations.RecordAuditLogInput{
+			WebsiteID: &product.WebsiteID,
+			Action:    organizations.AuditLogActionProductAccessRemove,
+			EntityID:  product.ID.String(),
+			Before:    map[string][]string{"emails": emails},
+			After:     nil,
+		})
+		return errTx
 	})
 
 	return
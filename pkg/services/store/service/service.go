@@ -16,6 +16,7 @@ import (
 	"markdown.ninja/pkg/services/organizations"
 	"markdown.ninja/pkg/services/store/notifications"
 	"markdown.ninja/pkg/services/store/repository"
+	"markdown.ninja/pkg/services/webhooks"
 	"markdown.ninja/pkg/services/websites"
 )
 
@@ -32,6 +33,7 @@ type StoreService struct {
 	eventsService        events.Service
 	emailsService        emails.Service
 	organizationsService organizations.Service
+	webhooksService      webhooks.Service
 
 	httpConfig                     config.Http
 	websitesPort                   string
@@ -40,7 +42,8 @@ type StoreService struct {
 
 func NewStoreService(db db.DB, queue queue.Queue, conf config.Config, mailer mailer.Mailer, kernel kernel.PrivateService, websitesService websites.Service,
 	contentService content.Service, contactsService contacts.Service, eventsService events.Service,
-	emailsService emails.Service, organizationsService organizations.Service) (service *StoreService, err error) {
+	emailsService emails.Service, organizationsService organizations.Service,
+	webhooksService webhooks.Service) (service *StoreService, err error) {
 	repo := repository.NewStoreRepository()
 
 	orderConfirmationEmailTemplate, err := template.New("store.OrderConfirmationEmailTemplate").Parse(notifications.OrderConfirmationEmailTemplate)
@@ -62,6 +65,7 @@ func NewStoreService(db db.DB, queue queue.Queue, conf config.Config, mailer mai
 		eventsService:        eventsService,
 		emailsService:        emailsService,
 		organizationsService: organizationsService,
+		webhooksService:      webhooksService,
 
 		httpConfig:                     conf.HTTP,
 		websitesPort:                   conf.HTTP.WebsitesPort,
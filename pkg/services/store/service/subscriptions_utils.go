@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/bloom42/stdx-go/db"
+	"github.com/bloom42/stdx-go/guid"
+	"github.com/stripe/stripe-go/v81"
+	"markdown.ninja/pkg/services/store"
+)
+
+// upsertSubscription saves the current state of the Stripe subscription. Stripe is the source of truth
+// for the lifecycle of subscriptions (renewals, failed payments, cancellations...)
+func (service *StoreService) upsertSubscription(ctx context.Context, db db.Queryer, stripeSubscription *stripe.Subscription,
+	websiteID, contactID, productID guid.GUID) (err error) {
+	now := time.Now().UTC()
+
+	billingInterval := store.SubscriptionIntervalMonth
+	if stripeSubscription.Items != nil && len(stripeSubscription.Items.Data) != 0 &&
+		stripeSubscription.Items.Data[0].Price != nil && stripeSubscription.Items.Data[0].Price.Recurring != nil &&
+		stripeSubscription.Items.Data[0].Price.Recurring.Interval == stripe.PriceRecurringIntervalYear {
+		billingInterval = store.SubscriptionIntervalYear
+	}
+
+	var canceledAt *time.Time
+	if stripeSubscription.CanceledAt != 0 {
+		canceledAtValue := time.Unix(stripeSubscription.CanceledAt, 0).UTC()
+		canceledAt = &canceledAtValue
+	}
+
+	subscription := store.Subscription{
+		ID:                   guid.NewTimeBased(),
+		CreatedAt:            now,
+		UpdatedAt:            now,
+		Status:               store.SubscriptionStatus(stripeSubscription.Status),
+		BillingInterval:      billingInterval,
+		CurrentPeriodEnd:     time.Unix(stripeSubscription.CurrentPeriodEnd, 0).UTC(),
+		CancelAtPeriodEnd:    stripeSubscription.CancelAtPeriodEnd,
+		CanceledAt:           canceledAt,
+		StripeSubscriptionID: stripeSubscription.ID,
+		WebsiteID:            websiteID,
+		ContactID:            contactID,
+		ProductID:            productID,
+	}
+	err = service.repo.UpsertSubscription(ctx, db, subscription)
+	if err != nil {
+		return
+	}
+
+	return
+}
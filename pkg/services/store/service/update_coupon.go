@@ -7,7 +7,10 @@ import (
 	"time"
 
 	"github.com/bloom42/stdx-go/db"
+	"github.com/bloom42/stdx-go/guid"
 	"markdown.ninja/pkg/errs"
+	"markdown.ninja/pkg/services/kernel"
+	"markdown.ninja/pkg/services/organizations"
 	"markdown.ninja/pkg/services/store"
 )
 
@@ -22,11 +25,12 @@ func (service *StoreService) UpdateCoupon(ctx context.Context, input store.Updat
 		return
 	}
 
-	err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, coupon.WebsiteID)
+	err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, coupon.WebsiteID, kernel.PermissionManageStore)
 	if err != nil {
 		return
 	}
 
+	couponBefore := coupon
 	now := time.Now().UTC()
 	var productsDiff productsDiff
 
@@ -92,6 +96,10 @@ func (service *StoreService) UpdateCoupon(ctx context.Context, input store.Updat
 	if err != nil {
 		return
 	}
+	couponBefore.Products = make([]guid.GUID, 0, len(couponProducts))
+	for _, product := range couponProducts {
+		couponBefore.Products = append(couponBefore.Products, product.ID)
+	}
 
 	websiteProducts, err := service.repo.FindProductsByWebsiteID(ctx, service.db, coupon.WebsiteID, math.MaxInt64)
 	if err != nil {
@@ -121,6 +129,17 @@ func (service *StoreService) UpdateCoupon(ctx context.Context, input store.Updat
 		}
 
 		txErr = service.hydrateCoupon(ctx, tx, &coupon)
+		if txErr != nil {
+			return txErr
+		}
+
+		txErr = service.organizationsService.RecordAuditLog(ctx, tx, organizations.RecordAuditLogInput{
+			WebsiteID: &coupon.WebsiteID,
+			Action:    organizations.AuditLogActionCouponUpdate,
+			EntityID:  coupon.ID.String(),
+			Before:    couponBefore,
+			After:     coupon,
+		})
 		return txErr
 	})
 	if err != nil {
@@ -5,8 +5,11 @@ import (
 	"strings"
 	"time"
 
+	"github.com/bloom42/stdx-go/db"
+	"github.com/bloom42/stdx-go/guid"
 	"markdown.ninja/pkg/server/httpctx"
 	"markdown.ninja/pkg/services/kernel"
+	"markdown.ninja/pkg/services/organizations"
 	"markdown.ninja/pkg/services/store"
 	"markdown.ninja/pkg/services/websites"
 )
@@ -19,7 +22,7 @@ func (service *StoreService) UpdateProduct(ctx context.Context, input store.Upda
 			return
 		}
 
-		err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, product.WebsiteID)
+		err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, product.WebsiteID, kernel.PermissionManageStore)
 		if err != nil {
 			return
 		}
@@ -41,12 +44,13 @@ func (service *StoreService) UpdateProduct(ctx context.Context, input store.Upda
 			return
 		}
 
-		_, err = service.organizationsService.CheckCurrentApiKey(ctx, website.OrganizationID)
+		_, err = service.organizationsService.CheckCurrentApiKey(ctx, website.OrganizationID, website.ID, organizations.ApiKeyScopeStoreWrite)
 		if err != nil {
 			return
 		}
 	}
 
+	productBefore := product
 	now := time.Now().UTC()
 
 	if input.Name != nil {
@@ -76,12 +80,72 @@ func (service *StoreService) UpdateProduct(ctx context.Context, input store.Upda
 		product.Price = price
 	}
 
+	if input.YearlyPrice != nil {
+		if product.Type != store.ProductTypeSubscription {
+			err = store.ErrProductIsNotASubscription
+			return
+		}
+
+		yearlyPrice := *input.YearlyPrice
+		err = service.validateProductPrice(yearlyPrice)
+		if err != nil {
+			return
+		}
+		product.YearlyPrice = yearlyPrice
+	}
+
 	if input.Status != nil {
 		product.Status = *input.Status
 	}
 
+	var bundledProducts []guid.GUID
+	if input.BundledProducts != nil {
+		if product.Type != store.ProductTypeBundle {
+			err = store.ErrProductIsNotABundle
+			return
+		}
+
+		bundledProducts, err = service.validateBundledProducts(ctx, service.db, product.WebsiteID, input.BundledProducts)
+		if err != nil {
+			return
+		}
+
+		var relations []store.BundleProductRelation
+		relations, err = service.repo.FindBundleProductRelations(ctx, service.db, []guid.GUID{product.ID})
+		if err != nil {
+			return
+		}
+		productBefore.BundledProducts = make([]guid.GUID, 0, len(relations))
+		for _, relation := range relations {
+			productBefore.BundledProducts = append(productBefore.BundledProducts, relation.ProductID)
+		}
+		product.BundledProducts = bundledProducts
+	}
+
 	product.UpdatedAt = now
-	err = service.repo.UpdateProduct(ctx, service.db, product)
+	err = service.db.Transaction(ctx, func(tx db.Tx) (txErr error) {
+		txErr = service.repo.UpdateProduct(ctx, tx, product)
+		if txErr != nil {
+			return txErr
+		}
+
+		// access to the products added to a bundle is not given retroactively to previous customers
+		if bundledProducts != nil {
+			txErr = service.setBundledProducts(ctx, tx, product.ID, bundledProducts)
+			if txErr != nil {
+				return txErr
+			}
+		}
+
+		txErr = service.organizationsService.RecordAuditLog(ctx, tx, organizations.RecordAuditLogInput{
+			WebsiteID: &product.WebsiteID,
+			Action:    organizations.AuditLogActionProductUpdate,
+			EntityID:  product.ID.String(),
+			Before:    productBefore,
+			After:     product,
+		})
+		return txErr
+	})
 	if err != nil {
 		return
 	}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/bloom42/stdx-go/crypto/blake3"
+	"markdown.ninja/pkg/services/kernel"
 	"markdown.ninja/pkg/services/store"
 )
 
@@ -25,7 +26,7 @@ func (service *StoreService) UpdateProductPage(ctx context.Context, input store.
 		return
 	}
 
-	err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, product.WebsiteID)
+	err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, product.WebsiteID, kernel.PermissionManageStore)
 	if err != nil {
 		return
 	}
@@ -39,7 +39,8 @@ func (service *StoreService) validateProductDescription(description string) erro
 
 func (service *StoreService) validateProductType(productType store.ProductType) error {
 	if productType != store.ProductTypeBook && productType != store.ProductTypeCourse &&
-		productType != store.ProductTypeDigitalDownload {
+		productType != store.ProductTypeDigitalDownload && productType != store.ProductTypeSubscription &&
+		productType != store.ProductTypeBundle {
 		return store.ErrProductTypeIsNotValid
 	}
 
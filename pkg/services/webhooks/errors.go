@@ -0,0 +1,24 @@
+package webhooks
+
+import (
+	"fmt"
+
+	"markdown.ninja/pkg/errs"
+)
+
+var (
+	// Endpoints
+	ErrWebhookEndpointNotFound              = errs.NotFound("Webhook endpoint not found.")
+	ErrWebhookEndpointUrlIsNotValid         = errs.InvalidArgument("URL is not valid. Webhook endpoints must use HTTPS.")
+	ErrWebhookEndpointUrlIsTooLong          = errs.InvalidArgument(fmt.Sprintf("URL is too long (max: %d characters)", WebhookEndpointUrlMaxLength))
+	ErrWebhookEndpointDescriptionIsTooLong  = errs.InvalidArgument(fmt.Sprintf("Description is too long (max: %d characters)", WebhookEndpointDescriptionMaxLength))
+	ErrWebhookEndpointDescriptionIsNotValid = errs.InvalidArgument("Description is not valid.")
+	ErrWebhookEndpointEventTypesAreEmpty    = errs.InvalidArgument("Please select at least one event.")
+	ErrEventTypeIsNotValid                  = func(eventType EventType) error {
+		return errs.InvalidArgument(fmt.Sprintf("Event \"%s\" is not valid.", eventType))
+	}
+	ErrWebhookEndpointsLimitReached = errs.InvalidArgument(fmt.Sprintf("Webhook endpoints limit reached (max: %d per website). Please contact support if you need more.", WebhookEndpointsMaxPerWebsite))
+
+	// Deliveries
+	ErrWebhookDeliveryNotFound = errs.NotFound("Webhook delivery not found.")
+)
@@ -0,0 +1,11 @@
+package webhooks
+
+import "github.com/bloom42/stdx-go/guid"
+
+type JobDeliverWebhook struct {
+	DeliveryID guid.GUID `json:"delivery_id"`
+}
+
+func (JobDeliverWebhook) JobType() string {
+	return "webhooks.deliver_webhook"
+}
@@ -0,0 +1,210 @@
+package webhooks
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/bloom42/stdx-go/guid"
+)
+
+const (
+	WebhookEndpointUrlMaxLength         = 2048
+	WebhookEndpointDescriptionMaxLength = 200
+	WebhookEndpointsMaxPerWebsite       = 10
+
+	// WebhookSecretPrefix is the prefix of the secrets used to sign the payloads
+	WebhookSecretPrefix = "whsec_"
+
+	// DeliveryMaxAttempts is the maximum number of times we try to deliver an event before giving up
+	DeliveryMaxAttempts = 10
+	// DeliveryTimeout is the maximum duration of an HTTP request to a webhook endpoint
+	DeliveryTimeout = 10 * time.Second
+	// DeliveryResponseBodyMaxLength is the maximum number of bytes of the response body that we store
+	// in the delivery log
+	DeliveryResponseBodyMaxLength = 2048
+
+	HeaderSignature  = "Markdown-Ninja-Signature"
+	HeaderEventType  = "Markdown-Ninja-Event"
+	HeaderDeliveryID = "Markdown-Ninja-Delivery"
+)
+
+type EventType string
+
+const (
+	EventTypePagePublished     EventType = "page.published"
+	EventTypeSubscriberAdded   EventType = "subscriber.added"
+	EventTypeSubscriberRemoved EventType = "subscriber.removed"
+	EventTypeOrderCompleted    EventType = "order.completed"
+	EventTypeRefundSucceeded   EventType = "refund.succeeded"
+)
+
+var AllEventTypes = []EventType{
+	EventTypePagePublished,
+	EventTypeSubscriberAdded,
+	EventTypeSubscriberRemoved,
+	EventTypeOrderCompleted,
+	EventTypeRefundSucceeded,
+}
+
+func (eventType EventType) IsValid() bool {
+	return slices.Contains(AllEventTypes, eventType)
+}
+
+type EventTypes []EventType
+
+func (eventTypes *EventTypes) Scan(val any) error {
+	switch v := val.(type) {
+	case []byte:
+		return json.Unmarshal(v, eventTypes)
+	case string:
+		return json.Unmarshal([]byte(v), eventTypes)
+	default:
+		return fmt.Errorf("EventTypes.Scan: Unsupported type: %T", v)
+	}
+}
+
+func (eventTypes EventTypes) Value() (driver.Value, error) {
+	if eventTypes == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(eventTypes)
+}
+
+type WebhookEndpoint struct {
+	ID          guid.GUID  `db:"id" json:"id"`
+	CreatedAt   time.Time  `db:"created_at" json:"created_at"`
+	UpdatedAt   time.Time  `db:"updated_at" json:"updated_at"`
+	Url         string     `db:"url" json:"url"`
+	Description string     `db:"description" json:"description"`
+	EventTypes  EventTypes `db:"event_types" json:"event_types"`
+	Active      bool       `db:"active" json:"active"`
+	// Secret is used to sign the payloads with HMAC-SHA256
+	Secret string `db:"secret" json:"secret"`
+
+	WebsiteID guid.GUID `db:"website_id" json:"website_id"`
+}
+
+type DeliveryStatus string
+
+const (
+	DeliveryStatusPending   DeliveryStatus = "pending"
+	DeliveryStatusSucceeded DeliveryStatus = "succeeded"
+	DeliveryStatusFailed    DeliveryStatus = "failed"
+)
+
+type WebhookDelivery struct {
+	ID        guid.GUID `db:"id" json:"id"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+	UpdatedAt time.Time `db:"updated_at" json:"updated_at"`
+	EventType EventType `db:"event_type" json:"event_type"`
+	// Payload is the exact body sent to the endpoint
+	Payload        json.RawMessage `db:"payload" json:"payload"`
+	Status         DeliveryStatus  `db:"status" json:"status"`
+	Attempts       int64           `db:"attempts" json:"attempts"`
+	LastAttemptAt  *time.Time      `db:"last_attempt_at" json:"last_attempt_at"`
+	NextAttemptAt  *time.Time      `db:"next_attempt_at" json:"next_attempt_at"`
+	ResponseStatus *int64          `db:"response_status" json:"response_status"`
+	ResponseBody   string          `db:"response_body" json:"response_body"`
+	Error          string          `db:"error" json:"error"`
+	// Duration of the last attempt, in milliseconds
+	Duration int64 `db:"duration" json:"duration"`
+
+	EndpointID guid.GUID `db:"endpoint_id" json:"endpoint_id"`
+	WebsiteID  guid.GUID `db:"website_id" json:"website_id"`
+}
+
+// Event is the payload sent to the webhook endpoints
+type Event struct {
+	// ID is the ID of the event and is the same for all the endpoints and redeliveries.
+	// It can be used by the receivers to deduplicate events.
+	ID        guid.GUID `json:"id"`
+	Type      EventType `json:"type"`
+	CreatedAt time.Time `json:"created_at"`
+	WebsiteID guid.GUID `json:"website_id"`
+	Data      any       `json:"data"`
+}
+
+type EventDataPage struct {
+	ID    guid.GUID `json:"id"`
+	Type  string    `json:"type"`
+	Path  string    `json:"path"`
+	Url   string    `json:"url"`
+	Title string    `json:"title"`
+	Date  time.Time `json:"date"`
+}
+
+type EventDataSubscriber struct {
+	ContactID guid.GUID `json:"contact_id"`
+	Email     string    `json:"email"`
+	Name      string    `json:"name"`
+}
+
+type EventDataOrder struct {
+	ID          guid.GUID               `json:"id"`
+	TotalAmount int64                   `json:"total_amount"`
+	Currency    string                  `json:"currency"`
+	Email       string                  `json:"email"`
+	ContactID   guid.GUID               `json:"contact_id"`
+	Products    []EventDataOrderProduct `json:"products"`
+}
+
+type EventDataOrderProduct struct {
+	ID   guid.GUID `json:"id"`
+	Name string    `json:"name"`
+}
+
+type EventDataRefund struct {
+	ID       guid.GUID `json:"id"`
+	Amount   int64     `json:"amount"`
+	Currency string    `json:"currency"`
+	Reason   string    `json:"reason"`
+	OrderID  guid.GUID `json:"order_id"`
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+// Service inputs / outputs
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+type CreateWebhookEndpointInput struct {
+	WebsiteID   guid.GUID   `json:"website_id"`
+	Url         string      `json:"url"`
+	Description string      `json:"description"`
+	EventTypes  []EventType `json:"event_types"`
+}
+
+type UpdateWebhookEndpointInput struct {
+	ID          guid.GUID    `json:"id"`
+	Url         *string      `json:"url"`
+	Description *string      `json:"description"`
+	EventTypes  *[]EventType `json:"event_types"`
+	Active      *bool        `json:"active"`
+}
+
+type DeleteWebhookEndpointInput struct {
+	ID guid.GUID `json:"id"`
+}
+
+type ListWebhookEndpointsInput struct {
+	WebsiteID guid.GUID `json:"website_id"`
+}
+
+type ListWebhookDeliveriesInput struct {
+	WebsiteID  guid.GUID  `json:"website_id"`
+	EndpointID *guid.GUID `json:"endpoint_id"`
+	Limit      int64      `json:"limit"`
+	After      *guid.GUID `json:"after"`
+}
+
+type RedeliverWebhookInput struct {
+	DeliveryID guid.GUID `json:"delivery_id"`
+}
+
+type DispatchEventInput struct {
+	WebsiteID guid.GUID
+	Type      EventType
+	// Data is serialized to JSON in the data field of the payload
+	Data any
+}
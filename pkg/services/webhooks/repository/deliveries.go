@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+
+	"github.com/bloom42/stdx-go/db"
+	"github.com/bloom42/stdx-go/guid"
+	"markdown.ninja/pkg/services/webhooks"
+)
+
+func (repo *WebhooksRepository) CreateWebhookDelivery(ctx context.Context, db db.Queryer, delivery webhooks.WebhookDelivery) (err error) {
+	const query = `INSERT INTO webhook_deliveries
+			(id, created_at, updated_at, event_type, payload, status, attempts, last_attempt_at, next_attempt_at,
+				response_status, response_body, error, duration, endpoint_id, website_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)`
+
+	_, err = db.Exec(ctx, query, delivery.ID, delivery.CreatedAt, delivery.UpdatedAt, delivery.EventType,
+		delivery.Payload, delivery.Status, delivery.Attempts, delivery.LastAttemptAt, delivery.NextAttemptAt,
+		delivery.ResponseStatus, delivery.ResponseBody, delivery.Error, delivery.Duration, delivery.EndpointID,
+		delivery.WebsiteID)
+	if err != nil {
+		err = fmt.Errorf("webhooks.CreateWebhookDelivery: %w", err)
+		return
+	}
+
+	return
+}
+
+func (repo *WebhooksRepository) UpdateWebhookDelivery(ctx context.Context, db db.Queryer, delivery webhooks.WebhookDelivery) (err error) {
+	const query = `UPDATE webhook_deliveries
+		SET updated_at = $1, status = $2, attempts = $3, last_attempt_at = $4, next_attempt_at = $5,
+			response_status = $6, response_body = $7, error = $8, duration = $9
+		WHERE id = $10`
+
+	_, err = db.Exec(ctx, query, delivery.UpdatedAt, delivery.Status, delivery.Attempts, delivery.LastAttemptAt,
+		delivery.NextAttemptAt, delivery.ResponseStatus, delivery.ResponseBody, delivery.Error, delivery.Duration,
+		delivery.ID)
+	if err != nil {
+		err = fmt.Errorf("webhooks.UpdateWebhookDelivery: %w", err)
+		return
+	}
+
+	return
+}
+
+func (repo *WebhooksRepository) FindWebhookDeliveryByID(ctx context.Context, db db.Queryer, deliveryID guid.GUID, forUpdate bool) (delivery webhooks.WebhookDelivery, err error) {
+	query := "SELECT * FROM webhook_deliveries WHERE id = $1"
+	if forUpdate {
+		query += " FOR UPDATE"
+	}
+
+	err = db.Get(ctx, &delivery, query, deliveryID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			err = webhooks.ErrWebhookDeliveryNotFound
+		} else {
+			err = fmt.Errorf("webhooks.FindWebhookDeliveryByID: %w", err)
+		}
+		return
+	}
+
+	return
+}
+
+// FindWebhookDeliveries returns the deliveries of the website, from the most recent to the oldest.
+func (repo *WebhooksRepository) FindWebhookDeliveries(ctx context.Context, db db.Queryer, websiteID guid.GUID, endpointID *guid.GUID, limit int64, after *guid.GUID) (deliveries []webhooks.WebhookDelivery, err error) {
+	deliveries = []webhooks.WebhookDelivery{}
+	query := `SELECT * FROM webhook_deliveries WHERE website_id = $1`
+	args := []any{websiteID}
+
+	if endpointID != nil {
+		args = append(args, *endpointID)
+		query += " AND endpoint_id = $" + strconv.Itoa(len(args))
+	}
+	if after != nil {
+		args = append(args, *after)
+		query += " AND id < $" + strconv.Itoa(len(args))
+	}
+	args = append(args, limit)
+	query += " ORDER BY id DESC LIMIT $" + strconv.Itoa(len(args))
+
+	err = db.Select(ctx, &deliveries, query, args...)
+	if err != nil {
+		err = fmt.Errorf("webhooks.FindWebhookDeliveries: %w", err)
+		return
+	}
+
+	return
+}
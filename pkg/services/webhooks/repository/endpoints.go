@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/bloom42/stdx-go/db"
+	"github.com/bloom42/stdx-go/guid"
+	"markdown.ninja/pkg/services/webhooks"
+)
+
+func (repo *WebhooksRepository) CreateWebhookEndpoint(ctx context.Context, db db.Queryer, endpoint webhooks.WebhookEndpoint) (err error) {
+	const query = `INSERT INTO webhook_endpoints
+			(id, created_at, updated_at, url, description, event_types, active, secret, website_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+
+	_, err = db.Exec(ctx, query, endpoint.ID, endpoint.CreatedAt, endpoint.UpdatedAt, endpoint.Url,
+		endpoint.Description, endpoint.EventTypes, endpoint.Active, endpoint.Secret, endpoint.WebsiteID)
+	if err != nil {
+		err = fmt.Errorf("webhooks.CreateWebhookEndpoint: %w", err)
+		return
+	}
+
+	return
+}
+
+func (repo *WebhooksRepository) UpdateWebhookEndpoint(ctx context.Context, db db.Queryer, endpoint webhooks.WebhookEndpoint) (err error) {
+	const query = `UPDATE webhook_endpoints
+		SET updated_at = $1, url = $2, description = $3, event_types = $4, active = $5
+		WHERE id = $6`
+
+	_, err = db.Exec(ctx, query, endpoint.UpdatedAt, endpoint.Url, endpoint.Description, endpoint.EventTypes,
+		endpoint.Active, endpoint.ID)
+	if err != nil {
+		err = fmt.Errorf("webhooks.UpdateWebhookEndpoint: %w", err)
+		return
+	}
+
+	return
+}
+
+func (repo *WebhooksRepository) DeleteWebhookEndpoint(ctx context.Context, db db.Queryer, endpointID guid.GUID) (err error) {
+	const query = `DELETE FROM webhook_endpoints WHERE id = $1`
+
+	_, err = db.Exec(ctx, query, endpointID)
+	if err != nil {
+		err = fmt.Errorf("webhooks.DeleteWebhookEndpoint: %w", err)
+		return
+	}
+
+	return
+}
+
+func (repo *WebhooksRepository) FindWebhookEndpointByID(ctx context.Context, db db.Queryer, endpointID guid.GUID) (endpoint webhooks.WebhookEndpoint, err error) {
+	const query = "SELECT * FROM webhook_endpoints WHERE id = $1"
+
+	err = db.Get(ctx, &endpoint, query, endpointID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			err = webhooks.ErrWebhookEndpointNotFound
+		} else {
+			err = fmt.Errorf("webhooks.FindWebhookEndpointByID: %w", err)
+		}
+		return
+	}
+
+	return
+}
+
+func (repo *WebhooksRepository) FindWebhookEndpointsForWebsite(ctx context.Context, db db.Queryer, websiteID guid.GUID) (endpoints []webhooks.WebhookEndpoint, err error) {
+	endpoints = []webhooks.WebhookEndpoint{}
+	const query = `SELECT * FROM webhook_endpoints
+		WHERE website_id = $1
+		ORDER BY id`
+
+	err = db.Select(ctx, &endpoints, query, websiteID)
+	if err != nil {
+		err = fmt.Errorf("webhooks.FindWebhookEndpointsForWebsite: %w", err)
+		return
+	}
+
+	return
+}
+
+// FindActiveWebhookEndpointsForEvent returns the active endpoints of the website that are subscribed
+// to the given event type
+func (repo *WebhooksRepository) FindActiveWebhookEndpointsForEvent(ctx context.Context, db db.Queryer, websiteID guid.GUID, eventType webhooks.EventType) (endpoints []webhooks.WebhookEndpoint, err error) {
+	endpoints = []webhooks.WebhookEndpoint{}
+	const query = `SELECT * FROM webhook_endpoints
+		WHERE website_id = $1 AND active = true AND event_types @> jsonb_build_array($2::TEXT)
+		ORDER BY id`
+
+	err = db.Select(ctx, &endpoints, query, websiteID, string(eventType))
+	if err != nil {
+		err = fmt.Errorf("webhooks.FindActiveWebhookEndpointsForEvent: %w", err)
+		return
+	}
+
+	return
+}
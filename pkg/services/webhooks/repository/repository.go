@@ -0,0 +1,7 @@
+package repository
+
+type WebhooksRepository struct{}
+
+func NewWebhooksRepository() WebhooksRepository {
+	return WebhooksRepository{}
+}
@@ -0,0 +1,29 @@
+package webhooks
+
+import (
+	"context"
+
+	"github.com/bloom42/stdx-go/db"
+	"markdown.ninja/pkg/services/kernel"
+)
+
+type Service interface {
+	// Endpoints
+	CreateWebhookEndpoint(ctx context.Context, input CreateWebhookEndpointInput) (endpoint WebhookEndpoint, err error)
+	UpdateWebhookEndpoint(ctx context.Context, input UpdateWebhookEndpointInput) (endpoint WebhookEndpoint, err error)
+	DeleteWebhookEndpoint(ctx context.Context, input DeleteWebhookEndpointInput) (err error)
+	ListWebhookEndpoints(ctx context.Context, input ListWebhookEndpointsInput) (ret kernel.PaginatedResult[WebhookEndpoint], err error)
+
+	// Deliveries
+	ListWebhookDeliveries(ctx context.Context, input ListWebhookDeliveriesInput) (ret kernel.PaginatedResult[WebhookDelivery], err error)
+	RedeliverWebhook(ctx context.Context, input RedeliverWebhookInput) (delivery WebhookDelivery, err error)
+
+	// DispatchEvent creates a delivery for each active endpoint of the website subscribed to the event
+	// and enqueues the jobs to deliver them.
+	// db should be the transaction that triggered the event, if any, so the event is not sent if the
+	// transaction is rolled back.
+	DispatchEvent(ctx context.Context, db db.Queryer, input DispatchEventInput) (err error)
+
+	// Jobs
+	JobDeliverWebhook(ctx context.Context, input JobDeliverWebhook) (err error)
+}
@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/bloom42/stdx-go/db"
+	"github.com/bloom42/stdx-go/guid"
+	"markdown.ninja/pkg/services/kernel"
+	"markdown.ninja/pkg/services/organizations"
+	"markdown.ninja/pkg/services/webhooks"
+)
+
+func (service *WebhooksService) CreateWebhookEndpoint(ctx context.Context, input webhooks.CreateWebhookEndpointInput) (endpoint webhooks.WebhookEndpoint, err error) {
+	actorID, err := service.kernel.CurrentUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, input.WebsiteID, kernel.PermissionManageWebsites)
+	if err != nil {
+		return
+	}
+
+	endpointUrl, err := service.cleanAndValidateEndpointUrl(input.Url)
+	if err != nil {
+		return
+	}
+
+	description := strings.TrimSpace(input.Description)
+	err = service.validateEndpointDescription(description)
+	if err != nil {
+		return
+	}
+
+	eventTypes, err := service.cleanAndValidateEventTypes(input.EventTypes)
+	if err != nil {
+		return
+	}
+
+	existingEndpoints, err := service.repo.FindWebhookEndpointsForWebsite(ctx, service.db, input.WebsiteID)
+	if err != nil {
+		return
+	}
+	if len(existingEndpoints) >= webhooks.WebhookEndpointsMaxPerWebsite {
+		err = webhooks.ErrWebhookEndpointsLimitReached
+		return
+	}
+
+	secret, err := generateWebhookSecret()
+	if err != nil {
+		return
+	}
+
+	now := time.Now().UTC()
+	endpoint = webhooks.WebhookEndpoint{
+		ID:          guid.NewTimeBased(),
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		Url:         endpointUrl,
+		Description: description,
+		EventTypes:  eventTypes,
+		Active:      true,
+		Secret:      secret,
+		WebsiteID:   input.WebsiteID,
+	}
+
+	err = service.db.Transaction(ctx, func(tx db.Tx) (txErr error) {
+		txErr = service.repo.CreateWebhookEndpoint(ctx, tx, endpoint)
+		if txErr != nil {
+			return txErr
+		}
+
+		txErr = service.organizationsService.RecordAuditLog(ctx, tx, organizations.RecordAuditLogInput{
+			WebsiteID: &endpoint.WebsiteID,
+			Action:    organizations.AuditLogActionWebhookEndpointCreate,
+			EntityID:  endpoint.ID.String(),
+			Before:    nil,
+			After:     endpointToAuditLogState(endpoint),
+		})
+		return txErr
+	})
+	if err != nil {
+		return
+	}
+
+	return
+}
@@ -0,0 +1,50 @@
+package service
+
+import (
+	"context"
+
+	"github.com/bloom42/stdx-go/db"
+	"markdown.ninja/pkg/services/kernel"
+	"markdown.ninja/pkg/services/organizations"
+	"markdown.ninja/pkg/services/webhooks"
+)
+
+func (service *WebhooksService) DeleteWebhookEndpoint(ctx context.Context, input webhooks.DeleteWebhookEndpointInput) (err error) {
+	actorID, err := service.kernel.CurrentUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	endpoint, err := service.repo.FindWebhookEndpointByID(ctx, service.db, input.ID)
+	if err != nil {
+		return
+	}
+
+	err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, endpoint.WebsiteID, kernel.PermissionManageWebsites)
+	if err != nil {
+		return
+	}
+
+	// the deliveries of the endpoint are deleted by the database (ON DELETE CASCADE) and the pending
+	// jobs are discarded when they can't find their delivery
+	err = service.db.Transaction(ctx, func(tx db.Tx) (txErr error) {
+		txErr = service.repo.DeleteWebhookEndpoint(ctx, tx, endpoint.ID)
+		if txErr != nil {
+			return txErr
+		}
+
+		txErr = service.organizationsService.RecordAuditLog(ctx, tx, organizations.RecordAuditLogInput{
+			WebsiteID: &endpoint.WebsiteID,
+			Action:    organizations.AuditLogActionWebhookEndpointDelete,
+			EntityID:  endpoint.ID.String(),
+			Before:    endpointToAuditLogState(endpoint),
+			After:     nil,
+		})
+		return txErr
+	})
+	if err != nil {
+		return
+	}
+
+	return
+}
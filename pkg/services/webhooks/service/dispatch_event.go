@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/bloom42/stdx-go/db"
+	"github.com/bloom42/stdx-go/guid"
+	"github.com/bloom42/stdx-go/opt"
+	"github.com/bloom42/stdx-go/queue"
+	"markdown.ninja/pkg/services/webhooks"
+)
+
+func (service *WebhooksService) DispatchEvent(ctx context.Context, db db.Queryer, input webhooks.DispatchEventInput) (err error) {
+	endpoints, err := service.repo.FindActiveWebhookEndpointsForEvent(ctx, db, input.WebsiteID, input.Type)
+	if err != nil {
+		return
+	}
+
+	if len(endpoints) == 0 {
+		return
+	}
+
+	now := time.Now().UTC()
+	event := webhooks.Event{
+		ID:        guid.NewTimeBased(),
+		Type:      input.Type,
+		CreatedAt: now,
+		WebsiteID: input.WebsiteID,
+		Data:      input.Data,
+	}
+	payload, err := json.Marshal(event)
+	if err != nil {
+		err = fmt.Errorf("webhooks.DispatchEvent: error encoding event to JSON: %w", err)
+		return
+	}
+
+	for _, endpoint := range endpoints {
+		delivery := webhooks.WebhookDelivery{
+			ID:             guid.NewTimeBased(),
+			CreatedAt:      now,
+			UpdatedAt:      now,
+			EventType:      input.Type,
+			Payload:        payload,
+			Status:         webhooks.DeliveryStatusPending,
+			Attempts:       0,
+			LastAttemptAt:  nil,
+			NextAttemptAt:  &now,
+			ResponseStatus: nil,
+			ResponseBody:   "",
+			Error:          "",
+			Duration:       0,
+			EndpointID:     endpoint.ID,
+			WebsiteID:      endpoint.WebsiteID,
+		}
+		err = service.createDeliveryAndPushJob(ctx, db, delivery)
+		if err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+func (service *WebhooksService) createDeliveryAndPushJob(ctx context.Context, db db.Queryer, delivery webhooks.WebhookDelivery) (err error) {
+	err = service.repo.CreateWebhookDelivery(ctx, db, delivery)
+	if err != nil {
+		return
+	}
+
+	return service.pushDeliveryJob(ctx, db, delivery.ID, delivery.NextAttemptAt)
+}
+
+// pushDeliveryJob enqueues a job to deliver the webhook. Retries are managed by JobDeliverWebhook
+// (and not by the queue) so that attempts are recorded in the delivery log.
+func (service *WebhooksService) pushDeliveryJob(ctx context.Context, db db.Queryer, deliveryID guid.GUID, scheduledFor *time.Time) (err error) {
+	job := queue.NewJobInput{
+		Data: webhooks.JobDeliverWebhook{
+			DeliveryID: deliveryID,
+		},
+		ScheduledFor: scheduledFor,
+		RetryMax:     opt.Int64(0),
+	}
+	err = service.queue.Push(ctx, db, job)
+	if err != nil {
+		err = fmt.Errorf("webhooks: error pushing delivery job to queue: %w", err)
+		return
+	}
+
+	return
+}
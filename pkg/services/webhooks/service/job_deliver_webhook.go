@@ -0,0 +1,129 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/bloom42/stdx-go/db"
+	"github.com/bloom42/stdx-go/log/slogx"
+	"markdown.ninja/pkg/errs"
+	"markdown.ninja/pkg/services/webhooks"
+)
+
+func (service *WebhooksService) JobDeliverWebhook(ctx context.Context, input webhooks.JobDeliverWebhook) (err error) {
+	logger := slogx.FromCtx(ctx)
+
+	delivery, err := service.repo.FindWebhookDeliveryByID(ctx, service.db, input.DeliveryID, false)
+	if err != nil {
+		// the endpoint (and thus its deliveries) may have been deleted since the job was enqueued
+		if errs.IsNotFound(err) {
+			err = nil
+		}
+		return
+	}
+
+	if delivery.Status != webhooks.DeliveryStatusPending {
+		return
+	}
+
+	endpoint, err := service.repo.FindWebhookEndpointByID(ctx, service.db, delivery.EndpointID)
+	if err != nil {
+		if errs.IsNotFound(err) {
+			err = nil
+		}
+		return
+	}
+
+	now := time.Now().UTC()
+	delivery.UpdatedAt = now
+	delivery.LastAttemptAt = &now
+	delivery.Attempts += 1
+
+	if !endpoint.Active {
+		delivery.Status = webhooks.DeliveryStatusFailed
+		delivery.NextAttemptAt = nil
+		delivery.Error = "Endpoint is disabled."
+		err = service.repo.UpdateWebhookDelivery(ctx, service.db, delivery)
+		return
+	}
+
+	deliveryErr := service.sendDelivery(ctx, endpoint, &delivery)
+	if deliveryErr == nil {
+		delivery.Status = webhooks.DeliveryStatusSucceeded
+		delivery.NextAttemptAt = nil
+		delivery.Error = ""
+		err = service.repo.UpdateWebhookDelivery(ctx, service.db, delivery)
+		return
+	}
+
+	delivery.Error = deliveryErr.Error()
+	logger.Debug("webhooks.JobDeliverWebhook: delivery failed", slog.String("delivery.id", delivery.ID.String()),
+		slog.Int64("attempts", delivery.Attempts), slogx.Err(deliveryErr))
+
+	if delivery.Attempts >= webhooks.DeliveryMaxAttempts {
+		delivery.Status = webhooks.DeliveryStatusFailed
+		delivery.NextAttemptAt = nil
+		err = service.repo.UpdateWebhookDelivery(ctx, service.db, delivery)
+		return
+	}
+
+	nextAttemptAt := now.Add(retryDelay(delivery.Attempts))
+	delivery.NextAttemptAt = &nextAttemptAt
+	err = service.db.Transaction(ctx, func(tx db.Tx) (txErr error) {
+		txErr = service.repo.UpdateWebhookDelivery(ctx, tx, delivery)
+		if txErr != nil {
+			return txErr
+		}
+
+		return service.pushDeliveryJob(ctx, tx, delivery.ID, &nextAttemptAt)
+	})
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// sendDelivery sends the signed payload of the delivery to the endpoint and records the response
+// in delivery. An error is returned if the endpoint didn't reply with a 2xx status code.
+func (service *WebhooksService) sendDelivery(ctx context.Context, endpoint webhooks.WebhookEndpoint, delivery *webhooks.WebhookDelivery) (err error) {
+	delivery.ResponseStatus = nil
+	delivery.ResponseBody = ""
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.Url, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return fmt.Errorf("error creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "MarkdownNinja-Webhooks/1.0")
+	req.Header.Set(webhooks.HeaderEventType, string(delivery.EventType))
+	req.Header.Set(webhooks.HeaderDeliveryID, delivery.ID.String())
+	req.Header.Set(webhooks.HeaderSignature, signPayload(endpoint.Secret, time.Now(), delivery.Payload))
+
+	start := time.Now()
+	res, err := service.httpClient.Do(req)
+	delivery.Duration = time.Since(start).Milliseconds()
+	if err != nil {
+		return fmt.Errorf("error sending request: %w", err)
+	}
+	defer res.Body.Close()
+
+	responseStatus := int64(res.StatusCode)
+	delivery.ResponseStatus = &responseStatus
+
+	responseBody, _ := io.ReadAll(io.LimitReader(res.Body, webhooks.DeliveryResponseBodyMaxLength))
+	// PostgreSQL doesn't accept NUL bytes in TEXT columns
+	delivery.ResponseBody = strings.ReplaceAll(strings.ToValidUTF8(string(responseBody), ""), "\x00", "")
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return fmt.Errorf("endpoint replied with status code: %d", res.StatusCode)
+	}
+
+	return nil
+}
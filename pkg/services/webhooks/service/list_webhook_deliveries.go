@@ -0,0 +1,37 @@
+package service
+
+import (
+	"context"
+
+	"markdown.ninja/pkg/errs"
+	"markdown.ninja/pkg/services/kernel"
+	"markdown.ninja/pkg/services/webhooks"
+)
+
+func (service *WebhooksService) ListWebhookDeliveries(ctx context.Context, input webhooks.ListWebhookDeliveriesInput) (ret kernel.PaginatedResult[webhooks.WebhookDelivery], err error) {
+	actorID, err := service.kernel.CurrentUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, input.WebsiteID, kernel.PermissionManageWebsites)
+	if err != nil {
+		return
+	}
+
+	limit := input.Limit
+	if limit < 0 {
+		return ret, errs.InvalidArgument("limit is not valid")
+	} else if limit > 1000 {
+		return ret, errs.InvalidArgument("limit is too high. max: 1000")
+	} else if limit == 0 {
+		limit = 100 // default value
+	}
+
+	ret.Data, err = service.repo.FindWebhookDeliveries(ctx, service.db, input.WebsiteID, input.EndpointID, limit, input.After)
+	if err != nil {
+		return
+	}
+
+	return
+}
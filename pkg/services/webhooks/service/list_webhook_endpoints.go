@@ -0,0 +1,27 @@
+package service
+
+import (
+	"context"
+
+	"markdown.ninja/pkg/services/kernel"
+	"markdown.ninja/pkg/services/webhooks"
+)
+
+func (service *WebhooksService) ListWebhookEndpoints(ctx context.Context, input webhooks.ListWebhookEndpointsInput) (ret kernel.PaginatedResult[webhooks.WebhookEndpoint], err error) {
+	actorID, err := service.kernel.CurrentUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, input.WebsiteID, kernel.PermissionManageWebsites)
+	if err != nil {
+		return
+	}
+
+	ret.Data, err = service.repo.FindWebhookEndpointsForWebsite(ctx, service.db, input.WebsiteID)
+	if err != nil {
+		return
+	}
+
+	return
+}
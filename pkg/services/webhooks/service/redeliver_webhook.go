@@ -0,0 +1,57 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/bloom42/stdx-go/db"
+	"github.com/bloom42/stdx-go/guid"
+	"markdown.ninja/pkg/services/kernel"
+	"markdown.ninja/pkg/services/webhooks"
+)
+
+// RedeliverWebhook sends the payload of a past delivery again, as a new delivery with its own attempts.
+func (service *WebhooksService) RedeliverWebhook(ctx context.Context, input webhooks.RedeliverWebhookInput) (delivery webhooks.WebhookDelivery, err error) {
+	actorID, err := service.kernel.CurrentUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	previousDelivery, err := service.repo.FindWebhookDeliveryByID(ctx, service.db, input.DeliveryID, false)
+	if err != nil {
+		return
+	}
+
+	err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, previousDelivery.WebsiteID, kernel.PermissionManageWebsites)
+	if err != nil {
+		return
+	}
+
+	now := time.Now().UTC()
+	delivery = webhooks.WebhookDelivery{
+		ID:             guid.NewTimeBased(),
+		CreatedAt:      now,
+		UpdatedAt:      now,
+		EventType:      previousDelivery.EventType,
+		Payload:        previousDelivery.Payload,
+		Status:         webhooks.DeliveryStatusPending,
+		Attempts:       0,
+		LastAttemptAt:  nil,
+		NextAttemptAt:  &now,
+		ResponseStatus: nil,
+		ResponseBody:   "",
+		Error:          "",
+		Duration:       0,
+		EndpointID:     previousDelivery.EndpointID,
+		WebsiteID:      previousDelivery.WebsiteID,
+	}
+
+	err = service.db.Transaction(ctx, func(tx db.Tx) (txErr error) {
+		return service.createDeliveryAndPushJob(ctx, tx, delivery)
+	})
+	if err != nil {
+		return
+	}
+
+	return
+}
@@ -0,0 +1,39 @@
+package service
+
+import (
+	"net/http"
+
+	"github.com/bloom42/stdx-go/db"
+	"github.com/bloom42/stdx-go/queue"
+	"markdown.ninja/pkg/services/kernel"
+	"markdown.ninja/pkg/services/organizations"
+	"markdown.ninja/pkg/services/webhooks/repository"
+	"markdown.ninja/pkg/services/websites"
+)
+
+type WebhooksService struct {
+	repo       repository.WebhooksRepository
+	db         db.DB
+	queue      queue.Queue
+	httpClient *http.Client
+
+	kernel               kernel.PrivateService
+	websitesService      websites.Service
+	organizationsService organizations.Service
+}
+
+func NewWebhooksService(db db.DB, queue queue.Queue, kernel kernel.PrivateService, websitesService websites.Service,
+	organizationsService organizations.Service) *WebhooksService {
+	repo := repository.NewWebhooksRepository()
+
+	return &WebhooksService{
+		repo:       repo,
+		db:         db,
+		queue:      queue,
+		httpClient: newWebhooksHttpClient(),
+
+		kernel:               kernel,
+		websitesService:      websitesService,
+		organizationsService: organizationsService,
+	}
+}
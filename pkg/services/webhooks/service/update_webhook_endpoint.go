@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/bloom42/stdx-go/db"
+	"markdown.ninja/pkg/services/kernel"
+	"markdown.ninja/pkg/services/organizations"
+	"markdown.ninja/pkg/services/webhooks"
+)
+
+func (service *WebhooksService) UpdateWebhookEndpoint(ctx context.Context, input webhooks.UpdateWebhookEndpointInput) (endpoint webhooks.WebhookEndpoint, err error) {
+	actorID, err := service.kernel.CurrentUserID(ctx)
+	if err != nil {
+		return
+	}
+
+	endpoint, err = service.repo.FindWebhookEndpointByID(ctx, service.db, input.ID)
+	if err != nil {
+		return
+	}
+	endpointBefore := endpoint
+
+	err = service.websitesService.CheckUserIsStaff(ctx, service.db, actorID, endpoint.WebsiteID, kernel.PermissionManageWebsites)
+	if err != nil {
+		return
+	}
+
+	if input.Url != nil {
+		endpoint.Url, err = service.cleanAndValidateEndpointUrl(*input.Url)
+		if err != nil {
+			return
+		}
+	}
+
+	if input.Description != nil {
+		description := strings.TrimSpace(*input.Description)
+		err = service.validateEndpointDescription(description)
+		if err != nil {
+			return
+		}
+		endpoint.Description = description
+	}
+
+	if input.EventTypes != nil {
+		endpoint.EventTypes, err = service.cleanAndValidateEventTypes(*input.EventTypes)
+		if err != nil {
+			return
+		}
+	}
+
+	if input.Active != nil {
+		endpoint.Active = *input.Active
+	}
+
+	endpoint.UpdatedAt = time.Now().UTC()
+	err = service.db.Transaction(ctx, func(tx db.Tx) (txErr error) {
+		txErr = service.repo.UpdateWebhookEndpoint(ctx, tx, endpoint)
+		if txErr != nil {
+			return txErr
+		}
+
+		txErr = service.organizationsService.RecordAuditLog(ctx, tx, organizations.RecordAuditLogInput{
+			WebsiteID: &endpoint.WebsiteID,
+			Action:    organizations.AuditLogActionWebhookEndpointUpdate,
+			EntityID:  endpoint.ID.String(),
+			Before:    endpointToAuditLogState(endpointBefore),
+			After:     endpointToAuditLogState(endpoint),
+		})
+		return txErr
+	})
+	if err != nil {
+		return
+	}
+
+	return
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"net/netip"
+	"net/url"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"markdown.ninja/pkg/services/webhooks"
+)
+
+func (service *WebhooksService) cleanAndValidateEndpointUrl(input string) (endpointUrl string, err error) {
+	endpointUrl = strings.TrimSpace(input)
+	if len(endpointUrl) > webhooks.WebhookEndpointUrlMaxLength {
+		err = webhooks.ErrWebhookEndpointUrlIsTooLong
+		return
+	}
+
+	parsedUrl, err := url.Parse(endpointUrl)
+	if err != nil || parsedUrl.Scheme != "https" || parsedUrl.Host == "" || parsedUrl.User != nil {
+		err = webhooks.ErrWebhookEndpointUrlIsNotValid
+		return
+	}
+
+	hostname := parsedUrl.Hostname()
+	if hostname == "localhost" || strings.HasSuffix(hostname, ".localhost") {
+		err = webhooks.ErrWebhookEndpointUrlIsNotValid
+		return
+	}
+	if ip, parseIpErr := netip.ParseAddr(hostname); parseIpErr == nil && !isPublicIP(ip) {
+		err = webhooks.ErrWebhookEndpointUrlIsNotValid
+		return
+	}
+
+	return
+}
+
+func (service *WebhooksService) cleanAndValidateEventTypes(input []webhooks.EventType) (eventTypes webhooks.EventTypes, err error) {
+	eventTypes = make(webhooks.EventTypes, 0, len(input))
+	for _, eventType := range input {
+		if !eventType.IsValid() {
+			err = webhooks.ErrEventTypeIsNotValid(eventType)
+			return
+		}
+		if !slices.Contains(eventTypes, eventType) {
+			eventTypes = append(eventTypes, eventType)
+		}
+	}
+
+	if len(eventTypes) == 0 {
+		err = webhooks.ErrWebhookEndpointEventTypesAreEmpty
+		return
+	}
+
+	return
+}
+
+func (service *WebhooksService) validateEndpointDescription(description string) (err error) {
+	if len(description) > webhooks.WebhookEndpointDescriptionMaxLength {
+		return webhooks.ErrWebhookEndpointDescriptionIsTooLong
+	}
+
+	if !utf8.ValidString(description) {
+		return webhooks.ErrWebhookEndpointDescriptionIsNotValid
+	}
+
+	return nil
+}
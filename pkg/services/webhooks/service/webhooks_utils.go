@@ -0,0 +1,161 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"syscall"
+	"time"
+
+	"markdown.ninja/pkg/services/webhooks"
+)
+
+const (
+	webhookSecretSize = 32
+
+	deliveryRetryBaseDelay = time.Minute
+	deliveryRetryMaxDelay  = 6 * time.Hour
+)
+
+var errWebhookDestinationIsPrivate = errors.New("webhooks: destination address is not public")
+
+func generateWebhookSecret() (secret string, err error) {
+	var secretBytes [webhookSecretSize]byte
+
+	_, err = rand.Read(secretBytes[:])
+	if err != nil {
+		err = fmt.Errorf("webhooks: error generating secret: %w", err)
+		return
+	}
+
+	secret = webhooks.WebhookSecretPrefix + hex.EncodeToString(secretBytes[:])
+	return
+}
+
+// signPayload returns the value of the signature header in the form t=<unix timestamp>,v1=<hex signature>
+// where signature = HMAC-SHA256(secret, "<unix timestamp>.<payload>").
+// The timestamp is signed to allow receivers to reject replayed requests.
+func signPayload(secret string, timestamp time.Time, payload []byte) string {
+	timestampStr := strconv.FormatInt(timestamp.Unix(), 10)
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestampStr))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	signature := hex.EncodeToString(mac.Sum(nil))
+
+	return "t=" + timestampStr + ",v1=" + signature
+}
+
+// retryDelay returns the delay before the next attempt after the given number of failed attempts.
+// It grows exponentially: 1m, 2m, 4m... and is capped to deliveryRetryMaxDelay
+func retryDelay(failedAttempts int64) time.Duration {
+	delay := deliveryRetryBaseDelay
+	for i := int64(1); i < failedAttempts; i += 1 {
+		delay *= 2
+		if delay >= deliveryRetryMaxDelay {
+			return deliveryRetryMaxDelay
+		}
+	}
+	return delay
+}
+
+// nonPublicPrefixes are the special-purpose address blocks (RFC 6890) that webhooks must not be able to
+// reach, either because they are internal or because they can be routed to internal addresses.
+var nonPublicPrefixes = []netip.Prefix{
+	// IPv4
+	netip.MustParsePrefix("0.0.0.0/8"),       // "this" network
+	netip.MustParsePrefix("10.0.0.0/8"),      // private
+	netip.MustParsePrefix("100.64.0.0/10"),   // shared address space (carrier-grade NAT)
+	netip.MustParsePrefix("127.0.0.0/8"),     // loopback
+	netip.MustParsePrefix("169.254.0.0/16"),  // link-local, including the cloud metadata endpoints
+	netip.MustParsePrefix("172.16.0.0/12"),   // private
+	netip.MustParsePrefix("192.0.0.0/24"),    // IETF protocol assignments
+	netip.MustParsePrefix("192.0.2.0/24"),    // documentation
+	netip.MustParsePrefix("192.88.99.0/24"),  // 6to4 relay anycast
+	netip.MustParsePrefix("192.168.0.0/16"),  // private
+	netip.MustParsePrefix("198.18.0.0/15"),   // benchmarking
+	netip.MustParsePrefix("198.51.100.0/24"), // documentation
+	netip.MustParsePrefix("203.0.113.0/24"),  // documentation
+	netip.MustParsePrefix("224.0.0.0/4"),     // multicast
+	netip.MustParsePrefix("240.0.0.0/4"),     // reserved and broadcast
+
+	// IPv6
+	netip.MustParsePrefix("::/128"),         // unspecified
+	netip.MustParsePrefix("::1/128"),        // loopback
+	netip.MustParsePrefix("::ffff:0:0/96"),  // IPv4-mapped
+	netip.MustParsePrefix("64:ff9b::/96"),   // IPv4/IPv6 translation
+	netip.MustParsePrefix("64:ff9b:1::/48"), // local IPv4/IPv6 translation
+	netip.MustParsePrefix("100::/64"),       // discard
+	netip.MustParsePrefix("2001::/32"),      // Teredo
+	netip.MustParsePrefix("2001:2::/48"),    // benchmarking
+	netip.MustParsePrefix("2001:db8::/32"),  // documentation
+	netip.MustParsePrefix("2002::/16"),      // 6to4
+	netip.MustParsePrefix("fc00::/7"),       // unique local
+	netip.MustParsePrefix("fe80::/10"),      // link-local
+	netip.MustParsePrefix("fec0::/10"),      // site-local (deprecated)
+	netip.MustParsePrefix("ff00::/8"),       // multicast
+}
+
+func isPublicIP(ip netip.Addr) bool {
+	if !ip.IsValid() {
+		return false
+	}
+	// IPv4-mapped IPv6 addresses are checked as IPv4 addresses, and prefixes never contain zoned addresses
+	ip = ip.Unmap().WithZone("")
+
+	for _, prefix := range nonPublicPrefixes {
+		if prefix.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// newWebhooksHttpClient returns an HTTP client that refuses to connect to private and local addresses
+// so that webhooks can't be used to reach our internal network. The check is done when dialing
+// to be safe against DNS rebinding.
+func newWebhooksHttpClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout: 5 * time.Second,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			addrPort, err := netip.ParseAddrPort(address)
+			if err != nil {
+				return err
+			}
+			if !isPublicIP(addrPort.Addr()) {
+				return errWebhookDestinationIsPrivate
+			}
+			return nil
+		},
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = func(ctx context.Context, network, address string) (net.Conn, error) {
+		return dialer.DialContext(ctx, network, address)
+	}
+
+	return &http.Client{
+		Transport: transport,
+		Timeout:   webhooks.DeliveryTimeout,
+		// redirects are not followed: receivers must reply directly
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// endpointToAuditLogState returns the endpoint without its secret so that it's not saved in the audit logs
+func endpointToAuditLogState(endpoint webhooks.WebhookEndpoint) webhooks.WebhookEndpoint {
+	endpoint.Secret = ""
+	return endpoint
+}
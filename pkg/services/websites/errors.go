@@ -36,6 +36,16 @@ var (
 	ErrAdIsNotValid                  = errs.InvalidArgument("Ad is not valid")
 	ErrAnnouncementIsNotValid        = errs.InvalidArgument("Announcement is not valid")
 	ErrLogoUrlisNotValid             = errs.InvalidArgument("Logo URL is not valid")
+	ErrWebsiteHasTooManyLanguages    = errs.InvalidArgument(fmt.Sprintf("A website can't have more than %d languages", WebsiteMaxLanguages))
+	ErrWebsiteLanguageIsDuplicated   = func(code string) error {
+		return errs.InvalidArgument(fmt.Sprintf("Language \"%s\" is configured multiple times", code))
+	}
+	ErrLanguagePathPrefixIsNotValid = func(prefix string) error {
+		return errs.InvalidArgument(fmt.Sprintf("Path prefix \"%s\" is not valid. It should be a single path segment such as /fr", prefix))
+	}
+	ErrLanguagePathPrefixIsAlreadyInUse = func(prefix string) error {
+		return errs.InvalidArgument(fmt.Sprintf("Path prefix \"%s\" is already in use", prefix))
+	}
 
 	// Staff
 	ErrStaffNotFound      = errs.NotFound("Staff not found")
@@ -47,9 +57,28 @@ var (
 	ErrStaffRoleIsNotValid     = errs.InvalidArgument("Role is not valid.")
 
 	// redirects
-	ErrRedirectStatusIsNotValid      = errs.InvalidArgument(fmt.Sprintf("Redirect status is no. Valid values are [%d, %d]", http.StatusFound, http.StatusMovedPermanently))
+	ErrRedirectStatusIsNotValid = errs.InvalidArgument(fmt.Sprintf("Redirect status is not valid. Valid values are [%d, %d, %d, %d, %d]",
+		http.StatusOK, http.StatusMovedPermanently, http.StatusFound, http.StatusTemporaryRedirect, http.StatusPermanentRedirect))
 	ErrRedirectPatternIsNotValid     = errs.InvalidArgument("Redirect pattern is not valid")
 	ErrRedirectDestinationIsNotValid = errs.InvalidArgument("Redirect destination is not valid")
+	ErrParsingRedirectPattern        = func(pattern string, err error) error {
+		return errs.InvalidArgument(fmt.Sprintf("Redirect pattern is not valid (%s): %s", pattern, err))
+	}
+	ErrRewriteDestinationIsNotValid = func(pattern string) error {
+		return errs.InvalidArgument(fmt.Sprintf("The destination of the rewrite %s must be a path of the website", pattern))
+	}
+	ErrRedirectLoop = func(pattern string) error {
+		return errs.InvalidArgument(fmt.Sprintf("The redirect %s creates a redirect loop", pattern))
+	}
+	ErrRedirectIsShadowed = func(pattern, shadowedBy string) error {
+		return errs.InvalidArgument(fmt.Sprintf("The redirect %s is never used because it is shadowed by %s", pattern, shadowedBy))
+	}
+	ErrRedirectsFormatIsNotValid = errs.InvalidArgument(fmt.Sprintf("Redirects format is not valid. Valid values are [%s, %s, %s]",
+		RedirectsFormatNetlify, RedirectsFormatCloudflareCsv, RedirectsFormatCsv))
+	ErrRedirectsFileIsTooLarge = errs.InvalidArgument(fmt.Sprintf("Redirects file is too large. Max size is %d MB", RedirectsFileMaxSize/1_000_000))
+	ErrParsingRedirectsFile    = func(err error) error {
+		return errs.InvalidArgument(fmt.Sprintf("Error parsing redirects file: %s", err))
+	}
 
 	// Themes
 	ErrOpeningTemplate = func(file string, err error) error {
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"regexp"
+	"strings"
 	"time"
 
 	"github.com/bloom42/stdx-go/guid"
@@ -37,6 +38,13 @@ const (
 
 	RobotsTxtMaxLength = 1500
 
+	RedirectPatternMaxLength     = 1024
+	RedirectDestinationMaxLength = 2048
+	// RedirectPatternMaxSplats limits the backtracking when matching patterns with multiple splats
+	RedirectPatternMaxSplats = 2
+	// RedirectMaxHops is the maximum length of a chain of redirects before it is considered a loop
+	RedirectMaxHops = 10
+
 	TemplateBase     = "base.html"
 	TemplatePosts    = "posts.html"
 	TemplatePage     = "page.html"
@@ -50,12 +58,17 @@ const (
 	PreviewPrefix           = MarkdownNinjaPathPrefix + "/preview/"
 
 	DefaultWebsiteLanguage = "en"
+	// WebsiteMaxLanguages is the maximum number of languages of multilingual websites
+	WebsiteMaxLanguages         = 20
+	LanguagePathPrefixMaxLength = 32
 
 	DefaultTheme = "blog"
 )
 
 var (
 	WebsiteSlugRegexp = regexp.MustCompile("^[a-z0-9-]{3,42}$")
+	// LanguagePathPrefixRegexp matches a single path segment. e.g. /fr or /en-us
+	LanguagePathPrefixRegexp = regexp.MustCompile("^/[a-z0-9-]{1,31}$")
 )
 
 var WebsiteIconSizes = set.NewFromSlice([]int{
@@ -128,6 +141,7 @@ type Website struct {
 	Footer        string            `db:"footer" json:"footer"`
 	Navigation    WebsiteNavigation `db:"navigation" json:"navigation"`
 	Language      string            `db:"language" json:"language"`
+	Languages     WebsiteLanguages  `db:"languages" json:"languages"`
 	PrimaryDomain string            `db:"primary_domain" json:"primary_domain"`
 	Description   string            `db:"description" json:"description"`
 	RobotsTxt     string            `db:"robots_txt" json:"robots_txt"`
@@ -173,6 +187,77 @@ func (item *WebsiteNavigation) Value() (driver.Value, error) {
 	return json.Marshal(item)
 }
 
+// WebsiteLanguage is a language of a multilingual website. The translations of a page are the pages
+// with the same translation key in other languages.
+type WebsiteLanguage struct {
+	// Code is the ISO 639-1 code of the language
+	Code string `json:"code"`
+	// PathPrefix is an optional prefix of the paths of the pages in this language. e.g. /fr
+	PathPrefix string `json:"path_prefix"`
+	// Navigation, if not null, replaces the navigation of the website on the pages in this language
+	Navigation *WebsiteNavigation `json:"navigation"`
+}
+
+type WebsiteLanguages []WebsiteLanguage
+
+func (languages *WebsiteLanguages) Scan(val any) error {
+	switch v := val.(type) {
+	case []byte:
+		return json.Unmarshal(v, languages)
+	case string:
+		return json.Unmarshal([]byte(v), languages)
+	default:
+		return fmt.Errorf("WebsiteLanguages.Scan: Unsupported type: %T", v)
+	}
+}
+
+func (languages WebsiteLanguages) Value() (driver.Value, error) {
+	if languages == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(languages)
+}
+
+// FindLanguage returns the configuration of a language of the website. The default language of the
+// website doesn't need to be configured.
+func (website *Website) FindLanguage(code string) (language WebsiteLanguage, found bool) {
+	for _, language := range website.Languages {
+		if language.Code == code {
+			return language, true
+		}
+	}
+	return WebsiteLanguage{Code: code}, false
+}
+
+// LanguageCodes returns the codes of all the languages of the website, starting with its default language
+func (website *Website) LanguageCodes() []string {
+	codes := make([]string, 1, len(website.Languages)+1)
+	codes[0] = website.Language
+	for _, language := range website.Languages {
+		if language.Code != website.Language {
+			codes = append(codes, language.Code)
+		}
+	}
+	return codes
+}
+
+// FindLanguageForPath returns the language whose path prefix contains path, and the rest of the path.
+// e.g. /fr/feed.xml -> (fr, /feed.xml)
+func (website *Website) FindLanguageForPath(path string) (language WebsiteLanguage, subpath string, found bool) {
+	for _, language := range website.Languages {
+		if language.PathPrefix == "" {
+			continue
+		}
+		if path == language.PathPrefix {
+			return language, "/", true
+		}
+		if subpath, ok := strings.CutPrefix(path, language.PathPrefix+"/"); ok {
+			return language, "/" + subpath, true
+		}
+	}
+	return WebsiteLanguage{}, "", false
+}
+
 type WebsiteNavigationItem struct {
 	Label    string                  `json:"label"` // TODO: rename?
 	Url      *string                 `json:"url,omitempty"`
@@ -208,20 +293,24 @@ func (colors *ThemeColors) Value() (driver.Value, error) {
 	return json.Marshal(colors)
 }
 
-// supported pattern -> To
+// Supported patterns -> To
 // /old -> /new
 // /:year/:month/:post -> /:month/:year/:post
 // /old/* -> /404
-// Not supported yet, but wanted
+// /blog/* -> /:splat
 // /:path* -> /new/:path
 // /*.json -> /json
+// /docs/*/edit -> /edit/:splat
+// /search?q=:query -> /find?term=:query
+// /store?id=:id&ref -> /products/:id
 //
-// inspiration: https://benhoyt.com/writings/go-routing/#split-switch
-// type Redirect struct {
-// 	To     string
-// 	Status int
-// }
-
+// `:name` matches a non-empty part of a single path segment, `*` and `:name*` (named splat) match
+// anything, including slashes. Conditions on the query string are listed after a `?`: `key=:name`
+// captures the value, `key=value` requires the exact value and `key` only requires the parameter
+// to be present.
+//
+// Redirects are matched in order (Position) and the first matching redirect wins.
+// Status 200 is a rewrite: the content of the destination is served under the requested URL.
 // We need to use to_url for db otherwise Postgresql doesn't accept only "to"
 type Redirect struct {
 	ID        guid.GUID `db:"id" json:"id"`
@@ -233,6 +322,10 @@ type Redirect struct {
 	PathPattern string `db:"path_pattern" json:"path_pattern"`
 	To          string `db:"to_url" json:"to"`
 	Status      int64  `db:"status" json:"status"`
+	// QueryPattern is the part of the pattern after the '?'
+	QueryPattern  string `db:"query_pattern" json:"query_pattern"`
+	PreserveQuery bool   `db:"preserve_query" json:"preserve_query"`
+	Position      int64  `db:"position" json:"position"`
 
 	WebsiteID guid.GUID `db:"website_id" json:"-"`
 }
@@ -275,6 +368,8 @@ type UpdateWebsiteInput struct {
 	Footer          *string            `json:"footer"`
 	Slug            *string            `json:"slug"`
 	Navigation      *WebsiteNavigation `json:"navigation"`
+	Language        *string            `json:"language"`
+	Languages       *WebsiteLanguages  `json:"languages"`
 	RobotsTxt       *string            `json:"robots_txt"`
 	Blocked         *bool              `json:"blocked"`
 	Currency        *Currency          `json:"currency"`
@@ -310,10 +405,30 @@ type SaveRedirectsInput struct {
 	Redirects []RedirectInput `json:"redirects"`
 }
 
+type ImportRedirectsInput struct {
+	WebsiteID guid.GUID       `json:"website_id"`
+	Format    RedirectsFormat `json:"format"`
+	Data      string          `json:"data"`
+	// If false, the imported redirects are added after the existing redirects of the website
+	Replace bool `json:"replace"`
+	// DryRun only validates the redirects
+	DryRun bool `json:"dry_run"`
+}
+
+type ImportRedirectsOutput struct {
+	Redirects []Redirect      `json:"redirects"`
+	Report    RedirectsReport `json:"report"`
+	// Saved is false for dry runs or if the report contains errors
+	Saved bool `json:"saved"`
+}
+
 type RedirectInput struct {
 	Pattern string `json:"pattern"`
 	To      string `json:"to"`
-	// Status  int
+	// Defaults to 301
+	Status *int64 `json:"status"`
+	// PreserveQuery appends the query string of the request to the destination
+	PreserveQuery bool `json:"preserve_query"`
 }
 
 // type ParsedTheme struct {
@@ -0,0 +1,451 @@
+package websites
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// RedirectsFormat is the format of redirects files exported by other platforms
+type RedirectsFormat string
+
+const (
+	// RedirectsFormatNetlify is the format of the `_redirects` files of Netlify and Cloudflare Pages
+	// https://docs.netlify.com/routing/redirects
+	RedirectsFormatNetlify RedirectsFormat = "netlify"
+	// RedirectsFormatCloudflareCsv is the CSV format of Cloudflare's bulk redirects
+	// https://developers.cloudflare.com/rules/url-forwarding/bulk-redirects/reference/csv-file-format
+	RedirectsFormatCloudflareCsv RedirectsFormat = "cloudflare_csv"
+	// RedirectsFormatCsv is a CSV file with the from, to, status and preserve_query columns. Only the
+	// first 2 columns are required. The column names of the header (if any) are used to find the columns
+	// so exports of WordPress plugins can be imported as is.
+	RedirectsFormatCsv RedirectsFormat = "csv"
+)
+
+const RedirectsFileMaxSize = 10_000_000 // 10 MB
+
+func (format RedirectsFormat) IsValid() bool {
+	switch format {
+	case RedirectsFormatNetlify, RedirectsFormatCloudflareCsv, RedirectsFormatCsv:
+		return true
+	default:
+		return false
+	}
+}
+
+// RedirectsFormatFromFilename returns the format of a redirects file from its name
+func RedirectsFormatFromFilename(filename string) RedirectsFormat {
+	if strings.ToLower(path.Ext(filename)) == ".csv" {
+		return RedirectsFormatCsv
+	}
+	return RedirectsFormatNetlify
+}
+
+// ImportedRedirect is a redirect parsed from a line of a redirects file
+type ImportedRedirect struct {
+	Line     int
+	Redirect RedirectInput
+}
+
+type RedirectsReport struct {
+	Errors   []RedirectsReportEntry `json:"errors"`
+	Warnings []RedirectsReportEntry `json:"warnings"`
+}
+
+type RedirectsReportEntry struct {
+	// Line is 0 for the existing redirects of the website
+	Line    int    `json:"line"`
+	Pattern string `json:"pattern"`
+	Message string `json:"message"`
+}
+
+func (report *RedirectsReport) AddError(line int, pattern string, err error) {
+	report.Errors = append(report.Errors, RedirectsReportEntry{Line: line, Pattern: pattern, Message: err.Error()})
+}
+
+func (report *RedirectsReport) AddWarning(line int, pattern string, message string) {
+	report.Warnings = append(report.Warnings, RedirectsReportEntry{Line: line, Pattern: pattern, Message: message})
+}
+
+func NewRedirectsReport() RedirectsReport {
+	return RedirectsReport{
+		Errors:   []RedirectsReportEntry{},
+		Warnings: []RedirectsReportEntry{},
+	}
+}
+
+// ParseRedirects parses a redirects file. Invalid lines are skipped and recorded in the report.
+// Only the syntax is checked here, the redirects are validated when saved.
+func ParseRedirects(format RedirectsFormat, data []byte) (redirects []ImportedRedirect, report RedirectsReport, err error) {
+	redirects = []ImportedRedirect{}
+	report = NewRedirectsReport()
+
+	if len(data) > RedirectsFileMaxSize {
+		err = ErrRedirectsFileIsTooLarge
+		return
+	}
+
+	switch format {
+	case RedirectsFormatNetlify:
+		redirects = parseNetlifyRedirects(data, &report)
+	case RedirectsFormatCloudflareCsv:
+		redirects, err = parseCloudflareCsvRedirects(data, &report)
+	case RedirectsFormatCsv:
+		redirects, err = parseCsvRedirects(data, &report)
+	default:
+		err = ErrRedirectsFormatIsNotValid
+	}
+
+	return
+}
+
+func parseNetlifyRedirects(data []byte, report *RedirectsReport) []ImportedRedirect {
+	redirects := []ImportedRedirect{}
+
+	for i, line := range strings.Split(string(data), "\n") {
+		lineNumber := i + 1
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		// comments can also be at the end of a line
+		line, _, _ = strings.Cut(line, " #")
+
+		fields := strings.Fields(line)
+		from := fields[0]
+		fields = fields[1:]
+
+		pattern, err := redirectSourceToPattern(from, report, lineNumber)
+		if err != nil {
+			report.AddError(lineNumber, from, err)
+			continue
+		}
+
+		// query parameters are listed between the source and the destination: /store id=:id /blog/:id
+		queryConditions := []string{}
+		for len(fields) != 0 && strings.Contains(fields[0], "=") && !isRedirectDestination(fields[0]) {
+			queryConditions = append(queryConditions, fields[0])
+			fields = fields[1:]
+		}
+		if len(queryConditions) != 0 {
+			if strings.Contains(pattern, "?") {
+				pattern += "&" + strings.Join(queryConditions, "&")
+			} else {
+				pattern += "?" + strings.Join(queryConditions, "&")
+			}
+		}
+
+		if len(fields) == 0 {
+			report.AddError(lineNumber, pattern, errors.New("destination is missing"))
+			continue
+		}
+		redirect := RedirectInput{
+			Pattern: pattern,
+			To:      fields[0],
+		}
+		fields = fields[1:]
+
+		if len(fields) != 0 {
+			rawStatus := fields[0]
+			if strings.HasSuffix(rawStatus, "!") {
+				rawStatus = strings.TrimSuffix(rawStatus, "!")
+				report.AddWarning(lineNumber, pattern, "'!' is ignored: redirects always have priority over pages")
+			}
+			status, err := strconv.ParseInt(rawStatus, 10, 64)
+			if err != nil {
+				report.AddError(lineNumber, pattern, fmt.Errorf("status is not valid: %s", fields[0]))
+				continue
+			}
+			redirect.Status = &status
+			fields = fields[1:]
+		}
+
+		// country, language, role and cookie conditions
+		if len(fields) != 0 {
+			report.AddError(lineNumber, pattern, fmt.Errorf("conditions are not supported: %s", strings.Join(fields, " ")))
+			continue
+		}
+
+		redirects = append(redirects, ImportedRedirect{Line: lineNumber, Redirect: redirect})
+	}
+
+	return redirects
+}
+
+// parseCloudflareCsvRedirects parses lines of the form:
+// source_url,target_url[,status_code,preserve_query_string,include_subdomains,subpath_matching,preserve_path_suffix]
+func parseCloudflareCsvRedirects(data []byte, report *RedirectsReport) (redirects []ImportedRedirect, err error) {
+	redirects = []ImportedRedirect{}
+
+	records, err := readRedirectsCsv(data)
+	if err != nil {
+		return
+	}
+
+	for _, record := range records {
+		lineNumber := record.Line
+		fields := record.Fields
+		if lineNumber == 1 && strings.EqualFold(fields[0], "source_url") {
+			continue
+		}
+		if len(fields) < 2 {
+			report.AddError(lineNumber, fields[0], errors.New("destination is missing"))
+			continue
+		}
+
+		pattern, err := redirectSourceToPattern(fields[0], report, lineNumber)
+		if err != nil {
+			report.AddError(lineNumber, fields[0], err)
+			continue
+		}
+
+		redirect := RedirectInput{
+			Pattern: pattern,
+			To:      fields[1],
+		}
+		options := make([]bool, 4)
+		if len(fields) > 2 && fields[2] != "" {
+			status, err := strconv.ParseInt(fields[2], 10, 64)
+			if err != nil {
+				report.AddError(lineNumber, pattern, fmt.Errorf("status is not valid: %s", fields[2]))
+				continue
+			}
+			redirect.Status = &status
+		}
+		invalidOption := false
+		for i := 3; i < len(fields) && i < 7; i += 1 {
+			options[i-3], err = parseRedirectsCsvBool(fields[i])
+			if err != nil {
+				report.AddError(lineNumber, pattern, err)
+				invalidOption = true
+				break
+			}
+		}
+		if invalidOption {
+			continue
+		}
+		preserveQueryString, includeSubdomains, subpathMatching, preservePathSuffix := options[0], options[1], options[2], options[3]
+
+		redirect.PreserveQuery = preserveQueryString
+		if includeSubdomains {
+			report.AddWarning(lineNumber, pattern, "include_subdomains is ignored")
+		}
+
+		redirects = append(redirects, ImportedRedirect{Line: lineNumber, Redirect: redirect})
+
+		if subpathMatching {
+			// /old also matches /old/*
+			subpathRedirect := redirect
+			subpathRedirect.Pattern = strings.TrimSuffix(pattern, "/") + "/*"
+			if preservePathSuffix {
+				subpathRedirect.To = strings.TrimSuffix(redirect.To, "/") + "/:splat"
+			}
+			redirects = append(redirects, ImportedRedirect{Line: lineNumber, Redirect: subpathRedirect})
+		}
+	}
+
+	return
+}
+
+// parseCsvRedirects parses CSV files with the from, to, status and preserve_query columns.
+// If the first line is a header, the columns are found by name.
+func parseCsvRedirects(data []byte, report *RedirectsReport) (redirects []ImportedRedirect, err error) {
+	redirects = []ImportedRedirect{}
+
+	records, err := readRedirectsCsv(data)
+	if err != nil || len(records) == 0 {
+		return
+	}
+
+	fromColumn, toColumn, statusColumn, preserveQueryColumn, regexColumn := 0, 1, 2, 3, -1
+	if records[0].Line == 1 && !isRedirectDestination(records[0].Fields[0]) {
+		header := records[0].Fields
+		records = records[1:]
+		fromColumn, toColumn, statusColumn, preserveQueryColumn = -1, -1, -1, -1
+		// the first matching column is used: the exports of the Redirection plugin have both a code column
+		// (the HTTP status) and a status column (enabled / disabled)
+		setColumn := func(column *int, index int) {
+			if *column < 0 {
+				*column = index
+			}
+		}
+		for i, column := range header {
+			switch strings.ToLower(strings.TrimSpace(column)) {
+			case "from", "source", "source_url", "pattern", "old", "old_url", "url":
+				setColumn(&fromColumn, i)
+			case "to", "target", "target_url", "destination", "new", "new_url", "action_data":
+				setColumn(&toColumn, i)
+			case "code", "status_code", "action_code", "status":
+				setColumn(&statusColumn, i)
+			case "preserve_query", "preserve_query_string":
+				setColumn(&preserveQueryColumn, i)
+			case "regex":
+				setColumn(&regexColumn, i)
+			}
+		}
+		if fromColumn < 0 || toColumn < 0 {
+			report.AddError(1, "", errors.New("the header must contain the from and to columns"))
+			return
+		}
+	}
+
+	for _, record := range records {
+		lineNumber := record.Line
+		fields := record.Fields
+		column := func(index int) string {
+			if index < 0 || index >= len(fields) {
+				return ""
+			}
+			return strings.TrimSpace(fields[index])
+		}
+
+		from := column(fromColumn)
+		if from == "" {
+			report.AddError(lineNumber, "", errors.New("source is missing"))
+			continue
+		}
+		if regex, _ := parseRedirectsCsvBool(column(regexColumn)); regex {
+			report.AddError(lineNumber, from, errors.New("regular expressions are not supported"))
+			continue
+		}
+
+		pattern, err := redirectSourceToPattern(from, report, lineNumber)
+		if err != nil {
+			report.AddError(lineNumber, from, err)
+			continue
+		}
+
+		to := column(toColumn)
+		if to == "" {
+			report.AddError(lineNumber, pattern, errors.New("destination is missing"))
+			continue
+		}
+		redirect := RedirectInput{
+			Pattern: pattern,
+			To:      to,
+		}
+
+		if rawStatus := column(statusColumn); rawStatus != "" {
+			status, err := strconv.ParseInt(rawStatus, 10, 64)
+			if err != nil {
+				report.AddError(lineNumber, pattern, fmt.Errorf("status is not valid: %s", rawStatus))
+				continue
+			}
+			redirect.Status = &status
+		}
+
+		redirect.PreserveQuery, err = parseRedirectsCsvBool(column(preserveQueryColumn))
+		if err != nil {
+			report.AddError(lineNumber, pattern, err)
+			continue
+		}
+
+		redirects = append(redirects, ImportedRedirect{Line: lineNumber, Redirect: redirect})
+	}
+
+	return redirects, nil
+}
+
+type redirectsCsvRecord struct {
+	Line   int
+	Fields []string
+}
+
+func readRedirectsCsv(data []byte) (records []redirectsCsvRecord, err error) {
+	records = []redirectsCsvRecord{}
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+
+	for {
+		fields, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, ErrParsingRedirectsFile(err)
+		}
+		line, _ := reader.FieldPos(0)
+		if len(fields) == 0 || (len(fields) == 1 && strings.TrimSpace(fields[0]) == "") {
+			continue
+		}
+		records = append(records, redirectsCsvRecord{Line: line, Fields: fields})
+	}
+
+	return records, nil
+}
+
+func parseRedirectsCsvBool(value string) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "", "false", "0", "no", "n":
+		return false, nil
+	case "true", "1", "yes", "y":
+		return true, nil
+	default:
+		return false, fmt.Errorf("boolean value is not valid: %s", value)
+	}
+}
+
+// redirectSourceToPattern converts the source of a redirect, which can be a path or an URL, to a pattern.
+// The domain is ignored as redirects only apply to the domains of the website.
+func redirectSourceToPattern(source string, report *RedirectsReport, line int) (pattern string, err error) {
+	source = strings.TrimSpace(source)
+	if strings.HasPrefix(source, "/") {
+		return source, nil
+	}
+
+	if !strings.HasPrefix(source, "http://") && !strings.HasPrefix(source, "https://") {
+		// Cloudflare's source URLs don't have a scheme: example.com/path
+		if strings.Contains(source, "/") && !strings.HasPrefix(source, ":") && !strings.HasPrefix(source, "*") {
+			source = "https://" + source
+		} else {
+			return "", errors.New("source must be a path or an URL")
+		}
+	}
+
+	sourceUrl, err := url.Parse(source)
+	if err != nil || sourceUrl.Host == "" {
+		return "", errors.New("source URL is not valid")
+	}
+	report.AddWarning(line, sourceUrl.Path, fmt.Sprintf("domain %s is ignored", sourceUrl.Host))
+
+	pattern = sourceUrl.Path
+	if pattern == "" {
+		pattern = "/"
+	}
+	if sourceUrl.RawQuery != "" {
+		pattern += "?" + sourceUrl.RawQuery
+	}
+	return pattern, nil
+}
+
+func isRedirectDestination(field string) bool {
+	return strings.HasPrefix(field, "/") || strings.HasPrefix(field, "http://") || strings.HasPrefix(field, "https://")
+}
+
+// RedirectInputsFromRedirects converts redirects to inputs, e.g. to append imported redirects to the
+// existing redirects of a website
+func RedirectInputsFromRedirects(redirects []Redirect) []RedirectInput {
+	ret := make([]RedirectInput, len(redirects))
+	for i, redirect := range redirects {
+		status := redirect.Status
+		if status == 0 {
+			status = http.StatusMovedPermanently
+		}
+		ret[i] = RedirectInput{
+			Pattern:       redirect.Pattern,
+			To:            redirect.To,
+			Status:        &status,
+			PreserveQuery: redirect.PreserveQuery,
+		}
+	}
+	return ret
+}
@@ -0,0 +1,123 @@
+package websites
+
+import (
+	"testing"
+)
+
+func TestParseNetlifyRedirects(t *testing.T) {
+	data := `# comment
+/old /new
+/blog/:slug  /posts/:slug  302
+/store id=:id  /products/:id  301!
+/news/*  https://news.example.com/:splat 301 # trailing comment
+https://old.example.com/about /about
+
+/country /fr 302 Country=fr
+/missing
+/status /new abc
+`
+	redirects, report, err := ParseRedirects(RedirectsFormatNetlify, []byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []struct {
+		Line    int
+		Pattern string
+		To      string
+		Status  int64
+	}{
+		{2, "/old", "/new", 0},
+		{3, "/blog/:slug", "/posts/:slug", 302},
+		{4, "/store?id=:id", "/products/:id", 301},
+		{5, "/news/*", "https://news.example.com/:splat", 301},
+		{6, "/about", "/about", 0},
+	}
+	if len(redirects) != len(expected) {
+		t.Fatalf("expected %d redirects, got %d: %#v", len(expected), len(redirects), redirects)
+	}
+	for i, redirect := range redirects {
+		var status int64
+		if redirect.Redirect.Status != nil {
+			status = *redirect.Redirect.Status
+		}
+		if redirect.Line != expected[i].Line || redirect.Redirect.Pattern != expected[i].Pattern ||
+			redirect.Redirect.To != expected[i].To || status != expected[i].Status {
+			t.Errorf("line %d: got %#v (status %d)", expected[i].Line, redirect, status)
+		}
+	}
+
+	errorLines := []int{}
+	for _, entry := range report.Errors {
+		errorLines = append(errorLines, entry.Line)
+	}
+	if len(errorLines) != 3 || errorLines[0] != 8 || errorLines[1] != 9 || errorLines[2] != 10 {
+		t.Errorf("unexpected errors: %#v", report.Errors)
+	}
+	// '!' and the domain
+	if len(report.Warnings) != 2 {
+		t.Errorf("unexpected warnings: %#v", report.Warnings)
+	}
+}
+
+func TestParseCloudflareCsvRedirects(t *testing.T) {
+	data := "source_url,target_url,status_code,preserve_query_string,include_subdomains,subpath_matching,preserve_path_suffix\n" +
+		"example.com/old,https://example.com/new,302,true,false,false,false\n" +
+		"example.com/docs,https://docs.example.com/,301,false,false,true,true\n" +
+		"example.com/bad,https://example.com/,301,maybe\n"
+
+	redirects, report, err := ParseRedirects(RedirectsFormatCloudflareCsv, []byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(redirects) != 3 {
+		t.Fatalf("expected 3 redirects, got %d: %#v", len(redirects), redirects)
+	}
+	if redirects[0].Redirect.Pattern != "/old" || !redirects[0].Redirect.PreserveQuery || *redirects[0].Redirect.Status != 302 {
+		t.Errorf("unexpected redirect: %#v", redirects[0])
+	}
+	if redirects[1].Redirect.Pattern != "/docs" || redirects[1].Redirect.To != "https://docs.example.com/" {
+		t.Errorf("unexpected redirect: %#v", redirects[1])
+	}
+	if redirects[2].Line != 3 || redirects[2].Redirect.Pattern != "/docs/*" || redirects[2].Redirect.To != "https://docs.example.com/:splat" {
+		t.Errorf("unexpected subpath redirect: %#v", redirects[2])
+	}
+	if len(report.Errors) != 1 || report.Errors[0].Line != 4 {
+		t.Errorf("unexpected errors: %#v", report.Errors)
+	}
+}
+
+func TestParseCsvRedirects(t *testing.T) {
+	// export of the WordPress Redirection plugin
+	data := "source,target,regex,code,type,hits,title,status\n" +
+		"/?p=42,/hello-world,0,301,url,3,,enabled\n" +
+		"\"/2020/01/post\",https://example.com/post,false,308,url,0,,enabled\n" +
+		"^/category/(.*)$,/tags/$1,1,301,url,0,,enabled\n"
+
+	redirects, report, err := ParseRedirects(RedirectsFormatCsv, []byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(redirects) != 2 {
+		t.Fatalf("expected 2 redirects, got %d: %#v", len(redirects), redirects)
+	}
+	if redirects[0].Redirect.Pattern != "/?p=42" || redirects[0].Redirect.To != "/hello-world" || *redirects[0].Redirect.Status != 301 {
+		t.Errorf("unexpected redirect: %#v", redirects[0])
+	}
+	if redirects[1].Line != 3 || *redirects[1].Redirect.Status != 308 {
+		t.Errorf("unexpected redirect: %#v", redirects[1])
+	}
+	if len(report.Errors) != 1 || report.Errors[0].Line != 4 {
+		t.Errorf("unexpected errors: %#v", report.Errors)
+	}
+
+	// without header
+	redirects, _, err = ParseRedirects(RedirectsFormatCsv, []byte("/a,/b\n/c,/d,302,true\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(redirects) != 2 || redirects[1].Redirect.Pattern != "/c" || !redirects[1].Redirect.PreserveQuery {
+		t.Errorf("unexpected redirects: %#v", redirects)
+	}
+}
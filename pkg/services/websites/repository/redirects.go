@@ -11,11 +11,13 @@ import (
 
 func (repo *WebsitesRepository) CreateRedirect(ctx context.Context, db db.Queryer, redirect websites.Redirect) (err error) {
 	const query = `INSERT INTO redirects
-			(id, created_at, updated_at, pattern, domain, path_pattern, to_url, status, website_id)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+			(id, created_at, updated_at, pattern, domain, path_pattern, to_url, status, query_pattern,
+			preserve_query, position, website_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`
 
 	_, err = db.Exec(ctx, query, redirect.ID, redirect.CreatedAt, redirect.UpdatedAt,
 		redirect.Pattern, redirect.Domain, redirect.PathPattern, redirect.To, redirect.Status,
+		redirect.QueryPattern, redirect.PreserveQuery, redirect.Position,
 		redirect.WebsiteID)
 	if err != nil {
 		err = fmt.Errorf("websites.CreateRedirect: %w", err)
@@ -25,11 +27,26 @@ func (repo *WebsitesRepository) CreateRedirect(ctx context.Context, db db.Querye
 	return
 }
 
+func (repo *WebsitesRepository) UpdateRedirect(ctx context.Context, db db.Queryer, redirect websites.Redirect) (err error) {
+	const query = `UPDATE redirects
+		SET updated_at = $1, to_url = $2, status = $3, preserve_query = $4, position = $5
+		WHERE id = $6`
+
+	_, err = db.Exec(ctx, query, redirect.UpdatedAt, redirect.To, redirect.Status, redirect.PreserveQuery,
+		redirect.Position, redirect.ID)
+	if err != nil {
+		err = fmt.Errorf("websites.UpdateRedirect: %w", err)
+		return
+	}
+
+	return
+}
+
 func (repo *WebsitesRepository) FindRedirectsForWebsite(ctx context.Context, db db.Queryer, websiteID guid.GUID) (redirects []websites.Redirect, err error) {
 	redirects = []websites.Redirect{}
 	const query = `SELECT * FROM redirects
 		WHERE website_id = $1
-		ORDER BY created_at DESC
+		ORDER BY position, created_at DESC
 		`
 
 	err = db.Select(ctx, &redirects, query, websiteID)
@@ -13,7 +13,7 @@ import (
 
 type Service interface {
 	// Utils
-	CheckUserIsStaff(ctx context.Context, db db.Queryer, userID uuid.UUID, websiteID guid.GUID) (err error)
+	CheckUserIsStaff(ctx context.Context, db db.Queryer, userID uuid.UUID, websiteID guid.GUID, permission kernel.Permission) (err error)
 
 	// Websites
 	FindWebsiteByDomain(ctx context.Context, db db.Queryer, domain string) (website Website, err error)
@@ -33,8 +33,9 @@ type Service interface {
 
 	// Redirects
 	SaveRedirects(ctx context.Context, input SaveRedirectsInput) (redirects []Redirect, err error)
+	ImportRedirects(ctx context.Context, input ImportRedirectsInput) (output ImportRedirectsOutput, err error)
 	FindRedirects(ctx context.Context, db db.Queryer, websiteID guid.GUID) (redirects []Redirect, err error)
-	MatchRedirect(ctx context.Context, domain, path string, redirects []Redirect) *Redirect
+	MatchRedirect(ctx context.Context, domain, path, rawQuery string, redirects []Redirect) *Redirect
 
 	// Domains
 	AddDomain(ctx context.Context, input AddDomainInput) (domain Domain, err error)
@@ -9,6 +9,7 @@ import (
 	"github.com/bloom42/stdx-go/guid"
 	"markdown.ninja/pkg/errs"
 	"markdown.ninja/pkg/server/httpctx"
+	"markdown.ninja/pkg/services/kernel"
 	"markdown.ninja/pkg/services/organizations"
 	"markdown.ninja/pkg/services/websites"
 )
@@ -28,7 +29,7 @@ func (service *WebsitesService) AddDomain(ctx context.Context, input websites.Ad
 		return
 	}
 
-	_, err = service.organizationsService.CheckUserIsStaff(ctx, service.db, actorID, website.OrganizationID)
+	err = service.checkUserPermissionForWebsite(ctx, service.db, actorID, website, kernel.PermissionManageWebsites)
 	if err != nil {
 		return
 	}
@@ -88,6 +89,14 @@ func (service *WebsitesService) AddDomain(ctx context.Context, input websites.Ad
 			}
 		}
 
+		txErr = service.organizationsService.RecordAuditLog(ctx, tx, organizations.RecordAuditLogInput{
+			OrganizationID: website.OrganizationID,
+			WebsiteID:      &website.ID,
+			Action:         organizations.AuditLogActionDomainAdd,
+			EntityID:       domain.ID.String(),
+			Before:         nil,
+			After:          domain,
+		})
 		return txErr
 	})
 	if err != nil {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"markdown.ninja/pkg/services/kernel"
 	"markdown.ninja/pkg/services/websites"
 )
 
@@ -23,7 +24,7 @@ func (service *WebsitesService) CheckTlsCertificateForDomain(ctx context.Context
 		return
 	}
 
-	_, err = service.organizationsService.CheckUserIsStaff(ctx, service.db, actorID, website.OrganizationID)
+	err = service.checkUserPermissionForWebsite(ctx, service.db, actorID, website, kernel.PermissionManageWebsites)
 	if err != nil {
 		return
 	}
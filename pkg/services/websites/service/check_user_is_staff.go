@@ -6,15 +6,31 @@ import (
 	"github.com/bloom42/stdx-go/db"
 	"github.com/bloom42/stdx-go/guid"
 	"github.com/bloom42/stdx-go/uuid"
+	"markdown.ninja/pkg/services/kernel"
+	"markdown.ninja/pkg/services/websites"
 )
 
-func (service *WebsitesService) CheckUserIsStaff(ctx context.Context, db db.Queryer, userID uuid.UUID, websiteID guid.GUID) (err error) {
+func (service *WebsitesService) CheckUserIsStaff(ctx context.Context, db db.Queryer, userID uuid.UUID, websiteID guid.GUID, permission kernel.Permission) (err error) {
 	// we don't use a join to keep the separation of concerns (avoid mixing tables between services)
 	website, err := service.repo.FindWebsiteByID(ctx, db, websiteID, false)
 	if err != nil {
 		return err
 	}
 
-	_, err = service.organizationsService.CheckUserIsStaff(ctx, db, userID, website.OrganizationID)
-	return err
+	return service.checkUserPermissionForWebsite(ctx, db, userID, website, permission)
+}
+
+// checkUserPermissionForWebsite verifies that the user is a staff of the organization of the website
+// and that their role (and website scope) allows them to perform the action.
+func (service *WebsitesService) checkUserPermissionForWebsite(ctx context.Context, db db.Queryer, userID uuid.UUID, website websites.Website, permission kernel.Permission) (err error) {
+	staff, err := service.organizationsService.CheckUserIsStaff(ctx, db, userID, website.OrganizationID)
+	if err != nil {
+		return err
+	}
+
+	if !staff.CanOnWebsite(website.ID, permission) {
+		return kernel.ErrPermissionDenied
+	}
+
+	return nil
 }
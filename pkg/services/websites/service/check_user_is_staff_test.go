@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bloom42/stdx-go/db"
+	"github.com/bloom42/stdx-go/guid"
+	"github.com/bloom42/stdx-go/uuid"
+	"markdown.ninja/pkg/services/kernel"
+	"markdown.ninja/pkg/services/organizations"
+	"markdown.ninja/pkg/services/websites"
+)
+
+// staffOrganizationsService is a fake organizations.Service that only implements CheckUserIsStaff
+type staffOrganizationsService struct {
+	organizations.Service
+	staffs map[uuid.UUID]organizations.Staff
+}
+
+func (service staffOrganizationsService) CheckUserIsStaff(ctx context.Context, db db.Queryer, userID uuid.UUID, organizationID guid.GUID) (staff organizations.Staff, err error) {
+	staff, ok := service.staffs[userID]
+	if !ok || !staff.OrganizationID.Equal(organizationID) {
+		return staff, kernel.ErrPermissionDenied
+	}
+	return staff, nil
+}
+
+func TestCheckUserPermissionForWebsite(t *testing.T) {
+	organizationID := guid.NewTimeBased()
+	website := websites.Website{ID: guid.NewTimeBased(), OrganizationID: organizationID}
+	otherWebsiteID := guid.NewTimeBased()
+
+	editorID := uuid.NewV4()
+	websiteEditorID := uuid.NewV4()
+	otherWebsiteEditorID := uuid.NewV4()
+	viewerID := uuid.NewV4()
+	otherOrganizationOwnerID := uuid.NewV4()
+	notStaffID := uuid.NewV4()
+
+	service := &WebsitesService{
+		organizationsService: staffOrganizationsService{
+			staffs: map[uuid.UUID]organizations.Staff{
+				editorID: {Role: organizations.StaffRoleEditor, OrganizationID: organizationID},
+				websiteEditorID: {Role: organizations.StaffRoleEditor, OrganizationID: organizationID,
+					WebsiteID: &website.ID},
+				otherWebsiteEditorID: {Role: organizations.StaffRoleEditor, OrganizationID: organizationID,
+					WebsiteID: &otherWebsiteID},
+				viewerID:                 {Role: organizations.StaffRoleViewer, OrganizationID: organizationID},
+				otherOrganizationOwnerID: {Role: organizations.StaffRoleOwner, OrganizationID: guid.NewTimeBased()},
+			},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		userID     uuid.UUID
+		permission kernel.Permission
+		allowed    bool
+	}{
+		{"organization-wide editor can write content", editorID, kernel.PermissionWriteContent, true},
+		{"organization-wide editor can't manage the store", editorID, kernel.PermissionManageStore, false},
+		{"website-scoped editor can write content", websiteEditorID, kernel.PermissionWriteContent, true},
+		{"website-scoped editor can't manage the store", websiteEditorID, kernel.PermissionManageStore, false},
+		{"editor scoped to another website can't read", otherWebsiteEditorID, kernel.PermissionRead, false},
+		{"viewer can read", viewerID, kernel.PermissionRead, true},
+		{"viewer can't write content", viewerID, kernel.PermissionWriteContent, false},
+		{"owner of another organization can't read", otherOrganizationOwnerID, kernel.PermissionRead, false},
+		{"not a staff", notStaffID, kernel.PermissionRead, false},
+	}
+
+	for _, test := range tests {
+		err := service.checkUserPermissionForWebsite(context.Background(), nil, test.userID, website, test.permission)
+		if test.allowed && err != nil {
+			t.Errorf("%s: unexpected error: %s", test.name, err)
+		} else if !test.allowed && !errors.Is(err, kernel.ErrPermissionDenied) {
+			t.Errorf("%s: expected permission denied, got: %v", test.name, err)
+		}
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/bloom42/stdx-go/guid"
 	"markdown.ninja/pkg/errs"
 	"markdown.ninja/pkg/server/httpctx"
+	"markdown.ninja/pkg/services/kernel"
 	"markdown.ninja/pkg/services/organizations"
 	"markdown.ninja/pkg/services/websites"
 )
@@ -21,11 +22,16 @@ func (service *WebsitesService) CreateWebsite(ctx context.Context, input website
 		return
 	}
 
-	_, err = service.organizationsService.CheckUserIsStaff(ctx, service.db, actorID, input.OrganizationID)
+	staff, err := service.organizationsService.CheckUserIsStaff(ctx, service.db, actorID, input.OrganizationID)
 	if err != nil {
 		return
 	}
 
+	if !staff.Can(kernel.PermissionManageWebsites) {
+		err = kernel.ErrPermissionDenied
+		return
+	}
+
 	name := strings.TrimSpace(input.Name)
 	slug := strings.TrimSpace(input.Slug)
 
@@ -82,6 +88,7 @@ func (service *WebsitesService) CreateWebsite(ctx context.Context, input website
 			Footer:         "",
 			Navigation:     websites.DefaultWebsiteNavigation,
 			Language:       websites.DefaultWebsiteLanguage,
+			Languages:      websites.WebsiteLanguages{},
 			PrimaryDomain:  service.getSubdomainForSlug(slug),
 			Description:    "",
 			RobotsTxt:      websites.DefaultRobotsTxt,
@@ -107,6 +114,18 @@ func (service *WebsitesService) CreateWebsite(ctx context.Context, input website
 		}
 
 		_, txErr = service.emailsService.InitWebsiteConfiguration(ctx, tx, website.ID, website.Name)
+		if txErr != nil {
+			return txErr
+		}
+
+		txErr = service.organizationsService.RecordAuditLog(ctx, tx, organizations.RecordAuditLogInput{
+			OrganizationID: website.OrganizationID,
+			WebsiteID:      &website.ID,
+			Action:         organizations.AuditLogActionWebsiteCreate,
+			EntityID:       website.ID.String(),
+			Before:         nil,
+			After:          website,
+		})
 		return txErr
 	})
 	if err != nil {
@@ -4,6 +4,8 @@ import (
 	"context"
 
 	"github.com/bloom42/stdx-go/db"
+	"markdown.ninja/pkg/services/kernel"
+	"markdown.ninja/pkg/services/organizations"
 	"markdown.ninja/pkg/services/websites"
 )
 
@@ -18,7 +20,7 @@ func (service *WebsitesService) DeleteWebsite(ctx context.Context, input website
 		return
 	}
 
-	_, err = service.organizationsService.CheckUserIsStaff(ctx, service.db, actorID, website.OrganizationID)
+	err = service.checkUserPermissionForWebsite(ctx, service.db, actorID, website, kernel.PermissionManageWebsites)
 	if err != nil {
 		return
 	}
@@ -50,6 +52,18 @@ func (service *WebsitesService) DeleteWebsite(ctx context.Context, input website
 		}
 
 		txErr = service.repo.DeleteWebsite(ctx, tx, website.ID)
+		if txErr != nil {
+			return txErr
+		}
+
+		txErr = service.organizationsService.RecordAuditLog(ctx, tx, organizations.RecordAuditLogInput{
+			OrganizationID: website.OrganizationID,
+			WebsiteID:      &website.ID,
+			Action:         organizations.AuditLogActionWebsiteDelete,
+			EntityID:       website.ID.String(),
+			Before:         website,
+			After:          nil,
+		})
 		return txErr
 	})
 	if err != nil {
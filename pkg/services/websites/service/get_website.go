@@ -5,6 +5,8 @@ import (
 	"time"
 
 	"markdown.ninja/pkg/server/httpctx"
+	"markdown.ninja/pkg/services/kernel"
+	"markdown.ninja/pkg/services/organizations"
 	"markdown.ninja/pkg/services/websites"
 )
 
@@ -17,7 +19,7 @@ func (service *WebsitesService) GetWebsite(ctx context.Context, input websites.G
 			return websites.Website{}, err
 		}
 
-		_, err = service.organizationsService.CheckCurrentApiKey(ctx, website.OrganizationID)
+		_, err = service.organizationsService.CheckCurrentApiKey(ctx, website.OrganizationID, website.ID, organizations.ApiKeyScopeWebsitesRead)
 		if err != nil {
 			return websites.Website{}, err
 		}
@@ -33,7 +35,7 @@ func (service *WebsitesService) GetWebsite(ctx context.Context, input websites.G
 		}
 
 		if !httpCtx.AccessToken.IsAdmin {
-			_, err = service.organizationsService.CheckUserIsStaff(ctx, service.db, actorID, website.OrganizationID)
+			err = service.checkUserPermissionForWebsite(ctx, service.db, actorID, website, kernel.PermissionRead)
 			if err != nil {
 				return websites.Website{}, err
 			}
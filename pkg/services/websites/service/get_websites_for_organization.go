@@ -2,10 +2,12 @@ package service
 
 import (
 	"context"
+	"slices"
 
 	"github.com/bloom42/stdx-go/guid"
 	"markdown.ninja/pkg/errs"
 	"markdown.ninja/pkg/server/httpctx"
+	"markdown.ninja/pkg/services/organizations"
 	"markdown.ninja/pkg/services/websites"
 )
 
@@ -13,6 +15,8 @@ func (service *WebsitesService) GetWebsitesForOrganization(ctx context.Context,
 	sites = make([]websites.Website, 0)
 	httpCtx := httpctx.FromCtx(ctx)
 	var organizationID guid.GUID
+	// staffs scoped to a single website can only see this website
+	var scopedWebsiteID *guid.GUID
 
 	if httpCtx.ApiKey != nil {
 		organizationID = httpCtx.ApiKey.OrganizationID
@@ -28,13 +32,30 @@ func (service *WebsitesService) GetWebsitesForOrganization(ctx context.Context,
 		organizationID = *input.OrganizationID
 
 		if !httpCtx.AccessToken.IsAdmin {
-			_, err = service.organizationsService.CheckUserIsStaff(ctx, service.db, actorID, organizationID)
+			staff, err := service.organizationsService.CheckUserIsStaff(ctx, service.db, actorID, organizationID)
 			if err != nil {
 				return sites, err
 			}
+			scopedWebsiteID = staff.WebsiteID
 		}
 	}
 
 	sites, err = service.repo.FindWebsitesForOrganization(ctx, service.db, organizationID)
+	if err != nil {
+		return
+	}
+
+	if scopedWebsiteID != nil {
+		sites = slices.DeleteFunc(sites, func(website websites.Website) bool {
+			return website.ID != *scopedWebsiteID
+		})
+	}
+
+	if httpCtx.ApiKey != nil {
+		sites = slices.DeleteFunc(sites, func(website websites.Website) bool {
+			return !httpCtx.ApiKey.Can(website.ID, organizations.ApiKeyScopeWebsitesRead)
+		})
+	}
+
 	return
 }
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"markdown.ninja/pkg/services/websites"
+)
+
+// ImportRedirects parses a redirects file exported from another platform and saves the redirects if
+// they are all valid. The errors are returned in the report of the output, with the line they come from.
+func (service *WebsitesService) ImportRedirects(ctx context.Context, input websites.ImportRedirectsInput) (output websites.ImportRedirectsOutput, err error) {
+	output = websites.ImportRedirectsOutput{
+		Redirects: []websites.Redirect{},
+		Report:    websites.NewRedirectsReport(),
+	}
+
+	website, err := service.findWebsiteForRedirectsUpdate(ctx, input.WebsiteID)
+	if err != nil {
+		return
+	}
+
+	importedRedirects, report, err := websites.ParseRedirects(input.Format, []byte(input.Data))
+	if err != nil {
+		return
+	}
+	output.Report = report
+
+	// the existing redirects have the line 0 and are kept first
+	redirectInputs := []websites.ImportedRedirect{}
+	if !input.Replace {
+		existingRedirects, err := service.repo.FindRedirectsForWebsite(ctx, service.db, website.ID)
+		if err != nil {
+			return output, err
+		}
+		for _, redirectInput := range websites.RedirectInputsFromRedirects(existingRedirects) {
+			redirectInputs = append(redirectInputs, websites.ImportedRedirect{Line: 0, Redirect: redirectInput})
+		}
+	}
+	redirectInputs = append(redirectInputs, importedRedirects...)
+
+	now := time.Now().UTC()
+	newRedirects := make([]websites.Redirect, 0, len(redirectInputs))
+	lines := make([]int, 0, len(redirectInputs))
+	for _, redirectInput := range redirectInputs {
+		redirect, validationErr := redirectFromInput(website.ID, redirectInput.Redirect, len(newRedirects), now)
+		if validationErr != nil {
+			output.Report.AddError(redirectInput.Line, redirectInput.Redirect.Pattern, validationErr)
+			continue
+		}
+		newRedirects = append(newRedirects, redirect)
+		lines = append(lines, redirectInput.Line)
+	}
+
+	rulesErrors := findRedirectRulesErrors(newRedirects)
+	for index, redirect := range newRedirects {
+		if ruleErr, hasError := rulesErrors[index]; hasError {
+			output.Report.AddError(lines[index], redirect.Pattern, ruleErr)
+		}
+	}
+
+	slices.SortStableFunc(output.Report.Errors, func(a, b websites.RedirectsReportEntry) int {
+		return a.Line - b.Line
+	})
+
+	if input.DryRun || len(output.Report.Errors) != 0 {
+		return
+	}
+
+	output.Redirects, err = service.replaceRedirects(ctx, website, newRedirects)
+	if err != nil {
+		return
+	}
+	output.Saved = true
+
+	return
+}
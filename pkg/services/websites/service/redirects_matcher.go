@@ -0,0 +1,134 @@
+package service
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"time"
+
+	"markdown.ninja/pkg/services/websites"
+)
+
+const redirectsMatchersCacheTTL = time.Hour
+
+// redirectsMatcher indexes redirects in a prefix trie built from the literal prefix of their patterns
+// (the part before the first wildcard) so that only the few redirects that can match a path are
+// tested instead of all the redirects of the website.
+type redirectsMatcher struct {
+	redirects []websites.Redirect
+	patterns  []redirectPattern
+	root      *redirectsTrieNode
+}
+
+type redirectsTrieNode struct {
+	children map[byte]*redirectsTrieNode
+	// indexes of the redirects whose literal prefix ends at this node
+	redirects []int
+}
+
+// newRedirectsMatcher builds a matcher for redirects sorted by position. Redirects with an invalid
+// pattern never match.
+func newRedirectsMatcher(redirects []websites.Redirect) *redirectsMatcher {
+	matcher := &redirectsMatcher{
+		redirects: redirects,
+		patterns:  make([]redirectPattern, len(redirects)),
+		root:      &redirectsTrieNode{},
+	}
+
+	for i, redirect := range redirects {
+		pattern, err := parseRedirectPattern(redirect.PathPattern, redirect.QueryPattern)
+		if err != nil {
+			continue
+		}
+		matcher.patterns[i] = pattern
+
+		prefix := redirectPatternLiteralPrefix(pattern)
+		node := matcher.root
+		for j := 0; j < len(prefix); j += 1 {
+			child := node.children[prefix[j]]
+			if child == nil {
+				if node.children == nil {
+					node.children = make(map[byte]*redirectsTrieNode, 1)
+				}
+				child = &redirectsTrieNode{}
+				node.children[prefix[j]] = child
+			}
+			node = child
+		}
+		node.redirects = append(node.redirects, i)
+	}
+
+	return matcher
+}
+
+func redirectPatternLiteralPrefix(pattern redirectPattern) string {
+	if len(pattern.Path) != 0 && pattern.Path[0].Type == redirectTokenLiteral {
+		return pattern.Path[0].Value
+	}
+	return ""
+}
+
+// candidates returns, in increasing order, the indexes of the redirects whose literal prefix is a
+// prefix of path.
+func (matcher *redirectsMatcher) candidates(path string) []int {
+	candidates := []int{}
+	node := matcher.root
+	for i := 0; node != nil; i += 1 {
+		candidates = append(candidates, node.redirects...)
+		if i == len(path) {
+			break
+		}
+		node = node.children[path[i]]
+	}
+	slices.Sort(candidates)
+	return candidates
+}
+
+// match returns the index of the first redirect matching the request and its destination, or -1
+func (matcher *redirectsMatcher) match(domain, path, rawQuery string) (index int, destination string) {
+	// the query string is parsed only if a redirect has conditions on it
+	var query url.Values
+
+	for _, candidate := range matcher.candidates(path) {
+		redirect := matcher.redirects[candidate]
+		if redirect.Domain != "" && redirect.Domain != domain {
+			continue
+		}
+
+		pattern := matcher.patterns[candidate]
+		if len(pattern.Query) != 0 && query == nil {
+			query, _ = url.ParseQuery(rawQuery)
+		}
+
+		matched, destination := matchRedirectPattern(pattern, path, query, redirect.To)
+		if matched {
+			if redirect.PreserveQuery {
+				destination = appendQueryToDestination(destination, rawQuery)
+			}
+			return candidate, destination
+		}
+	}
+
+	return -1, ""
+}
+
+// getRedirectsMatcher returns the matcher of the redirects of a website from the cache, or builds it.
+// The redirects are loaded from the database for each request, so the key of the cache changes as soon
+// as a redirect is created, updated or deleted, even if it was saved by another instance.
+func (service *WebsitesService) getRedirectsMatcher(redirects []websites.Redirect) *redirectsMatcher {
+	var lastUpdatedAt time.Time
+	for _, redirect := range redirects {
+		if redirect.UpdatedAt.After(lastUpdatedAt) {
+			lastUpdatedAt = redirect.UpdatedAt
+		}
+	}
+	cacheKey := fmt.Sprintf("%s-%d-%d", redirects[0].WebsiteID.String(), len(redirects), lastUpdatedAt.UnixNano())
+
+	if cachedMatcher := service.redirectsMatchersCache.Get(cacheKey); cachedMatcher != nil {
+		return cachedMatcher.Value()
+	}
+
+	matcher := newRedirectsMatcher(redirects)
+	service.redirectsMatchersCache.Set(cacheKey, matcher, redirectsMatchersCacheTTL)
+	return matcher
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"fmt"
+	"net/url"
+	"testing"
+
+	"markdown.ninja/pkg/services/websites"
+)
+
+func newTestRedirects(patterns [][2]string) []websites.Redirect {
+	redirects := make([]websites.Redirect, len(patterns))
+	for i, pattern := range patterns {
+		pathPattern, queryPattern := splitRedirectPattern(pattern[0])
+		redirects[i] = websites.Redirect{
+			Pattern:      pattern[0],
+			PathPattern:  pathPattern,
+			QueryPattern: queryPattern,
+			To:           pattern[1],
+			Status:       301,
+			Position:     int64(i),
+		}
+	}
+	return redirects
+}
+
+func TestRedirectsMatcherMatchesLikeLinearScan(t *testing.T) {
+	patterns := [][2]string{
+		{"/blog/hello", "/hello"},
+		{"/index.php?p=:id", "/posts/:id"},
+		{"/blog/:slug.html", "/:slug"},
+		{"/blog/*", "/:splat"},
+		{"/b", "/c"},
+		{"/*.json", "/json"},
+		{"/docs/*/edit", "/edit/:splat"},
+	}
+	for i := range 1000 {
+		patterns = append(patterns, [2]string{fmt.Sprintf("/old/%d", i), fmt.Sprintf("/new/%d", i)})
+	}
+	redirects := newTestRedirects(patterns)
+	matcher := newRedirectsMatcher(redirects)
+
+	paths := []string{"/blog/hello", "/blog/world.html", "/blog/a/b", "/b", "/bb", "/data.json", "/docs/x/edit",
+		"/index.php?p=1", "/index.php", "/old/999", "/old/1000", "/", ""}
+	for _, requestUrl := range paths {
+		path, rawQuery := splitRedirectPattern(requestUrl)
+		query, _ := url.ParseQuery(rawQuery)
+
+		expectedIndex, expectedDestination := -1, ""
+		for i, redirect := range redirects {
+			matched, destination := matchRedirectPattern(matcher.patterns[i], path, query, redirect.To)
+			if matched {
+				expectedIndex, expectedDestination = i, destination
+				break
+			}
+		}
+
+		index, destination := matcher.match("", path, rawQuery)
+		if index != expectedIndex || destination != expectedDestination {
+			t.Errorf("%s: got (%d, %s), want (%d, %s)", requestUrl, index, destination, expectedIndex, expectedDestination)
+		}
+	}
+}
+
+func TestValidateLargeRedirectRules(t *testing.T) {
+	patterns := [][2]string{}
+	for i := range 10_000 {
+		patterns = append(patterns, [2]string{fmt.Sprintf("/%d/:slug", i), fmt.Sprintf("/posts/%d/:slug", i)})
+	}
+	patterns = append(patterns, [2]string{"/42/hello", "/hello"})
+
+	rulesErrors := findRedirectRulesErrors(newTestRedirects(patterns))
+	if len(rulesErrors) != 1 || rulesErrors[10_000] == nil {
+		t.Errorf("unexpected errors: %v", rulesErrors)
+	}
+}
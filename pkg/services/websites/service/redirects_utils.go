@@ -2,33 +2,51 @@ package service
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
 	"strings"
+	"time"
 
+	"github.com/bloom42/stdx-go/db"
+	"github.com/bloom42/stdx-go/guid"
+	"markdown.ninja/pkg/server/httpctx"
+	"markdown.ninja/pkg/services/kernel"
+	"markdown.ninja/pkg/services/organizations"
 	"markdown.ninja/pkg/services/websites"
 )
 
-func (service *WebsitesService) MatchRedirect(ctx context.Context, domain, path string, redirects []websites.Redirect) *websites.Redirect {
-	for _, redirect := range redirects {
-		if redirect.Domain == "" || redirect.Domain == domain {
-			matched, destination := matchRedirectAndReplace(path, redirect.PathPattern, redirect.To)
-			if matched {
-				redirect.To = destination
-				return &redirect
-			}
-		}
+// MatchRedirect returns the first redirect matching the request, with its To field replaced by the
+// final destination, or nil if no redirect matches.
+func (service *WebsitesService) MatchRedirect(ctx context.Context, domain, path, rawQuery string, redirects []websites.Redirect) *websites.Redirect {
+	if len(redirects) == 0 {
+		return nil
 	}
 
-	return nil
+	matcher := service.getRedirectsMatcher(redirects)
+	index, destination := matcher.match(domain, path, rawQuery)
+	if index < 0 {
+		return nil
+	}
+
+	redirect := matcher.redirects[index]
+	redirect.To = destination
+	return &redirect
 }
 
 type redirectsDiff struct {
-	RedirectsToCreate []websites.RedirectInput
+	RedirectsToCreate []websites.Redirect
+	RedirectsToUpdate []websites.Redirect
 	RedirectsToRemove []websites.Redirect
 }
 
-func (service *WebsitesService) diffRedirects(siteRedirects []websites.Redirect, newRedirects []websites.RedirectInput) (diff redirectsDiff) {
+// diffRedirects compares the redirects by pattern. newRedirects must have been validated and must not
+// contain duplicate patterns.
+func (service *WebsitesService) diffRedirects(siteRedirects []websites.Redirect, newRedirects []websites.Redirect) (diff redirectsDiff) {
 	diff = redirectsDiff{
-		RedirectsToCreate: []websites.RedirectInput{},
+		RedirectsToCreate: []websites.Redirect{},
+		RedirectsToUpdate: []websites.Redirect{},
 		RedirectsToRemove: []websites.Redirect{},
 	}
 
@@ -37,9 +55,8 @@ func (service *WebsitesService) diffRedirects(siteRedirects []websites.Redirect,
 		existingRedirectsMap[redirect.Pattern] = redirect
 	}
 
-	newRedirectsMap := make(map[string]websites.RedirectInput, len(newRedirects))
+	newRedirectsMap := make(map[string]websites.Redirect, len(newRedirects))
 	for _, redirect := range newRedirects {
-		redirect.Pattern = strings.TrimSpace(redirect.Pattern)
 		newRedirectsMap[redirect.Pattern] = redirect
 	}
 
@@ -49,73 +66,678 @@ func (service *WebsitesService) diffRedirects(siteRedirects []websites.Redirect,
 		}
 	}
 
-	for pattern, redirect := range newRedirectsMap {
-		if _, alreadyExists := existingRedirectsMap[pattern]; !alreadyExists {
+	for _, redirect := range newRedirects {
+		existingRedirect, alreadyExists := existingRedirectsMap[redirect.Pattern]
+		if !alreadyExists {
 			diff.RedirectsToCreate = append(diff.RedirectsToCreate, redirect)
+			continue
+		}
+
+		if existingRedirect.To != redirect.To ||
+			existingRedirect.Status != redirect.Status ||
+			existingRedirect.PreserveQuery != redirect.PreserveQuery ||
+			existingRedirect.Position != redirect.Position {
+			existingRedirect.UpdatedAt = redirect.UpdatedAt
+			existingRedirect.To = redirect.To
+			existingRedirect.Status = redirect.Status
+			existingRedirect.PreserveQuery = redirect.PreserveQuery
+			existingRedirect.Position = redirect.Position
+			diff.RedirectsToUpdate = append(diff.RedirectsToUpdate, existingRedirect)
 		}
 	}
 
 	return
 }
 
-func matchRedirectAndReplace(path, pattern string, to string) (matched bool, destination string) {
-	destination = to
+// findWebsiteForRedirectsUpdate returns the website if the current user or API key can update its redirects
+func (service *WebsitesService) findWebsiteForRedirectsUpdate(ctx context.Context, websiteID guid.GUID) (website websites.Website, err error) {
+	actorID, err := service.kernel.CurrentUserID(ctx)
+	if err == nil {
+		website, err = service.repo.FindWebsiteByID(ctx, service.db, websiteID, false)
+		if err != nil {
+			return
+		}
 
-	for pattern != "" && path != "" {
+		err = service.checkUserPermissionForWebsite(ctx, service.db, actorID, website, kernel.PermissionManageWebsites)
+		if err != nil {
+			return
+		}
+	} else {
+		httpCtx := httpctx.FromCtx(ctx)
+		if httpCtx.ApiKey == nil {
+			err = kernel.ErrPermissionDenied
+			return
+		}
 
-		switch pattern[0] {
-		case ':':
-			// ':' matches till next slash in path
-			nextPatternSlash := strings.IndexByte(pattern, '/')
-			if nextPatternSlash < 0 {
-				nextPatternSlash = len(pattern)
+		website, err = service.repo.FindWebsiteByID(ctx, service.db, websiteID, false)
+		if err != nil {
+			return
+		}
+
+		_, err = service.organizationsService.CheckCurrentApiKey(ctx, website.OrganizationID, website.ID, organizations.ApiKeyScopeWebsitesWrite)
+		if err != nil {
+			return
+		}
+	}
+
+	if !websiteID.Equal(website.ID) {
+		err = websites.ErrWebsiteNotFound
+		return
+	}
+
+	return
+}
+
+// redirectFromInput cleans and validates a redirect. The rules (loops, shadowed redirects...) are
+// validated separately with validateRedirectRules.
+func redirectFromInput(websiteID guid.GUID, input websites.RedirectInput, position int, now time.Time) (redirect websites.Redirect, err error) {
+	pattern := strings.TrimSpace(input.Pattern)
+	err = validateRedirectPattern(pattern)
+	if err != nil {
+		return
+	}
+
+	status := int64(http.StatusMovedPermanently)
+	if input.Status != nil {
+		status = *input.Status
+	}
+	err = validateRedirectStatus(status)
+	if err != nil {
+		return
+	}
+
+	to := strings.TrimSpace(input.To)
+	err = validateRedirectDestination(pattern, to, status)
+	if err != nil {
+		return
+	}
+
+	// TODO: parse pattern for domain
+	pathPattern, queryPattern := splitRedirectPattern(pattern)
+	redirect = websites.Redirect{
+		ID:            guid.NewTimeBased(),
+		CreatedAt:     now,
+		UpdatedAt:     now,
+		Pattern:       pattern,
+		Domain:        "",
+		PathPattern:   pathPattern,
+		To:            to,
+		Status:        status,
+		QueryPattern:  queryPattern,
+		PreserveQuery: input.PreserveQuery,
+		Position:      int64(position),
+		WebsiteID:     websiteID,
+	}
+	return
+}
+
+// replaceRedirects replaces the redirects of the website by newRedirects, which must have been validated
+func (service *WebsitesService) replaceRedirects(ctx context.Context, website websites.Website, newRedirects []websites.Redirect) (redirects []websites.Redirect, err error) {
+	existingRedirects, err := service.repo.FindRedirectsForWebsite(ctx, service.db, website.ID)
+	if err != nil {
+		return
+	}
+
+	diff := service.diffRedirects(existingRedirects, newRedirects)
+
+	err = service.db.Transaction(ctx, func(tx db.Tx) (txErr error) {
+		for _, redirectToCreate := range diff.RedirectsToCreate {
+			txErr = service.repo.CreateRedirect(ctx, tx, redirectToCreate)
+			if txErr != nil {
+				return txErr
 			}
-			varName := pattern[:nextPatternSlash]
-			pattern = pattern[nextPatternSlash:]
+		}
 
-			nextPathSlash := strings.IndexByte(path, '/')
-			if nextPathSlash < 0 {
-				nextPathSlash = len(path)
+		for _, redirectToUpdate := range diff.RedirectsToUpdate {
+			txErr = service.repo.UpdateRedirect(ctx, tx, redirectToUpdate)
+			if txErr != nil {
+				return txErr
 			}
-			capturedPath := path[:nextPathSlash]
-			path = path[nextPathSlash:]
+		}
+
+		for _, redirectToRemove := range diff.RedirectsToRemove {
+			txErr = service.repo.DeleteRedirect(ctx, tx, redirectToRemove.ID)
+			if txErr != nil {
+				return txErr
+			}
+		}
+
+		// TODO: improve
+		redirects, txErr = service.repo.FindRedirectsForWebsite(ctx, tx, website.ID)
+		if txErr != nil {
+			return txErr
+		}
+
+		if len(diff.RedirectsToCreate) == 0 && len(diff.RedirectsToUpdate) == 0 && len(diff.RedirectsToRemove) == 0 {
+			return nil
+		}
 
-			destination = strings.ReplaceAll(destination, varName, capturedPath)
+		txErr = service.organizationsService.RecordAuditLog(ctx, tx, organizations.RecordAuditLogInput{
+			OrganizationID: website.OrganizationID,
+			WebsiteID:      &website.ID,
+			Action:         organizations.AuditLogActionRedirectsSave,
+			EntityID:       website.ID.String(),
+			Before:         redirectsToAuditLogState(existingRedirects),
+			After:          redirectsToAuditLogState(redirects),
+		})
+		return txErr
+	})
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+type redirectTokenType int
+
+const (
+	redirectTokenLiteral redirectTokenType = iota
+	// :name matches a non-empty part of a single path segment
+	redirectTokenParam
+	// * and :name* match anything, including slashes
+	redirectTokenSplat
+)
+
+const redirectSplatName = "splat"
+
+type redirectToken struct {
+	Type redirectTokenType
+	// Value is the literal for literal tokens and the name of the capture for params and splats
+	Value string
+}
+
+type redirectQueryCondition struct {
+	Key string
+	// Value is the exact value required. Ignored if Capture is not empty
+	Value string
+	// Capture is the name of the capture for conditions of the form key=:name
+	Capture string
+}
+
+type redirectPattern struct {
+	Path  []redirectToken
+	Query []redirectQueryCondition
+}
+
+// splitRedirectPattern splits a pattern into its path and query parts
+func splitRedirectPattern(pattern string) (pathPattern, queryPattern string) {
+	pathPattern, queryPattern, _ = strings.Cut(pattern, "?")
+	return
+}
+
+func parseRedirectPattern(pathPattern, queryPattern string) (pattern redirectPattern, err error) {
+	if !strings.HasPrefix(pathPattern, "/") {
+		err = errors.New("the pattern must start with '/'")
+		return
+	}
+
+	captures := map[string]bool{}
+	addCapture := func(name string) error {
+		if captures[name] {
+			return fmt.Errorf("':%s' is used more than once", name)
+		}
+		captures[name] = true
+		return nil
+	}
+
+	pattern.Path = []redirectToken{}
+	splats := 0
+	literal := strings.Builder{}
+	for i := 0; i < len(pathPattern); {
+		var token redirectToken
+
+		switch pathPattern[i] {
 		case '*':
-			matched = true
-			destination = strings.ReplaceAll(destination, ":splat", path)
-			return
-			// pattern = pattern[1:]
-			// if len(pattern) == 0 {
-			// 	path = ""
-			// } else {
-			// 	nextByte := pattern[0]
-			// 	// '*' matches till next slash in path
-			// 	nextPathByte := strings.IndexByte(path, nextByte)
-			// 	if nextPathByte < 0 {
-			// 		nextPathByte = len(path)
-			// 	}
-			// 	path = path[nextPathByte:]
-			// }
-
-		case path[0]:
-			// non-'*' pattern byte must match path byte
-			path = path[1:]
-			pattern = pattern[1:]
+			token = redirectToken{Type: redirectTokenSplat, Value: redirectSplatName}
+			i += 1
+		case ':':
+			name := readRedirectCaptureName(pathPattern[i+1:])
+			if name == "" {
+				literal.WriteByte(':')
+				i += 1
+				continue
+			}
+			i += 1 + len(name)
+			if i < len(pathPattern) && pathPattern[i] == '*' {
+				token = redirectToken{Type: redirectTokenSplat, Value: name}
+				i += 1
+			} else {
+				token = redirectToken{Type: redirectTokenParam, Value: name}
+			}
 		default:
-			destination = ""
+			literal.WriteByte(pathPattern[i])
+			i += 1
+			continue
+		}
+
+		// we reject ambiguous patterns such as /:a:b or /*:name
+		if literal.Len() == 0 && len(pattern.Path) != 0 {
+			err = errors.New("wildcards must be separated by at least one character")
+			return
+		}
+		if literal.Len() != 0 {
+			pattern.Path = append(pattern.Path, redirectToken{Type: redirectTokenLiteral, Value: literal.String()})
+			literal.Reset()
+		}
+
+		if token.Type == redirectTokenSplat {
+			splats += 1
+			if splats > websites.RedirectPatternMaxSplats {
+				err = fmt.Errorf("a pattern can't have more than %d splats", websites.RedirectPatternMaxSplats)
+				return
+			}
+		}
+		err = addCapture(token.Value)
+		if err != nil {
 			return
 		}
+		pattern.Path = append(pattern.Path, token)
+	}
+	if literal.Len() != 0 {
+		pattern.Path = append(pattern.Path, redirectToken{Type: redirectTokenLiteral, Value: literal.String()})
+	}
+
+	pattern.Query = []redirectQueryCondition{}
+	if queryPattern == "" {
+		return
 	}
+	for _, part := range strings.Split(queryPattern, "&") {
+		rawKey, rawValue, _ := strings.Cut(part, "=")
+		var condition redirectQueryCondition
 
-	if (pattern == "" || pattern == "*") && path == "" {
-		matched = true
-		if pattern == "*" {
-			destination = strings.ReplaceAll(destination, ":splat", path)
+		condition.Key, err = url.QueryUnescape(rawKey)
+		if err != nil || condition.Key == "" {
+			err = fmt.Errorf("query parameter '%s' is not valid", part)
+			return
 		}
-	} else {
-		destination = ""
+
+		if strings.HasPrefix(rawValue, ":") {
+			condition.Capture = readRedirectCaptureName(rawValue[1:])
+			if condition.Capture == "" || len(condition.Capture) != len(rawValue)-1 {
+				err = fmt.Errorf("query parameter '%s' is not valid", part)
+				return
+			}
+			err = addCapture(condition.Capture)
+			if err != nil {
+				return
+			}
+		} else {
+			condition.Value, err = url.QueryUnescape(rawValue)
+			if err != nil {
+				err = fmt.Errorf("query parameter '%s' is not valid", part)
+				return
+			}
+		}
+
+		pattern.Query = append(pattern.Query, condition)
+	}
+
+	return
+}
+
+// readRedirectCaptureName returns the name at the start of input, or an empty string if input doesn't
+// start with a valid name. Names are made of ASCII letters, digits and '_' and can't start with a digit.
+func readRedirectCaptureName(input string) string {
+	end := 0
+	for end < len(input) {
+		c := input[end]
+		if (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c == '_' || (end != 0 && c >= '0' && c <= '9') {
+			end += 1
+			continue
+		}
+		break
+	}
+	return input[:end]
+}
+
+// matchRedirectPattern returns the destination with the captures replaced if path and query match the
+// pattern. query can be nil if the pattern has no conditions on the query string.
+func matchRedirectPattern(pattern redirectPattern, path string, query url.Values, to string) (matched bool, destination string) {
+	captures := map[string]string{}
+
+	if !matchRedirectPath(pattern.Path, path, captures) {
+		return
+	}
+
+	for _, condition := range pattern.Query {
+		values, exists := query[condition.Key]
+		if !exists {
+			return
+		}
+
+		if condition.Capture != "" {
+			captures[condition.Capture] = values[0]
+		} else if condition.Value != "" {
+			valueFound := false
+			for _, value := range values {
+				if value == condition.Value {
+					valueFound = true
+					break
+				}
+			}
+			if !valueFound {
+				return
+			}
+		}
+	}
+
+	destination = replaceRedirectCaptures(to, captures)
+	// captures must not turn a local destination into an external one. e.g. /go?to=:dest -> /:dest
+	// with ?to=/evil.com
+	if isLocalRedirectDestination(to) && !isLocalRedirectDestination(destination) {
+		return false, ""
 	}
 
+	matched = true
 	return
 }
+
+// matchRedirectPath matches path against tokens with backtracking. Params and splats are greedy.
+func matchRedirectPath(tokens []redirectToken, path string, captures map[string]string) bool {
+	if len(tokens) == 0 {
+		return path == ""
+	}
+
+	token := tokens[0]
+	switch token.Type {
+	case redirectTokenLiteral:
+		if !strings.HasPrefix(path, token.Value) {
+			return false
+		}
+		return matchRedirectPath(tokens[1:], path[len(token.Value):], captures)
+
+	case redirectTokenParam:
+		end := strings.IndexByte(path, '/')
+		if end < 0 {
+			end = len(path)
+		}
+		for i := end; i > 0; i -= 1 {
+			if matchRedirectPath(tokens[1:], path[i:], captures) {
+				captures[token.Value] = path[:i]
+				return true
+			}
+		}
+		return false
+
+	case redirectTokenSplat:
+		for i := len(path); i >= 0; i -= 1 {
+			if matchRedirectPath(tokens[1:], path[i:], captures) {
+				captures[token.Value] = path[:i]
+				return true
+			}
+		}
+		return false
+	}
+
+	return false
+}
+
+// replaceRedirectCaptures replaces the :name placeholders of destination by their captured values.
+// Unknown placeholders (e.g. the port of an URL) are left untouched.
+// Captured values come from the request, so they are escaped: they can't add path segments (except splats),
+// query parameters or a fragment to the destination.
+func replaceRedirectCaptures(destination string, captures map[string]string) string {
+	if len(captures) == 0 || !strings.Contains(destination, ":") {
+		return destination
+	}
+
+	ret := strings.Builder{}
+	inQuery := false
+	for {
+		colon := strings.IndexByte(destination, ':')
+		if colon < 0 {
+			ret.WriteString(destination)
+			break
+		}
+		ret.WriteString(destination[:colon])
+		inQuery = inQuery || strings.ContainsAny(destination[:colon], "?#")
+		destination = destination[colon+1:]
+
+		name := readRedirectCaptureName(destination)
+		if value, isCaptured := captures[name]; name != "" && isCaptured {
+			if inQuery {
+				ret.WriteString(url.QueryEscape(value))
+			} else {
+				ret.WriteString(escapeRedirectPathCapture(value))
+			}
+			destination = destination[len(name):]
+		} else {
+			ret.WriteByte(':')
+		}
+	}
+
+	return ret.String()
+}
+
+// escapeRedirectPathCapture escapes a captured value used in the path of a destination. Slashes are
+// kept as splats can capture several segments.
+func escapeRedirectPathCapture(value string) string {
+	segments := strings.Split(value, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+func appendQueryToDestination(destination, rawQuery string) string {
+	if rawQuery == "" {
+		return destination
+	}
+	if strings.Contains(destination, "?") {
+		return destination + "&" + rawQuery
+	}
+	return destination + "?" + rawQuery
+}
+
+// matchRedirectAndReplace matches a path (without query string) against a pattern
+func matchRedirectAndReplace(path, pattern string, to string) (matched bool, destination string) {
+	parsedPattern, err := parseRedirectPattern(splitRedirectPattern(pattern))
+	if err != nil {
+		return
+	}
+
+	return matchRedirectPattern(parsedPattern, path, url.Values{}, to)
+}
+
+// isLocalRedirectDestination returns true if destination is a path. Browsers treat "/\" like "//", as the
+// start of a protocol-relative URL.
+func isLocalRedirectDestination(destination string) bool {
+	return strings.HasPrefix(destination, "/") && !strings.HasPrefix(destination, "//") &&
+		!strings.HasPrefix(destination, "/\\")
+}
+
+// validateRedirectRules returns the first error found by findRedirectRulesErrors
+func validateRedirectRules(redirects []websites.Redirect) error {
+	rulesErrors := findRedirectRulesErrors(redirects)
+	for i := range redirects {
+		if err, hasError := rulesErrors[i]; hasError {
+			return err
+		}
+	}
+	return nil
+}
+
+// findRedirectRulesErrors detects redirects that are shadowed by a previous redirect and thus are never
+// used, and redirects that create loops. redirects must be sorted by position and their patterns valid.
+// The errors are indexed by the index of the redirect in redirects.
+func findRedirectRulesErrors(redirects []websites.Redirect) map[int]error {
+	rulesErrors := map[int]error{}
+	matcher := newRedirectsMatcher(redirects)
+
+	for j := range redirects {
+		pattern := matcher.patterns[j]
+		atoms := redirectPatternToAtoms(pattern.Path)
+		// only the redirects with a literal prefix that is a prefix of the literal prefix of the
+		// redirect can include it
+		for _, i := range matcher.candidates(redirectPatternLiteralPrefix(pattern)) {
+			if i >= j {
+				break
+			}
+			if redirects[i].Domain != "" && redirects[i].Domain != redirects[j].Domain {
+				continue
+			}
+			if redirectPatternIncludes(matcher.patterns[i], pattern, atoms) {
+				rulesErrors[j] = websites.ErrRedirectIsShadowed(redirects[j].Pattern, redirects[i].Pattern)
+				break
+			}
+		}
+	}
+
+	for i, redirect := range redirects {
+		if redirect.Status == http.StatusOK {
+			continue
+		}
+		if _, hasError := rulesErrors[i]; hasError {
+			continue
+		}
+
+		// we follow the chain of redirects starting with a sample URL matching the pattern
+		samplePath, sampleQuery := redirectPatternSample(matcher.patterns[i])
+		_, destination := matchRedirectPattern(matcher.patterns[i], samplePath, sampleQuery, redirect.To)
+		if redirect.PreserveQuery {
+			destination = appendQueryToDestination(destination, sampleQuery.Encode())
+		}
+
+		visited := map[string]bool{appendQueryToDestination(samplePath, sampleQuery.Encode()): true}
+		for hops := 1; isLocalRedirectDestination(destination); hops += 1 {
+			if visited[destination] || hops > websites.RedirectMaxHops {
+				rulesErrors[i] = websites.ErrRedirectLoop(redirect.Pattern)
+				break
+			}
+			visited[destination] = true
+
+			path, rawQuery, _ := strings.Cut(destination, "?")
+			var next int
+			next, destination = matcher.match(redirect.Domain, path, rawQuery)
+			if next < 0 || redirects[next].Status == http.StatusOK {
+				break
+			}
+		}
+	}
+
+	return rulesErrors
+}
+
+// redirectAtom is either a single character or a wildcard of a pattern.
+// Used to check if a pattern includes another pattern.
+type redirectAtom struct {
+	Type redirectTokenType
+	Char byte
+}
+
+func redirectPatternToAtoms(tokens []redirectToken) []redirectAtom {
+	atoms := make([]redirectAtom, 0, len(tokens))
+	for _, token := range tokens {
+		if token.Type == redirectTokenLiteral {
+			for i := 0; i < len(token.Value); i += 1 {
+				atoms = append(atoms, redirectAtom{Type: redirectTokenLiteral, Char: token.Value[i]})
+			}
+		} else {
+			atoms = append(atoms, redirectAtom{Type: token.Type})
+		}
+	}
+	return atoms
+}
+
+// redirectPatternIncludes returns true if all the URLs matched by other are also matched by pattern.
+// It may return false for some patterns that are included, but never returns true for patterns that
+// are not included.
+func redirectPatternIncludes(pattern, other redirectPattern, otherAtoms []redirectAtom) bool {
+	// all the conditions of pattern must be implied by the conditions of other
+	for _, condition := range pattern.Query {
+		implied := false
+		for _, otherCondition := range other.Query {
+			if otherCondition.Key != condition.Key {
+				continue
+			}
+			if condition.Capture != "" || condition.Value == "" ||
+				(otherCondition.Capture == "" && otherCondition.Value == condition.Value) {
+				implied = true
+				break
+			}
+		}
+		if !implied {
+			return false
+		}
+	}
+
+	return redirectTokensIncludeAtoms(pattern.Path, otherAtoms)
+}
+
+func redirectTokensIncludeAtoms(tokens []redirectToken, atoms []redirectAtom) bool {
+	if len(tokens) == 0 {
+		return len(atoms) == 0
+	}
+
+	token := tokens[0]
+	switch token.Type {
+	case redirectTokenLiteral:
+		if len(atoms) < len(token.Value) {
+			return false
+		}
+		for i := 0; i < len(token.Value); i += 1 {
+			if atoms[i].Type != redirectTokenLiteral || atoms[i].Char != token.Value[i] {
+				return false
+			}
+		}
+		return redirectTokensIncludeAtoms(tokens[1:], atoms[len(token.Value):])
+
+	case redirectTokenParam:
+		// a param can match the characters and params of the other pattern, but not its splats
+		// as they may contain slashes
+		for i := 1; i <= len(atoms); i += 1 {
+			atom := atoms[i-1]
+			if atom.Type == redirectTokenSplat || (atom.Type == redirectTokenLiteral && atom.Char == '/') {
+				break
+			}
+			if redirectTokensIncludeAtoms(tokens[1:], atoms[i:]) {
+				return true
+			}
+		}
+		return false
+
+	case redirectTokenSplat:
+		for i := 0; i <= len(atoms); i += 1 {
+			if redirectTokensIncludeAtoms(tokens[1:], atoms[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+
+	return false
+}
+
+// redirectPatternSample returns an URL matching the pattern
+func redirectPatternSample(pattern redirectPattern) (path string, query url.Values) {
+	pathBuilder := strings.Builder{}
+	for _, token := range pattern.Path {
+		if token.Type == redirectTokenLiteral {
+			pathBuilder.WriteString(token.Value)
+		} else {
+			pathBuilder.WriteString("x")
+		}
+	}
+
+	query = url.Values{}
+	for _, condition := range pattern.Query {
+		if condition.Capture != "" || condition.Value == "" {
+			query.Add(condition.Key, "x")
+		} else {
+			query.Add(condition.Key, condition.Value)
+		}
+	}
+
+	return pathBuilder.String(), query
+}
+
+// redirectsToAuditLogState returns the redirects as a pattern -> destination map so that the changes
+// recorded in the audit log are per redirect
+func redirectsToAuditLogState(redirects []websites.Redirect) map[string]string {
+	ret := make(map[string]string, len(redirects))
+	for _, redirect := range redirects {
+		ret[redirect.Pattern] = fmt.Sprintf("%s (%d)", redirect.To, redirect.Status)
+	}
+	return ret
+}
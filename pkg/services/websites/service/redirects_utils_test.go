@@ -1,9 +1,12 @@
 package service
 
 import (
+	"context"
 	"fmt"
+	"strconv"
 	"testing"
 
+	"github.com/bloom42/stdx-go/memorycache"
 	"markdown.ninja/pkg/services/websites"
 )
 
@@ -133,3 +136,153 @@ func TestMatchRedirectAndReplace(t *testing.T) {
 		})
 	}
 }
+
+func TestMatchAdvancedRedirectPatterns(t *testing.T) {
+	tests := []struct {
+		Pattern     string
+		To          string
+		Path        string
+		Matched     bool
+		Destination string
+	}{
+		{"/:path*", "/new/:path", "/a/b/c", true, "/new/a/b/c"},
+		{"/:path*", "/new/:path", "/", true, "/new/"},
+		{"/*.json", "/json", "/data.json", true, "/json"},
+		{"/*.json", "/json", "/api/data.json", true, "/json"},
+		{"/*.json", "/json", "/data.xml", false, ""},
+		{"/:slug.html", "/:slug", "/hello.html", true, "/hello"},
+		{"/:slug.html", "/:slug", "/blog/hello.html", false, ""},
+		{"/docs/*/edit", "/edit/:splat", "/docs/a/b/edit", true, "/edit/a/b"},
+		{"/docs/*/edit", "/edit/:splat", "/docs/a/b/view", false, ""},
+		{"/:lang/:rest*", "/:rest?lang=:lang", "/fr/blog/post", true, "/blog/post?lang=fr"},
+		{"/old/:post", "https://example.com:8080/:post", "/old/hello", true, "https://example.com:8080/hello"},
+		{"/old/:post", "/:postId", "/old/hello", true, "/:postId"},
+		{"/old/:post", "/new/:post", "/old/a?b#c", true, "/new/a%3Fb%23c"},
+		{"/old/*", "/new/:splat", "/old/café/x", true, "/new/caf%C3%A9/x"},
+		{"/old/*", "/:splat", "/old//evil.com", false, ""},
+		{"/old/*", "/:splat", "/old/\\evil.com", true, "/%5Cevil.com"},
+		{"/:lang/:rest*", "/:rest?lang=:lang", "/fr&admin=1/post", true, "/post?lang=fr%26admin%3D1"},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%s|%s|%s", test.Path, test.Pattern, test.To), func(t *testing.T) {
+			matched, destination := matchRedirectAndReplace(test.Path, test.Pattern, test.To)
+			if matched != test.Matched || destination != test.Destination {
+				t.Errorf("got: matched(%v), destination(%s) | want matched(%v), destination(%s)", matched, destination, test.Matched, test.Destination)
+			}
+		})
+	}
+}
+
+func TestMatchRedirectQuery(t *testing.T) {
+	service := &WebsitesService{redirectsMatchersCache: memorycache.New[string, *redirectsMatcher]()}
+	redirects := []websites.Redirect{
+		{PathPattern: "/index.php", QueryPattern: "p=:id&preview", To: "/preview/:id", Status: 302},
+		{PathPattern: "/index.php", QueryPattern: "p=:id", To: "/posts/:id", Status: 301},
+		{PathPattern: "/search", QueryPattern: "type=post", To: "/posts", Status: 301, PreserveQuery: true},
+		{PathPattern: "/feed", To: "/feed.xml?format=rss", Status: 308, PreserveQuery: true},
+		{PathPattern: "/blog/*", To: "/:splat", Status: 200},
+		{PathPattern: "/go", QueryPattern: "to=:dest", To: "/:dest?ref=:dest", Status: 302},
+	}
+	tests := []struct {
+		Path        string
+		RawQuery    string
+		Destination string
+		Status      int64
+	}{
+		{"/index.php", "p=42", "/posts/42", 301},
+		{"/index.php", "p=42&preview=true", "/preview/42", 302},
+		{"/index.php", "", "", 0},
+		{"/search", "type=post&q=go", "/posts?type=post&q=go", 301},
+		{"/search", "type=page", "", 0},
+		{"/feed", "utm_source=x", "/feed.xml?format=rss&utm_source=x", 308},
+		{"/blog/hello", "", "/hello", 200},
+		{"/go", "to=hello", "/hello?ref=hello", 302},
+		{"/go", "to=a%26b", "/a&b?ref=a%26b", 302},
+		{"/go", "to=/evil.com", "", 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Path+"?"+test.RawQuery, func(t *testing.T) {
+			redirect := service.MatchRedirect(context.Background(), "", test.Path, test.RawQuery, redirects)
+			if test.Destination == "" {
+				if redirect != nil {
+					t.Errorf("expected no match, got: %s", redirect.To)
+				}
+				return
+			}
+			if redirect == nil || redirect.To != test.Destination || redirect.Status != test.Status {
+				t.Errorf("got: %#v | want destination(%s), status(%d)", redirect, test.Destination, test.Status)
+			}
+		})
+	}
+}
+
+func TestParseRedirectPatternErrors(t *testing.T) {
+	invalidPatterns := []string{
+		"old",
+		"/:a:b",
+		"/*:name",
+		"/:a/:a",
+		"/*/*/*.json",
+		"/search?=x",
+		"/search?q=:1",
+		"/:id?id=:id",
+	}
+
+	for _, pattern := range invalidPatterns {
+		_, err := parseRedirectPattern(splitRedirectPattern(pattern))
+		if err == nil {
+			t.Errorf("%s: expected an error", pattern)
+		}
+	}
+}
+
+func TestValidateRedirectRules(t *testing.T) {
+	tests := []struct {
+		Name      string
+		Redirects [][3]string // pattern, to, status
+		Valid     bool
+	}{
+		{"simple", [][3]string{{"/old", "/new", "301"}, {"/blog/:post", "/:post", "301"}}, true},
+		{"duplicate", [][3]string{{"/old", "/new", "301"}, {"/old", "/other", "302"}}, false},
+		{"shadowed by splat", [][3]string{{"/blog/*", "/:splat", "301"}, {"/blog/:post", "/posts/:post", "301"}}, false},
+		{"shadowed by param", [][3]string{{"/blog/:post", "/:post", "301"}, {"/blog/hello", "/hello", "301"}}, false},
+		{"param doesn't shadow splat", [][3]string{{"/blog/:post", "/:post", "301"}, {"/blog/*", "/", "301"}}, true},
+		{"more specific first", [][3]string{{"/blog/hello", "/hello", "301"}, {"/blog/*", "/:splat", "301"}}, true},
+		{"query is more specific", [][3]string{{"/index.php?p=:id", "/:id", "301"}, {"/index.php", "/", "301"}}, true},
+		{"shadowed query", [][3]string{{"/index.php?p=:id", "/posts/:id", "301"}, {"/index.php?p=1&x", "/first", "301"}}, false},
+		{"self loop", [][3]string{{"/a", "/a", "301"}}, false},
+		{"loop", [][3]string{{"/a", "/b", "301"}, {"/b", "/c", "302"}, {"/c", "/a", "301"}}, false},
+		{"growing loop", [][3]string{{"/:path*", "/new/:path", "301"}}, false},
+		{"catch all loop", [][3]string{{"/*", "/", "301"}}, false},
+		{"chain", [][3]string{{"/a", "/b", "301"}, {"/b", "/c", "301"}}, true},
+		{"rewrite", [][3]string{{"/a", "/a", "200"}}, true},
+		{"external", [][3]string{{"/*", "https://example.com/:splat", "301"}}, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			redirects := make([]websites.Redirect, len(test.Redirects))
+			for i, redirect := range test.Redirects {
+				pathPattern, queryPattern := splitRedirectPattern(redirect[0])
+				status, _ := strconv.ParseInt(redirect[2], 10, 64)
+				redirects[i] = websites.Redirect{
+					Pattern:      redirect[0],
+					PathPattern:  pathPattern,
+					QueryPattern: queryPattern,
+					To:           redirect[1],
+					Status:       status,
+					Position:     int64(i),
+				}
+			}
+
+			err := validateRedirectRules(redirects)
+			if test.Valid && err != nil {
+				t.Errorf("expected valid rules, got: %s", err)
+			} else if !test.Valid && err == nil {
+				t.Errorf("expected an error")
+			}
+		})
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"time"
 
 	"github.com/bloom42/stdx-go/db"
+	"markdown.ninja/pkg/services/kernel"
+	"markdown.ninja/pkg/services/organizations"
 	"markdown.ninja/pkg/services/websites"
 )
 
@@ -24,7 +26,7 @@ func (service *WebsitesService) RemoveDomain(ctx context.Context, input websites
 		return
 	}
 
-	_, err = service.organizationsService.CheckUserIsStaff(ctx, service.db, actorID, website.OrganizationID)
+	err = service.checkUserPermissionForWebsite(ctx, service.db, actorID, website, kernel.PermissionManageWebsites)
 	if err != nil {
 		return
 	}
@@ -43,6 +45,18 @@ func (service *WebsitesService) RemoveDomain(ctx context.Context, input websites
 		}
 
 		txErr = service.repo.DeleteDomain(ctx, tx, domain.ID)
+		if txErr != nil {
+			return txErr
+		}
+
+		txErr = service.organizationsService.RecordAuditLog(ctx, tx, organizations.RecordAuditLogInput{
+			OrganizationID: website.OrganizationID,
+			WebsiteID:      &website.ID,
+			Action:         organizations.AuditLogActionDomainRemove,
+			EntityID:       domain.ID.String(),
+			Before:         domain,
+			After:          nil,
+		})
 		return txErr
 	})
 	if err != nil {
@@ -2,6 +2,7 @@ package service
 
 import (
 	"github.com/bloom42/stdx-go/db"
+	"github.com/bloom42/stdx-go/memorycache"
 	"github.com/bloom42/stdx-go/queue"
 	"markdown.ninja/cmd/mdninja-server/config"
 	"markdown.ninja/pkg/mailer"
@@ -33,6 +34,8 @@ type WebsitesService struct {
 	organizationsService organizations.Service
 
 	websitesRootDomain string
+
+	redirectsMatchersCache *memorycache.Cache[string, *redirectsMatcher]
 }
 
 func NewWebsitesService(conf config.Config, db db.DB, queue queue.Queue, mailer mailer.Mailer,
@@ -41,6 +44,11 @@ func NewWebsitesService(conf config.Config, db db.DB, queue queue.Queue, mailer
 	eventsService events.Service, organizationsService organizations.Service) (service *WebsitesService, err error) {
 	repo := repository.NewWebsitesRepository()
 
+	redirectsMatchersCache := memorycache.New(
+		memorycache.WithCapacity[string, *redirectsMatcher](10_000),
+		memorycache.WithTTL[string, *redirectsMatcher](redirectsMatchersCacheTTL),
+	)
+
 	service = &WebsitesService{
 		config:  conf,
 		repo:    repo,
@@ -58,6 +66,8 @@ func NewWebsitesService(conf config.Config, db db.DB, queue queue.Queue, mailer
 		organizationsService: organizationsService,
 
 		websitesRootDomain: conf.HTTP.WebsitesRootDomain,
+
+		redirectsMatchersCache: redirectsMatchersCache,
 	}
 	return
 }
@@ -4,6 +4,9 @@ import (
 	"context"
 	"time"
 
+	"github.com/bloom42/stdx-go/db"
+	"markdown.ninja/pkg/services/kernel"
+	"markdown.ninja/pkg/services/organizations"
 	"markdown.ninja/pkg/services/websites"
 )
 
@@ -18,11 +21,13 @@ func (service *WebsitesService) SetDomainAsPrimary(ctx context.Context, input we
 		return
 	}
 
-	_, err = service.organizationsService.CheckUserIsStaff(ctx, service.db, actorID, website.OrganizationID)
+	err = service.checkUserPermissionForWebsite(ctx, service.db, actorID, website, kernel.PermissionManageWebsites)
 	if err != nil {
 		return
 	}
 
+	primaryDomainBefore := website.PrimaryDomain
+
 	if input.Domain == nil {
 		website.PrimaryDomain = service.getSubdomainForSlug(website.Slug)
 	} else {
@@ -41,7 +46,22 @@ func (service *WebsitesService) SetDomainAsPrimary(ctx context.Context, input we
 	website.UpdatedAt = now
 	website.ModifiedAt = now
 
-	err = service.repo.UpdateWebsite(ctx, service.db, website)
+	err = service.db.Transaction(ctx, func(tx db.Tx) (txErr error) {
+		txErr = service.repo.UpdateWebsite(ctx, tx, website)
+		if txErr != nil {
+			return txErr
+		}
+
+		txErr = service.organizationsService.RecordAuditLog(ctx, tx, organizations.RecordAuditLogInput{
+			OrganizationID: website.OrganizationID,
+			WebsiteID:      &website.ID,
+			Action:         organizations.AuditLogActionDomainSetPrimary,
+			EntityID:       website.ID.String(),
+			Before:         map[string]string{"primary_domain": primaryDomainBefore},
+			After:          map[string]string{"primary_domain": website.PrimaryDomain},
+		})
+		return txErr
+	})
 	if err != nil {
 		return
 	}
@@ -5,6 +5,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/bloom42/stdx-go/db"
 	"markdown.ninja/pkg/errs"
 	"markdown.ninja/pkg/server/httpctx"
 	"markdown.ninja/pkg/services/kernel"
@@ -24,7 +25,7 @@ func (service *WebsitesService) UpdateWebsite(ctx context.Context, input website
 			return
 		}
 
-		_, err = service.organizationsService.CheckUserIsStaff(ctx, service.db, actorID, website.OrganizationID)
+		err = service.checkUserPermissionForWebsite(ctx, service.db, actorID, website, kernel.PermissionManageWebsites)
 		if err != nil {
 			return
 		}
@@ -41,13 +42,14 @@ func (service *WebsitesService) UpdateWebsite(ctx context.Context, input website
 			return
 		}
 
-		_, err = service.organizationsService.CheckCurrentApiKey(ctx, website.OrganizationID)
+		_, err = service.organizationsService.CheckCurrentApiKey(ctx, website.OrganizationID, website.ID, organizations.ApiKeyScopeWebsitesWrite)
 		if err != nil {
 			return
 		}
 	}
 
 	now := time.Now().UTC()
+	websiteBefore := website
 
 	if input.Name != nil {
 		website.Name = strings.TrimSpace(*input.Name)
@@ -97,6 +99,35 @@ func (service *WebsitesService) UpdateWebsite(ctx context.Context, input website
 		}
 	}
 
+	if input.Language != nil {
+		website.Language = strings.ToLower(strings.TrimSpace(*input.Language))
+		err = validateLanguage(website.Language)
+		if err != nil {
+			return
+		}
+	}
+
+	if input.Languages != nil {
+		website.Languages = make(websites.WebsiteLanguages, len(*input.Languages))
+		for i, language := range *input.Languages {
+			language.Code = strings.ToLower(strings.TrimSpace(language.Code))
+			language.PathPrefix = strings.TrimSpace(language.PathPrefix)
+			if language.Navigation != nil {
+				if language.Navigation.Primary == nil {
+					language.Navigation.Primary = []websites.WebsiteNavigationItem{}
+				}
+				if language.Navigation.Secondary == nil {
+					language.Navigation.Secondary = []websites.WebsiteNavigationItem{}
+				}
+			}
+			website.Languages[i] = language
+		}
+		err = validateWebsiteLanguages(website.Languages)
+		if err != nil {
+			return
+		}
+	}
+
 	if input.Slug != nil {
 		var existingWebsiteWithSlug websites.Website
 
@@ -263,7 +294,22 @@ func (service *WebsitesService) UpdateWebsite(ctx context.Context, input website
 	website.UpdatedAt = now
 	website.ModifiedAt = now
 
-	err = service.repo.UpdateWebsite(ctx, service.db, website)
+	err = service.db.Transaction(ctx, func(tx db.Tx) (txErr error) {
+		txErr = service.repo.UpdateWebsite(ctx, tx, website)
+		if txErr != nil {
+			return txErr
+		}
+
+		txErr = service.organizationsService.RecordAuditLog(ctx, tx, organizations.RecordAuditLogInput{
+			OrganizationID: website.OrganizationID,
+			WebsiteID:      &website.ID,
+			Action:         organizations.AuditLogActionWebsiteUpdate,
+			EntityID:       website.ID.String(),
+			Before:         websiteBefore,
+			After:          website,
+		})
+		return txErr
+	})
 	if err != nil {
 		return
 	}
@@ -17,6 +17,7 @@ import (
 	"github.com/bloom42/stdx-go/imaging"
 	"github.com/bloom42/stdx-go/retry"
 	"markdown.ninja/pkg/errs"
+	"markdown.ninja/pkg/services/kernel"
 	"markdown.ninja/pkg/services/websites"
 	"markdown.ninja/pkg/storage"
 )
@@ -32,7 +33,7 @@ func (service *WebsitesService) UpdateWebsiteIcon(ctx context.Context, input web
 		return err
 	}
 
-	_, err = service.organizationsService.CheckUserIsStaff(ctx, service.db, actorID, website.OrganizationID)
+	err = service.checkUserPermissionForWebsite(ctx, service.db, actorID, website, kernel.PermissionManageWebsites)
 	if err != nil {
 		return err
 	}
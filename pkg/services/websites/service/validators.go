@@ -2,9 +2,11 @@ package service
 
 import (
 	"net/http"
+	"net/url"
 	"strings"
 	"unicode/utf8"
 
+	"github.com/bloom42/stdx-go/languages"
 	"github.com/bloom42/stdx-go/stringsx"
 	"github.com/bloom42/stdx-go/validate"
 	"markdown.ninja/pkg/errs"
@@ -106,29 +108,51 @@ func validateWebsiteDescription(description string) error {
 	return nil
 }
 
-func validateRedirectStatus(status int) error {
-	if status != http.StatusFound && status != http.StatusMovedPermanently {
+func validateRedirectStatus(status int64) error {
+	switch status {
+	case http.StatusOK, http.StatusMovedPermanently, http.StatusFound, http.StatusTemporaryRedirect,
+		http.StatusPermanentRedirect:
+		return nil
+	default:
 		return websites.ErrRedirectStatusIsNotValid
 	}
-
-	return nil
 }
 
-// TODO
-func validateRedirectDestination(destination string) error {
-	if !utf8.ValidString(destination) {
+// validateRedirectDestination validates that destination is either a path or an absolute HTTP(S) URL.
+// The destination of rewrites must be a path.
+func validateRedirectDestination(pattern, destination string, status int64) error {
+	if destination == "" || len(destination) > websites.RedirectDestinationMaxLength ||
+		!utf8.ValidString(destination) || strings.ContainsAny(destination, " \t\r\n") {
+		return websites.ErrRedirectDestinationIsNotValid
+	}
+
+	if isLocalRedirectDestination(destination) {
+		return nil
+	}
+
+	if status == http.StatusOK {
+		return websites.ErrRewriteDestinationIsNotValid(pattern)
+	}
+
+	destinationUrl, err := url.Parse(destination)
+	if err != nil || (destinationUrl.Scheme != "http" && destinationUrl.Scheme != "https") || destinationUrl.Host == "" {
 		return websites.ErrRedirectDestinationIsNotValid
 	}
 
 	return nil
 }
 
-// TODO
 func validateRedirectPattern(pattern string) error {
-	if !utf8.ValidString(pattern) {
+	if pattern == "" || len(pattern) > websites.RedirectPatternMaxLength || !utf8.ValidString(pattern) ||
+		strings.ContainsAny(pattern, " \t\r\n") {
 		return websites.ErrRedirectPatternIsNotValid
 	}
 
+	_, err := parseRedirectPattern(splitRedirectPattern(pattern))
+	if err != nil {
+		return websites.ErrParsingRedirectPattern(pattern, err)
+	}
+
 	return nil
 }
 
@@ -267,3 +291,53 @@ func validateWebsiteNavigationItem(item *websites.WebsiteNavigationItem, depth u
 
 	return nil
 }
+
+func validateLanguage(language string) error {
+	_, exists := languages.Get()[language]
+	if !exists {
+		return websites.ErrInvalidLanguage
+	}
+
+	return nil
+}
+
+// validateWebsiteLanguages validates the languages of a multilingual website. Path prefixes can't be
+// shared between languages or shadow the special paths of websites.
+func validateWebsiteLanguages(websiteLanguages websites.WebsiteLanguages) error {
+	if len(websiteLanguages) > websites.WebsiteMaxLanguages {
+		return websites.ErrWebsiteHasTooManyLanguages
+	}
+
+	codes := make(map[string]bool, len(websiteLanguages))
+	pathPrefixes := make(map[string]bool, len(websiteLanguages))
+	for _, language := range websiteLanguages {
+		err := validateLanguage(language.Code)
+		if err != nil {
+			return err
+		}
+		if codes[language.Code] {
+			return websites.ErrWebsiteLanguageIsDuplicated(language.Code)
+		}
+		codes[language.Code] = true
+
+		if language.PathPrefix != "" {
+			if !websites.LanguagePathPrefixRegexp.MatchString(language.PathPrefix) {
+				return websites.ErrLanguagePathPrefixIsNotValid(language.PathPrefix)
+			}
+			if pathPrefixes[language.PathPrefix] ||
+				language.PathPrefix == "/assets" || language.PathPrefix == "/theme" {
+				return websites.ErrLanguagePathPrefixIsAlreadyInUse(language.PathPrefix)
+			}
+			pathPrefixes[language.PathPrefix] = true
+		}
+
+		if language.Navigation != nil {
+			err = validateWebsiteNavigation(language.Navigation)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
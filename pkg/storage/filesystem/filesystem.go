@@ -0,0 +1,369 @@
+package filesystem
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"markdown.ninja/pkg/storage"
+)
+
+// ensure that Storage satisfies the storage.Storage interface
+var _ storage.Storage = (*Storage)(nil)
+
+const (
+	directoryPermissions = 0o750
+	filePermissions      = 0o640
+	tmpFilePattern       = ".tmp-*"
+)
+
+var (
+	ErrKeyIsNotValid   = errors.New("filesystem: object key is not valid")
+	ErrRangeIsNotValid = errors.New("filesystem: range is not valid")
+
+	rangeRegexp = regexp.MustCompile(`^bytes=(\d*)-(\d*)$`)
+)
+
+// Storage is a storage.Storage backed by a local directory. Objects are stored as regular files
+// and keys are mapped to paths relative to the root directory.
+type Storage struct {
+	directory string
+}
+
+type Config struct {
+	// Directory is the root directory where objects are stored. It is created if it doesn't exist.
+	Directory string
+}
+
+func NewStorage(config Config) (*Storage, error) {
+	directory := strings.TrimSpace(config.Directory)
+	if directory == "" {
+		return nil, errors.New("filesystem: directory is empty")
+	}
+
+	directory, err := filepath.Abs(directory)
+	if err != nil {
+		return nil, fmt.Errorf("filesystem: getting absolute path of directory: %w", err)
+	}
+
+	err = os.MkdirAll(directory, directoryPermissions)
+	if err != nil {
+		return nil, fmt.Errorf("filesystem: creating directory (%s): %w", directory, err)
+	}
+
+	return &Storage{
+		directory: directory,
+	}, nil
+}
+
+func (fsStorage *Storage) BasePath() string {
+	return fsStorage.directory
+}
+
+func (fsStorage *Storage) CopyObject(ctx context.Context, from string, to string) error {
+	fromPath, err := fsStorage.objectPath(from)
+	if err != nil {
+		return err
+	}
+
+	toPath, err := fsStorage.objectPath(to)
+	if err != nil {
+		return err
+	}
+
+	source, err := os.Open(fromPath)
+	if err != nil {
+		return fmt.Errorf("filesystem: opening source object (%s): %w", from, err)
+	}
+	defer source.Close()
+
+	return writeFileAtomically(ctx, toPath, func(file *os.File) error {
+		_, err := io.Copy(file, contextReader{ctx: ctx, reader: source})
+		return err
+	})
+}
+
+func (fsStorage *Storage) DeleteObject(ctx context.Context, key string) error {
+	objectPath, err := fsStorage.objectPath(key)
+	if err != nil {
+		return err
+	}
+
+	// like S3, deleting an object that doesn't exist is not an error
+	err = os.Remove(objectPath)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("filesystem: deleting object (%s): %w", key, err)
+	}
+
+	return nil
+}
+
+func (fsStorage *Storage) GetObject(ctx context.Context, key string, options *storage.GetObjectOptions) (io.ReadCloser, error) {
+	objectPath, err := fsStorage.objectPath(key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(objectPath)
+	if err != nil {
+		return nil, fmt.Errorf("filesystem: opening object (%s): %w", key, err)
+	}
+
+	if options == nil || options.Range == nil {
+		return file, nil
+	}
+
+	fileInfo, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("filesystem: getting object info (%s): %w", key, err)
+	}
+
+	offset, length, err := parseRange(*options.Range, fileInfo.Size())
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	_, err = file.Seek(offset, io.SeekStart)
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("filesystem: seeking object (%s): %w", key, err)
+	}
+
+	return limitedReadCloser{Reader: io.LimitReader(file, length), Closer: file}, nil
+}
+
+func (fsStorage *Storage) GetObjectSize(ctx context.Context, key string) (int64, error) {
+	objectPath, err := fsStorage.objectPath(key)
+	if err != nil {
+		return 0, err
+	}
+
+	fileInfo, err := os.Stat(objectPath)
+	if err != nil {
+		return 0, fmt.Errorf("filesystem: getting object info (%s): %w", key, err)
+	}
+
+	return fileInfo.Size(), nil
+}
+
+func (fsStorage *Storage) PutObject(ctx context.Context, key string, size int64, object io.Reader, options *storage.PutObjectOptions) error {
+	objectPath, err := fsStorage.objectPath(key)
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomically(ctx, objectPath, func(file *os.File) error {
+		hasher := sha256.New()
+		// we read one more byte than expected to detect objects that are bigger than size
+		written, err := io.Copy(io.MultiWriter(file, hasher), io.LimitReader(contextReader{ctx: ctx, reader: object}, size+1))
+		if err != nil {
+			return err
+		}
+
+		if written != size {
+			return fmt.Errorf("filesystem: object size (%d) doesn't match the expected size (%d)", written, size)
+		}
+
+		if options != nil && options.HashSha256 != nil {
+			if !bytes.Equal(hasher.Sum(nil), options.HashSha256) {
+				return errors.New("filesystem: object SHA-256 hash doesn't match the expected hash")
+			}
+		}
+
+		return nil
+	})
+}
+
+// DeleteObjectsWithPrefix deletes all the objects whose key starts with prefix. Like S3, prefix is
+// a string prefix and not necessarily a directory.
+func (fsStorage *Storage) DeleteObjectsWithPrefix(ctx context.Context, prefix string) (err error) {
+	prefixPath, err := fsStorage.objectPath(prefix)
+	if err != nil {
+		return err
+	}
+
+	parentDirectory := filepath.Dir(prefixPath)
+	err = filepath.WalkDir(parentDirectory, func(path string, entry fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			if errors.Is(walkErr, fs.ErrNotExist) {
+				return nil
+			}
+			return walkErr
+		}
+
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		if !strings.HasPrefix(path, prefixPath) {
+			if entry.IsDir() && path != parentDirectory {
+				return fs.SkipDir
+			}
+			return nil
+		}
+
+		if entry.IsDir() {
+			removeErr := os.RemoveAll(path)
+			if removeErr != nil {
+				return removeErr
+			}
+			return fs.SkipDir
+		}
+
+		return os.Remove(path)
+	})
+	if err != nil {
+		return fmt.Errorf("filesystem: deleting objects with prefix (%s): %w", prefix, err)
+	}
+
+	return nil
+}
+
+// objectPath returns the absolute path of the object identified by key and makes sure that it can't
+// escape the root directory.
+func (fsStorage *Storage) objectPath(key string) (string, error) {
+	if key == "" || strings.ContainsRune(key, 0) {
+		return "", ErrKeyIsNotValid
+	}
+
+	// joining with "/" before cleaning removes all the leading ".." elements
+	cleanKey := filepath.Clean("/" + filepath.ToSlash(key))
+	if cleanKey == "/" {
+		return "", ErrKeyIsNotValid
+	}
+
+	objectPath := filepath.Join(fsStorage.directory, filepath.FromSlash(cleanKey))
+	if !strings.HasPrefix(objectPath, fsStorage.directory+string(filepath.Separator)) {
+		return "", ErrKeyIsNotValid
+	}
+
+	// keep the trailing slash for prefixes
+	if strings.HasSuffix(key, "/") {
+		objectPath += string(filepath.Separator)
+	}
+
+	return objectPath, nil
+}
+
+// writeFileAtomically writes a temporary file in the destination directory using write and then
+// renames it to path, so readers never see a partially-written object.
+func writeFileAtomically(ctx context.Context, path string, write func(file *os.File) error) (err error) {
+	directory := filepath.Dir(path)
+	err = os.MkdirAll(directory, directoryPermissions)
+	if err != nil {
+		return fmt.Errorf("filesystem: creating directory (%s): %w", directory, err)
+	}
+
+	tmpFile, err := os.CreateTemp(directory, tmpFilePattern)
+	if err != nil {
+		return fmt.Errorf("filesystem: creating temporary file: %w", err)
+	}
+	tmpFilePath := tmpFile.Name()
+	defer func() {
+		if err != nil {
+			tmpFile.Close()
+			os.Remove(tmpFilePath)
+		}
+	}()
+
+	err = write(tmpFile)
+	if err != nil {
+		return fmt.Errorf("filesystem: writing object: %w", err)
+	}
+
+	err = ctx.Err()
+	if err != nil {
+		return err
+	}
+
+	err = tmpFile.Chmod(filePermissions)
+	if err != nil {
+		return fmt.Errorf("filesystem: setting permissions of temporary file: %w", err)
+	}
+
+	err = tmpFile.Sync()
+	if err != nil {
+		return fmt.Errorf("filesystem: syncing temporary file: %w", err)
+	}
+
+	err = tmpFile.Close()
+	if err != nil {
+		return fmt.Errorf("filesystem: closing temporary file: %w", err)
+	}
+
+	err = os.Rename(tmpFilePath, path)
+	if err != nil {
+		return fmt.Errorf("filesystem: renaming temporary file: %w", err)
+	}
+
+	return nil
+}
+
+// parseRange parses an HTTP-like range (e.g. bytes=0-499, bytes=500- or bytes=-500) and returns the
+// offset and the length of the requested part.
+func parseRange(rangeStr string, size int64) (offset, length int64, err error) {
+	matches := rangeRegexp.FindStringSubmatch(strings.TrimSpace(rangeStr))
+	if len(matches) != 3 || (matches[1] == "" && matches[2] == "") {
+		err = ErrRangeIsNotValid
+		return
+	}
+
+	// suffix range: bytes=-500 means the last 500 bytes
+	if matches[1] == "" {
+		var suffixLength int64
+		suffixLength, err = strconv.ParseInt(matches[2], 10, 64)
+		if err != nil || suffixLength <= 0 {
+			err = ErrRangeIsNotValid
+			return
+		}
+		suffixLength = min(suffixLength, size)
+		return size - suffixLength, suffixLength, nil
+	}
+
+	from, err := strconv.ParseInt(matches[1], 10, 64)
+	if err != nil || from >= size {
+		err = ErrRangeIsNotValid
+		return
+	}
+
+	to := size - 1
+	if matches[2] != "" {
+		to, err = strconv.ParseInt(matches[2], 10, 64)
+		if err != nil || to < from {
+			err = ErrRangeIsNotValid
+			return
+		}
+		to = min(to, size-1)
+	}
+
+	return from, to - from + 1, nil
+}
+
+type limitedReadCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// contextReader stops reading as soon as ctx is canceled
+type contextReader struct {
+	ctx    context.Context
+	reader io.Reader
+}
+
+func (reader contextReader) Read(buffer []byte) (int, error) {
+	if err := reader.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return reader.reader.Read(buffer)
+}
@@ -0,0 +1,189 @@
+package filesystem
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"io"
+	"testing"
+
+	"markdown.ninja/pkg/storage"
+)
+
+func TestPutAndGetObject(t *testing.T) {
+	ctx := context.Background()
+	fsStorage, err := NewStorage(Config{Directory: t.TempDir()})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data := []byte("Hello World")
+	hash := sha256.Sum256(data)
+
+	err = fsStorage.PutObject(ctx, "websites/a/assets/1", int64(len(data)), bytes.NewReader(data), &storage.PutObjectOptions{HashSha256: hash[:]})
+	if err != nil {
+		t.Fatalf("PutObject: %v", err)
+	}
+
+	object, err := fsStorage.GetObject(ctx, "websites/a/assets/1", nil)
+	if err != nil {
+		t.Fatalf("GetObject: %v", err)
+	}
+	result, _ := io.ReadAll(object)
+	object.Close()
+	if !bytes.Equal(result, data) {
+		t.Errorf("Invalid object. Got: %s | Expected: %s", result, data)
+	}
+
+	size, err := fsStorage.GetObjectSize(ctx, "websites/a/assets/1")
+	if err != nil {
+		t.Fatalf("GetObjectSize: %v", err)
+	}
+	if size != int64(len(data)) {
+		t.Errorf("Invalid size. Got: %d | Expected: %d", size, len(data))
+	}
+
+	invalidHash := sha256.Sum256([]byte("invalid"))
+	err = fsStorage.PutObject(ctx, "websites/a/assets/2", int64(len(data)), bytes.NewReader(data), &storage.PutObjectOptions{HashSha256: invalidHash[:]})
+	if err == nil {
+		t.Error("PutObject should fail when the hash doesn't match")
+	}
+
+	err = fsStorage.PutObject(ctx, "websites/a/assets/2", int64(len(data))-1, bytes.NewReader(data), nil)
+	if err == nil {
+		t.Error("PutObject should fail when the size doesn't match")
+	}
+
+	_, err = fsStorage.GetObjectSize(ctx, "websites/a/assets/2")
+	if err == nil {
+		t.Error("failed PutObject should not leave an object behind")
+	}
+}
+
+func TestGetObjectRange(t *testing.T) {
+	ctx := context.Background()
+	fsStorage, err := NewStorage(Config{Directory: t.TempDir()})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data := []byte("0123456789")
+	err = fsStorage.PutObject(ctx, "object", int64(len(data)), bytes.NewReader(data), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		Range    string
+		Expected string
+	}{
+		{"bytes=0-0", "0"},
+		{"bytes=2-5", "2345"},
+		{"bytes=7-", "789"},
+		{"bytes=-3", "789"},
+		{"bytes=8-100", "89"},
+	}
+
+	for _, test := range tests {
+		object, err := fsStorage.GetObject(ctx, "object", &storage.GetObjectOptions{Range: &test.Range})
+		if err != nil {
+			t.Errorf("GetObject(%s): %v", test.Range, err)
+			continue
+		}
+		result, _ := io.ReadAll(object)
+		object.Close()
+		if string(result) != test.Expected {
+			t.Errorf("Invalid result for %s. Got: %s | Expected: %s", test.Range, result, test.Expected)
+		}
+	}
+
+	for _, invalidRange := range []string{"bytes=10-", "bytes=5-2", "bytes=-", "0-1"} {
+		_, err := fsStorage.GetObject(ctx, "object", &storage.GetObjectOptions{Range: &invalidRange})
+		if err == nil {
+			t.Errorf("GetObject(%s) should fail", invalidRange)
+		}
+	}
+}
+
+func TestObjectPath(t *testing.T) {
+	fsStorage := &Storage{directory: "/data"}
+
+	tests := []struct {
+		Key      string
+		Expected string
+		Valid    bool
+	}{
+		{"websites/a/icon.png", "/data/websites/a/icon.png", true},
+		{"/websites/a", "/data/websites/a", true},
+		{"websites/a/", "/data/websites/a/", true},
+		{"../etc/passwd", "/data/etc/passwd", true},
+		{"websites/../../etc/passwd", "/data/etc/passwd", true},
+		{"", "", false},
+		{"/", "", false},
+		{"..", "", false},
+	}
+
+	for _, test := range tests {
+		result, err := fsStorage.objectPath(test.Key)
+		if test.Valid != (err == nil) {
+			t.Errorf("Invalid error for %s: %v", test.Key, err)
+			continue
+		}
+		if result != test.Expected {
+			t.Errorf("Invalid path for %s. Got: %s | Expected: %s", test.Key, result, test.Expected)
+		}
+	}
+}
+
+func TestCopyAndDeleteObjects(t *testing.T) {
+	ctx := context.Background()
+	fsStorage, err := NewStorage(Config{Directory: t.TempDir()})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data := []byte("data")
+	keys := []string{"websites/abc/assets/1", "websites/abc/assets/2", "websites/abcd/assets/1", "websites/xyz/assets/1"}
+	for _, key := range keys {
+		err = fsStorage.PutObject(ctx, key, int64(len(data)), bytes.NewReader(data), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	err = fsStorage.CopyObject(ctx, "websites/xyz/assets/1", "websites/xyz/assets/2")
+	if err != nil {
+		t.Fatalf("CopyObject: %v", err)
+	}
+	if _, err = fsStorage.GetObjectSize(ctx, "websites/xyz/assets/2"); err != nil {
+		t.Errorf("copied object not found: %v", err)
+	}
+
+	// string prefix, like S3: both websites/abc and websites/abcd should be deleted
+	err = fsStorage.DeleteObjectsWithPrefix(ctx, "websites/abc")
+	if err != nil {
+		t.Fatalf("DeleteObjectsWithPrefix: %v", err)
+	}
+	for _, key := range keys[:3] {
+		if _, err = fsStorage.GetObjectSize(ctx, key); err == nil {
+			t.Errorf("object %s should have been deleted", key)
+		}
+	}
+
+	err = fsStorage.DeleteObject(ctx, "websites/xyz/assets/1")
+	if err != nil {
+		t.Fatalf("DeleteObject: %v", err)
+	}
+	if _, err = fsStorage.GetObjectSize(ctx, "websites/xyz/assets/1"); err == nil {
+		t.Error("object should have been deleted")
+	}
+	if _, err = fsStorage.GetObjectSize(ctx, "websites/xyz/assets/2"); err != nil {
+		t.Errorf("object should not have been deleted: %v", err)
+	}
+
+	// deleting an object that doesn't exist is not an error
+	err = fsStorage.DeleteObject(ctx, "websites/xyz/assets/1")
+	if err != nil {
+		t.Errorf("DeleteObject of a missing object: %v", err)
+	}
+}
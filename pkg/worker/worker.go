@@ -15,13 +15,14 @@ import (
 	"markdown.ninja/pkg/services/organizations"
 	"markdown.ninja/pkg/services/site"
 	"markdown.ninja/pkg/services/store"
+	"markdown.ninja/pkg/services/webhooks"
 	"markdown.ninja/pkg/services/websites"
 )
 
 func Start(ctx context.Context, logger *slog.Logger, concurrencyMax uint32, queuee queue.Queue, kernelService kernel.PrivateService,
 	websitesService websites.Service, emailsService emails.Service, storeService store.Service,
 	contentService content.Service, siteService site.Service, contactsService contacts.Service,
-	eventsService events.Service, organizationsService organizations.Service) (err error) {
+	eventsService events.Service, organizationsService organizations.Service, webhooksService webhooks.Service) (err error) {
 	if logger == nil {
 		return errors.New("worker: logger is null")
 	}
@@ -76,6 +77,14 @@ func Start(ctx context.Context, logger *slog.Logger, concurrencyMax uint32, queu
 	workerpool.AddHandler(workerPool, eventsService.JobDeleteWebsiteEvents)
 	workerpool.AddHandler(workerPool, eventsService.JobDeleteOrganizationEvents)
 	workerpool.AddHandler(workerPool, eventsService.JobRotateAnonymousIDSalt)
+	workerpool.AddHandler(workerPool, eventsService.JobRollupPageViews)
+	workerpool.AddHandler(workerPool, eventsService.JobExportAnalytics)
+	workerpool.AddHandler(workerPool, eventsService.JobDeleteExpiredAnalyticsExports)
+	workerpool.AddHandler(workerPool, eventsService.JobDispatchSendAnalyticsReports)
+	workerpool.AddHandler(workerPool, eventsService.JobSendAnalyticsReport)
+
+	// webhooks
+	workerpool.AddHandler(workerPool, webhooksService.JobDeliverWebhook)
 
 	workerPool.Start(ctx)
 
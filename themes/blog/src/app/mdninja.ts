@@ -191,7 +191,7 @@ export async function listTags(): Promise<model.PaginatedResult<model.Tag>> {
 
 export async function listPages(input: model.ListPagesInput): Promise<model.PaginatedResult<model.PageMetadata>> {
   const pages: model.PaginatedResult<model.PageMetadata> = await get(Routes.pages, input);
-  if (!input.tag) {
+  if (!input.tag && !input.author) {
     const $store = useStore();
     $store.setAllPages(pages.data);
   }
@@ -199,6 +199,14 @@ export async function listPages(input: model.ListPagesInput): Promise<model.Pagi
   return pages;
 }
 
+export async function getAuthor(input: model.GetAuthorInput): Promise<model.Author> {
+  return await get(Routes.author, input);
+}
+
+export async function search(input: model.SearchInput): Promise<model.PaginatedResult<model.SearchResult>> {
+  return await get(Routes.search, input);
+}
+
 // TODO: uncomment?
 // The value of this data is relatively low...
 export async function trackPage() {
@@ -212,6 +220,16 @@ export async function trackPage() {
   // await post(Routes.eventsPageView, input);
 }
 
+// trackEvent tracks a custom event, which can be used as a goal. e.g. trackEvent('download_ebook')
+export async function trackEvent(name: string, properties?: Record<string, string>) {
+  const input: model.TrackEventCustomInput = {
+    name: name,
+    path: window.location.pathname,
+    properties: properties,
+  };
+  await post(Routes.eventsCustom, input);
+}
+
 export async function login(email: string): Promise<model.LoginOutput> {
   const input: model.LoginInput = {
     email: email,
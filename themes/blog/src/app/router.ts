@@ -5,6 +5,8 @@ const Any = () =>  import('@/ui/pages/any.vue');
 const Blog = () =>  import('@/ui/pages/blog.vue');
 const Tags = () =>  import('@/ui/pages/tags.vue');
 const Tag = () =>  import('@/ui/pages/tag.vue');
+const Author = () =>  import('@/ui/pages/author.vue');
+const Search = () =>  import('@/ui/pages/search.vue');
 const Subscribe = () =>  import('@/ui/pages/subscribe.vue');
 const Unsubscribe = () =>  import('@/ui/pages/unsubscribe.vue');
 const Checkout = () =>  import('@/ui/pages/checkout/checkout.vue');
@@ -25,6 +27,8 @@ export function newRouter(): Router {
       { path: '/blog', component: Blog },
       { path: '/tags', component: Tags },
       { path: '/tags/:tag', component: Tag },
+      { path: '/authors/:author', component: Author },
+      { path: '/search', component: Search },
       { path: '/checkout', component: Checkout },
       { path: '/checkout/:order_id/complete', component: CompleteCheckout },
       { path: '/checkout/:order_id/cancel', component: CancelCheckout },
@@ -3,8 +3,11 @@ export const Routes = {
   page: '/page',
   website: '/website',
   tags: '/tags',
+  author: '/author',
   pages: '/pages',
+  search: '/search',
   eventsPageView: '/events/page_view',
+  eventsCustom: '/events/custom',
   login: '/login',
   completeLogin: '/complete_login',
   logout: '/logout',
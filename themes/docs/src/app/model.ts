@@ -15,6 +15,8 @@ export enum ProductType {
   Book = "book",
   Course = "course",
   Download = "download",
+  Subscription = "subscription",
+  Bundle = "bundle",
 };
 
 export enum BlockType {
@@ -65,6 +67,8 @@ export type Website = {
   description: string;
   navigation: WebsiteNavigation;
   language: string;
+  // empty if the website is not multilingual
+  languages: WebsiteLanguage[];
   ad: string | null;
   announcement: string | null;
   logo: string | null;
@@ -80,6 +84,14 @@ export type WebsiteNavigation = {
   secondary: WebsiteNavigationItem[],
 }
 
+export type WebsiteLanguage = {
+  code: string;
+  // native name of the language
+  name: string;
+  url: string;
+  navigation: WebsiteNavigation | null;
+};
+
 export type WebsiteNavigationItem = {
   url?: string;
   label: string;
@@ -95,11 +107,42 @@ export type PageMetadata = {
   description: string;
   body_hash: string;
   metadata_hash: string,
+  paid_only: boolean,
 };
 
 export type Page = PageMetadata & {
   body: string;
   tags: Tag[];
+  authors: Author[];
+  // versions of the page in other languages
+  translations: PageTranslation[];
+  // paywall is not null when body is only a teaser of a paid-only page
+  paywall: Paywall | null;
+}
+
+export type PageTranslation = {
+  language: string;
+  title: string;
+  url: string;
+}
+
+export type Paywall = {
+  currency: string;
+  plans: SubscriptionPlan[];
+}
+
+export type SubscriptionPlan = {
+  product_id: string;
+  name: string;
+  description: string;
+  monthly_price: number;
+  // yearly_price is 0 if the plan can't be paid yearly
+  yearly_price: number;
+}
+
+export enum SubscriptionInterval {
+  Month = "month",
+  Year = "year",
 }
 
 // export type Block = {
@@ -113,6 +156,20 @@ export type Tag = {
   description: string;
 };
 
+export type Author = {
+  slug: string;
+  name: string;
+  bio: string;
+  avatar: string | null;
+  social_links: AuthorSocialLink[];
+  url: string;
+};
+
+export type AuthorSocialLink = {
+  name: string;
+  url: string;
+};
+
 export type LoginOutput = {
   session_id: string;
 };
@@ -163,13 +220,42 @@ export type GetPageInput = {
 
 export type ListPagesInput = {
   tag?: string,
+  // slug of the author
+  author?: string,
   type?: PageType,
 }
 
+export type GetAuthorInput = {
+  slug: string;
+}
+
+export type SearchInput = {
+  q: string,
+  lang?: string,
+  type?: PageType,
+}
+
+export type SearchResult = PageMetadata & {
+  // HTML where the matching terms are wrapped in <mark> tags
+  title_highlight: string;
+  body_highlight: string;
+}
+
 export type TrackEventPageViewInput = {
   path: string;
   header_referrer: string;
   query_parameter_ref: string;
+  utm_source?: string;
+  utm_medium?: string;
+  utm_campaign?: string;
+  utm_term?: string;
+  utm_content?: string;
+}
+
+export type TrackEventCustomInput = {
+  name: string;
+  path: string;
+  properties?: Record<string, string>;
 }
 
 export type LoginInput = {
@@ -215,6 +301,9 @@ export type PlaceOrderInput = {
   products: string[];
   email?: string;
   subscribe_to_newsletter: boolean;
+  subscription_interval?: SubscriptionInterval;
+  // code of the coupon
+  coupon?: string;
 }
 
 export type CompleteOrderInput = {
@@ -4,6 +4,8 @@ import { useStore } from './store';
 const Any = () =>  import('@/ui/pages/any.vue');
 const Tags = () =>  import('@/ui/pages/tags.vue');
 const Tag = () =>  import('@/ui/pages/tag.vue');
+const Author = () =>  import('@/ui/pages/author.vue');
+const Search = () =>  import('@/ui/pages/search.vue');
 
 
 export function newRouter(): Router {
@@ -12,6 +14,8 @@ export function newRouter(): Router {
     routes: [
       { path: '/tags', component: Tags },
       { path: '/tags/:tag', component: Tag },
+      { path: '/authors/:author', component: Author },
+      { path: '/search', component: Search },
 
       { path: '/:path(.*)*', component: Any, name: 'any' },
     ],
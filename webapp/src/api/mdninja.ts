@@ -96,6 +96,102 @@ async function upload(route: string, data: FormData): Promise<any> {
   return await unwrapApiResponse(response);
 }
 
+// download sends a POST request and returns the response body as a Blob instead of decoding it as JSON
+async function download<I>(route: string, data: I): Promise<Blob> {
+  const url = `${API_BASE_URL}${route}`;
+  let response: any = null;
+  const pingoo = usePingoo();
+
+  const headers = new Headers();
+  headers.set('Content-Type', 'application/json');
+  if (pingoo.isAuthenticated()) {
+    headers.set('Authorization', `Bearer ${await pingoo.getAccessToken()}`);
+  }
+
+  try {
+    response = await fetch(url, {
+      method: 'POST',
+      headers,
+      body: JSON.stringify(data),
+    });
+  } catch (err: any) {
+    throw new Error(networkErrorMessage);
+  }
+
+  if (!response.ok) {
+    // errors are always sent as JSON
+    await unwrapApiResponse(response);
+    throw new Error(INTERNAL_ERROR_MESSAGE);
+  }
+
+  return await response.blob();
+}
+
+// stream sends a POST request and calls onEvent with the data of each server-sent event until the server
+// ends the stream or signal is aborted.
+// We don't use EventSource because it can't send the Authorization header.
+async function stream<I>(route: string, data: I, onEvent: (data: any) => void, signal: AbortSignal): Promise<void> {
+  const url = `${API_BASE_URL}${route}`;
+  let response: any = null;
+  const pingoo = usePingoo();
+
+  const headers = new Headers();
+  headers.set('Content-Type', 'application/json');
+  headers.set('Accept', 'text/event-stream');
+  if (pingoo.isAuthenticated()) {
+    headers.set('Authorization', `Bearer ${await pingoo.getAccessToken()}`);
+  }
+
+  try {
+    response = await fetch(url, {
+      method: 'POST',
+      headers,
+      body: JSON.stringify(data),
+      signal,
+    });
+  } catch (err: any) {
+    if (signal.aborted) {
+      return;
+    }
+    throw new Error(networkErrorMessage);
+  }
+
+  if (!response.ok || !response.body) {
+    // errors are always sent as JSON
+    await unwrapApiResponse(response);
+    throw new Error(INTERNAL_ERROR_MESSAGE);
+  }
+
+  const reader = response.body.pipeThrough(new TextDecoderStream()).getReader();
+  let buffer = '';
+  try {
+    while (true) {
+      const { value, done } = await reader.read();
+      if (done) {
+        return;
+      }
+
+      buffer += value;
+      // events are separated by an empty line
+      const events = buffer.split('\n\n');
+      buffer = events.pop()!;
+      for (const event of events) {
+        const dataLines = event.split('\n')
+          .filter((line) => line.startsWith('data:'))
+          .map((line) => line.slice('data:'.length).trim());
+        if (dataLines.length !== 0) {
+          onEvent(JSON.parse(dataLines.join('\n')));
+        }
+      }
+    }
+  } catch (err: any) {
+    if (signal.aborted) {
+      return;
+    }
+    throw new Error(networkErrorMessage);
+  }
+}
+
 async function unwrapApiResponse(response: Response): Promise<any>  {
   // if the status code is >= 500 or the response is not JSON then something has gone really wrong
   if (response.status >= 500 || !response.headers.get('Content-Type')?.includes('application/json')) {
@@ -166,6 +262,47 @@ export class MdninjaService {
     return res;
   }
 
+  async createGoal(input: model.CreateGoalInput): Promise<model.Goal> {
+    return await post(Routes.createGoal, input);
+  }
+
+  async updateGoal(input: model.UpdateGoalInput): Promise<model.Goal> {
+    return await post(Routes.updateGoal, input);
+  }
+
+  async deleteGoal(input: model.DeleteGoalInput): Promise<void> {
+    await post(Routes.deleteGoal, input);
+  }
+
+  async listGoals(input: model.ListGoalsInput): Promise<model.PaginatedResult<model.Goal>> {
+    return await post(Routes.goals, input);
+  }
+
+  async createAnalyticsExport(input: model.CreateAnalyticsExportInput): Promise<model.AnalyticsExport> {
+    return await post(Routes.createAnalyticsExport, input);
+  }
+
+  async listAnalyticsExports(input: model.ListAnalyticsExportsInput): Promise<model.PaginatedResult<model.AnalyticsExport>> {
+    return await post(Routes.analyticsExports, input);
+  }
+
+  async downloadAnalyticsExport(input: model.DownloadAnalyticsExportInput): Promise<Blob> {
+    return await download(Routes.downloadAnalyticsExport, input);
+  }
+
+  async getAnalyticsReportSettings(input: model.GetAnalyticsReportSettingsInput): Promise<model.AnalyticsReportSettings> {
+    return await post(Routes.analyticsReportSettings, input);
+  }
+
+  async updateAnalyticsReportSettings(input: model.UpdateAnalyticsReportSettingsInput): Promise<model.AnalyticsReportSettings> {
+    return await post(Routes.updateAnalyticsReportSettings, input);
+  }
+
+  async streamLiveAnalytics(input: model.StreamLiveAnalyticsInput, onData: (data: model.LiveAnalytics) => void,
+    signal: AbortSignal): Promise<void> {
+    return await stream(Routes.liveAnalytics, input, onData, signal);
+  }
+
   //////////////////////////////////////////////////////////////////////////////////////////////////
   // Contacts
   //////////////////////////////////////////////////////////////////////////////////////////////////
@@ -228,6 +365,35 @@ export class MdninjaService {
     return res;
   }
 
+  //////////////////////////////////////////////////////////////////////////////////////////////////
+  // Labels
+  //////////////////////////////////////////////////////////////////////////////////////////////////
+
+  async createLabel(input: model.CreateLabelInput): Promise<model.Label> {
+    const res: model.Label = await post(Routes.createLabel, input);
+
+    return res;
+  }
+
+  async updateLabel(input: model.UpdateLabelInput): Promise<model.Label> {
+    const res: model.Label = await post(Routes.updateLabel, input);
+
+    return res;
+  }
+
+  async deleteLabel(labelId: string): Promise<void> {
+    const input: model.DeleteLabelInput = {
+      id: labelId,
+    };
+    await post(Routes.deleteLabel, input);
+  }
+
+  async listLabels(input: model.ListLabelsInput): Promise<model.PaginatedResult<model.Label>> {
+    const res: model.PaginatedResult<model.Label> = await post(Routes.labels, input);
+
+    return res;
+  }
+
   //////////////////////////////////////////////////////////////////////////////////////////////////
   // Content
   //////////////////////////////////////////////////////////////////////////////////////////////////
@@ -258,6 +424,32 @@ export class MdninjaService {
     return page;
   }
 
+  async listPageRevisions(input: model.ListPageRevisionsInput): Promise<model.PaginatedResult<model.PageRevisionMetadata>> {
+    const revisions: model.PaginatedResult<model.PageRevisionMetadata> = await post(Routes.pageRevisions, input);
+    return revisions;
+  }
+
+  async fetchPageRevision(revisionID: string): Promise<model.PageRevision> {
+    const input: model.GetPageRevisionInput = {
+      id: revisionID,
+    };
+    const revision: model.PageRevision = await post(Routes.pageRevision, input);
+    return revision;
+  }
+
+  async diffPageRevisions(input: model.DiffPageRevisionsInput): Promise<model.PageRevisionsDiff> {
+    const diff: model.PageRevisionsDiff = await post(Routes.diffPageRevisions, input);
+    return diff;
+  }
+
+  async restorePageRevision(revisionID: string): Promise<model.Page> {
+    const input: model.RestorePageRevisionInput = {
+      id: revisionID,
+    };
+    const page: model.Page = await post(Routes.restorePageRevision, input);
+    return page;
+  }
+
   async uploadAsset(input: model.UploadAssetInput): Promise<model.Asset> {
     const formData = new FormData();
     formData.append('website_id', input.website_id);
@@ -360,6 +552,31 @@ export class MdninjaService {
     return res;
   }
 
+  async createAuthor(input: model.CreateAuthorInput): Promise<model.Author> {
+    const author: model.Author = await post(Routes.createAuthor, input);
+
+    return author;
+  }
+
+  async updateAuthor(input: model.UpdateAuthorInput): Promise<model.Author> {
+    const author: model.Author = await post(Routes.updateAuthor, input);
+
+    return author;
+  }
+
+  async deleteAuthor(authorId: string): Promise<void> {
+    const input: model.DeleteAuthorInput = {
+      id: authorId,
+    };
+    await post(Routes.deleteAuthor, input);
+  }
+
+  async listAuthors(input: model.ListAuthorsInput): Promise<model.PaginatedResult<model.Author>> {
+    const res: model.PaginatedResult<model.Author> = await post(Routes.authors, input);
+
+    return res;
+  }
+
   async listPosts(input: model.ListPostsInput): Promise<model.PaginatedResult<model.PageMetadata>> {
     const res: model.PaginatedResult<model.PageMetadata> = await post(Routes.posts, input);
     return res;
@@ -571,6 +788,14 @@ export class MdninjaService {
     return await post(Routes.addStaffs, input);
   }
 
+  async listAuditLogs(input: model.ListAuditLogsInput): Promise<model.PaginatedResult<model.AuditLogEntry>> {
+    return await post(Routes.organizationAuditLogs, input);
+  }
+
+  async exportAuditLogs(input: model.ExportAuditLogsInput): Promise<model.ExportAuditLogsOutput> {
+    return await post(Routes.organizationExportAuditLogs, input);
+  }
+
   //////////////////////////////////////////////////////////////////////////////////////////////////
   // Store
   //////////////////////////////////////////////////////////////////////////////////////////////////
@@ -778,6 +1003,12 @@ export class MdninjaService {
     return res;
   }
 
+  async importRedirects(input: model.ImportRedirectsInput): Promise<model.ImportRedirectsOutput> {
+    const res: model.ImportRedirectsOutput = await post(Routes.importRedirects, input);
+
+    return res;
+  }
+
   async listAllWebsites(input: model.ListWebsitesInput): Promise<model.PaginatedResult<model.Website>> {
     const res: model.PaginatedResult<model.Website> = await post(Routes.allWebsites, input);
     return res;
@@ -790,6 +1021,34 @@ export class MdninjaService {
 
     await upload(Routes.websiteUpdateIcon, formData);
   }
+
+  //////////////////////////////////////////////////////////////////////////////////////////////////
+  // Webhooks
+  //////////////////////////////////////////////////////////////////////////////////////////////////
+
+  async createWebhookEndpoint(input: model.CreateWebhookEndpointInput): Promise<model.WebhookEndpoint> {
+    return await post(Routes.createWebhookEndpoint, input);
+  }
+
+  async updateWebhookEndpoint(input: model.UpdateWebhookEndpointInput): Promise<model.WebhookEndpoint> {
+    return await post(Routes.updateWebhookEndpoint, input);
+  }
+
+  async deleteWebhookEndpoint(input: model.DeleteWebhookEndpointInput): Promise<void> {
+    await post(Routes.deleteWebhookEndpoint, input);
+  }
+
+  async listWebhookEndpoints(input: model.ListWebhookEndpointsInput): Promise<model.PaginatedResult<model.WebhookEndpoint>> {
+    return await post(Routes.webhookEndpoints, input);
+  }
+
+  async listWebhookDeliveries(input: model.ListWebhookDeliveriesInput): Promise<model.PaginatedResult<model.WebhookDelivery>> {
+    return await post(Routes.webhookDeliveries, input);
+  }
+
+  async redeliverWebhook(input: model.RedeliverWebhookInput): Promise<model.WebhookDelivery> {
+    return await post(Routes.redeliverWebhook, input);
+  }
 }
 
 export async function getInitData() {
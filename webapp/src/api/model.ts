@@ -10,7 +10,12 @@ export type Counter = {
   count: number;
 };
 
+export type AnalyticsGranularity = 'hour' | 'day' | 'week' | 'month';
+
 export type AnalyticsData = {
+  from: string;
+  to: string;
+  granularity: AnalyticsGranularity;
   total_page_views: number;
   page_views: Counter[];
   total_visitors: number;
@@ -21,10 +26,193 @@ export type AnalyticsData = {
   browsers: Counter[];
   oses: Counter[];
   new_subscribers: number;
+  newsletters: NewsletterStats[];
+  email_links: Counter[];
+  goals: GoalStats[];
+  utm_sources: Counter[];
+  utm_mediums: Counter[];
+  utm_campaigns: Counter[];
+  campaigns: CampaignStats[];
+  previous: AnalyticsComparison | null;
+}
+
+export type CampaignStats = {
+  utm_source: string;
+  utm_medium: string;
+  utm_campaign: string;
+  visitors: number;
+  subscriptions: number;
+  orders: number;
+  revenue: number;
+}
+
+export type GoalStats = {
+  goal_id: string | null;
+  name: string;
+  type: GoalType;
+  conversions: number;
+  conversion_rate: number;
+}
+
+export enum GoalType {
+  CustomEvent = 'custom_event',
+  PageView = 'page_view',
+  NewsletterSubscription = 'newsletter_subscription',
+  OrderCompleted = 'order_completed',
+}
+
+export type Goal = {
+  id: string;
+  created_at: string;
+  updated_at: string;
+  name: string;
+  type: GoalType;
+  event_name: string;
+  path: string;
+  website_id: string;
+}
+
+export type CreateGoalInput = {
+  website_id: string;
+  name: string;
+  type: GoalType;
+  event_name: string;
+  path: string;
+}
+
+export type UpdateGoalInput = {
+  id: string;
+  name?: string;
+  event_name?: string;
+  path?: string;
+}
+
+export type DeleteGoalInput = {
+  id: string;
+}
+
+export type ListGoalsInput = {
+  website_id: string;
+}
+
+export enum AnalyticsExportType {
+  Aggregated = 'aggregated',
+  PageViews = 'page_views',
+}
+
+export enum AnalyticsExportFormat {
+  Csv = 'csv',
+  Json = 'json',
+}
+
+export type AnalyticsExport = {
+  id: string;
+  created_at: string;
+  updated_at: string;
+  type: AnalyticsExportType;
+  format: AnalyticsExportFormat;
+  from: string;
+  to: string;
+  completed_at: string | null;
+  size: number;
+  website_id: string;
+}
+
+export type CreateAnalyticsExportInput = {
+  website_id: string;
+  type: AnalyticsExportType;
+  format: AnalyticsExportFormat;
+  from: string;
+  to: string;
+}
+
+export type ListAnalyticsExportsInput = {
+  website_id: string;
+}
+
+export type DownloadAnalyticsExportInput = {
+  id: string;
+}
+
+export enum AnalyticsReportFrequency {
+  Never = 'never',
+  Weekly = 'weekly',
+  Monthly = 'monthly',
+}
+
+export type AnalyticsReportSettings = {
+  created_at: string;
+  updated_at: string;
+  frequency: AnalyticsReportFrequency;
+  website_id: string;
+}
+
+export type GetAnalyticsReportSettingsInput = {
+  website_id: string;
+}
+
+export type UpdateAnalyticsReportSettingsInput = {
+  website_id: string;
+  frequency: AnalyticsReportFrequency;
+}
+
+export type LiveAnalytics = {
+  current_visitors: number;
+  pages: Counter[];
+  referrers: LiveReferrer[];
+}
+
+export type LiveReferrer = {
+  time: string;
+  referrer: string;
+  path: string;
+  country: string;
+}
+
+export type StreamLiveAnalyticsInput = {
+  website_id: string;
+}
+
+export type AnalyticsComparison = {
+  from: string;
+  to: string;
+  total_page_views: number;
+  page_views: Counter[];
+  total_visitors: number;
+  visitors: Counter[];
+}
+
+export type NewsletterStats = {
+  newsletter_id: string;
+  sent: number;
+  opens: number;
+  clicks: number;
+  bounces: number;
+  complaints: number;
+  open_rate: number;
+  click_rate: number;
+  bounce_rate: number;
+  complaint_rate: number;
 }
 
 export type GetAnalyticsDataInput = {
   website_id: string;
+  from?: string;
+  to?: string;
+  granularity?: AnalyticsGranularity;
+  compare?: boolean;
+  filters?: AnalyticsFilters;
+}
+
+export type AnalyticsFilters = {
+  path?: string;
+  referrer?: string;
+  country?: string;
+  browser?: string;
+  operating_system?: string;
+  utm_source?: string;
+  utm_medium?: string;
+  utm_campaign?: string;
 }
 
 
@@ -50,12 +238,15 @@ export type Contact = {
   country_code: string;
   subscribed_to_newsletter_at: string | null;
   blocked_at: string | null;
+  bounced_at: string | null;
+  complained_at: string | null;
 
   billing_address: Address;
   stripe_customer_id: string | null;
 
   products: Product[] | null;
   orders: Order[] | null;
+  labels: Label[] | null;
 }
 
 export type CreateContactInput = {
@@ -71,6 +262,7 @@ export type UpdateContactInput = {
   subscribed_to_newsletter?: boolean;
 
   billing_address?: Address;
+  labels?: string[];
 }
 
 export type DeleteContactInput = {
@@ -80,6 +272,7 @@ export type DeleteContactInput = {
 export type ListContactsInput = {
   website_id: string;
   query?: string;
+  labels?: string[];
 }
 
 export type GetContactInput = {
@@ -89,6 +282,35 @@ export type GetContactInput = {
 export type ImportContactsInput = {
   website_id: string;
   contacts: string;
+  labels?: string[];
+}
+
+export type Label = {
+  id: string;
+  created_at: string;
+  updated_at: string;
+  name: string;
+  description: string;
+}
+
+export type CreateLabelInput = {
+  website_id: string;
+  name: string;
+  description: string;
+}
+
+export type UpdateLabelInput = {
+  id: string;
+  name: string;
+  description: string;
+}
+
+export type DeleteLabelInput = {
+  id: string;
+}
+
+export type ListLabelsInput = {
+  website_id: string;
 }
 
 export type ExportContactsInput = {
@@ -151,8 +373,10 @@ export enum VideoStatus {
 export interface Page extends PageMetadata {
   description: string;
   body_markdown: string;
+  translation_key: string | null;
 
   tags: Tag[];
+  authors: Author[];
 }
 
 export interface PageMetadata {
@@ -170,8 +394,38 @@ export interface PageMetadata {
   language: string;
   send_as_newsletter: boolean;
   newsletter_sent_at: string | null;
+  paid_only: boolean;
 };
 
+export interface PageRevisionMetadata {
+  id: string;
+  created_at: string;
+  title: string;
+  path: string;
+  size: number;
+  body_hash: string;
+  metadata_hash: string;
+  user_id: string | null;
+}
+
+export interface PageRevision extends PageRevisionMetadata {
+  date: string;
+  description: string;
+  language: string;
+  tags: string[];
+  authors: string[];
+  body_markdown: string;
+  page_id: string;
+}
+
+export type PageRevisionsDiff = {
+  from: PageRevisionMetadata;
+  to: PageRevisionMetadata;
+  diff: string;
+  body_changed: boolean;
+  metadata_changed: boolean;
+}
+
 export type Asset = {
   id: string;
   created_at: string;
@@ -183,6 +437,8 @@ export type Asset = {
   media_type: string;
   size: number;
   hash: string;
+  width: number | null;
+  height: number | null;
 }
 
 
@@ -194,6 +450,22 @@ export type Tag = {
   description: string;
 };
 
+export type Author = {
+  id: string;
+  created_at: string;
+  updated_at: string;
+  slug: string;
+  name: string;
+  bio: string;
+  social_links: AuthorSocialLink[];
+  avatar_id: string | null;
+};
+
+export type AuthorSocialLink = {
+  name: string;
+  url: string;
+};
+
 export type Snippet = {
   id: string;
   created_at: string;
@@ -221,6 +493,32 @@ export type DeleteTagInput = {
   id: string;
 }
 
+export type CreateAuthorInput = {
+  website_id: string;
+  slug: string;
+  name: string;
+  bio: string;
+  avatar_id: string | null;
+  social_links: AuthorSocialLink[];
+}
+
+export type UpdateAuthorInput = {
+  id: string;
+  slug: string;
+  name: string;
+  bio: string;
+  avatar_id: string | null;
+  social_links: AuthorSocialLink[];
+}
+
+export type DeleteAuthorInput = {
+  id: string;
+}
+
+export type ListAuthorsInput = {
+  website_id: string;
+}
+
 export type CreateSnippetInput = {
   website_id: string;
   name: string;
@@ -246,11 +544,15 @@ export type CreatePageInput = {
   title: string;
   path: string;
   tags: string[];
+  // slugs of the authors
+  authors: string[];
   description: string;
   language: string;
   draft: boolean;
   body_markdown: string;
   send_as_newsletter: boolean;
+  paid_only: boolean;
+  translation_key?: string;
 }
 
 export type UpdatePageInput = {
@@ -259,11 +561,15 @@ export type UpdatePageInput = {
   title: string;
   path: string;
   tags: string[];
+  // slugs of the authors
+  authors: string[];
   description?: string;
   language: string;
   draft: boolean;
   body_markdown?: string;
   send_as_newsletter: boolean;
+  paid_only: boolean;
+  translation_key?: string;
 }
 
 export type DeletePageInput = {
@@ -278,6 +584,25 @@ export type FetchPageInput = {
   id: string;
 }
 
+export type ListPageRevisionsInput = {
+  page_id: string;
+  limit?: number;
+  after?: string;
+}
+
+export type GetPageRevisionInput = {
+  id: string;
+}
+
+export type DiffPageRevisionsInput = {
+  from: string;
+  to: string;
+}
+
+export type RestorePageRevisionInput = {
+  id: string;
+}
+
 export type GetTagsInput = {
   website_id: string;
 }
@@ -332,6 +657,8 @@ export interface NewsletterMetadata {
 
 export interface Newsletter extends NewsletterMetadata {
   body_markdown: string;
+  included_labels: string[];
+  excluded_labels: string[];
 }
 
 export type EmailConfiguration = {
@@ -339,6 +666,8 @@ export type EmailConfiguration = {
   from_address: string;
   domain_verified: string;
   dns_records: EmailDnsRecord[];
+  track_opens: boolean;
+  track_clicks: boolean;
 }
 
 export type EmailDnsRecord = {
@@ -360,6 +689,8 @@ export type CreateNewsletterInput = {
   subject: string;
   scheduled_for?: string;
   body_markdown: string;
+  included_labels?: string[];
+  excluded_labels?: string[];
 }
 
 export type UpdateNewsletterInput = {
@@ -367,6 +698,8 @@ export type UpdateNewsletterInput = {
   subject: string;
   scheduled_for?: string;
   body_markdown?: string;
+  included_labels?: string[];
+  excluded_labels?: string[];
 }
 
 export type DeleteNewsletterInput = {
@@ -377,6 +710,8 @@ export type UpdateEmailConfigurationInput = {
   website_id: string;
   from_name: string;
   from_address: string;
+  track_opens?: boolean;
+  track_clicks?: boolean;
 }
 
 export type GetEmailConfigurationInput = {
@@ -454,7 +789,11 @@ export type DeleteBackgroundJobInput = {
 ////////////////////////////////////////////////////////////////////////////////////////////////////
 
 export enum StaffRole {
+  Owner = "owner",
   Administrator = "administrator",
+  Editor = "editor",
+  Author = "author",
+  Viewer = "viewer",
 };
 
 
@@ -490,10 +829,11 @@ export type StaffInvitation = {
   id: string;
   created_at: string;
   updated_at: string;
-  role: string;
+  role: StaffRole;
   invitee_email: string;
   organization_id: string;
   inviter_id: string;
+  website_id: string | null;
 }
 
 export type UserInvitation = {
@@ -548,6 +888,7 @@ export type Staff = {
   email: string;
   user_id: string;
   organization_id: string;
+  website_id: string | null;
 }
 
 export type DeleteOrganizationInput = {
@@ -564,11 +905,15 @@ export type DeleteApiKeyInput = {
 export type CreateApiKeyInput = {
   organization_id: string;
   name: string;
+  scopes: ApiKeyScope[];
+  websites: string[];
 }
 
 export type InviteStaffsInput = {
   organization_id: string;
   emails: string[];
+  role?: StaffRole;
+  website_id?: string;
 }
 
 export type AcceptStaffInvitationInput = {
@@ -630,6 +975,48 @@ export type AddStaffs = {
   user_ids: string[],
 }
 
+export type AuditLogEntry = {
+  id: string;
+  created_at: string;
+  action: string;
+  entity_type: string;
+  entity_id: string;
+  changes: Record<string, AuditLogChange>;
+  actor_user_id: string | null;
+  actor_api_key_id: string | null;
+  actor_ip: string;
+  website_id: string | null;
+  organization_id: string;
+}
+
+export type AuditLogChange = {
+  before?: any;
+  after?: any;
+}
+
+export type AuditLogsFilter = {
+  organization_id: string;
+  website_id?: string;
+  actor_user_id?: string;
+  actor_api_key_id?: string;
+  action?: string;
+  entity_type?: string;
+  entity_id?: string;
+  from?: Date;
+  to?: Date;
+}
+
+export type ListAuditLogsInput = AuditLogsFilter & {
+  limit?: number;
+  after?: string;
+}
+
+export type ExportAuditLogsInput = AuditLogsFilter;
+
+export type ExportAuditLogsOutput = {
+  audit_logs: string;
+}
+
 ////////////////////////////////////////////////////////////////////////////////////////////////////
 // Products
 ////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -639,6 +1026,8 @@ export enum ProductType {
   Book = "book",
   Course = "course",
   Download = "download",
+  Subscription = "subscription",
+  Bundle = "bundle",
 };
 
 export enum ProductStatus {
@@ -669,10 +1058,14 @@ export type Product = {
   description: string;
   type: ProductType;
   status: ProductStatus;
+  // for subscriptions, price is the monthly price
   price: number;
+  yearly_price: number;
 
   content: ProductPage[] | null;
   assets: Asset[] | null;
+  // only for bundles
+  bundled_products: string[] | null;
 }
 
 export type Order = {
@@ -692,6 +1085,7 @@ export type Order = {
 
   line_items?: OrderLineItem[];
   contact_id: string;
+  coupon_id: string | null;
   refunds: Refund[] | null;
 }
 
@@ -769,6 +1163,8 @@ export type CreateProductInput = {
   description: string;
   type: ProductType;
   price: number;
+  yearly_price?: number;
+  bundled_products?: string[];
 }
 
 export type GetProductInput = {
@@ -796,6 +1192,8 @@ export type UpdateProductInput = {
   description?: string;
   status?: ProductStatus;
   price?: number;
+  yearly_price?: number;
+  bundled_products?: string[];
 }
 
 export type CreateCouponInput = {
@@ -890,6 +1288,7 @@ export type Website = {
   footer: string;
   navigation: WebsiteNavigation;
   language: string;
+  languages: WebsiteLanguage[];
   used_storage: number;
   primary_domain: string;
   description: string;
@@ -925,6 +1324,9 @@ export type Redirect = {
   path_pattern: string;
   to: string;
   status: number;
+  query_pattern: string;
+  preserve_query: boolean;
+  position: number;
 }
 
 export type WebsiteNavigation = {
@@ -937,14 +1339,38 @@ export type SiteNavigationItem = {
   label: string;
 };
 
+export type WebsiteLanguage = {
+  code: string;
+  path_prefix: string;
+  navigation: WebsiteNavigation | null;
+};
+
 export type ApiKey = {
   id: string;
   created_at: string;
   updated_at: string;
+  expires_at: string | null;
   name: string;
+  scopes: ApiKeyScope[];
+  websites: string[];
+  last_used_at: string | null;
+  last_used_ip: string | null;
   token?: string;
 };
 
+export enum ApiKeyScope {
+  WebsitesRead = "websites:read",
+  WebsitesWrite = "websites:write",
+  ContentRead = "content:read",
+  ContentWrite = "content:write",
+  AssetsRead = "assets:read",
+  AssetsWrite = "assets:write",
+  StoreRead = "store:read",
+  StoreWrite = "store:write",
+  ContactsRead = "contacts:read",
+  ContactsWrite = "contacts:write",
+};
+
 export type Domain = {
   id: string;
   created_at: string;
@@ -974,10 +1400,38 @@ export type SaveRedirectsInput = {
   redirects: RedirectInput[],
 }
 
+export type RedirectsFormat = 'netlify' | 'cloudflare_csv' | 'csv';
+
+export type ImportRedirectsInput = {
+  website_id: string;
+  format: RedirectsFormat;
+  data: string;
+  replace: boolean;
+  dry_run: boolean;
+}
+
+export type ImportRedirectsOutput = {
+  redirects: Redirect[];
+  report: RedirectsReport;
+  saved: boolean;
+}
+
+export type RedirectsReport = {
+  errors: RedirectsReportEntry[];
+  warnings: RedirectsReportEntry[];
+}
+
+export type RedirectsReportEntry = {
+  line: number;
+  pattern: string;
+  message: string;
+}
+
 export type RedirectInput = {
   pattern: string;
   to: string;
-  // status: number;
+  status?: number;
+  preserve_query?: boolean;
 }
 
 
@@ -996,6 +1450,8 @@ export type UpdateWebsiteInput = {
   description?: string;
   slug?: string;
   navigation?: WebsiteNavigation;
+  language?: string;
+  languages?: WebsiteLanguage[];
   robots_txt?: string;
   blocked?: boolean;
   currency?: string;
@@ -1035,3 +1491,86 @@ export type UpdateWebsiteIconInput = {
   file: File,
   website_id: string;
 }
+
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+// Webhooks
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+export enum WebhookEventType {
+  PagePublished = 'page.published',
+  SubscriberAdded = 'subscriber.added',
+  SubscriberRemoved = 'subscriber.removed',
+  OrderCompleted = 'order.completed',
+  RefundSucceeded = 'refund.succeeded',
+}
+
+export type WebhookEndpoint = {
+  id: string;
+  created_at: string;
+  updated_at: string;
+  url: string;
+  description: string;
+  event_types: WebhookEventType[];
+  active: boolean;
+  secret: string;
+  website_id: string;
+}
+
+export enum WebhookDeliveryStatus {
+  Pending = 'pending',
+  Succeeded = 'succeeded',
+  Failed = 'failed',
+}
+
+export type WebhookDelivery = {
+  id: string;
+  created_at: string;
+  updated_at: string;
+  event_type: WebhookEventType;
+  payload: any;
+  status: WebhookDeliveryStatus;
+  attempts: number;
+  last_attempt_at: string | null;
+  next_attempt_at: string | null;
+  response_status: number | null;
+  response_body: string;
+  error: string;
+  duration: number;
+  endpoint_id: string;
+  website_id: string;
+}
+
+export type CreateWebhookEndpointInput = {
+  website_id: string;
+  url: string;
+  description: string;
+  event_types: WebhookEventType[];
+}
+
+export type UpdateWebhookEndpointInput = {
+  id: string;
+  url?: string;
+  description?: string;
+  event_types?: WebhookEventType[];
+  active?: boolean;
+}
+
+export type DeleteWebhookEndpointInput = {
+  id: string;
+}
+
+export type ListWebhookEndpointsInput = {
+  website_id: string;
+}
+
+export type ListWebhookDeliveriesInput = {
+  website_id: string;
+  endpoint_id?: string;
+  limit?: number;
+  after?: string;
+}
+
+export type RedeliverWebhookInput = {
+  delivery_id: string;
+}
@@ -31,6 +31,8 @@ export const Routes = {
   organizationStripeCustomerPortal: '/organizations/stripe_customer_portal',
   organizationSyncStripe: '/organizations/sync_stripe',
   organizationBillingUsage: '/organizations/billing_usage',
+  organizationAuditLogs: '/organizations/audit_logs',
+  organizationExportAuditLogs: '/organizations/export_audit_logs',
 
   // staffs
   staffs: '/staffs',
@@ -58,15 +60,30 @@ export const Routes = {
   website: '/website',
   updateWebsite: '/update_website',
   saveRedirects: '/save_redirects',
+  importRedirects: '/import_redirects',
   allWebsites: '/all_websites',
   websiteUpdateIcon: '/websites/update_icon',
 
+  // webhooks
+  createWebhookEndpoint: '/create_webhook_endpoint',
+  updateWebhookEndpoint: '/update_webhook_endpoint',
+  deleteWebhookEndpoint: '/delete_webhook_endpoint',
+  webhookEndpoints: '/webhook_endpoints',
+  webhookDeliveries: '/webhook_deliveries',
+  redeliverWebhook: '/redeliver_webhook',
+
   // tags
   createTag: '/create_tag',
   updateTag: '/update_tag',
   deleteTag: '/delete_tag',
   tags: '/tags',
 
+  // authors
+  createAuthor: '/create_author',
+  updateAuthor: '/update_author',
+  deleteAuthor: '/delete_author',
+  authors: '/authors',
+
   // snippets
   createSnippet: '/create_snippet',
   updateSnippet: '/update_snippet',
@@ -81,6 +98,12 @@ export const Routes = {
   pages: '/pages',
   posts: '/posts',
 
+  // page revisions
+  pageRevisions: '/page_revisions',
+  pageRevision: '/page_revision',
+  diffPageRevisions: '/diff_page_revisions',
+  restorePageRevision: '/restore_page_revision',
+
   // assets
   uploadAsset: '/upload_asset',
   deleteAsset: '/delete_asset',
@@ -109,6 +132,12 @@ export const Routes = {
   blockContact: '/block_contact',
   unblockContact: '/unblock_contact',
 
+  // labels
+  createLabel: '/create_label',
+  updateLabel: '/update_label',
+  deleteLabel: '/delete_label',
+  labels: '/labels',
+
   // labels
   createLabel: '/create_label',
   deleteLabel: '/delete_label',
@@ -169,4 +198,14 @@ export const Routes = {
   // Analytics
   //////////////////////////////////////////////////////////////////////////////////////////////////
   analyticsData: '/analytics_data',
+  createGoal: '/create_goal',
+  updateGoal: '/update_goal',
+  deleteGoal: '/delete_goal',
+  goals: '/goals',
+  createAnalyticsExport: '/create_analytics_export',
+  analyticsExports: '/analytics_exports',
+  downloadAnalyticsExport: '/download_analytics_export',
+  analyticsReportSettings: '/analytics_report_settings',
+  updateAnalyticsReportSettings: '/update_analytics_report_settings',
+  liveAnalytics: '/live_analytics',
 }
@@ -24,10 +24,12 @@ import OrganizationSettings from '@/ui/pages/organizations/organization/settings
 import Websites from '@/ui/pages/organizations/organization/websites.vue';
 import NewWebsite from '@/ui/pages//organizations/organization/new_website.vue';
 import OrganizationApi from '@/ui/pages/organizations/organization/api.vue';
+import OrganizationAuditLogs from '@/ui/pages/organizations/organization/audit_logs.vue';
 import OrganizationInvitations from '@/ui/pages/organizations/invitations.vue';
 
 // Websites
 import Website from '@/ui/pages/websites/website/website.vue';
+import WebsiteLive from '@/ui/pages/websites/website/live.vue';
 import WebsitePosts from '@/ui/pages/websites/website/posts/posts.vue';
 import WebsitePost from '@/ui/pages/websites/website/posts/post.vue';
 import WebsiteNewPost from '@/ui/pages/websites/website/posts/new.vue';
@@ -35,7 +37,11 @@ import WebsitePages from '@/ui/pages/websites/website/pages/pages.vue';
 import WebsitePage from '@/ui/pages/websites/website/pages/page.vue';
 import WebsiteNewPage from '@/ui/pages/websites/website/pages/new.vue';
 import WebsiteSnippets from '@/ui/pages/websites/website/settings/snippets.vue';
+import WebsiteWebhooks from '@/ui/pages/websites/website/settings/webhooks.vue';
+import WebsiteGoals from '@/ui/pages/websites/website/settings/goals.vue';
+import WebsiteSettingsAnalytics from '@/ui/pages/websites/website/settings/analytics.vue';
 import WebsiteTags from '@/ui/pages/websites/website/settings/tags.vue';
+import WebsiteAuthors from '@/ui/pages/websites/website/settings/authors.vue';
 import WebsiteAssets from '@/ui/pages/websites/website/assets.vue';
 import WebsiteRedirects from '@/ui/pages/websites/website/settings/redirects.vue';
 import WebsiteNavigation from '@/ui/pages/websites/website/settings/navigation.vue';
@@ -44,6 +50,7 @@ import WebsiteNavigation from '@/ui/pages/websites/website/settings/navigation.v
 import WebsiteContacts from '@/ui/pages/websites/website/contacts/contacts.vue';
 import WebsiteNewContact from '@/ui/pages/websites/website/contacts/new.vue';
 import WebsiteContact from '@/ui/pages/websites/website/contacts/contact.vue';
+import WebsiteContactsLabels from '@/ui/pages/websites/website/contacts/labels.vue';
 
 // Emails
 import WebsiteNewsletters from '@/ui/pages/websites/website/newsletters/newsletters.vue';
@@ -115,9 +122,11 @@ export function newRouter(config: Config): Router {
       { path: '/organizations/:organization_id/billing/checkout/complete', component: OrganizationBillingCheckoutComplete },
       { path: '/organizations/:organization_id/settings', component: OrganizationSettings },
       { path: '/organizations/:organization_id/api', component: OrganizationApi },
+      { path: '/organizations/:organization_id/audit_logs', component: OrganizationAuditLogs },
 
       // websites
       { path: '/websites/:website_id', component: Website, name: 'website_home' },
+      { path: '/websites/:website_id/live', component: WebsiteLive },
       { path: '/websites/:website_id/posts', component: WebsitePosts },
       { path: '/websites/:website_id/posts/:page_id', component: WebsitePost },
       { path: '/websites/:website_id/posts/new', component: WebsiteNewPost },
@@ -126,13 +135,17 @@ export function newRouter(config: Config): Router {
       { path: '/websites/:website_id/pages/:page_id', component: WebsitePage },
       { path: '/websites/:website_id/snippets', component: WebsiteSnippets },
       { path: '/websites/:website_id/tags', component: WebsiteTags },
+      { path: '/websites/:website_id/authors', component: WebsiteAuthors },
       { path: '/websites/:website_id/assets', component: WebsiteAssets },
       { path: '/websites/:website_id/redirects', component: WebsiteRedirects },
       { path: '/websites/:website_id/navigation', component: WebsiteNavigation },
+      { path: '/websites/:website_id/webhooks', component: WebsiteWebhooks },
+      { path: '/websites/:website_id/goals', component: WebsiteGoals },
 
       // Contacts
       { path: '/websites/:website_id/contacts', component: WebsiteContacts },
       { path: '/websites/:website_id/contacts/new', component: WebsiteNewContact },
+      { path: '/websites/:website_id/contacts/labels', component: WebsiteContactsLabels },
       { path: '/websites/:website_id/contacts/:contact_id', component: WebsiteContact },
 
       // Email
@@ -157,6 +170,7 @@ export function newRouter(config: Config): Router {
       { path: '/websites/:website_id/settings/domains', component: WebsiteSettingsDomains },
       { path: '/websites/:website_id/settings/emails', component: WebsiteSettingsEmails },
       { path: '/websites/:website_id/settings/design', component: WebsiteSettingsDesign },
+      { path: '/websites/:website_id/settings/analytics', component: WebsiteSettingsAnalytics },
 
       // Admin
       { path: '/admin', component: Admin },